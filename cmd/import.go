@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/openapi"
+	"github.com/spf13/cobra"
+)
+
+// newImportCommand returns the command that generates tools files from API
+// descriptions.
+func newImportCommand() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Generate a tools file from an API description.",
+	}
+
+	var opts openapi.Options
+	var output string
+	openapiCmd := &cobra.Command{
+		Use:   "openapi SPEC",
+		Short: "Generate an http tool for each operation of an OpenAPI 3 document, from a file path or URL.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			spec, err := readSpec(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read %q: %w", args[0], err)
+			}
+			toolsFile, warnings, err := openapi.Convert(spec, opts)
+			for _, w := range warnings {
+				fmt.Fprintf(c.ErrOrStderr(), "warning: %s\n", w)
+			}
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				_, err = c.OutOrStdout().Write(toolsFile)
+				return err
+			}
+			return os.WriteFile(output, toolsFile, 0o644)
+		},
+	}
+	flags := openapiCmd.Flags()
+	flags.StringVar(&opts.SourceName, "source-name", "", "Name of the generated http source. Defaults to a name derived from the document's title.")
+	flags.StringVar(&opts.BaseURL, "base-url", "", "Base URL of the API. Defaults to the first server URL of the document.")
+	flags.StringVarP(&output, "output", "o", "", "File path to write the tools file to. Defaults to stdout.")
+
+	importCmd.AddCommand(openapiCmd)
+	return importCmd
+}
+
+// readSpec reads a document from a file path, or an http or https URL.
+func readSpec(location string) ([]byte, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		return os.ReadFile(location)
+	}
+	resp, err := http.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
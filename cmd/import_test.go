@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestImportOpenAPI(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	output := filepath.Join(t.TempDir(), "tools.yaml")
+
+	c := NewCommand()
+	stderr := new(bytes.Buffer)
+	c.SetErr(stderr)
+	c.SetArgs([]string{"import", "openapi", "../internal/openapi/testdata/petstore.yaml", "--source-name", "petstore", "-o", output})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(stderr.String(), "warning: skipped PUT /pets/{petId}/photo") {
+		t.Fatalf("expected warnings, got %q", stderr.String())
+	}
+
+	// the generated tools file can be loaded
+	raw, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("unable to read output: %s", err)
+	}
+	toolsFile, err := parseToolsFile(ctx, raw)
+	if err != nil {
+		t.Fatalf("unable to parse generated tools file: %s", err)
+	}
+	if _, ok := toolsFile.Sources["petstore"]; !ok {
+		t.Fatalf("expected a source named petstore")
+	}
+	want := []string{"listPets", "createPet", "showPetById", "delete_pets_petId"}
+	if diff := cmp.Diff(want, toolsFile.Toolsets["petstore"].ToolNames); diff != "" {
+		t.Fatalf("incorrect toolset: diff %v", diff)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
@@ -43,56 +44,142 @@ import (
 
 	// Import tool packages for side effect of registration
 	_ "github.com/googleapis/genai-toolbox/internal/tools/alloydbainl"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/athena/athenasql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerydryrun"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexecutesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexplain"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexport"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerygetdatasetinfo"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerygettableinfo"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerylistdatasetids"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerylisttableids"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryload"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerysql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/bigtable"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigtable/bigtablereadrows"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/cassandra/cassandracql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/cloudlogging/cloudloggingquery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/cloudmonitoring/cloudmonitoringquery"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/couchbase"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/databricks/databricksexecutesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/databricks/databrickssql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/dgraph"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/duckdb/duckdbsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/dynamodb/dynamodbgetitem"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/dynamodb/dynamodbquery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/dynamodb/dynamodbscan"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/elasticsearch/elasticsearchgetdocument"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/elasticsearch/elasticsearchlistindices"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/elasticsearch/elasticsearchsearch"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestoredeletedocuments"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestoregetdocuments"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestoregetrules"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestorelistcollections"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestorequerycollection"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/firestore/firestorevalidaterules"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/gcs/gcslistobjects"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/gcs/gcsreadobject"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/gcs/gcswriteobject"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/graphql/graphqlexecute"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/grpc/grpcinvoke"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/http"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/httpbatch"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/httppoll"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/influxdb/fluxquery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/influxdb/influxqlquery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/introspection/describetable"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/introspection/listcolumns"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/introspection/listtables"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/introspection/samplerows"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mongodb/mongodbaggregate"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mongodb/mongodbfind"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mongodb/mongodbinsert"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqlcallprocedure"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqlexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqlsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcallprocedure"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlexecutesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlexplain"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlsql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/neo4j"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/pinecone/pineconequery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/pinecone/pineconeupsert"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescallprocedure"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescreatevectorindex"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresexecutesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresexplain"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresvectorsearch"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/prometheus/promqlquery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/prometheus/promqlqueryrange"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/pubsub/pubsubpublish"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/pubsub/pubsubpull"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/qdrant/qdrantquery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/qdrant/qdrantupsert"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/redis"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/redis/rediscommand"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/redis/redisget"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/redis/redishgetall"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/redis/redisscan"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/redis/redisset"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/s3/s3getobject"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/s3/s3listobjects"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/s3/s3presignurl"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/s3/s3putobject"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner/spannerexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner/spannersql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/sqliteexecutesql"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/sqltransaction"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/transaction/begintransaction"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/transaction/committransaction"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/transaction/rollbacktransaction"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/utility/wait"
 	_ "github.com/googleapis/genai-toolbox/internal/tools/valkey"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/weaviate/weaviatequery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/weaviate/weaviateupsert"
 
 	"github.com/spf13/cobra"
 
 	_ "github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/athena"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/azuresynapse"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/cassandra"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudlogging"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudmonitoring"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/couchbase"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/databricks"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/dgraph"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/duckdb"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/dynamodb"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/elasticsearch"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/embeddings"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/firestore"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/gcs"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/graphql"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/grpc"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/http"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/influxdb"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/mongodb"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/neo4j"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/pinecone"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/prometheus"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/pubsub"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/qdrant"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/redis"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/s3"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	_ "github.com/googleapis/genai-toolbox/internal/sources/valkey"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/weaviate"
 )
 
 var (
@@ -178,6 +265,11 @@ func NewCommand(opts ...Option) *Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&cmd.cfg.Address, "address", "a", "127.0.0.1", "Address of the interface the server will listen on.")
 	flags.IntVarP(&cmd.cfg.Port, "port", "p", 5000, "Port the server will listen on.")
+	flags.StringVar(&cmd.cfg.Listen, "listen", "", "Listen on a Unix domain socket ('unix:/path/to/toolbox.sock') or on the socket passed by systemd socket activation ('systemd') instead of --address and --port.")
+	flags.StringVar(&cmd.cfg.TLS.CertFile, "tls-cert-file", "", "File path of the PEM encoded certificate chain the server serves TLS with. Requires --tls-key-file.")
+	flags.StringVar(&cmd.cfg.TLS.KeyFile, "tls-key-file", "", "File path of the PEM encoded private key of the TLS certificate.")
+	flags.StringVar(&cmd.cfg.TLS.ClientCAFile, "tls-client-ca-file", "", "File path of a PEM encoded bundle of CAs. Clients must present a certificate signed by one of them (mutual TLS).")
+	flags.StringSliceVar(&cmd.cfg.TLS.AllowedClientSANs, "tls-allowed-client-sans", []string{}, "Comma-separated subject alternative names (DNS names, URIs such as SPIFFE IDs, emails, or IPs) of the client certificates that are allowed. Requires --tls-client-ca-file.")
 
 	flags.StringVar(&cmd.tools_file, "tools_file", "", "File path specifying the tool configuration. Cannot be used with --prebuilt.")
 	// deprecate tools_file
@@ -189,14 +281,38 @@ func NewCommand(opts ...Option) *Command {
 	flags.Var(&cmd.cfg.LoggingFormat, "logging-format", "Specify logging format to use. Allowed: 'standard' or 'JSON'.")
 	flags.BoolVar(&cmd.cfg.TelemetryGCP, "telemetry-gcp", false, "Enable exporting directly to Google Cloud Monitoring.")
 	flags.StringVar(&cmd.cfg.TelemetryOTLP, "telemetry-otlp", "", "Enable exporting using OpenTelemetry Protocol (OTLP) to the specified endpoint (e.g. 'http://127.0.0.1:4318')")
+	flags.BoolVar(&cmd.cfg.TelemetryPrometheus, "telemetry-prometheus", false, "Enable serving metrics in the Prometheus format on the /metrics endpoint.")
 	flags.StringVar(&cmd.cfg.TelemetryServiceName, "telemetry-service-name", "toolbox", "Sets the value of the service.name resource attribute for telemetry data.")
 	flags.StringVar(&cmd.prebuiltConfig, "prebuilt", "", "Use a prebuilt tool configuration by source type. Cannot be used with --tools-file. Allowed: 'alloydb-postgres', 'bigquery', 'cloud-sql-mysql', 'cloud-sql-postgres', 'cloud-sql-mssql', 'postgres', 'spanner', 'spanner-postgres'.")
 	flags.BoolVar(&cmd.cfg.Stdio, "stdio", false, "Listens via MCP STDIO instead of acting as a remote HTTP server.")
+	flags.StringSliceVar(&cmd.cfg.StdioToolsets, "toolset", []string{}, "Comma-separated toolsets whose tools are served via MCP STDIO. Requires --stdio. Defaults to every tool.")
 	flags.BoolVar(&cmd.cfg.DisableReload, "disable-reload", false, "Disables dynamic reloading of tools file.")
+	flags.IntVar(&cmd.cfg.ResponseLimits.MaxResponseBytes, "max-response-bytes", 0, "Default maximum size in bytes of a tool result. 0 means unlimited.")
+	flags.IntVar(&cmd.cfg.ResponseLimits.MaxRows, "max-rows", 0, "Default maximum number of rows in a tool result. 0 means unlimited.")
+	flags.Var(&cmd.cfg.ResponseLimits.Truncation, "truncation", "How tool results over the limits are handled. Allowed: 'truncate', 'error', or 'paginate'.")
+	flags.Var(&cmd.cfg.ResultFormat, "result-format", "Default format of the rows of tool results, unless the tool sets its own resultFormat. Allowed: 'json', 'csv', or 'markdown'.")
+	flags.IntVar(&cmd.cfg.Cache.MaxEntries, "cache-max-entries", 1000, "Maximum number of tool results cached in memory.")
+	flags.StringVar(&cmd.cfg.Cache.RedisURL, "cache-redis-url", "", "Cache tool results in Redis instead of in memory (e.g. 'redis://127.0.0.1:6379/0').")
+	flags.IntVar(&cmd.cfg.McpResourceThreshold, "mcp-resource-threshold", 0, "Size in bytes above which MCP tool results are returned as resources instead of inline, for clients of MCP version 2025-06-18. 0 means always inline.")
+	flags.StringVar(&cmd.cfg.Audit.Destination, "audit-log", "", "Records every tool invocation in an audit log at the given file path, Cloud Storage prefix ('gs://bucket/prefix'), BigQuery table ('bigquery://project/dataset/table'), or webhook URL.")
+	flags.StringSliceVar(&cmd.cfg.Audit.RedactParams, "audit-redact-params", []string{}, "Comma-separated names of parameters whose values are redacted from the audit log.")
+	flags.StringSliceVar(&cmd.cfg.SensitiveParams, "sensitive-params", []string{}, "Comma-separated names, or glob patterns such as '*password*', of parameters whose values are redacted from logs, traces, errors, and the audit log in every tool.")
+	flags.StringSliceVar(&cmd.cfg.PIIDetectors, "pii-detectors", []string{}, "Comma-separated built-in detectors of personal information to mask in tool results, unless a tool sets its own piiMasking. Allowed: 'email', 'ssn', 'creditCard'.")
+	flags.DurationVar(&cmd.cfg.Approvals.Timeout, "approval-timeout", time.Hour, "How long an invocation of a tool that requires approval waits to be approved before it expires.")
+	flags.StringVar(&cmd.cfg.Approvals.WebhookURL, "approval-webhook", "", "URL that is sent each invocation waiting for approval as JSON.")
+	flags.StringVar(&cmd.cfg.Approvals.SlackWebhookURL, "approval-slack-webhook", "", "Slack incoming webhook URL that is sent a message for each invocation waiting for approval.")
+	flags.StringVar(&cmd.cfg.Approvals.AuthService, "approval-auth-service", "", "Auth service that reviewers must be authenticated with to use the approvals API. Required by tools with requiresApproval.")
+	flags.StringSliceVar(&cmd.cfg.Approvals.Approvers, "approvers", []string{}, "Comma-separated emails of the reviewers allowed to approve invocations. Requires --approval-auth-service.")
+	flags.DurationVar(&cmd.cfg.ToolTimeout, "tool-timeout", 0, "Default time a tool invocation may take before it is cancelled, unless the tool sets a timeout. 0 means unbounded.")
+	flags.BoolVar(&cmd.cfg.DryRun, "dry-run", false, "Makes every tool invocation a dry run, which previews its effects without making any changes. Tools that can't be dry run fail.")
+	flags.BoolVar(&cmd.cfg.AllowDegraded, "allow-degraded", false, "Starts the server even if some sources fail to initialize. Their tools are unavailable until the sources can be initialized.")
 
 	// wrap RunE command so that we have access to original Command object
 	cmd.RunE = func(*cobra.Command, []string) error { return run(cmd) }
 
+	baseCmd.AddCommand(newImportCommand())
+	baseCmd.CompletionOptions.DisableDefaultCmd = true
+
 	return cmd
 }
 
@@ -206,6 +322,9 @@ type ToolsFile struct {
 	AuthServices server.AuthServiceConfigs `yaml:"authServices"`
 	Tools        server.ToolConfigs        `yaml:"tools"`
 	Toolsets     server.ToolsetConfigs     `yaml:"toolsets"`
+	Prompts      server.PromptConfigs      `yaml:"prompts"`
+	// Include lists additional tools files to load, relative to this file.
+	Include []string `yaml:"include"`
 }
 
 // parseEnv replaces environment variables ${ENV_NAME} with their values.
@@ -241,66 +360,106 @@ func parseToolsFile(ctx context.Context, raw []byte) (ToolsFile, error) {
 	return toolsFile, nil
 }
 
-// mergeToolsFiles merges multiple ToolsFile structs into one.
-// Detects and raises errors for resource conflicts in sources, authServices, tools, and toolsets.
-// All resource names (sources, authServices, tools, toolsets) must be unique across all files.
-func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
+// mergeToolsFiles merges multiple ToolsFile structs into one. filePaths holds
+// the path each file was loaded from, in the same order, and is used to report
+// where a conflicting resource was defined.
+// Detects and raises errors for resource conflicts in sources, authServices, tools, toolsets, and prompts.
+// All resource names (sources, authServices, tools, toolsets, prompts) must be unique across all files.
+func mergeToolsFiles(filePaths []string, files ...ToolsFile) (ToolsFile, error) {
 	merged := ToolsFile{
 		Sources:      make(server.SourceConfigs),
+		AuthSources:  make(server.AuthServiceConfigs),
 		AuthServices: make(server.AuthServiceConfigs),
 		Tools:        make(server.ToolConfigs),
 		Toolsets:     make(server.ToolsetConfigs),
+		Prompts:      make(server.PromptConfigs),
 	}
 
 	var conflicts []string
+	// origins records the file each resource was first defined in, keyed by "<type> '<name>'"
+	origins := make(map[string]string)
+	conflict := func(resource string, filePath string) {
+		conflicts = append(conflicts, fmt.Sprintf("%s (defined in %q and %q)", resource, origins[resource], filePath))
+	}
 
 	for fileIndex, file := range files {
+		filePath := fmt.Sprintf("file #%d", fileIndex+1)
+		if fileIndex < len(filePaths) {
+			filePath = filePaths[fileIndex]
+		}
+
 		// Check for conflicts and merge sources
 		for name, source := range file.Sources {
+			resource := fmt.Sprintf("source '%s'", name)
 			if _, exists := merged.Sources[name]; exists {
-				conflicts = append(conflicts, fmt.Sprintf("source '%s' (file #%d)", name, fileIndex+1))
+				conflict(resource, filePath)
 			} else {
 				merged.Sources[name] = source
+				origins[resource] = filePath
 			}
 		}
 
 		// Check for conflicts and merge authSources (deprecated, but still support)
 		for name, authSource := range file.AuthSources {
+			resource := fmt.Sprintf("authSource '%s'", name)
 			if _, exists := merged.AuthSources[name]; exists {
-				conflicts = append(conflicts, fmt.Sprintf("authSource '%s' (file #%d)", name, fileIndex+1))
+				conflict(resource, filePath)
 			} else {
 				merged.AuthSources[name] = authSource
+				origins[resource] = filePath
 			}
 		}
 
 		// Check for conflicts and merge authServices
 		for name, authService := range file.AuthServices {
+			resource := fmt.Sprintf("authService '%s'", name)
 			if _, exists := merged.AuthServices[name]; exists {
-				conflicts = append(conflicts, fmt.Sprintf("authService '%s' (file #%d)", name, fileIndex+1))
+				conflict(resource, filePath)
 			} else {
 				merged.AuthServices[name] = authService
+				origins[resource] = filePath
 			}
 		}
 
 		// Check for conflicts and merge tools
 		for name, tool := range file.Tools {
+			resource := fmt.Sprintf("tool '%s'", name)
 			if _, exists := merged.Tools[name]; exists {
-				conflicts = append(conflicts, fmt.Sprintf("tool '%s' (file #%d)", name, fileIndex+1))
+				conflict(resource, filePath)
 			} else {
 				merged.Tools[name] = tool
+				origins[resource] = filePath
 			}
 		}
 
 		// Check for conflicts and merge toolsets
 		for name, toolset := range file.Toolsets {
+			resource := fmt.Sprintf("toolset '%s'", name)
 			if _, exists := merged.Toolsets[name]; exists {
-				conflicts = append(conflicts, fmt.Sprintf("toolset '%s' (file #%d)", name, fileIndex+1))
+				conflict(resource, filePath)
 			} else {
 				merged.Toolsets[name] = toolset
+				origins[resource] = filePath
+			}
+		}
+
+		// Check for conflicts and merge prompts
+		for name, prompt := range file.Prompts {
+			resource := fmt.Sprintf("prompt '%s'", name)
+			if _, exists := merged.Prompts[name]; exists {
+				conflict(resource, filePath)
+			} else {
+				merged.Prompts[name] = prompt
+				origins[resource] = filePath
 			}
 		}
 	}
 
+	// authSources is deprecated, so only keep it when one of the files used it
+	if len(merged.AuthSources) == 0 {
+		merged.AuthSources = nil
+	}
+
 	// If conflicts were detected, return an error
 	if len(conflicts) > 0 {
 		return ToolsFile{}, fmt.Errorf("resource conflicts detected:\n  - %s\n\nPlease ensure each source, authService, tool, and toolset has a unique name across all files", strings.Join(conflicts, "\n  - "))
@@ -309,62 +468,102 @@ func mergeToolsFiles(files ...ToolsFile) (ToolsFile, error) {
 	return merged, nil
 }
 
-// loadAndMergeToolsFiles loads multiple YAML files and merges them
-func loadAndMergeToolsFiles(ctx context.Context, filePaths []string) (ToolsFile, error) {
+// loadToolsFiles loads the provided YAML files along with any files they
+// include. Included paths are resolved relative to the including file, and each
+// file is only loaded once so that include cycles terminate.
+func loadToolsFiles(ctx context.Context, filePaths []string) ([]string, []ToolsFile, error) {
+	var loadedPaths []string
 	var toolsFiles []ToolsFile
+	loaded := make(map[string]bool)
 
-	for _, filePath := range filePaths {
-		buf, err := os.ReadFile(filePath)
+	var load func(filePath string) error
+	load = func(filePath string) error {
+		cleanPath := filepath.Clean(filePath)
+		if loaded[cleanPath] {
+			return nil
+		}
+		loaded[cleanPath] = true
+
+		buf, err := os.ReadFile(cleanPath)
 		if err != nil {
-			return ToolsFile{}, fmt.Errorf("unable to read tool file at %q: %w", filePath, err)
+			return fmt.Errorf("unable to read tool file at %q: %w", filePath, err)
 		}
 
 		toolsFile, err := parseToolsFile(ctx, buf)
 		if err != nil {
-			return ToolsFile{}, fmt.Errorf("unable to parse tool file at %q: %w", filePath, err)
+			return fmt.Errorf("unable to parse tool file at %q: %w", filePath, err)
 		}
 
+		loadedPaths = append(loadedPaths, cleanPath)
 		toolsFiles = append(toolsFiles, toolsFile)
+
+		for _, include := range toolsFile.Include {
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(cleanPath), include)
+			}
+			if err := load(include); err != nil {
+				return fmt.Errorf("unable to include %q from %q: %w", include, filePath, err)
+			}
+		}
+		return nil
 	}
 
-	mergedFile, err := mergeToolsFiles(toolsFiles...)
+	for _, filePath := range filePaths {
+		if err := load(filePath); err != nil {
+			return nil, nil, err
+		}
+	}
+	return loadedPaths, toolsFiles, nil
+}
+
+// loadAndMergeToolsFiles loads multiple YAML files, along with any files they
+// include, and merges them. It also returns the paths of every loaded file,
+// including the included ones.
+func loadAndMergeToolsFiles(ctx context.Context, filePaths []string) (ToolsFile, []string, error) {
+	loadedPaths, toolsFiles, err := loadToolsFiles(ctx, filePaths)
+	if err != nil {
+		return ToolsFile{}, nil, err
+	}
+
+	mergedFile, err := mergeToolsFiles(loadedPaths, toolsFiles...)
 	if err != nil {
-		return ToolsFile{}, fmt.Errorf("unable to merge tools files: %w", err)
+		return ToolsFile{}, nil, fmt.Errorf("unable to merge tools files: %w", err)
 	}
 
-	return mergedFile, nil
+	return mergedFile, loadedPaths, nil
 }
 
-// loadAndMergeToolsFolder loads all YAML files from a directory and merges them
-func loadAndMergeToolsFolder(ctx context.Context, folderPath string) (ToolsFile, error) {
+// loadAndMergeToolsFolder loads all YAML files from a directory and merges them.
+// It also returns the paths of every loaded file, including the included ones.
+func loadAndMergeToolsFolder(ctx context.Context, folderPath string) (ToolsFile, []string, error) {
 	// Check if directory exists
 	info, err := os.Stat(folderPath)
 	if err != nil {
-		return ToolsFile{}, fmt.Errorf("unable to access tools folder at %q: %w", folderPath, err)
+		return ToolsFile{}, nil, fmt.Errorf("unable to access tools folder at %q: %w", folderPath, err)
 	}
 	if !info.IsDir() {
-		return ToolsFile{}, fmt.Errorf("path %q is not a directory", folderPath)
+		return ToolsFile{}, nil, fmt.Errorf("path %q is not a directory", folderPath)
 	}
 
 	// Find all YAML files in the directory
 	pattern := filepath.Join(folderPath, "*.yaml")
 	yamlFiles, err := filepath.Glob(pattern)
 	if err != nil {
-		return ToolsFile{}, fmt.Errorf("error finding YAML files in %q: %w", folderPath, err)
+		return ToolsFile{}, nil, fmt.Errorf("error finding YAML files in %q: %w", folderPath, err)
 	}
 
 	// Also find .yml files
 	ymlPattern := filepath.Join(folderPath, "*.yml")
 	ymlFiles, err := filepath.Glob(ymlPattern)
 	if err != nil {
-		return ToolsFile{}, fmt.Errorf("error finding YML files in %q: %w", folderPath, err)
+		return ToolsFile{}, nil, fmt.Errorf("error finding YML files in %q: %w", folderPath, err)
 	}
 
 	// Combine both file lists
 	allFiles := append(yamlFiles, ymlFiles...)
 
 	if len(allFiles) == 0 {
-		return ToolsFile{}, fmt.Errorf("no YAML files found in directory %q", folderPath)
+		return ToolsFile{}, nil, fmt.Errorf("no YAML files found in directory %q", folderPath)
 	}
 
 	// Use existing loadAndMergeToolsFiles function
@@ -377,22 +576,25 @@ func handleDynamicReload(ctx context.Context, toolsFile ToolsFile, s *server.Ser
 		panic(err)
 	}
 
-	sourcesMap, authServicesMap, toolsMap, toolsetsMap, err := validateReloadEdits(ctx, toolsFile)
+	sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, err := validateReloadEdits(ctx, toolsFile, s.ToolDefaults())
 	if err != nil {
 		errMsg := fmt.Errorf("unable to validate reloaded edits: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
 		return err
 	}
 
-	s.ResourceMgr.SetResources(sourcesMap, authServicesMap, toolsMap, toolsetsMap)
+	s.ReloadResources(ctx, sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap)
+	logger.InfoContext(ctx, fmt.Sprintf("Reloaded tools file: %d sources, %d auth services, %d tools, %d toolsets, %d prompts.", len(sourcesMap), len(authServicesMap), len(toolsMap), len(toolsetsMap), len(promptsMap)))
 
 	return nil
 }
 
-// validateReloadEdits checks that the reloaded tools file configs can initialized without failing
+// validateReloadEdits checks that the reloaded tools file configs can be
+// initialized without failing, using the server-wide settings in defaults.
+// The currently served resources are left untouched if any of them fail.
 func validateReloadEdits(
-	ctx context.Context, toolsFile ToolsFile,
-) (map[string]sources.Source, map[string]auth.AuthService, map[string]tools.Tool, map[string]tools.Toolset, error,
+	ctx context.Context, toolsFile ToolsFile, defaults server.ServerConfig,
+) (map[string]sources.Source, map[string]auth.AuthService, map[string]tools.Tool, map[string]tools.Toolset, map[string]prompts.Prompt, error,
 ) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
@@ -409,26 +611,31 @@ func validateReloadEdits(
 	ctx, span := instrumentation.Tracer.Start(ctx, "toolbox/server/reload")
 	defer span.End()
 
-	reloadedConfig := server.ServerConfig{
-		Version:            versionString,
-		SourceConfigs:      toolsFile.Sources,
-		AuthServiceConfigs: toolsFile.AuthServices,
-		ToolConfigs:        toolsFile.Tools,
-		ToolsetConfigs:     toolsFile.Toolsets,
+	reloadedConfig := defaults
+	reloadedConfig.SourceConfigs = toolsFile.Sources
+	reloadedConfig.AuthServiceConfigs = toolsFile.AuthServices
+	reloadedConfig.ToolConfigs = toolsFile.Tools
+	reloadedConfig.ToolsetConfigs = toolsFile.Toolsets
+	reloadedConfig.PromptConfigs = toolsFile.Prompts
+	if toolsFile.AuthSources != nil {
+		logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` instead")
+		reloadedConfig.AuthServiceConfigs = toolsFile.AuthSources
 	}
 
-	sourcesMap, authServicesMap, toolsMap, toolsetsMap, err := server.InitializeConfigs(ctx, reloadedConfig)
+	sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, err := server.InitializeConfigs(ctx, reloadedConfig)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to initialize reloaded configs: %w", err)
 		logger.WarnContext(ctx, errMsg.Error())
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 
-	return sourcesMap, authServicesMap, toolsMap, toolsetsMap, nil
+	return sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, nil
 }
 
 // watchChanges checks for changes in the provided yaml tools file(s) or folder.
-func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, s *server.Server) {
+// Files included by the watched files, given by loadedPaths, are also watched,
+// and are updated on each reload.
+func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles map[string]bool, loadedPaths []string, s *server.Server) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		panic(err)
@@ -470,6 +677,31 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 		logger.DebugContext(ctx, fmt.Sprintf("Added directory %s to watcher.", dir))
 	}
 
+	// includedFiles are the files included by the watched files, which are
+	// reloaded with them
+	includedFiles := make(map[string]bool)
+	watchIncludedFiles := func(loadedPaths []string) {
+		includedFiles = make(map[string]bool)
+		for _, p := range loadedPaths {
+			p = filepath.Clean(p)
+			if watchedFiles[p] {
+				continue
+			}
+			includedFiles[p] = true
+			dir := filepath.Dir(p)
+			if watchDirs[dir] {
+				continue
+			}
+			if err := w.Add(dir); err != nil {
+				logger.WarnContext(ctx, fmt.Sprintf("Error adding path %s to watcher: %s", dir, err))
+				continue
+			}
+			watchDirs[dir] = true
+			logger.DebugContext(ctx, fmt.Sprintf("Added directory %s to watcher.", dir))
+		}
+	}
+	watchIncludedFiles(loadedPaths)
+
 	// debounce timer is used to prevent multiple writes triggering multiple reloads
 	debounceDelay := 100 * time.Millisecond
 	debounce := time.NewTimer(1 * time.Minute)
@@ -486,8 +718,8 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 				return
 			}
 			if err != nil {
-				logger.WarnContext(ctx, "file watcher error %s", err)
-				return
+				// errors such as a dropped event should not stop future reloads
+				logger.WarnContext(ctx, fmt.Sprintf("file watcher error: %s", err))
 			}
 
 		case e, ok := <-w.Events:
@@ -508,7 +740,7 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 			folderChanged := watchingFolder &&
 				(strings.HasSuffix(cleanedFilename, ".yaml") || strings.HasSuffix(cleanedFilename, ".yml"))
 
-			if folderChanged || watchedFiles[cleanedFilename] {
+			if folderChanged || watchedFiles[cleanedFilename] || includedFiles[cleanedFilename] {
 				// indicates the write event is on a relevant file
 				debounce.Reset(debounceDelay)
 			}
@@ -516,26 +748,29 @@ func watchChanges(ctx context.Context, watchDirs map[string]bool, watchedFiles m
 		case <-debounce.C:
 			debounce.Stop()
 			var reloadedToolsFile ToolsFile
+			var reloadedPaths []string
 
 			if watchingFolder {
 				logger.DebugContext(ctx, "Reloading tools folder.")
-				reloadedToolsFile, err = loadAndMergeToolsFolder(ctx, folderToWatch)
+				reloadedToolsFile, reloadedPaths, err = loadAndMergeToolsFolder(ctx, folderToWatch)
 				if err != nil {
-					logger.WarnContext(ctx, "error loading tools folder %s", err)
+					logger.WarnContext(ctx, fmt.Sprintf("error loading tools folder: %s", err))
 					continue
 				}
 			} else {
 				logger.DebugContext(ctx, "Reloading tools file(s).")
-				reloadedToolsFile, err = loadAndMergeToolsFiles(ctx, slices.Collect(maps.Keys(watchedFiles)))
+				reloadedToolsFile, reloadedPaths, err = loadAndMergeToolsFiles(ctx, slices.Collect(maps.Keys(watchedFiles)))
 				if err != nil {
-					logger.WarnContext(ctx, "error loading tools files %s", err)
+					logger.WarnContext(ctx, fmt.Sprintf("error loading tools files: %s", err))
 					continue
 				}
 			}
+			// the files may include other files than before
+			watchIncludedFiles(reloadedPaths)
 
 			err = handleDynamicReload(ctx, reloadedToolsFile, s)
 			if err != nil {
-				errMsg := fmt.Errorf("unable to parse reloaded tools file at %q: %w", reloadedToolsFile, err)
+				errMsg := fmt.Errorf("unable to reload tools file, continuing to serve the previous configuration: %w", err)
 				logger.WarnContext(ctx, errMsg.Error())
 				continue
 			}
@@ -633,7 +868,7 @@ func run(cmd *Command) error {
 	ctx = util.WithLogger(ctx, cmd.logger)
 
 	// Set up OpenTelemetry
-	otelShutdown, err := telemetry.SetupOTel(ctx, cmd.cfg.Version, cmd.cfg.TelemetryOTLP, cmd.cfg.TelemetryGCP, cmd.cfg.TelemetryServiceName)
+	otelShutdown, err := telemetry.SetupOTel(ctx, cmd.cfg.Version, cmd.cfg.TelemetryOTLP, cmd.cfg.TelemetryGCP, cmd.cfg.TelemetryPrometheus, cmd.cfg.TelemetryServiceName)
 	if err != nil {
 		errMsg := fmt.Errorf("error setting up OpenTelemetry: %w", err)
 		cmd.logger.ErrorContext(ctx, errMsg.Error())
@@ -647,7 +882,23 @@ func run(cmd *Command) error {
 		}
 	}()
 
+	// HTTP clients select a toolset with the path of the MCP endpoint instead
+	if len(cmd.cfg.StdioToolsets) > 0 && !cmd.cfg.Stdio {
+		errMsg := fmt.Errorf("--toolset can only be used with --stdio")
+		cmd.logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
+	if cmd.cfg.Listen != "" && (cmd.Flags().Changed("address") || cmd.Flags().Changed("port")) {
+		errMsg := fmt.Errorf("--listen cannot be used with --address or --port")
+		cmd.logger.ErrorContext(ctx, errMsg.Error())
+		return errMsg
+	}
+
 	var toolsFile ToolsFile
+	// loadedPaths are the paths of the loaded tools files, including the ones
+	// they include, which are watched for changes
+	var loadedPaths []string
 
 	if cmd.prebuiltConfig != "" {
 		// Make sure --prebuilt and --tools-file/--tools-files/--tools-folder flags are mutually exclusive
@@ -684,7 +935,7 @@ func run(cmd *Command) error {
 		// Use multiple tools files
 		cmd.logger.InfoContext(ctx, fmt.Sprintf("Loading and merging %d tool configuration files", len(cmd.tools_files)))
 		var err error
-		toolsFile, err = loadAndMergeToolsFiles(ctx, cmd.tools_files)
+		toolsFile, loadedPaths, err = loadAndMergeToolsFiles(ctx, cmd.tools_files)
 		if err != nil {
 			cmd.logger.ErrorContext(ctx, err.Error())
 			return err
@@ -700,7 +951,7 @@ func run(cmd *Command) error {
 		// Use tools folder
 		cmd.logger.InfoContext(ctx, fmt.Sprintf("Loading and merging all YAML files from directory: %s", cmd.tools_folder))
 		var err error
-		toolsFile, err = loadAndMergeToolsFolder(ctx, cmd.tools_folder)
+		toolsFile, loadedPaths, err = loadAndMergeToolsFolder(ctx, cmd.tools_folder)
 		if err != nil {
 			cmd.logger.ErrorContext(ctx, err.Error())
 			return err
@@ -711,23 +962,17 @@ func run(cmd *Command) error {
 			cmd.tools_file = "tools.yaml"
 		}
 
-		// Read single tool file contents
-		buf, err := os.ReadFile(cmd.tools_file)
-		if err != nil {
-			errMsg := fmt.Errorf("unable to read tool file at %q: %w", cmd.tools_file, err)
-			cmd.logger.ErrorContext(ctx, errMsg.Error())
-			return errMsg
-		}
-
-		toolsFile, err = parseToolsFile(ctx, buf)
+		// Read single tool file contents, along with any files it includes
+		var err error
+		toolsFile, loadedPaths, err = loadAndMergeToolsFiles(ctx, []string{cmd.tools_file})
 		if err != nil {
-			errMsg := fmt.Errorf("unable to parse tool file at %q: %w", cmd.tools_file, err)
-			cmd.logger.ErrorContext(ctx, errMsg.Error())
-			return errMsg
+			cmd.logger.ErrorContext(ctx, err.Error())
+			return err
 		}
 	}
 
 	cmd.cfg.SourceConfigs, cmd.cfg.AuthServiceConfigs, cmd.cfg.ToolConfigs, cmd.cfg.ToolsetConfigs = toolsFile.Sources, toolsFile.AuthServices, toolsFile.Tools, toolsFile.Toolsets
+	cmd.cfg.PromptConfigs = toolsFile.Prompts
 	authSourceConfigs := toolsFile.AuthSources
 	if authSourceConfigs != nil {
 		cmd.logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` instead")
@@ -781,9 +1026,10 @@ func run(cmd *Command) error {
 
 	watchDirs, watchedFiles := resolveWatcherInputs(cmd.tools_file, cmd.tools_files, cmd.tools_folder)
 
-	if !cmd.cfg.DisableReload {
+	// prebuilt configurations are embedded in the binary, so there is nothing to watch
+	if !cmd.cfg.DisableReload && cmd.prebuiltConfig == "" {
 		// start watching the file(s) or folder for changes to trigger dynamic reloading
-		go watchChanges(ctx, watchDirs, watchedFiles, s)
+		go watchChanges(ctx, watchDirs, watchedFiles, loadedPaths, s)
 	}
 
 	// wait for either the server to error out or the command's context to be canceled
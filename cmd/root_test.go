@@ -34,6 +34,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/auth/google"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/prebuiltconfigs"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	cloudsqlpgsrc "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
@@ -60,6 +61,30 @@ func withDefaults(c server.ServerConfig) server.ServerConfig {
 	if c.TelemetryServiceName == "" {
 		c.TelemetryServiceName = "toolbox"
 	}
+	if c.Cache.MaxEntries == 0 {
+		c.Cache.MaxEntries = 1000
+	}
+	if c.StdioToolsets == nil {
+		c.StdioToolsets = []string{}
+	}
+	if c.Audit.RedactParams == nil {
+		c.Audit.RedactParams = []string{}
+	}
+	if c.SensitiveParams == nil {
+		c.SensitiveParams = []string{}
+	}
+	if c.PIIDetectors == nil {
+		c.PIIDetectors = []string{}
+	}
+	if c.Approvals.Timeout == 0 {
+		c.Approvals.Timeout = time.Hour
+	}
+	if c.Approvals.Approvers == nil {
+		c.Approvals.Approvers = []string{}
+	}
+	if c.TLS.AllowedClientSANs == nil {
+		c.TLS.AllowedClientSANs = []string{}
+	}
 	return c
 }
 
@@ -184,6 +209,14 @@ func TestServerConfigFlags(t *testing.T) {
 				Stdio: true,
 			}),
 		},
+		{
+			desc: "stdio toolsets",
+			args: []string{"--stdio", "--toolset", "analytics,admin"},
+			want: withDefaults(server.ServerConfig{
+				Stdio:         true,
+				StdioToolsets: []string{"analytics", "admin"},
+			}),
+		},
 		{
 			desc: "disable reload",
 			args: []string{"--disable-reload"},
@@ -191,6 +224,110 @@ func TestServerConfigFlags(t *testing.T) {
 				DisableReload: true,
 			}),
 		},
+		{
+			desc: "response limits",
+			args: []string{"--max-response-bytes", "1024", "--max-rows", "50", "--truncation", "error"},
+			want: withDefaults(server.ServerConfig{
+				ResponseLimits: tools.ResponseLimits{
+					MaxResponseBytes: 1024,
+					MaxRows:          50,
+					Truncation:       tools.ErrorResponse,
+				},
+			}),
+		},
+		{
+			desc: "cache",
+			args: []string{"--cache-max-entries", "50", "--cache-redis-url", "redis://127.0.0.1:6379/0"},
+			want: withDefaults(server.ServerConfig{
+				Cache: server.CacheConfig{
+					MaxEntries: 50,
+					RedisURL:   "redis://127.0.0.1:6379/0",
+				},
+			}),
+		},
+		{
+			desc: "audit log",
+			args: []string{"--audit-log", "gs://my-bucket/audit", "--audit-redact-params", "password,ssn"},
+			want: withDefaults(server.ServerConfig{
+				Audit: server.AuditConfig{
+					Destination:  "gs://my-bucket/audit",
+					RedactParams: []string{"password", "ssn"},
+				},
+			}),
+		},
+		{
+			desc: "sensitive params",
+			args: []string{"--sensitive-params", "*password*,api_key"},
+			want: withDefaults(server.ServerConfig{
+				SensitiveParams: []string{"*password*", "api_key"},
+			}),
+		},
+		{
+			desc: "pii detectors",
+			args: []string{"--pii-detectors", "email,creditCard"},
+			want: withDefaults(server.ServerConfig{
+				PIIDetectors: []string{"email", "creditCard"},
+			}),
+		},
+		{
+			desc: "approvals",
+			args: []string{"--approval-timeout", "30m", "--approval-slack-webhook", "https://hooks.slack.com/services/T0/B0/X", "--approval-auth-service", "my-google-auth", "--approvers", "admin@example.com"},
+			want: withDefaults(server.ServerConfig{
+				Approvals: server.ApprovalConfig{
+					Timeout:         30 * time.Minute,
+					SlackWebhookURL: "https://hooks.slack.com/services/T0/B0/X",
+					AuthService:     "my-google-auth",
+					Approvers:       []string{"admin@example.com"},
+				},
+			}),
+		},
+		{
+			desc: "dry run",
+			args: []string{"--dry-run"},
+			want: withDefaults(server.ServerConfig{
+				DryRun: true,
+			}),
+		},
+		{
+			desc: "tool timeout",
+			args: []string{"--tool-timeout", "2m"},
+			want: withDefaults(server.ServerConfig{
+				ToolTimeout: 2 * time.Minute,
+			}),
+		},
+		{
+			desc: "result format",
+			args: []string{"--result-format", "CSV"},
+			want: withDefaults(server.ServerConfig{
+				ResultFormat: tools.CSVFormat,
+			}),
+		},
+		{
+			desc: "allow degraded",
+			args: []string{"--allow-degraded"},
+			want: withDefaults(server.ServerConfig{
+				AllowDegraded: true,
+			}),
+		},
+		{
+			desc: "unix socket",
+			args: []string{"--listen", "unix:/run/toolbox/toolbox.sock"},
+			want: withDefaults(server.ServerConfig{
+				Listen: "unix:/run/toolbox/toolbox.sock",
+			}),
+		},
+		{
+			desc: "mutual TLS",
+			args: []string{"--tls-cert-file", "server.pem", "--tls-key-file", "server-key.pem", "--tls-client-ca-file", "ca.pem", "--tls-allowed-client-sans", "spiffe://example.com/agent,agent.example.com"},
+			want: withDefaults(server.ServerConfig{
+				TLS: server.TLSConfig{
+					CertFile:          "server.pem",
+					KeyFile:           "server-key.pem",
+					ClientCAFile:      "ca.pem",
+					AllowedClientSANs: []string{"spiffe://example.com/agent", "agent.example.com"},
+				},
+			}),
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -354,6 +491,14 @@ func TestFailServerConfigFlags(t *testing.T) {
 			desc: "debug logs",
 			args: []string{"--log-level", "fail"},
 		},
+		{
+			desc: "truncation",
+			args: []string{"--truncation", "fail"},
+		},
+		{
+			desc: "result format",
+			args: []string{"--result-format", "xml"},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -461,6 +606,72 @@ func TestParseToolFile(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "toolset with authorization policy",
+			in: `
+			toolsets:
+				example_toolset:
+					- example_tool
+				restricted_toolset:
+					tools:
+						- example_tool
+					allowedAudiences:
+						- my-client-id
+					allowedSubjects:
+						- "1234567890"
+					allowedClaims:
+						hd:
+							- example.com
+					sourceResources: true
+			`,
+			wantToolsFile: ToolsFile{
+				Toolsets: server.ToolsetConfigs{
+					"example_toolset": tools.ToolsetConfig{
+						Name:      "example_toolset",
+						ToolNames: []string{"example_tool"},
+					},
+					"restricted_toolset": tools.ToolsetConfig{
+						Name:             "restricted_toolset",
+						ToolNames:        []string{"example_tool"},
+						AllowedAudiences: []string{"my-client-id"},
+						AllowedSubjects:  []string{"1234567890"},
+						AllowedClaims:    map[string][]string{"hd": {"example.com"}},
+						SourceResources:  true,
+					},
+				},
+			},
+		},
+		{
+			description: "prompts",
+			in: `
+			prompts:
+				investigate_slow_query:
+					description: Investigate why a query is slow.
+					arguments:
+						- name: query
+							description: The slow query.
+							required: true
+					messages:
+						- content: Explain why {{.query}} is slow.
+						- role: assistant
+							content: I will look at its query plan.
+			`,
+			wantToolsFile: ToolsFile{
+				Prompts: server.PromptConfigs{
+					"investigate_slow_query": prompts.PromptConfig{
+						Name:        "investigate_slow_query",
+						Description: "Investigate why a query is slow.",
+						Arguments: []prompts.ArgumentConfig{
+							{Name: "query", Description: "The slow query.", Required: true},
+						},
+						Messages: []prompts.MessageConfig{
+							{Content: "Explain why {{.query}} is slow."},
+							{Role: "assistant", Content: "I will look at its query plan."},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
@@ -480,6 +691,9 @@ func TestParseToolFile(t *testing.T) {
 			if diff := cmp.Diff(tc.wantToolsFile.Toolsets, toolsFile.Toolsets); diff != "" {
 				t.Fatalf("incorrect tools parse: diff %v", diff)
 			}
+			if diff := cmp.Diff(tc.wantToolsFile.Prompts, toolsFile.Prompts); diff != "" {
+				t.Fatalf("incorrect prompts parse: diff %v", diff)
+			}
 		})
 	}
 
@@ -1132,7 +1346,7 @@ func TestSingleEdit(t *testing.T) {
 	watchedFiles := map[string]bool{cleanFileToWatch: true}
 	watchDirs := map[string]bool{watchDir: true}
 
-	go watchChanges(ctx, watchDirs, watchedFiles, mockServer)
+	go watchChanges(ctx, watchDirs, watchedFiles, nil, mockServer)
 
 	// escape backslash so regex doesn't fail on windows filepaths
 	regexEscapedPathFile := strings.ReplaceAll(cleanFileToWatch, `\`, `\\\\*\\`)
@@ -1160,6 +1374,54 @@ func TestSingleEdit(t *testing.T) {
 	}
 }
 
+func TestIncludedFileEdit(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelCtx()
+
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	defer pr.Close()
+
+	// the included file is in another directory than the tools file
+	fileToWatch := filepath.Join(t.TempDir(), "tools.yaml")
+	includedFile := filepath.Join(t.TempDir(), "included.yaml")
+	for _, f := range []string{fileToWatch, includedFile} {
+		if err := os.WriteFile(f, []byte("initial content"), 0o644); err != nil {
+			t.Fatalf("error writing tools file %s", err)
+		}
+	}
+
+	logger, err := log.NewStdLogger(pw, pw, "DEBUG")
+	if err != nil {
+		t.Fatalf("failed to setup logger %s", err)
+	}
+	ctx = util.WithLogger(ctx, logger)
+
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
+	if err != nil {
+		t.Fatalf("failed to setup instrumentation %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	watchDirs, watchedFiles := resolveWatcherInputs(fileToWatch, nil, "")
+	go watchChanges(ctx, watchDirs, watchedFiles, []string{fileToWatch, includedFile}, &server.Server{})
+
+	// escape backslash so regex doesn't fail on windows filepaths
+	includedDir := strings.ReplaceAll(filepath.Dir(includedFile), `\`, `\\\\*\\`)
+	begunWatchingDir := regexp.MustCompile(fmt.Sprintf(`DEBUG "Added directory %s to watcher."`, path.Clean(includedDir)))
+	if _, err := testutils.WaitForString(ctx, begunWatchingDir, pr); err != nil {
+		t.Fatalf("timeout or error waiting for watcher to watch the included file: %s", err)
+	}
+
+	if err := os.WriteFile(includedFile, []byte("modification"), 0o644); err != nil {
+		t.Fatalf("error writing to file: %v", err)
+	}
+	reloading := regexp.MustCompile(`DEBUG "Reloading tools file\(s\)."`)
+	if _, err := testutils.WaitForString(ctx, reloading, pr); err != nil {
+		t.Fatalf("timeout or error waiting for the edit of the included file to reload: %s", err)
+	}
+}
+
 func TestPrebuiltTools(t *testing.T) {
 	alloydb_config, _ := prebuiltconfigs.Get("alloydb-postgres")
 	bigquery_config, _ := prebuiltconfigs.Get("bigquery")
@@ -1185,7 +1447,7 @@ func TestPrebuiltTools(t *testing.T) {
 			wantToolset: server.ToolsetConfigs{
 				"alloydb-postgres-database-tools": tools.ToolsetConfig{
 					Name:      "alloydb-postgres-database-tools",
-					ToolNames: []string{"execute_sql", "list_tables"},
+					ToolNames: []string{"execute_sql", "list_tables", "describe_table", "list_columns", "sample_rows", "list_slow_queries", "list_missing_index_candidates", "list_table_bloat"},
 				},
 			},
 		},
@@ -1205,7 +1467,7 @@ func TestPrebuiltTools(t *testing.T) {
 			wantToolset: server.ToolsetConfigs{
 				"cloud-sql-postgres-database-tools": tools.ToolsetConfig{
 					Name:      "cloud-sql-postgres-database-tools",
-					ToolNames: []string{"execute_sql", "list_tables"},
+					ToolNames: []string{"execute_sql", "list_tables", "describe_table", "list_columns", "sample_rows", "list_slow_queries", "list_missing_index_candidates", "list_table_bloat"},
 				},
 			},
 		},
@@ -1215,7 +1477,7 @@ func TestPrebuiltTools(t *testing.T) {
 			wantToolset: server.ToolsetConfigs{
 				"cloud-sql-mysql-database-tools": tools.ToolsetConfig{
 					Name:      "cloud-sql-mysql-database-tools",
-					ToolNames: []string{"execute_sql", "list_tables"},
+					ToolNames: []string{"execute_sql", "list_tables", "describe_table", "list_columns", "sample_rows", "list_slow_queries", "list_missing_index_candidates", "list_table_bloat"},
 				},
 			},
 		},
@@ -1225,7 +1487,7 @@ func TestPrebuiltTools(t *testing.T) {
 			wantToolset: server.ToolsetConfigs{
 				"cloud-sql-mssql-database-tools": tools.ToolsetConfig{
 					Name:      "cloud-sql-mssql-database-tools",
-					ToolNames: []string{"execute_sql", "list_tables"},
+					ToolNames: []string{"execute_sql", "list_tables", "describe_table", "list_columns", "sample_rows"},
 				},
 			},
 		},
@@ -1245,7 +1507,7 @@ func TestPrebuiltTools(t *testing.T) {
 			wantToolset: server.ToolsetConfigs{
 				"postgres-database-tools": tools.ToolsetConfig{
 					Name:      "postgres-database-tools",
-					ToolNames: []string{"execute_sql", "list_tables"},
+					ToolNames: []string{"execute_sql", "list_tables", "describe_table", "list_columns", "sample_rows", "list_slow_queries", "list_missing_index_candidates", "list_table_bloat"},
 				},
 			},
 		},
@@ -1331,3 +1593,166 @@ func TestUpdateLogLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleDynamicReload(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(versionString)
+	if err != nil {
+		t.Fatalf("failed to setup instrumentation %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	s, err := server.NewServer(ctx, server.ServerConfig{Version: versionString})
+	if err != nil {
+		t.Fatalf("unable to initialize server: %s", err)
+	}
+
+	toolsFileFor := func(toolName, sourceName string) ToolsFile {
+		raw := fmt.Sprintf(`
+			sources:
+				my-http-instance:
+					kind: http
+					baseUrl: http://example.com
+			tools:
+				%s:
+					kind: http
+					source: %s
+					method: GET
+					path: "/"
+					description: some description
+		`, toolName, sourceName)
+		toolsFile, err := parseToolsFile(ctx, testutils.FormatYaml(raw))
+		if err != nil {
+			t.Fatalf("unable to parse tools file: %s", err)
+		}
+		return toolsFile
+	}
+
+	// a valid config replaces the served resources
+	if err := handleDynamicReload(ctx, toolsFileFor("first_tool", "my-http-instance"), s); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := s.ResourceMgr.GetTool("first_tool"); !ok {
+		t.Fatalf("expected first_tool to be served after reload")
+	}
+
+	// an invalid config is rejected and the previous resources keep serving
+	if err := handleDynamicReload(ctx, toolsFileFor("second_tool", "missing-source"), s); err == nil {
+		t.Fatalf("expected error when reloading invalid config")
+	}
+	if _, ok := s.ResourceMgr.GetTool("first_tool"); !ok {
+		t.Fatalf("expected first_tool to still be served after rejected reload")
+	}
+	if _, ok := s.ResourceMgr.GetTool("second_tool"); ok {
+		t.Fatalf("expected second_tool not to be served after rejected reload")
+	}
+}
+
+func TestLoadAndMergeToolsFiles(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	source := `
+		sources:
+			my-http-instance:
+				kind: http
+				baseUrl: http://example.com
+	`
+	tool := func(name string) string {
+		return fmt.Sprintf(`
+		tools:
+			%s:
+				kind: http
+				source: my-http-instance
+				method: GET
+				path: "/"
+				description: some description
+		`, name)
+	}
+
+	include := func(paths ...string) string {
+		return "\n\t\tinclude:\n\t\t\t- " + strings.Join(paths, "\n\t\t\t- ") + "\n"
+	}
+
+	tcs := []struct {
+		desc      string
+		files     map[string]string
+		wantTools []string
+		wantErr   string
+	}{
+		{
+			desc: "include relative to including file",
+			files: map[string]string{
+				"tools.yaml":      source + include("teams/team.yaml"),
+				"teams/team.yaml": tool("team_tool"),
+			},
+			wantTools: []string{"team_tool"},
+		},
+		{
+			desc: "include cycle",
+			files: map[string]string{
+				"tools.yaml": source + include("other.yaml"),
+				"other.yaml": tool("other_tool") + include("tools.yaml"),
+			},
+			wantTools: []string{"other_tool"},
+		},
+		{
+			desc: "duplicate tool across included files",
+			files: map[string]string{
+				"tools.yaml": source + include("a.yaml", "b.yaml"),
+				"a.yaml":     tool("dup_tool"),
+				"b.yaml":     tool("dup_tool"),
+			},
+			wantErr: "tool 'dup_tool'",
+		},
+		{
+			desc: "missing include",
+			files: map[string]string{
+				"tools.yaml": source + include("missing.yaml"),
+			},
+			wantErr: "unable to read tool file",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				p := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+					t.Fatalf("unable to create dir: %s", err)
+				}
+				if err := os.WriteFile(p, testutils.FormatYaml(content), 0o644); err != nil {
+					t.Fatalf("unable to write file: %s", err)
+				}
+			}
+
+			got, loadedPaths, err := loadAndMergeToolsFiles(ctx, []string{filepath.Join(dir, "tools.yaml")})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, ok := got.Sources["my-http-instance"]; !ok {
+				t.Errorf("expected source from root file to be merged")
+			}
+			for _, name := range tc.wantTools {
+				if _, ok := got.Tools[name]; !ok {
+					t.Errorf("expected tool %q to be merged", name)
+				}
+			}
+			// every loaded file is returned, so that it is watched
+			if len(loadedPaths) != len(tc.files) {
+				t.Errorf("unexpected loaded files: want %d, got %v", len(tc.files), loadedPaths)
+			}
+		})
+	}
+}
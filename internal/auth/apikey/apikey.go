@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"golang.org/x/oauth2/google"
+)
+
+const AuthServiceKind string = "api-key"
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+// Auth service configuration
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	// Header is the request header of the API key, which defaults to
+	// `<name>_token`, like the tokens of the other auth services.
+	Header string `yaml:"header"`
+	Keys   []Key  `yaml:"keys" validate:"required,min=1,dive"`
+}
+
+// Key is an API key, and the identity of the callers that use it.
+type Key struct {
+	Identity string `yaml:"identity" validate:"required"`
+	Key      string `yaml:"key"`
+	// Secret is the resource name of a Secret Manager secret version that
+	// holds the key, e.g. projects/my-project/secrets/my-key/versions/latest.
+	Secret string `yaml:"secret"`
+}
+
+// Returns the auth service kind
+func (cfg Config) AuthServiceConfigKind() string {
+	return AuthServiceKind
+}
+
+// Initialize an API key auth service. Keys stored in Secret Manager are
+// accessed with the Application Default Credentials.
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	header := cfg.Header
+	if header == "" {
+		header = cfg.Name + "_token"
+	}
+
+	ctx := context.Background()
+	identities := make(map[[sha256.Size]byte]string, len(cfg.Keys))
+	for i, k := range cfg.Keys {
+		if (k.Key == "") == (k.Secret == "") {
+			return nil, fmt.Errorf("key %d of identity %q must set exactly one of key or secret", i, k.Identity)
+		}
+		key := k.Key
+		if k.Secret != "" {
+			var err error
+			key, err = accessSecret(ctx, k.Secret)
+			if err != nil {
+				return nil, fmt.Errorf("unable to access secret of identity %q: %w", k.Identity, err)
+			}
+		}
+		// keys are looked up by their hash, so that they can't be recovered
+		// from memory and comparing them doesn't depend on their contents
+		h := sha256.Sum256([]byte(key))
+		if identity, ok := identities[h]; ok && identity != k.Identity {
+			return nil, fmt.Errorf("identities %q and %q have the same key", identity, k.Identity)
+		}
+		identities[h] = k.Identity
+	}
+
+	a := &AuthService{
+		Name:       cfg.Name,
+		Kind:       AuthServiceKind,
+		Header:     header,
+		identities: identities,
+	}
+	return a, nil
+}
+
+// secretManagerEndpoint is the endpoint of the Secret Manager API.
+const secretManagerEndpoint = "https://secretmanager.googleapis.com/v1/"
+
+// accessSecret returns the payload of a Secret Manager secret version. A
+// secret without a version is accessed at its latest version.
+func accessSecret(ctx context.Context, name string) (string, error) {
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	client, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		return "", fmt.Errorf("failed to find default Google Cloud credentials: %w", err)
+	}
+	resp, err := client.Get(secretManagerEndpoint + name + ":access")
+	if err != nil {
+		return "", fmt.Errorf("failed to call Secret Manager: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to read Secret Manager response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Secret Manager returned status %d for %q: %s", resp.StatusCode, name, string(body)) //nolint:staticcheck
+	}
+
+	var secret struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("unable to parse Secret Manager response: %w", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(secret.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode secret payload: %w", err)
+	}
+	// secrets are often created from files that end with a newline
+	return strings.TrimSpace(string(data)), nil
+}
+
+var _ auth.AuthService = &AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Name   string `yaml:"name"`
+	Kind   string `yaml:"kind"`
+	Header string `yaml:"header"`
+
+	// identities maps the SHA-256 hash of each key to its identity
+	identities map[[sha256.Size]byte]string
+}
+
+// Returns the auth service kind
+func (a *AuthService) AuthServiceKind() string {
+	return AuthServiceKind
+}
+
+// Returns the name of the auth service
+func (a *AuthService) GetName() string {
+	return a.Name
+}
+
+// Verifies the API key and returns the identity of the caller as the `sub`
+// claim, so that it's recorded in audit logs and can be matched by the
+// allowedSubjects of toolsets.
+func (a *AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	key := h.Get(a.Header)
+	if key == "" {
+		return nil, nil
+	}
+	identity, ok := a.identities[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, fmt.Errorf("invalid API key for auth service %q", a.Name)
+	}
+	return map[string]any{"sub": identity}, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikey_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth/apikey"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlAPIKey(t *testing.T) {
+	in := `
+	authServices:
+		my-api-key:
+			kind: api-key
+			header: X-API-Key
+			keys:
+				- identity: billing-service
+				  key: my-key
+				- identity: reporting-job
+				  secret: projects/my-project/secrets/reporting-key/versions/latest
+	`
+	want := server.AuthServiceConfigs{
+		"my-api-key": apikey.Config{
+			Name:   "my-api-key",
+			Kind:   apikey.AuthServiceKind,
+			Header: "X-API-Key",
+			Keys: []apikey.Key{
+				{Identity: "billing-service", Key: "my-key"},
+				{Identity: "reporting-job", Secret: "projects/my-project/secrets/reporting-key/versions/latest"},
+			},
+		},
+	}
+	got := struct {
+		AuthServices server.AuthServiceConfigs `yaml:"authServices"`
+	}{}
+	if err := yaml.Unmarshal(testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.AuthServices); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestGetClaimsFromHeader(t *testing.T) {
+	cfg := apikey.Config{
+		Name: "my-api-key",
+		Kind: apikey.AuthServiceKind,
+		Keys: []apikey.Key{
+			{Identity: "billing-service", Key: "billing-key"},
+			{Identity: "billing-service", Key: "rotated-billing-key"},
+			{Identity: "reporting-job", Key: "reporting-key"},
+		},
+	}
+	a, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		key     string
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			desc: "valid key",
+			key:  "reporting-key",
+			want: map[string]any{"sub": "reporting-job"},
+		},
+		{
+			desc: "rotated key",
+			key:  "rotated-billing-key",
+			want: map[string]any{"sub": "billing-service"},
+		},
+		{
+			desc:    "invalid key",
+			key:     "other-key",
+			wantErr: `invalid API key for auth service "my-api-key"`,
+		},
+		{
+			desc: "missing key",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := http.Header{}
+			if tc.key != "" {
+				h.Set("my-api-key_token", tc.key)
+			}
+			got, err := a.GetClaimsFromHeader(context.Background(), h)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect claims: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestGetClaimsFromCustomHeader(t *testing.T) {
+	cfg := apikey.Config{
+		Name:   "my-api-key",
+		Kind:   apikey.AuthServiceKind,
+		Header: "X-API-Key",
+		Keys:   []apikey.Key{{Identity: "billing-service", Key: "billing-key"}},
+	}
+	a, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	h := http.Header{}
+	h.Set("x-api-key", "billing-key")
+	got, err := a.GetClaimsFromHeader(context.Background(), h)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(map[string]any{"sub": "billing-service"}, got); diff != "" {
+		t.Fatalf("incorrect claims: diff %v", diff)
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		desc string
+		keys []apikey.Key
+		err  string
+	}{
+		{
+			desc: "key and secret",
+			keys: []apikey.Key{{Identity: "billing-service", Key: "billing-key", Secret: "projects/my-project/secrets/billing-key"}},
+			err:  `key 0 of identity "billing-service" must set exactly one of key or secret`,
+		},
+		{
+			desc: "neither key nor secret",
+			keys: []apikey.Key{{Identity: "billing-service"}},
+			err:  `key 0 of identity "billing-service" must set exactly one of key or secret`,
+		},
+		{
+			desc: "shared key",
+			keys: []apikey.Key{{Identity: "billing-service", Key: "my-key"}, {Identity: "reporting-job", Key: "my-key"}},
+			err:  `identities "billing-service" and "reporting-job" have the same key`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := apikey.Config{Name: "my-api-key", Kind: apikey.AuthServiceKind, Keys: tc.keys}
+			_, err := cfg.Initialize()
+			if err == nil {
+				t.Fatalf("expect initialization to fail")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
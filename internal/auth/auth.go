@@ -31,3 +31,13 @@ type AuthService interface {
 	GetName() string
 	GetClaimsFromHeader(context.Context, http.Header) (map[string]any, error)
 }
+
+// AccessTokenService is implemented by the authentication services that can
+// verify an OAuth access token of the caller, which sources may forward as
+// the credential of their requests.
+type AccessTokenService interface {
+	AuthService
+	// GetAccessTokenFromHeader returns the verified access token of the
+	// caller, or an empty string if the header doesn't contain one.
+	GetAccessTokenFromHeader(context.Context, http.Header) (string, error)
+}
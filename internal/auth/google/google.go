@@ -16,8 +16,15 @@ package google
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"google.golang.org/api/idtoken"
@@ -43,34 +50,45 @@ func (cfg Config) AuthServiceConfigKind() string {
 // Initialize a Google auth service
 func (cfg Config) Initialize() (auth.AuthService, error) {
 	a := &AuthService{
-		Name:     cfg.Name,
-		Kind:     AuthServiceKind,
-		ClientID: cfg.ClientID,
+		Name:         cfg.Name,
+		Kind:         AuthServiceKind,
+		ClientID:     cfg.ClientID,
+		tokenInfoURL: tokenInfoURL,
+		client:       &http.Client{Timeout: tokenInfoTimeout},
+		accessTokens: make(map[string]time.Time),
 	}
 	return a, nil
 }
 
-var _ auth.AuthService = AuthService{}
+var _ auth.AccessTokenService = &AuthService{}
 
 // struct used to store auth service info
 type AuthService struct {
 	Name     string `yaml:"name"`
 	Kind     string `yaml:"kind"`
 	ClientID string `yaml:"clientId"`
+
+	tokenInfoURL string
+	client       *http.Client
+
+	mu sync.Mutex
+	// accessTokens holds the expiry of the access tokens that were verified,
+	// keyed by their hash, so that they aren't verified again on every call
+	accessTokens map[string]time.Time
 }
 
 // Returns the auth service kind
-func (a AuthService) AuthServiceKind() string {
+func (a *AuthService) AuthServiceKind() string {
 	return AuthServiceKind
 }
 
 // Returns the name of the auth service
-func (a AuthService) GetName() string {
+func (a *AuthService) GetName() string {
 	return a.Name
 }
 
 // Verifies Google ID token and return claims
-func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+func (a *AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
 	if token := h.Get(a.Name + "_token"); token != "" {
 		payload, err := idtoken.Validate(ctx, token, a.ClientID)
 		if err != nil {
@@ -80,3 +98,96 @@ func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (ma
 	}
 	return nil, nil
 }
+
+const (
+	// tokenInfoURL is the endpoint that returns the information of a Google
+	// OAuth access token.
+	tokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+	// tokenInfoTimeout bounds the requests to the tokeninfo endpoint.
+	tokenInfoTimeout = 10 * time.Second
+	// maxAccessTokens is the maximum number of verified access tokens held
+	// at once.
+	maxAccessTokens = 10000
+)
+
+// Verifies the Google OAuth access token of the caller and returns it. The
+// token must have been issued to the client ID of the auth service, so that
+// tokens issued to other applications can't be replayed against the sources.
+// Verified tokens aren't verified again until they expire.
+func (a *AuthService) GetAccessTokenFromHeader(ctx context.Context, h http.Header) (string, error) {
+	token := h.Get(a.Name + "_access_token")
+	if token == "" {
+		return "", nil
+	}
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	if a.verified(key) {
+		return token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.tokenInfoURL+"?access_token="+url.QueryEscape(token), nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to create tokeninfo request: %w", err)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call tokeninfo endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Google access token verification failure: tokeninfo endpoint returned status %d", resp.StatusCode) //nolint:staticcheck
+	}
+
+	var info struct {
+		Audience         string `json:"aud"`
+		AuthorizedParty  string `json:"azp"`
+		ExpiresInSeconds string `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("unable to parse tokeninfo response: %w", err)
+	}
+	if info.Audience != a.ClientID && info.AuthorizedParty != a.ClientID {
+		return "", fmt.Errorf("Google access token verification failure: token wasn't issued to client ID %q", a.ClientID) //nolint:staticcheck
+	}
+	expiresIn, err := strconv.Atoi(info.ExpiresInSeconds)
+	if err != nil || expiresIn <= 0 {
+		return "", fmt.Errorf("Google access token verification failure: token is expired") //nolint:staticcheck
+	}
+	a.remember(key, time.Now().Add(time.Duration(expiresIn)*time.Second))
+	return token, nil
+}
+
+// verified reports whether the access token with the given hash was verified
+// and hasn't expired since.
+func (a *AuthService) verified(key string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	expiry, ok := a.accessTokens[key]
+	if !ok {
+		return false
+	}
+	if time.Now().After(expiry) {
+		delete(a.accessTokens, key)
+		return false
+	}
+	return true
+}
+
+// remember records the expiry of a verified access token. Tokens aren't
+// remembered while too many unexpired ones are held.
+func (a *AuthService) remember(key string, expiry time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.accessTokens) >= maxAccessTokens {
+		now := time.Now()
+		for k, e := range a.accessTokens {
+			if now.After(e) {
+				delete(a.accessTokens, k)
+			}
+		}
+		if len(a.accessTokens) >= maxAccessTokens {
+			return
+		}
+	}
+	a.accessTokens[key] = expiry
+}
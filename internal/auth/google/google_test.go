@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetAccessTokenFromHeader(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		info := map[string]string{"aud": "my-client-id", "expires_in": "3600"}
+		switch r.URL.Query().Get("access_token") {
+		case "other-app-token":
+			info["aud"] = "other-client-id"
+		case "expired-token":
+			info["expires_in"] = "0"
+		case "invalid-token":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(info)
+	}))
+	defer srv.Close()
+
+	cfg := Config{Name: "my-google-auth", Kind: AuthServiceKind, ClientID: "my-client-id"}
+	svc, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize auth service: %s", err)
+	}
+	a := svc.(*AuthService)
+	a.tokenInfoURL = srv.URL
+
+	tcs := []struct {
+		desc    string
+		token   string
+		wantErr bool
+	}{
+		{desc: "valid token", token: "my-token"},
+		{desc: "token of another client", token: "other-app-token", wantErr: true},
+		{desc: "expired token", token: "expired-token", wantErr: true},
+		{desc: "invalid token", token: "invalid-token", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			h := http.Header{}
+			h.Set("my-google-auth_access_token", tc.token)
+			got, err := a.GetAccessTokenFromHeader(context.Background(), h)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got token %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.token {
+				t.Fatalf("unexpected token: got %q, want %q", got, tc.token)
+			}
+		})
+	}
+
+	// a verified token isn't verified again until it expires, while a
+	// rejected one is
+	before := calls.Load()
+	for _, token := range []string{"my-token", "other-app-token"} {
+		h := http.Header{}
+		h.Set("my-google-auth_access_token", token)
+		_, _ = a.GetAccessTokenFromHeader(context.Background(), h)
+	}
+	if got := calls.Load() - before; got != 1 {
+		t.Fatalf("unexpected number of tokeninfo calls: got %d, want 1", got)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+)
+
+const AuthServiceKind string = "oidc"
+
+// defaultJWKSCacheDuration is how long the signing keys of the issuer are
+// cached by default.
+const defaultJWKSCacheDuration = time.Hour
+
+const (
+	// discoveryTimeout is how long the discovery of the issuer can take.
+	discoveryTimeout = 10 * time.Second
+	// minDiscoveryBackoff is how long the issuer isn't discovered again after
+	// a failed discovery. It doubles with each failure that follows, up to
+	// maxDiscoveryBackoff.
+	minDiscoveryBackoff = time.Second
+	maxDiscoveryBackoff = time.Minute
+)
+
+// validate interface
+var _ auth.AuthServiceConfig = Config{}
+
+// Auth service configuration
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Kind     string `yaml:"kind" validate:"required"`
+	Issuer   string `yaml:"issuer" validate:"required"`
+	Audience string `yaml:"audience" validate:"required"`
+	// JWKSURL is the URL of the signing keys of the issuer, which is
+	// discovered from the issuer if it isn't set.
+	JWKSURL           string `yaml:"jwksUrl"`
+	JWKSCacheDuration string `yaml:"jwksCacheDuration"`
+}
+
+// Returns the auth service kind
+func (cfg Config) AuthServiceConfigKind() string {
+	return AuthServiceKind
+}
+
+// Initialize an OIDC auth service
+func (cfg Config) Initialize() (auth.AuthService, error) {
+	if _, err := url.ParseRequestURI(cfg.Issuer); err != nil {
+		return nil, fmt.Errorf("invalid issuer %q: %w", cfg.Issuer, err)
+	}
+	if cfg.JWKSURL != "" {
+		if _, err := url.ParseRequestURI(cfg.JWKSURL); err != nil {
+			return nil, fmt.Errorf("invalid jwksUrl %q: %w", cfg.JWKSURL, err)
+		}
+	}
+	cacheDuration := defaultJWKSCacheDuration
+	if cfg.JWKSCacheDuration != "" {
+		d, err := time.ParseDuration(cfg.JWKSCacheDuration)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse jwksCacheDuration %q: %w", cfg.JWKSCacheDuration, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("jwksCacheDuration must be positive, got %q", cfg.JWKSCacheDuration)
+		}
+		cacheDuration = d
+	}
+
+	a := &AuthService{
+		Name:              cfg.Name,
+		Kind:              AuthServiceKind,
+		Issuer:            cfg.Issuer,
+		Audience:          cfg.Audience,
+		jwksURL:           cfg.JWKSURL,
+		jwksCacheDuration: cacheDuration,
+	}
+	return a, nil
+}
+
+var _ auth.AuthService = &AuthService{}
+
+// struct used to store auth service info
+type AuthService struct {
+	Name     string `yaml:"name"`
+	Kind     string `yaml:"kind"`
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+
+	jwksURL           string
+	jwksCacheDuration time.Duration
+
+	mu sync.Mutex
+	// verifier is created on first use, so that an issuer that is unreachable
+	// at startup doesn't prevent the server from starting
+	verifier        *oidc.IDTokenVerifier
+	verifierCreated time.Time
+	// discovering is closed once the discovery in progress, if any, ends
+	discovering chan struct{}
+	// discoveryErr is the error of the last discovery if it failed, which is
+	// returned until retryTime
+	discoveryErr error
+	retryTime    time.Time
+	backoff      time.Duration
+}
+
+// Returns the auth service kind
+func (a *AuthService) AuthServiceKind() string {
+	return AuthServiceKind
+}
+
+// Returns the name of the auth service
+func (a *AuthService) GetName() string {
+	return a.Name
+}
+
+// Verifies the OIDC token and returns its claims
+func (a *AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
+	token := h.Get(a.Name + "_token")
+	if token == "" {
+		return nil, nil
+	}
+	verifier, err := a.getVerifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC token verification failure: %w", err)
+	}
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("unable to parse OIDC token claims: %w", err)
+	}
+	return claims, nil
+}
+
+// getVerifier returns the verifier of the tokens. The signing keys of the
+// issuer are fetched again once the cache duration has passed, as well as
+// whenever a token is signed with an unknown key, e.g. after a rotation. The
+// issuer is discovered by one request at a time without holding the lock, and
+// isn't discovered again for a while after a failure. Meanwhile, the previous
+// verifier is used if there is one.
+func (a *AuthService) getVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	a.mu.Lock()
+	for a.verifier == nil || time.Since(a.verifierCreated) >= a.jwksCacheDuration {
+		if a.discovering == nil && time.Now().Before(a.retryTime) {
+			verifier, err := a.verifier, a.discoveryErr
+			a.mu.Unlock()
+			if verifier != nil {
+				return verifier, nil
+			}
+			return nil, err
+		}
+		if a.discovering == nil {
+			return a.discover(ctx)
+		}
+		verifier, done := a.verifier, a.discovering
+		a.mu.Unlock()
+		if verifier != nil {
+			return verifier, nil
+		}
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		a.mu.Lock()
+	}
+	verifier := a.verifier
+	a.mu.Unlock()
+	return verifier, nil
+}
+
+// discover creates a new verifier, and records the outcome. a.mu must be held,
+// and is released.
+func (a *AuthService) discover(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	done := make(chan struct{})
+	a.discovering = done
+	a.mu.Unlock()
+
+	verifier, err := a.newVerifier(ctx)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.discovering = nil
+	close(done)
+	if err != nil {
+		a.backoff = min(max(2*a.backoff, minDiscoveryBackoff), maxDiscoveryBackoff)
+		a.retryTime = time.Now().Add(a.backoff)
+		a.discoveryErr = err
+		if a.verifier != nil {
+			return a.verifier, nil
+		}
+		return nil, err
+	}
+	a.verifier, a.verifierCreated = verifier, time.Now()
+	a.discoveryErr, a.retryTime, a.backoff = nil, time.Time{}, 0
+	return verifier, nil
+}
+
+func (a *AuthService) newVerifier(ctx context.Context) (*oidc.IDTokenVerifier, error) {
+	config := &oidc.Config{ClientID: a.Audience}
+	if a.jwksURL != "" {
+		// the key set outlives the request, so it must not use its context
+		keySet := oidc.NewRemoteKeySet(context.Background(), a.jwksURL)
+		return oidc.NewVerifier(a.Issuer, keySet, config), nil
+	}
+	// other requests wait for the discovery, so it isn't cancelled with the
+	// request that started it
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), discoveryTimeout)
+	defer cancel()
+	provider, err := oidc.NewProvider(ctx, a.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover OIDC issuer %q: %w", a.Issuer, err)
+	}
+	return provider.Verifier(config), nil
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth/oidc"
+)
+
+// newIssuer starts an OIDC issuer, and returns its URL and a function that
+// signs tokens with its key.
+func newIssuer(t *testing.T) (string, func(claims map[string]any) string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	jwk := jose.JSONWebKey{Key: key.Public(), KeyID: "my-key", Algorithm: "RS256", Use: "sig"}
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                srv.URL,
+			"jwks_uri":                              srv.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk}})
+	})
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: "my-key"}}, nil)
+	if err != nil {
+		t.Fatalf("unable to create signer: %s", err)
+	}
+	sign := func(claims map[string]any) string {
+		b, err := json.Marshal(claims)
+		if err != nil {
+			t.Fatalf("unable to marshal claims: %s", err)
+		}
+		jws, err := signer.Sign(b)
+		if err != nil {
+			t.Fatalf("unable to sign token: %s", err)
+		}
+		token, err := jws.CompactSerialize()
+		if err != nil {
+			t.Fatalf("unable to serialize token: %s", err)
+		}
+		return token
+	}
+	return srv.URL, sign
+}
+
+func TestGetClaimsFromHeader(t *testing.T) {
+	issuer, sign := newIssuer(t)
+	exp := float64(time.Now().Add(time.Hour).Unix())
+
+	tcs := []struct {
+		desc    string
+		cfg     oidc.Config
+		claims  map[string]any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			desc:   "discovered keys",
+			cfg:    oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: issuer, Audience: "my-api"},
+			claims: map[string]any{"iss": issuer, "aud": "my-api", "exp": exp, "sub": "alice", "email": "alice@example.com"},
+			want:   map[string]any{"iss": issuer, "aud": "my-api", "exp": exp, "sub": "alice", "email": "alice@example.com"},
+		},
+		{
+			desc:   "jwks url",
+			cfg:    oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: issuer, Audience: "my-api", JWKSURL: issuer + "/keys", JWKSCacheDuration: "5m"},
+			claims: map[string]any{"iss": issuer, "aud": "my-api", "exp": exp, "sub": "alice"},
+			want:   map[string]any{"iss": issuer, "aud": "my-api", "exp": exp, "sub": "alice"},
+		},
+		{
+			desc:    "wrong audience",
+			cfg:     oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: issuer, Audience: "my-api"},
+			claims:  map[string]any{"iss": issuer, "aud": "other-api", "exp": exp, "sub": "alice"},
+			wantErr: "OIDC token verification failure",
+		},
+		{
+			desc:    "wrong issuer",
+			cfg:     oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: issuer, Audience: "my-api"},
+			claims:  map[string]any{"iss": "https://other.example.com", "aud": "my-api", "exp": exp, "sub": "alice"},
+			wantErr: "OIDC token verification failure",
+		},
+		{
+			desc:    "expired",
+			cfg:     oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: issuer, Audience: "my-api"},
+			claims:  map[string]any{"iss": issuer, "aud": "my-api", "exp": float64(time.Now().Add(-time.Hour).Unix()), "sub": "alice"},
+			wantErr: "OIDC token verification failure",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := tc.cfg.Initialize()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			h := http.Header{}
+			h.Set("my-oidc_token", sign(tc.claims))
+			got, err := a.GetClaimsFromHeader(context.Background(), h)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect claims: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestDiscoveryBackoff(t *testing.T) {
+	var discoveries atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		discoveries.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cfg := oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: srv.URL, Audience: "my-api"}
+	a, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	h := http.Header{}
+	h.Set("my-oidc_token", "some-token")
+	// the issuer isn't discovered again right after a failed discovery
+	for range 3 {
+		if _, err := a.GetClaimsFromHeader(context.Background(), h); err == nil || !strings.Contains(err.Error(), "unable to discover OIDC issuer") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := discoveries.Load(); n != 1 {
+		t.Fatalf("unexpected number of discoveries: got %d, want 1", n)
+	}
+}
+
+func TestGetClaimsFromHeaderMissingToken(t *testing.T) {
+	cfg := oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: "https://issuer.example.com", Audience: "my-api"}
+	a, err := cfg.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	claims, err := a.GetClaimsFromHeader(context.Background(), http.Header{})
+	if err != nil || claims != nil {
+		t.Fatalf("expected no claims, got %v, %v", claims, err)
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  oidc.Config
+		err  string
+	}{
+		{
+			desc: "invalid issuer",
+			cfg:  oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: "issuer", Audience: "my-api"},
+			err:  `invalid issuer "issuer": parse "issuer": invalid URI for request`,
+		},
+		{
+			desc: "invalid jwksCacheDuration",
+			cfg:  oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: "https://issuer.example.com", Audience: "my-api", JWKSCacheDuration: "often"},
+			err:  `unable to parse jwksCacheDuration "often": time: invalid duration "often"`,
+		},
+		{
+			desc: "negative jwksCacheDuration",
+			cfg:  oidc.Config{Name: "my-oidc", Kind: oidc.AuthServiceKind, Issuer: "https://issuer.example.com", Audience: "my-api", JWKSCacheDuration: "-1m"},
+			err:  `jwksCacheDuration must be positive, got "-1m"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil {
+				t.Fatalf("expect initialization to fail")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
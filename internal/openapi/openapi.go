@@ -0,0 +1,616 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapi generates a tools file with an http tool for each operation
+// of an OpenAPI 3 document.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Options configures how a document is converted.
+type Options struct {
+	// SourceName is the name of the generated http source. Defaults to a name
+	// derived from the document's title.
+	SourceName string
+	// BaseURL overrides the first server URL of the document.
+	BaseURL string
+}
+
+// document is the subset of an OpenAPI 3 document used to generate tools.
+type document struct {
+	OpenAPI    string              `yaml:"openapi"`
+	Swagger    string              `yaml:"swagger"`
+	Info       info                `yaml:"info"`
+	Servers    []server            `yaml:"servers"`
+	Paths      map[string]pathItem `yaml:"paths"`
+	Components components          `yaml:"components"`
+	Security   []map[string][]string
+}
+
+type info struct {
+	Title string `yaml:"title"`
+}
+
+type server struct {
+	URL       string                    `yaml:"url"`
+	Variables map[string]serverVariable `yaml:"variables"`
+}
+
+type serverVariable struct {
+	Default string `yaml:"default"`
+}
+
+type components struct {
+	Schemas         map[string]*schema         `yaml:"schemas"`
+	Parameters      map[string]*parameter      `yaml:"parameters"`
+	RequestBodies   map[string]*requestBody    `yaml:"requestBodies"`
+	SecuritySchemes map[string]*securityScheme `yaml:"securitySchemes"`
+}
+
+type pathItem struct {
+	Parameters []*parameter `yaml:"parameters"`
+	Get        *operation   `yaml:"get"`
+	Put        *operation   `yaml:"put"`
+	Post       *operation   `yaml:"post"`
+	Delete     *operation   `yaml:"delete"`
+	Patch      *operation   `yaml:"patch"`
+}
+
+// operations returns the operations of the path by HTTP method.
+func (p pathItem) operations() map[string]*operation {
+	return map[string]*operation{
+		http.MethodGet:    p.Get,
+		http.MethodPut:    p.Put,
+		http.MethodPost:   p.Post,
+		http.MethodDelete: p.Delete,
+		http.MethodPatch:  p.Patch,
+	}
+}
+
+type operation struct {
+	OperationID string                 `yaml:"operationId"`
+	Summary     string                 `yaml:"summary"`
+	Description string                 `yaml:"description"`
+	Parameters  []*parameter           `yaml:"parameters"`
+	RequestBody *requestBody           `yaml:"requestBody"`
+	Security    *[]map[string][]string `yaml:"security"`
+}
+
+type parameter struct {
+	Ref         string  `yaml:"$ref"`
+	Name        string  `yaml:"name"`
+	In          string  `yaml:"in"`
+	Description string  `yaml:"description"`
+	Required    bool    `yaml:"required"`
+	Schema      *schema `yaml:"schema"`
+}
+
+type requestBody struct {
+	Ref      string               `yaml:"$ref"`
+	Required bool                 `yaml:"required"`
+	Content  map[string]mediaType `yaml:"content"`
+}
+
+type mediaType struct {
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref         string             `yaml:"$ref"`
+	Type        any                `yaml:"type"`
+	Description string             `yaml:"description"`
+	Enum        []any              `yaml:"enum"`
+	Items       *schema            `yaml:"items"`
+	Properties  map[string]*schema `yaml:"properties"`
+	Required    []string           `yaml:"required"`
+}
+
+// typeName returns the type of the schema. OpenAPI 3.1 allows a list of types,
+// such as ["string", "null"], of which the first non-null type is used.
+func (s *schema) typeName() string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if name, ok := v.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if len(s.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+type securityScheme struct {
+	Ref    string `yaml:"$ref"`
+	Type   string `yaml:"type"`
+	Scheme string `yaml:"scheme"`
+	Name   string `yaml:"name"`
+	In     string `yaml:"in"`
+}
+
+// toolsFile is the generated tools file.
+type toolsFile struct {
+	Sources  map[string]*sourceConfig `yaml:"sources"`
+	Tools    map[string]toolConfig    `yaml:"tools"`
+	Toolsets map[string][]string      `yaml:"toolsets"`
+}
+
+type sourceConfig struct {
+	Kind        string            `yaml:"kind"`
+	BaseURL     string            `yaml:"baseUrl"`
+	Headers     map[string]string `yaml:"headers,omitempty"`
+	QueryParams map[string]string `yaml:"queryParams,omitempty"`
+}
+
+type toolConfig struct {
+	Kind         string            `yaml:"kind"`
+	Source       string            `yaml:"source"`
+	Method       string            `yaml:"method"`
+	Path         string            `yaml:"path"`
+	Description  string            `yaml:"description"`
+	Headers      map[string]string `yaml:"headers,omitempty"`
+	PathParams   []toolParameter   `yaml:"pathParams,omitempty"`
+	QueryParams  []toolParameter   `yaml:"queryParams,omitempty"`
+	HeaderParams []toolParameter   `yaml:"headerParams,omitempty"`
+	BodyParams   []toolParameter   `yaml:"bodyParams,omitempty"`
+	RequestBody  string            `yaml:"requestBody,omitempty"`
+}
+
+type toolParameter struct {
+	Name        string         `yaml:"name"`
+	Type        string         `yaml:"type"`
+	Description string         `yaml:"description"`
+	Required    *bool          `yaml:"required,omitempty"`
+	Items       *toolParameter `yaml:"items,omitempty"`
+}
+
+// Convert generates a tools file from an OpenAPI 3 document, in YAML or JSON.
+// It returns warnings for the parts of the document that couldn't be
+// converted, such as operations with unsupported parameters.
+func Convert(spec []byte, opts Options) ([]byte, []string, error) {
+	var doc document
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse OpenAPI document: %w", err)
+	}
+	if doc.OpenAPI == "" {
+		if doc.Swagger != "" {
+			return nil, nil, fmt.Errorf("swagger %s documents aren't supported, convert the document to OpenAPI 3 first", doc.Swagger)
+		}
+		return nil, nil, fmt.Errorf("not an OpenAPI 3 document: missing 'openapi' field")
+	}
+
+	c := converter{doc: doc, sourceName: opts.SourceName}
+	if c.sourceName == "" {
+		c.sourceName = slug(doc.Info.Title)
+	}
+	if c.sourceName == "" {
+		c.sourceName = "openapi-source"
+	}
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		if len(doc.Servers) == 0 {
+			return nil, nil, fmt.Errorf("the document doesn't list any servers, set the base URL of the API")
+		}
+		baseURL = serverURL(doc.Servers[0])
+	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		return nil, nil, fmt.Errorf("base URL %q must be an absolute http or https URL", baseURL)
+	}
+
+	// the credentials of the document's security requirements are set on the
+	// source, while operations with their own requirements set them as headers
+	src := &sourceConfig{Kind: "http", BaseURL: strings.TrimSuffix(baseURL, "/")}
+	src.Headers, src.QueryParams = c.security(doc.Security)
+	out := toolsFile{
+		Sources: map[string]*sourceConfig{c.sourceName: src},
+		Tools:   make(map[string]toolConfig),
+	}
+	var toolNames []string
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	methods := []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	for _, path := range paths {
+		item := doc.Paths[path]
+		for _, method := range methods {
+			op := item.operations()[method]
+			if op == nil {
+				continue
+			}
+			name := c.toolName(op, method, path, out.Tools)
+			tool, err := c.tool(item, op, method, path)
+			if err != nil {
+				c.warn("skipped %s %s: %s", method, path, err)
+				continue
+			}
+			out.Tools[name] = tool
+			toolNames = append(toolNames, name)
+		}
+	}
+	if len(out.Tools) == 0 {
+		return nil, c.warnings, fmt.Errorf("no operations could be converted to tools")
+	}
+	out.Toolsets = map[string][]string{c.sourceName: toolNames}
+
+	b, err := yaml.MarshalWithOptions(out, yaml.IndentSequence(true), yaml.UseLiteralStyleIfMultiline(true))
+	if err != nil {
+		return nil, c.warnings, err
+	}
+	return b, c.warnings, nil
+}
+
+type converter struct {
+	doc        document
+	sourceName string
+	warnings   []string
+}
+
+func (c *converter) warn(format string, a ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, a...))
+}
+
+// warnOnce is warn, for warnings that would repeat for every operation.
+func (c *converter) warnOnce(format string, a ...any) {
+	msg := fmt.Sprintf(format, a...)
+	if !slices.Contains(c.warnings, msg) {
+		c.warnings = append(c.warnings, msg)
+	}
+}
+
+var pathParamRe = regexp.MustCompile(`\{([^}]+)\}`)
+var identRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+var nonNameRe = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+var envRe = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+var slugRe = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// toolName returns a unique tool name for an operation, from its operationId
+// or else its method and path.
+func (c *converter) toolName(op *operation, method, path string, existing map[string]toolConfig) string {
+	name := nonNameRe.ReplaceAllString(op.OperationID, "_")
+	if name == "" {
+		name = strings.ToLower(method) + "_" + strings.Trim(nonNameRe.ReplaceAllString(path, "_"), "_")
+	}
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := existing[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	if unique != name {
+		c.warn("renamed %s %s to %q, since a tool named %q already exists", method, path, unique, name)
+	}
+	return unique
+}
+
+func (c *converter) tool(item pathItem, op *operation, method, path string) (toolConfig, error) {
+	description := strings.TrimSpace(op.Summary)
+	if d := strings.TrimSpace(op.Description); d != "" && d != description {
+		description = strings.TrimSpace(description + "\n\n" + d)
+	}
+	if description == "" {
+		description = fmt.Sprintf("%s %s", method, path)
+	}
+	t := toolConfig{
+		Kind:        "http",
+		Source:      c.sourceName,
+		Method:      method,
+		Path:        pathParamRe.ReplaceAllStringFunc(path, func(m string) string { return templateField(m[1 : len(m)-1]) }),
+		Description: description,
+	}
+
+	// operation parameters override path parameters with the same name
+	params := make(map[string]*parameter)
+	var order []string
+	for _, p := range slices.Concat(item.Parameters, op.Parameters) {
+		p, err := c.parameter(p)
+		if err != nil {
+			return toolConfig{}, err
+		}
+		key := p.In + ":" + p.Name
+		if _, ok := params[key]; !ok {
+			order = append(order, key)
+		}
+		params[key] = p
+	}
+	seen := make(map[string]bool)
+	for _, key := range order {
+		p := params[key]
+		if p.In == "cookie" {
+			return toolConfig{}, fmt.Errorf("cookie parameter %q isn't supported", p.Name)
+		}
+		if seen[p.Name] {
+			return toolConfig{}, fmt.Errorf("parameter name %q is used more than once", p.Name)
+		}
+		seen[p.Name] = true
+		desc := p.Description
+		if desc == "" && p.Schema != nil {
+			desc = p.Schema.Description
+		}
+		if desc == "" {
+			desc = fmt.Sprintf("The %s %s parameter.", p.Name, p.In)
+		}
+		tp, err := c.toolParameter(p.Name, desc, p.Schema, p.Required || p.In == "path", 0)
+		if err != nil {
+			return toolConfig{}, err
+		}
+		switch p.In {
+		case "path":
+			t.PathParams = append(t.PathParams, tp)
+		case "query":
+			t.QueryParams = append(t.QueryParams, tp)
+		case "header":
+			t.HeaderParams = append(t.HeaderParams, tp)
+		default:
+			return toolConfig{}, fmt.Errorf("parameter %q has unknown location %q", p.Name, p.In)
+		}
+	}
+
+	if op.RequestBody != nil {
+		if err := c.body(&t, op.RequestBody, seen); err != nil {
+			return toolConfig{}, err
+		}
+	}
+	if op.Security != nil {
+		headers, queryParams := c.security(*op.Security)
+		if len(queryParams) > 0 {
+			return toolConfig{}, fmt.Errorf("query parameter credentials are only supported for the whole document")
+		}
+		for k, v := range headers {
+			setDefault(&t.Headers, k, v)
+		}
+	}
+	return t, nil
+}
+
+// body adds a body parameter for each property of a JSON request body.
+func (c *converter) body(t *toolConfig, rb *requestBody, seen map[string]bool) error {
+	rb, err := resolve(rb, rb.Ref, c.doc.Components.RequestBodies)
+	if err != nil {
+		return err
+	}
+	mt, ok := rb.Content["application/json"]
+	if !ok {
+		return fmt.Errorf("only application/json request bodies are supported")
+	}
+	s, err := c.schema(mt.Schema)
+	if err != nil {
+		return err
+	}
+	if s == nil || s.typeName() != "object" {
+		return fmt.Errorf("only object request bodies are supported")
+	}
+	if len(s.Properties) == 0 {
+		return fmt.Errorf("request bodies without properties aren't supported")
+	}
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		if seen[name] {
+			return fmt.Errorf("body property %q has the same name as a parameter", name)
+		}
+		prop, err := c.schema(s.Properties[name])
+		if err != nil {
+			return err
+		}
+		desc := prop.Description
+		if desc == "" {
+			desc = fmt.Sprintf("The %s property of the request body.", name)
+		}
+		required := rb.Required && slices.Contains(s.Required, name)
+		tp, err := c.toolParameter(name, desc, prop, required, 0)
+		if err != nil && !required {
+			c.warn("left out optional body property of %s %s: %s", t.Method, t.Path, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		t.BodyParams = append(t.BodyParams, tp)
+		fields = append(fields, fmt.Sprintf("  %q: %s", name, templateCall("json", name)))
+	}
+	t.Headers = map[string]string{"Content-Type": "application/json"}
+	t.RequestBody = "{\n" + strings.Join(fields, ",\n") + "\n}\n"
+	return nil
+}
+
+func (c *converter) parameter(p *parameter) (*parameter, error) {
+	return resolve(p, p.Ref, c.doc.Components.Parameters)
+}
+
+func (c *converter) schema(s *schema) (*schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return resolve(s, s.Ref, c.doc.Components.Schemas)
+}
+
+// toolParameter converts a schema to a tool parameter. Objects aren't
+// supported, since tool parameters can't describe them.
+func (c *converter) toolParameter(name, desc string, s *schema, required bool, depth int) (toolParameter, error) {
+	s, err := c.schema(s)
+	if err != nil {
+		return toolParameter{}, err
+	}
+	if s == nil {
+		s = &schema{Type: "string"}
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, 0, len(s.Enum))
+		for _, v := range s.Enum {
+			values = append(values, fmt.Sprint(v))
+		}
+		desc = fmt.Sprintf("%s. One of: %s.", strings.TrimSuffix(desc, "."), strings.Join(values, ", "))
+	}
+	tp := toolParameter{Name: name, Description: desc}
+	if !required {
+		tp.Required = new(bool)
+	}
+	switch s.typeName() {
+	case "string", "":
+		tp.Type = "string"
+	case "integer":
+		tp.Type = "integer"
+	case "number":
+		tp.Type = "float"
+	case "boolean":
+		tp.Type = "boolean"
+	case "array":
+		if depth > 0 {
+			return toolParameter{}, fmt.Errorf("nested array %q isn't supported", name)
+		}
+		itemDesc := fmt.Sprintf("An item of %s.", name)
+		if s.Items != nil && s.Items.Description != "" {
+			itemDesc = s.Items.Description
+		}
+		items, err := c.toolParameter(name, itemDesc, s.Items, true, depth+1)
+		if err != nil {
+			return toolParameter{}, err
+		}
+		tp.Type = "array"
+		tp.Items = &items
+	default:
+		return toolParameter{}, fmt.Errorf("%q is an %s, which isn't supported as a tool parameter", name, s.typeName())
+	}
+	return tp, nil
+}
+
+// security returns the headers and query parameters that pass the
+// credentials of a list of security requirements, as environment variables to
+// set.
+func (c *converter) security(reqs []map[string][]string) (headers, queryParams map[string]string) {
+	if len(reqs) == 0 {
+		return nil, nil
+	}
+	// only one of the alternative requirements needs to be met
+	names := make([]string, 0, len(reqs[0]))
+	for name := range reqs[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		scheme, ok := c.doc.Components.SecuritySchemes[name]
+		if !ok {
+			c.warnOnce("security scheme %q isn't defined", name)
+			continue
+		}
+		env := fmt.Sprintf("${%s}", strings.ToUpper(envRe.ReplaceAllString(c.sourceName+"_"+name, "_")))
+		switch {
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+			setDefault(&headers, "Authorization", "Bearer "+env)
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+			setDefault(&headers, "Authorization", "Basic "+env)
+		case scheme.Type == "apiKey" && scheme.In == "header":
+			setDefault(&headers, scheme.Name, env)
+		case scheme.Type == "apiKey" && scheme.In == "query":
+			setDefault(&queryParams, scheme.Name, env)
+		default:
+			c.warnOnce("security scheme %q of type %q must be configured manually", name, scheme.Type)
+		}
+	}
+	return headers, queryParams
+}
+
+func setDefault(m *map[string]string, k, v string) {
+	if *m == nil {
+		*m = make(map[string]string)
+	}
+	if _, ok := (*m)[k]; !ok {
+		(*m)[k] = v
+	}
+}
+
+// resolve follows a local reference, such as "#/components/schemas/Pet".
+func resolve[T any](v *T, ref string, defs map[string]*T) (*T, error) {
+	for i := 0; ref != ""; i++ {
+		if i > 10 {
+			return nil, fmt.Errorf("too many nested references at %q", ref)
+		}
+		idx := strings.LastIndex(ref, "/")
+		if !strings.HasPrefix(ref, "#/components/") || idx < 0 {
+			return nil, fmt.Errorf("reference %q isn't supported, only references within the document are", ref)
+		}
+		next, ok := defs[ref[idx+1:]]
+		if !ok {
+			return nil, fmt.Errorf("reference %q not found", ref)
+		}
+		v = next
+		ref = refOf(v)
+	}
+	return v, nil
+}
+
+func refOf(v any) string {
+	switch v := v.(type) {
+	case *schema:
+		return v.Ref
+	case *parameter:
+		return v.Ref
+	case *requestBody:
+		return v.Ref
+	}
+	return ""
+}
+
+// templateField returns the Go template action that inserts a parameter.
+func templateField(name string) string {
+	if identRe.MatchString(name) {
+		return fmt.Sprintf("{{.%s}}", name)
+	}
+	return fmt.Sprintf("{{index . %q}}", name)
+}
+
+// templateCall returns the Go template action that calls fn on a parameter.
+func templateCall(fn, name string) string {
+	if identRe.MatchString(name) {
+		return fmt.Sprintf("{{%s .%s}}", fn, name)
+	}
+	return fmt.Sprintf("{{%s (index . %q)}}", fn, name)
+}
+
+// serverURL returns the URL of a server, with its variables set to their
+// defaults.
+func serverURL(s server) string {
+	return pathParamRe.ReplaceAllStringFunc(s.URL, func(m string) string {
+		if v, ok := s.Variables[m[1:len(m)-1]]; ok {
+			return v.Default
+		}
+		return m
+	})
+}
+
+// slug returns a lowercase name made of letters, digits and dashes.
+func slug(s string) string {
+	s = strings.ToLower(slugRe.ReplaceAllString(s, "-"))
+	return strings.Trim(s, "-")
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvert(t *testing.T) {
+	spec, err := os.ReadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatalf("unable to read spec: %s", err)
+	}
+	want, err := os.ReadFile("testdata/petstore.tools.yaml")
+	if err != nil {
+		t.Fatalf("unable to read tools file: %s", err)
+	}
+	got, warnings, err := Convert(spec, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Fatalf("incorrect tools file: diff %v", diff)
+	}
+	wantWarnings := []string{
+		`left out optional body property of POST /pets: "owner" is an object, which isn't supported as a tool parameter`,
+		`skipped PUT /pets/{petId}/photo: only application/json request bodies are supported`,
+	}
+	if diff := cmp.Diff(wantWarnings, warnings); diff != "" {
+		t.Fatalf("incorrect warnings: diff %v", diff)
+	}
+}
+
+func TestConvertOptions(t *testing.T) {
+	spec := `{
+		"openapi": "3.1.0",
+		"info": {"title": "Example"},
+		"paths": {
+			"/items/{item-id}": {
+				"get": {
+					"parameters": [
+						{"name": "item-id", "in": "path", "required": true, "schema": {"type": ["string", "null"]}}
+					]
+				}
+			}
+		}
+	}`
+	got, _, err := Convert([]byte(spec), Options{SourceName: "my-api", BaseURL: "http://localhost:8080/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, want := range []string{
+		"  my-api:\n    kind: http\n    baseUrl: http://localhost:8080\n",
+		"  get_items_item-id:\n",
+		`    path: /items/{{index . "item-id"}}` + "\n",
+		"    description: GET /items/{item-id}\n",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Fatalf("expected tools file to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	tcs := []struct {
+		desc string
+		spec string
+		err  string
+	}{
+		{
+			desc: "swagger",
+			spec: `swagger: "2.0"`,
+			err:  "swagger 2.0 documents aren't supported, convert the document to OpenAPI 3 first",
+		},
+		{
+			desc: "no servers",
+			spec: "openapi: 3.0.0\npaths: {}",
+			err:  "the document doesn't list any servers, set the base URL of the API",
+		},
+		{
+			desc: "no operations",
+			spec: "openapi: 3.0.0\nservers: [{url: 'https://example.com'}]\npaths: {}",
+			err:  "no operations could be converted to tools",
+		},
+		{
+			desc: "relative server",
+			spec: "openapi: 3.0.0\nservers: [{url: '/v1'}]\npaths: {}",
+			err:  `base URL "/v1" must be an absolute http or https URL`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, err := Convert([]byte(tc.spec), Options{})
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prompts implements prompt templates that are served to MCP clients.
+package prompts
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// PromptConfig is a prompt template, as declared in the `prompts` section of
+// the tools file.
+type PromptConfig struct {
+	Name        string           `yaml:"name"`
+	Description string           `yaml:"description"`
+	Arguments   []ArgumentConfig `yaml:"arguments" validate:"dive"`
+	Messages    []MessageConfig  `yaml:"messages" validate:"required,min=1,dive"`
+}
+
+// ArgumentConfig is an argument that is substituted into the messages of a
+// prompt.
+type ArgumentConfig struct {
+	Name        string `yaml:"name" validate:"required"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+// MessageConfig is a message of a prompt. Content is a Go template, which can
+// refer to the arguments of the prompt, such as `{{.query}}`.
+type MessageConfig struct {
+	Role    string `yaml:"role"`
+	Content string `yaml:"content" validate:"required"`
+}
+
+// Prompt is an initialized prompt template.
+type Prompt struct {
+	Name        string
+	Description string
+	Arguments   []ArgumentConfig
+	messages    []message
+	manifest    McpManifest
+}
+
+type message struct {
+	role    string
+	content *template.Template
+}
+
+// Message is a rendered message of a prompt.
+type Message struct {
+	Role string
+	Text string
+}
+
+// McpManifest is the definition of a prompt served to MCP clients.
+type McpManifest struct {
+	// The name of the prompt.
+	Name string `json:"name"`
+	// An optional description of what this prompt provides.
+	Description string `json:"description,omitempty"`
+	// A list of arguments to use for templating the prompt.
+	Arguments []ArgumentMcpManifest `json:"arguments,omitempty"`
+}
+
+// ArgumentMcpManifest describes an argument that a prompt can accept.
+type ArgumentMcpManifest struct {
+	// The name of the argument.
+	Name string `json:"name"`
+	// A human-readable description of the argument.
+	Description string `json:"description,omitempty"`
+	// Whether this argument must be provided.
+	Required bool `json:"required,omitempty"`
+}
+
+// Initialize validates the prompt and parses its message templates.
+func (c PromptConfig) Initialize() (Prompt, error) {
+	if !tools.IsValidName(c.Name) {
+		return Prompt{}, fmt.Errorf("invalid prompt name: %q", c.Name)
+	}
+	p := Prompt{
+		Name:        c.Name,
+		Description: c.Description,
+		Arguments:   c.Arguments,
+		manifest:    McpManifest{Name: c.Name, Description: c.Description},
+	}
+	var names []string
+	for _, a := range c.Arguments {
+		if slices.Contains(names, a.Name) {
+			return Prompt{}, fmt.Errorf("duplicate argument %q", a.Name)
+		}
+		names = append(names, a.Name)
+		p.manifest.Arguments = append(p.manifest.Arguments, ArgumentMcpManifest(a))
+	}
+	for i, m := range c.Messages {
+		role := strings.ToLower(m.Role)
+		switch role {
+		case "":
+			role = RoleUser
+		case RoleUser, RoleAssistant:
+		default:
+			return Prompt{}, fmt.Errorf("invalid role %q for message %d: must be one of %q or %q", m.Role, i, RoleUser, RoleAssistant)
+		}
+		// arguments that aren't provided render as empty strings
+		tmpl, err := template.New(fmt.Sprintf("%s/%d", c.Name, i)).Option("missingkey=zero").Parse(m.Content)
+		if err != nil {
+			return Prompt{}, fmt.Errorf("unable to parse message %d: %w", i, err)
+		}
+		p.messages = append(p.messages, message{role: role, content: tmpl})
+	}
+	return p, nil
+}
+
+// McpManifest returns the definition of the prompt served to MCP clients.
+func (p Prompt) McpManifest() McpManifest {
+	return p.manifest
+}
+
+// Render substitutes the arguments into the messages of the prompt. Every
+// required argument must be provided, and unknown arguments are rejected.
+func (p Prompt) Render(args map[string]string) ([]Message, error) {
+	data := make(map[string]string, len(p.Arguments))
+	for _, a := range p.Arguments {
+		v, ok := args[a.Name]
+		if !ok && a.Required {
+			return nil, fmt.Errorf("argument %q is required", a.Name)
+		}
+		data[a.Name] = v
+	}
+	for name := range args {
+		if _, ok := data[name]; !ok {
+			return nil, fmt.Errorf("unknown argument %q", name)
+		}
+	}
+
+	messages := make([]Message, 0, len(p.messages))
+	for _, m := range p.messages {
+		var b strings.Builder
+		if err := m.content.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("unable to render prompt: %w", err)
+		}
+		messages = append(messages, Message{Role: m.role, Text: b.String()})
+	}
+	return messages, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prompts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+)
+
+func TestPromptInitializeErrors(t *testing.T) {
+	tcs := []struct {
+		name string
+		cfg  prompts.PromptConfig
+		err  string
+	}{
+		{
+			name: "invalid name",
+			cfg:  prompts.PromptConfig{Name: "my prompt", Messages: []prompts.MessageConfig{{Content: "hello"}}},
+			err:  `invalid prompt name: "my prompt"`,
+		},
+		{
+			name: "duplicate argument",
+			cfg: prompts.PromptConfig{
+				Name:      "my_prompt",
+				Arguments: []prompts.ArgumentConfig{{Name: "a"}, {Name: "a"}},
+				Messages:  []prompts.MessageConfig{{Content: "hello"}},
+			},
+			err: `duplicate argument "a"`,
+		},
+		{
+			name: "invalid role",
+			cfg:  prompts.PromptConfig{Name: "my_prompt", Messages: []prompts.MessageConfig{{Role: "system", Content: "hello"}}},
+			err:  `invalid role "system" for message 0: must be one of "user" or "assistant"`,
+		},
+		{
+			name: "invalid template",
+			cfg:  prompts.PromptConfig{Name: "my_prompt", Messages: []prompts.MessageConfig{{Content: "{{.a"}}},
+			err:  "unable to parse message 0:",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.cfg.Initialize()
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want prefix %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestPromptRender(t *testing.T) {
+	p, err := prompts.PromptConfig{
+		Name:        "investigate_slow_query",
+		Description: "Investigate why a query is slow.",
+		Arguments: []prompts.ArgumentConfig{
+			{Name: "query", Description: "The slow query.", Required: true},
+			{Name: "table"},
+		},
+		Messages: []prompts.MessageConfig{
+			{Content: "Explain why {{.query}} is slow.{{if .table}} It reads {{.table}}.{{end}}"},
+			{Role: "Assistant", Content: "I will look at its query plan."},
+		},
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wantManifest := prompts.McpManifest{
+		Name:        "investigate_slow_query",
+		Description: "Investigate why a query is slow.",
+		Arguments: []prompts.ArgumentMcpManifest{
+			{Name: "query", Description: "The slow query.", Required: true},
+			{Name: "table"},
+		},
+	}
+	if diff := cmp.Diff(wantManifest, p.McpManifest()); diff != "" {
+		t.Fatalf("unexpected manifest: diff %v", diff)
+	}
+
+	tcs := []struct {
+		name string
+		args map[string]string
+		want []prompts.Message
+		err  string
+	}{
+		{
+			name: "required argument",
+			args: map[string]string{"query": "SELECT 1"},
+			want: []prompts.Message{
+				{Role: prompts.RoleUser, Text: "Explain why SELECT 1 is slow."},
+				{Role: prompts.RoleAssistant, Text: "I will look at its query plan."},
+			},
+		},
+		{
+			name: "optional argument",
+			args: map[string]string{"query": "SELECT 1", "table": "users"},
+			want: []prompts.Message{
+				{Role: prompts.RoleUser, Text: "Explain why SELECT 1 is slow. It reads users."},
+				{Role: prompts.RoleAssistant, Text: "I will look at its query plan."},
+			},
+		},
+		{
+			name: "missing required argument",
+			args: map[string]string{"table": "users"},
+			err:  `argument "query" is required`,
+		},
+		{
+			name: "unknown argument",
+			args: map[string]string{"query": "SELECT 1", "limit": "10"},
+			err:  `unknown argument "limit"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := p.Render(tc.args)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected messages: diff %v", diff)
+			}
+		})
+	}
+}
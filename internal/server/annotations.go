@@ -0,0 +1,65 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// popAnnotations removes the annotations field from a raw tool config and
+// returns it.
+func popAnnotations(ctx context.Context, v map[string]any) (*tools.ToolAnnotations, error) {
+	raw, ok := v["annotations"]
+	if !ok {
+		return nil, nil
+	}
+	delete(v, "annotations")
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return nil, err
+	}
+	var a tools.ToolAnnotations
+	if err := dec.DecodeContext(ctx, &a); err != nil {
+		return nil, err
+	}
+	if a.ReadOnlyHint != nil && *a.ReadOnlyHint && a.DestructiveHint != nil && *a.DestructiveHint {
+		return nil, fmt.Errorf("a tool with 'readOnlyHint' can't also set 'destructiveHint'")
+	}
+	return &a, nil
+}
+
+var _ tools.Tool = annotatedTool{}
+
+// annotatedTool adds the annotations from its config to the MCP manifest of
+// the wrapped tool.
+type annotatedTool struct {
+	tools.Tool
+	annotations tools.ToolAnnotations
+}
+
+func newAnnotatedTool(tool tools.Tool, annotations tools.ToolAnnotations) annotatedTool {
+	return annotatedTool{Tool: tool, annotations: annotations}
+}
+
+func (t annotatedTool) McpManifest() tools.McpManifest {
+	m := t.Tool.McpManifest()
+	a := t.annotations
+	m.Annotations = &a
+	return m
+}
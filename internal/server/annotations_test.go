@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestPopAnnotations(t *testing.T) {
+	v := map[string]any{
+		"kind": "mock",
+		"annotations": map[string]any{
+			"title":          "List Users",
+			"readOnlyHint":   true,
+			"openWorldHint":  false,
+			"idempotentHint": true,
+		},
+	}
+	got, err := popAnnotations(context.Background(), v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	yes, no := true, false
+	want := &tools.ToolAnnotations{Title: "List Users", ReadOnlyHint: &yes, IdempotentHint: &yes, OpenWorldHint: &no}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect annotations: diff %v", diff)
+	}
+	if _, ok := v["annotations"]; ok {
+		t.Fatalf("annotations were not removed from config")
+	}
+
+	got, err = popAnnotations(context.Background(), map[string]any{"kind": "mock"})
+	if err != nil || got != nil {
+		t.Fatalf("expected no annotations, got %v, %v", got, err)
+	}
+
+	for _, val := range []any{
+		map[string]any{"readOnly": true},
+		map[string]any{"readOnlyHint": "yes"},
+		map[string]any{"readOnlyHint": true, "destructiveHint": true},
+	} {
+		if _, err := popAnnotations(context.Background(), map[string]any{"annotations": val}); err == nil {
+			t.Fatalf("expected error for annotations %v", val)
+		}
+	}
+}
+
+func TestAnnotatedToolMcpManifest(t *testing.T) {
+	no := false
+	tool := newAnnotatedTool(tool1, tools.ToolAnnotations{DestructiveHint: &no})
+	b, err := json.Marshal(tool.McpManifest())
+	if err != nil {
+		t.Fatalf("unable to marshal manifest: %s", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unable to unmarshal manifest: %s", err)
+	}
+	// hints that are set to false are still sent, since they differ from the
+	// defaults of the specification
+	want := map[string]any{"destructiveHint": false}
+	if diff := cmp.Diff(want, got["annotations"]); diff != "" {
+		t.Fatalf("incorrect annotations: diff %v", diff)
+	}
+}
@@ -15,7 +15,9 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -43,6 +46,18 @@ func apiRouter(s *Server) (chi.Router, error) {
 	r.Route("/tool/{toolName}", func(r chi.Router) {
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) { toolGetHandler(s, w, r) })
 		r.Post("/invoke", func(w http.ResponseWriter, r *http.Request) { toolInvokeHandler(s, w, r) })
+		r.Post("/invoke:async", func(w http.ResponseWriter, r *http.Request) { toolInvokeAsyncHandler(s, w, r) })
+		r.Post("/invoke/stream", func(w http.ResponseWriter, r *http.Request) { toolInvokeStreamHandler(s, w, r) })
+		r.Post("/invoke/{invocationId}/cancel", func(w http.ResponseWriter, r *http.Request) { toolInvokeCancelHandler(s, w, r) })
+	})
+
+	r.Get("/operations/{operationId}", func(w http.ResponseWriter, r *http.Request) { operationGetHandler(s, w, r) })
+
+	r.Route("/approvals", func(r chi.Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { approvalListHandler(s, w, r) })
+		r.Get("/{approvalId}", func(w http.ResponseWriter, r *http.Request) { approvalGetHandler(s, w, r) })
+		r.Post("/{approvalId}/approve", func(w http.ResponseWriter, r *http.Request) { approvalDecideHandler(s, w, r, true) })
+		r.Post("/{approvalId}/deny", func(w http.ResponseWriter, r *http.Request) { approvalDecideHandler(s, w, r, false) })
 	})
 
 	return r, nil
@@ -82,7 +97,14 @@ func toolsetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
-	render.JSON(w, r, toolset.Manifest)
+	claimsFromAuth := claimsFromHeader(ctx, s, r.Header)
+	if !toolset.Authorized(claimsFromAuth) {
+		err = fmt.Errorf("toolset %q not authorized. Please make sure your specify correct auth headers", toolsetName)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
+		return
+	}
+	render.JSON(w, r, s.ResourceMgr.authorizedToolset(toolset, claimsFromAuth).Manifest)
 }
 
 // toolGetHandler handles requests for a single Tool.
@@ -118,6 +140,12 @@ func toolGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
+	if !s.ResourceMgr.toolAuthorized(toolName, claimsFromHeader(ctx, s, r.Header)) {
+		err = fmt.Errorf("tool %q not authorized. Please make sure your specify correct auth headers", toolName)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
+		return
+	}
 	// TODO: this can be optimized later with some caching
 	m := tools.ToolsetManifest{
 		ServerVersion: s.version,
@@ -157,30 +185,443 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		)
 	}()
 
-	tool, ok := s.ResourceMgr.GetTool(toolName)
+	ctx, tool, params, code, err := parseToolInvocation(ctx, s, r, toolName)
+	if err != nil {
+		_ = render.Render(w, r, newErrResponse(err, code))
+		return
+	}
+
+	ctx, invocationId, done, err := s.invocationManager.start(ctx, r.Header.Get(invocationIdHeader), toolName)
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusConflict))
+		return
+	}
+	defer done()
+	span.SetAttributes(attribute.String("invocation_id", invocationId))
+	w.Header().Set(invocationIdHeader, invocationId)
+
+	if rowsRequested(r.Header) {
+		err = invokeStreamingRows(ctx, w, r, tool, params)
+		if err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+		}
+		return
+	}
+
+	res, err := tool.Invoke(ctx, params)
+	if err != nil {
+		if ctx.Err() != nil {
+			err = errInvocationCancelled
+		}
+		err = fmt.Errorf("error while invoking tool: %w", err)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, invokeErrorStatus(err)))
+		return
+	}
+
+	resMarshal, err := marshalResult(res)
+	if err != nil {
+		err = fmt.Errorf("unable to marshal result: %w", err)
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+
+	if _, ok := res.(pendingApproval); ok {
+		// the tool runs once a human approves the invocation
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, resultResponse{Result: string(resMarshal)})
+		return
+	}
+	_ = render.Render(w, r, &resultResponse{Result: string(resMarshal), Schema: resultSchema(ctx)})
+}
+
+// toolInvokeAsyncHandler handles the API request to invoke a specific Tool in
+// the background. It responds immediately with an operation that can be
+// polled through the operations endpoint.
+func toolInvokeAsyncHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/invoke_async")
+	r = r.WithContext(ctx)
+	ctx = util.WithLogger(r.Context(), s.logger)
+
+	toolName := chi.URLParam(r, "toolName")
+	s.logger.DebugContext(ctx, fmt.Sprintf("tool name: %s", toolName))
+	span.SetAttributes(attribute.String("tool_name", toolName))
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		s.instrumentation.ToolInvoke.Add(
+			r.Context(),
+			1,
+			metric.WithAttributes(attribute.String("toolbox.name", toolName)),
+			metric.WithAttributes(attribute.String("toolbox.operation.status", status)),
+		)
+	}()
+
+	ctx, tool, params, code, err := parseToolInvocation(ctx, s, r, toolName)
+	if err != nil {
+		_ = render.Render(w, r, newErrResponse(err, code))
+		return
+	}
+
+	operationId, err := s.operationManager.start(ctx, toolName)
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusTooManyRequests))
+		return
+	}
+	span.SetAttributes(attribute.String("operation_id", operationId))
+	s.logger.DebugContext(ctx, fmt.Sprintf("started operation %s", operationId))
+
+	// the invocation outlives the request, so it must not be canceled with it
+	invokeCtx, _, done, err := s.invocationManager.start(context.WithoutCancel(ctx), operationId, toolName)
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		s.operationManager.finish(operationId, "", err)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+	w.Header().Set(invocationIdHeader, operationId)
+	invokeCtx = util.WithProgressReporter(invokeCtx, func(progress, total float64, message string) {
+		s.operationManager.progress(operationId, progressResponse{Progress: progress, Total: total, Message: message})
+	})
+	go func() {
+		defer done()
+		res, err := tool.Invoke(invokeCtx, params)
+		if err != nil {
+			if invokeCtx.Err() != nil {
+				err = errInvocationCancelled
+			}
+			err = fmt.Errorf("error while invoking tool: %w", err)
+			s.logger.DebugContext(invokeCtx, err.Error())
+			s.operationManager.finish(operationId, "", err)
+			return
+		}
+		resMarshal, err := marshalResult(res)
+		if err != nil {
+			err = fmt.Errorf("unable to marshal result: %w", err)
+			s.logger.DebugContext(invokeCtx, err.Error())
+			s.operationManager.finish(operationId, "", err)
+			return
+		}
+		s.operationManager.finish(operationId, string(resMarshal), nil)
+	}()
+
+	op, _ := s.operationManager.get(ctx, operationId)
+	_ = render.Render(w, r, &op)
+}
+
+// toolInvokeStreamHandler handles the API request to invoke a specific Tool and
+// streams progress updates reported by the tool to the client as Server-Sent
+// Events. The stream ends with a single "result" or "error" event.
+func toolInvokeStreamHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/invoke_stream")
+	r = r.WithContext(ctx)
+	ctx = util.WithLogger(r.Context(), s.logger)
+
+	toolName := chi.URLParam(r, "toolName")
+	s.logger.DebugContext(ctx, fmt.Sprintf("tool name: %s", toolName))
+	span.SetAttributes(attribute.String("tool_name", toolName))
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		s.instrumentation.ToolInvoke.Add(
+			r.Context(),
+			1,
+			metric.WithAttributes(attribute.String("toolbox.name", toolName)),
+			metric.WithAttributes(attribute.String("toolbox.operation.status", status)),
+		)
+	}()
+
+	ctx, tool, params, code, err := parseToolInvocation(ctx, s, r, toolName)
+	if err != nil {
+		_ = render.Render(w, r, newErrResponse(err, code))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
 	if !ok {
-		err = fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		err = fmt.Errorf("unable to retrieve flusher for sse")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+
+	ctx, invocationId, done, err := s.invocationManager.start(ctx, r.Header.Get(invocationIdHeader), toolName)
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusConflict))
+		return
+	}
+	defer done()
+	span.SetAttributes(attribute.String("invocation_id", invocationId))
+
+	w.Header().Set(invocationIdHeader, invocationId)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// progress is reported from the invocation goroutine, and written to the
+	// client from this one to ensure only a single event is written at once
+	progressQueue := make(chan progressResponse, 100)
+	invokeCtx := util.WithProgressReporter(ctx, func(progress, total float64, message string) {
+		select {
+		case progressQueue <- progressResponse{Progress: progress, Total: total, Message: message}:
+		case <-ctx.Done():
+		}
+	})
+
+	var res any
+	var invokeErr error
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		res, invokeErr = tool.Invoke(invokeCtx, params)
+	}()
+
+	clientClose := r.Context().Done()
+	for {
+		select {
+		case p := <-progressQueue:
+			writeSseEvent(w, flusher, "progress", p)
+		case <-finished:
+			// flush any progress reported right before the invocation finished
+			for len(progressQueue) > 0 {
+				writeSseEvent(w, flusher, "progress", <-progressQueue)
+			}
+			if invokeErr != nil {
+				if ctx.Err() != nil {
+					invokeErr = errInvocationCancelled
+				}
+				err = fmt.Errorf("error while invoking tool: %w", invokeErr)
+				s.logger.DebugContext(ctx, err.Error())
+				writeSseEvent(w, flusher, "error", newErrResponse(err, invokeErrorStatus(err)))
+				return
+			}
+			var resMarshal []byte
+			resMarshal, err = marshalResult(res)
+			if err != nil {
+				err = fmt.Errorf("unable to marshal result: %w", err)
+				s.logger.DebugContext(ctx, err.Error())
+				writeSseEvent(w, flusher, "error", newErrResponse(err, http.StatusInternalServerError))
+				return
+			}
+			writeSseEvent(w, flusher, "result", resultResponse{Result: string(resMarshal), Schema: resultSchema(ctx)})
+			return
+		case <-clientClose:
+			// canceling the request context also cancels the invocation
+			s.logger.DebugContext(ctx, "client disconnected")
+			return
+		}
+	}
+}
+
+// invokeErrorStatus returns the HTTP status code for a failed invocation.
+func invokeErrorStatus(err error) int {
+	if errors.Is(err, errRateLimited) || errors.Is(err, errTooManyInvocations) {
+		return http.StatusTooManyRequests
+	}
+	if errors.Is(err, errCircuitOpen) || errors.Is(err, errUnavailable) {
+		return http.StatusServiceUnavailable
+	}
+	if errors.Is(err, errToolTimeout) {
+		return http.StatusGatewayTimeout
+	}
+	var violation *sqlpolicy.ViolationError
+	if errors.As(err, &violation) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
+
+// writeSseEvent writes a single JSON encoded Server-Sent Event to the client.
+func writeSseEvent(w http.ResponseWriter, flusher http.Flusher, event string, data any) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		b, _ = json.Marshal(newErrResponse(err, http.StatusInternalServerError))
+		event = "error"
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+	flusher.Flush()
+}
+
+// toolInvokeCancelHandler handles the API request to cancel a running
+// invocation of a specific Tool.
+func toolInvokeCancelHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/tool/invoke_cancel")
+	r = r.WithContext(ctx)
+	defer span.End()
+
+	toolName := chi.URLParam(r, "toolName")
+	invocationId := chi.URLParam(r, "invocationId")
+	span.SetAttributes(attribute.String("tool_name", toolName))
+	span.SetAttributes(attribute.String("invocation_id", invocationId))
+
+	if !s.invocationManager.cancel(callerFromHeader(ctx, s, r.Header), invocationId, toolName) {
+		err := fmt.Errorf("no running invocation %q for tool %q", invocationId, toolName)
+		span.SetStatus(codes.Error, err.Error())
 		s.logger.DebugContext(ctx, err.Error())
 		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
 		return
 	}
+	s.logger.DebugContext(ctx, fmt.Sprintf("cancelled invocation %s", invocationId))
+	_ = render.Render(w, r, &cancelResponse{InvocationId: invocationId})
+}
 
-	// Tool authentication
-	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
-	claimsFromAuth := make(map[string]map[string]any)
-	for _, aS := range s.ResourceMgr.GetAuthServiceMap() {
-		claims, err := aS.GetClaimsFromHeader(ctx, r.Header)
+// operationGetHandler handles requests for the state of a background
+// invocation.
+func operationGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/operation/get")
+	r = r.WithContext(ctx)
+	defer span.End()
+
+	operationId := chi.URLParam(r, "operationId")
+	span.SetAttributes(attribute.String("operation_id", operationId))
+
+	// operations of other callers are reported as not existing
+	op, ok := s.operationManager.get(callerFromHeader(ctx, s, r.Header), operationId)
+	if !ok {
+		err := fmt.Errorf("operation %q does not exist", operationId)
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	_ = render.Render(w, r, &op)
+}
+
+// approvalListHandler handles the API request to list the approvals, which
+// can be filtered with the `status` query parameter.
+func approvalListHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/approval/list")
+	r = r.WithContext(ctx)
+	defer span.End()
+
+	if _, err := s.approvalManager.authorizeReviewer(claimsFromHeader(ctx, s, r.Header)); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
+		return
+	}
+	render.JSON(w, r, map[string]any{"approvals": s.approvalManager.list(r.URL.Query().Get("status"))})
+}
+
+// approvalGetHandler handles the API request for the state of an approval.
+// Besides reviewers, the caller that requested the approval can retrieve it to
+// follow its invocation.
+func approvalGetHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/approval/get")
+	r = r.WithContext(ctx)
+	defer span.End()
+
+	approvalId := chi.URLParam(r, "approvalId")
+	span.SetAttributes(attribute.String("approval_id", approvalId))
+
+	callerCtx := callerFromHeader(ctx, s, r.Header)
+	var a approval
+	var ok bool
+	if _, err := s.approvalManager.authorizeReviewer(util.ClaimsFromContext(callerCtx)); err == nil {
+		a, ok = s.approvalManager.get(approvalId)
+	} else if a, ok = s.approvalManager.getRequested(callerCtx, approvalId); !ok {
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
+		return
+	}
+	if !ok {
+		err := fmt.Errorf("approval %q does not exist", approvalId)
+		span.SetStatus(codes.Error, err.Error())
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	_ = render.Render(w, r, &a)
+}
+
+// approvalDecideHandler handles the API request to approve or deny an
+// invocation. The body may give the reason of the decision.
+func approvalDecideHandler(s *Server, w http.ResponseWriter, r *http.Request, approve bool) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/approval/decide")
+	r = r.WithContext(ctx)
+	defer span.End()
+
+	approvalId := chi.URLParam(r, "approvalId")
+	span.SetAttributes(attribute.String("approval_id", approvalId))
+	span.SetAttributes(attribute.Bool("approved", approve))
+	var err error
+	defer func() {
 		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
 			s.logger.DebugContext(ctx, err.Error())
-			continue
 		}
-		if claims == nil {
-			// authService not present in header
-			continue
+	}()
+
+	reviewer, err := s.approvalManager.authorizeReviewer(claimsFromHeader(ctx, s, r.Header))
+	if err != nil {
+		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
+		return
+	}
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err = util.DecodeJSON(r.Body, &body); err != nil {
+			err = fmt.Errorf("unable to parse request body: %w", err)
+			_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
+			return
 		}
-		claimsFromAuth[aS.GetName()] = claims
 	}
 
+	a, err := s.approvalManager.decide(approvalId, approve, reviewer, body.Reason)
+	switch {
+	case errors.Is(err, errApprovalNotFound):
+		err = fmt.Errorf("approval %q does not exist", approvalId)
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	case errors.Is(err, errSelfApproval):
+		_ = render.Render(w, r, newErrResponse(err, http.StatusForbidden))
+		return
+	case err != nil:
+		_ = render.Render(w, r, newErrResponse(err, http.StatusConflict))
+		return
+	}
+	_ = render.Render(w, r, &a)
+}
+
+// parseToolInvocation looks up the tool, verifies the caller is authorized to
+// invoke it, and parses the parameters from the request body. On failure, it
+// returns the HTTP status code that should be sent to the client.
+func parseToolInvocation(ctx context.Context, s *Server, r *http.Request, toolName string) (context.Context, tools.Tool, tools.ParamValues, int, error) {
+	tool, ok := s.ResourceMgr.GetTool(toolName)
+	if !ok {
+		err := fmt.Errorf("invalid tool name: tool with name %q does not exist", toolName)
+		s.logger.DebugContext(ctx, err.Error())
+		return ctx, nil, nil, http.StatusNotFound, err
+	}
+
+	// Tool authentication
+	// claimsFromAuth maps the name of the authservice to the claims retrieved from it.
+	claimsFromAuth := claimsFromHeader(ctx, s, r.Header)
+
 	// Tool authorization check
 	verifiedAuthServices := make([]string, len(claimsFromAuth))
 	i := 0
@@ -192,55 +633,77 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	// Check if any of the specified auth services is verified
 	isAuthorized := tool.Authorized(verifiedAuthServices)
 	if !isAuthorized {
-		err = fmt.Errorf("tool invocation not authorized. Please make sure your specify correct auth headers")
+		err := fmt.Errorf("tool invocation not authorized. Please make sure your specify correct auth headers")
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusUnauthorized))
-		return
+		return ctx, nil, nil, http.StatusUnauthorized, err
+	}
+	// Toolset policy check
+	if !s.ResourceMgr.toolAuthorized(toolName, claimsFromAuth) {
+		err := fmt.Errorf("tool invocation not authorized by any toolset containing %q. Please make sure your specify correct auth headers", toolName)
+		s.logger.DebugContext(ctx, err.Error())
+		return ctx, nil, nil, http.StatusUnauthorized, err
 	}
 	s.logger.DebugContext(ctx, "tool invocation authorized")
 
 	var data map[string]any
-	if err = util.DecodeJSON(r.Body, &data); err != nil {
-		render.Status(r, http.StatusBadRequest)
+	if err := util.DecodeJSON(r.Body, &data); err != nil {
 		err = fmt.Errorf("request body was invalid JSON: %w", err)
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
-		return
+		return ctx, nil, nil, http.StatusBadRequest, err
 	}
 
 	params, err := tool.ParseParams(data, claimsFromAuth)
 	if err != nil {
 		err = fmt.Errorf("provided parameters were invalid: %w", err)
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
-		return
+		return ctx, nil, nil, http.StatusBadRequest, err
 	}
 	s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
-
-	res, err := tool.Invoke(ctx, params)
+	dryRun, err := dryRunRequested(r.Header)
 	if err != nil {
-		err = fmt.Errorf("error while invoking tool: %w", err)
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusBadRequest))
-		return
+		return ctx, nil, nil, http.StatusBadRequest, err
 	}
-
-	resMarshal, err := json.Marshal(res)
+	format, err := resultFormatRequested(r.Header)
 	if err != nil {
-		err = fmt.Errorf("unable to marshal result: %w", err)
 		s.logger.DebugContext(ctx, err.Error())
-		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
-		return
+		return ctx, nil, nil, http.StatusBadRequest, err
+	}
+	includeSchema, err := schemaRequested(r.Header)
+	if err != nil {
+		s.logger.DebugContext(ctx, err.Error())
+		return ctx, nil, nil, http.StatusBadRequest, err
+	}
+	ctx = withApprovals(util.WithClaims(ctx, claimsFromAuth), s.approvalManager)
+	ctx = util.WithAccessTokens(ctx, accessTokensFromHeader(ctx, s, r.Header))
+	if dryRun {
+		ctx = withDryRun(ctx)
+	}
+	if format != "" {
+		ctx = withResultFormat(ctx, format)
 	}
+	if includeSchema {
+		ctx = util.WithSchema(ctx, &util.Schema{})
+	}
+	return ctx, tool, params, http.StatusOK, nil
+}
 
-	_ = render.Render(w, r, &resultResponse{Result: string(resMarshal)})
+// marshalResult returns the result of a tool invocation as it is sent to the
+// client. Results already serialized as text, such as CSV, are sent as they
+// are rather than as a JSON string.
+func marshalResult(res any) ([]byte, error) {
+	if text, ok := res.(tools.TextResult); ok {
+		return []byte(text), nil
+	}
+	return json.Marshal(res)
 }
 
 var _ render.Renderer = &resultResponse{} // Renderer interface for managing response payloads.
 
 // resultResponse is the response sent back when the tool was invocated successfully.
 type resultResponse struct {
-	Result string `json:"result"` // result of tool invocation
+	Result string        `json:"result"`           // result of tool invocation
+	Schema []util.Column `json:"schema,omitempty"` // columns of the rows of the result, if requested
 }
 
 // Render renders a single payload and respond to the client request.
@@ -249,17 +712,42 @@ func (rr resultResponse) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+var _ render.Renderer = &cancelResponse{} // Renderer interface for managing response payloads.
+
+// cancelResponse is the response sent back when an invocation was cancelled.
+type cancelResponse struct {
+	InvocationId string `json:"invocationId"`
+}
+
+// Render renders a single payload and respond to the client request.
+func (cr cancelResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// progressResponse is an intermediate progress update reported by a tool.
+type progressResponse struct {
+	Progress float64 `json:"progress"`
+	Total    float64 `json:"total,omitempty"`
+	Message  string  `json:"message,omitempty"`
+}
+
 var _ render.Renderer = &errResponse{} // Renderer interface for managing response payloads.
 
 // newErrResponse is a helper function initializing an ErrResponse
 func newErrResponse(err error, code int) *errResponse {
-	return &errResponse{
+	resp := &errResponse{
 		Err:            err,
 		HTTPStatusCode: code,
 
 		StatusText: http.StatusText(code),
 		ErrorText:  err.Error(),
 	}
+	var violation *sqlpolicy.ViolationError
+	if errors.As(err, &violation) {
+		resp.Violation = violation
+	}
+	return resp
 }
 
 // errResponse is the response sent back when an error has been encountered.
@@ -269,6 +757,8 @@ type errResponse struct {
 
 	StatusText string `json:"status"`          // user-level status message
 	ErrorText  string `json:"error,omitempty"` // application-level error message, for debugging
+
+	Violation *sqlpolicy.ViolationError `json:"violation,omitempty"` // the SQL policy rule that rejected the invocation
 }
 
 func (e *errResponse) Render(w http.ResponseWriter, r *http.Request) error {
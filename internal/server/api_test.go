@@ -16,14 +16,18 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
 )
 
 func TestToolsetEndpoint(t *testing.T) {
@@ -281,3 +285,360 @@ func TestToolInvokeEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestToolInvokeAsyncEndpoint(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name        string
+		toolName    string
+		requestBody io.Reader
+		wantResult  string
+		wantStatus  int
+	}{
+		{
+			name:        "tool1",
+			toolName:    tool1.Name,
+			requestBody: bytes.NewBuffer([]byte(`{}`)),
+			wantResult:  `["no_params"]`,
+			wantStatus:  http.StatusAccepted,
+		},
+		{
+			name:        "tool2",
+			toolName:    tool2.Name,
+			requestBody: bytes.NewBuffer([]byte(`{"param1": 1, "param2": 2}`)),
+			wantResult:  `["some_params"]`,
+			wantStatus:  http.StatusAccepted,
+		},
+		{
+			name:        "invalid params",
+			toolName:    tool2.Name,
+			requestBody: bytes.NewBuffer([]byte(`{"param1": 1}`)),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "invalid tool",
+			toolName:    "some_imaginary_tool",
+			requestBody: bytes.NewBuffer([]byte(`{}`)),
+			wantStatus:  http.StatusNotFound,
+		},
+	}
+
+	alice := map[string]string{"reviewers_token": "alice"}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke:async", tc.toolName), tc.requestBody, alice)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", tc.wantStatus, resp.StatusCode, string(body))
+			}
+			if resp.StatusCode != http.StatusAccepted {
+				return
+			}
+
+			var op map[string]any
+			if err := json.Unmarshal(body, &op); err != nil {
+				t.Fatalf("unable to parse operation: %s", err)
+			}
+			if op["toolName"] != tc.toolName {
+				t.Fatalf("unexpected tool name: want %q, got %q", tc.toolName, op["toolName"])
+			}
+			id, ok := op["id"].(string)
+			if !ok || id == "" {
+				t.Fatalf("operation is missing an id: %s", string(body))
+			}
+
+			// poll the operation until it finishes
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				resp, body, err = runRequest(ts, http.MethodGet, fmt.Sprintf("/operations/%s", id), nil, alice)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode == http.StatusOK {
+					break
+				}
+				if resp.StatusCode != http.StatusAccepted || time.Now().After(deadline) {
+					t.Fatalf("operation did not finish: status %d, %s", resp.StatusCode, string(body))
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			if err := json.Unmarshal(body, &op); err != nil {
+				t.Fatalf("unable to parse operation: %s", err)
+			}
+			if op["status"] != "succeeded" {
+				t.Fatalf("unexpected operation status: %s", string(body))
+			}
+			if op["result"] != tc.wantResult {
+				t.Fatalf("unexpected result: want %q, got %q", tc.wantResult, op["result"])
+			}
+
+			// operations are scoped to the caller who started them
+			for _, header := range []map[string]string{nil, {"reviewers_token": "bob"}} {
+				resp, body, err = runRequest(ts, http.MethodGet, fmt.Sprintf("/operations/%s", id), nil, header)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusNotFound {
+					t.Fatalf("unexpected status code for another caller: want %d, got %d, %s", http.StatusNotFound, resp.StatusCode, string(body))
+				}
+			}
+		})
+	}
+}
+
+func TestOperationGetEndpointNotFound(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, body, err := runRequest(ts, http.MethodGet, "/operations/some-imaginary-operation", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusNotFound, resp.StatusCode, string(body))
+	}
+}
+
+func TestToolInvokeStreamEndpoint(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name        string
+		toolName    string
+		requestBody io.Reader
+		want        string
+		wantStatus  int
+	}{
+		{
+			name:        "tool1",
+			toolName:    tool1.Name,
+			requestBody: bytes.NewBuffer([]byte(`{}`)),
+			want:        "event: progress\ndata: {\"progress\":1,\"total\":1,\"message\":\"invoked\"}\n\nevent: result\ndata: {\"result\":\"[\\\"no_params\\\"]\"}\n\n",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "invalid params",
+			toolName:    tool2.Name,
+			requestBody: bytes.NewBuffer([]byte(`{"param1": 1}`)),
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "invalid tool",
+			toolName:    "some_imaginary_tool",
+			requestBody: bytes.NewBuffer([]byte(`{}`)),
+			wantStatus:  http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke/stream", tc.toolName), tc.requestBody, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", tc.wantStatus, resp.StatusCode, string(body))
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+				t.Fatalf("unexpected content type: got %q", got)
+			}
+			if got := string(body); got != tc.want {
+				t.Fatalf("unexpected events: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// blockingTool is a mock tool that runs until its invocation is cancelled.
+type blockingTool struct {
+	MockTool
+	started chan struct{}
+}
+
+func (t blockingTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	t.started <- struct{}{}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestToolInvokeCancelEndpoint(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	blocking := blockingTool{MockTool: MockTool{Name: "blocking", Params: []tools.Parameter{}}, started: make(chan struct{}, 1)}
+	toolsMap[blocking.Name] = blocking
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	t.Run("invoke", func(t *testing.T) {
+		type result struct {
+			resp *http.Response
+			body []byte
+			err  error
+		}
+		resCh := make(chan result, 1)
+		go func() {
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/blocking/invoke", bytes.NewBuffer([]byte(`{}`)), map[string]string{"Toolbox-Invocation-Id": "my-invocation"})
+			resCh <- result{resp, body, err}
+		}()
+		<-blocking.started
+
+		resp, body, err := runRequest(ts, http.MethodPost, "/tool/blocking/invoke/my-invocation/cancel", nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusOK, resp.StatusCode, string(body))
+		}
+
+		res := <-resCh
+		if res.err != nil {
+			t.Fatalf("unexpected error during request: %s", res.err)
+		}
+		if res.resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("unexpected status code: want %d, got %d", http.StatusBadRequest, res.resp.StatusCode)
+		}
+		if !strings.Contains(string(res.body), "tool invocation was cancelled") {
+			t.Fatalf("unexpected response: %s", string(res.body))
+		}
+	})
+
+	t.Run("invoke async", func(t *testing.T) {
+		resp, body, err := runRequest(ts, http.MethodPost, "/tool/blocking/invoke:async", bytes.NewBuffer([]byte(`{}`)), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusAccepted, resp.StatusCode, string(body))
+		}
+		id := resp.Header.Get("Toolbox-Invocation-Id")
+		<-blocking.started
+
+		resp, body, err = runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/blocking/invoke/%s/cancel", id), nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusOK, resp.StatusCode, string(body))
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		var op map[string]any
+		for {
+			resp, body, err = runRequest(ts, http.MethodGet, fmt.Sprintf("/operations/%s", id), nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode == http.StatusOK {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("operation did not finish: status %d, %s", resp.StatusCode, string(body))
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err := json.Unmarshal(body, &op); err != nil {
+			t.Fatalf("unable to parse operation: %s", err)
+		}
+		if op["status"] != "cancelled" {
+			t.Fatalf("unexpected operation status: %s", string(body))
+		}
+	})
+
+	t.Run("other caller", func(t *testing.T) {
+		resCh := make(chan error, 1)
+		go func() {
+			_, _, err := runRequest(ts, http.MethodPost, "/tool/blocking/invoke", bytes.NewBuffer([]byte(`{}`)), map[string]string{"Toolbox-Invocation-Id": "alice-invocation", "reviewers_token": "alice"})
+			resCh <- err
+		}()
+		<-blocking.started
+
+		// invocation ids are scoped to the caller who started the invocation
+		for _, header := range []map[string]string{nil, {"reviewers_token": "bob"}} {
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/blocking/invoke/alice-invocation/cancel", nil, header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != http.StatusNotFound {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusNotFound, resp.StatusCode, string(body))
+			}
+		}
+
+		resp, body, err := runRequest(ts, http.MethodPost, "/tool/blocking/invoke/alice-invocation/cancel", nil, map[string]string{"reviewers_token": "alice"})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusOK, resp.StatusCode, string(body))
+		}
+		if err := <-resCh; err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+	})
+
+	t.Run("invalid invocation", func(t *testing.T) {
+		resp, body, err := runRequest(ts, http.MethodPost, "/tool/blocking/invoke/some-imaginary-invocation/cancel", nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusNotFound, resp.StatusCode, string(body))
+		}
+	})
+}
+
+// violatingTool is a tool whose invocations violate its SQL policy.
+type violatingTool struct {
+	MockTool
+}
+
+func (t violatingTool) Invoke(context.Context, tools.ParamValues) (any, error) {
+	return nil, &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleAllowedTables, Statement: 1, Value: "secret", Message: `table "secret" is not allowed`}
+}
+
+func TestToolInvokeEndpointPolicyViolation(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	violating := violatingTool{MockTool: MockTool{Name: "violating", Params: []tools.Parameter{}}}
+	toolsMap[violating.Name] = violating
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, body, err := runRequest(ts, http.MethodPost, "/tool/violating/invoke", bytes.NewBuffer([]byte(`{}`)), nil)
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusForbidden, resp.StatusCode, string(body))
+	}
+	var got struct {
+		Violation map[string]any `json:"violation"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	want := map[string]any{"rule": "allowedTables", "statement": float64(1), "value": "secret", "message": `table "secret" is not allowed`}
+	if diff := cmp.Diff(want, got.Violation); diff != "" {
+		t.Fatalf("incorrect violation: diff %v", diff)
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// approval statuses reported by the approvals API
+const (
+	approvalPending   = "pending"
+	approvalDenied    = "denied"
+	approvalExpired   = "expired"
+	approvalRunning   = "running"
+	approvalSucceeded = "succeeded"
+	approvalFailed    = "failed"
+)
+
+// approvalRetention is how long a decided approval can still be retrieved.
+const approvalRetention = 1 * time.Hour
+
+var (
+	// errApprovalNotFound is returned for approvals that don't exist.
+	errApprovalNotFound = errors.New("approval not found")
+	// errApprovalDecided is returned when deciding an approval that isn't
+	// pending anymore.
+	errApprovalDecided = errors.New("approval is not pending")
+	// errSelfApproval is returned when the caller that invoked the tool
+	// decides its own approval.
+	errSelfApproval = errors.New("an approval can't be decided by the caller that requested it")
+)
+
+// ApprovalConfig configures how invocations of tools that require approval
+// are reviewed.
+type ApprovalConfig struct {
+	// Timeout is how long an approval can be decided before it expires.
+	Timeout time.Duration
+	// WebhookURL, if set, receives each new approval as JSON.
+	WebhookURL string
+	// SlackWebhookURL, if set, is a Slack incoming webhook that is sent a
+	// message for each new approval.
+	SlackWebhookURL string
+	// AuthService is the auth service that reviewers must be verified with to
+	// use the approvals API. It is required by tools that require approval.
+	AuthService string
+	// Approvers, if set, are the emails of the reviewers allowed to decide
+	// approvals. It requires AuthService.
+	Approvers []string
+}
+
+// popRequiresApproval removes the requiresApproval field from a raw tool
+// config and returns it.
+func popRequiresApproval(v map[string]any) (bool, error) {
+	val, ok := v["requiresApproval"]
+	if !ok {
+		return false, nil
+	}
+	delete(v, "requiresApproval")
+	b, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("must be a boolean")
+	}
+	return b, nil
+}
+
+var _ render.Renderer = &approval{} // Renderer interface for managing response payloads.
+
+// approval is a tool invocation that waits for a human to approve it before
+// it runs.
+type approval struct {
+	Id       string `json:"id"`
+	ToolName string `json:"toolName"`
+	// Parameters are the parameters of the invocation, with the values of
+	// sensitive parameters redacted.
+	Parameters map[string]any `json:"parameters"`
+	Caller     []auditCaller  `json:"caller"`
+	Status     string         `json:"status"`
+	Reviewer   string         `json:"reviewer,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+	Result     string         `json:"result,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	CreateTime time.Time      `json:"createTime"`
+	ExpireTime time.Time      `json:"expireTime"`
+	EndTime    *time.Time     `json:"endTime,omitempty"`
+	// callerIdentity identifies the caller that requested the approval, who
+	// can retrieve it without being a reviewer, see util.CallerIdentity
+	callerIdentity string
+	// run invokes the tool once the invocation is approved
+	run func() (any, error)
+}
+
+// Render renders a single payload and respond to the client request.
+func (a approval) Render(w http.ResponseWriter, r *http.Request) error {
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+// pendingApproval is the result of an invocation that waits for approval.
+type pendingApproval struct {
+	Status     string `json:"status"`
+	ApprovalId string `json:"approvalId"`
+	Message    string `json:"message"`
+}
+
+// approvalManager keeps the invocations that wait for approval, and runs
+// them once they are approved.
+type approvalManager struct {
+	cfg       ApprovalConfig
+	logger    log.Logger
+	client    *http.Client
+	mu        sync.Mutex
+	approvals map[string]*approval
+}
+
+func newApprovalManager(ctx context.Context, cfg ApprovalConfig, logger log.Logger) *approvalManager {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Hour
+	}
+	m := &approvalManager{
+		cfg:       cfg,
+		logger:    logger,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		approvals: make(map[string]*approval),
+	}
+	go m.cleanupRoutine(ctx)
+	return m
+}
+
+// request registers an invocation that waits for approval, and notifies the
+// reviewers.
+func (m *approvalManager) request(ctx context.Context, toolName string, params tools.ParamValues, run func(context.Context) (any, error)) approval {
+	// the invocation runs after the request that asked for it has finished,
+	// so it keeps the values of its context, such as the claims of the
+	// caller, but not its cancellation, progress updates or streamed rows
+	runCtx := util.WithProgressReporter(context.WithoutCancel(ctx), func(float64, float64, string) {})
+	runCtx = util.WithRowWriter(runCtx, nil)
+	redacted := make(map[string]any, len(params))
+	for _, p := range params {
+		redacted[p.Name] = p.Value
+		if p.Sensitive {
+			redacted[p.Name] = tools.RedactedValue
+		}
+	}
+	now := time.Now()
+	a := &approval{
+		Id:             uuid.New().String(),
+		ToolName:       toolName,
+		Parameters:     redacted,
+		Caller:         auditCallers(util.ClaimsFromContext(ctx)),
+		Status:         approvalPending,
+		CreateTime:     now,
+		ExpireTime:     now.Add(m.cfg.Timeout),
+		callerIdentity: util.CallerIdentity(ctx),
+		run:            func() (any, error) { return run(runCtx) },
+	}
+	m.mu.Lock()
+	m.approvals[a.Id] = a
+	m.mu.Unlock()
+
+	go m.notify(runCtx, *a)
+	return *a
+}
+
+// get returns a copy of the approval with the given id.
+func (m *approvalManager) get(id string) (approval, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.approvals[id]
+	if !ok {
+		return approval{}, false
+	}
+	m.expireLocked(a, time.Now())
+	return *a, true
+}
+
+// getRequested returns a copy of the approval with the given id, if it was
+// requested by the caller in ctx.
+func (m *approvalManager) getRequested(ctx context.Context, id string) (approval, bool) {
+	a, ok := m.get(id)
+	if !ok || a.callerIdentity != util.CallerIdentity(ctx) {
+		return approval{}, false
+	}
+	return a, true
+}
+
+// list returns copies of the approvals with the given status, or of every
+// approval if status is empty, oldest first.
+func (m *approvalManager) list(status string) []approval {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	approvals := []approval{}
+	for _, a := range m.approvals {
+		m.expireLocked(a, now)
+		if status == "" || a.Status == status {
+			approvals = append(approvals, *a)
+		}
+	}
+	sort.Slice(approvals, func(i, j int) bool { return approvals[i].CreateTime.Before(approvals[j].CreateTime) })
+	return approvals
+}
+
+// decide approves or denies a pending approval. An approved invocation runs
+// in the background.
+func (m *approvalManager) decide(id string, approve bool, reviewer auditCaller, reason string) (approval, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.approvals[id]
+	if !ok {
+		return approval{}, errApprovalNotFound
+	}
+	now := time.Now()
+	m.expireLocked(a, now)
+	if a.Status != approvalPending {
+		return *a, fmt.Errorf("%w: it is %s", errApprovalDecided, a.Status)
+	}
+	if requestedBy(*a, reviewer) {
+		return *a, errSelfApproval
+	}
+	a.Reviewer = reviewer.Email
+	if a.Reviewer == "" {
+		a.Reviewer = reviewer.Subject
+	}
+	a.Reason = reason
+	if !approve {
+		a.Status = approvalDenied
+		a.EndTime = &now
+		return *a, nil
+	}
+	a.Status = approvalRunning
+	go func() {
+		res, err := a.run()
+		var result string
+		if err == nil {
+			var b []byte
+			b, err = marshalResult(res)
+			result = string(b)
+		}
+		m.finish(id, result, err)
+	}()
+	return *a, nil
+}
+
+// finish records the outcome of an approved invocation.
+func (m *approvalManager) finish(id string, result string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.approvals[id]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	a.EndTime = &now
+	if err != nil {
+		a.Status = approvalFailed
+		a.Error = err.Error()
+		return
+	}
+	a.Status = approvalSucceeded
+	a.Result = result
+}
+
+// expireLocked marks a pending approval as expired once its time is up.
+func (m *approvalManager) expireLocked(a *approval, now time.Time) {
+	if a.Status == approvalPending && now.After(a.ExpireTime) {
+		a.Status = approvalExpired
+		end := a.ExpireTime
+		a.EndTime = &end
+	}
+}
+
+// requestedBy reports whether the reviewer is one of the identities of the
+// caller that requested the approval.
+func requestedBy(a approval, reviewer auditCaller) bool {
+	for _, c := range a.Caller {
+		if reviewer.Email != "" && strings.EqualFold(c.Email, reviewer.Email) {
+			return true
+		}
+		if c.AuthService == reviewer.AuthService && reviewer.Subject != "" && c.Subject == reviewer.Subject {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeReviewer checks that the caller of the approvals API may decide
+// approvals, and returns the identity of the reviewer. The approvals API is
+// unavailable without an auth service for reviewers.
+func (m *approvalManager) authorizeReviewer(claimsFromAuth map[string]map[string]any) (auditCaller, error) {
+	if m.cfg.AuthService == "" {
+		return auditCaller{}, fmt.Errorf("the approvals API requires an approval auth service")
+	}
+	claims, ok := claimsFromAuth[m.cfg.AuthService]
+	if !ok {
+		return auditCaller{}, fmt.Errorf("reviewers must be authenticated with %q", m.cfg.AuthService)
+	}
+	reviewer := auditCaller{AuthService: m.cfg.AuthService}
+	reviewer.Subject, _ = claims["sub"].(string)
+	reviewer.Email, _ = claims["email"].(string)
+	if len(m.cfg.Approvers) > 0 {
+		allowed := false
+		for _, approver := range m.cfg.Approvers {
+			if strings.EqualFold(approver, reviewer.Email) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return auditCaller{}, fmt.Errorf("%q is not an approver", reviewer.Email)
+		}
+	}
+	return reviewer, nil
+}
+
+// notify sends a new approval to the configured webhooks.
+func (m *approvalManager) notify(ctx context.Context, a approval) {
+	if m.cfg.WebhookURL != "" {
+		if err := m.post(ctx, m.cfg.WebhookURL, a); err != nil {
+			m.logError(ctx, fmt.Errorf("unable to notify approval webhook: %w", err))
+		}
+	}
+	if m.cfg.SlackWebhookURL != "" {
+		callers := make([]string, 0, len(a.Caller))
+		for _, c := range a.Caller {
+			if c.Email != "" {
+				callers = append(callers, c.Email)
+			} else {
+				callers = append(callers, c.Subject)
+			}
+		}
+		caller := "an anonymous caller"
+		if len(callers) > 0 {
+			caller = strings.Join(callers, ", ")
+		}
+		params, _ := json.Marshal(a.Parameters)
+		text := fmt.Sprintf("Tool `%s` was invoked by %s and requires approval.\nParameters: `%s`\nApprove or deny it with `POST /api/approvals/%s/approve` or `POST /api/approvals/%s/deny` before %s.",
+			a.ToolName, caller, params, a.Id, a.Id, a.ExpireTime.UTC().Format(time.RFC3339))
+		if err := m.post(ctx, m.cfg.SlackWebhookURL, map[string]string{"text": text}); err != nil {
+			m.logError(ctx, fmt.Errorf("unable to notify Slack of approval: %w", err))
+		}
+	}
+}
+
+func (m *approvalManager) post(ctx context.Context, url string, body any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (m *approvalManager) logError(ctx context.Context, err error) {
+	if m.logger != nil {
+		m.logger.ErrorContext(ctx, err.Error())
+	}
+}
+
+func (m *approvalManager) cleanupRoutine(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			func() {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				now := time.Now()
+				for id, a := range m.approvals {
+					m.expireLocked(a, now)
+					if a.EndTime != nil && now.Sub(*a.EndTime) > approvalRetention {
+						delete(m.approvals, id)
+					}
+				}
+			}()
+		}
+	}
+}
+
+// approvalsKey is the context key of the approval manager.
+type approvalsKey struct{}
+
+// withApprovals adds the approval manager into the context, for the tools
+// that require approval.
+func withApprovals(ctx context.Context, m *approvalManager) context.Context {
+	return context.WithValue(ctx, approvalsKey{}, m)
+}
+
+func approvalsFromContext(ctx context.Context) (*approvalManager, bool) {
+	m, ok := ctx.Value(approvalsKey{}).(*approvalManager)
+	return m, ok && m != nil
+}
+
+var _ tools.Tool = approvalTool{}
+
+// approvalTool doesn't invoke the wrapped tool until a human approves the
+// invocation. Invoking it returns a pendingApproval instead.
+type approvalTool struct {
+	tools.Tool
+	name string
+}
+
+func newApprovalTool(name string, tool tools.Tool) approvalTool {
+	return approvalTool{Tool: tool, name: name}
+}
+
+func (t approvalTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	if dryRunFromContext(ctx) {
+		// a dry run makes no changes, so it doesn't need approval
+		return t.Tool.Invoke(ctx, params)
+	}
+	m, ok := approvalsFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("tool %q requires approval, which is not available", t.name)
+	}
+	a := m.request(ctx, t.name, params, func(ctx context.Context) (any, error) {
+		return t.Tool.Invoke(ctx, params)
+	})
+	return pendingApproval{
+		Status:     "pendingApproval",
+		ApprovalId: a.Id,
+		Message:    fmt.Sprintf("The invocation of tool %q is waiting for a human to approve it. It runs once approved, and expires if it isn't approved by %s.", t.name, a.ExpireTime.UTC().Format(time.RFC3339)),
+	}, nil
+}
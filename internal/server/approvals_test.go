@@ -0,0 +1,337 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestPopRequiresApproval(t *testing.T) {
+	v := map[string]any{"kind": "mock", "requiresApproval": true}
+	got, err := popRequiresApproval(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !got {
+		t.Fatalf("expected the tool to require approval")
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("requiresApproval was not removed from config: diff %v", diff)
+	}
+	if _, err := popRequiresApproval(map[string]any{"requiresApproval": "yes"}); err == nil {
+		t.Fatalf("expected error for a non-boolean requiresApproval")
+	}
+}
+
+func TestApprovalsEndpoints(t *testing.T) {
+	var count int
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	toolsMap[tool2.Name] = newApprovalTool(tool2.Name, countingTool{MockTool: tool2, count: &count})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	// the tool is invoked by alice, who is also a reviewer
+	invoke := func() pendingApproval {
+		resp, body, err := runRequest(ts, http.MethodPost, "/tool/some_params/invoke", bytes.NewBufferString(`{"param1": 1, "param2": 2}`), map[string]string{"reviewers_token": "alice"})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("unexpected status code: want %d, got %d, %s", http.StatusAccepted, resp.StatusCode, string(body))
+		}
+		var res resultResponse
+		if err := json.Unmarshal(body, &res); err != nil {
+			t.Fatalf("unable to parse response: %s", err)
+		}
+		var pending pendingApproval
+		if err := json.Unmarshal([]byte(res.Result), &pending); err != nil {
+			t.Fatalf("unable to parse result: %s", err)
+		}
+		return pending
+	}
+	getApproval := func(method, path string, body io.Reader, wantStatus int) approval {
+		resp, respBody, err := runRequest(ts, method, path, body, map[string]string{"reviewers_token": "bob"})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != wantStatus {
+			t.Fatalf("unexpected status code: want %d, got %d, %s", wantStatus, resp.StatusCode, string(respBody))
+		}
+		var a approval
+		if err := json.Unmarshal(respBody, &a); err != nil {
+			t.Fatalf("unable to parse approval: %s", err)
+		}
+		return a
+	}
+
+	// the tool isn't invoked until the invocation is approved
+	approved := invoke()
+	if approved.Status != "pendingApproval" || approved.ApprovalId == "" {
+		t.Fatalf("unexpected pending approval: %+v", approved)
+	}
+	denied := invoke()
+	if count != 0 {
+		t.Fatalf("the tool was invoked before it was approved")
+	}
+
+	// the approvals API requires an authenticated reviewer
+	resp, body, err := runRequest(ts, http.MethodGet, "/approvals?status=pending", nil, nil)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected an unauthenticated reviewer to be rejected: %v, %s", err, string(body))
+	}
+	resp, body, err = runRequest(ts, http.MethodGet, "/approvals?status=pending", nil, map[string]string{"reviewers_token": "bob"})
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("unable to list approvals: %v, %s", err, string(body))
+	}
+	var list struct {
+		Approvals []approval `json:"approvals"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		t.Fatalf("unable to parse approvals: %s", err)
+	}
+	if len(list.Approvals) != 2 || list.Approvals[0].Id != approved.ApprovalId {
+		t.Fatalf("unexpected pending approvals: %+v", list.Approvals)
+	}
+	if diff := cmp.Diff(map[string]any{"param1": float64(1), "param2": float64(2)}, list.Approvals[0].Parameters); diff != "" {
+		t.Fatalf("unexpected parameters (-want +got):\n%s", diff)
+	}
+
+	// alice can't approve her own invocation
+	resp, body, err = runRequest(ts, http.MethodPost, "/approvals/"+approved.ApprovalId+"/approve", nil, map[string]string{"reviewers_token": "alice"})
+	if err != nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected the requester to be rejected: %v, %s", err, string(body))
+	}
+	a := getApproval(http.MethodPost, "/approvals/"+approved.ApprovalId+"/approve", bytes.NewBufferString(`{"reason": "looks good"}`), http.StatusOK)
+	if a.Status != approvalRunning || a.Reason != "looks good" || a.Reviewer != "bob" {
+		t.Fatalf("unexpected approved approval: %+v", a)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for a.Status == approvalRunning && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		a = getApproval(http.MethodGet, "/approvals/"+approved.ApprovalId, nil, http.StatusOK)
+	}
+	if a.Status != approvalSucceeded || a.Result != `[1]` {
+		t.Fatalf("unexpected finished approval: %+v", a)
+	}
+
+	a = getApproval(http.MethodPost, "/approvals/"+denied.ApprovalId+"/deny", nil, http.StatusOK)
+	if a.Status != approvalDenied || a.EndTime == nil {
+		t.Fatalf("unexpected denied approval: %+v", a)
+	}
+	// an approval can only be decided once
+	getApproval(http.MethodPost, "/approvals/"+denied.ApprovalId+"/approve", nil, http.StatusConflict)
+	getApproval(http.MethodPost, "/approvals/some-imaginary-approval/approve", nil, http.StatusNotFound)
+	getApproval(http.MethodGet, "/approvals/some-imaginary-approval", nil, http.StatusNotFound)
+	if count != 1 {
+		t.Fatalf("unexpected number of invocations: want 1, got %d", count)
+	}
+}
+
+// textTool returns its result as text, such as CSV.
+type textTool struct {
+	MockTool
+}
+
+func (t textTool) Invoke(context.Context, tools.ParamValues) (any, error) {
+	return tools.TextResult("id,name\n1,Alice\n"), nil
+}
+
+func TestApprovalRequester(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	toolsMap[tool2.Name] = newApprovalTool(tool2.Name, textTool{MockTool: tool2})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	// carol isn't a reviewer
+	resp, body, err := runRequest(ts, http.MethodPost, "/tool/some_params/invoke", bytes.NewBufferString(`{"param1": 1, "param2": 2}`), map[string]string{"callers_token": "carol"})
+	if err != nil || resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("unable to invoke tool: %v, %s", err, string(body))
+	}
+	var res resultResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	var pending pendingApproval
+	if err := json.Unmarshal([]byte(res.Result), &pending); err != nil {
+		t.Fatalf("unable to parse result: %s", err)
+	}
+	getApproval := func(header map[string]string, wantStatus int) approval {
+		resp, body, err := runRequest(ts, http.MethodGet, "/approvals/"+pending.ApprovalId, nil, header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if resp.StatusCode != wantStatus {
+			t.Fatalf("unexpected status code: want %d, got %d, %s", wantStatus, resp.StatusCode, string(body))
+		}
+		var a approval
+		_ = json.Unmarshal(body, &a)
+		return a
+	}
+
+	// only the requester can retrieve the approval without being a reviewer
+	getApproval(nil, http.StatusUnauthorized)
+	getApproval(map[string]string{"callers_token": "dave"}, http.StatusUnauthorized)
+	if a := getApproval(map[string]string{"callers_token": "carol"}, http.StatusOK); a.Status != approvalPending {
+		t.Fatalf("unexpected approval: %+v", a)
+	}
+	resp, body, err = runRequest(ts, http.MethodPost, "/approvals/"+pending.ApprovalId+"/approve", nil, map[string]string{"callers_token": "carol"})
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the requester to be unable to decide the approval: %v, %s", err, string(body))
+	}
+	if _, _, err := runRequest(ts, http.MethodPost, "/approvals/"+pending.ApprovalId+"/approve", nil, map[string]string{"reviewers_token": "bob"}); err != nil {
+		t.Fatalf("unable to approve: %s", err)
+	}
+
+	// the result is formatted as the tool returns it
+	deadline := time.Now().Add(5 * time.Second)
+	a := getApproval(map[string]string{"callers_token": "carol"}, http.StatusOK)
+	for a.Status == approvalRunning && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		a = getApproval(map[string]string{"callers_token": "carol"}, http.StatusOK)
+	}
+	if a.Status != approvalSucceeded || a.Result != "id,name\n1,Alice\n" {
+		t.Fatalf("unexpected finished approval: %+v", a)
+	}
+}
+
+func TestRequiresApprovalWithoutAuthService(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("error setting up logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+	cfg := ServerConfig{
+		Version:     fakeVersionString,
+		ToolConfigs: ToolConfigs{"cancel_flight": wrappedToolConfig{RequiresApproval: true}},
+	}
+	_, _, _, _, _, err = InitializeConfigs(ctx, cfg)
+	if err == nil || !strings.Contains(err.Error(), "requires an approval auth service") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestApprovalExpires(t *testing.T) {
+	m := newApprovalManager(context.Background(), ApprovalConfig{Timeout: time.Millisecond}, nil)
+	a := m.request(context.Background(), "my-tool", nil, func(context.Context) (any, error) { return nil, nil })
+	time.Sleep(5 * time.Millisecond)
+	if _, err := m.decide(a.Id, true, auditCaller{}, ""); err == nil || !strings.Contains(err.Error(), approvalExpired) {
+		t.Fatalf("expected an expired approval, got %v", err)
+	}
+}
+
+func TestAuthorizeReviewer(t *testing.T) {
+	claims := map[string]map[string]any{"my-google-auth": {"sub": "1234", "email": "Admin@example.com"}}
+	reviewer := auditCaller{AuthService: "my-google-auth", Subject: "1234", Email: "Admin@example.com"}
+	tcs := []struct {
+		desc    string
+		cfg     ApprovalConfig
+		claims  map[string]map[string]any
+		want    auditCaller
+		wantErr bool
+	}{
+		{
+			desc:    "no auth service",
+			claims:  claims,
+			wantErr: true,
+		},
+		{
+			desc:   "authenticated",
+			cfg:    ApprovalConfig{AuthService: "my-google-auth"},
+			claims: claims,
+			want:   reviewer,
+		},
+		{
+			desc:    "not authenticated",
+			cfg:     ApprovalConfig{AuthService: "my-google-auth"},
+			claims:  nil,
+			wantErr: true,
+		},
+		{
+			desc:   "approver",
+			cfg:    ApprovalConfig{AuthService: "my-google-auth", Approvers: []string{"admin@example.com"}},
+			claims: claims,
+			want:   reviewer,
+		},
+		{
+			desc:    "not an approver",
+			cfg:     ApprovalConfig{AuthService: "my-google-auth", Approvers: []string{"someone@example.com"}},
+			claims:  claims,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			m := &approvalManager{cfg: tc.cfg}
+			got, err := m.authorizeReviewer(tc.claims)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected reviewer: want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestApprovalNotifications(t *testing.T) {
+	var webhook, slack atomic.Value
+	newHook := func(v *atomic.Value) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			v.Store(string(b))
+		}))
+	}
+	webhookServer, slackServer := newHook(&webhook), newHook(&slack)
+	defer webhookServer.Close()
+	defer slackServer.Close()
+
+	m := newApprovalManager(context.Background(), ApprovalConfig{WebhookURL: webhookServer.URL, SlackWebhookURL: slackServer.URL}, nil)
+	a := m.request(context.Background(), "delete_flights", nil, func(context.Context) (any, error) { return nil, nil })
+	deadline := time.Now().Add(5 * time.Second)
+	for (webhook.Load() == nil || slack.Load() == nil) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got, _ := webhook.Load().(string); !strings.Contains(got, a.Id) {
+		t.Fatalf("webhook wasn't sent the approval: %q", got)
+	}
+	var msg map[string]string
+	got, _ := slack.Load().(string)
+	if err := json.Unmarshal([]byte(got), &msg); err != nil || !strings.Contains(msg["text"], "/api/approvals/"+a.Id+"/approve") {
+		t.Fatalf("unexpected Slack message: %q", got)
+	}
+}
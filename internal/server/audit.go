@@ -0,0 +1,402 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"google.golang.org/api/option"
+)
+
+const (
+	// auditBatchSize is the maximum number of records written to the sink
+	// at once.
+	auditBatchSize = 100
+	// auditFlushInterval is how long records are buffered before they are
+	// written to the sink.
+	auditFlushInterval = time.Second
+)
+
+// AuditConfig configures the audit log of tool invocations.
+type AuditConfig struct {
+	// Destination is where audit records are written: a file path, a Cloud
+	// Storage prefix ("gs://bucket/prefix"), a BigQuery table
+	// ("bigquery://project/dataset/table"), or a webhook URL. Auditing is
+	// disabled if it is empty.
+	Destination string
+	// RedactParams are the names of the parameters whose values are
+	// redacted from audit records.
+	RedactParams []string
+}
+
+// Enabled returns whether tool invocations are audited.
+func (c AuditConfig) Enabled() bool {
+	return c.Destination != ""
+}
+
+// auditCaller is an identity the caller of a tool was verified with.
+type auditCaller struct {
+	AuthService string `json:"authService"`
+	Subject     string `json:"subject,omitempty"`
+	Email       string `json:"email,omitempty"`
+}
+
+// auditRecord records a single tool invocation.
+type auditRecord struct {
+	Timestamp  time.Time      `json:"timestamp"`
+	Tool       string         `json:"tool"`
+	Kind       string         `json:"kind"`
+	Source     string         `json:"source,omitempty"`
+	Caller     []auditCaller  `json:"caller"`
+	Parameters map[string]any `json:"parameters"`
+	DurationMs int64          `json:"durationMs"`
+	Rows       int            `json:"rows"`
+	Bytes      int            `json:"bytes"`
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	DryRun     bool           `json:"dryRun,omitempty"`
+}
+
+// Save implements bigquery.ValueSaver. Parameters are stored as a JSON
+// string, since they differ from tool to tool.
+func (r auditRecord) Save() (map[string]bigquery.Value, string, error) {
+	params, err := json.Marshal(r.Parameters)
+	if err != nil {
+		return nil, "", err
+	}
+	callers := make([]map[string]bigquery.Value, 0, len(r.Caller))
+	for _, c := range r.Caller {
+		callers = append(callers, map[string]bigquery.Value{
+			"auth_service": c.AuthService,
+			"subject":      c.Subject,
+			"email":        c.Email,
+		})
+	}
+	return map[string]bigquery.Value{
+		"timestamp":   r.Timestamp,
+		"tool":        r.Tool,
+		"kind":        r.Kind,
+		"source":      r.Source,
+		"caller":      callers,
+		"parameters":  string(params),
+		"duration_ms": r.DurationMs,
+		"rows":        r.Rows,
+		"bytes":       r.Bytes,
+		"status":      r.Status,
+		"error":       r.Error,
+		"dry_run":     r.DryRun,
+	}, "", nil
+}
+
+// auditCallers returns the identities of the caller from the claims of the
+// auth services it was verified with, sorted by auth service.
+func auditCallers(claimsFromAuth map[string]map[string]any) []auditCaller {
+	callers := make([]auditCaller, 0, len(claimsFromAuth))
+	for name, claims := range claimsFromAuth {
+		c := auditCaller{AuthService: name}
+		c.Subject, _ = claims["sub"].(string)
+		c.Email, _ = claims["email"].(string)
+		callers = append(callers, c)
+	}
+	sort.Slice(callers, func(i, j int) bool { return callers[i].AuthService < callers[j].AuthService })
+	return callers
+}
+
+// auditSink writes audit records to a destination.
+type auditSink interface {
+	write(ctx context.Context, records []auditRecord) error
+	close() error
+}
+
+func newAuditSink(ctx context.Context, destination string) (auditSink, error) {
+	switch {
+	case strings.HasPrefix(destination, "http://"), strings.HasPrefix(destination, "https://"):
+		return &webhookAuditSink{url: destination, client: &http.Client{Timeout: 30 * time.Second}}, nil
+	case strings.HasPrefix(destination, "gs://"):
+		bucket, prefix, _ := strings.Cut(strings.TrimPrefix(destination, "gs://"), "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("invalid audit log destination %q: missing bucket", destination)
+		}
+		userAgent, err := util.UserAgentFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		client, err := storage.NewClient(ctx, option.WithUserAgent(userAgent))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Cloud Storage client: %w", err)
+		}
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		return &gcsAuditSink{client: client, bucket: bucket, prefix: prefix}, nil
+	case strings.HasPrefix(destination, "bigquery://"):
+		parts := strings.Split(strings.TrimPrefix(destination, "bigquery://"), "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid audit log destination %q: expected bigquery://project/dataset/table", destination)
+		}
+		userAgent, err := util.UserAgentFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		client, err := bigquery.NewClient(ctx, parts[0], option.WithUserAgent(userAgent))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create BigQuery client for project %q: %w", parts[0], err)
+		}
+		return &bigqueryAuditSink{client: client, inserter: client.Dataset(parts[1]).Table(parts[2]).Inserter()}, nil
+	default:
+		f, err := os.OpenFile(destination, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open audit log file: %w", err)
+		}
+		return &fileAuditSink{f: f}, nil
+	}
+}
+
+// fileAuditSink appends audit records to a file, one JSON object per line.
+type fileAuditSink struct {
+	f *os.File
+}
+
+func (s *fileAuditSink) write(_ context.Context, records []auditRecord) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	_, err := s.f.Write(buf.Bytes())
+	return err
+}
+
+func (s *fileAuditSink) close() error {
+	return s.f.Close()
+}
+
+// webhookAuditSink sends each batch of audit records as a JSON array in the
+// body of a POST request.
+type webhookAuditSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookAuditSink) write(ctx context.Context, records []auditRecord) error {
+	b, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *webhookAuditSink) close() error {
+	return nil
+}
+
+// gcsAuditSink writes each batch of audit records to a new Cloud Storage
+// object, one JSON object per line.
+type gcsAuditSink struct {
+	client *storage.Client
+	bucket string
+	prefix string
+}
+
+func (s *gcsAuditSink) write(ctx context.Context, records []auditRecord) error {
+	name := fmt.Sprintf("%s%s-%s.jsonl", s.prefix, time.Now().UTC().Format("20060102T150405.000Z"), uuid.New().String())
+	w := s.client.Bucket(s.bucket).Object(name).NewWriter(ctx)
+	w.ContentType = "application/x-ndjson"
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+func (s *gcsAuditSink) close() error {
+	return s.client.Close()
+}
+
+// bigqueryAuditSink streams audit records into an existing BigQuery table.
+type bigqueryAuditSink struct {
+	client   *bigquery.Client
+	inserter *bigquery.Inserter
+}
+
+func (s *bigqueryAuditSink) write(ctx context.Context, records []auditRecord) error {
+	return s.inserter.Put(ctx, records)
+}
+
+func (s *bigqueryAuditSink) close() error {
+	return s.client.Close()
+}
+
+// auditLog buffers audit records and writes them to its sink in batches, in
+// the background.
+type auditLog struct {
+	destination string
+	sink        auditSink
+	logger      log.Logger
+	records     chan auditRecord
+	done        chan struct{}
+	// mu guards closed, so that no record is sent once records is closed
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newAuditLog(ctx context.Context, destination string, sink auditSink) *auditLog {
+	// failures are logged if a logger is available, which it is outside of
+	// tests
+	logger, _ := util.LoggerFromContext(ctx)
+	a := &auditLog{
+		destination: destination,
+		sink:        sink,
+		logger:      logger,
+		records:     make(chan auditRecord, auditBatchSize),
+		done:        make(chan struct{}),
+	}
+	go a.run(context.WithoutCancel(ctx))
+	return a
+}
+
+// record adds a record to the audit log. It blocks if the log falls behind,
+// rather than dropping records.
+func (a *auditLog) record(r auditRecord) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		return
+	}
+	a.records <- r
+}
+
+func (a *auditLog) run(ctx context.Context) {
+	defer close(a.done)
+	ticker := time.NewTicker(auditFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]auditRecord, 0, auditBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := a.sink.write(ctx, batch); err != nil && a.logger != nil {
+			a.logger.ErrorContext(ctx, fmt.Sprintf("unable to write %d audit records to %q: %s", len(batch), a.destination, err))
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case r, ok := <-a.records:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, r)
+			if len(batch) == auditBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// close writes the buffered records and closes the sink.
+func (a *auditLog) close(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.records)
+	}
+	a.mu.Unlock()
+	select {
+	case <-a.done:
+	case <-ctx.Done():
+		return fmt.Errorf("unable to flush audit log %q: %w", a.destination, ctx.Err())
+	}
+	return a.sink.close()
+}
+
+// auditLogs are the open audit logs, by destination, so that the tools of a
+// reloaded tools file keep writing to the same log.
+var auditLogs = struct {
+	mu   sync.Mutex
+	logs map[string]*auditLog
+}{logs: make(map[string]*auditLog)}
+
+// openAuditLog returns the audit log of a destination, opening it if needed.
+func openAuditLog(ctx context.Context, destination string) (*auditLog, error) {
+	auditLogs.mu.Lock()
+	defer auditLogs.mu.Unlock()
+	if a, ok := auditLogs.logs[destination]; ok {
+		return a, nil
+	}
+	sink, err := newAuditSink(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+	a := newAuditLog(ctx, destination, sink)
+	auditLogs.logs[destination] = a
+	return a, nil
+}
+
+// closeAuditLogs writes the buffered records of every open audit log and
+// closes them.
+func closeAuditLogs(ctx context.Context) error {
+	auditLogs.mu.Lock()
+	logs := auditLogs.logs
+	auditLogs.logs = make(map[string]*auditLog)
+	auditLogs.mu.Unlock()
+
+	var errs []error
+	for _, a := range logs {
+		if err := a.close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestAuditedTool(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := newAuditSink(context.Background(), path)
+	if err != nil {
+		t.Fatalf("unable to create audit sink: %s", err)
+	}
+	log := newAuditLog(context.Background(), path, sink)
+
+	var count int
+	fail := true
+	ok := newAuditedTool(tool2.Name, "mock", "my-source", countingTool{MockTool: tool2, count: &count}, []string{"param2"}, log)
+	failing := newAuditedTool(tool2.Name, "mock", "my-source", failingTool{MockTool: tool2, fail: &fail, count: &count}, nil, log)
+	params, err := ok.ParseParams(map[string]any{"param1": 1, "param2": 2}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	ctx := util.WithClaims(context.Background(), map[string]map[string]any{
+		"my-google-auth": {"sub": "1234", "email": "jane@example.com"},
+	})
+	if _, err := ok.Invoke(ctx, params); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := failing.Invoke(context.Background(), params); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if err := log.close(context.Background()); err != nil {
+		t.Fatalf("unable to close audit log: %s", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unable to open audit log: %s", err)
+	}
+	defer f.Close()
+	var got []auditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("unable to parse audit record %q: %s", scanner.Text(), err)
+		}
+		got = append(got, r)
+	}
+	want := []auditRecord{
+		{
+			Tool:       tool2.Name,
+			Kind:       "mock",
+			Source:     "my-source",
+			Caller:     []auditCaller{{AuthService: "my-google-auth", Subject: "1234", Email: "jane@example.com"}},
+			Parameters: map[string]any{"param1": float64(1), "param2": tools.RedactedValue},
+			Rows:       1,
+			Bytes:      len("[1]"),
+			Status:     "success",
+		},
+		{
+			Tool:       tool2.Name,
+			Kind:       "mock",
+			Source:     "my-source",
+			Caller:     []auditCaller{},
+			Parameters: map[string]any{"param1": float64(1), "param2": float64(2)},
+			Status:     "error",
+			Error:      "connection refused",
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(auditRecord{}, "Timestamp", "DurationMs")); diff != "" {
+		t.Fatalf("unexpected audit records (-want +got):\n%s", diff)
+	}
+}
+
+func TestWebhookAuditSink(t *testing.T) {
+	var mu sync.Mutex
+	var got []auditRecord
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var records []auditRecord
+		if err := json.NewDecoder(r.Body).Decode(&records); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		got = append(got, records...)
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	sink, err := newAuditSink(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatalf("unable to create audit sink: %s", err)
+	}
+	log := newAuditLog(context.Background(), ts.URL, sink)
+	for _, name := range []string{"tool-a", "tool-b"} {
+		log.record(auditRecord{Tool: name, Status: "success"})
+	}
+	if err := log.close(context.Background()); err != nil {
+		t.Fatalf("unable to close audit log: %s", err)
+	}
+	// records after the log is closed are dropped
+	log.record(auditRecord{Tool: "tool-c", Status: "success"})
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []auditRecord{{Tool: "tool-a", Status: "success"}, {Tool: "tool-b", Status: "success"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected audit records (-want +got):\n%s", diff)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	sink, err = newAuditSink(context.Background(), failing.URL)
+	if err != nil {
+		t.Fatalf("unable to create audit sink: %s", err)
+	}
+	if err := sink.write(context.Background(), want); err == nil {
+		t.Fatalf("expected an error for a failing webhook")
+	}
+}
+
+func TestNewAuditSinkErrors(t *testing.T) {
+	tcs := []struct {
+		desc        string
+		destination string
+		want        string
+	}{
+		{
+			desc:        "missing bucket",
+			destination: "gs://",
+			want:        "missing bucket",
+		},
+		{
+			desc:        "missing table",
+			destination: "bigquery://my-project/my-dataset",
+			want:        "expected bigquery://project/dataset/table",
+		},
+		{
+			desc:        "missing directory",
+			destination: filepath.Join(t.TempDir(), "missing", "audit.log"),
+			want:        "unable to open audit log file",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := newAuditSink(context.Background(), tc.destination)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
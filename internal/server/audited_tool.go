@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+var _ tools.Tool = auditedTool{}
+
+// auditedTool records every invocation of the wrapped tool in an audit log.
+type auditedTool struct {
+	tools.Tool
+	name   string
+	kind   string
+	source string
+	// redact are the names of the parameters whose values aren't recorded
+	redact map[string]bool
+	log    *auditLog
+}
+
+func newAuditedTool(name string, kind string, source string, tool tools.Tool, redactParams []string, log *auditLog) auditedTool {
+	redact := make(map[string]bool, len(redactParams))
+	for _, p := range redactParams {
+		redact[p] = true
+	}
+	return auditedTool{Tool: tool, name: name, kind: kind, source: source, redact: redact, log: log}
+}
+
+func (t auditedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	start := time.Now()
+	streamed := 0
+	if write, ok := util.RowWriterFromContext(ctx); ok {
+		ctx = util.WithRowWriter(ctx, func(row any) error {
+			streamed++
+			return write(row)
+		})
+	}
+	res, err := t.Tool.Invoke(ctx, params)
+
+	r := auditRecord{
+		Timestamp:  start.UTC(),
+		Tool:       t.name,
+		Kind:       t.kind,
+		Source:     t.source,
+		Caller:     auditCallers(util.ClaimsFromContext(ctx)),
+		Parameters: t.redactParams(params),
+		DurationMs: time.Since(start).Milliseconds(),
+		Status:     "success",
+		DryRun:     dryRunFromContext(ctx),
+	}
+	if err != nil {
+		r.Status = "error"
+		r.Error = err.Error()
+	} else {
+		if rows, ok := res.([]any); ok {
+			r.Rows = len(rows)
+		}
+		if streamed > 0 {
+			r.Rows = streamed
+		}
+		if b, err := json.Marshal(res); err == nil {
+			r.Bytes = len(b)
+		}
+	}
+	t.log.record(r)
+	return res, err
+}
+
+// redactParams returns the parameters to record, with the values of sensitive
+// and redacted parameters replaced.
+func (t auditedTool) redactParams(params tools.ParamValues) map[string]any {
+	m := make(map[string]any, len(params))
+	for _, p := range params {
+		if p.Sensitive || t.redact[p.Name] {
+			m[p.Name] = tools.RedactedValue
+			continue
+		}
+		m[p.Name] = p.Value
+	}
+	return m
+}
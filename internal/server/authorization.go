@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// claimsFromHeader verifies the request headers against each configured auth
+// service, and maps the name of every verified auth service to the claims
+// retrieved from it.
+func claimsFromHeader(ctx context.Context, s *Server, h http.Header) map[string]map[string]any {
+	claimsFromAuth := make(map[string]map[string]any)
+	if h == nil {
+		return claimsFromAuth
+	}
+	for _, aS := range s.ResourceMgr.GetAuthServiceMap() {
+		claims, err := aS.GetClaimsFromHeader(ctx, h)
+		if err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+			continue
+		}
+		if claims == nil {
+			// authService not present in header
+			continue
+		}
+		claimsFromAuth[aS.GetName()] = claims
+	}
+	return claimsFromAuth
+}
+
+// accessTokensFromHeader verifies the OAuth access tokens in the request
+// headers against each configured auth service that supports them, and maps
+// the name of every verified auth service to the access token of the caller.
+func accessTokensFromHeader(ctx context.Context, s *Server, h http.Header) map[string]string {
+	tokensFromAuth := make(map[string]string)
+	if h == nil {
+		return tokensFromAuth
+	}
+	for _, aS := range s.ResourceMgr.GetAuthServiceMap() {
+		tS, ok := aS.(auth.AccessTokenService)
+		if !ok {
+			continue
+		}
+		token, err := tS.GetAccessTokenFromHeader(ctx, h)
+		if err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+			continue
+		}
+		if token == "" {
+			// access token not present in header
+			continue
+		}
+		tokensFromAuth[aS.GetName()] = token
+	}
+	return tokensFromAuth
+}
+
+// callerFromHeader returns a context carrying the verified identities and
+// access tokens of the caller in the request headers, which identifies them
+// for requests that don't invoke a tool, such as cancellations.
+func callerFromHeader(ctx context.Context, s *Server, h http.Header) context.Context {
+	ctx = util.WithClaims(ctx, claimsFromHeader(ctx, s, h))
+	return util.WithAccessTokens(ctx, accessTokensFromHeader(ctx, s, h))
+}
+
+// toolAuthorized checks the toolset policies that apply to a tool. A tool that
+// only belongs to toolsets with a policy may only be used by callers that are
+// authorized for at least one of them. The default toolset is ignored, since it
+// contains every tool.
+func (r *ResourceManager) toolAuthorized(toolName string, claimsFromAuth map[string]map[string]any) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	restricted := false
+	for name, toolset := range r.toolsets {
+		if name == "" {
+			continue
+		}
+		if _, ok := toolset.Manifest.ToolsManifest[toolName]; !ok {
+			continue
+		}
+		if toolset.Authorized(claimsFromAuth) {
+			return true
+		}
+		restricted = true
+	}
+	return !restricted
+}
+
+// authorizedToolset returns a copy of the toolset that only lists the tools the
+// caller is authorized to use. Manifests are read from the tools, since the
+// tools of a lazily initialized source can only describe themselves once the
+// source is initialized.
+func (r *ResourceManager) authorizedToolset(toolset tools.Toolset, claimsFromAuth map[string]map[string]any) tools.Toolset {
+	toolsMap := r.GetToolsMap()
+	toolNames := make([]string, 0, len(toolset.Manifest.ToolsManifest))
+	for name := range toolset.Manifest.ToolsManifest {
+		if r.toolAuthorized(name, claimsFromAuth) {
+			toolNames = append(toolNames, name)
+		}
+	}
+	waitForLazySources(toolsMap, toolNames)
+
+	filtered := toolset
+	filtered.Manifest = tools.ToolsetManifest{
+		ServerVersion: toolset.Manifest.ServerVersion,
+		ToolsManifest: make(map[string]tools.Manifest),
+	}
+	for _, name := range toolNames {
+		if tool, ok := toolsMap[name]; ok {
+			filtered.Manifest.ToolsManifest[name] = tool.Manifest()
+		}
+	}
+	filtered.McpManifest = nil
+	for _, m := range toolset.McpManifest {
+		if _, ok := filtered.Manifest.ToolsManifest[m.Name]; ok {
+			filtered.McpManifest = append(filtered.McpManifest, toolsMap[m.Name].McpManifest())
+		}
+	}
+	return filtered
+}
+
+// authorizedToolsMap returns the tools the caller is authorized to use.
+func (r *ResourceManager) authorizedToolsMap(claimsFromAuth map[string]map[string]any) map[string]tools.Tool {
+	toolsMap := make(map[string]tools.Tool)
+	for name, tool := range r.GetToolsMap() {
+		if r.toolAuthorized(name, claimsFromAuth) {
+			toolsMap[name] = tool
+		}
+	}
+	return toolsMap
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// mockAuthService verifies a "<name>_token" header, using the token as the
+// subject of the returned claims.
+type mockAuthService struct {
+	name string
+}
+
+var _ auth.AuthService = mockAuthService{}
+
+func (a mockAuthService) AuthServiceKind() string {
+	return "mock"
+}
+
+func (a mockAuthService) GetName() string {
+	return a.name
+}
+
+func (a mockAuthService) GetClaimsFromHeader(_ context.Context, h http.Header) (map[string]any, error) {
+	token := h.Get(a.name + "_token")
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]any{"sub": token, "aud": []any{"toolbox"}}, nil
+}
+
+func TestToolsetAuthorization(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	tc := tools.ToolsetConfig{
+		Name:             "restricted",
+		ToolNames:        []string{tool2.Name},
+		AllowedAudiences: []string{"toolbox"},
+		AllowedSubjects:  []string{"alice"},
+	}
+	restricted, err := tc.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets["restricted"] = restricted
+	// tool2 is only reachable through the restricted toolset
+	delete(toolsets, "tool2_only")
+
+	logger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	authServices := map[string]auth.AuthService{"my-auth": mockAuthService{name: "my-auth"}}
+	s := &Server{
+		logger:      logger,
+		ResourceMgr: NewResourceManager(nil, authServices, toolsMap, toolsets),
+	}
+
+	testCases := []struct {
+		name      string
+		header    http.Header
+		wantTools []string
+	}{
+		{
+			name:      "no credentials",
+			header:    http.Header{},
+			wantTools: []string{tool1.Name},
+		},
+		{
+			name:      "unauthorized subject",
+			header:    http.Header{"My-Auth_token": []string{"bob"}},
+			wantTools: []string{tool1.Name},
+		},
+		{
+			name:      "authorized subject",
+			header:    http.Header{"My-Auth_token": []string{"alice"}},
+			wantTools: []string{tool1.Name, tool2.Name},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := claimsFromHeader(context.Background(), s, tc.header)
+			wantRestricted := len(tc.wantTools) == 2
+
+			if got := restricted.Authorized(claims); got != wantRestricted {
+				t.Errorf("unexpected toolset authorization: want %t, got %t", wantRestricted, got)
+			}
+			if got := s.ResourceMgr.toolAuthorized(tool2.Name, claims); got != wantRestricted {
+				t.Errorf("unexpected tool authorization: want %t, got %t", wantRestricted, got)
+			}
+
+			defaultToolset, _ := s.ResourceMgr.GetToolset("")
+			m := s.ResourceMgr.authorizedToolset(defaultToolset, claims)
+			if len(m.Manifest.ToolsManifest) != len(tc.wantTools) || len(m.McpManifest) != len(tc.wantTools) {
+				t.Fatalf("unexpected tools in default toolset: %v", m.Manifest.ToolsManifest)
+			}
+			for _, name := range tc.wantTools {
+				if _, ok := m.Manifest.ToolsManifest[name]; !ok {
+					t.Errorf("%q tool not found in manifest", name)
+				}
+			}
+			if got := s.ResourceMgr.authorizedToolsMap(claims); len(got) != len(tc.wantTools) {
+				t.Errorf("unexpected number of authorized tools: want %d, got %d", len(tc.wantTools), len(got))
+			}
+		})
+	}
+}
+
+func TestToolsetAllowedClaims(t *testing.T) {
+	toolset := tools.Toolset{
+		AllowedClaims: map[string][]string{
+			"hd":     {"example.com"},
+			"groups": {"admins", "analysts"},
+		},
+	}
+	testCases := []struct {
+		name   string
+		claims map[string]map[string]any
+		want   bool
+	}{
+		{
+			name:   "no credentials",
+			claims: map[string]map[string]any{},
+			want:   false,
+		},
+		{
+			name: "all claims match",
+			claims: map[string]map[string]any{
+				"my-auth": {"hd": "example.com", "groups": []any{"users", "analysts"}},
+			},
+			want: true,
+		},
+		{
+			name: "missing claim",
+			claims: map[string]map[string]any{
+				"my-auth": {"hd": "example.com"},
+			},
+			want: false,
+		},
+		{
+			name: "claim with another value",
+			claims: map[string]map[string]any{
+				"my-auth": {"hd": "other.com", "groups": []any{"admins"}},
+			},
+			want: false,
+		},
+		{
+			name: "claims from different auth services",
+			claims: map[string]map[string]any{
+				"my-auth":    {"hd": "example.com"},
+				"other-auth": {"groups": []any{"admins"}},
+			},
+			want: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := toolset.Authorized(tc.claims); got != tc.want {
+				t.Fatalf("unexpected authorization: want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateClientOAuthServices(t *testing.T) {
+	googleAuth, err := google.Config{Name: "my-google-auth", Kind: google.AuthServiceKind, ClientID: "my-client-id"}.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize auth service: %s", err)
+	}
+	authServicesMap := map[string]auth.AuthService{
+		"my-google-auth": googleAuth,
+		"my-mock-auth":   mockAuthService{"my-mock-auth"},
+	}
+	source := func(authService string) SourceConfigs {
+		return SourceConfigs{"my-http": httpsrc.Config{Name: "my-http", Kind: httpsrc.SourceKind, ClientOAuthService: authService}}
+	}
+	tcs := []struct {
+		desc          string
+		sourceConfigs SourceConfigs
+		wantErr       string
+	}{
+		{desc: "no client oauth", sourceConfigs: source("")},
+		{desc: "access token service", sourceConfigs: source("my-google-auth")},
+		{
+			desc:          "wrapped source",
+			sourceConfigs: SourceConfigs{"my-http": wrappedSourceConfig{SourceConfig: httpsrc.Config{ClientOAuthService: "missing"}, LazyInit: true}},
+			wantErr:       `clientOAuthService "missing" of source "my-http" does not exist`,
+		},
+		{
+			desc:          "missing auth service",
+			sourceConfigs: source("missing"),
+			wantErr:       `clientOAuthService "missing" of source "my-http" does not exist`,
+		},
+		{
+			desc:          "auth service without access tokens",
+			sourceConfigs: source("my-mock-auth"),
+			wantErr:       `clientOAuthService "my-mock-auth" of source "my-http" doesn't verify access tokens, auth services of kind "mock" don't support them`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validateClientOAuthServices(tc.sourceConfigs, authServicesMap)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
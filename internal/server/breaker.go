@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// errCircuitOpen is returned for invocations of tools whose source is failing.
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker stops invoking the tools of a failing source for a while,
+// instead of letting every invocation wait for the source to time out.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failed invocations that
+	// opens the circuit. Defaults to 5.
+	FailureThreshold int `yaml:"failureThreshold" validate:"gte=0"`
+	// ResetTimeout is how long the circuit stays open before an invocation is
+	// let through to probe whether the source has recovered. Defaults to 30s.
+	ResetTimeout time.Duration `yaml:"resetTimeout" validate:"gte=0"`
+}
+
+// popCircuitBreaker removes the circuitBreaker field from a raw source config
+// and returns it, or nil if it isn't set.
+func popCircuitBreaker(ctx context.Context, v map[string]any) (*CircuitBreaker, error) {
+	raw, ok := v["circuitBreaker"]
+	if !ok {
+		return nil, nil
+	}
+	delete(v, "circuitBreaker")
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return nil, err
+	}
+	var cb CircuitBreaker
+	if err := dec.DecodeContext(ctx, &cb); err != nil {
+		return nil, err
+	}
+	if cb.FailureThreshold == 0 {
+		cb.FailureThreshold = 5
+	}
+	if cb.ResetTimeout == 0 {
+		cb.ResetTimeout = 30 * time.Second
+	}
+	return &cb, nil
+}
+
+// breaker states
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker tracks the failures of a source's tools.
+type breaker struct {
+	cfg      CircuitBreaker
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+	lastErr  error
+}
+
+func newBreaker(cfg CircuitBreaker) *breaker {
+	return &breaker{cfg: cfg, mu: sync.Mutex{}}
+}
+
+// allow returns an error if invocations are currently rejected. Once the
+// circuit has been open for ResetTimeout, a single invocation is let through
+// as a probe.
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if wait := b.cfg.ResetTimeout - time.Since(b.openedAt); wait > 0 {
+			return fmt.Errorf("%w after %d consecutive failures, retry in %s. Last error: %w", errCircuitOpen, b.failures, wait.Round(time.Second), b.lastErr)
+		}
+		b.state = breakerHalfOpen
+		return nil
+	case breakerHalfOpen:
+		return fmt.Errorf("%w, waiting for the source to recover. Last error: %w", errCircuitOpen, b.lastErr)
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker with the outcome of an invocation.
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.state = breakerClosed
+		b.failures = 0
+		b.lastErr = nil
+		return
+	}
+	b.failures++
+	b.lastErr = err
+	if b.state == breakerHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// release returns a half open circuit to open without counting a failure, so
+// the next invocation can probe again.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+		b.openedAt = time.Now().Add(-b.cfg.ResetTimeout)
+	}
+}
+
+var _ tools.Tool = breakerTool{}
+
+// breakerTool fails fast while the circuit breaker of its source is open.
+type breakerTool struct {
+	tools.Tool
+	sourceName string
+	breaker    *breaker
+}
+
+func newBreakerTool(sourceName string, tool tools.Tool, b *breaker) breakerTool {
+	return breakerTool{Tool: tool, sourceName: sourceName, breaker: b}
+}
+
+func (t breakerTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	if err := t.breaker.allow(); err != nil {
+		return nil, fmt.Errorf("source %q is unavailable: %w", t.sourceName, err)
+	}
+	res, err := t.Tool.Invoke(ctx, params)
+	switch {
+	case err != nil && (ctx.Err() != nil || errors.Is(err, errRateLimited) || errors.Is(err, errTooManyInvocations) || errors.As(err, new(*sqlpolicy.ViolationError))):
+		// cancelled, rate limited, concurrency limited and policy violating
+		// invocations say nothing about the source, but a probe must not leave
+		// the circuit half open
+		t.breaker.release()
+	default:
+		t.breaker.record(err)
+	}
+	return res, err
+}
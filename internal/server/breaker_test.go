@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// failingTool is a MockTool that fails while fail is true.
+type failingTool struct {
+	MockTool
+	fail  *bool
+	count *int
+}
+
+func (t failingTool) Invoke(context.Context, tools.ParamValues) (any, error) {
+	*t.count++
+	if *t.fail {
+		return nil, errors.New("connection refused")
+	}
+	return []any{"ok"}, nil
+}
+
+func TestPopCircuitBreaker(t *testing.T) {
+	v := map[string]any{"kind": "mock", "circuitBreaker": map[string]any{"failureThreshold": 3}}
+	got, err := popCircuitBreaker(context.Background(), v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &CircuitBreaker{FailureThreshold: 3, ResetTimeout: 30 * time.Second}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect circuit breaker: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("circuit breaker was not removed from config: diff %v", diff)
+	}
+
+	if _, err := popCircuitBreaker(context.Background(), map[string]any{"circuitBreaker": map[string]any{"resetTimeout": "soon"}}); err == nil {
+		t.Fatalf("expected error for invalid resetTimeout")
+	}
+}
+
+func TestBreakerTool(t *testing.T) {
+	fail, count := true, 0
+	b := newBreaker(CircuitBreaker{FailureThreshold: 2, ResetTimeout: time.Hour})
+	tool := newBreakerTool("my-source", failingTool{MockTool: tool1, fail: &fail, count: &count}, b)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := tool.Invoke(ctx, nil); err == nil || errors.Is(err, errCircuitOpen) {
+			t.Fatalf("expected the tool's error, got %v", err)
+		}
+	}
+	// the circuit is open, so the tool isn't invoked
+	if _, err := tool.Invoke(ctx, nil); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected open circuit error, got %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 invocations, got %d", count)
+	}
+
+	// once the reset timeout passes, a successful probe closes the circuit
+	b.openedAt = time.Now().Add(-time.Hour)
+	fail = false
+	if _, err := tool.Invoke(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := tool.Invoke(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// a failed probe opens the circuit again
+	fail = true
+	for i := 0; i < 2; i++ {
+		_, _ = tool.Invoke(ctx, nil)
+	}
+	b.openedAt = time.Now().Add(-time.Hour)
+	if _, err := tool.Invoke(ctx, nil); err == nil || errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected the probe to fail, got %v", err)
+	}
+	if _, err := tool.Invoke(ctx, nil); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected open circuit error, got %v", err)
+	}
+}
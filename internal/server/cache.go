@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/redis/go-redis/v9"
+)
+
+// CacheConfig configures where cached tool results are stored.
+type CacheConfig struct {
+	// MaxEntries is the maximum number of results held in memory.
+	MaxEntries int
+	// RedisURL, if set, stores results in Redis instead of in memory.
+	RedisURL string
+}
+
+// resultCache stores tool results for a limited time.
+type resultCache interface {
+	get(ctx context.Context, key string) (any, bool, error)
+	set(ctx context.Context, key string, result any, ttl time.Duration) error
+}
+
+func newResultCache(ctx context.Context, cfg CacheConfig) (resultCache, error) {
+	if cfg.RedisURL == "" {
+		return newLRUCache(cfg.MaxEntries), nil
+	}
+	opts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache redis url: %w", err)
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("unable to connect to cache redis: %w", err)
+	}
+	return redisCache{client: client}, nil
+}
+
+// cacheKey returns the key of a tool result for the given parameters and the
+// OAuth access tokens of the caller. Tools of sources that forward the access
+// token of the caller return the rows the caller is allowed to read, so their
+// results are only served to callers with the same tokens.
+func cacheKey(toolName string, params tools.ParamValues, accessTokens map[string]string) (string, error) {
+	// maps are encoded with sorted keys, so the order of parameters is ignored
+	b, err := json.Marshal(params.AsMap())
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(toolName + "\x00"))
+	h.Write(b)
+	if len(accessTokens) > 0 {
+		t, err := json.Marshal(accessTokens)
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte("\x00"))
+		h.Write(t)
+	}
+	return "toolbox:cache:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// lruCache is an in-memory resultCache that evicts the least recently used
+// result once it is full.
+type lruCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key    string
+	result any
+	expiry time.Time
+}
+
+func newLRUCache(maxEntries int) *lruCache {
+	return &lruCache{
+		mu:         sync.Mutex{},
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) get(_ context.Context, key string) (any, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := e.Value.(*lruEntry)
+	if time.Now().After(entry.expiry) {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(e)
+	return entry.result, true, nil
+}
+
+func (c *lruCache) set(_ context.Context, key string, result any, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &lruEntry{key: key, result: result, expiry: time.Now().Add(ttl)}
+	if e, ok := c.entries[key]; ok {
+		e.Value = entry
+		c.order.MoveToFront(e)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+// redisCache is a resultCache that stores results in Redis, so they can be
+// shared by every instance of Toolbox.
+type redisCache struct {
+	client *redis.Client
+}
+
+func (c redisCache) get(ctx context.Context, key string) (any, bool, error) {
+	b, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var result any
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, false, err
+	}
+	return result, true, nil
+}
+
+func (c redisCache) set(ctx context.Context, key string, result any, ttl time.Duration) error {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, key, b, ttl).Err()
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// countingTool is a MockTool that counts its invocations.
+type countingTool struct {
+	MockTool
+	count *int
+}
+
+func (t countingTool) Invoke(context.Context, tools.ParamValues) (any, error) {
+	*t.count++
+	return []any{*t.count}, nil
+}
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	c := newLRUCache(2)
+	_ = c.set(ctx, "a", 1, time.Minute)
+	_ = c.set(ctx, "b", 2, time.Minute)
+	// reading "a" makes "b" the least recently used
+	if _, ok, _ := c.get(ctx, "a"); !ok {
+		t.Fatalf("expected a cached result for %q", "a")
+	}
+	_ = c.set(ctx, "c", 3, time.Minute)
+	if _, ok, _ := c.get(ctx, "b"); ok {
+		t.Fatalf("expected %q to be evicted", "b")
+	}
+
+	_ = c.set(ctx, "d", 4, -time.Second)
+	if _, ok, _ := c.get(ctx, "d"); ok {
+		t.Fatalf("expected %q to be expired", "d")
+	}
+}
+
+func TestCachedTool(t *testing.T) {
+	var count int
+	tool := newCachedTool(tool2.Name, countingTool{MockTool: tool2, count: &count}, time.Minute, newLRUCache(10))
+	invoke := func(data map[string]any) any {
+		params, err := tool.ParseParams(data, nil)
+		if err != nil {
+			t.Fatalf("unable to parse params: %s", err)
+		}
+		res, err := tool.Invoke(context.Background(), params)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return res.([]any)[0]
+	}
+
+	if got := invoke(map[string]any{"param1": 1, "param2": 2}); got != 1 {
+		t.Fatalf("expected first invocation, got %v", got)
+	}
+	if got := invoke(map[string]any{"param2": 2, "param1": 1}); got != 1 {
+		t.Fatalf("expected cached result, got %v", got)
+	}
+	if got := invoke(map[string]any{"param1": 1, "param2": 3}); got != 2 {
+		t.Fatalf("expected a new invocation for different parameters, got %v", got)
+	}
+}
+
+func TestPopCacheTTL(t *testing.T) {
+	v := map[string]any{"kind": "mock", "cacheTTL": "5m"}
+	got, err := popCacheTTL(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != 5*time.Minute {
+		t.Fatalf("incorrect ttl: got %s", got)
+	}
+	if _, ok := v["cacheTTL"]; ok {
+		t.Fatalf("cacheTTL was not removed from config")
+	}
+
+	for _, val := range []any{"soon", "-1m", 5} {
+		if _, err := popCacheTTL(map[string]any{"cacheTTL": val}); err == nil {
+			t.Fatalf("expected error for cacheTTL %v", val)
+		}
+	}
+}
+
+func TestCachedToolAccessTokens(t *testing.T) {
+	var count int
+	tool := newCachedTool(tool2.Name, countingTool{MockTool: tool2, count: &count}, time.Minute, newLRUCache(10))
+	params, err := tool.ParseParams(map[string]any{"param1": 1, "param2": 2}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	invoke := func(token string) any {
+		ctx := util.WithAccessTokens(context.Background(), map[string]string{"my-google-auth": token})
+		res, err := tool.Invoke(ctx, params)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return res.([]any)[0]
+	}
+
+	if got := invoke("token-a"); got != 1 {
+		t.Fatalf("expected first invocation, got %v", got)
+	}
+	if got := invoke("token-a"); got != 1 {
+		t.Fatalf("expected cached result for the same token, got %v", got)
+	}
+	// the result read with the token of another caller is never served
+	if got := invoke("token-b"); got != 2 {
+		t.Fatalf("expected a new invocation for a different token, got %v", got)
+	}
+}
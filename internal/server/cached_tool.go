@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+var _ tools.Tool = cachedTool{}
+
+// cachedTool returns the cached result of the wrapped tool for repeated
+// invocations with the same parameters.
+type cachedTool struct {
+	tools.Tool
+	name  string
+	ttl   time.Duration
+	cache resultCache
+}
+
+func newCachedTool(name string, tool tools.Tool, ttl time.Duration, cache resultCache) cachedTool {
+	return cachedTool{Tool: tool, name: name, ttl: ttl, cache: cache}
+}
+
+func (t cachedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	// the whole result is cached, so its rows aren't streamed
+	ctx = util.WithRowWriter(ctx, nil)
+	if dryRunFromContext(ctx) {
+		// the result of a dry run is neither cached nor served from the cache
+		return t.Tool.Invoke(ctx, params)
+	}
+	key, err := cacheKey(t.name, params, util.AccessTokensFromContext(ctx))
+	if err != nil {
+		// parameters that can't be encoded are never cached
+		return t.Tool.Invoke(ctx, params)
+	}
+	if res, ok, err := t.cache.get(ctx, key); err != nil {
+		logCacheError(ctx, fmt.Errorf("unable to read cached result of tool %q: %w", t.name, err))
+	} else if ok {
+		return res, nil
+	}
+
+	res, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return res, err
+	}
+	if err := t.cache.set(ctx, key, res, t.ttl); err != nil {
+		logCacheError(ctx, fmt.Errorf("unable to cache result of tool %q: %w", t.name, err))
+	}
+	return res, nil
+}
+
+// logCacheError logs a cache failure. A failing cache never fails the
+// invocation, the tool is invoked as if nothing was cached.
+func logCacheError(ctx context.Context, err error) {
+	logger, lErr := util.LoggerFromContext(ctx)
+	if lErr != nil {
+		return
+	}
+	logger.WarnContext(ctx, err.Error())
+}
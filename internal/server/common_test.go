@@ -24,9 +24,11 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 // fakeVersionString is used as a temporary version string in tests
@@ -42,7 +44,8 @@ type MockTool struct {
 	manifest    tools.Manifest
 }
 
-func (t MockTool) Invoke(context.Context, tools.ParamValues) (any, error) {
+func (t MockTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	util.ReportProgress(ctx, 1, 1, "invoked")
 	mock := []any{t.Name}
 	return mock, nil
 }
@@ -142,7 +145,7 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 		t.Fatalf("unable to initialize logger: %s", err)
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, false, "toolbox")
 	if err != nil {
 		t.Fatalf("unable to setup otel: %s", err)
 	}
@@ -153,15 +156,26 @@ func setUpServer(t *testing.T, router string, tools map[string]tools.Tool, tools
 	}
 
 	sseManager := newSseManager(ctx)
+	operationManager := newOperationManager(ctx)
 
-	resourceManager := NewResourceManager(nil, nil, tools, toolsets)
+	// reviewers of approvals are authenticated with a "reviewers_token" header,
+	// and other callers with a "callers_token" header
+	authServices := map[string]auth.AuthService{
+		"reviewers": mockAuthService{name: "reviewers"},
+		"callers":   mockAuthService{name: "callers"},
+	}
+	resourceManager := NewResourceManager(nil, authServices, tools, toolsets)
 
 	server := Server{
-		version:         fakeVersionString,
-		logger:          testLogger,
-		instrumentation: instrumentation,
-		sseManager:      sseManager,
-		ResourceMgr:     resourceManager,
+		version:           fakeVersionString,
+		logger:            testLogger,
+		instrumentation:   instrumentation,
+		sseManager:        sseManager,
+		mcpSessionManager: newMcpSessionManager(ctx),
+		operationManager:  operationManager,
+		invocationManager: newInvocationManager(),
+		approvalManager:   newApprovalManager(ctx, ApprovalConfig{AuthService: "reviewers"}, testLogger),
+		ResourceMgr:       resourceManager,
 	}
 
 	var r chi.Router
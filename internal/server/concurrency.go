@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// errTooManyInvocations is returned for invocations that can't start because
+// their source already runs as many invocations as it allows.
+var errTooManyInvocations = errors.New("too many concurrent invocations")
+
+// ConcurrencyLimit is the number of invocations the tools of a source can run
+// at once.
+type ConcurrencyLimit struct {
+	// MaxConcurrentInvocations is the number of invocations allowed at once.
+	MaxConcurrentInvocations int
+	// QueueTimeout is how long an invocation waits for another to finish
+	// once the limit is reached. 0 fails it right away.
+	QueueTimeout time.Duration
+}
+
+// popConcurrencyLimit removes the maxConcurrentInvocations and queueTimeout
+// fields from a raw source config and returns them, or nil if
+// maxConcurrentInvocations isn't set.
+func popConcurrencyLimit(v map[string]any) (*ConcurrencyLimit, error) {
+	rawMax, hasMax := v["maxConcurrentInvocations"]
+	rawTimeout, hasTimeout := v["queueTimeout"]
+	delete(v, "maxConcurrentInvocations")
+	delete(v, "queueTimeout")
+	if !hasMax {
+		if hasTimeout {
+			return nil, fmt.Errorf("'queueTimeout' requires 'maxConcurrentInvocations'")
+		}
+		return nil, nil
+	}
+	var limit ConcurrencyLimit
+	switch m := rawMax.(type) {
+	case uint64:
+		limit.MaxConcurrentInvocations = int(m)
+	case int64:
+		limit.MaxConcurrentInvocations = int(m)
+	case int:
+		limit.MaxConcurrentInvocations = m
+	}
+	if limit.MaxConcurrentInvocations <= 0 {
+		return nil, fmt.Errorf("'maxConcurrentInvocations' must be an integer greater than 0")
+	}
+	if hasTimeout {
+		s, ok := rawTimeout.(string)
+		if !ok {
+			return nil, fmt.Errorf("'queueTimeout' must be a duration string, such as \"5s\"")
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse 'queueTimeout': %w", err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("'queueTimeout' must not be negative")
+		}
+		limit.QueueTimeout = d
+	}
+	return &limit, nil
+}
+
+// concurrencyLimiter is a semaphore shared by the tools of a source.
+type concurrencyLimiter struct {
+	limit ConcurrencyLimit
+	sem   chan struct{}
+}
+
+func newConcurrencyLimiter(limit ConcurrencyLimit) *concurrencyLimiter {
+	return &concurrencyLimiter{limit: limit, sem: make(chan struct{}, limit.MaxConcurrentInvocations)}
+}
+
+// acquire takes a slot, waiting up to QueueTimeout for one to be released.
+func (l *concurrencyLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	default:
+	}
+	if l.limit.QueueTimeout == 0 {
+		return errTooManyInvocations
+	}
+	timer := time.NewTimer(l.limit.QueueTimeout)
+	defer timer.Stop()
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w, waited %s", errTooManyInvocations, l.limit.QueueTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by acquire.
+func (l *concurrencyLimiter) release() {
+	<-l.sem
+}
+
+var _ tools.Tool = concurrencyLimitedTool{}
+
+// concurrencyLimitedTool waits for, or fails, invocations over the
+// concurrency limit of its source.
+type concurrencyLimitedTool struct {
+	tools.Tool
+	sourceName string
+	limiter    *concurrencyLimiter
+}
+
+func newConcurrencyLimitedTool(sourceName string, tool tools.Tool, l *concurrencyLimiter) concurrencyLimitedTool {
+	return concurrencyLimitedTool{Tool: tool, sourceName: sourceName, limiter: l}
+}
+
+func (t concurrencyLimitedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	if err := t.limiter.acquire(ctx); err != nil {
+		if errors.Is(err, errTooManyInvocations) {
+			return nil, fmt.Errorf("source %q allows %d concurrent invocations: %w, please retry later", t.sourceName, t.limiter.limit.MaxConcurrentInvocations, err)
+		}
+		return nil, err
+	}
+	defer t.limiter.release()
+	return t.Tool.Invoke(ctx, params)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPopConcurrencyLimit(t *testing.T) {
+	var v map[string]any
+	if err := yaml.Unmarshal([]byte("kind: mock\nmaxConcurrentInvocations: 4\nqueueTimeout: 5s\n"), &v); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	got, err := popConcurrencyLimit(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &ConcurrencyLimit{MaxConcurrentInvocations: 4, QueueTimeout: 5 * time.Second}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect concurrency limit: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("concurrency limit was not removed from config: diff %v", diff)
+	}
+
+	if got, err := popConcurrencyLimit(map[string]any{"kind": "mock"}); got != nil || err != nil {
+		t.Fatalf("expected no concurrency limit, got %v, %v", got, err)
+	}
+
+	for _, in := range []string{
+		"maxConcurrentInvocations: 0",
+		"maxConcurrentInvocations: -1",
+		"maxConcurrentInvocations: many",
+		"maxConcurrentInvocations: 2\nqueueTimeout: soon",
+		"queueTimeout: 5s",
+	} {
+		var v map[string]any
+		if err := yaml.Unmarshal([]byte(in), &v); err != nil {
+			t.Fatalf("unable to unmarshal: %s", err)
+		}
+		if _, err := popConcurrencyLimit(v); err == nil {
+			t.Fatalf("expected error for %q", in)
+		}
+	}
+}
+
+func TestConcurrencyLimitedTool(t *testing.T) {
+	l := newConcurrencyLimiter(ConcurrencyLimit{MaxConcurrentInvocations: 1})
+	slow := newConcurrencyLimitedTool("my-source", slowTool{MockTool: tool1}, l)
+	fast := newConcurrencyLimitedTool("my-source", tool1, l)
+
+	// a slow invocation takes the only slot until it is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = slow.Invoke(ctx, nil)
+	}()
+	for len(l.sem) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	_, err := fast.Invoke(context.Background(), nil)
+	if !errors.Is(err, errTooManyInvocations) {
+		t.Fatalf("expected too many invocations error, got %v", err)
+	}
+	if got := invokeErrorStatus(err); got != http.StatusTooManyRequests {
+		t.Fatalf("incorrect status: got %d", got)
+	}
+
+	// a queued invocation waits for the slot to be released
+	l.limit.QueueTimeout = time.Minute
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := fast.Invoke(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	<-done
+
+	// or fails once its queue timeout passes
+	l.limit.QueueTimeout = 10 * time.Millisecond
+	l.sem <- struct{}{}
+	if _, err := fast.Invoke(context.Background(), nil); !errors.Is(err, errTooManyInvocations) {
+		t.Fatalf("expected too many invocations error, got %v", err)
+	}
+	l.release()
+	if len(l.sem) != 0 {
+		t.Fatalf("expected every slot to be released, %d are taken", len(l.sem))
+	}
+}
@@ -17,10 +17,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/auth/apikey"
 	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	"github.com/googleapis/genai-toolbox/internal/auth/oidc"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
@@ -33,6 +37,13 @@ type ServerConfig struct {
 	Address string
 	// Port is the port the server will listen on.
 	Port int
+	// Listen replaces Address and Port with a Unix domain socket
+	// ("unix:<path>"), or with the socket passed by systemd socket activation
+	// ("systemd").
+	Listen string
+	// TLS defines whether the server serves TLS, and whether it requires
+	// client certificates.
+	TLS TLSConfig
 	// SourceConfigs defines what sources of data are available for tools.
 	SourceConfigs SourceConfigs
 	// AuthServiceConfigs defines what sources of authentication are available for tools.
@@ -41,6 +52,8 @@ type ServerConfig struct {
 	ToolConfigs ToolConfigs
 	// ToolsetConfigs defines what tools are available.
 	ToolsetConfigs ToolsetConfigs
+	// PromptConfigs defines the prompt templates served to MCP clients.
+	PromptConfigs PromptConfigs
 	// LoggingFormat defines whether structured loggings are used.
 	LoggingFormat logFormat
 	// LogLevel defines the levels to log.
@@ -49,12 +62,45 @@ type ServerConfig struct {
 	TelemetryGCP bool
 	// TelemetryOTLP defines OTLP collector url for telemetry exports.
 	TelemetryOTLP string
+	// TelemetryPrometheus defines whether metrics are served in the Prometheus format on /metrics.
+	TelemetryPrometheus bool
 	// TelemetryServiceName defines the value of service.name resource attribute.
 	TelemetryServiceName string
 	// Stdio indicates if Toolbox is listening via MCP stdio.
 	Stdio bool
+	// StdioToolsets are the toolsets whose tools are served via MCP stdio.
+	// Every tool is served if it is empty.
+	StdioToolsets []string
 	// DisableReload indicates if the user has disabled dynamic reloading for Toolbox.
 	DisableReload bool
+	// ResponseLimits defines the default response limits for every tool.
+	ResponseLimits tools.ResponseLimits
+	// Cache defines where the results of tools with a cacheTTL are stored.
+	Cache CacheConfig
+	// AllowDegraded starts the server even if some sources fail to initialize.
+	// Those sources are initialized again on first use.
+	AllowDegraded bool
+	// McpResourceThreshold is the size in bytes above which MCP tool results
+	// are returned as resources instead of inline. 0 means always inline.
+	McpResourceThreshold int
+	// Audit defines where tool invocations are audited.
+	Audit AuditConfig
+	// SensitiveParams are glob patterns of the names of parameters whose
+	// values are redacted from logs, traces, and errors, in every tool.
+	SensitiveParams []string
+	// PIIDetectors are the built-in detectors of personal information that
+	// is masked in the results of tools that don't set their own piiMasking.
+	PIIDetectors []string
+	// Approvals defines how invocations of tools that require approval are
+	// reviewed.
+	Approvals ApprovalConfig
+	// DryRun makes every invocation of every tool a dry run.
+	DryRun bool
+	// ToolTimeout is the default time an invocation of a tool may take before
+	// it is cancelled. 0 means unbounded.
+	ToolTimeout time.Duration
+	// ResultFormat is the default format of the rows of tool results.
+	ResultFormat tools.ResultFormat
 }
 
 type logFormat string
@@ -139,6 +185,26 @@ func (c *SourceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interf
 			return fmt.Errorf("invalid 'kind' field for source %q (must be a string)", name)
 		}
 
+		// rate limits, concurrency limits, circuit breakers and lazy
+		// initialization are shared by every kind of source, so they are removed before decoding the kind
+		// specific config
+		rateLimit, err := popRateLimit(ctx, v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'rateLimit' for source %q: %w", name, err)
+		}
+		concurrencyLimit, err := popConcurrencyLimit(v)
+		if err != nil {
+			return fmt.Errorf("unable to parse concurrency limit for source %q: %w", name, err)
+		}
+		circuitBreaker, err := popCircuitBreaker(ctx, v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'circuitBreaker' for source %q: %w", name, err)
+		}
+		lazyInit, err := popLazyInit(v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'lazyInit' for source %q: %w", name, err)
+		}
+
 		yamlDecoder, err := util.NewStrictDecoder(v)
 		if err != nil {
 			return fmt.Errorf("error creating YAML decoder for source %q: %w", name, err)
@@ -148,11 +214,39 @@ func (c *SourceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interf
 		if err != nil {
 			return err
 		}
+		if rateLimit != nil || concurrencyLimit != nil || circuitBreaker != nil || lazyInit {
+			sourceConfig = wrappedSourceConfig{SourceConfig: sourceConfig, RateLimit: rateLimit, ConcurrencyLimit: concurrencyLimit, CircuitBreaker: circuitBreaker, LazyInit: lazyInit}
+		}
 		(*c)[name] = sourceConfig
 	}
 	return nil
 }
 
+// wrappedSourceConfig is a source config that sets a rate limit, concurrency
+// limit or circuit breaker for its tools, or is initialized lazily.
+type wrappedSourceConfig struct {
+	sources.SourceConfig
+	RateLimit        *RateLimit
+	ConcurrencyLimit *ConcurrencyLimit
+	CircuitBreaker   *CircuitBreaker
+	LazyInit         bool
+}
+
+// popLazyInit removes the lazyInit field from a raw source config and returns
+// it.
+func popLazyInit(v map[string]any) (bool, error) {
+	raw, ok := v["lazyInit"]
+	if !ok {
+		return false, nil
+	}
+	delete(v, "lazyInit")
+	lazyInit, ok := raw.(bool)
+	if !ok {
+		return false, fmt.Errorf("must be a boolean, got %v", raw)
+	}
+	return lazyInit, nil
+}
+
 // AuthServiceConfigs is a type used to allow unmarshal of the data authService config map
 type AuthServiceConfigs map[string]auth.AuthServiceConfig
 
@@ -183,12 +277,24 @@ func (c *AuthServiceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(i
 			return fmt.Errorf("error creating decoder: %w", err)
 		}
 		switch kind {
+		case apikey.AuthServiceKind:
+			actual := apikey.Config{Name: name}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			}
+			(*c)[name] = actual
 		case google.AuthServiceKind:
 			actual := google.Config{Name: name}
 			if err := dec.DecodeContext(ctx, &actual); err != nil {
 				return fmt.Errorf("unable to parse as %q: %w", kind, err)
 			}
 			(*c)[name] = actual
+		case oidc.AuthServiceKind:
+			actual := oidc.Config{Name: name}
+			if err := dec.DecodeContext(ctx, &actual); err != nil {
+				return fmt.Errorf("unable to parse as %q: %w", kind, err)
+			}
+			(*c)[name] = actual
 		default:
 			return fmt.Errorf("%q is not a valid kind of auth source", kind)
 		}
@@ -230,20 +336,136 @@ func (c *ToolConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interfac
 			return fmt.Errorf("invalid 'kind' field for tool %q (must be a string)", name)
 		}
 
-		yamlDecoder, err := util.NewStrictDecoder(v)
+		// response limits, caching, rate limits, annotations, PII masking,
+		// approvals, dry runs, result formats, normalization, and timeouts are
+		// shared by every kind of tool, so they are removed before decoding the
+		// kind specific config
+		limits, err := popResponseLimits(ctx, v)
 		if err != nil {
-			return fmt.Errorf("error creating YAML decoder for tool %q: %w", name, err)
+			return fmt.Errorf("unable to parse response limits for tool %q: %w", name, err)
+		}
+		cacheTTL, err := popCacheTTL(v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'cacheTTL' for tool %q: %w", name, err)
+		}
+		rateLimit, err := popRateLimit(ctx, v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'rateLimit' for tool %q: %w", name, err)
+		}
+		annotations, err := popAnnotations(ctx, v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'annotations' for tool %q: %w", name, err)
+		}
+		piiMasking, err := popPIIMasking(ctx, v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'piiMasking' for tool %q: %w", name, err)
+		}
+		requiresApproval, err := popRequiresApproval(v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'requiresApproval' for tool %q: %w", name, err)
+		}
+		dryRun, err := popDryRun(v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'dryRun' for tool %q: %w", name, err)
+		}
+		resultFormat, err := popResultFormat(v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'resultFormat' for tool %q: %w", name, err)
+		}
+		normalization, err := popNormalization(ctx, v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'normalization' for tool %q: %w", name, err)
+		}
+
+		timeout, err := popTimeout(kindStr, v)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'timeout' for tool %q: %w", name, err)
 		}
 
-		toolCfg, err := tools.DecodeConfig(ctx, kindStr, name, yamlDecoder)
+		toolCfg, err := decodeToolConfig(ctx, kindStr, name, v)
 		if err != nil {
 			return err
 		}
+		if limits.Enabled() || limits.Truncation != "" || cacheTTL > 0 || rateLimit != nil || annotations != nil || piiMasking != nil || requiresApproval || dryRun || timeout > 0 || resultFormat != "" || normalization != nil {
+			toolCfg = wrappedToolConfig{ToolConfig: toolCfg, Limits: limits, CacheTTL: cacheTTL, RateLimit: rateLimit, Annotations: annotations, PIIMasking: piiMasking, RequiresApproval: requiresApproval, DryRun: dryRun, Timeout: timeout, ResultFormat: resultFormat, Normalization: normalization}
+		}
 		(*c)[name] = toolCfg
 	}
 	return nil
 }
 
+// decodeToolConfig decodes a raw tool config as the given kind.
+func decodeToolConfig(ctx context.Context, kind, name string, v map[string]any) (tools.ToolConfig, error) {
+	yamlDecoder, err := util.NewStrictDecoder(v)
+	if err != nil {
+		return nil, fmt.Errorf("error creating YAML decoder for tool %q: %w", name, err)
+	}
+	return tools.DecodeConfig(ctx, kind, name, yamlDecoder)
+}
+
+// popResponseLimits removes the response limit fields from a raw tool config
+// and returns them.
+func popResponseLimits(ctx context.Context, v map[string]any) (tools.ResponseLimits, error) {
+	var limits tools.ResponseLimits
+	raw := make(map[string]any)
+	for _, key := range []string{"maxResponseBytes", "maxRows", "truncation"} {
+		if val, ok := v[key]; ok {
+			raw[key] = val
+			delete(v, key)
+		}
+	}
+	if len(raw) == 0 {
+		return limits, nil
+	}
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return limits, err
+	}
+	if err := dec.DecodeContext(ctx, &limits); err != nil {
+		return limits, err
+	}
+	return limits, nil
+}
+
+// popCacheTTL removes the cacheTTL field from a raw tool config and returns
+// it. Tools without a cacheTTL aren't cached.
+func popCacheTTL(v map[string]any) (time.Duration, error) {
+	val, ok := v["cacheTTL"]
+	if !ok {
+		return 0, nil
+	}
+	delete(v, "cacheTTL")
+	s, ok := val.(string)
+	if !ok {
+		return 0, fmt.Errorf("must be a duration string, such as \"5m\"")
+	}
+	ttl, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("must be greater than 0")
+	}
+	return ttl, nil
+}
+
+// wrappedToolConfig is a tool config that sets its own response limits,
+// caching, rate limit, annotations, PII masking, requires approval, only runs
+// dry, sets its own timeout or result format, or normalizes its rows.
+type wrappedToolConfig struct {
+	tools.ToolConfig
+	Limits           tools.ResponseLimits
+	CacheTTL         time.Duration
+	RateLimit        *RateLimit
+	Annotations      *tools.ToolAnnotations
+	PIIMasking       *PIIMasking
+	RequiresApproval bool
+	DryRun           bool
+	Timeout          time.Duration
+	ResultFormat     tools.ResultFormat
+	Normalization    *Normalization
+}
+
 // ToolConfigs is a type used to allow unmarshal of the toolset configs
 type ToolsetConfigs map[string]tools.ToolsetConfig
 
@@ -253,13 +475,78 @@ var _ yaml.InterfaceUnmarshalerContext = &ToolsetConfigs{}
 func (c *ToolsetConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
 	*c = make(ToolsetConfigs)
 
-	var raw map[string][]string
+	var raw map[string]util.DelayedUnmarshaler
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	for name, u := range raw {
+		// a toolset is either a plain list of tool names, or a mapping that
+		// also declares an authorization policy and options
+		var toolList []string
+		if err := u.Unmarshal(&toolList); err == nil {
+			(*c)[name] = tools.ToolsetConfig{Name: name, ToolNames: toolList}
+			continue
+		}
+
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			return fmt.Errorf("unable to unmarshal toolset %q: must be a list of tool names or a mapping: %w", name, err)
+		}
+		yamlDecoder, err := util.NewStrictDecoder(v)
+		if err != nil {
+			return fmt.Errorf("error creating YAML decoder for toolset %q: %w", name, err)
+		}
+		var policy struct {
+			Tools            []string            `yaml:"tools" validate:"required"`
+			AllowedAudiences []string            `yaml:"allowedAudiences"`
+			AllowedSubjects  []string            `yaml:"allowedSubjects"`
+			AllowedClaims    map[string][]string `yaml:"allowedClaims"`
+			SourceResources  bool                `yaml:"sourceResources"`
+		}
+		if err := yamlDecoder.DecodeContext(ctx, &policy); err != nil {
+			return fmt.Errorf("unable to parse toolset %q: %w", name, err)
+		}
+		(*c)[name] = tools.ToolsetConfig{
+			Name:             name,
+			ToolNames:        policy.Tools,
+			AllowedAudiences: policy.AllowedAudiences,
+			AllowedSubjects:  policy.AllowedSubjects,
+			AllowedClaims:    policy.AllowedClaims,
+			SourceResources:  policy.SourceResources,
+		}
+	}
+	return nil
+}
+
+// PromptConfigs is a type used to allow unmarshal of the prompt configs
+type PromptConfigs map[string]prompts.PromptConfig
+
+// validate interface
+var _ yaml.InterfaceUnmarshalerContext = &PromptConfigs{}
+
+func (c *PromptConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	*c = make(PromptConfigs)
+
+	var raw map[string]util.DelayedUnmarshaler
 	if err := unmarshal(&raw); err != nil {
 		return err
 	}
 
-	for name, toolList := range raw {
-		(*c)[name] = tools.ToolsetConfig{Name: name, ToolNames: toolList}
+	for name, u := range raw {
+		var v map[string]any
+		if err := u.Unmarshal(&v); err != nil {
+			return fmt.Errorf("unable to unmarshal prompt %q: %w", name, err)
+		}
+		yamlDecoder, err := util.NewStrictDecoder(v)
+		if err != nil {
+			return fmt.Errorf("error creating YAML decoder for prompt %q: %w", name, err)
+		}
+		pc := prompts.PromptConfig{Name: name}
+		if err := yamlDecoder.DecodeContext(ctx, &pc); err != nil {
+			return fmt.Errorf("unable to parse prompt %q: %w", name, err)
+		}
+		(*c)[name] = pc
 	}
 	return nil
 }
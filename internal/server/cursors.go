@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// cursorRetention is how long the remaining pages of a result can be
+	// fetched after the last page was returned.
+	cursorRetention = 10 * time.Minute
+	// maxCursors is the maximum number of paginated results held at once.
+	maxCursors = 1000
+	// maxCursorBytes is the maximum size of the rows of all the paginated
+	// results held at once, encoded as JSON.
+	maxCursorBytes = 64 << 20
+)
+
+// cursors holds paginated results. It is shared by every tool, so pages can
+// still be fetched after the tools file is reloaded.
+var cursors = newCursorStore()
+
+// cursor is a tool result whose rows are being returned one page at a time.
+type cursor struct {
+	toolName string
+	// caller identifies the caller the result was returned to, who is the
+	// only one who can fetch its other pages, see util.CallerIdentity
+	caller string
+	rows   []any
+	size   int
+	expiry time.Time
+}
+
+// cursorStore manages and control access to paginated results.
+type cursorStore struct {
+	mu       sync.Mutex
+	cursors  map[string]*cursor
+	size     int
+	maxBytes int
+}
+
+func newCursorStore() *cursorStore {
+	return &cursorStore{
+		mu:       sync.Mutex{},
+		cursors:  make(map[string]*cursor),
+		maxBytes: maxCursorBytes,
+	}
+}
+
+// rowsSize returns the size of rows encoded as JSON.
+func rowsSize(rows []any) int {
+	size := 0
+	for _, row := range rows {
+		b, err := json.Marshal(row)
+		if err != nil {
+			continue
+		}
+		size += len(b)
+	}
+	return size
+}
+
+// add stores the rows of a result returned to caller and returns the cursor's
+// id. Results too large to be held aren't stored.
+func (s *cursorStore) add(toolName, caller string, rows []any) (string, bool) {
+	size := rowsSize(rows)
+	if size > s.maxBytes {
+		return "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for id, c := range s.cursors {
+		if now.After(c.expiry) {
+			s.removeLocked(id)
+		}
+	}
+	for len(s.cursors) > 0 && (len(s.cursors) >= maxCursors || s.size+size > s.maxBytes) {
+		// evict the cursor closest to expiring
+		var oldest string
+		for id, c := range s.cursors {
+			if oldest == "" || c.expiry.Before(s.cursors[oldest].expiry) {
+				oldest = id
+			}
+		}
+		s.removeLocked(oldest)
+	}
+	id := uuid.New().String()
+	s.cursors[id] = &cursor{toolName: toolName, caller: caller, rows: rows, size: size, expiry: now.Add(cursorRetention)}
+	s.size += size
+	return id, true
+}
+
+// get returns the rows of a result and extends its expiry. Only the caller
+// the result was returned to can get it.
+func (s *cursorStore) get(id, toolName, caller string) ([]any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.cursors[id]
+	if !ok || c.toolName != toolName || c.caller != caller {
+		return nil, false
+	}
+	now := time.Now()
+	if now.After(c.expiry) {
+		s.removeLocked(id)
+		return nil, false
+	}
+	c.expiry = now.Add(cursorRetention)
+	return c.rows, true
+}
+
+// remove drops a result once its last page has been returned.
+func (s *cursorStore) remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeLocked(id)
+}
+
+func (s *cursorStore) removeLocked(id string) {
+	if c, ok := s.cursors[id]; ok {
+		s.size -= c.size
+		delete(s.cursors, id)
+	}
+}
+
+// encodePageToken returns the opaque token for the page of a result starting
+// at offset.
+func encodePageToken(id string, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%d", id, offset)))
+}
+
+// decodePageToken returns the cursor id and offset from a page token.
+func decodePageToken(token string) (string, int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid page token")
+	}
+	id, o, ok := strings.Cut(string(b), ":")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid page token")
+	}
+	offset, err := strconv.Atoi(o)
+	if err != nil || offset < 0 {
+		return "", 0, fmt.Errorf("invalid page token")
+	}
+	return id, offset, nil
+}
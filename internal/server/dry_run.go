@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// dryRunHeader is the header of a request whose tool invocations are dry runs.
+const dryRunHeader = "X-Toolbox-Dry-Run"
+
+// popDryRun removes the dryRun field from a raw tool config and returns it.
+func popDryRun(v map[string]any) (bool, error) {
+	val, ok := v["dryRun"]
+	if !ok {
+		return false, nil
+	}
+	delete(v, "dryRun")
+	b, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("must be a boolean")
+	}
+	return b, nil
+}
+
+// dryRunRequested reports whether the header of a request asks for its tool
+// invocations to be dry runs.
+func dryRunRequested(header http.Header) (bool, error) {
+	v := header.Get(dryRunHeader)
+	if v == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s header %q: must be a boolean", dryRunHeader, v)
+	}
+	return dryRun, nil
+}
+
+// dryRunKey is the context key of dry runs.
+type dryRunKey struct{}
+
+// withDryRun marks the tool invocations of the context as dry runs.
+func withDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+func dryRunFromContext(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+var _ tools.Tool = dryRunTool{}
+
+// dryRunTool dry runs the wrapped tool instead of invoking it if the
+// invocation is a dry run. It wraps the tool of a config directly, so that
+// a tool that can't be dry run is never invoked by a dry run.
+type dryRunTool struct {
+	tools.Tool
+	name string
+}
+
+func newDryRunTool(name string, tool tools.Tool) dryRunTool {
+	return dryRunTool{Tool: tool, name: name}
+}
+
+func (t dryRunTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	if !dryRunFromContext(ctx) {
+		return t.Tool.Invoke(ctx, params)
+	}
+	dr, ok := t.Tool.(tools.DryRunner)
+	if !ok {
+		return nil, fmt.Errorf("tool %q doesn't support dry runs", t.name)
+	}
+	return dr.DryRun(ctx, params)
+}
+
+var _ tools.Tool = dryRunOnlyTool{}
+
+// dryRunOnlyTool makes every invocation of the wrapped tool a dry run.
+type dryRunOnlyTool struct {
+	tools.Tool
+}
+
+func newDryRunOnlyTool(tool tools.Tool) dryRunOnlyTool {
+	return dryRunOnlyTool{Tool: tool}
+}
+
+func (t dryRunOnlyTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.Tool.Invoke(withDryRun(ctx), params)
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// previewTool is a countingTool that can be dry run.
+type previewTool struct {
+	countingTool
+}
+
+var _ tools.DryRunner = previewTool{}
+
+func (t previewTool) DryRun(ctx context.Context, params tools.ParamValues) (any, error) {
+	return "preview", nil
+}
+
+func TestPopDryRun(t *testing.T) {
+	v := map[string]any{"kind": "mock", "dryRun": true}
+	got, err := popDryRun(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !got {
+		t.Fatalf("expected the tool to only run dry")
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("dryRun was not removed from config: diff %v", diff)
+	}
+	if _, err := popDryRun(map[string]any{"dryRun": "yes"}); err == nil {
+		t.Fatalf("expected error for a non-boolean dryRun")
+	}
+}
+
+func TestDryRunTool(t *testing.T) {
+	var count int
+	preview := newDryRunTool(tool2.Name, previewTool{countingTool{MockTool: tool2, count: &count}})
+	unsupported := newDryRunTool(tool2.Name, countingTool{MockTool: tool2, count: &count})
+	dryRunCtx := withDryRun(context.Background())
+
+	if _, err := preview.Invoke(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected the tool to be invoked, got %d invocations", count)
+	}
+
+	tcs := []struct {
+		desc    string
+		tool    tools.Tool
+		ctx     context.Context
+		want    any
+		wantErr string
+	}{
+		{
+			desc: "dry run",
+			tool: preview,
+			ctx:  dryRunCtx,
+			want: "preview",
+		},
+		{
+			desc:    "tool without dry runs",
+			tool:    unsupported,
+			ctx:     dryRunCtx,
+			wantErr: `tool "some_params" doesn't support dry runs`,
+		},
+		{
+			desc: "tool that only runs dry",
+			tool: newDryRunOnlyTool(preview),
+			ctx:  context.Background(),
+			want: "preview",
+		},
+		{
+			desc: "cached tool",
+			tool: newCachedTool(tool2.Name, preview, time.Minute, newLRUCache(0)),
+			ctx:  dryRunCtx,
+			want: "preview",
+		},
+		{
+			desc: "tool that requires approval",
+			tool: newApprovalTool(tool2.Name, preview),
+			ctx:  dryRunCtx,
+			want: "preview",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.tool.Invoke(tc.ctx, nil)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected result: got %v, want %v", got, tc.want)
+			}
+			if count != 1 {
+				t.Fatalf("a dry run invoked the tool")
+			}
+		})
+	}
+}
+
+func TestDryRunRequests(t *testing.T) {
+	var count int
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	toolsMap[tool2.Name] = newDryRunTool(tool2.Name, previewTool{countingTool{MockTool: tool2, count: &count}})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	tcs := []struct {
+		desc       string
+		header     map[string]string
+		wantStatus int
+		want       string
+	}{
+		{
+			desc:       "dry run",
+			header:     map[string]string{dryRunHeader: "true"},
+			wantStatus: http.StatusOK,
+			want:       `"preview"`,
+		},
+		{
+			desc:       "invocation",
+			header:     map[string]string{dryRunHeader: "false"},
+			wantStatus: http.StatusOK,
+			want:       "[1]",
+		},
+		{
+			desc:       "invalid header",
+			header:     map[string]string{dryRunHeader: "maybe"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/some_params/invoke", bytes.NewBufferString(`{"param1": 1, "param2": 2}`), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", tc.wantStatus, resp.StatusCode, string(body))
+			}
+			if tc.want == "" {
+				return
+			}
+			var res resultResponse
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatalf("unable to parse response: %s", err)
+			}
+			if res.Result != tc.want {
+				t.Fatalf("unexpected result: want %s, got %s", tc.want, res.Result)
+			}
+		})
+	}
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	s := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+	}
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"some_params","arguments":{"param1":1,"param2":2},"_meta":{"dryRun":true}}}`
+	_, res, err := processMcpMessage(util.WithLogger(context.Background(), testLogger), []byte(body), s, protocolVersion20250618, "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unable to marshal response: %s", err)
+	}
+	if !strings.Contains(string(b), "preview") {
+		t.Fatalf("expected the MCP tool call to be a dry run, got %s", string(b))
+	}
+	if count != 1 {
+		t.Fatalf("expected the tool to be invoked once, got %d invocations", count)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// health statuses reported by the health endpoints
+const (
+	healthOk          = "ok"
+	healthUnavailable = "unavailable"
+	// healthDegraded is reported when only lazily initialized sources failed,
+	// so the rest of the tools can still serve.
+	healthDegraded = "degraded"
+	healthError    = "error"
+	// healthUnchecked is reported for sources that can't check their health.
+	healthUnchecked = "unchecked"
+)
+
+// sourceCheckTimeout is how long a source has to respond to a health check.
+const sourceCheckTimeout = 5 * time.Second
+
+var _ render.Renderer = &healthResponse{} // Renderer interface for managing response payloads.
+
+// healthResponse is the response sent back by the health endpoints.
+type healthResponse struct {
+	Status  string                  `json:"status"`
+	Sources map[string]sourceHealth `json:"sources,omitempty"`
+}
+
+// sourceHealth is the result of checking a single source.
+type sourceHealth struct {
+	Kind   string `json:"kind"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Render renders a single payload and respond to the client request.
+func (hr healthResponse) Render(w http.ResponseWriter, r *http.Request) error {
+	if hr.Status == healthOk || hr.Status == healthDegraded {
+		render.Status(r, http.StatusOK)
+	} else {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	return nil
+}
+
+// healthzHandler reports that the server is running, without checking its
+// sources.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	_ = render.Render(w, r, &healthResponse{Status: healthOk})
+}
+
+// readyzHandler checks every source and reports whether the server is ready
+// to serve tool invocations.
+func readyzHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/readyz")
+	defer span.End()
+
+	res := checkSources(ctx, s.ResourceMgr.GetSourcesMap())
+	if res.Status != healthOk {
+		s.logger.DebugContext(ctx, fmt.Sprintf("server is %s: a source failed its health check", res.Status))
+	}
+	_ = render.Render(w, r, &res)
+}
+
+// checkSources checks the health of every source concurrently.
+func checkSources(ctx context.Context, sourcesMap map[string]sources.Source) healthResponse {
+	ctx, cancel := context.WithTimeout(ctx, sourceCheckTimeout)
+	defer cancel()
+
+	res := healthResponse{Status: healthOk, Sources: make(map[string]sourceHealth, len(sourcesMap))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, src := range sourcesMap {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h := sourceHealth{Kind: src.SourceKind(), Status: healthUnchecked}
+			if hc, ok := src.(sources.HealthChecker); ok {
+				h.Status = healthOk
+				if err := hc.CheckHealth(ctx); err != nil {
+					h.Status = healthError
+					h.Error = err.Error()
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			res.Sources[name] = h
+			if h.Status != healthError {
+				return
+			}
+			// lazily initialized sources only make their own tools unavailable
+			if _, ok := src.(*lazySource); !ok {
+				res.Status = healthUnavailable
+			} else if res.Status == healthOk {
+				res.Status = healthDegraded
+			}
+		}()
+	}
+	wg.Wait()
+	return res
+}
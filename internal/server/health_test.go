@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+type mockSource struct {
+	kind string
+}
+
+func (s mockSource) SourceKind() string {
+	return s.kind
+}
+
+type mockHealthCheckedSource struct {
+	mockSource
+	err error
+}
+
+func (s mockHealthCheckedSource) CheckHealth(context.Context) error {
+	return s.err
+}
+
+func TestCheckSources(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		sourcesMap map[string]sources.Source
+		want       healthResponse
+	}{
+		{
+			desc: "healthy",
+			sourcesMap: map[string]sources.Source{
+				"my-pg":    mockHealthCheckedSource{mockSource: mockSource{kind: "postgres"}},
+				"my-other": mockSource{kind: "bigquery"},
+			},
+			want: healthResponse{
+				Status: healthOk,
+				Sources: map[string]sourceHealth{
+					"my-pg":    {Kind: "postgres", Status: healthOk},
+					"my-other": {Kind: "bigquery", Status: healthUnchecked},
+				},
+			},
+		},
+		{
+			desc: "unavailable",
+			sourcesMap: map[string]sources.Source{
+				"my-pg":    mockHealthCheckedSource{mockSource: mockSource{kind: "postgres"}},
+				"my-mysql": mockHealthCheckedSource{mockSource: mockSource{kind: "mysql"}, err: errors.New("connection refused")},
+			},
+			want: healthResponse{
+				Status: healthUnavailable,
+				Sources: map[string]sourceHealth{
+					"my-pg":    {Kind: "postgres", Status: healthOk},
+					"my-mysql": {Kind: "mysql", Status: healthError, Error: "connection refused"},
+				},
+			},
+		},
+		{
+			desc: "degraded",
+			sourcesMap: map[string]sources.Source{
+				"my-pg":    mockHealthCheckedSource{mockSource: mockSource{kind: "postgres"}},
+				"my-mysql": newFailedLazySource(context.Background(), "my-mysql", flakySourceConfig{}, errors.New("connection refused")),
+			},
+			want: healthResponse{
+				Status: healthDegraded,
+				Sources: map[string]sourceHealth{
+					"my-pg":    {Kind: "postgres", Status: healthOk},
+					"my-mysql": {Kind: "postgres", Status: healthError, Error: "connection refused"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := checkSources(context.Background(), tc.sourcesMap)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect health: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestHealthzHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	healthzHandler(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: got %d", w.Code)
+	}
+	if got, want := w.Body.String(), "{\"status\":\"ok\"}\n"; got != want {
+		t.Fatalf("unexpected body: got %q, want %q", got, want)
+	}
+}
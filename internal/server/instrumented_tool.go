@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var _ tools.Tool = instrumentedTool{}
+
+// instrumentedTool records metrics for every invocation of the wrapped tool.
+type instrumentedTool struct {
+	tools.Tool
+	name string
+	kind string
+	// source is the name of the tool's source, or empty if it has none.
+	source          string
+	instrumentation *telemetry.Instrumentation
+}
+
+func newInstrumentedTool(name string, kind string, source string, tool tools.Tool, instrumentation *telemetry.Instrumentation) instrumentedTool {
+	return instrumentedTool{
+		Tool:            tool,
+		name:            name,
+		kind:            kind,
+		source:          source,
+		instrumentation: instrumentation,
+	}
+}
+
+func (t instrumentedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	start := time.Now()
+	res, err := t.Tool.Invoke(ctx, params)
+	duration := time.Since(start)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("toolbox.name", t.name),
+		attribute.String("toolbox.tool.kind", t.kind),
+		attribute.String("toolbox.operation.status", status),
+	)
+	t.instrumentation.ToolInvocationCount.Add(ctx, 1, attrs)
+	t.instrumentation.ToolInvocationDuration.Record(ctx, duration.Seconds(), attrs)
+	if rows, ok := res.([]any); ok && err == nil {
+		t.instrumentation.ToolInvocationRows.Record(ctx, int64(len(rows)), attrs)
+	}
+	return res, err
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// invocationIdHeader is the header used to set or retrieve the id of a tool
+// invocation, which can be used to cancel it while it is running. Ids are
+// scoped to the caller, see util.CallerIdentity, so that callers can only cancel
+// their own invocations.
+const invocationIdHeader = "Toolbox-Invocation-Id"
+
+// errInvocationCancelled is returned for invocations that were cancelled by
+// the client.
+var errInvocationCancelled = errors.New("tool invocation was cancelled")
+
+// invocationKey identifies a running invocation by the caller who started it
+// and the id they gave it.
+type invocationKey struct {
+	caller string
+	id     string
+}
+
+// runningInvocation is a tool invocation that can be cancelled.
+type runningInvocation struct {
+	toolName string
+	cancel   context.CancelFunc
+}
+
+// invocationManager manages and control access to running tool invocations.
+type invocationManager struct {
+	mu          sync.Mutex
+	invocations map[invocationKey]runningInvocation
+}
+
+func newInvocationManager() *invocationManager {
+	return &invocationManager{
+		mu:          sync.Mutex{},
+		invocations: make(map[invocationKey]runningInvocation),
+	}
+}
+
+// start registers a cancelable invocation of the caller in ctx. If id is
+// empty, a new id is generated. The returned context is canceled when the
+// invocation is cancelled, and the returned function must be called once the
+// invocation finishes.
+func (m *invocationManager) start(ctx context.Context, id string, toolName string) (context.Context, string, func(), error) {
+	if id == "" {
+		id = uuid.New().String()
+	}
+	key := invocationKey{caller: util.CallerIdentity(ctx), id: id}
+	ctx, cancel := context.WithCancel(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.invocations[key]; ok {
+		cancel()
+		return nil, "", nil, fmt.Errorf("invocation %q is already running", id)
+	}
+	m.invocations[key] = runningInvocation{toolName: toolName, cancel: cancel}
+
+	done := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.invocations, key)
+		cancel()
+	}
+	return ctx, id, done, nil
+}
+
+// cancel cancels the running invocation of the tool started by the caller in
+// ctx. It returns false if there is no such invocation.
+func (m *invocationManager) cancel(ctx context.Context, id string, toolName string) bool {
+	key := invocationKey{caller: util.CallerIdentity(ctx), id: id}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	inv, ok := m.invocations[key]
+	if !ok || inv.toolName != toolName {
+		return false
+	}
+	inv.cancel()
+	return true
+}
@@ -0,0 +1,371 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// lazyRetryInterval is how long a source that failed to initialize waits
+	// before it is initialized again.
+	lazyRetryInterval = 10 * time.Second
+	// lazyInitWait is how long an invocation waits for a source to initialize.
+	lazyInitWait = 30 * time.Second
+	// lazyListWait is how long listing tools waits for their sources to
+	// initialize, before the tools are listed as unavailable.
+	lazyListWait = 1 * time.Second
+)
+
+// errUnavailable is returned for invocations of tools whose source isn't
+// initialized.
+var errUnavailable = errors.New("unavailable")
+
+var _ sources.Source = &lazySource{}
+var _ sources.HealthChecker = &lazySource{}
+
+// lazySource is a source that is initialized on first use, instead of when
+// the server starts. A source that fails to initialize is retried on a later
+// use, after lazyRetryInterval.
+type lazySource struct {
+	name string
+	cfg  sources.SourceConfig
+	// ctx is the context the source is initialized with, which must outlive
+	// any single request.
+	ctx context.Context
+
+	mu          sync.Mutex
+	src         sources.Source
+	err         error
+	attempt     chan struct{}
+	lastAttempt time.Time
+}
+
+func newLazySource(ctx context.Context, name string, cfg sources.SourceConfig) *lazySource {
+	return &lazySource{name: name, cfg: cfg, ctx: ctx}
+}
+
+// newFailedLazySource returns a lazySource for a source that already failed
+// to initialize.
+func newFailedLazySource(ctx context.Context, name string, cfg sources.SourceConfig, err error) *lazySource {
+	s := newLazySource(ctx, name, cfg)
+	s.err = err
+	s.lastAttempt = time.Now()
+	return s
+}
+
+func (s *lazySource) SourceKind() string {
+	return s.cfg.SourceConfigKind()
+}
+
+// start begins initializing the source, unless it is initialized, being
+// initialized, or failed too recently. The returned channel is closed once
+// no initialization is running.
+func (s *lazySource) start() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attempt != nil {
+		return s.attempt
+	}
+	done := make(chan struct{})
+	if s.src != nil || time.Since(s.lastAttempt) < lazyRetryInterval {
+		close(done)
+		return done
+	}
+	s.attempt = done
+	go func() {
+		src, err := s.initialize()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.src, s.err = src, err
+		s.lastAttempt = time.Now()
+		s.attempt = nil
+		close(done)
+	}()
+	return done
+}
+
+func (s *lazySource) initialize() (sources.Source, error) {
+	instrumentation, err := util.InstrumentationFromContext(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := instrumentation.Tracer.Start(
+		s.ctx,
+		"toolbox/server/source/init",
+		trace.WithAttributes(attribute.String("source_kind", s.SourceKind())),
+		trace.WithAttributes(attribute.String("source_name", s.name)),
+	)
+	defer span.End()
+	src, err := s.cfg.Initialize(ctx, instrumentation.Tracer)
+	if err != nil {
+		err = fmt.Errorf("unable to initialize source %q: %w", s.name, err)
+		if l, lErr := util.LoggerFromContext(s.ctx); lErr == nil {
+			l.WarnContext(s.ctx, err.Error())
+		}
+		return nil, err
+	}
+	return src, nil
+}
+
+// get returns the initialized source, initializing it if needed.
+func (s *lazySource) get(ctx context.Context) (sources.Source, error) {
+	select {
+	case <-s.start():
+	case <-ctx.Done():
+		return nil, fmt.Errorf("source %q is still initializing", s.name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.src == nil {
+		return nil, s.err
+	}
+	return s.src, nil
+}
+
+// peek returns the source if it is initialized, without initializing it.
+func (s *lazySource) peek() (sources.Source, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.src != nil {
+		return s.src, nil
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return nil, fmt.Errorf("source %q is not initialized yet", s.name)
+}
+
+// CheckHealth initializes the source, and checks its health if it can.
+func (s *lazySource) CheckHealth(ctx context.Context) error {
+	src, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+	if hc, ok := src.(sources.HealthChecker); ok {
+		return hc.CheckHealth(ctx)
+	}
+	return nil
+}
+
+var _ tools.Tool = &lazyTool{}
+
+// lazyTool is a tool of a lazySource, which is initialized once its source is.
+// Until then, it is listed as unavailable and its invocations fail.
+type lazyTool struct {
+	name   string
+	source *lazySource
+	init   func(sources.Source) (tools.Tool, error)
+	// authRequired is the tool's auth requirement, read from its config so it
+	// is enforced even before the tool is initialized.
+	authRequired []string
+
+	mu   sync.Mutex
+	tool tools.Tool
+	err  error
+}
+
+func newLazyTool(name string, tc tools.ToolConfig, source *lazySource, init func(sources.Source) (tools.Tool, error)) *lazyTool {
+	return &lazyTool{name: name, source: source, init: init, authRequired: toolAuthRequired(tc)}
+}
+
+// get returns the initialized tool, initializing its source if needed.
+func (t *lazyTool) get(ctx context.Context) (tools.Tool, error) {
+	if tool, ok, err := t.initialized(); ok {
+		return tool, err
+	}
+	src, err := t.source.get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tool %q is %w: %w", t.name, errUnavailable, err)
+	}
+	return t.initialize(src)
+}
+
+// peek returns the tool if its source is initialized, without initializing
+// the source.
+func (t *lazyTool) peek() (tools.Tool, error) {
+	if tool, ok, err := t.initialized(); ok {
+		return tool, err
+	}
+	src, err := t.source.peek()
+	if err != nil {
+		return nil, fmt.Errorf("tool %q is %w: %w", t.name, errUnavailable, err)
+	}
+	return t.initialize(src)
+}
+
+// initialized returns the tool, or the error initializing it, and whether it
+// was initialized yet.
+func (t *lazyTool) initialized() (tools.Tool, bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tool, t.tool != nil || t.err != nil, t.err
+}
+
+func (t *lazyTool) initialize(src sources.Source) (tools.Tool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.tool == nil && t.err == nil {
+		t.tool, t.err = t.init(src)
+	}
+	return t.tool, t.err
+}
+
+// getWithin is get, waiting at most d for the source to initialize.
+func (t *lazyTool) getWithin(d time.Duration) (tools.Tool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return t.get(ctx)
+}
+
+func (t *lazyTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	tool, err := t.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tool.Invoke(ctx, params)
+}
+
+func (t *lazyTool) ParseParams(data map[string]any, claimsMap map[string]map[string]any) (tools.ParamValues, error) {
+	tool, err := t.getWithin(lazyInitWait)
+	if err != nil {
+		return nil, err
+	}
+	return tool.ParseParams(data, claimsMap)
+}
+
+func (t *lazyTool) Authorized(verifiedAuthServices []string) bool {
+	tool, err := t.getWithin(lazyInitWait)
+	if err != nil {
+		// a later step of the invocation reports the tool is unavailable
+		return t.authRequired != nil && tools.IsAuthorized(t.authRequired, verifiedAuthServices)
+	}
+	return tool.Authorized(verifiedAuthServices)
+}
+
+// Manifest returns the manifest of the tool if its source is initialized, or
+// describes the tool as unavailable otherwise. Listing tools doesn't wait for
+// sources to initialize, see waitForLazySources.
+func (t *lazyTool) Manifest() tools.Manifest {
+	tool, err := t.peek()
+	if err != nil {
+		authRequired := t.authRequired
+		if authRequired == nil {
+			authRequired = []string{}
+		}
+		return tools.Manifest{
+			Description:  unavailableDescription(err),
+			Parameters:   []tools.ParameterManifest{},
+			AuthRequired: authRequired,
+		}
+	}
+	return tool.Manifest()
+}
+
+func (t *lazyTool) McpManifest() tools.McpManifest {
+	tool, err := t.peek()
+	if err != nil {
+		return tools.McpManifest{
+			Name:        t.name,
+			Description: unavailableDescription(err),
+			InputSchema: tools.McpToolsSchema{
+				Type:       "object",
+				Properties: map[string]tools.ParameterMcpManifest{},
+				Required:   []string{},
+			},
+		}
+	}
+	return tool.McpManifest()
+}
+
+// waitForLazySources starts initializing the sources of any lazy tools, and
+// waits up to lazyListWait for them, so their tools can be listed.
+func waitForLazySources(toolsMap map[string]tools.Tool, toolNames []string) {
+	var attempts []<-chan struct{}
+	started := make(map[*lazySource]bool)
+	for _, name := range toolNames {
+		t, ok := toolsMap[name].(*lazyTool)
+		if !ok || started[t.source] {
+			continue
+		}
+		started[t.source] = true
+		attempts = append(attempts, t.source.start())
+	}
+	if len(attempts) == 0 {
+		return
+	}
+	timeout := time.After(lazyListWait)
+	for _, done := range attempts {
+		select {
+		case <-done:
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func unavailableDescription(err error) string {
+	return fmt.Sprintf("This tool is currently unavailable, try again later: %s", err)
+}
+
+// toolAuthRequired returns the auth services required by a tool, or nil if
+// its config doesn't say. Tool configs list them in a []string field called
+// AuthRequired.
+func toolAuthRequired(tc tools.ToolConfig) []string {
+	if wc, ok := tc.(wrappedToolConfig); ok {
+		tc = wc.ToolConfig
+	}
+	v := reflect.ValueOf(tc)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.FieldByName("AuthRequired")
+	if !f.IsValid() {
+		return nil
+	}
+	authRequired, ok := f.Interface().([]string)
+	if !ok {
+		return nil
+	}
+	if authRequired == nil {
+		authRequired = []string{}
+	}
+	return authRequired
+}
+
+// withSource returns a copy of sourcesMap with the source called name
+// replaced by src.
+func withSource(sourcesMap map[string]sources.Source, name string, src sources.Source) map[string]sources.Source {
+	m := make(map[string]sources.Source, len(sourcesMap))
+	for k, v := range sourcesMap {
+		m[k] = v
+	}
+	m[name] = src
+	return m
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// flakySourceConfig is a source config that fails to initialize while fail is
+// true.
+type flakySourceConfig struct {
+	fail  *bool
+	count *int
+}
+
+func (flakySourceConfig) SourceConfigKind() string { return "postgres" }
+
+func (c flakySourceConfig) Initialize(context.Context, trace.Tracer) (sources.Source, error) {
+	*c.count++
+	if *c.fail {
+		return nil, errors.New("connection refused")
+	}
+	return mockSource{kind: "postgres"}, nil
+}
+
+type authToolConfig struct {
+	Source       string
+	AuthRequired []string
+}
+
+func (authToolConfig) ToolConfigKind() string { return "mock" }
+
+func (authToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return tool1, nil
+}
+
+func TestPopLazyInit(t *testing.T) {
+	v := map[string]any{"kind": "postgres", "lazyInit": true}
+	got, err := popLazyInit(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !got {
+		t.Fatalf("expected lazyInit to be true")
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "postgres"}, v); diff != "" {
+		t.Fatalf("lazyInit was not removed from config: diff %v", diff)
+	}
+
+	if _, err := popLazyInit(map[string]any{"lazyInit": "yes"}); err == nil {
+		t.Fatalf("expected error for invalid lazyInit")
+	}
+}
+
+func TestLazyTool(t *testing.T) {
+	logger, err := log.NewStdLogger(os.Stdout, os.Stderr, "info")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), logger)
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	fail, count := true, 0
+	source := newLazySource(ctx, "my-source", flakySourceConfig{fail: &fail, count: &count})
+	tc := authToolConfig{Source: "my-source", AuthRequired: []string{"my-auth"}}
+	tool := newLazyTool("my-tool", tc, source, func(sources.Source) (tools.Tool, error) {
+		return tool1, nil
+	})
+
+	// listing the tool doesn't initialize its source
+	m := tool.Manifest()
+	if !strings.HasPrefix(m.Description, "This tool is currently unavailable") {
+		t.Fatalf("expected unavailable description, got %q", m.Description)
+	}
+	if diff := cmp.Diff([]string{"my-auth"}, m.AuthRequired); diff != "" {
+		t.Fatalf("incorrect auth required: diff %v", diff)
+	}
+	if count != 0 {
+		t.Fatalf("expected no initialization, got %d", count)
+	}
+
+	if _, err := tool.Invoke(ctx, nil); !errors.Is(err, errUnavailable) {
+		t.Fatalf("expected unavailable error, got %v", err)
+	}
+	// the auth requirement of the config still applies
+	if tool.Authorized([]string{}) {
+		t.Fatalf("expected unauthorized without the required auth service")
+	}
+	if !tool.Authorized([]string{"my-auth"}) {
+		t.Fatalf("expected authorized with the required auth service")
+	}
+	// the source isn't retried until the retry interval passes
+	if count != 1 {
+		t.Fatalf("expected 1 initialization, got %d", count)
+	}
+
+	fail = false
+	source.lastAttempt = time.Now().Add(-lazyRetryInterval)
+	got, err := tool.Invoke(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]any{tool1.Name}, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	if diff := cmp.Diff(tool1.Manifest(), tool.Manifest()); diff != "" {
+		t.Fatalf("incorrect manifest: diff %v", diff)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 initializations, got %d", count)
+	}
+}
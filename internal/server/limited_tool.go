@@ -0,0 +1,195 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// parameters added to tools that paginate their results
+const (
+	pageSizeParam  = "pageSize"
+	pageTokenParam = "pageToken"
+)
+
+var _ tools.Tool = limitedTool{}
+
+// limitedTool enforces response limits on the results of the wrapped tool.
+type limitedTool struct {
+	tools.Tool
+	name       string
+	limits     tools.ResponseLimits
+	pageParams tools.Parameters
+}
+
+func newLimitedTool(name string, tool tools.Tool, limits tools.ResponseLimits) (limitedTool, error) {
+	t := limitedTool{Tool: tool, name: name, limits: limits}
+	if limits.Truncation != tools.PaginateResponse {
+		return t, nil
+	}
+	for _, p := range tool.Manifest().Parameters {
+		if p.Name == pageSizeParam || p.Name == pageTokenParam {
+			return limitedTool{}, fmt.Errorf("tool %q can't paginate its results: parameter %q is reserved", name, p.Name)
+		}
+	}
+	pageSizeDesc := "The maximum number of rows to return."
+	if limits.MaxRows > 0 {
+		pageSizeDesc = fmt.Sprintf("The maximum number of rows to return, at most %d.", limits.MaxRows)
+	}
+	t.pageParams = tools.Parameters{
+		tools.NewIntParameterWithRequired(pageSizeParam, pageSizeDesc, false),
+		tools.NewStringParameterWithRequired(pageTokenParam, "The token of the page to return, from a previous result. Other parameters are ignored when it is set.", false),
+	}
+	return t, nil
+}
+
+func (t limitedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	// the limits apply to the whole result, so its rows aren't streamed
+	ctx = util.WithRowWriter(ctx, nil)
+	if t.pageParams == nil {
+		res, err := t.Tool.Invoke(ctx, params)
+		if err != nil {
+			return res, err
+		}
+		return t.limits.Apply(res)
+	}
+
+	// the page parameters are always last, see ParseParams
+	var pageSize int
+	var pageToken string
+	if n := len(params) - len(t.pageParams); n >= 0 {
+		pageSize, _ = params[n].Value.(int)
+		pageToken, _ = params[n+1].Value.(string)
+		params = params[:n]
+	}
+	if pageToken != "" {
+		id, offset, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		rows, ok := cursors.get(id, t.name, util.CallerIdentity(ctx))
+		if !ok || offset > len(rows) {
+			return nil, fmt.Errorf("page token is invalid or has expired")
+		}
+		return t.page(ctx, id, rows, offset, pageSize), nil
+	}
+
+	res, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return res, err
+	}
+	rows, ok := res.([]any)
+	if !ok {
+		return t.limits.Apply(res)
+	}
+	return t.page(ctx, "", rows, 0, pageSize), nil
+}
+
+// page returns the rows of a result starting at offset, followed by the token
+// of the next page if any rows remain. id is the cursor holding the result, or
+// empty if it hasn't been stored yet.
+func (t limitedTool) page(ctx context.Context, id string, rows []any, offset, pageSize int) []any {
+	if pageSize <= 0 || (t.limits.MaxRows > 0 && pageSize > t.limits.MaxRows) {
+		pageSize = t.limits.MaxRows
+	}
+	end := len(rows)
+	if pageSize > 0 && offset+pageSize < end {
+		end = offset + pageSize
+	}
+	// always return at least one row so every page makes progress
+	end = offset + max(t.limits.FitRows(rows[offset:end]), 1)
+	if end >= len(rows) {
+		if id != "" {
+			cursors.remove(id)
+		}
+		return rows[offset:]
+	}
+	page := rows[offset:end:end]
+	if id == "" {
+		var ok bool
+		if id, ok = cursors.add(t.name, util.CallerIdentity(ctx), rows); !ok {
+			marker := fmt.Sprintf("[truncated: returned rows %d-%d of %d, the result is too large to get its next pages]", offset+1, end, len(rows))
+			return append(page, marker)
+		}
+	}
+	marker := fmt.Sprintf("[truncated: returned rows %d-%d of %d, pass pageToken %q to get the next page]", offset+1, end, len(rows), encodePageToken(id, end))
+	return append(page, marker)
+}
+
+func (t limitedTool) ParseParams(data map[string]any, claimsMap map[string]map[string]any) (tools.ParamValues, error) {
+	if t.pageParams == nil {
+		return t.Tool.ParseParams(data, claimsMap)
+	}
+	pageParams, err := tools.ParseParams(t.pageParams, data, claimsMap)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize, ok := pageParams[0].Value.(int); ok && pageSize <= 0 {
+		return nil, fmt.Errorf("parameter %q must be greater than 0", pageSizeParam)
+	}
+	if pageToken, _ := pageParams[1].Value.(string); pageToken != "" {
+		// later pages are read from the stored result, but still require the
+		// caller to be verified with the auth services of the tool's
+		// authenticated parameters
+		for _, p := range t.Tool.Manifest().Parameters {
+			if len(p.AuthServices) == 0 {
+				continue
+			}
+			verified := false
+			for _, name := range p.AuthServices {
+				if _, ok := claimsMap[name]; ok {
+					verified = true
+					break
+				}
+			}
+			if !verified {
+				return nil, fmt.Errorf("error parsing authenticated parameter %q: missing or invalid authentication header", p.Name)
+			}
+		}
+		return pageParams, nil
+	}
+	params, err := t.Tool.ParseParams(data, claimsMap)
+	if err != nil {
+		return nil, err
+	}
+	return append(params, pageParams...), nil
+}
+
+func (t limitedTool) Manifest() tools.Manifest {
+	m := t.Tool.Manifest()
+	if t.pageParams != nil {
+		m.Parameters = append(m.Parameters[:len(m.Parameters):len(m.Parameters)], t.pageParams.Manifest()...)
+	}
+	return m
+}
+
+func (t limitedTool) McpManifest() tools.McpManifest {
+	m := t.Tool.McpManifest()
+	if t.pageParams != nil {
+		properties := make(map[string]tools.ParameterMcpManifest, len(m.InputSchema.Properties)+len(t.pageParams))
+		for name, p := range m.InputSchema.Properties {
+			properties[name] = p
+		}
+		for name, p := range t.pageParams.McpManifest().Properties {
+			properties[name] = p
+		}
+		m.InputSchema.Properties = properties
+	}
+	return m
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestPopResponseLimits(t *testing.T) {
+	v := map[string]any{
+		"kind":             "mock",
+		"maxResponseBytes": 2048,
+		"maxRows":          10,
+		"truncation":       "error",
+	}
+	got, err := popResponseLimits(context.Background(), v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := tools.ResponseLimits{MaxResponseBytes: 2048, MaxRows: 10, Truncation: tools.ErrorResponse}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect limits: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("limits were not removed from config: diff %v", diff)
+	}
+
+	if _, err := popResponseLimits(context.Background(), map[string]any{"truncation": "fail"}); err == nil {
+		t.Fatalf("expected error for invalid truncation")
+	}
+}
+
+func TestLimitedTool(t *testing.T) {
+	tool, err := newLimitedTool(tool1.Name, tool1, tools.ResponseLimits{MaxResponseBytes: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{"[truncated: returned 0 of 1 rows]"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	tool, err = newLimitedTool(tool1.Name, tool1, tools.ResponseLimits{MaxRows: 1, Truncation: tools.ErrorResponse})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := tool.Invoke(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+// rowsTool is a MockTool that returns a fixed list of rows.
+type rowsTool struct {
+	MockTool
+	rows []any
+}
+
+func (t rowsTool) Invoke(context.Context, tools.ParamValues) (any, error) {
+	return t.rows, nil
+}
+
+func TestLimitedToolPagination(t *testing.T) {
+	inner := rowsTool{MockTool: tool2, rows: []any{"a", "b", "c", "d", "e"}}
+	tool, err := newLimitedTool(inner.Name, inner, tools.ResponseLimits{MaxRows: 2, Truncation: tools.PaginateResponse})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	m := tool.Manifest()
+	if got := m.Parameters[len(m.Parameters)-2].Name; got != "pageSize" {
+		t.Fatalf("expected pageSize parameter, got %q", got)
+	}
+	if _, ok := tool.McpManifest().InputSchema.Properties["pageToken"]; !ok {
+		t.Fatalf("expected pageToken in the mcp manifest")
+	}
+
+	tokenRe := regexp.MustCompile(`pass pageToken "([^"]+)"`)
+	invoke := func(data map[string]any) []any {
+		params, err := tool.ParseParams(data, nil)
+		if err != nil {
+			t.Fatalf("unable to parse params: %s", err)
+		}
+		res, err := tool.Invoke(context.Background(), params)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return res.([]any)
+	}
+
+	got := invoke(map[string]any{"param1": 1, "param2": 2})
+	if diff := cmp.Diff([]any{"a", "b"}, got[:2]); diff != "" {
+		t.Fatalf("incorrect first page: diff %v", diff)
+	}
+	match := tokenRe.FindStringSubmatch(got[2].(string))
+	if match == nil {
+		t.Fatalf("expected a page token, got %q", got[2])
+	}
+
+	// later pages don't need the tool's parameters
+	got = invoke(map[string]any{"pageToken": match[1], "pageSize": 1})
+	if diff := cmp.Diff([]any{"c"}, got[:1]); diff != "" {
+		t.Fatalf("incorrect second page: diff %v", diff)
+	}
+	match = tokenRe.FindStringSubmatch(got[1].(string))
+	if match == nil {
+		t.Fatalf("expected a page token, got %q", got[1])
+	}
+
+	got = invoke(map[string]any{"pageToken": match[1], "pageSize": 5})
+	if diff := cmp.Diff([]any{"d", "e"}, got); diff != "" {
+		t.Fatalf("incorrect last page: diff %v", diff)
+	}
+
+	// the result is dropped once the last page is returned
+	params, err := tool.ParseParams(map[string]any{"pageToken": match[1]}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(context.Background(), params); err == nil {
+		t.Fatalf("expected error for an expired page token")
+	}
+
+	if _, err := tool.ParseParams(map[string]any{"pageSize": 0}, nil); err == nil {
+		t.Fatalf("expected error for an invalid page size")
+	}
+
+	conflict := MockTool{Name: "conflict", Params: tools.Parameters{tools.NewStringParameter("pageToken", "a token")}}
+	if _, err := newLimitedTool(conflict.Name, conflict, tools.ResponseLimits{MaxRows: 1, Truncation: tools.PaginateResponse}); err == nil {
+		t.Fatalf("expected error for a reserved parameter name")
+	}
+}
+
+func TestLimitedToolPaginationCaller(t *testing.T) {
+	inner := rowsTool{
+		MockTool: MockTool{Name: "authed", Params: tools.Parameters{tools.NewStringParameterWithAuth("user", "the user", []tools.ParamAuthService{{Name: "my-auth", Field: "sub"}})}},
+		rows:     []any{"a", "b", "c"},
+	}
+	tool, err := newLimitedTool(inner.Name, inner, tools.ResponseLimits{MaxRows: 1, Truncation: tools.PaginateResponse})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	claims := func(sub string) map[string]map[string]any {
+		return map[string]map[string]any{"my-auth": {"sub": sub}}
+	}
+	invoke := func(data map[string]any, claimsMap map[string]map[string]any) ([]any, error) {
+		params, err := tool.ParseParams(data, claimsMap)
+		if err != nil {
+			return nil, err
+		}
+		res, err := tool.Invoke(util.WithClaims(context.Background(), claimsMap), params)
+		if err != nil {
+			return nil, err
+		}
+		return res.([]any), nil
+	}
+
+	got, err := invoke(map[string]any{}, claims("alice"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	match := regexp.MustCompile(`pass pageToken "([^"]+)"`).FindStringSubmatch(got[1].(string))
+	if match == nil {
+		t.Fatalf("expected a page token, got %q", got[1])
+	}
+	// later pages still require the caller to be authenticated, and are only
+	// returned to the caller of the first page
+	if _, err := invoke(map[string]any{"pageToken": match[1]}, nil); err == nil {
+		t.Fatalf("expected error for an unauthenticated caller")
+	}
+	if _, err := invoke(map[string]any{"pageToken": match[1]}, claims("bob")); err == nil {
+		t.Fatalf("expected error for another caller")
+	}
+	got, err = invoke(map[string]any{"pageToken": match[1]}, claims("alice"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff("b", got[0]); diff != "" {
+		t.Fatalf("incorrect second page: diff %v", diff)
+	}
+}
+
+func TestCursorStoreMaxBytes(t *testing.T) {
+	s := newCursorStore()
+	s.maxBytes = 10
+	// each row is 5 bytes encoded as JSON
+	first, ok := s.add("tool", "", []any{"abc", "def"})
+	if !ok {
+		t.Fatalf("expected the result to be stored")
+	}
+	if _, ok := s.add("tool", "", []any{"abc", "def", "ghi"}); ok {
+		t.Fatalf("expected a result larger than the limit not to be stored")
+	}
+	if _, ok := s.add("tool", "", []any{"ghi"}); !ok {
+		t.Fatalf("expected the result to be stored")
+	}
+	// the first result was evicted to hold the last one
+	if _, ok := s.get(first, "tool", ""); ok {
+		t.Fatalf("expected the first result to be evicted")
+	}
+	if s.size != 5 {
+		t.Fatalf("unexpected size of the held results: %d", s.size)
+	}
+}
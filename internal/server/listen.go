@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// listenSystemd listens on the socket passed by systemd socket activation.
+	listenSystemd = "systemd"
+	// listenUnixPrefix prefixes the path of a Unix domain socket.
+	listenUnixPrefix = "unix:"
+	// systemdListenFdsStart is the first file descriptor passed by systemd.
+	systemdListenFdsStart = 3
+)
+
+// validateListen checks the listen address of the server, which is either
+// empty, to listen on its TCP address, "unix:<path>", or "systemd".
+func validateListen(listen string) error {
+	switch {
+	case listen == "", listen == listenSystemd:
+		return nil
+	case strings.HasPrefix(listen, listenUnixPrefix):
+		if strings.TrimPrefix(listen, listenUnixPrefix) == "" {
+			return fmt.Errorf("invalid listen address %q: missing path of the Unix domain socket", listen)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid listen address %q: must be \"unix:<path>\" or \"systemd\"", listen)
+	}
+}
+
+// openListener opens the listener of the server, on the TCP address addr
+// unless listen is set.
+func openListener(ctx context.Context, listen, addr string) (net.Listener, error) {
+	lc := net.ListenConfig{KeepAlive: 30 * time.Second}
+	switch {
+	case listen == listenSystemd:
+		return systemdListener()
+	case strings.HasPrefix(listen, listenUnixPrefix):
+		return unixListener(ctx, lc, strings.TrimPrefix(listen, listenUnixPrefix))
+	default:
+		l, err := lc.Listen(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open listener for %q: %w", addr, err)
+		}
+		return l, nil
+	}
+}
+
+// unixListener listens on a Unix domain socket, which only the user running
+// the server can connect to. A socket left behind by a server that didn't shut
+// down cleanly is replaced, but a socket that is still in use isn't.
+func unixListener(ctx context.Context, lc net.ListenConfig, path string) (net.Listener, error) {
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("failed to open listener for %q: file exists and isn't a socket", path)
+		}
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to open listener for %q: socket is in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("unable to remove stale socket %q: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to open listener for %q: %w", path, err)
+	}
+
+	l, err := listenPrivate(ctx, lc, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open listener for %q: %w", path, err)
+	}
+	return l, nil
+}
+
+// systemdListener returns the socket passed by systemd socket activation,
+// following sd_listen_fds(3).
+func systemdListener() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, fmt.Errorf("no socket was passed by systemd socket activation: LISTEN_PID isn't the PID of toolbox")
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("no socket was passed by systemd socket activation: LISTEN_FDS is %q", os.Getenv("LISTEN_FDS"))
+	}
+	if n > 1 {
+		return nil, fmt.Errorf("systemd passed %d sockets, but toolbox listens on a single socket", n)
+	}
+	// the variables only apply to this process, not to its children
+	for _, v := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+		_ = os.Unsetenv(v)
+	}
+
+	f := os.NewFile(systemdListenFdsStart, "systemd-socket")
+	defer f.Close()
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on the socket passed by systemd: %w", err)
+	}
+	return l, nil
+}
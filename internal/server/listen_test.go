@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestValidateListen(t *testing.T) {
+	tcs := []struct {
+		listen string
+		err    string
+	}{
+		{listen: ""},
+		{listen: "systemd"},
+		{listen: "unix:/run/toolbox.sock"},
+		{listen: "unix:", err: `invalid listen address "unix:": missing path of the Unix domain socket`},
+		{listen: "tcp:127.0.0.1:5000", err: `invalid listen address "tcp:127.0.0.1:5000": must be "unix:<path>" or "systemd"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.listen, func(t *testing.T) {
+			err := validateListen(tc.listen)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestUnixListener(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "toolbox.sock")
+
+	// a socket left behind by a server that didn't shut down is replaced
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("unable to create socket: %s", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	l, err := openListener(ctx, "unix:"+path, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})}
+	go func() { _ = srv.Serve(l) }()
+	defer srv.Close()
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0o600 {
+		t.Fatalf("incorrect permissions of socket: got %o, want 600", perm)
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://toolbox/")
+	if err != nil {
+		t.Fatalf("unable to send request: %s", err)
+	}
+	defer resp.Body.Close()
+	if b, _ := io.ReadAll(resp.Body); string(b) != "ok" {
+		t.Fatalf("unexpected response: %q", string(b))
+	}
+
+	// a socket that is in use isn't replaced
+	if _, err := openListener(ctx, "unix:"+path, ""); err == nil || !strings.Contains(err.Error(), "socket is in use") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "toolbox.sock")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+	if _, err := openListener(ctx, "unix:"+file, ""); err == nil || !strings.Contains(err.Error(), "file exists and isn't a socket") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSystemdListenerErrors(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+	tcs := []struct {
+		desc string
+		pid  string
+		fds  string
+		err  string
+	}{
+		{
+			desc: "not activated",
+			err:  "no socket was passed by systemd socket activation: LISTEN_PID isn't the PID of toolbox",
+		},
+		{
+			desc: "other process",
+			pid:  "1",
+			fds:  "1",
+			err:  "no socket was passed by systemd socket activation: LISTEN_PID isn't the PID of toolbox",
+		},
+		{
+			desc: "no sockets",
+			pid:  pid,
+			fds:  "0",
+			err:  `no socket was passed by systemd socket activation: LISTEN_FDS is "0"`,
+		},
+		{
+			desc: "several sockets",
+			pid:  pid,
+			fds:  "2",
+			err:  "systemd passed 2 sockets, but toolbox listens on a single socket",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Setenv("LISTEN_PID", tc.pid)
+			t.Setenv("LISTEN_FDS", tc.fds)
+			_, err := openListener(context.Background(), "systemd", "")
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
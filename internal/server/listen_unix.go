@@ -0,0 +1,34 @@
+//go:build unix
+
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net"
+	"syscall"
+)
+
+// listenPrivate listens on a Unix domain socket that is created with
+// permissions for its owner only, so that other users can't connect to it
+// before its permissions are restricted. The umask applies to the whole
+// process, so files created by other goroutines meanwhile are restricted as
+// well, which is harmless while the server starts.
+func listenPrivate(ctx context.Context, lc net.ListenConfig, path string) (net.Listener, error) {
+	old := syscall.Umask(0o177)
+	defer syscall.Umask(old)
+	return lc.Listen(ctx, "unix", path)
+}
@@ -21,7 +21,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,18 +36,24 @@ import (
 	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	v20241105 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20241105"
 	v20250326 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20250326"
+	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 )
 
+// toolsetHeader is the header MCP clients can set to only be served the tools
+// of the given comma-separated toolsets.
+const toolsetHeader = "X-Toolbox-Toolset"
+
 type sseSession struct {
-	writer     http.ResponseWriter
-	flusher    http.Flusher
-	done       chan struct{}
-	eventQueue chan string
-	lastActive time.Time
+	toolsetName string
+	writer      http.ResponseWriter
+	flusher     http.Flusher
+	done        chan struct{}
+	eventQueue  chan string
+	lastActive  time.Time
 }
 
 // sseManager manages and control access to sse sessions
@@ -78,6 +86,17 @@ func (m *sseManager) add(id string, session *sseSession) {
 	session.lastActive = time.Now()
 }
 
+// list returns the open sse sessions.
+func (m *sseManager) list() []*sseSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sessions := make([]*sseSession, 0, len(m.sseSessions))
+	for _, session := range m.sseSessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
 func (m *sseManager) remove(id string) {
 	m.mu.Lock()
 	delete(m.sseSessions, id)
@@ -110,9 +129,14 @@ func (m *sseManager) cleanupRoutine(ctx context.Context) {
 
 type stdioSession struct {
 	protocol string
-	server   *Server
-	reader   *bufio.Reader
-	writer   io.Writer
+	// toolsetName is the comma-separated toolsets served to the client
+	toolsetName string
+	server      *Server
+	reader      *bufio.Reader
+	writer      io.Writer
+	// mu guards the protocol and writes to stdout, since notifications can
+	// be written while a request is processed
+	mu sync.Mutex
 }
 
 func NewStdioSession(s *Server, stdin io.Reader, stdout io.Writer) *stdioSession {
@@ -141,14 +165,23 @@ func (s *stdioSession) readInputStream(ctx context.Context) error {
 			}
 			return err
 		}
-		v, res, err := processMcpMessage(ctx, []byte(line), s.server, s.protocol, "")
+		// progress notifications are written before the response, since
+		// requests are processed one at a time
+		notify := func(notification any) {
+			if err := s.write(ctx, notification); err != nil {
+				s.server.logger.DebugContext(ctx, err.Error())
+			}
+		}
+		v, res, err := processMcpMessage(ctx, []byte(line), s.server, s.protocol, s.toolsetName, "", nil, notify)
 		if err != nil {
 			// errors during the processing of message will generate a valid MCP Error response.
 			// server can continue to run.
 			s.server.logger.ErrorContext(ctx, err.Error())
 		}
 		if v != "" {
+			s.mu.Lock()
 			s.protocol = v
+			s.mu.Unlock()
 		}
 		// no responses for notifications
 		if res != nil {
@@ -206,10 +239,19 @@ func (s *stdioSession) readLine(ctx context.Context) (string, error) {
 func (s *stdioSession) write(ctx context.Context, response any) error {
 	res, _ := json.Marshal(response)
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	_, err := fmt.Fprintf(s.writer, "%s\n", res)
 	return err
 }
 
+// initialized returns whether the client has initialized the session.
+func (s *stdioSession) initialized() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.protocol != ""
+}
+
 // mcpRouter creates a router that represents the routes under /mcp
 func mcpRouter(s *Server) (chi.Router, error) {
 	r := chi.NewRouter()
@@ -219,15 +261,15 @@ func mcpRouter(s *Server) (chi.Router, error) {
 	r.Use(render.SetContentType(render.ContentTypeJSON))
 
 	r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
-	r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) { streamHandler(s, w, r) })
 	r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-	r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+	r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, w, r) })
 
 	r.Route("/{toolsetName}", func(r chi.Router) {
 		r.Get("/sse", func(w http.ResponseWriter, r *http.Request) { sseHandler(s, w, r) })
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) { methodNotAllowed(s, w, r) })
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) { streamHandler(s, w, r) })
 		r.Post("/", func(w http.ResponseWriter, r *http.Request) { httpHandler(s, w, r) })
-		r.Delete("/", func(w http.ResponseWriter, r *http.Request) {})
+		r.Delete("/", func(w http.ResponseWriter, r *http.Request) { deleteSessionHandler(s, w, r) })
 	})
 
 	return r, nil
@@ -275,10 +317,11 @@ func sseHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
 	}
 	session := &sseSession{
-		writer:     w,
-		flusher:    flusher,
-		done:       make(chan struct{}),
-		eventQueue: make(chan string, 100),
+		toolsetName: toolsetName,
+		writer:      w,
+		flusher:     flusher,
+		done:        make(chan struct{}),
+		eventQueue:  make(chan string, 100),
 	}
 	s.sseManager.add(sessionId, session)
 	defer s.sseManager.remove(sessionId)
@@ -350,11 +393,20 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 
 	// check if client have `Mcp-Session-Id` header
-	// if `Mcp-Session-Id` header is set, we are using v2025-03-26 since
-	// previous version doesn't use this header.
+	// if `Mcp-Session-Id` header is set, we are using v2025-03-26 or later
+	// since previous version doesn't use this header.
 	headerSessionId := r.Header.Get("Mcp-Session-Id")
+	// sessions can only be used by the caller who started them
+	callerCtx := callerFromHeader(ctx, s, r.Header)
+	var mcpSess *mcpSession
 	if headerSessionId != "" {
 		protocolVersion = v20250326.PROTOCOL_VERSION
+		// sessions that are unknown, such as sessions created by another
+		// instance, are still processed, but their streams can't be resumed
+		if sess, ok := s.mcpSessionManager.get(callerCtx, headerSessionId); ok {
+			mcpSess = sess
+			protocolVersion = sess.protocolVersion
+		}
 	}
 
 	// check if client have `MCP-Protocol-Version` header
@@ -401,7 +453,34 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	v, res, err := processMcpMessage(ctx, body, s, protocolVersion, toolsetName)
+	// requests of a streamable HTTP session are answered with a resumable
+	// stream if the client accepts it
+	if mcpSess != nil && acceptsEventStream(r.Header) {
+		var baseMessage jsonrpc.BaseMessage
+		if err := json.Unmarshal(body, &baseMessage); err == nil && baseMessage.Id != nil && baseMessage.Method != "" {
+			if flusher, ok := w.(http.Flusher); ok {
+				streamMcpResponse(ctx, s, w, r, flusher, mcpSess, body, protocolVersion, toolsetName)
+				return
+			}
+		}
+	}
+
+	// notifications can only be sent out-of-band to clients connected via sse
+	var notify func(any)
+	if session != nil {
+		notify = func(notification any) {
+			queueSseEvent(ctx, s, session, notification)
+		}
+	}
+
+	// requests can only be cancelled within a session, since request ids are
+	// only unique per session
+	mcpSessionId := sessionId
+	if mcpSessionId == "" {
+		mcpSessionId = headerSessionId
+	}
+
+	v, res, err := processMcpMessage(ctx, body, s, protocolVersion, toolsetName, mcpSessionId, r.Header, notify)
 	// notifications will return empty string
 	if res == nil {
 		// Notifications do not expect a response
@@ -413,31 +492,145 @@ func httpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 		s.logger.DebugContext(ctx, err.Error())
 	}
 
-	// for v20250326, add the `Mcp-Session-Id` header
-	if v == v20250326.PROTOCOL_VERSION {
-		sessionId = uuid.New().String()
+	// for v20250326 and later, start a session and add the `Mcp-Session-Id`
+	// header
+	if v != "" && v != v20241105.PROTOCOL_VERSION {
+		var newSess *mcpSession
+		newSess, err = s.mcpSessionManager.create(callerCtx, v, toolsetName)
+		if err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusTooManyRequests))
+			return
+		}
+		sessionId = newSess.id
 		w.Header().Set("Mcp-Session-Id", sessionId)
 	}
 
 	if session != nil {
-		// queue sse event
-		eventData, _ := json.Marshal(res)
-		select {
-		case session.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", eventData):
-			s.logger.DebugContext(ctx, "event queue successful")
-		case <-session.done:
-			s.logger.DebugContext(ctx, "session is close")
-		default:
-			s.logger.DebugContext(ctx, "unable to add to event queue")
-		}
+		queueSseEvent(ctx, s, session, res)
 	}
 
 	// send HTTP response
 	render.JSON(w, r, res)
 }
 
-// processMcpMessage process the messages received from clients
-func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVersion string, toolsetName string) (string, any, error) {
+// acceptsEventStream returns whether the client accepts SSE responses.
+func acceptsEventStream(header http.Header) bool {
+	for _, v := range header.Values("Accept") {
+		for _, t := range strings.Split(v, ",") {
+			mediaType, _, _ := strings.Cut(t, ";")
+			if strings.TrimSpace(mediaType) == "text/event-stream" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// streamMcpResponse responds to a request of a streamable HTTP session with an
+// SSE stream of its progress notifications and response. The request keeps
+// running if the client disconnects, and the client can resume the stream with
+// a GET request with the `Last-Event-ID` header. Running requests are
+// cancelled when the session is terminated.
+func streamMcpResponse(ctx context.Context, s *Server, w http.ResponseWriter, r *http.Request, flusher http.Flusher, sess *mcpSession, body []byte, protocolVersion, toolsetName string) {
+	st := sess.newStream()
+	procCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(sess.ctx, cancel)
+	header := r.Header.Clone()
+	go func() {
+		defer cancel()
+		defer stop()
+		notify := func(notification any) {
+			data, _ := json.Marshal(notification)
+			st.add(data)
+		}
+		_, res, err := processMcpMessage(procCtx, body, s, protocolVersion, toolsetName, sess.id, header, notify)
+		if err != nil {
+			s.logger.DebugContext(procCtx, err.Error())
+		}
+		data, _ := json.Marshal(res)
+		st.add(data)
+		st.finish()
+	}()
+
+	writeMcpStream(r.Context(), w, flusher, sess, st, 0)
+}
+
+// streamHandler opens the notifications stream of the streamable HTTP session
+// in the `Mcp-Session-Id` header, or resumes a stream of the session after the
+// event in the `Last-Event-ID` header.
+func streamHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	sessionId := r.Header.Get("Mcp-Session-Id")
+	if sessionId == "" {
+		methodNotAllowed(s, w, r)
+		return
+	}
+	ctx, span := s.instrumentation.Tracer.Start(r.Context(), "toolbox/server/mcp/stream")
+	defer span.End()
+
+	sess, ok := s.mcpSessionManager.get(callerFromHeader(ctx, s, r.Header), sessionId)
+	if !ok {
+		err := fmt.Errorf("session not found")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+		return
+	}
+	// new notifications streams only receive the events sent after they
+	// are opened
+	st, i := sess.notifications, sess.notifications.end()
+	if lastEventId := r.Header.Get("Last-Event-ID"); lastEventId != "" {
+		st, i, ok = sess.resume(lastEventId)
+		if !ok {
+			err := fmt.Errorf("event %q not found", lastEventId)
+			s.logger.DebugContext(ctx, err.Error())
+			_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+			return
+		}
+		s.logger.DebugContext(ctx, fmt.Sprintf("resuming stream after event %s", lastEventId))
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err := fmt.Errorf("unable to retrieve flusher for sse")
+		s.logger.DebugContext(ctx, err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusInternalServerError))
+		return
+	}
+	writeMcpStream(r.Context(), w, flusher, sess, st, i)
+}
+
+// deleteSessionHandler terminates the streamable HTTP session in the
+// `Mcp-Session-Id` header, cancelling its running requests.
+func deleteSessionHandler(s *Server, w http.ResponseWriter, r *http.Request) {
+	sessionId := r.Header.Get("Mcp-Session-Id")
+	if sessionId == "" {
+		return
+	}
+	if !s.mcpSessionManager.remove(callerFromHeader(r.Context(), s, r.Header), sessionId) {
+		err := fmt.Errorf("session not found")
+		s.logger.DebugContext(r.Context(), err.Error())
+		_ = render.Render(w, r, newErrResponse(err, http.StatusNotFound))
+	}
+}
+
+// queueSseEvent queues a message to be sent to the client of the sse session.
+func queueSseEvent(ctx context.Context, s *Server, session *sseSession, message any) {
+	eventData, _ := json.Marshal(message)
+	select {
+	case session.eventQueue <- fmt.Sprintf("event: message\ndata: %s\n\n", eventData):
+		s.logger.DebugContext(ctx, "event queue successful")
+	case <-session.done:
+		s.logger.DebugContext(ctx, "session is close")
+	default:
+		s.logger.DebugContext(ctx, "unable to add to event queue")
+	}
+}
+
+// processMcpMessage process the messages received from clients. toolsetName
+// can be a comma-separated list of toolsets, whose tools are combined. If
+// sessionId is set, requests in the session can be cancelled by the client. If
+// notify is not nil, it is used to send progress notifications for requests
+// that include a progress token.
+func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVersion string, toolsetName string, sessionId string, header http.Header, notify func(any)) (string, any, error) {
 	logger, err := util.LoggerFromContext(ctx)
 	if err != nil {
 		return "", jsonrpc.NewError("", jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
@@ -476,6 +669,14 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 	// Check if message is a notification
 	if baseMessage.Id == nil {
 		err := mcp.NotificationHandler(ctx, body)
+		if err == nil && baseMessage.Method == mcputil.CANCELLED_NOTIFICATION && sessionId != "" {
+			var notification mcputil.CancelledNotification
+			if err := json.Unmarshal(body, &notification); err == nil {
+				if s.invocationManager.cancel(callerFromHeader(ctx, s, header), mcpInvocationId(sessionId, notification.Params.RequestId), "") {
+					logger.DebugContext(ctx, fmt.Sprintf("cancelled request %v: %s", notification.Params.RequestId, notification.Params.Reason))
+				}
+			}
+		}
 		return "", nil, err
 	}
 
@@ -487,12 +688,164 @@ func processMcpMessage(ctx context.Context, body []byte, s *Server, protocolVers
 		}
 		return v, res, err
 	default:
-		toolset, ok := s.ResourceMgr.GetToolset(toolsetName)
+		toolsets, ok := getMcpToolsets(s.ResourceMgr, toolsetName)
 		if !ok {
 			err = fmt.Errorf("toolset does not exist")
 			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
 		}
-		res, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, s.ResourceMgr.GetToolsMap(), body)
+		claimsFromAuth := claimsFromHeader(ctx, s, header)
+		for _, ts := range toolsets {
+			if !ts.Authorized(claimsFromAuth) {
+				err = fmt.Errorf("toolset %q not authorized", ts.Name)
+				return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+			}
+		}
+		toolset := mergeToolsets(toolsetName, toolsets)
+		// callers can narrow the tools they are served to some toolsets,
+		// which they must be authorized for as well
+		selectedName := header.Get(toolsetHeader)
+		if selectedName != "" {
+			selected, ok := getMcpToolsets(s.ResourceMgr, selectedName)
+			if !ok {
+				err = fmt.Errorf("toolset does not exist")
+				return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+			}
+			for _, ts := range selected {
+				if !ts.Authorized(claimsFromAuth) {
+					err = fmt.Errorf("toolset %q not authorized", ts.Name)
+					return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+				}
+			}
+			toolset = intersectToolsets(toolset, mergeToolsets(selectedName, selected))
+		}
+		toolset = s.ResourceMgr.authorizedToolset(toolset, claimsFromAuth)
+		ctx = withApprovals(util.WithClaims(ctx, claimsFromAuth), s.approvalManager)
+		ctx = util.WithAccessTokens(ctx, accessTokensFromHeader(ctx, s, header))
+		if sessionId != "" {
+			cancelCtx, _, done, err := s.invocationManager.start(ctx, mcpInvocationId(sessionId, baseMessage.Id), "")
+			if err != nil {
+				// the request can still be processed, it just can't be cancelled
+				logger.DebugContext(ctx, err.Error())
+			} else {
+				defer done()
+				ctx = cancelCtx
+			}
+		}
+		// tool calls are dry runs, and include the columns of their result,
+		// if either the header or the _meta of the request asks for it
+		dryRun, err := dryRunRequested(header)
+		if err != nil {
+			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+		}
+		includeSchema, err := schemaRequested(header)
+		if err != nil {
+			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+		}
+		var req jsonrpc.Request
+		if err := json.Unmarshal(body, &req); err == nil {
+			dryRun = dryRun || req.Params.Meta.DryRun
+			includeSchema = includeSchema || req.Params.Meta.IncludeSchema
+			if notify != nil && req.Params.Meta.ProgressToken != nil {
+				token := req.Params.Meta.ProgressToken
+				ctx = util.WithProgressReporter(ctx, func(progress, total float64, message string) {
+					notify(mcputil.NewProgressNotification(token, progress, total, message))
+				})
+			}
+		}
+		if dryRun {
+			ctx = withDryRun(ctx)
+		}
+		format, err := resultFormatRequested(header)
+		if err != nil {
+			return "", jsonrpc.NewError(baseMessage.Id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+		}
+		if format != "" {
+			ctx = withResultFormat(ctx, format)
+		}
+		if includeSchema {
+			ctx = util.WithSchema(ctx, &util.Schema{})
+		}
+		if s.toolDefaults.McpResourceThreshold > 0 {
+			ctx = mcputil.WithResourceThreshold(ctx, s.toolDefaults.McpResourceThreshold)
+		}
+		toolsMap := s.ResourceMgr.authorizedToolsMap(claimsFromAuth)
+		// clients connected to a toolset can only call the tools it lists
+		if toolsetName != "" || selectedName != "" {
+			maps.DeleteFunc(toolsMap, func(name string, _ tools.Tool) bool {
+				_, ok := toolset.Manifest.ToolsManifest[name]
+				return !ok
+			})
+		}
+		ctx = mcputil.WithResourceProvider(ctx, newMcpResources(toolset, toolsMap, s.ResourceMgr.GetSourcesMap(), claimsFromAuth))
+		res, err := mcp.ProcessMethod(ctx, protocolVersion, baseMessage.Id, baseMessage.Method, toolset, toolsMap, s.ResourceMgr.GetPromptsMap(), body)
 		return "", res, err
 	}
 }
+
+// getMcpToolsets returns the toolsets served to a MCP client, given the
+// comma-separated names of the toolsets.
+func getMcpToolsets(r *ResourceManager, toolsetName string) ([]tools.Toolset, bool) {
+	var toolsets []tools.Toolset
+	for _, name := range strings.Split(toolsetName, ",") {
+		toolset, ok := r.GetToolset(name)
+		if !ok {
+			return nil, false
+		}
+		toolsets = append(toolsets, toolset)
+	}
+	return toolsets, true
+}
+
+// intersectToolsets returns a copy of toolset that only lists the tools that
+// are also in other.
+func intersectToolsets(toolset, other tools.Toolset) tools.Toolset {
+	filtered := toolset
+	filtered.Manifest = tools.ToolsetManifest{
+		ServerVersion: toolset.Manifest.ServerVersion,
+		ToolsManifest: make(map[string]tools.Manifest),
+	}
+	filtered.SourceResources = toolset.SourceResources && other.SourceResources
+	filtered.McpManifest = nil
+	for _, m := range toolset.McpManifest {
+		if _, ok := other.Manifest.ToolsManifest[m.Name]; ok {
+			filtered.Manifest.ToolsManifest[m.Name] = toolset.Manifest.ToolsManifest[m.Name]
+			filtered.McpManifest = append(filtered.McpManifest, m)
+		}
+	}
+	return filtered
+}
+
+// mergeToolsets combines the tools of several toolsets into a single toolset.
+// The policies of the toolsets aren't combined, so callers must be authorized
+// for each toolset first.
+func mergeToolsets(name string, toolsets []tools.Toolset) tools.Toolset {
+	if len(toolsets) == 1 {
+		return toolsets[0]
+	}
+	merged := tools.Toolset{
+		Name: name,
+		Manifest: tools.ToolsetManifest{
+			ServerVersion: toolsets[0].Manifest.ServerVersion,
+			ToolsManifest: make(map[string]tools.Manifest),
+		},
+		// sources are only exposed if every toolset exposes them
+		SourceResources: true,
+	}
+	for _, toolset := range toolsets {
+		merged.SourceResources = merged.SourceResources && toolset.SourceResources
+		merged.Tools = append(merged.Tools, toolset.Tools...)
+		for _, m := range toolset.McpManifest {
+			if _, ok := merged.Manifest.ToolsManifest[m.Name]; ok {
+				continue
+			}
+			merged.Manifest.ToolsManifest[m.Name] = toolset.Manifest.ToolsManifest[m.Name]
+			merged.McpManifest = append(merged.McpManifest, m)
+		}
+	}
+	return merged
+}
+
+// mcpInvocationId returns the id used to cancel a request within a session.
+func mcpInvocationId(sessionId string, requestId jsonrpc.RequestId) string {
+	return fmt.Sprintf("mcp/%s/%v", sessionId, requestId)
+}
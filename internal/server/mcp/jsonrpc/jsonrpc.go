@@ -45,6 +45,12 @@ type Request struct {
 			// notifications. The receiver is not obligated to provide these
 			// notifications.
 			ProgressToken ProgressToken `json:"progressToken,omitempty"`
+			// If true, the caller is requesting a dry run of a tool call,
+			// which previews its effects without making any changes.
+			DryRun bool `json:"dryRun,omitempty"`
+			// If true, the caller is requesting the columns of the rows of
+			// the result of a tool call.
+			IncludeSchema bool `json:"includeSchema,omitempty"`
 		} `json:"_meta,omitempty"`
 	} `json:"params,omitempty"`
 }
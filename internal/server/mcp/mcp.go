@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"slices"
 
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	v20241105 "github.com/googleapis/genai-toolbox/internal/server/mcp/v20241105"
@@ -57,13 +58,21 @@ func InitializeResponse(ctx context.Context, id jsonrpc.RequestId, body []byte,
 		protocolVersion = LATEST_PROTOCOL_VERSION
 	}
 
-	toolsListChanged := false
+	toolsListChanged := true
+	resourcesListChanged := false
+	promptsListChanged := false
 	result := mcputil.InitializeResult{
 		ProtocolVersion: protocolVersion,
 		Capabilities: mcputil.ServerCapabilities{
 			Tools: &mcputil.ListChanged{
 				ListChanged: &toolsListChanged,
 			},
+			Resources: &mcputil.ListChanged{
+				ListChanged: &resourcesListChanged,
+			},
+			Prompts: &mcputil.ListChanged{
+				ListChanged: &promptsListChanged,
+			},
 		},
 		ServerInfo: mcputil.Implementation{
 			BaseMetadata: mcputil.BaseMetadata{
@@ -93,14 +102,14 @@ func NotificationHandler(ctx context.Context, body []byte) error {
 
 // ProcessMethod returns a response for the request.
 // This is the Operation phase of the lifecycle for MCP client-server connections.
-func ProcessMethod(ctx context.Context, mcpVersion string, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, body []byte) (any, error) {
+func ProcessMethod(ctx context.Context, mcpVersion string, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, prompts map[string]prompts.Prompt, body []byte) (any, error) {
 	switch mcpVersion {
 	case v20250618.PROTOCOL_VERSION:
-		return v20250618.ProcessMethod(ctx, id, method, toolset, tools, body)
+		return v20250618.ProcessMethod(ctx, id, method, toolset, tools, prompts, body)
 	case v20250326.PROTOCOL_VERSION:
-		return v20250326.ProcessMethod(ctx, id, method, toolset, tools, body)
+		return v20250326.ProcessMethod(ctx, id, method, toolset, tools, prompts, body)
 	default:
-		return v20241105.ProcessMethod(ctx, id, method, toolset, tools, body)
+		return v20241105.ProcessMethod(ctx, id, method, toolset, tools, prompts, body)
 	}
 }
 
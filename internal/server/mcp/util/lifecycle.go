@@ -86,7 +86,9 @@ type ClientCapabilities struct {
 // capabilities are defined here, in this schema, but this is not a closed set: any
 // server can define its own, additional capabilities.
 type ServerCapabilities struct {
-	Tools *ListChanged `json:"tools,omitempty"`
+	Tools     *ListChanged `json:"tools,omitempty"`
+	Resources *ListChanged `json:"resources,omitempty"`
+	Prompts   *ListChanged `json:"prompts,omitempty"`
 }
 
 // Base interface for metadata with name (identifier) and title (display name) properties.
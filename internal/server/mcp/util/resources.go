@@ -0,0 +1,56 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrResourceNotFound is returned when reading a resource that doesn't exist,
+// or that isn't exposed to the client.
+var ErrResourceNotFound = errors.New("resource not found")
+
+// Resource describes a resource the server exposes to a client.
+type Resource struct {
+	Uri         string
+	Name        string
+	Description string
+	MimeType    string
+}
+
+// ResourceProvider lists and reads the resources exposed to a client, such
+// as database schemas and tool documentation.
+type ResourceProvider interface {
+	ListResources(ctx context.Context) ([]Resource, error)
+	// ReadResource returns the MIME type and text of a resource, or an error
+	// wrapping ErrResourceNotFound.
+	ReadResource(ctx context.Context, uri string) (mimeType string, text string, err error)
+}
+
+const resourceProviderKey contextKey = "resourceProvider"
+
+// WithResourceProvider sets the provider of the resources exposed to the
+// client.
+func WithResourceProvider(ctx context.Context, p ResourceProvider) context.Context {
+	return context.WithValue(ctx, resourceProviderKey, p)
+}
+
+// ResourceProviderFromContext returns the provider of the resources exposed
+// to the client, or nil if no resources are exposed.
+func ResourceProviderFromContext(ctx context.Context) ResourceProvider {
+	p, _ := ctx.Value(resourceProviderKey).(ResourceProvider)
+	return p
+}
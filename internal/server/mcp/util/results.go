@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RESULT_URI_PREFIX is the prefix of the URIs of tool results returned as
+	// resources.
+	RESULT_URI_PREFIX = "toolbox://results/"
+	// resultRetention is how long a tool result can be read after it was last
+	// read.
+	resultRetention = 10 * time.Minute
+	// maxResults is the maximum number of tool results held at once.
+	maxResults = 100
+)
+
+// Results holds the tool results returned as resources. It is shared by every
+// session and protocol version.
+var Results = newResultStore()
+
+// result is a tool result that is read as a resource.
+type result struct {
+	toolName string
+	mimeType string
+	text     string
+	expiry   time.Time
+}
+
+// resultStore manages and control access to tool results returned as
+// resources.
+type resultStore struct {
+	mu      sync.Mutex
+	results map[string]*result
+}
+
+func newResultStore() *resultStore {
+	return &resultStore{
+		mu:      sync.Mutex{},
+		results: make(map[string]*result),
+	}
+}
+
+// Add stores the result of a tool and returns the URI of the resource.
+func (s *resultStore) Add(toolName, mimeType, text string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for id, r := range s.results {
+		if now.After(r.expiry) {
+			delete(s.results, id)
+		}
+	}
+	if len(s.results) >= maxResults {
+		// evict the result closest to expiring
+		var oldest string
+		for id, r := range s.results {
+			if oldest == "" || r.expiry.Before(s.results[oldest].expiry) {
+				oldest = id
+			}
+		}
+		delete(s.results, oldest)
+	}
+	id := uuid.New().String()
+	s.results[id] = &result{toolName: toolName, mimeType: mimeType, text: text, expiry: now.Add(resultRetention)}
+	return RESULT_URI_PREFIX + id
+}
+
+// Get returns the name of the tool, the MIME type and the text of the result
+// with the given URI, and extends its expiry.
+func (s *resultStore) Get(uri string) (toolName, mimeType, text string, ok bool) {
+	id, found := strings.CutPrefix(uri, RESULT_URI_PREFIX)
+	if !found {
+		return "", "", "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.results[id]
+	if !ok {
+		return "", "", "", false
+	}
+	now := time.Now()
+	if now.After(r.expiry) {
+		delete(s.results, id)
+		return "", "", "", false
+	}
+	r.expiry = now.Add(resultRetention)
+	return r.toolName, r.mimeType, r.text, true
+}
+
+type contextKey string
+
+const resourceThresholdKey contextKey = "resourceThreshold"
+
+// WithResourceThreshold sets the size in bytes above which tool results are
+// returned as resources instead of inline.
+func WithResourceThreshold(ctx context.Context, threshold int) context.Context {
+	return context.WithValue(ctx, resourceThresholdKey, threshold)
+}
+
+// ResourceThreshold returns the size in bytes above which tool results are
+// returned as resources, or 0 if they are always returned inline.
+func ResourceThreshold(ctx context.Context) int {
+	threshold, _ := ctx.Value(resourceThresholdKey).(int)
+	return threshold
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import "github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+
+// PROGRESS_NOTIFICATION is the method used for progress notifications.
+const PROGRESS_NOTIFICATION = "notifications/progress"
+
+/* Progress */
+
+// ProgressNotificationParams is the progress update for a request.
+type ProgressNotificationParams struct {
+	// The progress token which was given in the initial request, used to
+	// associate this notification with the request that is proceeding.
+	ProgressToken jsonrpc.ProgressToken `json:"progressToken"`
+	// The progress thus far. This should increase every time progress is made,
+	// even if the total is unknown.
+	Progress float64 `json:"progress"`
+	// Total number of items to process (or total progress required), if known.
+	Total float64 `json:"total,omitempty"`
+	// An optional message describing the current progress.
+	Message string `json:"message,omitempty"`
+}
+
+// ProgressNotification is an out-of-band notification used to inform the
+// receiver of a progress update for a long-running request.
+type ProgressNotification struct {
+	Jsonrpc string                     `json:"jsonrpc"`
+	Method  string                     `json:"method"`
+	Params  ProgressNotificationParams `json:"params"`
+}
+
+// NewProgressNotification creates a progress notification for the request
+// associated with the progress token.
+func NewProgressNotification(token jsonrpc.ProgressToken, progress float64, total float64, message string) ProgressNotification {
+	return ProgressNotification{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Method:  PROGRESS_NOTIFICATION,
+		Params: ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      progress,
+			Total:         total,
+			Message:       message,
+		},
+	}
+}
+
+/* Cancellation */
+
+// CANCELLED_NOTIFICATION is the method used to cancel a previously-issued request.
+const CANCELLED_NOTIFICATION = "notifications/cancelled"
+
+// CancelledNotification can be sent by either side to indicate that it is
+// cancelling a previously-issued request.
+type CancelledNotification struct {
+	jsonrpc.Notification
+	Params struct {
+		// The ID of the request to cancel.
+		RequestId jsonrpc.RequestId `json:"requestId"`
+		// An optional string describing the reason for the cancellation.
+		Reason string `json:"reason,omitempty"`
+	} `json:"params"`
+}
+
+/* List changed */
+
+// TOOLS_LIST_CHANGED_NOTIFICATION is the method used to inform the client that
+// the list of tools it can use has changed.
+const TOOLS_LIST_CHANGED_NOTIFICATION = "notifications/tools/list_changed"
+
+// NewToolsListChangedNotification creates a notification that the list of
+// tools has changed, which prompts the client to list the tools again.
+func NewToolsListChangedNotification() jsonrpc.JSONRPCNotification {
+	return jsonrpc.JSONRPCNotification{
+		Jsonrpc:      jsonrpc.JSONRPC_VERSION,
+		Notification: jsonrpc.Notification{Method: TOOLS_LIST_CHANGED_NOTIFICATION},
+	}
+}
@@ -18,20 +18,33 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 // ProcessMethod returns a response for the request.
-func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, body []byte) (any, error) {
+func ProcessMethod(ctx context.Context, id jsonrpc.RequestId, method string, toolset tools.Toolset, tools map[string]tools.Tool, promptsMap map[string]prompts.Prompt, body []byte) (any, error) {
 	switch method {
 	case TOOLS_LIST:
 		return toolsListHandler(id, toolset, body)
 	case TOOLS_CALL:
 		return toolsCallHandler(ctx, id, tools, body)
+	case RESOURCES_LIST:
+		return resourcesListHandler(ctx, id, body)
+	case RESOURCES_READ:
+		return resourcesReadHandler(ctx, id, tools, body)
+	case PROMPTS_LIST:
+		return promptsListHandler(id, promptsMap, body)
+	case PROMPTS_GET:
+		return promptsGetHandler(id, promptsMap, body)
 	default:
 		err := fmt.Errorf("invalid method %s", method)
 		return jsonrpc.NewError(id, jsonrpc.METHOD_NOT_FOUND, err.Error(), nil), err
@@ -55,6 +68,12 @@ func toolsListHandler(id jsonrpc.RequestId, toolset tools.Toolset, body []byte)
 	}, nil
 }
 
+// textResult returns the text of a result that is already serialized.
+func textResult(d any) (string, bool) {
+	t, ok := d.(tools.TextResult)
+	return string(t), ok
+}
+
 // toolsCallHandler generate a response for tools call.
 func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[string]tools.Tool, body []byte) (any, error) {
 	// retrieve logger from context
@@ -117,11 +136,31 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 		return jsonrpc.JSONRPCResponse{
 			Jsonrpc: jsonrpc.JSONRPC_VERSION,
 			Id:      id,
-			Result:  CallToolResult{Content: []TextContent{text}, IsError: true},
+			Result:  CallToolResult{Content: []any{text}, IsError: true},
 		}, nil
 	}
 
-	content := make([]TextContent, 0)
+	// large results are returned as a link to a resource that the client can
+	// read, instead of being inlined in the result
+	if threshold := mcputil.ResourceThreshold(ctx); threshold > 0 {
+		if b, err := json.Marshal(results); err == nil && len(b) > threshold {
+			link := ResourceLink{
+				Type:        "resource_link",
+				Uri:         mcputil.Results.Add(toolName, "application/json", string(b)),
+				Name:        fmt.Sprintf("%s result", toolName),
+				Description: fmt.Sprintf("The result of the %s tool, which is too large to return inline.", toolName),
+				MimeType:    "application/json",
+				Size:        len(b),
+			}
+			return jsonrpc.JSONRPCResponse{
+				Jsonrpc: jsonrpc.JSONRPC_VERSION,
+				Id:      id,
+				Result:  CallToolResult{Content: []any{link}},
+			}, nil
+		}
+	}
+
+	content := make([]any, 0)
 
 	sliceRes, ok := results.([]any)
 	if !ok {
@@ -130,6 +169,12 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 
 	for _, d := range sliceRes {
 		text := TextContent{Type: "text"}
+		if t, ok := textResult(d); ok {
+			// results serialized as CSV or Markdown are returned as they are
+			text.Text = t
+			content = append(content, text)
+			continue
+		}
 		dM, err := json.Marshal(d)
 		if err != nil {
 			text.Text = fmt.Sprintf("fail to marshal: %s, result: %s", err, d)
@@ -139,9 +184,145 @@ func toolsCallHandler(ctx context.Context, id jsonrpc.RequestId, tools map[strin
 		content = append(content, text)
 	}
 
+	// structured content must be an object, so the result is wrapped
+	structured := map[string]any{"result": results}
+	if schema, ok := util.SchemaFromContext(ctx); ok && schema.Columns() != nil {
+		// the columns of the rows, which the caller asked for
+		structured["schema"] = schema.Columns()
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result: CallToolResult{
+			Content:           content,
+			StructuredContent: structured,
+		},
+	}, nil
+}
+
+func resourcesListHandler(ctx context.Context, id jsonrpc.RequestId, body []byte) (any, error) {
+	var req ListResourcesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	resources := []Resource{}
+	if p := mcputil.ResourceProviderFromContext(ctx); p != nil {
+		rs, err := p.ListResources(ctx)
+		if err != nil {
+			err = fmt.Errorf("unable to list resources: %w", err)
+			return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+		}
+		for _, r := range rs {
+			resources = append(resources, Resource{Name: r.Name, Uri: r.Uri, Description: r.Description, MimeType: r.MimeType})
+		}
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ListResourcesResult{Resources: resources},
+	}, nil
+}
+
+// resourcesReadHandler returns a resource exposed to the client, or a tool
+// result that was returned as a resource.
+func resourcesReadHandler(ctx context.Context, id jsonrpc.RequestId, tools map[string]tools.Tool, body []byte) (any, error) {
+	var req ReadResourceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp resources read request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	uri := req.Params.Uri
+	if strings.HasPrefix(uri, mcputil.RESULT_URI_PREFIX) {
+		// tool results can only be read if their tool is still authorized
+		toolName, mimeType, text, ok := mcputil.Results.Get(uri)
+		if _, authorized := tools[toolName]; !ok || !authorized {
+			err := fmt.Errorf("resource %q not found", uri)
+			return jsonrpc.NewError(id, RESOURCE_NOT_FOUND, err.Error(), map[string]any{"uri": uri}), err
+		}
+		return jsonrpc.JSONRPCResponse{
+			Jsonrpc: jsonrpc.JSONRPC_VERSION,
+			Id:      id,
+			Result: ReadResourceResult{
+				Contents: []TextResourceContents{{Uri: uri, MimeType: mimeType, Text: text}},
+			},
+		}, nil
+	}
+	p := mcputil.ResourceProviderFromContext(ctx)
+	if p == nil {
+		err := fmt.Errorf("resource %q not found", uri)
+		return jsonrpc.NewError(id, RESOURCE_NOT_FOUND, err.Error(), map[string]any{"uri": uri}), err
+	}
+	mimeType, text, err := p.ReadResource(ctx, uri)
+	if errors.Is(err, mcputil.ErrResourceNotFound) {
+		err = fmt.Errorf("resource %q not found", uri)
+		return jsonrpc.NewError(id, RESOURCE_NOT_FOUND, err.Error(), map[string]any{"uri": uri}), err
+	}
+	if err != nil {
+		err = fmt.Errorf("unable to read resource %q: %w", uri, err)
+		return jsonrpc.NewError(id, jsonrpc.INTERNAL_ERROR, err.Error(), nil), err
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result: ReadResourceResult{
+			Contents: []TextResourceContents{{Uri: uri, MimeType: mimeType, Text: text}},
+		},
+	}, nil
+}
+
+func promptsListHandler(id jsonrpc.RequestId, promptsMap map[string]prompts.Prompt, body []byte) (any, error) {
+	var req ListPromptsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp prompts list request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	names := make([]string, 0, len(promptsMap))
+	for name := range promptsMap {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	manifests := make([]prompts.McpManifest, 0, len(names))
+	for _, name := range names {
+		manifests = append(manifests, promptsMap[name].McpManifest())
+	}
+	return jsonrpc.JSONRPCResponse{
+		Jsonrpc: jsonrpc.JSONRPC_VERSION,
+		Id:      id,
+		Result:  ListPromptsResult{Prompts: manifests},
+	}, nil
+}
+
+func promptsGetHandler(id jsonrpc.RequestId, promptsMap map[string]prompts.Prompt, body []byte) (any, error) {
+	var req GetPromptRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		err = fmt.Errorf("invalid mcp prompts get request: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_REQUEST, err.Error(), nil), err
+	}
+
+	prompt, ok := promptsMap[req.Params.Name]
+	if !ok {
+		err := fmt.Errorf("invalid prompt name: prompt with name %q does not exist", req.Params.Name)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+	rendered, err := prompt.Render(req.Params.Arguments)
+	if err != nil {
+		err = fmt.Errorf("provided arguments were invalid: %w", err)
+		return jsonrpc.NewError(id, jsonrpc.INVALID_PARAMS, err.Error(), nil), err
+	}
+	messages := make([]PromptMessage, 0, len(rendered))
+	for _, m := range rendered {
+		messages = append(messages, PromptMessage{
+			Role:    Role(m.Role),
+			Content: TextContent{Type: "text", Text: m.Text},
+		})
+	}
 	return jsonrpc.JSONRPCResponse{
 		Jsonrpc: jsonrpc.JSONRPC_VERSION,
 		Id:      id,
-		Result:  CallToolResult{Content: content},
+		Result:  GetPromptResult{Description: prompt.Description, Messages: messages},
 	}, nil
 }
@@ -15,6 +15,7 @@
 package v20250618
 
 import (
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 )
@@ -27,10 +28,17 @@ const PROTOCOL_VERSION = "2025-06-18"
 
 // methods that are supported.
 const (
-	TOOLS_LIST = "tools/list"
-	TOOLS_CALL = "tools/call"
+	TOOLS_LIST     = "tools/list"
+	TOOLS_CALL     = "tools/call"
+	RESOURCES_LIST = "resources/list"
+	RESOURCES_READ = "resources/read"
+	PROMPTS_LIST   = "prompts/list"
+	PROMPTS_GET    = "prompts/get"
 )
 
+// RESOURCE_NOT_FOUND is the error code for a resource that doesn't exist.
+const RESOURCE_NOT_FOUND = -32002
+
 /* Empty result */
 
 // EmptyResult represents a response that indicates success but carries no data.
@@ -128,9 +136,10 @@ type TextContent struct {
 // should be reported as an MCP error response.
 type CallToolResult struct {
 	jsonrpc.Result
-	// Could be either a TextContent, ImageContent, or EmbeddedResources
-	// For Toolbox, we will only be sending TextContent
-	Content []TextContent `json:"content"`
+	// Could be either a TextContent, ImageContent, ResourceLink, or
+	// EmbeddedResources. For Toolbox, we will only be sending TextContent, or a
+	// ResourceLink for large results.
+	Content []any `json:"content"`
 	// Whether the tool call ended in an error.
 	// If not set, this is assumed to be false (the call was successful).
 	//
@@ -178,3 +187,109 @@ type ToolAnnotations struct {
 	// Default: true
 	OpenWorldHint bool `json:"openWorldHint,omitempty"`
 }
+
+/* Resources */
+
+// ResourceLink is a link to a resource that the client can read, included in
+// the result of a tool call.
+type ResourceLink struct {
+	Annotated
+	Type string `json:"type"`
+	// The URI of this resource.
+	Uri string `json:"uri"`
+	// The name of this resource.
+	Name string `json:"name"`
+	// A description of what this resource represents.
+	Description string `json:"description,omitempty"`
+	// The MIME type of this resource, if known.
+	MimeType string `json:"mimeType,omitempty"`
+	// The size of the raw resource content, in bytes, if known.
+	Size int `json:"size,omitempty"`
+}
+
+// Sent from the client to request a list of resources the server has.
+type ListResourcesRequest struct {
+	PaginatedRequest
+}
+
+// A known resource that the server is capable of reading.
+type Resource struct {
+	// The name of this resource.
+	Name string `json:"name"`
+	// The URI of this resource.
+	Uri string `json:"uri"`
+	// A description of what this resource represents.
+	Description string `json:"description,omitempty"`
+	// The MIME type of this resource, if known.
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// The server's response to a resources/list request from the client.
+type ListResourcesResult struct {
+	PaginatedResult
+	Resources []Resource `json:"resources"`
+}
+
+// Sent from the client to the server, to read a specific resource URI.
+type ReadResourceRequest struct {
+	jsonrpc.Request
+	Params struct {
+		// The URI of the resource to read.
+		Uri string `json:"uri"`
+	} `json:"params"`
+}
+
+// TextResourceContents is the text contents of a resource.
+type TextResourceContents struct {
+	// The URI of this resource.
+	Uri string `json:"uri"`
+	// The MIME type of this resource, if known.
+	MimeType string `json:"mimeType,omitempty"`
+	// The text of the item.
+	Text string `json:"text"`
+}
+
+// The server's response to a resources/read request from the client.
+type ReadResourceResult struct {
+	jsonrpc.Result
+	Contents []TextResourceContents `json:"contents"`
+}
+
+/* Prompts */
+
+// Sent from the client to request a list of prompts and prompt templates the
+// server has.
+type ListPromptsRequest struct {
+	PaginatedRequest
+}
+
+// The server's response to a prompts/list request from the client.
+type ListPromptsResult struct {
+	PaginatedResult
+	Prompts []prompts.McpManifest `json:"prompts"`
+}
+
+// Used by the client to get a prompt provided by the server.
+type GetPromptRequest struct {
+	jsonrpc.Request
+	Params struct {
+		// The name of the prompt or prompt template.
+		Name string `json:"name"`
+		// Arguments to use for templating the prompt.
+		Arguments map[string]string `json:"arguments,omitempty"`
+	} `json:"params"`
+}
+
+// Describes a message returned as part of a prompt.
+type PromptMessage struct {
+	Role    Role        `json:"role"`
+	Content TextContent `json:"content"`
+}
+
+// The server's response to a prompts/get request from the client.
+type GetPromptResult struct {
+	jsonrpc.Result
+	// An optional description for the prompt.
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
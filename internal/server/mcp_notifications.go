@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// ReloadResources swaps all resources at once, so that requests never observe
+// a partial config, and notifies the connected MCP clients whose toolset
+// changed.
+func (s *Server) ReloadResources(ctx context.Context, sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt) {
+	oldToolsets := s.ResourceMgr.GetToolsetsMap()
+	s.ResourceMgr.SetResources(sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap)
+	if changed := changedToolsets(oldToolsets, toolsetsMap); len(changed) > 0 {
+		s.notifyToolsListChanged(ctx, changed)
+	}
+}
+
+// changedToolsets returns the names of the toolsets whose tools were added,
+// removed or changed.
+func changedToolsets(oldToolsets, newToolsets map[string]tools.Toolset) map[string]bool {
+	changed := make(map[string]bool)
+	for name, old := range oldToolsets {
+		if ts, ok := newToolsets[name]; !ok || !reflect.DeepEqual(old.McpManifest, ts.McpManifest) {
+			changed[name] = true
+		}
+	}
+	for name := range newToolsets {
+		if _, ok := oldToolsets[name]; !ok {
+			changed[name] = true
+		}
+	}
+	return changed
+}
+
+// toolsetChanged returns whether any of the comma-separated toolsets a client
+// is connected to changed.
+func toolsetChanged(changed map[string]bool, toolsetName string) bool {
+	return slices.ContainsFunc(strings.Split(toolsetName, ","), func(name string) bool {
+		return changed[name]
+	})
+}
+
+// notifyToolsListChanged sends a `notifications/tools/list_changed`
+// notification to the MCP clients connected to the given toolsets.
+func (s *Server) notifyToolsListChanged(ctx context.Context, toolsetNames map[string]bool) {
+	notification := mcputil.NewToolsListChangedNotification()
+	for _, session := range s.sseManager.list() {
+		if toolsetChanged(toolsetNames, session.toolsetName) {
+			queueSseEvent(ctx, s, session, notification)
+		}
+	}
+	data, _ := json.Marshal(notification)
+	for _, sess := range s.mcpSessionManager.list() {
+		if toolsetChanged(toolsetNames, sess.toolsetName) {
+			sess.notifications.add(data)
+		}
+	}
+	if stdio := s.stdio.Load(); stdio != nil && stdio.initialized() && toolsetChanged(toolsetNames, stdio.toolsetName) {
+		if err := stdio.write(ctx, notification); err != nil {
+			s.logger.DebugContext(ctx, err.Error())
+		}
+	}
+}
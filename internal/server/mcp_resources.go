@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	mcputil "github.com/googleapis/genai-toolbox/internal/server/mcp/util"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	// toolResourcePrefix is the prefix of the URIs of tool documentation.
+	toolResourcePrefix = "toolbox://tools/"
+	// sourceResourcePrefix is the prefix of the URIs of source schemas.
+	sourceResourcePrefix = "toolbox://sources/"
+)
+
+var _ mcputil.ResourceProvider = mcpResources{}
+
+// mcpResources exposes the documentation of the tools of a toolset, and the
+// tables of their sources if the toolset sets sourceResources, as MCP
+// resources. Sources are only exposed through the tools a caller is authorized
+// to use, including their authRequired.
+type mcpResources struct {
+	// toolNames is the tools of the toolset the caller is authorized to use.
+	toolNames []string
+	tools     map[string]tools.Tool
+	sources   map[string]sources.Source
+}
+
+func newMcpResources(toolset tools.Toolset, toolsMap map[string]tools.Tool, sourcesMap map[string]sources.Source, claimsFromAuth map[string]map[string]any) mcpResources {
+	verifiedAuthServices := make([]string, 0, len(claimsFromAuth))
+	for name := range claimsFromAuth {
+		verifiedAuthServices = append(verifiedAuthServices, name)
+	}
+	r := mcpResources{tools: toolsMap, sources: make(map[string]sources.Source)}
+	for _, m := range toolset.McpManifest {
+		tool, ok := toolsMap[m.Name]
+		if !ok {
+			continue
+		}
+		r.toolNames = append(r.toolNames, m.Name)
+		if !toolset.SourceResources || !tool.Authorized(verifiedAuthServices) {
+			continue
+		}
+		if it, ok := tool.(instrumentedTool); ok && it.source != "" {
+			if src, ok := sourcesMap[it.source]; ok {
+				r.sources[it.source] = src
+			}
+		}
+	}
+	slices.Sort(r.toolNames)
+	return r
+}
+
+func (r mcpResources) ListResources(ctx context.Context) ([]mcputil.Resource, error) {
+	resources := make([]mcputil.Resource, 0, len(r.toolNames))
+	for _, name := range r.toolNames {
+		resources = append(resources, mcputil.Resource{
+			Uri:         toolResourcePrefix + name,
+			Name:        name,
+			Description: fmt.Sprintf("Documentation of the %s tool.", name),
+			MimeType:    "text/markdown",
+		})
+	}
+
+	sourceNames := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		sourceNames = append(sourceNames, name)
+	}
+	slices.Sort(sourceNames)
+	for _, name := range sourceNames {
+		// lazily initialized sources are only listed once they are initialized
+		sd, ok := schemaDescriber(ctx, r.sources[name], false)
+		if !ok {
+			continue
+		}
+		tables, err := sd.ListTables(ctx)
+		if err != nil {
+			// a source that can't be reached shouldn't hide the other resources
+			if logger, lErr := util.LoggerFromContext(ctx); lErr == nil {
+				logger.WarnContext(ctx, fmt.Sprintf("unable to list tables of source %q: %s", name, err))
+			}
+			continue
+		}
+		for _, table := range tables {
+			resources = append(resources, mcputil.Resource{
+				Uri:         tableResourceUri(name, table),
+				Name:        fmt.Sprintf("%s/%s", name, table),
+				Description: fmt.Sprintf("Schema of the %s table in the %s source.", table, name),
+				MimeType:    "application/sql",
+			})
+		}
+	}
+	return resources, nil
+}
+
+func (r mcpResources) ReadResource(ctx context.Context, uri string) (string, string, error) {
+	if name, ok := strings.CutPrefix(uri, toolResourcePrefix); ok {
+		if !slices.Contains(r.toolNames, name) {
+			return "", "", fmt.Errorf("%w: %q", mcputil.ErrResourceNotFound, uri)
+		}
+		return "text/markdown", toolDoc(name, r.tools[name].Manifest()), nil
+	}
+
+	path, ok := strings.CutPrefix(uri, sourceResourcePrefix)
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", mcputil.ErrResourceNotFound, uri)
+	}
+	name, table, ok := strings.Cut(path, "/tables/")
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", mcputil.ErrResourceNotFound, uri)
+	}
+	table, err := url.PathUnescape(table)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %q", mcputil.ErrResourceNotFound, uri)
+	}
+	src, ok := r.sources[name]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", mcputil.ErrResourceNotFound, uri)
+	}
+	sd, ok := schemaDescriber(ctx, src, true)
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", mcputil.ErrResourceNotFound, uri)
+	}
+	ddl, err := sd.DescribeTable(ctx, table)
+	if errors.Is(err, sources.ErrTableNotFound) {
+		return "", "", fmt.Errorf("%w: %q", mcputil.ErrResourceNotFound, uri)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return "application/sql", ddl, nil
+}
+
+// schemaDescriber returns the source if it can describe its tables. A lazily
+// initialized source is initialized first if init is set, otherwise it is
+// only returned if it is already initialized.
+func schemaDescriber(ctx context.Context, src sources.Source, init bool) (sources.SchemaDescriber, bool) {
+	if ls, ok := src.(*lazySource); ok {
+		var err error
+		if init {
+			src, err = ls.get(ctx)
+		} else {
+			src, err = ls.peek()
+		}
+		if err != nil {
+			return nil, false
+		}
+	}
+	sd, ok := src.(sources.SchemaDescriber)
+	return sd, ok
+}
+
+func tableResourceUri(source, table string) string {
+	return fmt.Sprintf("%s%s/tables/%s", sourceResourcePrefix, source, url.PathEscape(table))
+}
+
+// toolDoc returns the documentation of a tool as markdown.
+func toolDoc(name string, m tools.Manifest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", name)
+	if m.Description != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.Description)
+	}
+	if len(m.AuthRequired) > 0 {
+		fmt.Fprintf(&b, "\nRequires authentication with one of: %s.\n", strings.Join(m.AuthRequired, ", "))
+	}
+	b.WriteString("\n## Parameters\n\n")
+	if len(m.Parameters) == 0 {
+		b.WriteString("This tool has no parameters.\n")
+		return b.String()
+	}
+	for _, p := range m.Parameters {
+		writeParamDoc(&b, p, 0)
+	}
+	return b.String()
+}
+
+func writeParamDoc(b *strings.Builder, p tools.ParameterManifest, depth int) {
+	typ := p.Type
+	if p.Items != nil {
+		typ = fmt.Sprintf("%s of %s", p.Type, p.Items.Type)
+	}
+	required := "optional"
+	if p.Required {
+		required = "required"
+	}
+	fmt.Fprintf(b, "%s- `%s` (%s, %s): %s", strings.Repeat("  ", depth), p.Name, typ, required, p.Description)
+	if p.Default != nil {
+		fmt.Fprintf(b, " Default: `%v`.", p.Default)
+	}
+	b.WriteString("\n")
+	for _, prop := range p.Properties {
+		writeParamDoc(b, prop, depth+1)
+	}
+	if p.Items != nil {
+		for _, prop := range p.Items.Properties {
+			writeParamDoc(b, prop, depth+1)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	// mcpSessionTimeout is how long a streamable HTTP session is kept after
+	// the last request of its client.
+	mcpSessionTimeout = 10 * time.Minute
+	// mcpStreamRetention is how long the events of a finished stream can be
+	// replayed by a client that reconnects.
+	mcpStreamRetention = 5 * time.Minute
+	// maxMcpStreamEvents is the number of events a stream buffers for
+	// clients that reconnect. Older events are dropped, so that a long-lived
+	// stream, such as the notifications stream, doesn't grow without bound.
+	maxMcpStreamEvents = 100
+	// maxMcpSessions is the maximum number of sessions kept at once. The
+	// least recently active session without open streams is expired to make
+	// room for a new one.
+	maxMcpSessions = 1000
+)
+
+// errTooManyMcpSessions is returned when starting a session while
+// maxMcpSessions sessions have open streams.
+var errTooManyMcpSessions = fmt.Errorf("too many sessions, at most %d sessions can be open at once", maxMcpSessions)
+
+// mcpEvent is an event of a resumable SSE stream.
+type mcpEvent struct {
+	id   string
+	data []byte
+}
+
+// mcpStream buffers the events sent in response to a request of a streamable
+// HTTP session, so that a client that loses its connection can resume the
+// stream with the `Last-Event-ID` header.
+type mcpStream struct {
+	id     string
+	mu     sync.Mutex
+	events []mcpEvent
+	// dropped is the number of events dropped from the start of the stream,
+	// which is the index of the first buffered event
+	dropped  int
+	done     bool
+	finished time.Time
+	// changed is closed, and replaced, whenever an event is added or the
+	// stream is finished
+	changed chan struct{}
+}
+
+func (st *mcpStream) add(data []byte) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.events = append(st.events, mcpEvent{id: fmt.Sprintf("%s_%d", st.id, st.dropped+len(st.events)), data: data})
+	if n := len(st.events) - maxMcpStreamEvents; n > 0 {
+		st.events = slices.Delete(st.events, 0, n)
+		st.dropped += n
+	}
+	close(st.changed)
+	st.changed = make(chan struct{})
+}
+
+// finish marks that the last event of the stream has been added.
+func (st *mcpStream) finish() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.done = true
+	st.finished = time.Now()
+	close(st.changed)
+	st.changed = make(chan struct{})
+}
+
+// since returns the buffered events from index i onwards, the index of the
+// event that follows them, whether the stream is finished, and a channel that
+// is closed when the stream changes.
+func (st *mcpStream) since(i int) ([]mcpEvent, int, bool, <-chan struct{}) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	events := st.events[min(max(i-st.dropped, 0), len(st.events)):]
+	return events, st.dropped + len(st.events), st.done, st.changed
+}
+
+// end returns the index of the next event of the stream.
+func (st *mcpStream) end() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.dropped + len(st.events)
+}
+
+// mcpSession is a session of the streamable HTTP transport, identified by the
+// `Mcp-Session-Id` header.
+type mcpSession struct {
+	id              string
+	protocolVersion string
+	toolsetName     string
+	// caller identifies the caller who started the session, who is the only
+	// one who can use it, see util.CallerIdentity
+	caller string
+	// notifications is the stream of messages that aren't sent in response
+	// to a request, such as list changed notifications
+	notifications *mcpStream
+	// ctx is cancelled when the session is terminated, which cancels the
+	// requests that are still running
+	ctx        context.Context
+	cancel     context.CancelFunc
+	mu         sync.Mutex
+	lastActive time.Time
+	// connections is the number of open streams, which keep the session
+	// alive
+	connections int
+	streams     map[string]*mcpStream
+}
+
+// newStream creates a stream for the response to a request.
+func (sess *mcpSession) newStream() *mcpStream {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.newStreamLocked()
+}
+
+func (sess *mcpSession) newStreamLocked() *mcpStream {
+	// event ids are the stream id and the index of the event, separated by
+	// an underscore, which uuids don't contain
+	st := &mcpStream{id: uuid.New().String(), changed: make(chan struct{})}
+	sess.streams[st.id] = st
+	return st
+}
+
+// connect marks that a stream of the session is open.
+func (sess *mcpSession) connect() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.connections++
+}
+
+// disconnect marks that a stream of the session was closed.
+func (sess *mcpSession) disconnect() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	sess.connections--
+	sess.lastActive = time.Now()
+}
+
+// resume returns the stream of an event, and the index of the event that
+// follows it.
+func (sess *mcpSession) resume(lastEventId string) (*mcpStream, int, bool) {
+	streamId, seq, ok := strings.Cut(lastEventId, "_")
+	if !ok {
+		return nil, 0, false
+	}
+	i, err := strconv.Atoi(seq)
+	if err != nil || i < 0 {
+		return nil, 0, false
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	st, ok := sess.streams[streamId]
+	return st, i + 1, ok
+}
+
+// close terminates the session, cancelling its running requests and ending
+// its notifications stream.
+func (sess *mcpSession) close() {
+	sess.cancel()
+	sess.notifications.finish()
+}
+
+// removeFinishedStreams removes the streams that finished before the given
+// time.
+func (sess *mcpSession) removeFinishedStreams(before time.Time) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	for id, st := range sess.streams {
+		st.mu.Lock()
+		expired := st.done && st.finished.Before(before)
+		st.mu.Unlock()
+		if expired {
+			delete(sess.streams, id)
+		}
+	}
+}
+
+// mcpSessionManager manages the sessions of the streamable HTTP transport.
+type mcpSessionManager struct {
+	ctx      context.Context
+	mu       sync.Mutex
+	sessions map[string]*mcpSession
+}
+
+func newMcpSessionManager(ctx context.Context) *mcpSessionManager {
+	m := &mcpSessionManager{
+		ctx:      ctx,
+		sessions: make(map[string]*mcpSession),
+	}
+	go m.cleanupRoutine(ctx)
+	return m
+}
+
+// create starts a new session of the caller in ctx for the given protocol
+// version and toolset.
+func (m *mcpSessionManager) create(ctx context.Context, protocolVersion, toolsetName string) (*mcpSession, error) {
+	sessCtx, cancel := context.WithCancel(m.ctx)
+	sess := &mcpSession{
+		id:              uuid.New().String(),
+		protocolVersion: protocolVersion,
+		toolsetName:     toolsetName,
+		caller:          util.CallerIdentity(ctx),
+		ctx:             sessCtx,
+		cancel:          cancel,
+		lastActive:      time.Now(),
+		streams:         make(map[string]*mcpStream),
+	}
+	sess.notifications = sess.newStreamLocked()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.sessions) >= maxMcpSessions && !m.expireLeastActive() {
+		cancel()
+		return nil, errTooManyMcpSessions
+	}
+	m.sessions[sess.id] = sess
+	return sess, nil
+}
+
+// expireLeastActive expires the least recently active session without open
+// streams, and reports whether there was one. m.mu must be held.
+func (m *mcpSessionManager) expireLeastActive() bool {
+	var oldest *mcpSession
+	for _, sess := range m.sessions {
+		sess.mu.Lock()
+		idle := sess.connections == 0 && (oldest == nil || sess.lastActive.Before(oldest.lastActive))
+		sess.mu.Unlock()
+		if idle {
+			oldest = sess
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	delete(m.sessions, oldest.id)
+	oldest.close()
+	return true
+}
+
+// get returns the session with the given id, if it was started by the caller
+// in ctx.
+func (m *mcpSessionManager) get(ctx context.Context, id string) (*mcpSession, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sess, ok := m.sessions[id]
+	if !ok || sess.caller != util.CallerIdentity(ctx) {
+		return nil, false
+	}
+	sess.mu.Lock()
+	sess.lastActive = time.Now()
+	sess.mu.Unlock()
+	return sess, true
+}
+
+// list returns the sessions that are alive.
+func (m *mcpSessionManager) list() []*mcpSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sessions := make([]*mcpSession, 0, len(m.sessions))
+	for _, sess := range m.sessions {
+		sessions = append(sessions, sess)
+	}
+	return sessions
+}
+
+// remove terminates a session of the caller in ctx, cancelling its running
+// requests.
+func (m *mcpSessionManager) remove(ctx context.Context, id string) bool {
+	m.mu.Lock()
+	sess, ok := m.sessions[id]
+	ok = ok && sess.caller == util.CallerIdentity(ctx)
+	if ok {
+		delete(m.sessions, id)
+	}
+	m.mu.Unlock()
+	if ok {
+		sess.close()
+	}
+	return ok
+}
+
+func (m *mcpSessionManager) cleanupRoutine(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			var expired []*mcpSession
+			m.mu.Lock()
+			for id, sess := range m.sessions {
+				sess.mu.Lock()
+				idle := sess.connections == 0 && now.Sub(sess.lastActive) > mcpSessionTimeout
+				sess.mu.Unlock()
+				if idle {
+					delete(m.sessions, id)
+					expired = append(expired, sess)
+					continue
+				}
+				sess.removeFinishedStreams(now.Add(-mcpStreamRetention))
+			}
+			m.mu.Unlock()
+			for _, sess := range expired {
+				sess.close()
+			}
+		}
+	}
+}
+
+// writeMcpStream writes the events of a stream of a session from index i
+// onwards as SSE, until the stream is finished or the client disconnects.
+func writeMcpStream(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, sess *mcpSession, st *mcpStream, i int) {
+	sess.connect()
+	defer sess.disconnect()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Mcp-Session-Id", sess.id)
+	w.WriteHeader(http.StatusOK)
+	for {
+		events, next, done, changed := st.since(i)
+		for _, e := range events {
+			fmt.Fprintf(w, "id: %s\nevent: message\ndata: %s\n\n", e.id, e.data)
+		}
+		flusher.Flush()
+		i = next
+		if done {
+			return
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -18,19 +18,30 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp/jsonrpc"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 const jsonrpcVersion = "2.0"
@@ -264,7 +275,9 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2024-11-05",
 					"capabilities": map[string]any{
-						"tools": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": true},
+						"resources": map[string]any{"listChanged": false},
+						"prompts":   map[string]any{"listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -280,7 +293,9 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2025-03-26",
 					"capabilities": map[string]any{
-						"tools": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": true},
+						"resources": map[string]any{"listChanged": false},
+						"prompts":   map[string]any{"listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -296,7 +311,9 @@ func TestMcpEndpoint(t *testing.T) {
 				"result": map[string]any{
 					"protocolVersion": "2025-06-18",
 					"capabilities": map[string]any{
-						"tools": map[string]any{"listChanged": false},
+						"tools":     map[string]any{"listChanged": true},
+						"resources": map[string]any{"listChanged": false},
+						"prompts":   map[string]any{"listChanged": false},
 					},
 					"serverInfo": map[string]any{"name": serverName, "version": fakeVersionString},
 				},
@@ -602,6 +619,426 @@ func TestGetEndpoint(t *testing.T) {
 	}
 }
 
+// gatedTool is a mock tool that reports its progress, and then runs until it
+// is released.
+type gatedTool struct {
+	MockTool
+	release chan struct{}
+}
+
+func (t gatedTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	util.ReportProgress(ctx, 0, 1, "started")
+	select {
+	case <-t.release:
+		return []any{t.Name}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// readSseEvent reads the id and data of the next event of a SSE stream.
+func readSseEvent(r *bufio.Reader) (string, string, error) {
+	var id, data string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", "", err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return id, data, nil
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestStreamableHttpSession(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	gated := gatedTool{MockTool: MockTool{Name: "gated", Params: []tools.Parameter{}}, release: make(chan struct{})}
+	toolsMap[gated.Name] = gated
+	blocking := blockingTool{MockTool: MockTool{Name: "blocking", Params: []tools.Parameter{}}, started: make(chan struct{}, 1)}
+	toolsMap[blocking.Name] = blocking
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	initialize := func(t *testing.T) string {
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":"init","method":"initialize","params":{"protocolVersion":%q}}`, protocolVersion20250618)
+		resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(body), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		sessionId := resp.Header.Get("Mcp-Session-Id")
+		if sessionId == "" {
+			t.Fatalf("Mcp-Session-Id header is expected")
+		}
+		return sessionId
+	}
+	stream := func(t *testing.T, method, body string, header map[string]string) (*http.Response, *bufio.Reader) {
+		var reqBody io.Reader
+		if body != "" {
+			reqBody = strings.NewReader(body)
+		}
+		req, err := http.NewRequest(method, ts.URL+"/", reqBody)
+		if err != nil {
+			t.Fatalf("unable to create request: %s", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unable to send request: %s", err)
+		}
+		return resp, bufio.NewReader(resp.Body)
+	}
+
+	t.Run("json response without event stream", func(t *testing.T) {
+		sessionId := initialize(t)
+		body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"no_params"}}`
+		resp, _, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(body), map[string]string{"Mcp-Session-Id": sessionId})
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+			t.Fatalf("unexpected content-type header: %s", contentType)
+		}
+	})
+
+	t.Run("resume after disconnect", func(t *testing.T) {
+		sessionId := initialize(t)
+		header := map[string]string{"Mcp-Session-Id": sessionId}
+		body := `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"gated","_meta":{"progressToken":"token-1"}}}`
+		resp, reader := stream(t, http.MethodPost, body, header)
+		if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+			t.Fatalf("unexpected content-type header: %s", contentType)
+		}
+		id, data, err := readSseEvent(reader)
+		if err != nil {
+			t.Fatalf("unable to read event: %s", err)
+		}
+		if id == "" || !strings.Contains(data, `"method":"notifications/progress"`) {
+			t.Fatalf("unexpected event: id %q, data %s", id, data)
+		}
+		// the client disconnects before the tool finishes
+		resp.Body.Close()
+		close(gated.release)
+
+		header["Last-Event-ID"] = id
+		resp, reader = stream(t, http.MethodGet, "", header)
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %s", resp.Status)
+		}
+		_, data, err = readSseEvent(reader)
+		if err != nil {
+			t.Fatalf("unable to read event: %s", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(data), &got); err != nil {
+			t.Fatalf("unexpected error unmarshalling event: %s", err)
+		}
+		want := map[string]any{
+			"jsonrpc": "2.0",
+			"id":      float64(2),
+			"result": map[string]any{
+				"content":           []any{map[string]any{"type": "text", "text": `"gated"`}},
+				"structuredContent": map[string]any{"result": []any{"gated"}},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected response (-want +got):\n%s", diff)
+		}
+		if _, _, err := readSseEvent(reader); err == nil {
+			t.Fatalf("expected the stream to end after the response")
+		}
+	})
+
+	t.Run("resume errors", func(t *testing.T) {
+		sessionId := initialize(t)
+		testCases := []struct {
+			name   string
+			header map[string]string
+		}{
+			{name: "unknown session", header: map[string]string{"Mcp-Session-Id": "unknown", "Last-Event-ID": "stream_0"}},
+			{name: "unknown event", header: map[string]string{"Mcp-Session-Id": sessionId, "Last-Event-ID": "stream_0"}},
+			{name: "invalid event", header: map[string]string{"Mcp-Session-Id": sessionId, "Last-Event-ID": "invalid"}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				resp, _, err := runRequest(ts, http.MethodGet, "/", nil, tc.header)
+				if err != nil {
+					t.Fatalf("unexpected error during request: %s", err)
+				}
+				if resp.StatusCode != http.StatusNotFound {
+					t.Fatalf("unexpected status: %s", resp.Status)
+				}
+			})
+		}
+	})
+
+	t.Run("delete cancels running requests", func(t *testing.T) {
+		sessionId := initialize(t)
+		header := map[string]string{"Mcp-Session-Id": sessionId}
+		body := `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"blocking"}}`
+		resp, reader := stream(t, http.MethodPost, body, header)
+		defer resp.Body.Close()
+		<-blocking.started
+
+		delResp, _, err := runRequest(ts, http.MethodDelete, "/", nil, header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if delResp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %s", delResp.Status)
+		}
+		_, data, err := readSseEvent(reader)
+		if err != nil {
+			t.Fatalf("unable to read event: %s", err)
+		}
+		if !strings.Contains(data, `"isError":true`) {
+			t.Fatalf("unexpected response: %s", data)
+		}
+
+		// the session no longer exists
+		delResp, _, err = runRequest(ts, http.MethodDelete, "/", nil, header)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		if delResp.StatusCode != http.StatusNotFound {
+			t.Fatalf("unexpected status: %s", delResp.Status)
+		}
+	})
+}
+
+func TestMcpToolsListChanged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	s := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		instrumentation:   instrumentation,
+		sseManager:        newSseManager(ctx),
+		mcpSessionManager: newMcpSessionManager(ctx),
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+	}
+	r, err := mcpRouter(s)
+	if err != nil {
+		t.Fatalf("unable to initialize mcp router: %s", err)
+	}
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	// a client connected via sse
+	sseResp, err := runSseRequest(ts, "/sse", "")
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	defer sseResp.Body.Close()
+	sseReader := bufio.NewReader(sseResp.Body)
+	if _, _, err := readSseEvent(sseReader); err != nil {
+		t.Fatalf("unable to read endpoint event: %s", err)
+	}
+
+	// clients of streamable HTTP sessions, which open their notifications
+	// streams
+	openStream := func(path string) (string, *http.Response) {
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":"init","method":"initialize","params":{"protocolVersion":%q}}`, protocolVersion20250618)
+		resp, _, err := runRequest(ts, http.MethodPost, path, bytes.NewBufferString(body), nil)
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		sessionId := resp.Header.Get("Mcp-Session-Id")
+		req, err := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+		if err != nil {
+			t.Fatalf("unable to create request: %s", err)
+		}
+		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set("Mcp-Session-Id", sessionId)
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unable to send request: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status: %s", resp.Status)
+		}
+		return sessionId, resp
+	}
+	_, streamResp := openStream("/")
+	defer streamResp.Body.Close()
+	otherSessionId, otherResp := openStream("/tool2_only")
+	defer otherResp.Body.Close()
+
+	// tool2 is removed from the default toolset
+	newToolsets := maps.Clone(toolsets)
+	tc := tools.ToolsetConfig{Name: "", ToolNames: []string{tool1.Name}}
+	newToolsets[""], err = tc.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	s.ReloadResources(ctx, nil, nil, toolsMap, newToolsets, nil)
+
+	want := `"method":"notifications/tools/list_changed"`
+	for name, reader := range map[string]*bufio.Reader{"sse": sseReader, "streamable http": bufio.NewReader(streamResp.Body)} {
+		_, data, err := readSseEvent(reader)
+		if err != nil {
+			t.Fatalf("unable to read %s event: %s", name, err)
+		}
+		if !strings.Contains(data, want) {
+			t.Fatalf("unexpected %s event: got %s, want to contain %s", name, data, want)
+		}
+	}
+	sess, ok := s.mcpSessionManager.get(ctx, otherSessionId)
+	if !ok {
+		t.Fatalf("session %q not found", otherSessionId)
+	}
+	if n := sess.notifications.end(); n != 0 {
+		t.Fatalf("unexpected notifications for unchanged toolset: %d", n)
+	}
+}
+
+func TestChangedToolsets(t *testing.T) {
+	_, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	_, swapped := setUpResources(t, []MockTool{tool2, tool1})
+
+	testCases := []struct {
+		name        string
+		newToolsets map[string]tools.Toolset
+		want        map[string]bool
+	}{
+		{
+			name:        "unchanged",
+			newToolsets: toolsets,
+			want:        map[string]bool{},
+		},
+		{
+			name:        "changed tools",
+			newToolsets: swapped,
+			want:        map[string]bool{"": true, "tool1_only": true, "tool2_only": true},
+		},
+		{
+			name:        "removed and added toolsets",
+			newToolsets: map[string]tools.Toolset{"": toolsets[""], "tool1_only": toolsets["tool1_only"], "new": toolsets["tool2_only"]},
+			want:        map[string]bool{"tool2_only": true, "new": true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := changedToolsets(toolsets, tc.newToolsets)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected changed toolsets (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMcpMultipleToolsets(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), testLogger)
+	s := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+	}
+	process := func(t *testing.T, toolsetName, body string, header http.Header) map[string]any {
+		_, res, _ := processMcpMessage(ctx, []byte(body), s, protocolVersion20250618, toolsetName, "", header, nil)
+		b, err := json.Marshal(res)
+		if err != nil {
+			t.Fatalf("unable to marshal response: %s", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("unable to unmarshal response: %s", err)
+		}
+		return got
+	}
+	toolNames := func(res map[string]any) []string {
+		var names []string
+		result, _ := res["result"].(map[string]any)
+		list, _ := result["tools"].([]any)
+		for _, tool := range list {
+			names = append(names, tool.(map[string]any)["name"].(string))
+		}
+		return names
+	}
+	list := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`
+
+	t.Run("combined toolsets", func(t *testing.T) {
+		got := toolNames(process(t, "tool1_only,tool2_only", list, nil))
+		want := []string{tool1.Name, tool2.Name}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected tools (-want +got):\n%s", diff)
+		}
+	})
+	t.Run("unknown toolset", func(t *testing.T) {
+		res := process(t, "tool1_only,unknown", list, nil)
+		errObj, _ := res["error"].(map[string]any)
+		if errObj["message"] != "toolset does not exist" {
+			t.Fatalf("unexpected response: %v", res)
+		}
+	})
+	t.Run("tools outside of the toolset can't be called", func(t *testing.T) {
+		call := fmt.Sprintf(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":%q}}`, tool1.Name)
+		if res := process(t, "tool1_only", call, nil); res["error"] != nil {
+			t.Fatalf("unexpected error: %v", res)
+		}
+		res := process(t, "tool2_only", call, nil)
+		errObj, _ := res["error"].(map[string]any)
+		want := fmt.Sprintf("invalid tool name: tool with name %q does not exist", tool1.Name)
+		if errObj["message"] != want {
+			t.Fatalf("unexpected response: %v", res)
+		}
+	})
+	t.Run("toolset header", func(t *testing.T) {
+		header := http.Header{"X-Toolbox-Toolset": []string{"tool2_only"}}
+		got := toolNames(process(t, "", list, header))
+		if diff := cmp.Diff([]string{tool2.Name}, got); diff != "" {
+			t.Fatalf("unexpected tools (-want +got):\n%s", diff)
+		}
+		// the header narrows the toolset of the path
+		if got := toolNames(process(t, "tool1_only", list, header)); len(got) != 0 {
+			t.Fatalf("unexpected tools: %v", got)
+		}
+
+		call := fmt.Sprintf(`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":%q}}`, tool1.Name)
+		res := process(t, "", call, header)
+		errObj, _ := res["error"].(map[string]any)
+		want := fmt.Sprintf("invalid tool name: tool with name %q does not exist", tool1.Name)
+		if errObj["message"] != want {
+			t.Fatalf("unexpected response: %v", res)
+		}
+
+		res = process(t, "", list, http.Header{"X-Toolbox-Toolset": []string{"unknown"}})
+		errObj, _ = res["error"].(map[string]any)
+		if errObj["message"] != "toolset does not exist" {
+			t.Fatalf("unexpected response: %v", res)
+		}
+	})
+}
+
 func TestSseEndpoint(t *testing.T) {
 	r, shutdown := setUpServer(t, "mcp", nil, nil)
 	defer shutdown()
@@ -713,6 +1150,110 @@ func runSseRequest(ts *httptest.Server, path string, proto string) (*http.Respon
 	return resp, nil
 }
 
+func TestMcpStreamMaxEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := newMcpSessionManager(ctx)
+	sess, err := m.create(ctx, "2025-06-18", "")
+	if err != nil {
+		t.Fatalf("unable to create session: %s", err)
+	}
+	st := sess.notifications
+	for i := range maxMcpStreamEvents + 10 {
+		st.add([]byte(fmt.Sprintf(`{"n":%d}`, i)))
+	}
+	if got, want := st.end(), maxMcpStreamEvents+10; got != want {
+		t.Fatalf("unexpected end of stream: got %d, want %d", got, want)
+	}
+
+	// clients resuming from a dropped event get the oldest buffered events
+	events, next, _, _ := st.since(0)
+	if len(events) != maxMcpStreamEvents {
+		t.Fatalf("unexpected number of buffered events: got %d, want %d", len(events), maxMcpStreamEvents)
+	}
+	if want := fmt.Sprintf("%s_10", st.id); events[0].id != want {
+		t.Fatalf("unexpected id of the oldest buffered event: got %q, want %q", events[0].id, want)
+	}
+	if next != st.end() {
+		t.Fatalf("unexpected next index: got %d, want %d", next, st.end())
+	}
+
+	// resuming from the last event id returns the events that follow it
+	resumed, i, ok := sess.resume(fmt.Sprintf("%s_%d", st.id, maxMcpStreamEvents+7))
+	if !ok || resumed != st {
+		t.Fatalf("unable to resume stream")
+	}
+	events, _, _, _ = st.since(i)
+	if len(events) != 2 || string(events[0].data) != fmt.Sprintf(`{"n":%d}`, maxMcpStreamEvents+8) {
+		t.Fatalf("unexpected resumed events: %v", events)
+	}
+}
+
+func TestMcpSessionCaller(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := newMcpSessionManager(ctx)
+	alice := util.WithClaims(ctx, map[string]map[string]any{"reviewers": {"sub": "alice"}})
+	bob := util.WithClaims(ctx, map[string]map[string]any{"reviewers": {"sub": "bob"}})
+	sess, err := m.create(alice, "2025-06-18", "")
+	if err != nil {
+		t.Fatalf("unable to create session: %s", err)
+	}
+	for name, callerCtx := range map[string]context.Context{"other caller": bob, "unverified caller": ctx} {
+		if _, ok := m.get(callerCtx, sess.id); ok {
+			t.Fatalf("expected the session to be not found for an %s", name)
+		}
+		if m.remove(callerCtx, sess.id) {
+			t.Fatalf("expected an %s to be unable to end the session", name)
+		}
+	}
+	if got, ok := m.get(alice, sess.id); !ok || got != sess {
+		t.Fatalf("session %q not found", sess.id)
+	}
+	if !m.remove(alice, sess.id) {
+		t.Fatalf("unable to end session %q", sess.id)
+	}
+}
+
+func TestMaxMcpSessions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := newMcpSessionManager(ctx)
+	sessions := make([]*mcpSession, maxMcpSessions)
+	for i := range sessions {
+		sess, err := m.create(ctx, "2025-06-18", "")
+		if err != nil {
+			t.Fatalf("unable to create session: %s", err)
+		}
+		// every session but the second one has an open stream
+		if i != 1 {
+			sess.connect()
+		}
+		sessions[i] = sess
+	}
+
+	// the session without open streams is expired to make room
+	sess, err := m.create(ctx, "2025-06-18", "")
+	if err != nil {
+		t.Fatalf("unable to create session: %s", err)
+	}
+	if _, ok := m.get(ctx, sessions[1].id); ok {
+		t.Fatalf("expected the idle session to be expired")
+	}
+	if sessions[1].ctx.Err() == nil {
+		t.Fatalf("expected the requests of the expired session to be cancelled")
+	}
+	if _, ok := m.get(ctx, sessions[0].id); !ok {
+		t.Fatalf("expected the session with an open stream to be kept")
+	}
+
+	// no session can be started once every session has an open stream
+	sess.connect()
+	if _, err := m.create(ctx, "2025-06-18", ""); err != errTooManyMcpSessions {
+		t.Fatalf("unexpected error: got %v, want %v", err, errTooManyMcpSessions)
+	}
+}
+
 func TestStdioSession(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -730,7 +1271,7 @@ func TestStdioSession(t *testing.T) {
 		t.Fatalf("unable to initialize logger: %s", err)
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, fakeVersionString, "", false, false, "toolbox")
 	if err != nil {
 		t.Fatalf("unable to setup otel: %s", err)
 	}
@@ -751,11 +1292,12 @@ func TestStdioSession(t *testing.T) {
 	resourceManager := NewResourceManager(nil, nil, toolsMap, toolsets)
 
 	server := &Server{
-		version:         fakeVersionString,
-		logger:          testLogger,
-		instrumentation: instrumentation,
-		sseManager:      sseManager,
-		ResourceMgr:     resourceManager,
+		version:           fakeVersionString,
+		logger:            testLogger,
+		instrumentation:   instrumentation,
+		sseManager:        sseManager,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       resourceManager,
 	}
 
 	in := bufio.NewReader(pr)
@@ -792,3 +1334,520 @@ func TestStdioSession(t *testing.T) {
 		t.Fatalf("unexpected read: got %s, want %s", read, want)
 	}
 }
+
+func TestMcpProgressNotification(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), testLogger)
+	server := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+	}
+
+	testCases := []struct {
+		name string
+		body string
+		want []any
+	}{
+		{
+			name: "with progress token",
+			body: `{"jsonrpc":"2.0","id":"tools-call-progress","method":"tools/call","params":{"name":"no_params","_meta":{"progressToken":"token-1"}}}`,
+			want: []any{
+				map[string]any{
+					"jsonrpc": "2.0",
+					"method":  "notifications/progress",
+					"params": map[string]any{
+						"progressToken": "token-1",
+						"progress":      float64(1),
+						"total":         float64(1),
+						"message":       "invoked",
+					},
+				},
+			},
+		},
+		{
+			name: "without progress token",
+			body: `{"jsonrpc":"2.0","id":"tools-call","method":"tools/call","params":{"name":"no_params"}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []any
+			notify := func(notification any) {
+				b, err := json.Marshal(notification)
+				if err != nil {
+					t.Fatalf("unable to marshal notification: %s", err)
+				}
+				var n any
+				if err := json.Unmarshal(b, &n); err != nil {
+					t.Fatalf("unable to unmarshal notification: %s", err)
+				}
+				got = append(got, n)
+			}
+			_, res, err := processMcpMessage(ctx, []byte(tc.body), server, protocolVersion20250618, "", "", nil, notify)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, ok := res.(jsonrpc.JSONRPCResponse); !ok {
+				t.Fatalf("unexpected response: %v", res)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("unexpected notifications: got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMcpCancelledNotification(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	blocking := blockingTool{MockTool: MockTool{Name: "blocking", Params: []tools.Parameter{}}, started: make(chan struct{}, 1)}
+	toolsMap[blocking.Name] = blocking
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), testLogger)
+	server := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+	}
+
+	resCh := make(chan any, 1)
+	go func() {
+		body := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"blocking"}}`
+		_, res, _ := processMcpMessage(ctx, []byte(body), server, protocolVersion20250618, "", "session-1", nil, nil)
+		resCh <- res
+	}()
+	<-blocking.started
+
+	// cancelling a request from another session has no effect
+	notification := `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user aborted"}}`
+	if _, res, err := processMcpMessage(ctx, []byte(notification), server, protocolVersion20250618, "", "session-2", nil, nil); err != nil || res != nil {
+		t.Fatalf("unexpected notification response: %v, %v", res, err)
+	}
+	select {
+	case res := <-resCh:
+		t.Fatalf("request finished before it was cancelled: %v", res)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if _, res, err := processMcpMessage(ctx, []byte(notification), server, protocolVersion20250618, "", "session-1", nil, nil); err != nil || res != nil {
+		t.Fatalf("unexpected notification response: %v, %v", res, err)
+	}
+	select {
+	case res := <-resCh:
+		b, _ := json.Marshal(res)
+		if !strings.Contains(string(b), `"isError":true`) {
+			t.Fatalf("unexpected response: %s", string(b))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("request was not cancelled")
+	}
+}
+
+func TestMcpResourceResults(t *testing.T) {
+	mockTools := []MockTool{tool1, tool2}
+	toolsMap, toolsets := setUpResources(t, mockTools)
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), testLogger)
+	newServer := func(threshold int) *Server {
+		return &Server{
+			version:           fakeVersionString,
+			logger:            testLogger,
+			invocationManager: newInvocationManager(),
+			toolDefaults:      ServerConfig{McpResourceThreshold: threshold},
+			ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+		}
+	}
+	process := func(t *testing.T, s *Server, version string, body string) map[string]any {
+		_, res, _ := processMcpMessage(ctx, []byte(body), s, version, "", "", nil, nil)
+		b, err := json.Marshal(res)
+		if err != nil {
+			t.Fatalf("unable to marshal response: %s", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("unable to unmarshal response: %s", err)
+		}
+		return got
+	}
+	call := `{"jsonrpc":"2.0","id":"tools-call","method":"tools/call","params":{"name":"no_params"}}`
+
+	t.Run("structured content", func(t *testing.T) {
+		got := process(t, newServer(0), protocolVersion20250618, call)
+		want := map[string]any{
+			"content":           []any{map[string]any{"type": "text", "text": `"no_params"`}},
+			"structuredContent": map[string]any{"result": []any{"no_params"}},
+		}
+		if diff := cmp.Diff(want, got["result"]); diff != "" {
+			t.Fatalf("unexpected result: diff %v", diff)
+		}
+	})
+
+	t.Run("inline for older versions", func(t *testing.T) {
+		got := process(t, newServer(5), protocolVersion20250326, call)
+		want := map[string]any{
+			"content": []any{map[string]any{"type": "text", "text": `"no_params"`}},
+		}
+		if diff := cmp.Diff(want, got["result"]); diff != "" {
+			t.Fatalf("unexpected result: diff %v", diff)
+		}
+	})
+
+	t.Run("resource link", func(t *testing.T) {
+		s := newServer(5)
+		got := process(t, s, protocolVersion20250618, call)
+		content := got["result"].(map[string]any)["content"].([]any)
+		link := content[0].(map[string]any)
+		uri, _ := link["uri"].(string)
+		if !strings.HasPrefix(uri, "toolbox://results/") {
+			t.Fatalf("unexpected resource uri: %q", uri)
+		}
+		want := map[string]any{
+			"type":        "resource_link",
+			"uri":         uri,
+			"name":        "no_params result",
+			"description": "The result of the no_params tool, which is too large to return inline.",
+			"mimeType":    "application/json",
+			"size":        float64(len(`["no_params"]`)),
+		}
+		if diff := cmp.Diff([]any{want}, content); diff != "" {
+			t.Fatalf("unexpected content: diff %v", diff)
+		}
+
+		got = process(t, s, protocolVersion20250618, fmt.Sprintf(`{"jsonrpc":"2.0","id":"resources-read","method":"resources/read","params":{"uri":%q}}`, uri))
+		wantRead := map[string]any{
+			"contents": []any{map[string]any{"uri": uri, "mimeType": "application/json", "text": `["no_params"]`}},
+		}
+		if diff := cmp.Diff(wantRead, got["result"]); diff != "" {
+			t.Fatalf("unexpected read result: diff %v", diff)
+		}
+	})
+
+	t.Run("unknown resource", func(t *testing.T) {
+		got := process(t, newServer(5), protocolVersion20250618, `{"jsonrpc":"2.0","id":"resources-read","method":"resources/read","params":{"uri":"toolbox://results/foo"}}`)
+		want := map[string]any{
+			"code":    float64(-32002),
+			"message": `resource "toolbox://results/foo" not found`,
+			"data":    map[string]any{"uri": "toolbox://results/foo"},
+		}
+		if diff := cmp.Diff(want, got["error"]); diff != "" {
+			t.Fatalf("unexpected error: diff %v", diff)
+		}
+	})
+}
+
+// authRequiredTool is a tool that requires one of the auth services.
+type authRequiredTool struct {
+	tools.Tool
+	authRequired []string
+}
+
+func (t authRequiredTool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.authRequired, verifiedAuthServices)
+}
+
+func TestMcpResources(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create instrumentation: %s", err)
+	}
+	// only tool1 uses the database, and it requires my-auth
+	toolsMap[tool1.Name] = newInstrumentedTool(tool1.Name, "mock", "my-db", authRequiredTool{tool1, []string{"my-auth"}}, instrumentation)
+	withSources, err := tools.ToolsetConfig{Name: "with_sources", ToolNames: []string{tool1.Name, tool2.Name}, SourceResources: true}.Initialize(fakeVersionString, toolsMap)
+	if err != nil {
+		t.Fatalf("unable to initialize toolset: %s", err)
+	}
+	toolsets[withSources.Name] = withSources
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("unable to open database: %s", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	sourcesMap := map[string]sources.Source{"my-db": &sqlite.Source{Name: "my-db", Kind: sqlite.SourceKind, Db: db}}
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), testLogger)
+	s := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(sourcesMap, map[string]auth.AuthService{"my-auth": mockAuthService{"my-auth"}}, toolsMap, toolsets),
+	}
+	authenticated := http.Header{}
+	authenticated.Set("my-auth_token", "alice")
+
+	toolDoc := func(name string) map[string]any {
+		return map[string]any{
+			"uri":         "toolbox://tools/" + name,
+			"name":        name,
+			"description": fmt.Sprintf("Documentation of the %s tool.", name),
+			"mimeType":    "text/markdown",
+		}
+	}
+	usersTable := map[string]any{
+		"uri":         "toolbox://sources/my-db/tables/users",
+		"name":        "my-db/users",
+		"description": "Schema of the users table in the my-db source.",
+		"mimeType":    "application/sql",
+	}
+	notFound := func(uri string) map[string]any {
+		return map[string]any{
+			"code":    float64(-32002),
+			"message": fmt.Sprintf("resource %q not found", uri),
+			"data":    map[string]any{"uri": uri},
+		}
+	}
+	read := func(uri string) string {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":"resources-read","method":"resources/read","params":{"uri":%q}}`, uri)
+	}
+	list := `{"jsonrpc":"2.0","id":"resources-list","method":"resources/list"}`
+
+	testCases := []struct {
+		name    string
+		toolset string
+		header  http.Header
+		body    string
+		want    map[string]any
+	}{
+		{
+			name:   "list resources",
+			header: authenticated,
+			body:   list,
+			want: map[string]any{
+				"result": map[string]any{"resources": []any{toolDoc("no_params"), toolDoc("some_params")}},
+			},
+		},
+		{
+			name:    "list resources of toolset with sources",
+			toolset: "with_sources",
+			header:  authenticated,
+			body:    list,
+			want: map[string]any{
+				"result": map[string]any{"resources": []any{toolDoc("no_params"), toolDoc("some_params"), usersTable}},
+			},
+		},
+		{
+			name:    "list resources of toolset with sources without auth",
+			toolset: "with_sources",
+			body:    list,
+			want: map[string]any{
+				"result": map[string]any{"resources": []any{toolDoc("no_params"), toolDoc("some_params")}},
+			},
+		},
+		{
+			name:    "list resources of toolset",
+			toolset: "tool2_only",
+			body:    list,
+			want: map[string]any{
+				"result": map[string]any{"resources": []any{toolDoc("some_params")}},
+			},
+		},
+		{
+			name: "read tool doc",
+			body: read("toolbox://tools/some_params"),
+			want: map[string]any{
+				"result": map[string]any{"contents": []any{map[string]any{
+					"uri":      "toolbox://tools/some_params",
+					"mimeType": "text/markdown",
+					"text":     "# some_params\n\n## Parameters\n\n- `param1` (integer, required): This is the first parameter.\n- `param2` (integer, required): This is the second parameter.\n",
+				}}},
+			},
+		},
+		{
+			name:    "read table",
+			toolset: "with_sources",
+			header:  authenticated,
+			body:    read("toolbox://sources/my-db/tables/users"),
+			want: map[string]any{
+				"result": map[string]any{"contents": []any{map[string]any{
+					"uri":      "toolbox://sources/my-db/tables/users",
+					"mimeType": "application/sql",
+					"text":     "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+				}}},
+			},
+		},
+		{
+			name:    "read missing table",
+			toolset: "with_sources",
+			header:  authenticated,
+			body:    read("toolbox://sources/my-db/tables/orders"),
+			want:    map[string]any{"error": notFound("toolbox://sources/my-db/tables/orders")},
+		},
+		{
+			name:   "read table of toolset without sources",
+			header: authenticated,
+			body:   read("toolbox://sources/my-db/tables/users"),
+			want:   map[string]any{"error": notFound("toolbox://sources/my-db/tables/users")},
+		},
+		{
+			name:    "read table without auth",
+			toolset: "with_sources",
+			body:    read("toolbox://sources/my-db/tables/users"),
+			want:    map[string]any{"error": notFound("toolbox://sources/my-db/tables/users")},
+		},
+		{
+			name:    "read tool doc outside of toolset",
+			toolset: "tool2_only",
+			body:    read("toolbox://tools/no_params"),
+			want:    map[string]any{"error": notFound("toolbox://tools/no_params")},
+		},
+		{
+			name:    "read table outside of toolset",
+			toolset: "tool2_only",
+			header:  authenticated,
+			body:    read("toolbox://sources/my-db/tables/users"),
+			want:    map[string]any{"error": notFound("toolbox://sources/my-db/tables/users")},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, version := range []string{protocolVersion20241105, protocolVersion20250326, protocolVersion20250618} {
+				_, res, _ := processMcpMessage(ctx, []byte(tc.body), s, version, tc.toolset, "", tc.header, nil)
+				b, err := json.Marshal(res)
+				if err != nil {
+					t.Fatalf("unable to marshal response: %s", err)
+				}
+				var got map[string]any
+				if err := json.Unmarshal(b, &got); err != nil {
+					t.Fatalf("unable to unmarshal response: %s", err)
+				}
+				delete(got, "jsonrpc")
+				delete(got, "id")
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Fatalf("unexpected response for version %s: diff %v", version, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestMcpPrompts(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	p, err := prompts.PromptConfig{
+		Name:        "investigate_slow_query",
+		Description: "Investigate why a query is slow.",
+		Arguments:   []prompts.ArgumentConfig{{Name: "query", Description: "The slow query.", Required: true}},
+		Messages: []prompts.MessageConfig{
+			{Content: "Explain why {{.query}} is slow."},
+			{Role: "assistant", Content: "I will look at its query plan."},
+		},
+	}.Initialize()
+	if err != nil {
+		t.Fatalf("unable to initialize prompt: %s", err)
+	}
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	ctx := util.WithLogger(context.Background(), testLogger)
+	s := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+	}
+	s.ResourceMgr.SetPrompts(map[string]prompts.Prompt{p.Name: p})
+
+	get := func(args string) string {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":"prompts-get","method":"prompts/get","params":{"name":"investigate_slow_query","arguments":%s}}`, args)
+	}
+	testCases := []struct {
+		name string
+		body string
+		want map[string]any
+	}{
+		{
+			name: "list prompts",
+			body: `{"jsonrpc":"2.0","id":"prompts-list","method":"prompts/list"}`,
+			want: map[string]any{
+				"result": map[string]any{"prompts": []any{map[string]any{
+					"name":        "investigate_slow_query",
+					"description": "Investigate why a query is slow.",
+					"arguments": []any{map[string]any{
+						"name":        "query",
+						"description": "The slow query.",
+						"required":    true,
+					}},
+				}}},
+			},
+		},
+		{
+			name: "get prompt",
+			body: get(`{"query":"SELECT 1"}`),
+			want: map[string]any{
+				"result": map[string]any{
+					"description": "Investigate why a query is slow.",
+					"messages": []any{
+						map[string]any{"role": "user", "content": map[string]any{"type": "text", "text": "Explain why SELECT 1 is slow."}},
+						map[string]any{"role": "assistant", "content": map[string]any{"type": "text", "text": "I will look at its query plan."}},
+					},
+				},
+			},
+		},
+		{
+			name: "get prompt without required argument",
+			body: get(`{}`),
+			want: map[string]any{
+				"error": map[string]any{
+					"code":    float64(jsonrpc.INVALID_PARAMS),
+					"message": `provided arguments were invalid: argument "query" is required`,
+				},
+			},
+		},
+		{
+			name: "get unknown prompt",
+			body: `{"jsonrpc":"2.0","id":"prompts-get","method":"prompts/get","params":{"name":"foo"}}`,
+			want: map[string]any{
+				"error": map[string]any{
+					"code":    float64(jsonrpc.INVALID_PARAMS),
+					"message": `invalid prompt name: prompt with name "foo" does not exist`,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, version := range []string{protocolVersion20241105, protocolVersion20250326, protocolVersion20250618} {
+				_, res, _ := processMcpMessage(ctx, []byte(tc.body), s, version, "", "", nil, nil)
+				b, err := json.Marshal(res)
+				if err != nil {
+					t.Fatalf("unable to marshal response: %s", err)
+				}
+				var got map[string]any
+				if err := json.Unmarshal(b, &got); err != nil {
+					t.Fatalf("unable to unmarshal response: %s", err)
+				}
+				delete(got, "jsonrpc")
+				delete(got, "id")
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Fatalf("unexpected response for version %s: diff %v", version, diff)
+				}
+			}
+		})
+	}
+}
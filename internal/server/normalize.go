@@ -0,0 +1,223 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const (
+	nullsOmitted    = "omit"
+	nullsAsSentinel = "sentinel"
+
+	snakeCase = "snake_case"
+	camelCase = "camelCase"
+)
+
+// Normalization makes the rows of the results of a tool look the same,
+// whichever database the tool reads them from.
+type Normalization struct {
+	// Nulls is how NULL values are returned: as JSON null, which is the
+	// default, omitted from their row, or replaced with NullSentinel.
+	Nulls string `yaml:"nulls" validate:"omitempty,oneof=null omit sentinel"`
+	// NullSentinel replaces NULL values if Nulls is "sentinel".
+	NullSentinel string `yaml:"nullSentinel"`
+	// ColumnNames is the case the names of columns are converted to, either
+	// "snake_case" or "camelCase". Names are kept as they are if unset.
+	ColumnNames string `yaml:"columnNames" validate:"omitempty,oneof=snake_case camelCase"`
+}
+
+// popNormalization removes the normalization field from a raw tool config and
+// returns it.
+func popNormalization(ctx context.Context, v map[string]any) (*Normalization, error) {
+	raw, ok := v["normalization"]
+	if !ok {
+		return nil, nil
+	}
+	delete(v, "normalization")
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return nil, err
+	}
+	var n Normalization
+	if err := dec.DecodeContext(ctx, &n); err != nil {
+		return nil, err
+	}
+	if n.NullSentinel != "" && n.Nulls != nullsAsSentinel {
+		return nil, fmt.Errorf("nullSentinel requires nulls to be %q", nullsAsSentinel)
+	}
+	return &n, nil
+}
+
+// normalizeRow returns a copy of a row of a result with its NULLs and the
+// names of its columns normalized. Rows that aren't a set of columns, such as
+// truncation markers, are returned as they are.
+func (n Normalization) normalizeRow(row any) (any, error) {
+	cols, ok := row.(map[string]any)
+	if !ok {
+		return row, nil
+	}
+	out := make(map[string]any, len(cols))
+	names := make(map[string]string, len(cols))
+	for name, v := range cols {
+		if v == nil {
+			switch n.Nulls {
+			case nullsOmitted:
+				continue
+			case nullsAsSentinel:
+				v = n.NullSentinel
+			}
+		}
+		newName := n.columnName(name)
+		if other, ok := names[newName]; ok {
+			return nil, fmt.Errorf("columns %q and %q are both named %q once normalized", min(name, other), max(name, other), newName)
+		}
+		names[newName] = name
+		out[newName] = v
+	}
+	return out, nil
+}
+
+// normalize returns a copy of a tool result with each of its rows normalized.
+func (n Normalization) normalize(res any) (any, error) {
+	rows, ok := res.([]any)
+	if !ok {
+		return res, nil
+	}
+	out := make([]any, len(rows))
+	for i, row := range rows {
+		var err error
+		if out[i], err = n.normalizeRow(row); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// normalizeSchema renames the columns reported by the tool, if the caller
+// asked for them.
+func (n Normalization) normalizeSchema(ctx context.Context) {
+	s, ok := util.SchemaFromContext(ctx)
+	if !ok || n.ColumnNames == "" {
+		return
+	}
+	columns := s.Columns()
+	if columns == nil {
+		return
+	}
+	renamed := make([]util.Column, len(columns))
+	for i, c := range columns {
+		c.Name = n.columnName(c.Name)
+		renamed[i] = c
+	}
+	util.ReportColumns(ctx, renamed)
+}
+
+func (n Normalization) columnName(name string) string {
+	switch n.ColumnNames {
+	case snakeCase:
+		return strings.Join(nameWords(name), "_")
+	case camelCase:
+		words := nameWords(name)
+		for i := 1; i < len(words); i++ {
+			r := []rune(words[i])
+			r[0] = unicode.ToUpper(r[0])
+			words[i] = string(r)
+		}
+		return strings.Join(words, "")
+	}
+	return name
+}
+
+// nameWords splits the name of a column into its lowercase words, whether it
+// is written in snake_case, camelCase, PascalCase, kebab-case, or with spaces.
+// Acronyms are one word, e.g. "HTTPStatus" is "http" and "status".
+func nameWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// a word starts at an uppercase letter after a lowercase letter or
+			// digit, or at the last letter of an acronym followed by lowercase
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, unicode.ToLower(r))
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	if len(words) == 0 {
+		// names without letters or digits are kept
+		return []string{name}
+	}
+	return words
+}
+
+var _ tools.Tool = normalizedTool{}
+
+// normalizedTool normalizes the NULLs and the names of the columns of the rows
+// of the results of a tool.
+type normalizedTool struct {
+	tools.Tool
+	normalization Normalization
+}
+
+func newNormalizedTool(tool tools.Tool, n Normalization) normalizedTool {
+	return normalizedTool{Tool: tool, normalization: n}
+}
+
+func (t normalizedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	if write, ok := util.RowWriterFromContext(ctx); ok {
+		first := true
+		ctx = util.WithRowWriter(ctx, func(row any) error {
+			// tools report their columns before their first row, which is
+			// written with them
+			if first {
+				t.normalization.normalizeSchema(ctx)
+				first = false
+			}
+			row, err := t.normalization.normalizeRow(row)
+			if err != nil {
+				return err
+			}
+			return write(row)
+		})
+	}
+	res, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return res, err
+	}
+	t.normalization.normalizeSchema(ctx)
+	return t.normalization.normalize(res)
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestPopNormalization(t *testing.T) {
+	var v map[string]any
+	in := "kind: mock\nnormalization:\n  nulls: sentinel\n  nullSentinel: N/A\n  columnNames: snake_case\n"
+	if err := yaml.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	got, err := popNormalization(context.Background(), v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &Normalization{Nulls: "sentinel", NullSentinel: "N/A", ColumnNames: "snake_case"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect normalization: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("normalization was not removed from config: diff %v", diff)
+	}
+
+	for _, in := range []string{
+		"normalization:\n  nulls: empty\n",
+		"normalization:\n  columnNames: kebab-case\n",
+		"normalization:\n  nullSentinel: N/A\n",
+		"normalization:\n  nulls: omit\n  casing: upper\n",
+	} {
+		var v map[string]any
+		if err := yaml.Unmarshal([]byte(in), &v); err != nil {
+			t.Fatalf("unable to unmarshal: %s", err)
+		}
+		if _, err := popNormalization(context.Background(), v); err == nil {
+			t.Fatalf("expected error for %q", in)
+		}
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for in, want := range map[string][2]string{
+		"flight_number": {"flight_number", "flightNumber"},
+		"flightNumber":  {"flight_number", "flightNumber"},
+		"FlightNumber":  {"flight_number", "flightNumber"},
+		"FLIGHT_NUMBER": {"flight_number", "flightNumber"},
+		"flight-number": {"flight_number", "flightNumber"},
+		"Flight Number": {"flight_number", "flightNumber"},
+		"HTTPStatus":    {"http_status", "httpStatus"},
+		"address2":      {"address2", "address2"},
+		"?column?":      {"column", "column"},
+		"_":             {"_", "_"},
+	} {
+		if got := (Normalization{ColumnNames: snakeCase}).columnName(in); got != want[0] {
+			t.Fatalf("incorrect snake_case name of %q: got %q, want %q", in, got, want[0])
+		}
+		if got := (Normalization{ColumnNames: camelCase}).columnName(in); got != want[1] {
+			t.Fatalf("incorrect camelCase name of %q: got %q, want %q", in, got, want[1])
+		}
+	}
+}
+
+func TestNormalizedTool(t *testing.T) {
+	rows := []any{
+		map[string]any{"FlightNumber": "CY 100", "Gate": nil},
+		"[truncated: returned 1 of 2 rows]",
+	}
+	tcs := []struct {
+		desc          string
+		normalization Normalization
+		rows          []any
+		want          any
+		wantErr       string
+	}{
+		{
+			desc:          "default",
+			normalization: Normalization{},
+			rows:          rows,
+			want:          rows,
+		},
+		{
+			desc:          "omit nulls",
+			normalization: Normalization{Nulls: nullsOmitted, ColumnNames: snakeCase},
+			rows:          rows,
+			want:          []any{map[string]any{"flight_number": "CY 100"}, rows[1]},
+		},
+		{
+			desc:          "sentinel",
+			normalization: Normalization{Nulls: nullsAsSentinel, NullSentinel: "N/A", ColumnNames: camelCase},
+			rows:          rows,
+			want:          []any{map[string]any{"flightNumber": "CY 100", "gate": "N/A"}, rows[1]},
+		},
+		{
+			desc:          "colliding names",
+			normalization: Normalization{ColumnNames: snakeCase},
+			rows:          []any{map[string]any{"userId": 1, "user_id": 2}},
+			wantErr:       `columns "userId" and "user_id" are both named "user_id"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool := newNormalizedTool(rowsTool{MockTool: tool1, rows: tc.rows}, tc.normalization)
+			got, err := tool.Invoke(context.Background(), nil)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizedToolStreamingRows(t *testing.T) {
+	columns := []util.Column{{Name: "FlightNumber", Type: "text"}, {Name: "Gate", Type: "text"}}
+	rows := []any{map[string]any{"FlightNumber": "CY 100", "Gate": nil}}
+	tool := newNormalizedTool(columnsTool{streamingTool: streamingTool{MockTool: tool1, rows: rows}, columns: columns}, Normalization{Nulls: nullsOmitted, ColumnNames: snakeCase})
+
+	schema := &util.Schema{}
+	var gotSchema []util.Column
+	var got []any
+	ctx := util.WithRowWriter(util.WithSchema(context.Background(), schema), func(row any) error {
+		if gotSchema == nil {
+			gotSchema = schema.Columns()
+		}
+		got = append(got, row)
+		return nil
+	})
+	if _, err := tool.Invoke(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]any{map[string]any{"flight_number": "CY 100"}}, got); diff != "" {
+		t.Fatalf("incorrect rows: diff %v", diff)
+	}
+	// the columns are renamed before the first row is written
+	wantSchema := []util.Column{{Name: "flight_number", Type: "text"}, {Name: "gate", Type: "text"}}
+	if diff := cmp.Diff(wantSchema, gotSchema); diff != "" {
+		t.Fatalf("incorrect schema: diff %v", diff)
+	}
+}
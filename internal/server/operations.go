@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// operation statuses reported by the operations API
+const (
+	operationRunning   = "running"
+	operationSucceeded = "succeeded"
+	operationFailed    = "failed"
+	operationCancelled = "cancelled"
+)
+
+// operationRetention is how long a finished operation can still be retrieved.
+const operationRetention = 1 * time.Hour
+
+// maxOperations is the maximum number of operations kept at once. The oldest
+// finished operation is dropped to make room for a new one, and no operation
+// can be started while this many are running.
+const maxOperations = 1000
+
+// errTooManyOperations is returned when starting an operation while
+// maxOperations operations are running.
+var errTooManyOperations = fmt.Errorf("too many running operations, at most %d operations can run at once", maxOperations)
+
+var _ render.Renderer = &operation{} // Renderer interface for managing response payloads.
+
+// operation tracks a tool invocation that runs in the background.
+type operation struct {
+	Id         string            `json:"id"`
+	ToolName   string            `json:"toolName"`
+	Status     string            `json:"status"`
+	Progress   *progressResponse `json:"progress,omitempty"`
+	Result     string            `json:"result,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	CreateTime time.Time         `json:"createTime"`
+	EndTime    *time.Time        `json:"endTime,omitempty"`
+	// caller identifies the caller who started the operation, who is the
+	// only one who can retrieve it, see util.CallerIdentity
+	caller string
+}
+
+// Render renders a single payload and respond to the client request.
+func (o operation) Render(w http.ResponseWriter, r *http.Request) error {
+	if o.Status == operationRunning {
+		render.Status(r, http.StatusAccepted)
+	} else {
+		render.Status(r, http.StatusOK)
+	}
+	return nil
+}
+
+// operationManager manages and control access to background tool invocations.
+type operationManager struct {
+	mu         sync.Mutex
+	operations map[string]*operation
+}
+
+func newOperationManager(ctx context.Context) *operationManager {
+	opM := &operationManager{
+		mu:         sync.Mutex{},
+		operations: make(map[string]*operation),
+	}
+	go opM.cleanupRoutine(ctx)
+	return opM
+}
+
+// start registers a new running operation of the caller in ctx and returns
+// its id.
+func (m *operationManager) start(ctx context.Context, toolName string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.operations) >= maxOperations && !m.dropOldestFinished() {
+		return "", errTooManyOperations
+	}
+	id := uuid.New().String()
+	m.operations[id] = &operation{
+		Id:         id,
+		ToolName:   toolName,
+		Status:     operationRunning,
+		CreateTime: time.Now(),
+		caller:     util.CallerIdentity(ctx),
+	}
+	return id, nil
+}
+
+// dropOldestFinished drops the operation that finished first, and reports
+// whether there was one. m.mu must be held.
+func (m *operationManager) dropOldestFinished() bool {
+	var oldest *operation
+	for _, op := range m.operations {
+		if op.EndTime != nil && (oldest == nil || op.EndTime.Before(*oldest.EndTime)) {
+			oldest = op
+		}
+	}
+	if oldest == nil {
+		return false
+	}
+	delete(m.operations, oldest.Id)
+	return true
+}
+
+// progress records the latest progress reported by a running operation.
+func (m *operationManager) progress(id string, p progressResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	op, ok := m.operations[id]
+	if !ok || op.Status != operationRunning {
+		return
+	}
+	op.Progress = &p
+}
+
+// finish records the outcome of an operation.
+func (m *operationManager) finish(id string, result string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	op, ok := m.operations[id]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	op.EndTime = &now
+	if err != nil {
+		op.Status = operationFailed
+		if errors.Is(err, errInvocationCancelled) {
+			op.Status = operationCancelled
+		}
+		op.Error = err.Error()
+		return
+	}
+	op.Status = operationSucceeded
+	op.Result = result
+}
+
+// get returns a copy of the operation with the given id, if it was started by
+// the caller in ctx.
+func (m *operationManager) get(ctx context.Context, id string) (operation, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	op, ok := m.operations[id]
+	if !ok || op.caller != util.CallerIdentity(ctx) {
+		return operation{}, false
+	}
+	return *op, true
+}
+
+func (m *operationManager) cleanupRoutine(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			func() {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				now := time.Now()
+				for id, op := range m.operations {
+					if op.EndTime != nil && now.Sub(*op.EndTime) > operationRetention {
+						delete(m.operations, id)
+					}
+				}
+			}()
+		}
+	}
+}
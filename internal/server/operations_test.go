@@ -0,0 +1,52 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMaxOperations(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := newOperationManager(ctx)
+
+	ids := make([]string, maxOperations)
+	for i := range ids {
+		id, err := m.start(ctx, "my-tool")
+		if err != nil {
+			t.Fatalf("unable to start operation: %s", err)
+		}
+		ids[i] = id
+	}
+	if _, err := m.start(ctx, "my-tool"); !errors.Is(err, errTooManyOperations) {
+		t.Fatalf("expected too many operations, got %v", err)
+	}
+
+	// the oldest finished operation makes room for a new one
+	m.finish(ids[1], "", nil)
+	m.finish(ids[0], "", nil)
+	if _, err := m.start(ctx, "my-tool"); err != nil {
+		t.Fatalf("unable to start operation after one finished: %s", err)
+	}
+	if _, ok := m.get(ctx, ids[1]); ok {
+		t.Fatalf("expected the oldest finished operation to be dropped")
+	}
+	if _, ok := m.get(ctx, ids[0]); !ok {
+		t.Fatalf("expected the other finished operation to be kept")
+	}
+}
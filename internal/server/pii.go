@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// PIIMasking is how personal information is masked in the results of a tool.
+type PIIMasking struct {
+	// Detectors are the names of the built-in detectors to apply.
+	Detectors []string `yaml:"detectors"`
+	// Patterns are additional regular expressions whose matches are masked.
+	Patterns []PIIPattern `yaml:"patterns" validate:"dive"`
+}
+
+// PIIPattern is a regular expression whose matches are masked.
+type PIIPattern struct {
+	Name  string `yaml:"name" validate:"required"`
+	Regex string `yaml:"regex" validate:"required"`
+	// Replacement replaces each match. Defaults to "[REDACTED]".
+	Replacement string `yaml:"replacement"`
+}
+
+// popPIIMasking removes the piiMasking field from a raw tool config and
+// returns it, or nil if it isn't set.
+func popPIIMasking(ctx context.Context, v map[string]any) (*PIIMasking, error) {
+	raw, ok := v["piiMasking"]
+	if !ok {
+		return nil, nil
+	}
+	delete(v, "piiMasking")
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return nil, err
+	}
+	var masking PIIMasking
+	if err := dec.DecodeContext(ctx, &masking); err != nil {
+		return nil, err
+	}
+	if _, err := newPIIMasker(masking); err != nil {
+		return nil, err
+	}
+	return &masking, nil
+}
+
+// piiDetector finds and masks a kind of personal information.
+type piiDetector struct {
+	re *regexp.Regexp
+	// valid filters out matches that aren't actually personal information,
+	// if set
+	valid       func(string) bool
+	replacement string
+}
+
+// builtinPIIDetectors are the detectors that can be referred to by name.
+var builtinPIIDetectors = map[string]piiDetector{
+	"email": {
+		re:          regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+		replacement: "[REDACTED_EMAIL]",
+	},
+	"ssn": {
+		re:          regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		valid:       validSSN,
+		replacement: "[REDACTED_SSN]",
+	},
+	"creditCard": {
+		re:          regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`),
+		valid:       luhnValid,
+		replacement: "[REDACTED_CREDIT_CARD]",
+	},
+}
+
+// validSSN reports whether s, formatted as 123-45-6789, is a possible US
+// social security number.
+func validSSN(s string) bool {
+	area, group, serial := s[0:3], s[4:6], s[7:11]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum used by
+// credit card numbers.
+func luhnValid(s string) bool {
+	var sum, n int
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
+
+// piiMasker masks personal information in tool results.
+type piiMasker struct {
+	detectors []piiDetector
+}
+
+// newPIIMasker returns the masker of a PIIMasking, or nil if it masks
+// nothing.
+func newPIIMasker(cfg PIIMasking) (*piiMasker, error) {
+	m := &piiMasker{}
+	for _, name := range cfg.Detectors {
+		d, ok := builtinPIIDetectors[name]
+		if !ok {
+			names := make([]string, 0, len(builtinPIIDetectors))
+			for n := range builtinPIIDetectors {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown PII detector %q, must be one of %s", name, strings.Join(names, ", "))
+		}
+		m.detectors = append(m.detectors, d)
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex of PII pattern %q: %w", p.Name, err)
+		}
+		replacement := p.Replacement
+		if replacement == "" {
+			replacement = tools.RedactedValue
+		}
+		m.detectors = append(m.detectors, piiDetector{re: re, replacement: replacement})
+	}
+	if len(m.detectors) == 0 {
+		return nil, nil
+	}
+	return m, nil
+}
+
+func (m *piiMasker) maskString(s string) string {
+	for _, d := range m.detectors {
+		s = d.re.ReplaceAllStringFunc(s, func(match string) string {
+			if d.valid != nil && !d.valid(match) {
+				return match
+			}
+			return d.replacement
+		})
+	}
+	return s
+}
+
+// mask returns a copy of a tool result with personal information masked in
+// its strings.
+func (m *piiMasker) mask(v any) any {
+	switch v := v.(type) {
+	case nil, bool, int, int32, int64, float32, float64, time.Time, tools.Decimal:
+		return v
+	case string:
+		return m.maskString(v)
+	case []any:
+		masked := make([]any, len(v))
+		for i, e := range v {
+			masked[i] = m.mask(e)
+		}
+		return masked
+	case map[string]any:
+		masked := make(map[string]any, len(v))
+		for k, e := range v {
+			masked[k] = m.mask(e)
+		}
+		return masked
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Pointer:
+		// other types are masked in their JSON representation, which is
+		// how they are returned to clients
+		b, err := json.Marshal(v)
+		if err != nil {
+			return v
+		}
+		var decoded any
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			return v
+		}
+		return m.mask(decoded)
+	case reflect.String:
+		return m.maskString(reflect.ValueOf(v).String())
+	default:
+		return v
+	}
+}
+
+var _ tools.Tool = piiMaskedTool{}
+
+// piiMaskedTool masks personal information in the results of the wrapped
+// tool.
+type piiMaskedTool struct {
+	tools.Tool
+	masker *piiMasker
+}
+
+func newPIIMaskedTool(tool tools.Tool, masker *piiMasker) piiMaskedTool {
+	return piiMaskedTool{Tool: tool, masker: masker}
+}
+
+func (t piiMaskedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	if write, ok := util.RowWriterFromContext(ctx); ok {
+		ctx = util.WithRowWriter(ctx, func(row any) error {
+			return write(t.masker.mask(row))
+		})
+	}
+	res, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return res, err
+	}
+	return t.masker.mask(res), nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPopPIIMasking(t *testing.T) {
+	v := map[string]any{
+		"kind": "mock",
+		"piiMasking": map[string]any{
+			"detectors": []any{"email", "ssn"},
+			"patterns":  []any{map[string]any{"name": "employee_id", "regex": `E\d{6}`, "replacement": "[EMPLOYEE]"}},
+		},
+	}
+	got, err := popPIIMasking(context.Background(), v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &PIIMasking{
+		Detectors: []string{"email", "ssn"},
+		Patterns:  []PIIPattern{{Name: "employee_id", Regex: `E\d{6}`, Replacement: "[EMPLOYEE]"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect PII masking: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("PII masking was not removed from config: diff %v", diff)
+	}
+
+	for _, raw := range []any{
+		map[string]any{"detectors": []any{"phone"}},
+		map[string]any{"patterns": []any{map[string]any{"name": "bad", "regex": "("}}},
+		map[string]any{"patterns": []any{map[string]any{"regex": "E[0-9]+"}}},
+		map[string]any{"detector": []any{"email"}},
+	} {
+		if _, err := popPIIMasking(context.Background(), map[string]any{"piiMasking": raw}); err == nil {
+			t.Fatalf("expected error for PII masking %v", raw)
+		}
+	}
+}
+
+func TestPIIMasker(t *testing.T) {
+	m, err := newPIIMasker(PIIMasking{
+		Detectors: []string{"email", "ssn", "creditCard"},
+		Patterns:  []PIIPattern{{Name: "employee_id", Regex: `E\d{6}`}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want string
+	}{
+		{
+			desc: "email",
+			in:   "contact jane.doe+work@example.co.uk today",
+			want: "contact [REDACTED_EMAIL] today",
+		},
+		{
+			desc: "ssn",
+			in:   "ssn 123-45-6789",
+			want: "ssn [REDACTED_SSN]",
+		},
+		{
+			desc: "invalid ssn",
+			in:   "ssn 000-45-6789",
+			want: "ssn 000-45-6789",
+		},
+		{
+			desc: "credit card",
+			in:   "card 4111 1111 1111 1111, or 4111-1111-1111-1111",
+			want: "card [REDACTED_CREDIT_CARD], or [REDACTED_CREDIT_CARD]",
+		},
+		{
+			desc: "number failing the checksum",
+			in:   "order 4111111111111112",
+			want: "order 4111111111111112",
+		},
+		{
+			desc: "custom pattern",
+			in:   "employee E123456",
+			want: "employee [REDACTED]",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := m.maskString(tc.in); got != tc.want {
+				t.Fatalf("unexpected masked string: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	if m, err := newPIIMasker(PIIMasking{}); err != nil || m != nil {
+		t.Fatalf("expected no masker without detectors, got %v, %v", m, err)
+	}
+}
+
+func TestPIIMaskedTool(t *testing.T) {
+	type user struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	rows := []any{
+		map[string]any{"id": 1, "email": "jane@example.com", "tags": []any{"vip", "ssn 123-45-6789"}},
+		user{Name: "John", Email: "john@example.com"},
+	}
+	m, err := newPIIMasker(PIIMasking{Detectors: []string{"email", "ssn"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tool := newPIIMaskedTool(rowsTool{MockTool: tool1, rows: rows}, m)
+	got, err := tool.Invoke(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []any{
+		map[string]any{"id": 1, "email": "[REDACTED_EMAIL]", "tags": []any{"vip", "ssn [REDACTED_SSN]"}},
+		map[string]any{"name": "John", "email": "[REDACTED_EMAIL]"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected result (-want +got):\n%s", diff)
+	}
+	// the result of the wrapped tool isn't modified, since it may be cached
+	if email := rows[0].(map[string]any)["email"]; email != "jane@example.com" {
+		t.Fatalf("the wrapped result was modified: %v", email)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"golang.org/x/time/rate"
+)
+
+// errRateLimited is returned for invocations over a rate limit.
+var errRateLimited = errors.New("rate limit exceeded")
+
+// callerIdleTimeout is how long the rate limit of an idle caller is kept.
+const callerIdleTimeout = 10 * time.Minute
+
+// RateLimit is the rate at which a tool, or the tools of a source, can be
+// invoked.
+type RateLimit struct {
+	// RPS is the number of invocations allowed per second.
+	RPS float64 `yaml:"rps" validate:"gt=0"`
+	// Burst is the number of invocations allowed at once. Defaults to RPS,
+	// rounded up.
+	Burst int `yaml:"burst" validate:"gte=0"`
+	// PerCaller applies the limit to each caller separately.
+	PerCaller bool `yaml:"perCaller"`
+}
+
+// popRateLimit removes the rateLimit field from a raw tool or source config
+// and returns it, or nil if it isn't set.
+func popRateLimit(ctx context.Context, v map[string]any) (*RateLimit, error) {
+	raw, ok := v["rateLimit"]
+	if !ok {
+		return nil, nil
+	}
+	delete(v, "rateLimit")
+	dec, err := util.NewStrictDecoder(raw)
+	if err != nil {
+		return nil, err
+	}
+	var limit RateLimit
+	if err := dec.DecodeContext(ctx, &limit); err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
+
+// rateLimiter enforces a RateLimit, for all callers or for each caller.
+type rateLimiter struct {
+	limit   RateLimit
+	mu      sync.Mutex
+	all     *rate.Limiter
+	callers map[string]*callerLimiter
+}
+
+type callerLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.Burst == 0 {
+		limit.Burst = int(math.Ceil(limit.RPS))
+	}
+	return &rateLimiter{
+		limit:   limit,
+		mu:      sync.Mutex{},
+		all:     rate.NewLimiter(rate.Limit(limit.RPS), limit.Burst),
+		callers: make(map[string]*callerLimiter),
+	}
+}
+
+// allow reports whether the caller can invoke a tool now.
+func (l *rateLimiter) allow(caller string) bool {
+	if !l.limit.PerCaller {
+		return l.all.Allow()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	for k, c := range l.callers {
+		if now.Sub(c.lastSeen) > callerIdleTimeout {
+			delete(l.callers, k)
+		}
+	}
+	c, ok := l.callers[caller]
+	if !ok {
+		c = &callerLimiter{limiter: rate.NewLimiter(rate.Limit(l.limit.RPS), l.limit.Burst)}
+		l.callers[caller] = c
+	}
+	c.lastSeen = now
+	return c.limiter.Allow()
+}
+
+var _ tools.Tool = rateLimitedTool{}
+
+// rateLimitedTool rejects invocations of the wrapped tool over a rate limit.
+type rateLimitedTool struct {
+	tools.Tool
+	// scope describes what the limit applies to, for error messages.
+	scope   string
+	limiter *rateLimiter
+}
+
+func newRateLimitedTool(scope string, tool tools.Tool, limiter *rateLimiter) rateLimitedTool {
+	return rateLimitedTool{Tool: tool, scope: scope, limiter: limiter}
+}
+
+func (t rateLimitedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	if !t.limiter.allow(callerFromContext(ctx)) {
+		return nil, fmt.Errorf("%w: %s allows %g requests per second, please retry later", errRateLimited, t.scope, t.limiter.limit.RPS)
+	}
+	return t.Tool.Invoke(ctx, params)
+}
+
+// toolSourceName returns the name of the source used by a tool, or an empty
+// string if the tool doesn't have one. Tool configs name their source in a
+// string field called Source.
+func toolSourceName(tc tools.ToolConfig) string {
+	if wc, ok := tc.(wrappedToolConfig); ok {
+		tc = wc.ToolConfig
+	}
+	v := reflect.ValueOf(tc)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	f := v.FieldByName("Source")
+	if !f.IsValid() || f.Kind() != reflect.String {
+		return ""
+	}
+	return f.String()
+}
+
+// callerKey is the key used to store the caller within context
+const callerKey contextKey = "caller"
+
+type contextKey string
+
+// withCaller is a middleware that identifies the caller of a request by its
+// IP address, for per caller rate limits.
+func withCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			caller = r.RemoteAddr
+		}
+		ctx := context.WithValue(r.Context(), callerKey, caller)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// callerFromContext returns the caller of the request, or an empty string if
+// the caller is unknown.
+func callerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey).(string)
+	return caller
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestPopRateLimit(t *testing.T) {
+	v := map[string]any{
+		"kind":      "mock",
+		"rateLimit": map[string]any{"rps": 5, "burst": 10, "perCaller": true},
+	}
+	got, err := popRateLimit(context.Background(), v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &RateLimit{RPS: 5, Burst: 10, PerCaller: true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect rate limit: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("rate limit was not removed from config: diff %v", diff)
+	}
+
+	for _, raw := range []any{
+		map[string]any{"burst": 10},
+		map[string]any{"rps": 5, "burts": 10},
+	} {
+		if _, err := popRateLimit(context.Background(), map[string]any{"rateLimit": raw}); err == nil {
+			t.Fatalf("expected error for rate limit %v", raw)
+		}
+	}
+}
+
+func TestRateLimitedTool(t *testing.T) {
+	tool := newRateLimitedTool(`tool "no_params"`, tool1, newRateLimiter(RateLimit{RPS: 0.001, Burst: 1}))
+	if _, err := tool.Invoke(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err := tool.Invoke(context.Background(), nil)
+	if !errors.Is(err, errRateLimited) {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+}
+
+func TestRateLimiterPerCaller(t *testing.T) {
+	l := newRateLimiter(RateLimit{RPS: 0.001, PerCaller: true})
+	if !l.allow("10.0.0.1") {
+		t.Fatalf("expected first invocation to be allowed")
+	}
+	if l.allow("10.0.0.1") {
+		t.Fatalf("expected second invocation from the same caller to be limited")
+	}
+	if !l.allow("10.0.0.2") {
+		t.Fatalf("expected invocation from another caller to be allowed")
+	}
+}
+
+type sourceToolConfig struct {
+	Source string
+}
+
+func (sourceToolConfig) ToolConfigKind() string { return "mock" }
+
+func (sourceToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return tool1, nil
+}
+
+func TestToolSourceName(t *testing.T) {
+	tc := sourceToolConfig{Source: "my-pg-instance"}
+	if got := toolSourceName(tc); got != "my-pg-instance" {
+		t.Fatalf("incorrect source: got %q", got)
+	}
+	if got := toolSourceName(wrappedToolConfig{ToolConfig: tc}); got != "my-pg-instance" {
+		t.Fatalf("incorrect source of wrapped config: got %q", got)
+	}
+}
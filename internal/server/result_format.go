@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// resultFormatHeader is the header of a request that sets the format of the
+// results of its tool invocations.
+const resultFormatHeader = "X-Toolbox-Result-Format"
+
+// popResultFormat removes the resultFormat field from a raw tool config and
+// returns it.
+func popResultFormat(v map[string]any) (tools.ResultFormat, error) {
+	val, ok := v["resultFormat"]
+	if !ok {
+		return "", nil
+	}
+	delete(v, "resultFormat")
+	s, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("must be a string")
+	}
+	var f tools.ResultFormat
+	if err := f.Set(s); err != nil {
+		return "", err
+	}
+	return f, nil
+}
+
+// resultFormatRequested returns the result format the header of a request
+// asks for, or "" if it doesn't ask for one.
+func resultFormatRequested(header http.Header) (tools.ResultFormat, error) {
+	v := header.Get(resultFormatHeader)
+	if v == "" {
+		return "", nil
+	}
+	var f tools.ResultFormat
+	if err := f.Set(v); err != nil {
+		return "", fmt.Errorf("invalid %s header %q: %w", resultFormatHeader, v, err)
+	}
+	return f, nil
+}
+
+// resultFormatKey is the context key of the requested result format.
+type resultFormatKey struct{}
+
+// withResultFormat sets the format of the results of the tool invocations of
+// the context, overriding the format of each tool.
+func withResultFormat(ctx context.Context, f tools.ResultFormat) context.Context {
+	return context.WithValue(ctx, resultFormatKey{}, f)
+}
+
+func resultFormatFromContext(ctx context.Context) tools.ResultFormat {
+	f, _ := ctx.Value(resultFormatKey{}).(tools.ResultFormat)
+	return f
+}
+
+var _ tools.Tool = formattedTool{}
+
+// formattedTool serializes the rows of the results of a tool as CSV or a
+// Markdown table, which take fewer tokens than JSON for wide results.
+type formattedTool struct {
+	tools.Tool
+	format tools.ResultFormat
+}
+
+func newFormattedTool(tool tools.Tool, format tools.ResultFormat) formattedTool {
+	return formattedTool{Tool: tool, format: format}
+}
+
+func (t formattedTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	format := t.format
+	if f := resultFormatFromContext(ctx); f != "" {
+		format = f
+	}
+	if _, ok := util.RowWriterFromContext(ctx); ok || format == "" || format == tools.JSONFormat {
+		// streamed rows are always written as JSON lines
+		return t.Tool.Invoke(ctx, params)
+	}
+	result, err := t.Tool.Invoke(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return format.Apply(result)
+}
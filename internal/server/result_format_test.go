@@ -0,0 +1,151 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+func TestPopResultFormat(t *testing.T) {
+	v := map[string]any{"kind": "mock", "resultFormat": "CSV"}
+	got, err := popResultFormat(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != tools.CSVFormat {
+		t.Fatalf("expected csv format, got %q", got)
+	}
+	if diff := cmp.Diff(map[string]any{"kind": "mock"}, v); diff != "" {
+		t.Fatalf("resultFormat was not removed from config: diff %v", diff)
+	}
+	for _, val := range []any{"xml", true} {
+		if _, err := popResultFormat(map[string]any{"resultFormat": val}); err == nil {
+			t.Fatalf("expected error for resultFormat %v", val)
+		}
+	}
+}
+
+func TestFormattedTool(t *testing.T) {
+	rows := []any{map[string]any{"id": 1, "name": "Alice"}}
+	tool := newFormattedTool(rowsTool{MockTool: tool1, rows: rows}, tools.CSVFormat)
+
+	got, err := tool.Invoke(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(tools.TextResult("id,name\n1,Alice\n"), got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	// the format of an invocation overrides the format of the tool
+	got, err = tool.Invoke(withResultFormat(context.Background(), tools.JSONFormat), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(rows, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+}
+
+func TestResultFormatRequests(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	rows := []any{map[string]any{"id": 1, "name": "Alice"}}
+	toolsMap[tool2.Name] = newFormattedTool(rowsTool{MockTool: tool2, rows: rows}, "")
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	tcs := []struct {
+		desc       string
+		header     map[string]string
+		wantStatus int
+		want       string
+	}{
+		{
+			desc:       "default",
+			wantStatus: http.StatusOK,
+			want:       `[{"id":1,"name":"Alice"}]`,
+		},
+		{
+			desc:       "markdown",
+			header:     map[string]string{resultFormatHeader: "markdown"},
+			wantStatus: http.StatusOK,
+			want:       "| id | name |\n| --- | --- |\n| 1 | Alice |\n",
+		},
+		{
+			desc:       "invalid header",
+			header:     map[string]string{resultFormatHeader: "xml"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/some_params/invoke", bytes.NewBufferString(`{"param1": 1, "param2": 2}`), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", tc.wantStatus, resp.StatusCode, string(body))
+			}
+			if tc.want == "" {
+				return
+			}
+			var res resultResponse
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatalf("unable to parse response: %s", err)
+			}
+			if res.Result != tc.want {
+				t.Fatalf("unexpected result: want %q, got %q", tc.want, res.Result)
+			}
+		})
+	}
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	s := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+	}
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"some_params","arguments":{"param1":1,"param2":2}}}`
+	header := http.Header{resultFormatHeader: []string{"csv"}}
+	_, res, err := processMcpMessage(util.WithLogger(context.Background(), testLogger), []byte(body), s, protocolVersion20250618, "", "", header, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unable to marshal response: %s", err)
+	}
+	// the CSV is the text of the result, rather than a JSON string
+	if !strings.Contains(string(b), `"text":"id,name\n1,Alice\n"`) {
+		t.Fatalf("expected the MCP tool call to return CSV, got %s", string(b))
+	}
+}
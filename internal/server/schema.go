@@ -0,0 +1,51 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// schemaHeader is the header of a request that asks for the columns of the
+// results of its tool invocations.
+const schemaHeader = "X-Toolbox-Include-Schema"
+
+// schemaRequested reports whether the header of a request asks for the
+// columns of the results of its tool invocations.
+func schemaRequested(header http.Header) (bool, error) {
+	v := header.Get(schemaHeader)
+	if v == "" {
+		return false, nil
+	}
+	include, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s header %q: must be a boolean", schemaHeader, v)
+	}
+	return include, nil
+}
+
+// resultSchema returns the columns of the result of an invocation, if they
+// were asked for and the tool reported them.
+func resultSchema(ctx context.Context) []util.Column {
+	if s, ok := util.SchemaFromContext(ctx); ok {
+		return s.Columns()
+	}
+	return nil
+}
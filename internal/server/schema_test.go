@@ -0,0 +1,133 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// columnsTool is a streamingTool that reports the columns of its rows.
+type columnsTool struct {
+	streamingTool
+	columns []util.Column
+}
+
+func (t columnsTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	util.ReportColumns(ctx, t.columns)
+	return t.streamingTool.Invoke(ctx, params)
+}
+
+func TestSchemaRequests(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	nullable := true
+	columns := []util.Column{{Name: "id", Type: "int8"}, {Name: "name", Type: "text", Nullable: &nullable}}
+	rows := []any{map[string]any{"id": 1, "name": "Alice"}}
+	toolsMap[tool2.Name] = columnsTool{streamingTool: streamingTool{MockTool: tool2, rows: rows}, columns: columns}
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	tcs := []struct {
+		desc       string
+		header     map[string]string
+		wantStatus int
+		want       string
+	}{
+		{
+			desc:       "schema",
+			header:     map[string]string{schemaHeader: "true"},
+			wantStatus: http.StatusOK,
+			want:       `{"result":"[{\"id\":1,\"name\":\"Alice\"}]","schema":[{"name":"id","type":"int8"},{"name":"name","type":"text","nullable":true}]}`,
+		},
+		{
+			desc:       "no schema",
+			wantStatus: http.StatusOK,
+			want:       `{"result":"[{\"id\":1,\"name\":\"Alice\"}]"}`,
+		},
+		{
+			desc:       "streamed rows",
+			header:     map[string]string{schemaHeader: "true", "Accept": ndjsonContentType},
+			wantStatus: http.StatusOK,
+			want:       "{\"schema\":[{\"name\":\"id\",\"type\":\"int8\"},{\"name\":\"name\",\"type\":\"text\",\"nullable\":true}]}\n{\"row\":{\"id\":1,\"name\":\"Alice\"}}",
+		},
+		{
+			desc:       "invalid header",
+			header:     map[string]string{schemaHeader: "maybe"},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/some_params/invoke", bytes.NewBufferString(`{"param1": 1, "param2": 2}`), tc.header)
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", tc.wantStatus, resp.StatusCode, string(body))
+			}
+			if tc.want == "" {
+				return
+			}
+			if got := strings.TrimSpace(string(body)); got != tc.want {
+				t.Fatalf("unexpected response: want %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	testLogger, err := log.NewStdLogger(os.Stdout, os.Stderr, "warn")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	s := &Server{
+		version:           fakeVersionString,
+		logger:            testLogger,
+		invocationManager: newInvocationManager(),
+		ResourceMgr:       NewResourceManager(nil, nil, toolsMap, toolsets),
+	}
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"some_params","arguments":{"param1":1,"param2":2},"_meta":{"includeSchema":true}}}`
+	_, res, err := processMcpMessage(util.WithLogger(context.Background(), testLogger), []byte(body), s, protocolVersion20250618, "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("unable to marshal response: %s", err)
+	}
+	var got struct {
+		Result struct {
+			StructuredContent struct {
+				Schema []util.Column `json:"schema"`
+			} `json:"structuredContent"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unable to parse response: %s", err)
+	}
+	if diff := cmp.Diff(columns, got.Result.StructuredContent.Schema); diff != "" {
+		t.Fatalf("incorrect schema of the MCP tool call: diff %v", diff)
+	}
+}
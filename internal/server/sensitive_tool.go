@@ -0,0 +1,80 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+var _ tools.Tool = sensitiveTool{}
+
+// sensitiveTool keeps the values of sensitive parameters out of the errors of
+// the wrapped tool. Parameters are sensitive if they are declared so, or if
+// their name matches one of the server-wide patterns.
+type sensitiveTool struct {
+	tools.Tool
+	patterns []string
+}
+
+func newSensitiveTool(tool tools.Tool, patterns []string) sensitiveTool {
+	return sensitiveTool{Tool: tool, patterns: patterns}
+}
+
+// validateSensitiveParams checks that the patterns of sensitive parameter
+// names are valid.
+func validateSensitiveParams(patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid sensitive parameter pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// matches returns whether a parameter name matches a server-wide pattern.
+func (t sensitiveTool) matches(name string) bool {
+	for _, p := range t.patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (t sensitiveTool) ParseParams(data map[string]any, claimsMap map[string]map[string]any) (tools.ParamValues, error) {
+	params, err := t.Tool.ParseParams(data, claimsMap)
+	if err != nil {
+		var given tools.ParamValues
+		for name, v := range data {
+			given = append(given, tools.ParamValue{Name: name, Value: v, Sensitive: t.matches(name)})
+		}
+		return nil, given.RedactError(err)
+	}
+	for i := range params {
+		if t.matches(params[i].Name) {
+			params[i].Sensitive = true
+		}
+	}
+	return params, nil
+}
+
+func (t sensitiveTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	res, err := t.Tool.Invoke(ctx, params)
+	return res, params.RedactError(err)
+}
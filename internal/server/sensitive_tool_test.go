@@ -0,0 +1,82 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// loginTool is a MockTool whose invocations fail with an error that includes
+// the values of its parameters.
+type loginTool struct {
+	MockTool
+}
+
+func (t loginTool) Invoke(_ context.Context, params tools.ParamValues) (any, error) {
+	m := params.AsMap()
+	return nil, fmt.Errorf("login failed for %v with %v", m["user"], m["db_password"])
+}
+
+func TestSensitiveTool(t *testing.T) {
+	mock := MockTool{
+		Name: "login",
+		Params: tools.Parameters{
+			tools.NewStringParameter("user", "name of the user"),
+			tools.NewStringParameter("db_password", "password of the user"),
+			tools.NewIntParameter("port", "port of the database"),
+		},
+	}
+	tool := newSensitiveTool(loginTool{MockTool: mock}, []string{"*password*"})
+
+	params, err := tool.ParseParams(map[string]any{"user": "ada", "db_password": "hunter2", "port": 5432}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if s := fmt.Sprintf("%s", params); s != "[{user ada} {db_password [REDACTED]} {port 5432}]" {
+		t.Fatalf("unexpected formatted params: %s", s)
+	}
+	_, err = tool.Invoke(context.Background(), params)
+	if err == nil || err.Error() != "login failed for ada with [REDACTED]" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = tool.ParseParams(map[string]any{"user": "ada", "db_password": 12.5, "port": 5432}, nil)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid password")
+	}
+	if strings.Contains(err.Error(), "12.5") {
+		t.Fatalf("error contains the value of a sensitive parameter: %s", err)
+	}
+	// the redacted error still wraps the original one
+	var typeErr *tools.ParseTypeError
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("expected a ParseTypeError, got %s", err)
+	}
+}
+
+func TestValidateSensitiveParams(t *testing.T) {
+	if err := validateSensitiveParams([]string{"*password*", "api_key"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := validateSensitiveParams([]string{"[password"}); err == nil {
+		t.Fatalf("expected an error for an invalid pattern")
+	}
+}
@@ -16,19 +16,23 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
 	"sync"
-	"time"
+	"sync/atomic"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/httplog/v2"
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -39,14 +43,23 @@ import (
 
 // Server contains info for running an instance of Toolbox. Should be instantiated with NewServer().
 type Server struct {
-	version         string
-	srv             *http.Server
-	listener        net.Listener
-	root            chi.Router
-	logger          log.Logger
-	instrumentation *telemetry.Instrumentation
-	sseManager      *sseManager
-	ResourceMgr     *ResourceManager
+	version           string
+	srv               *http.Server
+	listener          net.Listener
+	listen            string
+	root              chi.Router
+	logger            log.Logger
+	instrumentation   *telemetry.Instrumentation
+	sseManager        *sseManager
+	mcpSessionManager *mcpSessionManager
+	stdio             atomic.Pointer[stdioSession]
+	// stdioToolset is the comma-separated toolsets served via MCP stdio
+	stdioToolset      string
+	operationManager  *operationManager
+	invocationManager *invocationManager
+	toolDefaults      ServerConfig
+	approvalManager   *approvalManager
+	ResourceMgr       *ResourceManager
 }
 
 // ResourceManager contains available resources for the server. Should be initialized with NewResourceManager().
@@ -56,6 +69,7 @@ type ResourceManager struct {
 	authServices map[string]auth.AuthService
 	tools        map[string]tools.Tool
 	toolsets     map[string]tools.Toolset
+	prompts      map[string]prompts.Prompt
 }
 
 func NewResourceManager(
@@ -102,13 +116,33 @@ func (r *ResourceManager) GetToolset(toolsetName string) (tools.Toolset, bool) {
 	return toolset, ok
 }
 
-func (r *ResourceManager) SetResources(sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset) {
+func (r *ResourceManager) SetResources(sourcesMap map[string]sources.Source, authServicesMap map[string]auth.AuthService, toolsMap map[string]tools.Tool, toolsetsMap map[string]tools.Toolset, promptsMap map[string]prompts.Prompt) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.sources = sourcesMap
 	r.authServices = authServicesMap
 	r.tools = toolsMap
 	r.toolsets = toolsetsMap
+	r.prompts = promptsMap
+}
+
+// SetPrompts sets the prompt templates served to MCP clients.
+func (r *ResourceManager) SetPrompts(promptsMap map[string]prompts.Prompt) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prompts = promptsMap
+}
+
+func (r *ResourceManager) GetPromptsMap() map[string]prompts.Prompt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.prompts
+}
+
+func (r *ResourceManager) GetSourcesMap() map[string]sources.Source {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sources
 }
 
 func (r *ResourceManager) GetAuthServiceMap() map[string]auth.AuthService {
@@ -117,17 +151,86 @@ func (r *ResourceManager) GetAuthServiceMap() map[string]auth.AuthService {
 	return r.authServices
 }
 
+func (r *ResourceManager) GetToolsetsMap() map[string]tools.Toolset {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.toolsets
+}
+
 func (r *ResourceManager) GetToolsMap() map[string]tools.Tool {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	return r.tools
 }
 
+// validateClientOAuthServices checks that the sources that send the access
+// tokens of callers name an auth service that verifies access tokens, since
+// their requests would otherwise always fail.
+func validateClientOAuthServices(sourceConfigs SourceConfigs, authServicesMap map[string]auth.AuthService) error {
+	for name, sc := range sourceConfigs {
+		if wc, ok := sc.(wrappedSourceConfig); ok {
+			sc = wc.SourceConfig
+		}
+		oc, ok := sc.(sources.ClientOAuthConfig)
+		if !ok || oc.GetClientOAuthService() == "" {
+			continue
+		}
+		a, ok := authServicesMap[oc.GetClientOAuthService()]
+		if !ok {
+			return fmt.Errorf("clientOAuthService %q of source %q does not exist", oc.GetClientOAuthService(), name)
+		}
+		if _, ok := a.(auth.AccessTokenService); !ok {
+			return fmt.Errorf("clientOAuthService %q of source %q doesn't verify access tokens, auth services of kind %q don't support them", oc.GetClientOAuthService(), name, a.AuthServiceKind())
+		}
+	}
+	return nil
+}
+
+// toolParameters returns the parameters and template parameters of a tool
+// config, which most tool kinds declare as fields of their config.
+func toolParameters(tc tools.ToolConfig) tools.Parameters {
+	v := reflect.ValueOf(tc)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var params tools.Parameters
+	for _, name := range []string{"Parameters", "TemplateParameters"} {
+		if f := v.FieldByName(name); f.IsValid() {
+			if p, ok := f.Interface().(tools.Parameters); ok {
+				params = append(params, p...)
+			}
+		}
+	}
+	return params
+}
+
+// checkCatalogParameters returns an error if a parameter of a tool config
+// requires its values to be found in the catalog of the database, but the
+// tool doesn't look them up, which would silently accept any identifier.
+func checkCatalogParameters(tc tools.ToolConfig) error {
+	if wc, ok := tc.(wrappedToolConfig); ok {
+		tc = wc.ToolConfig
+	}
+	var checked tools.Parameters
+	if cc, ok := tc.(tools.CatalogChecker); ok {
+		checked = cc.CatalogParameters()
+	}
+	names := tools.UncheckedCatalogParameters(toolParameters(tc), checked)
+	if len(names) > 0 {
+		return fmt.Errorf("tools of kind %q don't look up the identifiers of parameters %q in the catalog, remove their identifier catalog", tc.ToolConfigKind(), names)
+	}
+	return nil
+}
+
 func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 	map[string]sources.Source,
 	map[string]auth.AuthService,
 	map[string]tools.Tool,
 	map[string]tools.Toolset,
+	map[string]prompts.Prompt,
 	error,
 ) {
 	ctx = util.WithUserAgent(ctx, cfg.Version)
@@ -143,7 +246,26 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 
 	// initialize and validate the sources from configs
 	sourcesMap := make(map[string]sources.Source)
+	sourceLimiters := make(map[string]*rateLimiter)
+	sourceConcurrency := make(map[string]*concurrencyLimiter)
+	sourceBreakers := make(map[string]*breaker)
 	for name, sc := range cfg.SourceConfigs {
+		wc, isWrapped := sc.(wrappedSourceConfig)
+		if isWrapped {
+			if wc.RateLimit != nil {
+				sourceLimiters[name] = newRateLimiter(*wc.RateLimit)
+			}
+			if wc.ConcurrencyLimit != nil {
+				sourceConcurrency[name] = newConcurrencyLimiter(*wc.ConcurrencyLimit)
+			}
+			if wc.CircuitBreaker != nil {
+				sourceBreakers[name] = newBreaker(*wc.CircuitBreaker)
+			}
+			if wc.LazyInit {
+				sourcesMap[name] = newLazySource(ctx, name, sc)
+				continue
+			}
+		}
 		s, err := func() (sources.Source, error) {
 			childCtx, span := instrumentation.Tracer.Start(
 				ctx,
@@ -159,7 +281,14 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return s, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, err
+			if !cfg.AllowDegraded {
+				return nil, nil, nil, nil, nil, err
+			}
+			// the source is retried on first use, and only its tools are
+			// unavailable until then
+			l.WarnContext(ctx, fmt.Sprintf("%s, its tools are unavailable until it is initialized", err))
+			sourcesMap[name] = newFailedLazySource(ctx, name, sc, err)
+			continue
 		}
 		sourcesMap[name] = s
 	}
@@ -183,15 +312,44 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return a, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, err
 		}
 		authServicesMap[name] = a
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d authServices.", len(authServicesMap)))
+	if err := validateClientOAuthServices(cfg.SourceConfigs, authServicesMap); err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
 
 	// initialize and validate the tools from configs
 	toolsMap := make(map[string]tools.Tool)
-	for name, tc := range cfg.ToolConfigs {
+	// the cache is created up front, since the tools of lazy sources are
+	// initialized later, concurrently
+	var cache resultCache
+	for _, tc := range cfg.ToolConfigs {
+		if wc, ok := tc.(wrappedToolConfig); ok && wc.CacheTTL > 0 {
+			cache, err = newResultCache(ctx, cfg.Cache)
+			if err != nil {
+				return nil, nil, nil, nil, nil, err
+			}
+			break
+		}
+	}
+	if err := validateSensitiveParams(cfg.SensitiveParams); err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	defaultMasker, err := newPIIMasker(PIIMasking{Detectors: cfg.PIIDetectors})
+	if err != nil {
+		return nil, nil, nil, nil, nil, err
+	}
+	var audit *auditLog
+	if cfg.Audit.Enabled() {
+		audit, err = openAuditLog(ctx, cfg.Audit.Destination)
+		if err != nil {
+			return nil, nil, nil, nil, nil, err
+		}
+	}
+	initTool := func(name string, tc tools.ToolConfig, sourcesMap map[string]sources.Source) (tools.Tool, error) {
 		t, err := func() (tools.Tool, error) {
 			_, span := instrumentation.Tracer.Start(
 				ctx,
@@ -207,9 +365,101 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return t, nil
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, err
+			return nil, err
+		}
+		ownTimeoutTool := t
+		t = newDryRunTool(name, t)
+		// the tools of a source share its rate limit, concurrency limit and
+		// circuit breaker
+		sourceName := toolSourceName(tc)
+		if sourceConcurrency[sourceName] != nil {
+			t = newConcurrencyLimitedTool(sourceName, t, sourceConcurrency[sourceName])
+		}
+		if sourceLimiters[sourceName] != nil {
+			t = newRateLimitedTool(fmt.Sprintf("source %q", sourceName), t, sourceLimiters[sourceName])
+		}
+		if sourceBreakers[sourceName] != nil {
+			t = newBreakerTool(sourceName, t, sourceBreakers[sourceName])
+		}
+		limits := cfg.ResponseLimits
+		timeout := cfg.ToolTimeout
+		if wc, ok := tc.(wrappedToolConfig); ok {
+			limits = wc.Limits.Override(limits)
+			if wc.RateLimit != nil {
+				t = newRateLimitedTool(fmt.Sprintf("tool %q", name), t, newRateLimiter(*wc.RateLimit))
+			}
+			if wc.CacheTTL > 0 {
+				t = newCachedTool(name, t, wc.CacheTTL, cache)
+			}
+			if wc.Timeout > 0 {
+				timeout = wc.Timeout
+			}
 		}
-		toolsMap[name] = t
+		if limits.Enabled() {
+			t, err = newLimitedTool(name, t, limits)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if timeout = boundTimeout(ownTimeoutTool, timeout); timeout > 0 {
+			t = newTimeoutTool(name, t, timeout)
+		}
+		return t, nil
+	}
+	for name, tc := range cfg.ToolConfigs {
+		if wc, ok := tc.(wrappedToolConfig); ok && wc.RequiresApproval && cfg.Approvals.AuthService == "" {
+			// without it, anyone could approve invocations, including the
+			// caller that requested them
+			return nil, nil, nil, nil, nil, fmt.Errorf("tool %q requires approval, which requires an approval auth service to authenticate reviewers", name)
+		}
+		// checked up front, since the tools of lazy sources are initialized
+		// later
+		if err := checkCatalogParameters(tc); err != nil {
+			return nil, nil, nil, nil, nil, fmt.Errorf("unable to initialize tool %q: %w", name, err)
+		}
+		var t tools.Tool
+		if ls, ok := sourcesMap[toolSourceName(tc)].(*lazySource); ok {
+			// the tool is initialized once its source is
+			t = newLazyTool(name, tc, ls, func(src sources.Source) (tools.Tool, error) {
+				return initTool(name, tc, withSource(sourcesMap, ls.name, src))
+			})
+		} else {
+			t, err = initTool(name, tc, sourcesMap)
+			if err != nil {
+				return nil, nil, nil, nil, nil, err
+			}
+		}
+		if wc, ok := tc.(wrappedToolConfig); ok && wc.Annotations != nil {
+			t = newAnnotatedTool(t, *wc.Annotations)
+		}
+		masker := defaultMasker
+		if wc, ok := tc.(wrappedToolConfig); ok && wc.PIIMasking != nil {
+			// the masking of a tool replaces the server-wide default; it was
+			// validated when the tool was parsed
+			masker, _ = newPIIMasker(*wc.PIIMasking)
+		}
+		if masker != nil {
+			t = newPIIMaskedTool(t, masker)
+		}
+		t = newSensitiveTool(t, cfg.SensitiveParams)
+		if wc, ok := tc.(wrappedToolConfig); ok && wc.Normalization != nil {
+			t = newNormalizedTool(t, *wc.Normalization)
+		}
+		if audit != nil {
+			t = newAuditedTool(name, tc.ToolConfigKind(), toolSourceName(tc), t, cfg.Audit.RedactParams, audit)
+		}
+		format := cfg.ResultFormat
+		if wc, ok := tc.(wrappedToolConfig); ok && wc.ResultFormat != "" {
+			format = wc.ResultFormat
+		}
+		t = newFormattedTool(t, format)
+		if wc, ok := tc.(wrappedToolConfig); ok && wc.RequiresApproval {
+			t = newApprovalTool(name, t)
+		}
+		if wc, ok := tc.(wrappedToolConfig); cfg.DryRun || (ok && wc.DryRun) {
+			t = newDryRunOnlyTool(t)
+		}
+		toolsMap[name] = newInstrumentedTool(name, tc.ToolConfigKind(), toolSourceName(tc), t, instrumentation)
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d tools.", len(toolsMap)))
 
@@ -240,13 +490,24 @@ func InitializeConfigs(ctx context.Context, cfg ServerConfig) (
 			return t, err
 		}()
 		if err != nil {
-			return nil, nil, nil, nil, err
+			return nil, nil, nil, nil, nil, err
 		}
 		toolsetsMap[name] = t
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d toolsets.", len(toolsetsMap)))
 
-	return sourcesMap, authServicesMap, toolsMap, toolsetsMap, nil
+	// initialize and validate the prompts from configs
+	promptsMap := make(map[string]prompts.Prompt)
+	for name, pc := range cfg.PromptConfigs {
+		p, err := pc.Initialize()
+		if err != nil {
+			return nil, nil, nil, nil, nil, fmt.Errorf("unable to initialize prompt %q: %w", name, err)
+		}
+		promptsMap[name] = p
+	}
+	l.InfoContext(ctx, fmt.Sprintf("Initialized %d prompts.", len(promptsMap)))
+
+	return sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, nil
 }
 
 // NewServer returns a Server object based on provided Config.
@@ -267,6 +528,7 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	// set up http serving
 	r := chi.NewRouter()
 	r.Use(middleware.Recoverer)
+	r.Use(withCaller)
 	// logging
 	logLevel, err := log.SeverityToLevel(cfg.LogLevel.String())
 	if err != nil {
@@ -298,26 +560,54 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 	httpLogger := httplog.NewLogger("httplog", httpOpts)
 	r.Use(httplog.RequestLogger(httpLogger))
 
-	sourcesMap, authServicesMap, toolsMap, toolsetsMap, err := InitializeConfigs(ctx, cfg)
+	sourcesMap, authServicesMap, toolsMap, toolsetsMap, promptsMap, err := InitializeConfigs(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize configs: %w", err)
 	}
 
+	if err := validateListen(cfg.Listen); err != nil {
+		return nil, err
+	}
 	addr := net.JoinHostPort(cfg.Address, strconv.Itoa(cfg.Port))
 	srv := &http.Server{Addr: addr, Handler: r}
+	if srv.TLSConfig, err = cfg.TLS.tlsConfig(); err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+	}
 
 	sseManager := newSseManager(ctx)
+	operationManager := newOperationManager(ctx)
+
+	for _, name := range cfg.StdioToolsets {
+		if _, ok := toolsetsMap[name]; !ok {
+			return nil, fmt.Errorf("toolset %q does not exist", name)
+		}
+	}
+	if cfg.Approvals.AuthService != "" {
+		if _, ok := authServicesMap[cfg.Approvals.AuthService]; !ok {
+			return nil, fmt.Errorf("approval auth service %q does not exist", cfg.Approvals.AuthService)
+		}
+	} else if len(cfg.Approvals.Approvers) > 0 {
+		return nil, fmt.Errorf("approvers require an approval auth service")
+	}
 
 	resourceManager := NewResourceManager(sourcesMap, authServicesMap, toolsMap, toolsetsMap)
+	resourceManager.SetPrompts(promptsMap)
 
 	s := &Server{
-		version:         cfg.Version,
-		srv:             srv,
-		root:            r,
-		logger:          l,
-		instrumentation: instrumentation,
-		sseManager:      sseManager,
-		ResourceMgr:     resourceManager,
+		version:           cfg.Version,
+		srv:               srv,
+		listen:            cfg.Listen,
+		root:              r,
+		logger:            l,
+		instrumentation:   instrumentation,
+		sseManager:        sseManager,
+		mcpSessionManager: newMcpSessionManager(ctx),
+		operationManager:  operationManager,
+		invocationManager: newInvocationManager(),
+		approvalManager:   newApprovalManager(ctx, cfg.Approvals, l),
+		toolDefaults:      ServerConfig{Version: cfg.Version, ResponseLimits: cfg.ResponseLimits, Cache: cfg.Cache, AllowDegraded: cfg.AllowDegraded, McpResourceThreshold: cfg.McpResourceThreshold, Audit: cfg.Audit, SensitiveParams: cfg.SensitiveParams, PIIDetectors: cfg.PIIDetectors, DryRun: cfg.DryRun, ToolTimeout: cfg.ToolTimeout, ResultFormat: cfg.ResultFormat, Approvals: cfg.Approvals},
+		ResourceMgr:       resourceManager,
+		stdioToolset:      strings.Join(cfg.StdioToolsets, ","),
 	}
 	// control plane
 	apiR, err := apiRouter(s)
@@ -330,14 +620,25 @@ func NewServer(ctx context.Context, cfg ServerConfig) (*Server, error) {
 		return nil, err
 	}
 	r.Mount("/mcp", mcpR)
+	if cfg.TelemetryPrometheus {
+		r.Handle("/metrics", telemetry.PrometheusHandler())
+	}
 	// default endpoint for validating server is running
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("🧰 Hello, World! 🧰"))
 	})
+	r.Get("/healthz", healthzHandler)
+	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) { readyzHandler(s, w, r) })
 
 	return s, nil
 }
 
+// ToolDefaults returns the server-wide settings used to initialize tools, for
+// initializing the tools of a reloaded tools file.
+func (s *Server) ToolDefaults() ServerConfig {
+	return s.toolDefaults
+}
+
 // Listen starts a listener for the given Server instance.
 func (s *Server) Listen(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
@@ -346,17 +647,21 @@ func (s *Server) Listen(ctx context.Context) error {
 	if s.listener != nil {
 		return fmt.Errorf("server is already listening: %s", s.listener.Addr().String())
 	}
-	lc := net.ListenConfig{KeepAlive: 30 * time.Second}
 	var err error
-	if s.listener, err = lc.Listen(ctx, "tcp", s.srv.Addr); err != nil {
-		return fmt.Errorf("failed to open listener for %q: %w", s.srv.Addr, err)
+	if s.listener, err = openListener(ctx, s.listen, s.srv.Addr); err != nil {
+		return err
 	}
-	s.logger.DebugContext(ctx, fmt.Sprintf("server listening on %s", s.srv.Addr))
+	s.logger.DebugContext(ctx, fmt.Sprintf("server listening on %s", s.listener.Addr().String()))
 	return nil
 }
 
 // Serve starts an HTTP server for the given Server instance.
 func (s *Server) Serve(ctx context.Context) error {
+	if s.srv.TLSConfig != nil {
+		s.logger.DebugContext(ctx, "Starting a HTTPS server.")
+		// the certificates are already in the TLS config
+		return s.srv.ServeTLS(s.listener, "", "")
+	}
 	s.logger.DebugContext(ctx, "Starting a HTTP server.")
 	return s.srv.Serve(s.listener)
 }
@@ -364,12 +669,20 @@ func (s *Server) Serve(ctx context.Context) error {
 // ServeStdio starts a new stdio session for mcp.
 func (s *Server) ServeStdio(ctx context.Context, stdin io.Reader, stdout io.Writer) error {
 	stdioServer := NewStdioSession(s, stdin, stdout)
-	return stdioServer.Start(ctx)
+	stdioServer.toolsetName = s.stdioToolset
+	s.stdio.Store(stdioServer)
+	err := stdioServer.Start(ctx)
+	if cErr := closeAuditLogs(context.WithoutCancel(ctx)); cErr != nil {
+		s.logger.ErrorContext(ctx, cErr.Error())
+	}
+	return err
 }
 
 // Shutdown gracefully shuts down the server without interrupting any active
 // connections. It uses http.Server.Shutdown() and has the same functionality.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.DebugContext(ctx, "shutting down the server.")
-	return s.srv.Shutdown(ctx)
+	err := s.srv.Shutdown(ctx)
+	// invocations have finished, so their audit records can be flushed
+	return errors.Join(err, closeAuditLogs(ctx))
 }
@@ -26,12 +26,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/prompts"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
@@ -46,7 +49,7 @@ func TestServe(t *testing.T) {
 		Port:    port,
 	}
 
-	otelShutdown, err := telemetry.SetupOTel(ctx, "0.0.0", "", false, "toolbox")
+	otelShutdown, err := telemetry.SetupOTel(ctx, "0.0.0", "", false, false, "toolbox")
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
@@ -147,7 +150,8 @@ func TestUpdateServer(t *testing.T) {
 			Name: "example-toolset", Tools: []*tools.Tool{},
 		},
 	}
-	s.ResourceMgr.SetResources(newSources, newAuth, newTools, newToolsets)
+	newPrompts := map[string]prompts.Prompt{"example-prompt": {Name: "example-prompt"}}
+	s.ResourceMgr.SetResources(newSources, newAuth, newTools, newToolsets, newPrompts)
 	if err != nil {
 		t.Errorf("error updating server: %s", err)
 	}
@@ -171,4 +175,77 @@ func TestUpdateServer(t *testing.T) {
 	if diff := cmp.Diff(gotToolset, newToolsets["example-toolset"]); diff != "" {
 		t.Errorf("error updating server, toolset (-want +got):\n%s", diff)
 	}
+
+	if _, ok := s.ResourceMgr.GetPromptsMap()["example-prompt"]; !ok {
+		t.Errorf("error updating server, prompts: %q not found", "example-prompt")
+	}
+}
+
+func TestNewServerUnknownStdioToolset(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("error setting up logger: %s", err)
+	}
+	cfg := server.ServerConfig{
+		Version:       "0.0.0",
+		Stdio:         true,
+		StdioToolsets: []string{"missing"},
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation(cfg.Version)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+
+	_, err = server.NewServer(ctx, cfg)
+	if err == nil || !strings.Contains(err.Error(), `toolset "missing" does not exist`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInitializeConfigsUncheckedCatalog(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("error setting up logger: %s", err)
+	}
+	instrumentation, err := telemetry.CreateTelemetryInstrumentation("0.0.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx = util.WithInstrumentation(ctx, instrumentation)
+	table := func() tools.Parameters {
+		return tools.Parameters{tools.NewStringParameterWithIdentifier("table", "a table", &tools.Identifier{Catalog: tools.CatalogTable})}
+	}
+
+	tcs := []struct {
+		desc    string
+		tc      tools.ToolConfig
+		wantErr string
+	}{
+		{
+			desc:    "tool that doesn't check the catalog",
+			tc:      sqlitesql.Config{Name: "my-tool", Kind: "sqlite-sql", Source: "my-db", TemplateParameters: table()},
+			wantErr: `tools of kind "sqlite-sql" don't look up the identifiers of parameters ["table"] in the catalog`,
+		},
+		{
+			desc:    "parameter that isn't checked",
+			tc:      postgressql.Config{Name: "my-tool", Kind: "postgres-sql", Source: "my-db", Parameters: table()},
+			wantErr: `tools of kind "postgres-sql" don't look up the identifiers of parameters ["table"] in the catalog`,
+		},
+		{
+			// the tool then fails since its source doesn't exist
+			desc:    "checked template parameter",
+			tc:      postgressql.Config{Name: "my-tool", Kind: "postgres-sql", Source: "my-db", TemplateParameters: table()},
+			wantErr: `no source named "my-db" configured`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := server.ServerConfig{Version: "0.0.0", ToolConfigs: server.ToolConfigs{"my-tool": tc.tc}}
+			_, _, _, _, _, err := server.InitializeConfigs(ctx, cfg)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
 }
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// ndjsonContentType is the media type of results whose rows are streamed as
+// newline delimited JSON.
+const ndjsonContentType = "application/x-ndjson"
+
+// rowsRequested returns true if the client accepts the rows of the result
+// streamed as newline delimited JSON.
+func rowsRequested(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Accept"))
+	return err == nil && mediaType == ndjsonContentType
+}
+
+// rowLine is a line of a streamed result. Each line has either a row of the
+// result, or the error of an invocation that failed after rows were written.
+// If the client asked for them, the columns of the rows are on the first line.
+type rowLine struct {
+	Schema []util.Column `json:"schema,omitempty"`
+	Row    any           `json:"row,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// invokeStreamingRows invokes a tool and writes the rows of its result to the
+// client as they're read, so that a large result isn't buffered in memory.
+// Tools that don't stream their rows, or whose results the server needs whole,
+// e.g. to limit or cache them, have their result written row by row once it
+// is returned.
+func invokeStreamingRows(ctx context.Context, w http.ResponseWriter, r *http.Request, tool tools.Tool, params tools.ParamValues) error {
+	enc := json.NewEncoder(w)
+	started := false
+	write := func(row any) error {
+		if !started {
+			w.Header().Set("Content-Type", ndjsonContentType)
+			w.WriteHeader(http.StatusOK)
+			started = true
+			// tools report the columns of their result before its rows
+			if columns := resultSchema(ctx); columns != nil {
+				if err := enc.Encode(rowLine{Schema: columns}); err != nil {
+					return err
+				}
+			}
+		}
+		return enc.Encode(rowLine{Row: row})
+	}
+
+	res, err := tool.Invoke(util.WithRowWriter(ctx, write), params)
+	if err != nil {
+		if ctx.Err() != nil {
+			err = errInvocationCancelled
+		}
+		err = fmt.Errorf("error while invoking tool: %w", err)
+		if started {
+			// the status was sent with the first row
+			_ = enc.Encode(rowLine{Error: err.Error()})
+			return err
+		}
+		_ = render.Render(w, r, newErrResponse(err, invokeErrorStatus(err)))
+		return err
+	}
+	if _, ok := res.(pendingApproval); ok {
+		// the tool runs once a human approves the invocation
+		resMarshal, _ := json.Marshal(res)
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, resultResponse{Result: string(resMarshal)})
+		return nil
+	}
+
+	rows, ok := res.([]any)
+	if !ok && res != nil {
+		rows = []any{res}
+	}
+	for _, row := range rows {
+		if err := write(row); err != nil {
+			err = fmt.Errorf("unable to marshal result: %w", err)
+			_ = enc.Encode(rowLine{Error: err.Error()})
+			return err
+		}
+	}
+	if !started {
+		// a result without rows
+		w.Header().Set("Content-Type", ndjsonContentType)
+		w.WriteHeader(http.StatusOK)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// streamingTool is a MockTool that writes its rows to the row writer of the
+// invocation if there is one, and fails after them if err is set.
+type streamingTool struct {
+	MockTool
+	rows []any
+	err  error
+}
+
+func (t streamingTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	write, ok := util.RowWriterFromContext(ctx)
+	if !ok {
+		return t.rows, t.err
+	}
+	for _, row := range t.rows {
+		if err := write(row); err != nil {
+			return nil, err
+		}
+	}
+	return nil, t.err
+}
+
+func TestRowsRequested(t *testing.T) {
+	for accept, want := range map[string]bool{
+		"application/x-ndjson":                true,
+		"application/x-ndjson; charset=utf-8": true,
+		"application/json":                    false,
+		"":                                    false,
+	} {
+		if got := rowsRequested(http.Header{"Accept": []string{accept}}); got != want {
+			t.Fatalf("incorrect result for %q: got %v, want %v", accept, got, want)
+		}
+	}
+}
+
+func TestToolInvokeEndpointStreamingRows(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	rows := []any{map[string]any{"email": "alice@example.com"}, map[string]any{"email": "bob@example.com"}}
+	masker, err := newPIIMasker(PIIMasking{Detectors: []string{"email"}})
+	if err != nil {
+		t.Fatalf("unable to create masker: %s", err)
+	}
+	toolsMap["streamed"] = streamingTool{MockTool: MockTool{Name: "streamed"}, rows: rows}
+	toolsMap["buffered"], err = newLimitedTool("buffered", streamingTool{MockTool: MockTool{Name: "buffered"}, rows: rows}, tools.ResponseLimits{MaxRows: 1})
+	if err != nil {
+		t.Fatalf("unable to create tool: %s", err)
+	}
+	toolsMap["masked"] = newPIIMaskedTool(streamingTool{MockTool: MockTool{Name: "masked"}, rows: rows}, masker)
+	toolsMap["failing"] = streamingTool{MockTool: MockTool{Name: "failing"}, rows: rows, err: errors.New("connection reset")}
+	toolsMap["failing_early"] = streamingTool{MockTool: MockTool{Name: "failing_early"}, err: errRateLimited}
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	testCases := []struct {
+		name       string
+		toolName   string
+		want       string
+		wantStatus int
+	}{
+		{
+			name:       "streamed rows",
+			toolName:   "streamed",
+			want:       "{\"row\":{\"email\":\"alice@example.com\"}}\n{\"row\":{\"email\":\"bob@example.com\"}}\n",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "buffered rows",
+			toolName:   "buffered",
+			want:       "{\"row\":{\"email\":\"alice@example.com\"}}\n{\"row\":\"[truncated: returned 1 of 2 rows]\"}\n",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "masked rows",
+			toolName:   "masked",
+			want:       "{\"row\":{\"email\":\"[REDACTED_EMAIL]\"}}\n{\"row\":{\"email\":\"[REDACTED_EMAIL]\"}}\n",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "result of a tool that doesn't stream",
+			toolName:   tool1.Name,
+			want:       "{\"row\":\"no_params\"}\n",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failure after rows",
+			toolName:   "failing",
+			want:       "{\"row\":{\"email\":\"alice@example.com\"}}\n{\"row\":{\"email\":\"bob@example.com\"}}\n{\"error\":\"error while invoking tool: connection reset\"}\n",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failure before rows",
+			toolName:   "failing_early",
+			wantStatus: http.StatusTooManyRequests,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body, err := runRequest(ts, http.MethodPost, "/tool/"+tc.toolName+"/invoke", bytes.NewBuffer([]byte(`{}`)), map[string]string{"Accept": ndjsonContentType})
+			if err != nil {
+				t.Fatalf("unexpected error during request: %s", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status code: want %d, got %d, %s", tc.wantStatus, resp.StatusCode, string(body))
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			if got := resp.Header.Get("Content-Type"); got != ndjsonContentType {
+				t.Fatalf("unexpected content type: got %q", got)
+			}
+			if got := string(body); got != tc.want {
+				t.Fatalf("unexpected rows: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// errToolTimeout is returned for invocations that don't finish within the
+// timeout of their tool.
+var errToolTimeout = errors.New("tool invocation timed out")
+
+// parseTimeout parses the raw value of the timeout of a tool.
+func parseTimeout(val any) (time.Duration, error) {
+	s, ok := val.(string)
+	if !ok {
+		return 0, fmt.Errorf("must be a duration string, such as \"30s\"")
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be greater than 0")
+	}
+	return d, nil
+}
+
+// popTimeout removes the timeout field from a raw tool config and returns it.
+// The timeout field of the kinds of tools that have one of their own is left
+// for them to decode.
+func popTimeout(kind string, v map[string]any) (time.Duration, error) {
+	val, ok := v["timeout"]
+	if !ok || tools.HasOwnTimeout(kind) {
+		return 0, nil
+	}
+	delete(v, "timeout")
+	return parseTimeout(val)
+}
+
+// boundTimeout returns the timeout that bounds the invocations of a tool, the
+// smaller of the timeout of the server and the tool's own timeout. It returns
+// 0 if the tool bounds its invocations with its own timeout.
+func boundTimeout(tool tools.Tool, timeout time.Duration) time.Duration {
+	ot, ok := tool.(tools.OwnTimeoutTool)
+	if !ok || ot.OwnTimeout() <= 0 {
+		return timeout
+	}
+	if timeout == 0 || ot.OwnTimeout() <= timeout {
+		// the tool reports its own timeout in more detail
+		return 0
+	}
+	return timeout
+}
+
+// timeoutTool cancels the invocations of a tool that don't finish within its
+// timeout.
+type timeoutTool struct {
+	tools.Tool
+	name    string
+	timeout time.Duration
+}
+
+func newTimeoutTool(name string, tool tools.Tool, timeout time.Duration) timeoutTool {
+	return timeoutTool{Tool: tool, name: name, timeout: timeout}
+}
+
+func (t timeoutTool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	tCtx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	res, err := t.Tool.Invoke(tCtx, params)
+	if err != nil && ctx.Err() == nil && errors.Is(tCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%w: tool %q did not finish within %s", errToolTimeout, t.name, t.timeout)
+	}
+	return res, err
+}
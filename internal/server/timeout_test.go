@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/postgres/postgressql"
+	"github.com/googleapis/genai-toolbox/internal/tools/utility/wait"
+)
+
+// slowTool is a MockTool whose invocations block until they are cancelled.
+type slowTool struct {
+	MockTool
+}
+
+func (t slowTool) Invoke(ctx context.Context, _ tools.ParamValues) (any, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestParseTimeout(t *testing.T) {
+	got, err := parseTimeout("30s")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != 30*time.Second {
+		t.Fatalf("incorrect timeout: got %s", got)
+	}
+
+	for _, val := range []any{30, "thirty seconds", "0s", "-1m"} {
+		if _, err := parseTimeout(val); err == nil {
+			t.Fatalf("expected error for timeout %v", val)
+		}
+	}
+}
+
+func TestToolConfigsTimeout(t *testing.T) {
+	in := `
+search_flights:
+  kind: postgres-sql
+  source: my-pg-instance
+  description: Search flights.
+  statement: SELECT * FROM flights
+  timeout: 30s
+pause:
+  kind: wait
+  description: Wait for a while.
+  timeout: 2m
+`
+	var got ToolConfigs
+	if err := yaml.UnmarshalContext(context.Background(), []byte(in), &got, yaml.Strict()); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	wc, ok := got["search_flights"].(wrappedToolConfig)
+	if !ok || wc.Timeout != 30*time.Second {
+		t.Fatalf("incorrect timeout of search_flights: %+v", got["search_flights"])
+	}
+	// the wait tool decodes its own timeout
+	if cfg, ok := got["pause"].(wait.Config); !ok || cfg.Timeout != "2m" {
+		t.Fatalf("incorrect config of pause: %+v", got["pause"])
+	}
+
+	in = `
+search_flights:
+  kind: postgres-sql
+  source: my-pg-instance
+  description: Search flights.
+  statement: SELECT * FROM flights
+  timeout: forever
+`
+	if err := yaml.UnmarshalContext(context.Background(), []byte(in), &got, yaml.Strict()); err == nil {
+		t.Fatalf("expected error for an invalid timeout")
+	}
+}
+
+// ownTimeoutTool is a MockTool with a timeout of its own.
+type ownTimeoutTool struct {
+	MockTool
+	timeout time.Duration
+}
+
+func (t ownTimeoutTool) OwnTimeout() time.Duration {
+	return t.timeout
+}
+
+func TestBoundTimeout(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		tool    tools.Tool
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{desc: "no own timeout", tool: tool1, timeout: time.Minute, want: time.Minute},
+		{desc: "unbounded", tool: tool1, want: 0},
+		{desc: "smaller own timeout", tool: ownTimeoutTool{MockTool: tool1, timeout: time.Second}, timeout: time.Minute, want: 0},
+		{desc: "larger own timeout", tool: ownTimeoutTool{MockTool: tool1, timeout: time.Hour}, timeout: time.Minute, want: time.Minute},
+		{desc: "own timeout only", tool: ownTimeoutTool{MockTool: tool1, timeout: time.Hour}, want: 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := boundTimeout(tc.tool, tc.timeout); got != tc.want {
+				t.Fatalf("incorrect timeout: want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTimeoutTool(t *testing.T) {
+	tool := newTimeoutTool("slow", slowTool{MockTool: tool1}, 10*time.Millisecond)
+	_, err := tool.Invoke(context.Background(), nil)
+	if !errors.Is(err, errToolTimeout) {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if invokeErrorStatus(err) != 504 {
+		t.Fatalf("incorrect status: got %d", invokeErrorStatus(err))
+	}
+
+	// cancelling the invocation isn't reported as a timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = tool.Invoke(ctx, nil)
+	if errors.Is(err, errToolTimeout) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+
+	tool = newTimeoutTool("fast", tool1, time.Minute)
+	if _, err := tool.Invoke(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// TLSConfig defines how the HTTP server serves TLS.
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM encoded certificate chain and private
+	// key of the server. TLS is disabled if they are empty.
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is a PEM encoded bundle of the CAs that client
+	// certificates are verified with. If it is set, clients must present a
+	// certificate, which is known as mutual TLS.
+	ClientCAFile string
+	// AllowedClientSANs restricts clients to certificates with one of the
+	// listed subject alternative names: a DNS name, a URI such as a SPIFFE
+	// ID, an email address, or an IP address. Every verified client is
+	// allowed if it is empty.
+	AllowedClientSANs []string
+}
+
+// Enabled returns whether the server serves TLS.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// tlsConfig returns the TLS configuration of the HTTP server, which is nil if
+// TLS is disabled.
+func (c TLSConfig) tlsConfig() (*tls.Config, error) {
+	if !c.Enabled() {
+		if c.ClientCAFile != "" || len(c.AllowedClientSANs) > 0 {
+			return nil, fmt.Errorf("client certificates require a TLS certificate file and key file")
+		}
+		return nil, nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, fmt.Errorf("TLS requires both a certificate file and a key file")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load TLS certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.ClientCAFile == "" {
+		if len(c.AllowedClientSANs) > 0 {
+			return nil, fmt.Errorf("allowed client SANs require a client CA file")
+		}
+		return cfg, nil
+	}
+
+	pem, err := os.ReadFile(c.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA file %q", c.ClientCAFile)
+	}
+	cfg.ClientCAs = pool
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	if len(c.AllowedClientSANs) > 0 {
+		allowed := c.AllowedClientSANs
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			// the chain was verified already, so the leaf is trusted
+			if len(cs.PeerCertificates) == 0 || !slices.ContainsFunc(certificateSANs(cs.PeerCertificates[0]), func(san string) bool {
+				return slices.Contains(allowed, san)
+			}) {
+				return fmt.Errorf("client certificate has no allowed subject alternative name")
+			}
+			return nil
+		}
+	}
+	return cfg, nil
+}
+
+// certificateSANs returns the subject alternative names of a certificate.
+func certificateSANs(cert *x509.Certificate) []string {
+	sans := slices.Clone(cert.DNSNames)
+	sans = append(sans, cert.EmailAddresses...)
+	for _, u := range cert.URIs {
+		sans = append(sans, u.String())
+	}
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	return sans
+}
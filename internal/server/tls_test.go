@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCA is a certificate authority that issues certificates for tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("unable to create CA certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse CA certificate: %s", err)
+	}
+	return testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a certificate with the SANs, and its PEM encoded chain and
+// key.
+func (ca testCA) issue(t *testing.T, tmpl *x509.Certificate) (tls.Certificate, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %s", err)
+	}
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %s", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("unable to load certificate: %s", err)
+	}
+	return cert, certPEM, keyPEM
+}
+
+func writeFile(t *testing.T, dir, name string, b []byte) string {
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, b, 0o600); err != nil {
+		t.Fatalf("unable to write %s: %s", name, err)
+	}
+	return p
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	_, serverPEM, serverKeyPEM := ca.issue(t, &x509.Certificate{IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}})
+	agentURI, _ := url.Parse("spiffe://example.com/agent")
+	agent, _, _ := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "agent"}, URIs: []*url.URL{agentURI}})
+	other, _, _ := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "other"}, DNSNames: []string{"other.example.com"}})
+	untrusted, _, _ := newTestCA(t).issue(t, &x509.Certificate{URIs: []*url.URL{agentURI}})
+
+	cfg := TLSConfig{
+		CertFile:          writeFile(t, dir, "server.pem", serverPEM),
+		KeyFile:           writeFile(t, dir, "server-key.pem", serverKeyPEM),
+		ClientCAFile:      writeFile(t, dir, "ca.pem", ca.pem),
+		AllowedClientSANs: []string{"spiffe://example.com/agent"},
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = tlsCfg
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	tcs := []struct {
+		desc    string
+		certs   []tls.Certificate
+		wantErr bool
+	}{
+		{desc: "allowed client", certs: []tls.Certificate{agent}},
+		{desc: "no client certificate", wantErr: true},
+		{desc: "client without allowed SAN", certs: []tls.Certificate{other}, wantErr: true},
+		{desc: "client of untrusted CA", certs: []tls.Certificate{untrusted}, wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: tc.certs}}}
+			resp, err := client.Get(srv.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("unexpected result: got error %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	_, serverPEM, serverKeyPEM := ca.issue(t, &x509.Certificate{DNSNames: []string{"localhost"}})
+	certFile := writeFile(t, dir, "server.pem", serverPEM)
+	keyFile := writeFile(t, dir, "server-key.pem", serverKeyPEM)
+
+	tcs := []struct {
+		desc string
+		cfg  TLSConfig
+		err  string
+	}{
+		{
+			desc: "client CA without certificate",
+			cfg:  TLSConfig{ClientCAFile: "ca.pem"},
+			err:  "client certificates require a TLS certificate file and key file",
+		},
+		{
+			desc: "certificate without key",
+			cfg:  TLSConfig{CertFile: certFile},
+			err:  "TLS requires both a certificate file and a key file",
+		},
+		{
+			desc: "allowed SANs without client CA",
+			cfg:  TLSConfig{CertFile: certFile, KeyFile: keyFile, AllowedClientSANs: []string{"agent.example.com"}},
+			err:  "allowed client SANs require a client CA file",
+		},
+		{
+			desc: "client CA without certificates",
+			cfg:  TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: writeFile(t, dir, "empty.pem", nil)},
+			err:  "no certificates found in client CA file",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.tlsConfig()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+
+	cfg, err := TLSConfig{}.tlsConfig()
+	if err != nil || cfg != nil {
+		t.Fatalf("expected TLS to be disabled, got %v, %v", cfg, err)
+	}
+}
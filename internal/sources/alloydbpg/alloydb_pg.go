@@ -58,6 +58,11 @@ type Config struct {
 	User     string         `yaml:"user"`
 	Password string         `yaml:"password"`
 	Database string         `yaml:"database" validate:"required"`
+	// Credentials configs, which default to the Application Default Credentials
+	CredentialsFile           string `yaml:"credentialsFile"`
+	ImpersonateServiceAccount string `yaml:"impersonateServiceAccount"`
+
+	sources.PoolConfig `yaml:",inline"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -65,7 +70,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initAlloyDBPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Cluster, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+	pool, err := initAlloyDBPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Cluster, r.Instance, r.IPType.String(), r.User, r.Password, r.Database, r.CredentialsFile, r.ImpersonateServiceAccount, r.PoolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -84,6 +89,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+var _ sources.SchemaDescriber = &Source{}
 
 type Source struct {
 	Name string `yaml:"name"`
@@ -99,6 +106,21 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
+}
+
+// ListTables returns the tables of the database.
+func (s *Source) ListTables(ctx context.Context) ([]string, error) {
+	return sources.ListPostgresTables(ctx, s.Pool)
+}
+
+// DescribeTable returns the DDL of a table.
+func (s *Source) DescribeTable(ctx context.Context, table string) (string, error) {
+	return sources.DescribePostgresTable(ctx, s.Pool, table)
+}
+
 func getOpts(ipType, userAgent string, useIAM bool) ([]alloydbconn.Option, error) {
 	opts := []alloydbconn.Option{alloydbconn.WithUserAgent(userAgent)}
 	switch strings.ToLower(ipType) {
@@ -145,11 +167,23 @@ func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string
 	return dsn, useIAM, nil
 }
 
-func initAlloyDBPgConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, cluster, instance, ipType, user, pass, dbname string) (*pgxpool.Pool, error) {
+func initAlloyDBPgConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, cluster, instance, ipType, user, pass, dbname, credentialsFile, impersonateServiceAccount string, poolConfig sources.PoolConfig) (*pgxpool.Pool, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
+	// The IAM database user of other credentials than the Application
+	// Default Credentials can't be looked up, but is the impersonated
+	// service account, if any
+	if user == "" && pass == "" {
+		switch {
+		case impersonateServiceAccount != "":
+			user = strings.TrimSuffix(impersonateServiceAccount, ".gserviceaccount.com")
+		case credentialsFile != "":
+			return nil, fmt.Errorf("user must be set for IAM authentication with credentialsFile")
+		}
+	}
+
 	dsn, useIAM, err := getConnectionConfig(ctx, user, pass, dbname)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get AlloyDB connection config: %w", err)
@@ -159,6 +193,9 @@ func initAlloyDBPgConnectionPool(ctx context.Context, tracer trace.Tracer, name,
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
 	}
+	if err := poolConfig.ConfigurePgxPool(config); err != nil {
+		return nil, err
+	}
 	// Create a new dialer with options
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
@@ -168,6 +205,15 @@ func initAlloyDBPgConnectionPool(ctx context.Context, tracer trace.Tracer, name,
 	if err != nil {
 		return nil, err
 	}
+	if credentialsFile != "" || impersonateServiceAccount != "" {
+		creds, err := sources.GetGoogleCredentials(ctx, credentialsFile, impersonateServiceAccount, sources.CloudPlatformScope)
+		if err != nil {
+			return nil, err
+		}
+		// the dialer calls the AlloyDB Admin API, and logs in with IAM
+		// database authentication, with the tokens of the credentials
+		opts = append(opts, alloydbconn.WithTokenSource(creds.TokenSource))
+	}
 	d, err := alloydbconn.NewDialer(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
@@ -60,6 +60,33 @@ func TestParseFromYamlAlloyDBPg(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "workload identity federation",
+			in: `
+			sources:
+				my-pg-instance:
+					kind: alloydb-postgres
+					project: my-project
+					region: my-region
+					cluster: my-cluster
+					instance: my-instance
+					database: my_db
+					credentialsFile: /etc/toolbox/wif.json
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-pg-instance": alloydbpg.Config{
+					Name:            "my-pg-instance",
+					Kind:            alloydbpg.SourceKind,
+					Project:         "my-project",
+					Region:          "my-region",
+					Cluster:         "my-cluster",
+					Instance:        "my-instance",
+					IPType:          "public",
+					Database:        "my_db",
+					CredentialsFile: "/etc/toolbox/wif.json",
+				},
+			},
+		},
 		{
 			desc: "public ipType",
 			in: `
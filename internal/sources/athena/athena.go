@@ -0,0 +1,273 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package athena
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	athenaapi "github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/aws-sdk-go-v2/service/athena/types"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "athena"
+
+const (
+	minPollInterval = 100 * time.Millisecond
+	maxPollInterval = 2 * time.Second
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Workgroup: "primary"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name           string `yaml:"name" validate:"required"`
+	Kind           string `yaml:"kind" validate:"required"`
+	Region         string `yaml:"region"`
+	Workgroup      string `yaml:"workgroup"`
+	OutputLocation string `yaml:"outputLocation"`
+	Catalog        string `yaml:"catalog"`
+	Database       string `yaml:"database"`
+	RoleArn        string `yaml:"roleArn"`
+	ExternalID     string `yaml:"externalId"`
+	// Endpoint overrides the endpoint of the Athena API, e.g. for a VPC
+	// endpoint.
+	Endpoint string `yaml:"endpoint"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initAthenaClient(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	s := &Source{
+		Name:           r.Name,
+		Kind:           SourceKind,
+		Client:         client,
+		Workgroup:      r.Workgroup,
+		OutputLocation: r.OutputLocation,
+		Catalog:        r.Catalog,
+		Database:       r.Database,
+	}
+
+	err = s.CheckHealth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name           string `yaml:"name"`
+	Kind           string `yaml:"kind"`
+	Client         *athenaapi.Client
+	Workgroup      string
+	OutputLocation string
+	Catalog        string
+	Database       string
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) AthenaClient() *athenaapi.Client {
+	return s.Client
+}
+
+// CheckHealth gets the workgroup, which verifies the credentials of the
+// source.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	_, err := s.Client.GetWorkGroup(ctx, &athenaapi.GetWorkGroupInput{WorkGroup: aws.String(s.Workgroup)})
+	return err
+}
+
+// RunQuery runs a query and returns its rows. Athena runs queries
+// asynchronously, so the query is polled until it completes, and is stopped if
+// the context is done first. The params are SQL literals that replace the `?`
+// placeholders of the query.
+func (s *Source) RunQuery(ctx context.Context, query string, params []string) ([]any, error) {
+	input := &athenaapi.StartQueryExecutionInput{
+		QueryString: aws.String(query),
+		WorkGroup:   aws.String(s.Workgroup),
+	}
+	if len(params) > 0 {
+		input.ExecutionParameters = params
+	}
+	if s.Catalog != "" || s.Database != "" {
+		input.QueryExecutionContext = &types.QueryExecutionContext{}
+		if s.Catalog != "" {
+			input.QueryExecutionContext.Catalog = aws.String(s.Catalog)
+		}
+		if s.Database != "" {
+			input.QueryExecutionContext.Database = aws.String(s.Database)
+		}
+	}
+	if s.OutputLocation != "" {
+		input.ResultConfiguration = &types.ResultConfiguration{OutputLocation: aws.String(s.OutputLocation)}
+	}
+
+	start, err := s.Client.StartQueryExecution(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start query: %w", err)
+	}
+	id := start.QueryExecutionId
+
+	if err := s.waitForQuery(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.queryResults(ctx, id)
+}
+
+// waitForQuery polls a query until it succeeds.
+func (s *Source) waitForQuery(ctx context.Context, id *string) error {
+	interval := minPollInterval
+	for {
+		out, err := s.Client.GetQueryExecution(ctx, &athenaapi.GetQueryExecutionInput{QueryExecutionId: id})
+		if err != nil {
+			return fmt.Errorf("unable to get status of query %q: %w", aws.ToString(id), err)
+		}
+		status := out.QueryExecution.Status
+		switch status.State {
+		case types.QueryExecutionStateSucceeded:
+			return nil
+		case types.QueryExecutionStateFailed, types.QueryExecutionStateCancelled:
+			return fmt.Errorf("query %q %s: %s", aws.ToString(id), status.State, aws.ToString(status.StateChangeReason))
+		}
+
+		select {
+		case <-ctx.Done():
+			// the query keeps running unless it is stopped, so stop it with a
+			// context that isn't done
+			stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+			defer cancel()
+			_, _ = s.Client.StopQueryExecution(stopCtx, &athenaapi.StopQueryExecutionInput{QueryExecutionId: id})
+			return fmt.Errorf("query %q was stopped: %w", aws.ToString(id), ctx.Err())
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxPollInterval)
+	}
+}
+
+// queryResults returns the rows of a query that succeeded.
+func (s *Source) queryResults(ctx context.Context, id *string) ([]any, error) {
+	var out []any
+	var columns []types.ColumnInfo
+	paginator := athenaapi.NewGetQueryResultsPaginator(s.Client, &athenaapi.GetQueryResultsInput{QueryExecutionId: id})
+	for first := true; paginator.HasMorePages(); first = false {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get results of query %q: %w", aws.ToString(id), err)
+		}
+		rows := page.ResultSet.Rows
+		if first {
+			columns = page.ResultSet.ResultSetMetadata.ColumnInfo
+			// the results of SELECT queries start with a row of column names
+			if len(rows) > 0 && isHeader(rows[0], columns) {
+				rows = rows[1:]
+			}
+		}
+		for _, row := range rows {
+			vMap := make(map[string]any, len(columns))
+			for i, col := range columns {
+				if i >= len(row.Data) {
+					break
+				}
+				v, err := parseValue(row.Data[i].VarCharValue, aws.ToString(col.Type))
+				if err != nil {
+					return nil, fmt.Errorf("unable to parse column %q: %w", aws.ToString(col.Name), err)
+				}
+				vMap[aws.ToString(col.Name)] = v
+			}
+			out = append(out, vMap)
+		}
+	}
+	return out, nil
+}
+
+func isHeader(row types.Row, columns []types.ColumnInfo) bool {
+	if len(row.Data) != len(columns) {
+		return false
+	}
+	for i, col := range columns {
+		if aws.ToString(row.Data[i].VarCharValue) != aws.ToString(col.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseValue converts a value of the results, which are all strings, to the
+// type of its column.
+func parseValue(v *string, colType string) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch colType {
+	case "boolean":
+		return strconv.ParseBool(*v)
+	case "tinyint", "smallint", "integer", "bigint":
+		return strconv.ParseInt(*v, 10, 64)
+	case "real", "float", "double":
+		return strconv.ParseFloat(*v, 64)
+	default:
+		return *v, nil
+	}
+}
+
+func initAthenaClient(ctx context.Context, tracer trace.Tracer, r Config) (*athenaapi.Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	cfg, err := sources.GetAWSConfig(ctx, r.Region, r.RoleArn, r.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := athenaapi.NewFromConfig(cfg, func(o *athenaapi.Options) {
+		if r.Endpoint != "" {
+			o.BaseEndpoint = aws.String(r.Endpoint)
+		}
+	})
+	return client, nil
+}
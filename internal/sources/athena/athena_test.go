@@ -0,0 +1,277 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package athena_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/athena"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlAthena(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-athena:
+					kind: athena
+					region: us-east-1
+			`,
+			want: server.SourceConfigs{
+				"my-athena": athena.Config{
+					Name:      "my-athena",
+					Kind:      athena.SourceKind,
+					Region:    "us-east-1",
+					Workgroup: "primary",
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			sources:
+				my-athena:
+					kind: athena
+					region: eu-west-1
+					workgroup: analytics
+					outputLocation: s3://my-bucket/results/
+					catalog: AwsDataCatalog
+					database: sales
+					roleArn: arn:aws:iam::123456789012:role/athena-reader
+					externalId: my-external-id
+					endpoint: https://athena.example.com
+			`,
+			want: server.SourceConfigs{
+				"my-athena": athena.Config{
+					Name:           "my-athena",
+					Kind:           athena.SourceKind,
+					Region:         "eu-west-1",
+					Workgroup:      "analytics",
+					OutputLocation: "s3://my-bucket/results/",
+					Catalog:        "AwsDataCatalog",
+					Database:       "sales",
+					RoleArn:        "arn:aws:iam::123456789012:role/athena-reader",
+					ExternalID:     "my-external-id",
+					Endpoint:       "https://athena.example.com",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// fakeAthena serves the operations of the Athena API used by the source.
+type fakeAthena struct {
+	mu sync.Mutex
+	// states are the states returned by GetQueryExecution, in order
+	states []string
+	reason string
+	// pages are the pages of rows returned by GetQueryResults
+	pages   [][][]any
+	columns []map[string]string
+	started map[string]any
+	stopped bool
+}
+
+func (f *fakeAthena) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var req map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var resp any
+	switch op := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonAthena."); op {
+	case "GetWorkGroup":
+		resp = map[string]any{"WorkGroup": map[string]any{"Name": req["WorkGroup"]}}
+	case "StartQueryExecution":
+		f.started = req
+		resp = map[string]any{"QueryExecutionId": "query-1"}
+	case "GetQueryExecution":
+		state := f.states[0]
+		if len(f.states) > 1 {
+			f.states = f.states[1:]
+		}
+		resp = map[string]any{"QueryExecution": map[string]any{
+			"QueryExecutionId": "query-1",
+			"Status":           map[string]any{"State": state, "StateChangeReason": f.reason},
+		}}
+	case "GetQueryResults":
+		page := 0
+		if token, ok := req["NextToken"].(string); ok {
+			page = int(token[0] - '0')
+		}
+		var rows []any
+		for _, row := range f.pages[page] {
+			var data []any
+			for _, v := range row {
+				if v == nil {
+					data = append(data, map[string]any{})
+					continue
+				}
+				data = append(data, map[string]any{"VarCharValue": v})
+			}
+			rows = append(rows, map[string]any{"Data": data})
+		}
+		out := map[string]any{"ResultSet": map[string]any{
+			"Rows":              rows,
+			"ResultSetMetadata": map[string]any{"ColumnInfo": f.columns},
+		}}
+		if page+1 < len(f.pages) {
+			out["NextToken"] = string(rune('0' + page + 1))
+		}
+		resp = out
+	case "StopQueryExecution":
+		f.stopped = true
+		resp = map[string]any{}
+	default:
+		http.Error(w, "unexpected operation "+op, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newSource(t *testing.T, f *fakeAthena) *athena.Source {
+	t.Setenv("AWS_ACCESS_KEY_ID", "my-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "my-secret")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+	ts := httptest.NewServer(f)
+	t.Cleanup(ts.Close)
+
+	cfg := athena.Config{
+		Name:           "my-athena",
+		Kind:           athena.SourceKind,
+		Region:         "us-east-1",
+		Workgroup:      "primary",
+		OutputLocation: "s3://my-bucket/results/",
+		Database:       "sales",
+		Endpoint:       ts.URL,
+	}
+	s, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	return s.(*athena.Source)
+}
+
+func TestRunQuery(t *testing.T) {
+	f := &fakeAthena{
+		states: []string{"QUEUED", "RUNNING", "SUCCEEDED"},
+		columns: []map[string]string{
+			{"Name": "name", "Type": "varchar"},
+			{"Name": "total", "Type": "bigint"},
+			{"Name": "ratio", "Type": "double"},
+			{"Name": "active", "Type": "boolean"},
+		},
+		pages: [][][]any{
+			{{"name", "total", "ratio", "active"}, {"apple", "3", "0.5", "true"}},
+			{{"pear", nil, "1.25", "false"}},
+		},
+	}
+	s := newSource(t, f)
+
+	got, err := s.RunQuery(context.Background(), "SELECT * FROM fruits WHERE name = ?", []string{"'apple'"})
+	if err != nil {
+		t.Fatalf("unable to run query: %s", err)
+	}
+	want := []any{
+		map[string]any{"name": "apple", "total": int64(3), "ratio": 0.5, "active": true},
+		map[string]any{"name": "pear", "total": nil, "ratio": 1.25, "active": false},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	wantStarted := map[string]any{
+		"QueryString":           "SELECT * FROM fruits WHERE name = ?",
+		"WorkGroup":             "primary",
+		"ExecutionParameters":   []any{"'apple'"},
+		"QueryExecutionContext": map[string]any{"Database": "sales"},
+		"ResultConfiguration":   map[string]any{"OutputLocation": "s3://my-bucket/results/"},
+	}
+	// the client token is random
+	delete(f.started, "ClientRequestToken")
+	if diff := cmp.Diff(wantStarted, f.started); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+}
+
+func TestRunQueryFailed(t *testing.T) {
+	f := &fakeAthena{
+		states: []string{"RUNNING", "FAILED"},
+		reason: "TABLE_NOT_FOUND: Table 'fruits' does not exist",
+	}
+	s := newSource(t, f)
+
+	_, err := s.RunQuery(context.Background(), "SELECT * FROM fruits", nil)
+	want := `query "query-1" FAILED: TABLE_NOT_FOUND: Table 'fruits' does not exist`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
+
+func TestRunQueryStopped(t *testing.T) {
+	f := &fakeAthena{states: []string{"RUNNING"}}
+	s := newSource(t, f)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err := s.RunQuery(ctx, "SELECT * FROM fruits", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.stopped {
+		t.Fatalf("query wasn't stopped")
+	}
+}
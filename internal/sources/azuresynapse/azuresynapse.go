@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuresynapse
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/microsoft/go-mssqldb/azuread"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "azure-synapse"
+
+// Methods of authenticating with Microsoft Entra ID.
+const (
+	AuthMethodDefault          = "default"
+	AuthMethodServicePrincipal = "servicePrincipal"
+	AuthMethodManagedIdentity  = "managedIdentity"
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Port: "1433", AuthMethod: AuthMethodDefault}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string `yaml:"name" validate:"required"`
+	Kind         string `yaml:"kind" validate:"required"`
+	Host         string `yaml:"host" validate:"required"`
+	Port         string `yaml:"port"`
+	Database     string `yaml:"database" validate:"required"`
+	AuthMethod   string `yaml:"authMethod"`
+	TenantID     string `yaml:"tenantId"`
+	ClientID     string `yaml:"clientId"`
+	ClientSecret string `yaml:"clientSecret"`
+
+	sources.PoolConfig `yaml:",inline"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	dsn, err := r.dsn()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := initAzureSynapseConnection(ctx, tracer, r.Name, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+	if err := r.ConfigureDB(db); err != nil {
+		return nil, err
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Db:   db,
+	}
+	return s, nil
+}
+
+// dsn returns the connection string of the source, which sets the workflow
+// the driver uses to get tokens from Microsoft Entra ID.
+func (r Config) dsn() (string, error) {
+	query := url.Values{}
+	query.Add("database", r.Database)
+	// Synapse and Fabric only accept encrypted connections
+	query.Add("encrypt", "true")
+
+	var user *url.Userinfo
+	switch r.AuthMethod {
+	case AuthMethodDefault:
+		if r.ClientID != "" || r.ClientSecret != "" {
+			return "", fmt.Errorf("clientId and clientSecret can't be set with authMethod %q", r.AuthMethod)
+		}
+		query.Add("fedauth", azuread.ActiveDirectoryDefault)
+	case AuthMethodServicePrincipal:
+		if r.ClientID == "" || r.ClientSecret == "" {
+			return "", fmt.Errorf("clientId and clientSecret must be set with authMethod %q", r.AuthMethod)
+		}
+		query.Add("fedauth", azuread.ActiveDirectoryServicePrincipal)
+		clientID := r.ClientID
+		if r.TenantID != "" {
+			clientID = fmt.Sprintf("%s@%s", r.ClientID, r.TenantID)
+		}
+		user = url.UserPassword(clientID, r.ClientSecret)
+	case AuthMethodManagedIdentity:
+		if r.ClientSecret != "" {
+			return "", fmt.Errorf("clientSecret can't be set with authMethod %q", r.AuthMethod)
+		}
+		query.Add("fedauth", azuread.ActiveDirectoryManagedIdentity)
+		// the client ID selects a user-assigned identity
+		if r.ClientID != "" {
+			user = url.User(r.ClientID)
+		}
+	default:
+		return "", fmt.Errorf("invalid authMethod %q", r.AuthMethod)
+	}
+
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		User:     user,
+		Host:     fmt.Sprintf("%s:%s", r.Host, r.Port),
+		RawQuery: query.Encode(),
+	}
+	return u.String(), nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Db   *sql.DB
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) MSSQLDB() *sql.DB {
+	return s.Db
+}
+
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
+}
+
+func initAzureSynapseConnection(ctx context.Context, tracer trace.Tracer, name, dsn string) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	// the azuread driver gets a token for each new connection
+	db, err := sql.Open(azuread.DriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("sql.Open: %w", err)
+	}
+	return db, nil
+}
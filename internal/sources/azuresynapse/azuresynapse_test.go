@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azuresynapse_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresynapse"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlAzureSynapse(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-synapse:
+					kind: azure-synapse
+					host: my-workspace-ondemand.sql.azuresynapse.net
+					database: my_db
+			`,
+			want: server.SourceConfigs{
+				"my-synapse": azuresynapse.Config{
+					Name:       "my-synapse",
+					Kind:       azuresynapse.SourceKind,
+					Host:       "my-workspace-ondemand.sql.azuresynapse.net",
+					Port:       "1433",
+					Database:   "my_db",
+					AuthMethod: azuresynapse.AuthMethodDefault,
+				},
+			},
+		},
+		{
+			desc: "service principal",
+			in: `
+			sources:
+				my-fabric:
+					kind: azure-synapse
+					host: abc.datawarehouse.fabric.microsoft.com
+					port: "1434"
+					database: my_warehouse
+					authMethod: servicePrincipal
+					tenantId: my-tenant
+					clientId: my-client
+					clientSecret: my-secret
+			`,
+			want: server.SourceConfigs{
+				"my-fabric": azuresynapse.Config{
+					Name:         "my-fabric",
+					Kind:         azuresynapse.SourceKind,
+					Host:         "abc.datawarehouse.fabric.microsoft.com",
+					Port:         "1434",
+					Database:     "my_warehouse",
+					AuthMethod:   azuresynapse.AuthMethodServicePrincipal,
+					TenantID:     "my-tenant",
+					ClientID:     "my-client",
+					ClientSecret: "my-secret",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYamlAzureSynapse(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-synapse:
+					kind: azure-synapse
+					host: my-workspace-ondemand.sql.azuresynapse.net
+			`,
+			err: "unable to parse source \"my-synapse\" as \"azure-synapse\": Key: 'Config.Database' Error:Field validation for 'Database' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidAuthAzureSynapse(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  azuresynapse.Config
+		err  string
+	}{
+		{
+			desc: "unknown auth method",
+			cfg:  azuresynapse.Config{AuthMethod: "password"},
+			err:  `invalid authMethod "password"`,
+		},
+		{
+			desc: "default with client secret",
+			cfg:  azuresynapse.Config{AuthMethod: azuresynapse.AuthMethodDefault, ClientID: "my-client", ClientSecret: "my-secret"},
+			err:  `clientId and clientSecret can't be set with authMethod "default"`,
+		},
+		{
+			desc: "service principal without secret",
+			cfg:  azuresynapse.Config{AuthMethod: azuresynapse.AuthMethodServicePrincipal, ClientID: "my-client"},
+			err:  `clientId and clientSecret must be set with authMethod "servicePrincipal"`,
+		},
+		{
+			desc: "managed identity with client secret",
+			cfg:  azuresynapse.Config{AuthMethod: azuresynapse.AuthMethodManagedIdentity, ClientSecret: "my-secret"},
+			err:  `clientSecret can't be set with authMethod "managedIdentity"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Name = "my-synapse"
+			cfg.Kind = azuresynapse.SourceKind
+			cfg.Host = "my-workspace-ondemand.sql.azuresynapse.net"
+			cfg.Port = "1433"
+			cfg.Database = "my_db"
+			_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+			if err == nil {
+				t.Fatalf("expect error")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
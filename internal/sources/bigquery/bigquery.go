@@ -17,13 +17,13 @@ package bigquery
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	bigqueryapi "cloud.google.com/go/bigquery"
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 )
 
@@ -52,6 +52,12 @@ type Config struct {
 	Kind     string `yaml:"kind" validate:"required"`
 	Project  string `yaml:"project" validate:"required"`
 	Location string `yaml:"location"`
+	// Credentials configs, which default to the Application Default Credentials
+	CredentialsFile           string `yaml:"credentialsFile"`
+	ImpersonateServiceAccount string `yaml:"impersonateServiceAccount"`
+	// ClientOAuthService is the name of the auth service whose verified OAuth
+	// access token of the caller is used instead, to query as the caller.
+	ClientOAuthService string `yaml:"clientOAuthService"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -59,9 +65,18 @@ func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+var _ sources.ClientOAuthConfig = Config{}
+
+func (r Config) GetClientOAuthService() string {
+	return r.ClientOAuthService
+}
+
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	// Initializes a BigQuery Google SQL source
-	client, err := initBigQueryConnection(ctx, tracer, r.Name, r.Project, r.Location)
+	if r.ClientOAuthService != "" && (r.CredentialsFile != "" || r.ImpersonateServiceAccount != "") {
+		return nil, fmt.Errorf("clientOAuthService can't be combined with credentialsFile or impersonateServiceAccount")
+	}
+	client, err := initBigQueryConnection(ctx, tracer, r.Name, r.Project, r.Location, r.CredentialsFile, r.ImpersonateServiceAccount, r.ClientOAuthService)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +98,9 @@ type Source struct {
 	Kind     string `yaml:"kind"`
 	Client   *bigqueryapi.Client
 	Location string `yaml:"location"`
+	// Credentials configs, which default to the Application Default Credentials
+	CredentialsFile           string `yaml:"credentialsFile"`
+	ImpersonateServiceAccount string `yaml:"impersonateServiceAccount"`
 }
 
 func (s *Source) SourceKind() string {
@@ -100,21 +118,33 @@ func initBigQueryConnection(
 	name string,
 	project string,
 	location string,
+	credentialsFile string,
+	impersonateServiceAccount string,
+	clientOAuthService string,
 ) (*bigqueryapi.Client, error) {
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
-	cred, err := google.FindDefaultCredentials(ctx, bigqueryapi.Scope)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find default Google Cloud credentials with scope %q: %w", bigqueryapi.Scope, err)
-	}
-
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	opts := []option.ClientOption{option.WithUserAgent(userAgent)}
+
+	if clientOAuthService != "" {
+		// every request is authorized with the access token of the caller
+		opts = append(opts, option.WithHTTPClient(&http.Client{
+			Transport: sources.ClientOAuthTransport(http.DefaultTransport, clientOAuthService),
+		}))
+	} else {
+		cred, err := sources.GetGoogleCredentials(ctx, credentialsFile, impersonateServiceAccount, bigqueryapi.Scope)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, option.WithCredentials(cred))
+	}
 
-	client, err := bigqueryapi.NewClient(ctx, project, option.WithUserAgent(userAgent), option.WithCredentials(cred))
+	client, err := bigqueryapi.NewClient(ctx, project, opts...)
 	client.Location = location
 	if err != nil {
 		return nil, fmt.Errorf("failed to create BigQuery client for project %q: %w", project, err)
@@ -48,6 +48,44 @@ func TestParseFromYamlBigQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "impersonated service account",
+			in: `
+			sources:
+				my-instance:
+					kind: bigquery
+					project: my-project
+					credentialsFile: /etc/toolbox/wif.json
+					impersonateServiceAccount: toolbox@my-project.iam.gserviceaccount.com
+			`,
+			want: server.SourceConfigs{
+				"my-instance": bigquery.Config{
+					Name:                      "my-instance",
+					Kind:                      bigquery.SourceKind,
+					Project:                   "my-project",
+					CredentialsFile:           "/etc/toolbox/wif.json",
+					ImpersonateServiceAccount: "toolbox@my-project.iam.gserviceaccount.com",
+				},
+			},
+		},
+		{
+			desc: "client oauth",
+			in: `
+			sources:
+				my-instance:
+					kind: bigquery
+					project: my-project
+					clientOAuthService: my-google-auth
+			`,
+			want: server.SourceConfigs{
+				"my-instance": bigquery.Config{
+					Name:               "my-instance",
+					Kind:               bigquery.SourceKind,
+					Project:            "my-project",
+					ClientOAuthService: "my-google-auth",
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
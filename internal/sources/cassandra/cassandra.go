@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cassandra
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	gocql "github.com/apache/cassandra-gocql-driver/v2"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "cassandra"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Port: 9042, Consistency: "quorum", Timeout: "11s"} // Driver defaults
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                   string   `yaml:"name" validate:"required"`
+	Kind                   string   `yaml:"kind" validate:"required"`
+	Hosts                  []string `yaml:"hosts" validate:"required"`
+	Port                   int      `yaml:"port"`
+	Keyspace               string   `yaml:"keyspace"`
+	Username               string   `yaml:"username"`
+	Password               string   `yaml:"password"`
+	TLS                    bool     `yaml:"tls"`
+	CACert                 string   `yaml:"caCert"`
+	DisableSslVerification bool     `yaml:"disableSslVerification"`
+	ProtoVersion           int      `yaml:"protoVersion"`
+	Consistency            string   `yaml:"consistency"`
+	Timeout                string   `yaml:"timeout"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	cluster, err := r.clusterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := initCassandraSession(ctx, tracer, r.Name, cluster)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create session: %w", err)
+	}
+
+	s := &Source{
+		Name:    r.Name,
+		Kind:    SourceKind,
+		Session: session,
+	}
+	if err := s.CheckHealth(ctx); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+// clusterConfig returns the configuration of the cluster, which is validated
+// before any connection is made.
+func (r Config) clusterConfig() (*gocql.ClusterConfig, error) {
+	consistency, err := gocql.ParseConsistencyWrapper(r.Consistency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid consistency %q: %w", r.Consistency, err)
+	}
+	timeout, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+
+	cluster := gocql.NewCluster(r.Hosts...)
+	cluster.Port = r.Port
+	cluster.Keyspace = r.Keyspace
+	cluster.ProtoVersion = r.ProtoVersion
+	cluster.Consistency = consistency
+	cluster.Timeout = timeout
+	cluster.ConnectTimeout = timeout
+	if r.Username != "" {
+		cluster.Authenticator = gocql.PasswordAuthenticator{
+			Username: r.Username,
+			Password: r.Password,
+		}
+	}
+	if r.TLS || r.CACert != "" || r.DisableSslVerification {
+		tlsConfig := &tls.Config{InsecureSkipVerify: r.DisableSslVerification}
+		if r.CACert != "" {
+			caCert, err := os.ReadFile(r.CACert)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read caCert: %w", err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("caCert %q contains no PEM certificates", r.CACert)
+			}
+		}
+		cluster.SslOpts = &gocql.SslOptions{
+			Config:                 tlsConfig,
+			EnableHostVerification: !r.DisableSslVerification,
+		}
+	}
+	return cluster, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name    string `yaml:"name"`
+	Kind    string `yaml:"kind"`
+	Session *gocql.Session
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) CassandraSession() *gocql.Session {
+	return s.Session
+}
+
+// CheckHealth reads the version of the coordinator node.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Session.Query("SELECT release_version FROM system.local").ExecContext(ctx)
+}
+
+func initCassandraSession(ctx context.Context, tracer trace.Tracer, name string, cluster *gocql.ClusterConfig) (*gocql.Session, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	return cluster.CreateSession()
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/cassandra"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlCassandra(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-cassandra:
+					kind: cassandra
+					hosts:
+						- 127.0.0.1
+					keyspace: my_keyspace
+			`,
+			want: server.SourceConfigs{
+				"my-cassandra": cassandra.Config{
+					Name:        "my-cassandra",
+					Kind:        cassandra.SourceKind,
+					Hosts:       []string{"127.0.0.1"},
+					Port:        9042,
+					Keyspace:    "my_keyspace",
+					Consistency: "quorum",
+					Timeout:     "11s",
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			sources:
+				my-cassandra:
+					kind: cassandra
+					hosts:
+						- 10.0.0.1
+						- 10.0.0.2
+					port: 9142
+					keyspace: my_keyspace
+					username: my_user
+					password: my_pass
+					tls: true
+					caCert: /path/to/ca.pem
+					disableSslVerification: true
+					protoVersion: 4
+					consistency: local_quorum
+					timeout: 5s
+			`,
+			want: server.SourceConfigs{
+				"my-cassandra": cassandra.Config{
+					Name:                   "my-cassandra",
+					Kind:                   cassandra.SourceKind,
+					Hosts:                  []string{"10.0.0.1", "10.0.0.2"},
+					Port:                   9142,
+					Keyspace:               "my_keyspace",
+					Username:               "my_user",
+					Password:               "my_pass",
+					TLS:                    true,
+					CACert:                 "/path/to/ca.pem",
+					DisableSslVerification: true,
+					ProtoVersion:           4,
+					Consistency:            "local_quorum",
+					Timeout:                "5s",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeCassandraInvalidConfig(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  cassandra.Config
+		err  string
+	}{
+		{
+			desc: "invalid consistency",
+			cfg:  cassandra.Config{Consistency: "most", Timeout: "11s"},
+			err:  `invalid consistency "most"`,
+		},
+		{
+			desc: "invalid timeout",
+			cfg:  cassandra.Config{Consistency: "one", Timeout: "soon"},
+			err:  "unable to parse Timeout",
+		},
+		{
+			desc: "missing caCert",
+			cfg:  cassandra.Config{Consistency: "one", Timeout: "11s", CACert: "/does/not/exist.pem"},
+			err:  "unable to read caCert",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.cfg.Name = "my-cassandra"
+			tc.cfg.Kind = cassandra.SourceKind
+			tc.cfg.Hosts = []string{"127.0.0.1"}
+			_, err := tc.cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
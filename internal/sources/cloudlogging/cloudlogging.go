@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cloudlogging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	logging "google.golang.org/api/logging/v2"
+	"google.golang.org/api/option"
+)
+
+const SourceKind string = "cloud-logging"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name    string `yaml:"name" validate:"required"`
+	Kind    string `yaml:"kind" validate:"required"`
+	Project string `yaml:"project" validate:"required"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	service, err := initCloudLoggingService(ctx, tracer, r.Name, r.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		Name:    r.Name,
+		Kind:    SourceKind,
+		Project: r.Project,
+		Service: service,
+	}
+	if err := s.CheckHealth(ctx); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name    string `yaml:"name"`
+	Kind    string `yaml:"kind"`
+	Project string `yaml:"project"`
+	Service *logging.Service
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) LoggingService() *logging.Service {
+	return s.Service
+}
+
+// CheckHealth lists a log of the project, which verifies the credentials of
+// the source.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	_, err := s.Service.Projects.Logs.List("projects/" + s.Project).PageSize(1).Context(ctx).Do()
+	return err
+}
+
+func initCloudLoggingService(ctx context.Context, tracer trace.Tracer, name, project string) (*logging.Service, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := logging.NewService(ctx, option.WithScopes(logging.LoggingReadScope), option.WithUserAgent(userAgent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Logging client for project %q: %w", project, err)
+	}
+	return service, nil
+}
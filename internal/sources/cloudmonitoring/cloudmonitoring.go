@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cloudmonitoring
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"go.opentelemetry.io/otel/trace"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+const SourceKind string = "cloud-monitoring"
+
+// prometheusEndpoint is the endpoint of the Prometheus HTTP API of Cloud
+// Monitoring, for a project.
+const prometheusEndpoint = "https://monitoring.googleapis.com/v1/projects/%s/location/global/prometheus"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name    string `yaml:"name" validate:"required"`
+	Kind    string `yaml:"kind" validate:"required"`
+	Project string `yaml:"project" validate:"required"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	service, promAPI, err := initCloudMonitoringClients(ctx, tracer, r.Name, r.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		Name:    r.Name,
+		Kind:    SourceKind,
+		Project: r.Project,
+		Service: service,
+		API:     promAPI,
+	}
+	if err := s.CheckHealth(ctx); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name    string `yaml:"name"`
+	Kind    string `yaml:"kind"`
+	Project string `yaml:"project"`
+	Service *monitoring.Service
+	API     promv1.API
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) MonitoringService() *monitoring.Service {
+	return s.Service
+}
+
+// PrometheusAPI returns the Prometheus HTTP API of the project, which
+// evaluates PromQL queries over the metrics of Cloud Monitoring.
+func (s *Source) PrometheusAPI() promv1.API {
+	return s.API
+}
+
+// CheckHealth lists a metric descriptor, which verifies the credentials of
+// the source.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	_, err := s.Service.Projects.MetricDescriptors.List("projects/" + s.Project).PageSize(1).Context(ctx).Do()
+	return err
+}
+
+func initCloudMonitoringClients(ctx context.Context, tracer trace.Tracer, name, project string) (*monitoring.Service, promv1.API, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpClient, _, err := htransport.NewClient(ctx, option.WithScopes(monitoring.MonitoringReadScope), option.WithUserAgent(userAgent))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create HTTP client with scope %q: %w", monitoring.MonitoringReadScope, err)
+	}
+
+	service, err := monitoring.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Cloud Monitoring client for project %q: %w", project, err)
+	}
+
+	promClient, err := api.NewClient(api.Config{
+		Address: fmt.Sprintf(prometheusEndpoint, project),
+		Client:  httpClient,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Prometheus client for project %q: %w", project, err)
+	}
+	return service, promv1.NewAPI(promClient), nil
+}
@@ -59,6 +59,8 @@ type Config struct {
 	User      string         `yaml:"user" validate:"required"`
 	Password  string         `yaml:"password" validate:"required"`
 	Database  string         `yaml:"database" validate:"required"`
+
+	sources.PoolConfig `yaml:",inline"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -72,6 +74,9 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	if err != nil {
 		return nil, fmt.Errorf("unable to create db connection: %w", err)
 	}
+	if err := r.ConfigureDB(db); err != nil {
+		return nil, err
+	}
 
 	// Verify db connection
 	err = db.PingContext(ctx)
@@ -88,6 +93,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
 
 type Source struct {
 	// Cloud SQL MSSQL struct with connection pool
@@ -106,6 +112,11 @@ func (s *Source) MSSQLDB() *sql.DB {
 	return s.Db
 }
 
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
+}
+
 func initCloudSQLMssqlConnection(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipAddress, ipType, user, pass, dbname string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
@@ -19,6 +19,7 @@ import (
 	"database/sql"
 	"fmt"
 	"slices"
+	"strings"
 
 	"cloud.google.com/go/cloudsqlconn/mysql/mysql"
 	"github.com/goccy/go-yaml"
@@ -53,9 +54,11 @@ type Config struct {
 	Region   string         `yaml:"region" validate:"required"`
 	Instance string         `yaml:"instance" validate:"required"`
 	IPType   sources.IPType `yaml:"ipType" validate:"required"`
-	User     string         `yaml:"user" validate:"required"`
-	Password string         `yaml:"password" validate:"required"`
+	User     string         `yaml:"user"`
+	Password string         `yaml:"password"`
 	Database string         `yaml:"database" validate:"required"`
+
+	sources.PoolConfig `yaml:",inline"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -67,6 +70,9 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
+	if err := r.ConfigureDB(pool); err != nil {
+		return nil, err
+	}
 
 	err = pool.PingContext(ctx)
 	if err != nil {
@@ -82,6 +88,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+var _ sources.SchemaDescriber = &Source{}
 
 type Source struct {
 	Name string `yaml:"name"`
@@ -97,32 +105,90 @@ func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
+}
+
+// ListTables returns the tables of the database.
+func (s *Source) ListTables(ctx context.Context) ([]string, error) {
+	return sources.ListMySQLTables(ctx, s.Pool)
+}
+
+// DescribeTable returns the DDL of a table.
+func (s *Source) DescribeTable(ctx context.Context, table string) (string, error) {
+	return sources.DescribeMySQLTable(ctx, s.Pool, table)
+}
+
+func getConnectionConfig(ctx context.Context, user, pass string) (string, bool, error) {
+	// If username and password both provided, use password authentication
+	if user != "" && pass != "" {
+		return user, false, nil
+	}
+
+	// If username is empty, fetch email from ADC
+	// otherwise, use username as IAM database user
+	if user == "" {
+		if pass != "" {
+			// If password is provided without an username, raise an error
+			return "", true, fmt.Errorf("password is provided without a username. Please provide both a username and password, or leave both fields empty")
+		}
+		email, err := sources.GetIAMPrincipalEmailFromADC(ctx)
+		if err != nil {
+			return "", true, fmt.Errorf("error getting email from ADC: %v", err)
+		}
+		// MySQL IAM database users are named after the email of the
+		// principal without its domain, which would exceed the length of a
+		// MySQL user name
+		user, _, _ = strings.Cut(email, "@")
+	}
+	return user, true, nil
+}
+
 func initCloudSQLMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
+	user, useIAM, err := getConnectionConfig(ctx, user, pass)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get Cloud SQL connection config: %w", err)
+	}
+
 	// Create a new dialer with options
 	userAgent, err := util.UserAgentFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	opts, err := sources.GetCloudSQLOpts(ipType, userAgent, false)
+	opts, err := sources.GetCloudSQLOpts(ipType, userAgent, useIAM)
 	if err != nil {
 		return nil, err
 	}
 
-	if !slices.Contains(sql.Drivers(), "cloudsql-mysql") {
-		_, err = mysql.RegisterDriver("cloudsql-mysql", opts...)
+	// A driver is registered once with the options of its dialer, so sources
+	// with different options need different drivers. With IAM database
+	// authentication, the dialer refreshes the OAuth2 token of the principal
+	// and logs in new connections with it, so that pools can live longer
+	// than a token.
+	driver := fmt.Sprintf("cloudsql-mysql-%s", strings.ToLower(ipType))
+	if useIAM {
+		driver += "-iam"
+	}
+	if !slices.Contains(sql.Drivers(), driver) {
+		_, err = mysql.RegisterDriver(driver, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("unable to register driver: %w", err)
 		}
 	}
 
 	// Tell the driver to use the Cloud SQL Go Connector to create connections
-	dsn := fmt.Sprintf("%s:%s@cloudsql-mysql(%s:%s:%s)/%s", user, pass, project, region, instance, dbname)
+	userInfo := user
+	if !useIAM {
+		userInfo = fmt.Sprintf("%s:%s", user, pass)
+	}
+	dsn := fmt.Sprintf("%s@%s(%s:%s:%s)/%s", userInfo, driver, project, region, instance, dbname)
 	db, err := sql.Open(
-		"cloudsql-mysql",
+		driver,
 		dsn,
 	)
 	if err != nil {
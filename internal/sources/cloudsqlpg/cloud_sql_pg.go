@@ -56,6 +56,8 @@ type Config struct {
 	Database string         `yaml:"database" validate:"required"`
 	User     string         `yaml:"user"`
 	Password string         `yaml:"password"`
+
+	sources.PoolConfig `yaml:",inline"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -63,7 +65,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initCloudSQLPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database)
+	pool, err := initCloudSQLPgConnectionPool(ctx, tracer, r.Name, r.Project, r.Region, r.Instance, r.IPType.String(), r.User, r.Password, r.Database, r.PoolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -82,6 +84,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+var _ sources.SchemaDescriber = &Source{}
 
 type Source struct {
 	Name string `yaml:"name"`
@@ -97,6 +101,21 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
+}
+
+// ListTables returns the tables of the database.
+func (s *Source) ListTables(ctx context.Context) ([]string, error) {
+	return sources.ListPostgresTables(ctx, s.Pool)
+}
+
+// DescribeTable returns the DDL of a table.
+func (s *Source) DescribeTable(ctx context.Context, table string) (string, error) {
+	return sources.DescribePostgresTable(ctx, s.Pool, table)
+}
+
 func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string, bool, error) {
 	useIAM := true
 
@@ -126,7 +145,7 @@ func getConnectionConfig(ctx context.Context, user, pass, dbname string) (string
 	return dsn, useIAM, nil
 }
 
-func initCloudSQLPgConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string) (*pgxpool.Pool, error) {
+func initCloudSQLPgConnectionPool(ctx context.Context, tracer trace.Tracer, name, project, region, instance, ipType, user, pass, dbname string, poolConfig sources.PoolConfig) (*pgxpool.Pool, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
@@ -141,6 +160,9 @@ func initCloudSQLPgConnectionPool(ctx context.Context, tracer trace.Tracer, name
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
 	}
+	if err := poolConfig.ConfigurePgxPool(config); err != nil {
+		return nil, err
+	}
 
 	// Create a new dialer with options
 	userAgent, err := util.UserAgentFromContext(ctx)
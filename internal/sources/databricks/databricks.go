@@ -0,0 +1,151 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package databricks
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	dbsql "github.com/databricks/databricks-sql-go"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "databricks"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string `yaml:"name" validate:"required"`
+	Kind         string `yaml:"kind" validate:"required"`
+	Host         string `yaml:"host" validate:"required"`
+	HTTPPath     string `yaml:"httpPath" validate:"required"`
+	AccessToken  string `yaml:"accessToken"`
+	ClientID     string `yaml:"clientId"`
+	ClientSecret string `yaml:"clientSecret"`
+	Catalog      string `yaml:"catalog"`
+	Schema       string `yaml:"schema"`
+	QueryTimeout string `yaml:"queryTimeout"`
+
+	sources.PoolConfig `yaml:",inline"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	pool, err := initDatabricksConnectionPool(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create pool: %w", err)
+	}
+	if err := r.ConfigureDB(pool); err != nil {
+		return nil, err
+	}
+
+	err = pool.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Pool: pool,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Pool *sql.DB
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) DatabricksPool() *sql.DB {
+	return s.Pool
+}
+
+// CheckHealth pings the SQL warehouse.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
+}
+
+func initDatabricksConnectionPool(ctx context.Context, tracer trace.Tracer, r Config) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	// the host must be set first, since the OAuth authenticator depends on it
+	opts := []dbsql.ConnOption{
+		dbsql.WithServerHostname(r.Host),
+		dbsql.WithPort(443),
+		dbsql.WithHTTPPath(r.HTTPPath),
+	}
+	switch {
+	case r.AccessToken != "" && r.ClientID != "":
+		return nil, fmt.Errorf("only one of accessToken or clientId can be set")
+	case r.AccessToken != "":
+		// authenticate with a personal access token
+		opts = append(opts, dbsql.WithAccessToken(r.AccessToken))
+	case r.ClientID != "" && r.ClientSecret != "":
+		// authenticate with OAuth machine-to-machine, as a service principal
+		opts = append(opts, dbsql.WithClientCredentials(r.ClientID, r.ClientSecret))
+	case r.ClientID != "":
+		return nil, fmt.Errorf("clientSecret must be set with clientId")
+	default:
+		return nil, fmt.Errorf("one of accessToken or clientId must be set")
+	}
+	if r.Catalog != "" || r.Schema != "" {
+		opts = append(opts, dbsql.WithInitialNamespace(r.Catalog, r.Schema))
+	}
+	if r.QueryTimeout != "" {
+		timeout, err := time.ParseDuration(r.QueryTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid queryTimeout %q: %w", r.QueryTimeout, err)
+		}
+		opts = append(opts, dbsql.WithTimeout(timeout))
+	}
+
+	connector, err := dbsql.NewConnector(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("dbsql.NewConnector: %w", err)
+	}
+	return sql.OpenDB(connector), nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databricks_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/databricks"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlDatabricks(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "access token",
+			in: `
+			sources:
+				my-databricks-instance:
+					kind: databricks
+					host: dbc-a1b2c3d4-e5f6.cloud.databricks.com
+					httpPath: /sql/1.0/warehouses/a1b2c3d4e5f6a7b8
+					accessToken: my-token
+			`,
+			want: server.SourceConfigs{
+				"my-databricks-instance": databricks.Config{
+					Name:        "my-databricks-instance",
+					Kind:        databricks.SourceKind,
+					Host:        "dbc-a1b2c3d4-e5f6.cloud.databricks.com",
+					HTTPPath:    "/sql/1.0/warehouses/a1b2c3d4e5f6a7b8",
+					AccessToken: "my-token",
+				},
+			},
+		},
+		{
+			desc: "oauth m2m",
+			in: `
+			sources:
+				my-databricks-instance:
+					kind: databricks
+					host: dbc-a1b2c3d4-e5f6.cloud.databricks.com
+					httpPath: /sql/1.0/warehouses/a1b2c3d4e5f6a7b8
+					clientId: my-client-id
+					clientSecret: my-client-secret
+					catalog: main
+					schema: sales
+					queryTimeout: 45s
+			`,
+			want: server.SourceConfigs{
+				"my-databricks-instance": databricks.Config{
+					Name:         "my-databricks-instance",
+					Kind:         databricks.SourceKind,
+					Host:         "dbc-a1b2c3d4-e5f6.cloud.databricks.com",
+					HTTPPath:     "/sql/1.0/warehouses/a1b2c3d4e5f6a7b8",
+					ClientID:     "my-client-id",
+					ClientSecret: "my-client-secret",
+					Catalog:      "main",
+					Schema:       "sales",
+					QueryTimeout: "45s",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	in := `
+	sources:
+		my-databricks-instance:
+			kind: databricks
+			host: dbc-a1b2c3d4-e5f6.cloud.databricks.com
+			accessToken: my-token
+	`
+	got := struct {
+		Sources server.SourceConfigs `yaml:"sources"`
+	}{}
+	err := yaml.Unmarshal(testutils.FormatYaml(in), &got)
+	if err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+	want := "unable to parse source \"my-databricks-instance\" as \"databricks\": Key: 'Config.HTTPPath' Error:Field validation for 'HTTPPath' failed on the 'required' tag"
+	if err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	base := databricks.Config{
+		Name:     "my-databricks-instance",
+		Kind:     databricks.SourceKind,
+		Host:     "dbc-a1b2c3d4-e5f6.cloud.databricks.com",
+		HTTPPath: "/sql/1.0/warehouses/a1b2c3d4e5f6a7b8",
+	}
+	tcs := []struct {
+		desc   string
+		modify func(*databricks.Config)
+		want   string
+	}{
+		{
+			desc:   "no credentials",
+			modify: func(c *databricks.Config) {},
+			want:   "unable to create pool: one of accessToken or clientId must be set",
+		},
+		{
+			desc: "both credentials",
+			modify: func(c *databricks.Config) {
+				c.AccessToken = "my-token"
+				c.ClientID = "my-client-id"
+				c.ClientSecret = "my-client-secret"
+			},
+			want: "unable to create pool: only one of accessToken or clientId can be set",
+		},
+		{
+			desc:   "client id without secret",
+			modify: func(c *databricks.Config) { c.ClientID = "my-client-id" },
+			want:   "unable to create pool: clientSecret must be set with clientId",
+		},
+		{
+			desc: "invalid query timeout",
+			modify: func(c *databricks.Config) {
+				c.AccessToken = "my-token"
+				c.QueryTimeout = "soon"
+			},
+			want: `unable to create pool: invalid queryTimeout "soon": time: invalid duration "soon"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := base
+			tc.modify(&cfg)
+			_, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
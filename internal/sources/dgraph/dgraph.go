@@ -141,20 +141,36 @@ func initDgraphHttpClient(ctx context.Context, tracer trace.Tracer, r Config) (*
 	return hc, nil
 }
 
+// QueryOptions are the transaction modes of a DQL query.
+type QueryOptions struct {
+	// ReadOnly runs the query in a read-only transaction.
+	ReadOnly bool
+	// BestEffort lets a read-only query read the latest data of the server
+	// it's sent to, without waiting for a timestamp from Zero.
+	BestEffort bool
+}
+
 func (hc *DgraphClient) ExecuteQuery(query string, paramsMap map[string]interface{},
-	isQuery bool, timeout string) ([]byte, error) {
+	isQuery bool, timeout string, opts QueryOptions) ([]byte, error) {
 	if isQuery {
-		return hc.postDqlQuery(query, paramsMap, timeout)
+		return hc.postDqlQuery(query, paramsMap, timeout, opts)
 	} else {
 		return hc.mutate(query, paramsMap)
 	}
 }
 
-// postDqlQuery sends a DQL query to the Dgraph server with query, parameters, and optional timeout.
+// postDqlQuery sends a DQL query to the Dgraph server with query, parameters, optional timeout
+// and transaction modes.
 // Returns the response body ([]byte) and an error, if any.
-func (hc *DgraphClient) postDqlQuery(query string, paramsMap map[string]interface{}, timeout string) ([]byte, error) {
+func (hc *DgraphClient) postDqlQuery(query string, paramsMap map[string]interface{}, timeout string, opts QueryOptions) ([]byte, error) {
 	urlParams := url.Values{}
 	urlParams.Add("timeout", timeout)
+	if opts.ReadOnly {
+		urlParams.Add("ro", "true")
+	}
+	if opts.BestEffort {
+		urlParams.Add("be", "true")
+	}
 	url, err := getUrl(hc.baseUrl, "/query", urlParams)
 	if err != nil {
 		return nil, err
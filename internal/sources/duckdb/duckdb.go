@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "duckdb"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	// Database is the path of the database file. The database is in memory
+	// if it isn't set.
+	Database string `yaml:"database"`
+	ReadOnly bool   `yaml:"readOnly"`
+	// Files maps the names of views to the data files they read.
+	Files map[string]string `yaml:"files"`
+
+	sources.PoolConfig `yaml:",inline"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	if r.ReadOnly && (r.Database == "" || r.Database == ":memory:") {
+		return nil, fmt.Errorf("readOnly requires a database file")
+	}
+	views, err := viewStatements(r.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := initDuckDBConnection(ctx, tracer, r.Name, r.dsn(), views)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create db connection: %w", err)
+	}
+	if err := r.ConfigureDB(db); err != nil {
+		return nil, err
+	}
+
+	err = db.PingContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		Db:   db,
+	}
+	return s, nil
+}
+
+func (r Config) dsn() string {
+	if r.ReadOnly {
+		return r.Database + "?access_mode=read_only"
+	}
+	return r.Database
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	Db   *sql.DB
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) DuckDB() *sql.DB {
+	return s.Db
+}
+
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
+}
+
+// readFunctions are the functions that read data files, by file extension.
+var readFunctions = map[string]string{
+	".parquet": "read_parquet",
+	".csv":     "read_csv_auto",
+	".tsv":     "read_csv_auto",
+	".json":    "read_json_auto",
+	".jsonl":   "read_json_auto",
+	".ndjson":  "read_json_auto",
+}
+
+// viewStatements returns the statements that create a view for each of the
+// data files. The views are temporary, so that they are never written to the
+// database file.
+func viewStatements(files map[string]string) ([]string, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stmts := make([]string, 0, len(files))
+	for _, name := range names {
+		file := files[name]
+		ext := path.Ext(strings.TrimSuffix(strings.ToLower(file), ".gz"))
+		fn, ok := readFunctions[ext]
+		if !ok {
+			return nil, fmt.Errorf("unable to read file %q of view %q: unsupported file extension %q", file, name, ext)
+		}
+		stmts = append(stmts, fmt.Sprintf("CREATE OR REPLACE TEMP VIEW %s AS SELECT * FROM %s(%s)", quoteIdentifier(name), fn, quoteLiteral(file)))
+	}
+	return stmts, nil
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
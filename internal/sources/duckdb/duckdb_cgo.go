@@ -0,0 +1,75 @@
+//go:build cgo
+
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/marcboeker/go-duckdb"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func initDuckDBConnection(ctx context.Context, tracer trace.Tracer, name, dsn string, views []string) (*sql.DB, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	// temporary views only exist in the connection that created them, so
+	// they are created for each connection
+	connector, err := duckdb.NewConnector(dsn, func(execer driver.ExecerContext) error {
+		for _, stmt := range views {
+			if _, err := execer.ExecContext(context.Background(), stmt, nil); err != nil {
+				return fmt.Errorf("unable to create view: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("duckdb.NewConnector: %w", err)
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// JSONValue converts a value returned by the driver into a value that can be
+// encoded as JSON.
+func JSONValue(v any) any {
+	switch v := v.(type) {
+	case duckdb.Decimal:
+		return v.Float64()
+	case duckdb.Map:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = JSONValue(e)
+		}
+		return m
+	case map[string]any:
+		for k, e := range v {
+			v[k] = JSONValue(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = JSONValue(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
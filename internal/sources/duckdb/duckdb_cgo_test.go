@@ -0,0 +1,86 @@
+//go:build cgo
+
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duckdb_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources/duckdb"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestInitializeDuckDBFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "orders.csv")
+	if err := os.WriteFile(csvPath, []byte("id,item,price\n1,apple,1.25\n2,pear,0.5\n"), 0o600); err != nil {
+		t.Fatalf("unable to write file: %s", err)
+	}
+
+	cfg := duckdb.Config{
+		Name:     "my-duckdb",
+		Kind:     duckdb.SourceKind,
+		Database: filepath.Join(dir, "test.duckdb"),
+		Files:    map[string]string{"orders": csvPath},
+	}
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	db := s.(*duckdb.Source).DuckDB()
+	defer db.Close()
+
+	// the view must exist in every connection of the pool
+	db.SetMaxIdleConns(0)
+	for i := 0; i < 2; i++ {
+		var count int
+		var total float64
+		if err := db.QueryRowContext(ctx, "SELECT count(*), sum(price) FROM orders").Scan(&count, &total); err != nil {
+			t.Fatalf("unable to query view: %s", err)
+		}
+		if count != 2 || total != 1.75 {
+			t.Fatalf("unexpected result: got %d rows with total %v", count, total)
+		}
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	ctx := context.Background()
+	s, err := duckdb.Config{Name: "my-duckdb", Kind: duckdb.SourceKind}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	db := s.(*duckdb.Source).DuckDB()
+	defer db.Close()
+
+	var got any
+	err = db.QueryRowContext(ctx, "SELECT {'price': 1.25::DECIMAL(4,2), 'tags': MAP {1: [0.5::DECIMAL(3,1)]}}").Scan(&got)
+	if err != nil {
+		t.Fatalf("unable to query: %s", err)
+	}
+	want := map[string]any{
+		"price": 1.25,
+		"tags":  map[string]any{"1": []any{0.5}},
+	}
+	if diff := cmp.Diff(want, duckdb.JSONValue(got)); diff != "" {
+		t.Fatalf("incorrect value: diff %v", diff)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build !cgo
+
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The DuckDB driver is a cgo library, so the source is unavailable in builds
+// without cgo.
+func initDuckDBConnection(ctx context.Context, tracer trace.Tracer, name, dsn string, views []string) (*sql.DB, error) {
+	return nil, fmt.Errorf("the %q source kind requires a build of Toolbox with cgo enabled", SourceKind)
+}
+
+// JSONValue converts a value returned by the driver into a value that can be
+// encoded as JSON.
+func JSONValue(v any) any {
+	return v
+}
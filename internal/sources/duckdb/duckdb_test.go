@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package duckdb_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/duckdb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlDuckDB(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+            sources:
+                my-duckdb:
+                    kind: duckdb
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-duckdb": duckdb.Config{
+					Name: "my-duckdb",
+					Kind: duckdb.SourceKind,
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+            sources:
+                my-duckdb:
+                    kind: duckdb
+                    database: /path/to/database.duckdb
+                    readOnly: true
+                    files:
+                        orders: /data/orders/*.parquet
+                        customers: /data/customers.csv
+            `,
+			want: map[string]sources.SourceConfig{
+				"my-duckdb": duckdb.Config{
+					Name:     "my-duckdb",
+					Kind:     duckdb.SourceKind,
+					Database: "/path/to/database.duckdb",
+					ReadOnly: true,
+					Files: map[string]string{
+						"orders":    "/data/orders/*.parquet",
+						"customers": "/data/customers.csv",
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidDuckDB(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  duckdb.Config
+		err  string
+	}{
+		{
+			desc: "read-only in-memory database",
+			cfg: duckdb.Config{
+				Name:     "my-duckdb",
+				Kind:     duckdb.SourceKind,
+				ReadOnly: true,
+			},
+			err: "readOnly requires a database file",
+		},
+		{
+			desc: "unsupported file",
+			cfg: duckdb.Config{
+				Name:  "my-duckdb",
+				Kind:  duckdb.SourceKind,
+				Files: map[string]string{"orders": "/data/orders.xlsx"},
+			},
+			err: `unable to read file "/data/orders.xlsx" of view "orders": unsupported file extension ".xlsx"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+			if err == nil {
+				t.Fatalf("expect error")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbapi "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "dynamodb"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name       string `yaml:"name" validate:"required"`
+	Kind       string `yaml:"kind" validate:"required"`
+	Region     string `yaml:"region"`
+	RoleArn    string `yaml:"roleArn"`
+	ExternalID string `yaml:"externalId"`
+	// Endpoint overrides the endpoint of the DynamoDB API, e.g. for DynamoDB
+	// local.
+	Endpoint string `yaml:"endpoint"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initDynamoDBClient(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	s := &Source{
+		Name:   r.Name,
+		Kind:   SourceKind,
+		Client: client,
+	}
+
+	err = s.CheckHealth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name   string `yaml:"name"`
+	Kind   string `yaml:"kind"`
+	Client *dynamodbapi.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) DynamoDBClient() *dynamodbapi.Client {
+	return s.Client
+}
+
+// CheckHealth lists a table, which verifies the credentials of the source.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	_, err := s.Client.ListTables(ctx, &dynamodbapi.ListTablesInput{Limit: aws.Int32(1)})
+	return err
+}
+
+func initDynamoDBClient(ctx context.Context, tracer trace.Tracer, r Config) (*dynamodbapi.Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	cfg, err := sources.GetAWSConfig(ctx, r.Region, r.RoleArn, r.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := dynamodbapi.NewFromConfig(cfg, func(o *dynamodbapi.Options) {
+		if r.Endpoint != "" {
+			o.BaseEndpoint = aws.String(r.Endpoint)
+		}
+	})
+	return client, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodb_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/dynamodb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlDynamoDB(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-dynamodb:
+					kind: dynamodb
+					region: us-east-1
+			`,
+			want: server.SourceConfigs{
+				"my-dynamodb": dynamodb.Config{
+					Name:   "my-dynamodb",
+					Kind:   dynamodb.SourceKind,
+					Region: "us-east-1",
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			sources:
+				my-dynamodb:
+					kind: dynamodb
+					region: eu-west-1
+					roleArn: arn:aws:iam::123456789012:role/dynamodb-reader
+					externalId: my-external-id
+					endpoint: http://localhost:8000
+			`,
+			want: server.SourceConfigs{
+				"my-dynamodb": dynamodb.Config{
+					Name:       "my-dynamodb",
+					Kind:       dynamodb.SourceKind,
+					Region:     "eu-west-1",
+					RoleArn:    "arn:aws:iam::123456789012:role/dynamodb-reader",
+					ExternalID: "my-external-id",
+					Endpoint:   "http://localhost:8000",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeDynamoDB(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "my-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "my-secret")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	tcs := []struct {
+		desc   string
+		status int
+		body   string
+		err    string
+	}{
+		{
+			desc:   "healthy",
+			status: http.StatusOK,
+			body:   `{"TableNames":["orders"]}`,
+		},
+		{
+			desc:   "access denied",
+			status: http.StatusBadRequest,
+			body:   `{"__type":"com.amazon.coral.service#AccessDeniedException","message":"not authorized"}`,
+			err:    "AccessDeniedException",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("X-Amz-Target"); got != "DynamoDB_20120810.ListTables" {
+					t.Errorf("unexpected operation %q", got)
+				}
+				w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer ts.Close()
+
+			cfg := dynamodb.Config{
+				Name:     "my-dynamodb",
+				Kind:     dynamodb.SourceKind,
+				Region:   "us-east-1",
+				Endpoint: ts.URL,
+			}
+			_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unable to initialize: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
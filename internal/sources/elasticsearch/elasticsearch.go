@@ -0,0 +1,245 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "elasticsearch"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                   string   `yaml:"name" validate:"required"`
+	Kind                   string   `yaml:"kind" validate:"required"`
+	Addresses              []string `yaml:"addresses" validate:"required,min=1"`
+	Username               string   `yaml:"username"`
+	Password               string   `yaml:"password"`
+	APIKey                 string   `yaml:"apiKey"`
+	Timeout                string   `yaml:"timeout"`
+	DisableSslVerification bool     `yaml:"disableSslVerification"`
+	Proxy                  string   `yaml:"proxy"`
+	CACert                 string   `yaml:"caCert"`
+	TLSMinVersion          string   `yaml:"tlsMinVersion"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+// Initialize initializes an Elasticsearch Source instance.
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	duration, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+	if r.APIKey != "" && r.Username != "" {
+		return nil, fmt.Errorf("only one of apiKey or username can be set")
+	}
+
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
+	}
+
+	tr := &http.Transport{}
+	if r.DisableSslVerification {
+		tr.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+
+		logger.WarnContext(ctx, "Insecure HTTP is enabled for Elasticsearch source %s. TLS certificate verification is skipped.\n", r.Name)
+	}
+	if err := sources.ConfigureTransport(tr, r.Proxy, r.CACert, r.TLSMinVersion); err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(r.Addresses))
+	for _, a := range r.Addresses {
+		if _, err := url.ParseRequestURI(a); err != nil {
+			return nil, fmt.Errorf("failed to parse address %v", err)
+		}
+		addresses = append(addresses, strings.TrimSuffix(a, "/"))
+	}
+
+	s := &Source{
+		Name:      r.Name,
+		Kind:      SourceKind,
+		Addresses: addresses,
+		Client:    &http.Client{Timeout: duration, Transport: tr},
+		username:  r.Username,
+		password:  r.Password,
+		apiKey:    r.APIKey,
+	}
+	if err := s.CheckHealth(ctx); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name      string   `yaml:"name"`
+	Kind      string   `yaml:"kind"`
+	Addresses []string `yaml:"addresses"`
+	Client    *http.Client
+
+	username string
+	password string
+	apiKey   string
+	// next is the index of the address the next request is sent to.
+	next atomic.Uint32
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// Error is an error response of the cluster.
+type Error struct {
+	StatusCode int
+	Type       string
+	Reason     string
+}
+
+func (e *Error) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("unexpected status code: %d, %s", e.StatusCode, e.Reason)
+	}
+	return fmt.Sprintf("unexpected status code: %d, %s: %s", e.StatusCode, e.Type, e.Reason)
+}
+
+// Do sends a request to the cluster and decodes its JSON response. Requests
+// are spread over the addresses, and sent to the next address if one can't be
+// reached.
+func (s *Source) Do(ctx context.Context, method, path string, query url.Values, body any) (any, error) {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal request body: %w", err)
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	start := int(s.next.Add(1))
+	for i := range s.Addresses {
+		addr := s.Addresses[(start+i)%len(s.Addresses)]
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, method, addr+path, bytes.NewReader(reqBody))
+		if err != nil {
+			return nil, fmt.Errorf("error creating HTTP request: %s", err)
+		}
+		req.URL.RawQuery = query.Encode()
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		switch {
+		case s.apiKey != "":
+			req.Header.Set("Authorization", "ApiKey "+s.apiKey)
+		case s.username != "":
+			req.SetBasicAuth(s.username, s.password)
+		}
+		resp, err = s.Client.Do(req)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error making HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newError(resp.StatusCode, respBody)
+	}
+	var data any
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+	return data, nil
+}
+
+// newError returns the error described by an error response.
+func newError(statusCode int, body []byte) *Error {
+	var resp struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Error.Reason == "" {
+		return &Error{StatusCode: statusCode, Reason: string(body)}
+	}
+	return &Error{StatusCode: statusCode, Type: resp.Error.Type, Reason: resp.Error.Reason}
+}
+
+// CheckHealth checks that the cluster responds, and that its status isn't
+// red. Credentials without the privilege to read the cluster's health are
+// enough to consider it healthy.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	res, err := s.Do(ctx, http.MethodGet, "/_cluster/health", nil, nil)
+	var esErr *Error
+	if errors.As(err, &esErr) && esErr.StatusCode == http.StatusForbidden {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if health, ok := res.(map[string]any); ok && health["status"] == "red" {
+		return fmt.Errorf("cluster status is red")
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearch_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/elasticsearch"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlElasticsearch(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-es-instance:
+					kind: elasticsearch
+					addresses:
+						- http://localhost:9200
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-es-instance": elasticsearch.Config{
+					Name:      "my-es-instance",
+					Kind:      elasticsearch.SourceKind,
+					Addresses: []string{"http://localhost:9200"},
+					Timeout:   "30s",
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			sources:
+				my-es-instance:
+					kind: elasticsearch
+					addresses:
+						- https://node-1:9200
+						- https://node-2:9200
+					username: elastic
+					password: my-pass
+					timeout: 10s
+					disableSslVerification: true
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-es-instance": elasticsearch.Config{
+					Name:                   "my-es-instance",
+					Kind:                   elasticsearch.SourceKind,
+					Addresses:              []string{"https://node-1:9200", "https://node-2:9200"},
+					Username:               "elastic",
+					Password:               "my-pass",
+					Timeout:                "10s",
+					DisableSslVerification: true,
+				},
+			},
+		},
+		{
+			desc: "api key",
+			in: `
+			sources:
+				my-es-instance:
+					kind: elasticsearch
+					addresses:
+						- https://my-deployment.es.us-central1.gcp.cloud.es.io
+					apiKey: my-key
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-es-instance": elasticsearch.Config{
+					Name:      "my-es-instance",
+					Kind:      elasticsearch.SourceKind,
+					Addresses: []string{"https://my-deployment.es.us-central1.gcp.cloud.es.io"},
+					APIKey:    "my-key",
+					Timeout:   "30s",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	in := `
+	sources:
+		my-es-instance:
+			kind: elasticsearch
+			username: elastic
+	`
+	got := struct {
+		Sources server.SourceConfigs `yaml:"sources"`
+	}{}
+	err := yaml.Unmarshal(testutils.FormatYaml(in), &got)
+	if err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+	want := "unable to parse source \"my-es-instance\" as \"elasticsearch\": Key: 'Config.Addresses' Error:Field validation for 'Addresses' failed on the 'required' tag"
+	if err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestInitialize(t *testing.T) {
+	var gotAuth string
+	status := "green"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Path != "/_cluster/health" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"cluster_name": "test", "status": "` + status + `"}`))
+	}))
+	defer srv.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tracer := noop.NewTracerProvider().Tracer("")
+	tcs := []struct {
+		desc     string
+		cfg      elasticsearch.Config
+		wantAuth string
+		wantErr  string
+	}{
+		{
+			desc:     "api key",
+			cfg:      elasticsearch.Config{Name: "es", Kind: elasticsearch.SourceKind, Addresses: []string{srv.URL}, APIKey: "my-key", Timeout: "5s"},
+			wantAuth: "ApiKey my-key",
+		},
+		{
+			desc:     "basic auth",
+			cfg:      elasticsearch.Config{Name: "es", Kind: elasticsearch.SourceKind, Addresses: []string{srv.URL + "/"}, Username: "elastic", Password: "pass", Timeout: "5s"},
+			wantAuth: "Basic ZWxhc3RpYzpwYXNz",
+		},
+		{
+			desc:    "both api key and username",
+			cfg:     elasticsearch.Config{Name: "es", Kind: elasticsearch.SourceKind, Addresses: []string{srv.URL}, APIKey: "my-key", Username: "elastic", Timeout: "5s"},
+			wantErr: "only one of apiKey or username can be set",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotAuth = ""
+			_, err := tc.cfg.Initialize(ctx, tracer)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotAuth != tc.wantAuth {
+				t.Fatalf("unexpected Authorization header: got %q, want %q", gotAuth, tc.wantAuth)
+			}
+		})
+	}
+
+	status = "red"
+	cfg := elasticsearch.Config{Name: "es", Kind: elasticsearch.SourceKind, Addresses: []string{srv.URL}, Timeout: "5s"}
+	if _, err := cfg.Initialize(ctx, tracer); err == nil || err.Error() != "unable to connect successfully: cluster status is red" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_cluster/health":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"type": "security_exception", "reason": "action [cluster:monitor/health] is unauthorized"}, "status": 403}`))
+		case "/missing/_search":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "index_not_found_exception", "reason": "no such index [missing]"}, "status": 404}`))
+		default:
+			_, _ = w.Write([]byte(`{"took": 1}`))
+		}
+	}))
+	defer srv.Close()
+	// an address that can't be reached
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	s := &elasticsearch.Source{
+		Name:      "es",
+		Kind:      elasticsearch.SourceKind,
+		Addresses: []string{closed.URL, srv.URL},
+		Client:    srv.Client(),
+	}
+	ctx := context.Background()
+	// requests fail over to the reachable address, whichever they start with
+	for i := 0; i < 2; i++ {
+		res, err := s.Do(ctx, http.MethodPost, "/my-index/_search", nil, map[string]any{"size": 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(map[string]any{"took": float64(1)}, res); diff != "" {
+			t.Fatalf("incorrect result: diff %v", diff)
+		}
+	}
+
+	_, err := s.Do(ctx, http.MethodPost, "/missing/_search", nil, map[string]any{})
+	var esErr *elasticsearch.Error
+	if !errors.As(err, &esErr) {
+		t.Fatalf("expected an *elasticsearch.Error, got %v", err)
+	}
+	want := &elasticsearch.Error{StatusCode: http.StatusNotFound, Type: "index_not_found_exception", Reason: "no such index [missing]"}
+	if diff := cmp.Diff(want, esErr); diff != "" {
+		t.Fatalf("incorrect error: diff %v", diff)
+	}
+
+	// credentials that can't read the cluster health are still healthy
+	if err := s.CheckHealth(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
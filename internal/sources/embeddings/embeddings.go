@@ -0,0 +1,252 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+const SourceKind string = "embeddings"
+
+const (
+	// ProviderVertexAI generates embeddings with the text embedding models
+	// of Vertex AI.
+	ProviderVertexAI = "vertexai"
+	// ProviderOpenAI generates embeddings with the embeddings API of OpenAI,
+	// or of any server that is compatible with it.
+	ProviderOpenAI = "openai"
+)
+
+const (
+	vertexAIEndpoint = "https://%s-aiplatform.googleapis.com/v1/projects/%s/locations/%s/publishers/google/models/%s:predict"
+	vertexAIScope    = "https://www.googleapis.com/auth/cloud-platform"
+	openAIBaseURL    = "https://api.openai.com/v1"
+)
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Location: "us-central1", Timeout: "30s"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name     string `yaml:"name" validate:"required"`
+	Kind     string `yaml:"kind" validate:"required"`
+	Provider string `yaml:"provider" validate:"required"`
+	Model    string `yaml:"model" validate:"required"`
+	// Dimensions is the number of dimensions of the embeddings, for models
+	// that can shorten them. Defaults to the dimensions of the model.
+	Dimensions int `yaml:"dimensions" validate:"gte=0"`
+	// Project and Location are the project and the region of Vertex AI.
+	Project  string `yaml:"project"`
+	Location string `yaml:"location"`
+	// BaseURL and APIKey are the endpoint and the key of an OpenAI-compatible
+	// API.
+	BaseURL string `yaml:"baseUrl"`
+	APIKey  string `yaml:"apiKey"`
+	Timeout string `yaml:"timeout"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	timeout, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse timeout as time.Duration: %w", err)
+	}
+
+	s := &Source{
+		Name:       r.Name,
+		Kind:       SourceKind,
+		Provider:   r.Provider,
+		Model:      r.Model,
+		Dimensions: r.Dimensions,
+	}
+	switch r.Provider {
+	case ProviderVertexAI:
+		if r.Project == "" {
+			return nil, fmt.Errorf("project is required for provider %q", r.Provider)
+		}
+		userAgent, err := util.UserAgentFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// the client authenticates with the Application Default Credentials
+		client, _, err := htransport.NewClient(ctx, option.WithScopes(vertexAIScope), option.WithUserAgent(userAgent))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP client with scope %q: %w", vertexAIScope, err)
+		}
+		client.Timeout = timeout
+		s.Client = client
+		s.URL = fmt.Sprintf(vertexAIEndpoint, r.Location, r.Project, r.Location, r.Model)
+	case ProviderOpenAI:
+		baseURL := r.BaseURL
+		if baseURL == "" {
+			baseURL = openAIBaseURL
+		}
+		s.Client = &http.Client{Timeout: timeout}
+		s.URL = strings.TrimSuffix(baseURL, "/") + "/embeddings"
+		s.APIKey = r.APIKey
+	default:
+		return nil, fmt.Errorf("invalid provider %q: must be %q or %q", r.Provider, ProviderVertexAI, ProviderOpenAI)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name       string `yaml:"name"`
+	Kind       string `yaml:"kind"`
+	Provider   string `yaml:"provider"`
+	Model      string `yaml:"model"`
+	Dimensions int    `yaml:"dimensions"`
+	// URL is the endpoint that embeddings are requested from.
+	URL    string
+	APIKey string
+	Client *http.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// Embed returns the embedding of a text, for a query of the embeddings of
+// documents.
+func (s *Source) Embed(ctx context.Context, text string) ([]float64, error) {
+	var embedding []float64
+	var err error
+	switch s.Provider {
+	case ProviderVertexAI:
+		embedding, err = s.embedVertexAI(ctx, text)
+	default:
+		embedding, err = s.embedOpenAI(ctx, text)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(embedding) == 0 {
+		return nil, fmt.Errorf("model %q returned no embedding", s.Model)
+	}
+	return embedding, nil
+}
+
+func (s *Source) embedVertexAI(ctx context.Context, text string) ([]float64, error) {
+	body := map[string]any{
+		// RETRIEVAL_QUERY optimizes the embedding of a search query for the
+		// retrieval of documents embedded with RETRIEVAL_DOCUMENT
+		"instances": []any{map[string]any{"content": text, "task_type": "RETRIEVAL_QUERY"}},
+	}
+	if s.Dimensions > 0 {
+		body["parameters"] = map[string]any{"outputDimensionality": s.Dimensions}
+	}
+	var resp struct {
+		Predictions []struct {
+			Embeddings struct {
+				Values []float64 `json:"values"`
+			} `json:"embeddings"`
+		} `json:"predictions"`
+	}
+	if err := s.post(ctx, body, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Predictions) == 0 {
+		return nil, nil
+	}
+	return resp.Predictions[0].Embeddings.Values, nil
+}
+
+func (s *Source) embedOpenAI(ctx context.Context, text string) ([]float64, error) {
+	body := map[string]any{"model": s.Model, "input": text}
+	if s.Dimensions > 0 {
+		body["dimensions"] = s.Dimensions
+	}
+	var resp struct {
+		Data []struct {
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := s.post(ctx, body, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+	return resp.Data[0].Embedding, nil
+}
+
+// post sends the body as JSON to the endpoint of the source, and decodes its
+// response into out.
+func (s *Source) post(ctx context.Context, body any, out any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("unable to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to generate embedding: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to generate embedding: model %q returned status %d: %s", s.Model, resp.StatusCode, respBody)
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("unable to parse response: %w", err)
+	}
+	return nil
+}
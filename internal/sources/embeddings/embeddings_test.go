@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embeddings_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/embeddings"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlEmbeddings(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "vertex ai example",
+			in: `
+			sources:
+				my-embeddings:
+					kind: embeddings
+					provider: vertexai
+					model: text-embedding-005
+					project: my-project
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-embeddings": embeddings.Config{
+					Name:     "my-embeddings",
+					Kind:     embeddings.SourceKind,
+					Provider: "vertexai",
+					Model:    "text-embedding-005",
+					Project:  "my-project",
+					Location: "us-central1",
+					Timeout:  "30s",
+				},
+			},
+		},
+		{
+			desc: "openai example",
+			in: `
+			sources:
+				my-embeddings:
+					kind: embeddings
+					provider: openai
+					model: text-embedding-3-small
+					dimensions: 768
+					baseUrl: http://localhost:8000/v1
+					apiKey: my-key
+					timeout: 10s
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-embeddings": embeddings.Config{
+					Name:       "my-embeddings",
+					Kind:       embeddings.SourceKind,
+					Provider:   "openai",
+					Model:      "text-embedding-3-small",
+					Dimensions: 768,
+					Location:   "us-central1",
+					BaseURL:    "http://localhost:8000/v1",
+					APIKey:     "my-key",
+					Timeout:    "10s",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeEmbeddings(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tracer := noop.NewTracerProvider().Tracer("")
+
+	cfg := embeddings.Config{Name: "my-embeddings", Kind: embeddings.SourceKind, Provider: "openai", Model: "text-embedding-3-small", Timeout: "30s"}
+	s, err := cfg.Initialize(ctx, tracer)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := s.(*embeddings.Source).URL, "https://api.openai.com/v1/embeddings"; got != want {
+		t.Fatalf("incorrect URL: got %q, want %q", got, want)
+	}
+
+	cfg.Provider = "cohere"
+	if _, err := cfg.Initialize(ctx, tracer); err == nil || !strings.Contains(err.Error(), `invalid provider "cohere"`) {
+		t.Fatalf("unexpected error for invalid provider: %v", err)
+	}
+	cfg.Provider = "vertexai"
+	if _, err := cfg.Initialize(ctx, tracer); err == nil || !strings.Contains(err.Error(), "project is required") {
+		t.Fatalf("unexpected error for missing project: %v", err)
+	}
+}
+
+func TestEmbed(t *testing.T) {
+	var got map[string]any
+	var gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/vertexai":
+			_, _ = w.Write([]byte(`{"predictions":[{"embeddings":{"values":[0.1,0.2,0.3]}}]}`))
+		case "/openai":
+			_, _ = w.Write([]byte(`{"data":[{"embedding":[0.4,0.5,0.6]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"model not found"}`))
+		}
+	}))
+	defer ts.Close()
+
+	tcs := []struct {
+		desc        string
+		src         embeddings.Source
+		wantRequest map[string]any
+		wantAuth    string
+		want        []float64
+		wantErr     string
+	}{
+		{
+			desc: "vertex ai",
+			src:  embeddings.Source{Provider: "vertexai", Model: "text-embedding-005", Dimensions: 3, URL: ts.URL + "/vertexai"},
+			wantRequest: map[string]any{
+				"instances":  []any{map[string]any{"content": "hello", "task_type": "RETRIEVAL_QUERY"}},
+				"parameters": map[string]any{"outputDimensionality": float64(3)},
+			},
+			want: []float64{0.1, 0.2, 0.3},
+		},
+		{
+			desc:        "openai",
+			src:         embeddings.Source{Provider: "openai", Model: "text-embedding-3-small", APIKey: "my-key", URL: ts.URL + "/openai"},
+			wantRequest: map[string]any{"model": "text-embedding-3-small", "input": "hello"},
+			wantAuth:    "Bearer my-key",
+			want:        []float64{0.4, 0.5, 0.6},
+		},
+		{
+			desc:    "error",
+			src:     embeddings.Source{Provider: "openai", Model: "unknown", URL: ts.URL + "/unknown"},
+			wantErr: `model "unknown" returned status 404: {"error":"model not found"}`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.src.Client = ts.Client()
+			embedding, err := tc.src.Embed(context.Background(), "hello")
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, embedding); diff != "" {
+				t.Fatalf("incorrect embedding: diff %v", diff)
+			}
+			if diff := cmp.Diff(tc.wantRequest, got); diff != "" {
+				t.Fatalf("incorrect request: diff %v", diff)
+			}
+			if gotAuth != tc.wantAuth {
+				t.Fatalf("incorrect authorization: got %q, want %q", gotAuth, tc.wantAuth)
+			}
+		})
+	}
+}
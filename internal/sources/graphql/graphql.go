@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "graphql"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                   string            `yaml:"name" validate:"required"`
+	Kind                   string            `yaml:"kind" validate:"required"`
+	Endpoint               string            `yaml:"endpoint" validate:"required"`
+	Timeout                string            `yaml:"timeout"`
+	DefaultHeaders         map[string]string `yaml:"headers"`
+	DisableSslVerification bool              `yaml:"disableSslVerification"`
+	Proxy                  string            `yaml:"proxy"`
+	CACert                 string            `yaml:"caCert"`
+	TLSMinVersion          string            `yaml:"tlsMinVersion"`
+	Retry                  retry.Config      `yaml:"retry"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+// Initialize initializes a GraphQL Source instance.
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	duration, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+
+	tr := &http.Transport{}
+
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
+	}
+
+	if r.DisableSslVerification {
+		tr.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+
+		logger.WarnContext(ctx, "Insecure HTTP is enabled for GraphQL source %s. TLS certificate verification is skipped.\n", r.Name)
+	}
+	if err := sources.ConfigureTransport(tr, r.Proxy, r.CACert, r.TLSMinVersion); err != nil {
+		return nil, err
+	}
+
+	// Validate Endpoint
+	if _, err := url.ParseRequestURI(r.Endpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint %v", err)
+	}
+
+	retryPolicy, err := r.Retry.Policy()
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	s := &Source{
+		Name:           r.Name,
+		Kind:           SourceKind,
+		Endpoint:       r.Endpoint,
+		DefaultHeaders: r.DefaultHeaders,
+		Client:         &http.Client{Timeout: duration, Transport: tr},
+		RetryPolicy:    retryPolicy,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name           string            `yaml:"name"`
+	Kind           string            `yaml:"kind"`
+	Endpoint       string            `yaml:"endpoint"`
+	DefaultHeaders map[string]string `yaml:"headers"`
+	Client         *http.Client
+	RetryPolicy    retry.Policy
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the body of a GraphQL response.
+type Response struct {
+	Data   any            `json:"data"`
+	Errors []ErrorMessage `json:"errors,omitempty"`
+}
+
+// ErrorMessage is an error reported in a GraphQL response.
+type ErrorMessage struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// ErrorString joins the messages of the errors in the response.
+func (r Response) ErrorString() string {
+	msgs := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		if len(e.Path) > 0 {
+			msgs = append(msgs, fmt.Sprintf("%s (path: %v)", e.Message, e.Path))
+			continue
+		}
+		msgs = append(msgs, e.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Execute sends a GraphQL request to the endpoint, with the source's default
+// headers overridden by headers.
+func (s *Source) Execute(ctx context.Context, gqlReq Request, headers map[string]string) (Response, error) {
+	body, err := json.Marshal(gqlReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("unable to marshal GraphQL request: %w", err)
+	}
+	resp, err := s.RetryPolicy.Do(ctx, s.Client, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("error creating HTTP request: %s", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		for k, v := range s.DefaultHeaders {
+			req.Header.Set(k, v)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("error making HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Response{}, err
+	}
+	var gqlResp Response
+	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
+		// servers may respond to invalid requests without a GraphQL response
+		return Response{}, fmt.Errorf("unexpected status code: %d, response body: %s", resp.StatusCode, string(respBody))
+	}
+	if gqlResp.Data == nil && len(gqlResp.Errors) == 0 && resp.StatusCode != http.StatusOK {
+		return Response{}, fmt.Errorf("unexpected status code: %d, response body: %s", resp.StatusCode, string(respBody))
+	}
+	return gqlResp, nil
+}
+
+// CheckHealth sends the `{ __typename }` query, which every GraphQL server
+// can answer.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	resp, err := s.Execute(ctx, Request{Query: "{ __typename }"}, nil)
+	if err != nil {
+		return err
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("GraphQL errors: %s", resp.ErrorString())
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql_test
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/graphql"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlGraphQL(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-graphql-instance:
+					kind: graphql
+					endpoint: https://api.github.com/graphql
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-graphql-instance": graphql.Config{
+					Name:     "my-graphql-instance",
+					Kind:     graphql.SourceKind,
+					Endpoint: "https://api.github.com/graphql",
+					Timeout:  "30s",
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			sources:
+				my-graphql-instance:
+					kind: graphql
+					endpoint: https://api.github.com/graphql
+					timeout: 10s
+					headers:
+						Authorization: Bearer test_token
+					disableSslVerification: true
+					retry:
+						maxRetries: 3
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-graphql-instance": graphql.Config{
+					Name:                   "my-graphql-instance",
+					Kind:                   graphql.SourceKind,
+					Endpoint:               "https://api.github.com/graphql",
+					Timeout:                "10s",
+					DefaultHeaders:         map[string]string{"Authorization": "Bearer test_token"},
+					DisableSslVerification: true,
+					Retry:                  retry.Config{MaxRetries: 3},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	in := `
+	sources:
+		my-graphql-instance:
+			kind: graphql
+			timeout: 10s
+	`
+	got := struct {
+		Sources server.SourceConfigs `yaml:"sources"`
+	}{}
+	err := yaml.Unmarshal(testutils.FormatYaml(in), &got)
+	if err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+	want := "unable to parse source \"my-graphql-instance\" as \"graphql\": Key: 'Config.Endpoint' Error:Field validation for 'Endpoint' failed on the 'required' tag"
+	if err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	var got graphql.Request
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		if got.Query == "{ __typename }" {
+			_, _ = w.Write([]byte(`{"data": {"__typename": "Query"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": null, "errors": [{"message": "Field 'foo' doesn't exist", "path": ["foo"]}]}`))
+	}))
+	defer srv.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := graphql.Config{
+		Name:           "my-graphql-instance",
+		Kind:           graphql.SourceKind,
+		Endpoint:       srv.URL,
+		Timeout:        "5s",
+		DefaultHeaders: map[string]string{"Authorization": "Bearer source_token"},
+	}
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src := s.(*graphql.Source)
+
+	if err := src.CheckHealth(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resp, err := src.Execute(ctx, graphql.Request{Query: "{ foo }", Variables: map[string]any{"id": 1.0}}, map[string]string{"Authorization": "Bearer tool_token"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(graphql.Request{Query: "{ foo }", Variables: map[string]any{"id": 1.0}}, got); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+	if gotAuth != "Bearer tool_token" {
+		t.Fatalf("expected the tool's header to override the source's, got %q", gotAuth)
+	}
+	if want := "Field 'foo' doesn't exist (path: [foo])"; resp.ErrorString() != want {
+		t.Fatalf("incorrect errors: got %q, want %q", resp.ErrorString(), want)
+	}
+}
+
+func TestEgress(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tracer := noop.NewTracerProvider().Tracer("")
+	healthy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data": {"__typename": "Query"}}`))
+	})
+
+	// the certificate of a TLS server is only trusted with caCert
+	tlsSrv := httptest.NewTLSServer(healthy)
+	defer tlsSrv.Close()
+	caCert := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsSrv.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("unable to write caCert: %s", err)
+	}
+
+	// a proxy receives the requests to any host
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		healthy(w, r)
+	}))
+	defer proxy.Close()
+
+	tcs := []struct {
+		desc    string
+		cfg     graphql.Config
+		wantErr bool
+	}{
+		{
+			desc: "custom CA",
+			cfg:  graphql.Config{Name: "my-graphql-instance", Kind: graphql.SourceKind, Endpoint: tlsSrv.URL, Timeout: "5s", CACert: caCert, TLSMinVersion: "1.2"},
+		},
+		{
+			desc:    "unknown CA",
+			cfg:     graphql.Config{Name: "my-graphql-instance", Kind: graphql.SourceKind, Endpoint: tlsSrv.URL, Timeout: "5s"},
+			wantErr: true,
+		},
+		{
+			desc: "proxy",
+			cfg:  graphql.Config{Name: "my-graphql-instance", Kind: graphql.SourceKind, Endpoint: "http://graphql.internal.example.com/graphql", Timeout: "5s", Proxy: proxy.URL},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := tc.cfg.Initialize(ctx, tracer)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			err = s.(*graphql.Source).CheckHealth(ctx)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("unexpected result: got error %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+	if want := "http://graphql.internal.example.com/graphql"; proxied != want {
+		t.Fatalf("incorrect proxied request: got %q, want %q", proxied, want)
+	}
+
+	cfg := graphql.Config{Name: "my-graphql-instance", Kind: graphql.SourceKind, Endpoint: tlsSrv.URL, Timeout: "5s", TLSMinVersion: "1.4"}
+	if _, err := cfg.Initialize(ctx, tracer); err == nil || err.Error() != `invalid tlsMinVersion "1.4": must be one of "1.0", "1.1", "1.2", or "1.3"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
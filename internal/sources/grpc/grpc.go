@@ -0,0 +1,294 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const SourceKind string = "grpc"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                   string            `yaml:"name" validate:"required"`
+	Kind                   string            `yaml:"kind" validate:"required"`
+	Target                 string            `yaml:"target" validate:"required"`
+	Timeout                string            `yaml:"timeout"`
+	UseTLS                 bool              `yaml:"useTls"`
+	DisableSslVerification bool              `yaml:"disableSslVerification"`
+	Headers                map[string]string `yaml:"headers"`
+	DescriptorSet          string            `yaml:"descriptorSet"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+// Initialize initializes a gRPC Source instance.
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	duration, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get logger from ctx: %s", err)
+	}
+
+	creds := insecure.NewCredentials()
+	if r.UseTLS {
+		tlsConfig := &tls.Config{}
+		if r.DisableSslVerification {
+			tlsConfig.InsecureSkipVerify = true
+			logger.WarnContext(ctx, "Insecure TLS is enabled for gRPC source %s. TLS certificate verification is skipped.\n", r.Name)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(r.Target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create gRPC client: %w", err)
+	}
+
+	s := &Source{
+		Name:          r.Name,
+		Kind:          SourceKind,
+		Conn:          conn,
+		Headers:       r.Headers,
+		Timeout:       duration,
+		useReflection: r.DescriptorSet == "",
+		files:         &protoregistry.Files{},
+	}
+	if r.DescriptorSet != "" {
+		s.files, err = loadDescriptorSet(r.DescriptorSet)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load descriptorSet: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// loadDescriptorSet reads a serialized FileDescriptorSet, as written by
+// `protoc --include_imports --descriptor_set_out`.
+func loadDescriptorSet(path string) (*protoregistry.Files, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(b, &fds); err != nil {
+		return nil, err
+	}
+	return protodesc.NewFiles(&fds)
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name    string            `yaml:"name"`
+	Kind    string            `yaml:"kind"`
+	Headers map[string]string `yaml:"headers"`
+	Conn    *grpc.ClientConn
+	// Timeout is the deadline of each call.
+	Timeout time.Duration
+
+	// files holds the descriptors of the descriptorSet, or those fetched by
+	// server reflection.
+	useReflection bool
+	mu            sync.Mutex
+	files         *protoregistry.Files
+	reflected     map[string]*descriptorpb.FileDescriptorProto
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// OutgoingContext returns a context for a call, with the source's headers
+// overridden by headers as metadata, and the source's timeout.
+func (s *Source) OutgoingContext(ctx context.Context, headers map[string]string) (context.Context, context.CancelFunc) {
+	md := metadata.MD{}
+	for k, v := range s.Headers {
+		md.Set(k, v)
+	}
+	for k, v := range headers {
+		md.Set(k, v)
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	return context.WithTimeout(ctx, s.Timeout)
+}
+
+// FindMethod returns the descriptor of a method, named either
+// "package.Service/Method" or "package.Service.Method". Without a
+// descriptorSet, the method's descriptors are fetched by server reflection.
+func (s *Source) FindMethod(ctx context.Context, name string) (protoreflect.MethodDescriptor, error) {
+	fullName := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(name, "/"), "/", "."))
+	if !fullName.IsValid() {
+		return nil, fmt.Errorf("invalid method name %q", name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, err := s.files.FindDescriptorByName(fullName)
+	if err != nil && s.useReflection {
+		if err := s.reflect(ctx, fullName.Parent()); err != nil {
+			return nil, fmt.Errorf("unable to resolve method %q by server reflection: %w", name, err)
+		}
+		d, err = s.files.FindDescriptorByName(fullName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("method %q not found: %w", name, err)
+	}
+	md, ok := d.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a method", name)
+	}
+	return md, nil
+}
+
+// reflect fetches the file defining symbol, and its dependencies, by server
+// reflection.
+func (s *Source) reflect(ctx context.Context, symbol protoreflect.FullName) error {
+	ctx, cancel := s.OutgoingContext(ctx, nil)
+	defer cancel()
+	stream, err := reflectionpb.NewServerReflectionClient(s.Conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	known := make(map[string]*descriptorpb.FileDescriptorProto)
+	for name, fd := range s.reflected {
+		known[name] = fd
+	}
+	pending := []*reflectionpb.ServerReflectionRequest{{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: string(symbol)},
+	}}
+	for len(pending) > 0 {
+		req := pending[0]
+		pending = pending[1:]
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return fmt.Errorf("%s", errResp.GetErrorMessage())
+		}
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(b, fd); err != nil {
+				return err
+			}
+			known[fd.GetName()] = fd
+		}
+		// servers may leave out dependencies they already sent, or that the
+		// client is expected to know
+		for added := true; added; {
+			added = false
+			for _, fd := range known {
+				for _, dep := range fd.GetDependency() {
+					if _, ok := known[dep]; ok {
+						continue
+					}
+					added = true
+					if f, err := protoregistry.GlobalFiles.FindFileByPath(dep); err == nil {
+						// well-known types are linked into the binary
+						known[dep] = protodesc.ToFileDescriptorProto(f)
+						continue
+					}
+					known[dep] = nil
+					pending = append(pending, &reflectionpb.ServerReflectionRequest{
+						MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+					})
+				}
+			}
+		}
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	for name, fd := range known {
+		if fd == nil {
+			return fmt.Errorf("server didn't send %q", name)
+		}
+		fds.File = append(fds.File, fd)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return err
+	}
+	s.files, s.reflected = files, known
+	return nil
+}
+
+// CheckHealth calls the standard gRPC health service. Servers that don't
+// implement it are considered healthy, since they responded.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	ctx, cancel := s.OutgoingContext(ctx, nil)
+	defer cancel()
+	resp, err := healthpb.NewHealthClient(s.Conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("server is %s", resp.GetStatus())
+	}
+	return nil
+}
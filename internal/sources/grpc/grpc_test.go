@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	grpcsrc "github.com/googleapis/genai-toolbox/internal/sources/grpc"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestParseFromYamlGRPC(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-grpc-instance:
+					kind: grpc
+					target: localhost:50051
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-grpc-instance": grpcsrc.Config{
+					Name:    "my-grpc-instance",
+					Kind:    grpcsrc.SourceKind,
+					Target:  "localhost:50051",
+					Timeout: "30s",
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			sources:
+				my-grpc-instance:
+					kind: grpc
+					target: api.example.com:443
+					timeout: 10s
+					useTls: true
+					headers:
+						authorization: Bearer test_token
+					descriptorSet: ./service.pb
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-grpc-instance": grpcsrc.Config{
+					Name:          "my-grpc-instance",
+					Kind:          grpcsrc.SourceKind,
+					Target:        "api.example.com:443",
+					Timeout:       "10s",
+					UseTLS:        true,
+					Headers:       map[string]string{"authorization": "Bearer test_token"},
+					DescriptorSet: "./service.pb",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// startServer starts a gRPC server with the health service, and optionally
+// server reflection, and returns its address.
+func startServer(t *testing.T, withReflection bool) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	if withReflection {
+		reflection.Register(s)
+	}
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+func initialize(t *testing.T, cfg grpcsrc.Config) *grpcsrc.Source {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg.Name, cfg.Kind, cfg.Timeout = "my-grpc-instance", grpcsrc.SourceKind, "5s"
+	s, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return s.(*grpcsrc.Source)
+}
+
+func TestFindMethod(t *testing.T) {
+	ctx := context.Background()
+
+	// by server reflection
+	src := initialize(t, grpcsrc.Config{Target: startServer(t, true)})
+	if err := src.CheckHealth(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, name := range []string{"grpc.health.v1.Health/Check", "grpc.health.v1.Health.Check"} {
+		m, err := src.FindMethod(ctx, name)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if m.Input().FullName() != "grpc.health.v1.HealthCheckRequest" {
+			t.Fatalf("incorrect input: got %s", m.Input().FullName())
+		}
+	}
+	if _, err := src.FindMethod(ctx, "grpc.health.v1.Health/Missing"); err == nil {
+		t.Fatalf("expected error for missing method")
+	}
+
+	// by descriptor set, without server reflection
+	fds := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto),
+	}}
+	b, err := proto.Marshal(fds)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "health.pb")
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src = initialize(t, grpcsrc.Config{Target: startServer(t, false), DescriptorSet: path})
+	if _, err := src.FindMethod(ctx, "grpc.health.v1.Health/Watch"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := src.FindMethod(ctx, "grpc.reflection.v1.ServerReflection/ServerReflectionInfo"); err == nil {
+		t.Fatalf("expected error for method missing from the descriptor set")
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -54,12 +55,25 @@ type Config struct {
 	DefaultHeaders         map[string]string `yaml:"headers"`
 	QueryParams            map[string]string `yaml:"queryParams"`
 	DisableSslVerification bool              `yaml:"disableSslVerification"`
+	Proxy                  string            `yaml:"proxy"`
+	CACert                 string            `yaml:"caCert"`
+	TLSMinVersion          string            `yaml:"tlsMinVersion"`
+	Retry                  retry.Config      `yaml:"retry"`
+	// ClientOAuthService is the name of the auth service whose verified OAuth
+	// access token of the caller is sent as the bearer token of the requests.
+	ClientOAuthService string `yaml:"clientOAuthService"`
 }
 
 func (r Config) SourceConfigKind() string {
 	return SourceKind
 }
 
+var _ sources.ClientOAuthConfig = Config{}
+
+func (r Config) GetClientOAuthService() string {
+	return r.ClientOAuthService
+}
+
 // Initialize initializes an HTTP Source instance.
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	duration, err := time.ParseDuration(r.Timeout)
@@ -81,10 +95,18 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 
 		logger.WarnContext(ctx, "Insecure HTTP is enabled for HTTP source %s. TLS certificate verification is skipped.\n", r.Name)
 	}
+	if err := sources.ConfigureTransport(tr, r.Proxy, r.CACert, r.TLSMinVersion); err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = tr
+	if r.ClientOAuthService != "" {
+		transport = sources.ClientOAuthTransport(tr, r.ClientOAuthService)
+	}
 
 	client := http.Client{
 		Timeout:   duration,
-		Transport: tr,
+		Transport: transport,
 	}
 
 	// Validate BaseURL
@@ -93,6 +115,11 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		return nil, fmt.Errorf("failed to parse BaseUrl %v", err)
 	}
 
+	retryPolicy, err := r.Retry.Policy()
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry policy: %w", err)
+	}
+
 	s := &Source{
 		Name:           r.Name,
 		Kind:           SourceKind,
@@ -100,12 +127,16 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 		DefaultHeaders: r.DefaultHeaders,
 		QueryParams:    r.QueryParams,
 		Client:         &client,
+		RetryPolicy:    retryPolicy,
+		// health checks have no caller whose access token could be sent
+		healthClient: &http.Client{Timeout: duration, Transport: tr},
 	}
 	return s, nil
 
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
 
 type Source struct {
 	Name           string            `yaml:"name"`
@@ -114,8 +145,38 @@ type Source struct {
 	DefaultHeaders map[string]string `yaml:"headers"`
 	QueryParams    map[string]string `yaml:"queryParams"`
 	Client         *http.Client
+	RetryPolicy    retry.Policy
+	// healthClient sends the health checks without the credentials of a
+	// caller, or is nil to send them with Client.
+	healthClient *http.Client
 }
 
 func (s *Source) SourceKind() string {
 	return SourceKind
 }
+
+// CheckHealth sends a HEAD request to the base URL. Any response other than a
+// server error means the server is reachable, including an authentication
+// error for a source that sends the access token of the caller.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.BaseURL, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range s.DefaultHeaders {
+		req.Header.Set(k, v)
+	}
+	client := s.Client
+	if s.healthClient != nil {
+		client = s.healthClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -15,6 +15,8 @@
 package http_test
 
 import (
+	nethttp "net/http"
+	"net/http/httptest"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -23,6 +25,8 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/http"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlHttp(t *testing.T) {
@@ -64,6 +68,9 @@ func TestParseFromYamlHttp(t *testing.T) {
 						api-key: test_api_key
 						param: param-value
 					disableSslVerification: true
+					retry:
+						maxRetries: 3
+						initialBackoff: 500ms
 			`,
 			want: map[string]sources.SourceConfig{
 				"my-http-instance": http.Config{
@@ -74,6 +81,49 @@ func TestParseFromYamlHttp(t *testing.T) {
 					DefaultHeaders:         map[string]string{"Authorization": "test_header", "Custom-Header": "custom"},
 					QueryParams:            map[string]string{"api-key": "test_api_key", "param": "param-value"},
 					DisableSslVerification: true,
+					Retry:                  retry.Config{MaxRetries: 3, InitialBackoff: "500ms"},
+				},
+			},
+		},
+		{
+			desc: "client oauth",
+			in: `
+			sources:
+				my-http-instance:
+					kind: http
+					baseUrl: http://test_server/
+					clientOAuthService: my-google-auth
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-http-instance": http.Config{
+					Name:               "my-http-instance",
+					Kind:               http.SourceKind,
+					BaseURL:            "http://test_server/",
+					Timeout:            "30s",
+					ClientOAuthService: "my-google-auth",
+				},
+			},
+		},
+		{
+			desc: "egress",
+			in: `
+			sources:
+				my-http-instance:
+					kind: http
+					baseUrl: https://api.example.com/
+					proxy: http://proxy.example.com:3128
+					caCert: /etc/ssl/corporate-ca.pem
+					tlsMinVersion: "1.3"
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-http-instance": http.Config{
+					Name:          "my-http-instance",
+					Kind:          http.SourceKind,
+					BaseURL:       "https://api.example.com/",
+					Timeout:       "30s",
+					Proxy:         "http://proxy.example.com:3128",
+					CACert:        "/etc/ssl/corporate-ca.pem",
+					TLSMinVersion: "1.3",
 				},
 			},
 		},
@@ -144,3 +194,26 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckHealthClientOAuth(t *testing.T) {
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(nethttp.StatusUnauthorized)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	cfg := http.Config{Name: "my-http", Kind: http.SourceKind, BaseURL: ts.URL, Timeout: "10s", ClientOAuthService: "my-google-auth"}
+	src, err := cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	// the health check has no caller whose access token could be sent
+	if err := src.(*http.Source).CheckHealth(ctx); err != nil {
+		t.Fatalf("unexpected health check error: %s", err)
+	}
+}
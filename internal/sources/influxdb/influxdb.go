@@ -0,0 +1,225 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package influxdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "influxdb"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name  string `yaml:"name" validate:"required"`
+	Kind  string `yaml:"kind" validate:"required"`
+	URL   string `yaml:"url" validate:"required"`
+	Token string `yaml:"token"`
+	Org   string `yaml:"org"`
+	// Database is the database, or the bucket mapped to it, that InfluxQL
+	// queries run in.
+	Database string `yaml:"database"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client := initInfluxDBClient(ctx, tracer, r)
+
+	s := &Source{
+		Name:       r.Name,
+		Kind:       SourceKind,
+		Client:     client,
+		URL:        strings.TrimSuffix(r.URL, "/"),
+		Token:      r.Token,
+		Org:        r.Org,
+		Database:   r.Database,
+		HTTPClient: client.Options().HTTPClient(),
+	}
+	if err := s.CheckHealth(ctx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name       string `yaml:"name"`
+	Kind       string `yaml:"kind"`
+	Client     influxdb2.Client
+	URL        string
+	Token      string
+	Org        string
+	Database   string
+	HTTPClient *http.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) InfluxDBClient() influxdb2.Client {
+	return s.Client
+}
+
+// CheckHealth pings the server, which is supported by InfluxDB 1.8 and later.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	_, err := s.Client.Ping(ctx)
+	return err
+}
+
+// RunFlux runs a Flux query and returns the records of its tables.
+func (s *Source) RunFlux(ctx context.Context, query string) ([]any, error) {
+	result, err := s.Client.QueryAPI(s.Org).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	out := []any{}
+	for result.Next() {
+		out = append(out, result.Record().Values())
+	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// influxQLResponse is the response of the InfluxQL query endpoint.
+type influxQLResponse struct {
+	Results []struct {
+		Series []struct {
+			Name    string            `json:"name"`
+			Tags    map[string]string `json:"tags"`
+			Columns []string          `json:"columns"`
+			Values  [][]any           `json:"values"`
+		} `json:"series"`
+		Error string `json:"error"`
+	} `json:"results"`
+	Error string `json:"error"`
+}
+
+// RunInfluxQL runs an InfluxQL query in the database of the source, binding
+// params to its `$name` placeholders. It returns a row for each point of the
+// resulting series, with the tags and the measurement of the series.
+func (s *Source) RunInfluxQL(ctx context.Context, query string, params map[string]any) ([]any, error) {
+	form := url.Values{}
+	form.Set("q", query)
+	if s.Database != "" {
+		form.Set("db", s.Database)
+	}
+	if len(params) > 0 {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal params: %w", err)
+		}
+		form.Set("params", string(b))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL+"/query", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Token "+s.Token)
+	}
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response: %w", err)
+	}
+	var r influxQLResponse
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&r); err != nil {
+		return nil, fmt.Errorf("unexpected response (status %d): %s", resp.StatusCode, body)
+	}
+	if r.Error != "" {
+		return nil, fmt.Errorf("%s", r.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response (status %d): %s", resp.StatusCode, body)
+	}
+
+	out := []any{}
+	for _, result := range r.Results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("%s", result.Error)
+		}
+		for _, series := range result.Series {
+			for _, values := range series.Values {
+				row := map[string]any{"_measurement": series.Name}
+				for k, v := range series.Tags {
+					row[k] = v
+				}
+				for i, c := range series.Columns {
+					if i < len(values) {
+						row[c] = values[i]
+					}
+				}
+				out = append(out, row)
+			}
+		}
+	}
+	return out, nil
+}
+
+func initInfluxDBClient(ctx context.Context, tracer trace.Tracer, r Config) influxdb2.Client {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	return influxdb2.NewClient(r.URL, r.Token)
+}
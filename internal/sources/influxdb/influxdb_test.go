@@ -0,0 +1,188 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/influxdb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlInfluxDB(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-influxdb:
+					kind: influxdb
+					url: http://localhost:8086
+					token: my-token
+					org: my-org
+					database: telegraf
+			`,
+			want: server.SourceConfigs{
+				"my-influxdb": influxdb.Config{
+					Name:     "my-influxdb",
+					Kind:     influxdb.SourceKind,
+					URL:      "http://localhost:8086",
+					Token:    "my-token",
+					Org:      "my-org",
+					Database: "telegraf",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+const fluxResponse = `#datatype,string,long,dateTime:RFC3339,double,string,string
+#group,false,false,false,false,true,true
+#default,_result,,,,,
+,result,table,_time,_value,_field,host
+,,0,2025-01-01T00:00:00Z,1.5,usage,web-1
+,,0,2025-01-01T00:01:00Z,2.5,usage,web-1
+
+`
+
+const influxQLResponse = `{"results":[{"statement_id":0,"series":[{"name":"cpu","tags":{"host":"web-1"},"columns":["time","mean"],"values":[["2025-01-01T00:00:00Z",1.5],["2025-01-01T00:01:00Z",2]]}]}]}`
+
+// newFakeInfluxDB returns a server that answers pings and queries, and
+// records the form of the last InfluxQL query.
+func newFakeInfluxDB(t *testing.T, form map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Token my-token" {
+			t.Errorf("unexpected authorization %q", got)
+		}
+		switch r.URL.Path {
+		case "/ping":
+			w.WriteHeader(http.StatusNoContent)
+		case "/api/v2/query":
+			if got := r.URL.Query().Get("org"); got != "my-org" {
+				t.Errorf("unexpected org %q", got)
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte(fluxResponse))
+		case "/query":
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("unable to parse form: %s", err)
+			}
+			for k := range r.PostForm {
+				form[k] = r.PostForm.Get(k)
+			}
+			if strings.Contains(r.PostForm.Get("q"), "bad") {
+				_, _ = w.Write([]byte(`{"results":[{"statement_id":0,"error":"error parsing query"}]}`))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(influxQLResponse))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func initSource(t *testing.T, url string) *influxdb.Source {
+	cfg := influxdb.Config{
+		Name:     "my-influxdb",
+		Kind:     influxdb.SourceKind,
+		URL:      url,
+		Token:    "my-token",
+		Org:      "my-org",
+		Database: "telegraf",
+	}
+	s, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	return s.(*influxdb.Source)
+}
+
+func TestRunFlux(t *testing.T) {
+	ts := newFakeInfluxDB(t, map[string]string{})
+	defer ts.Close()
+	s := initSource(t, ts.URL)
+
+	got, err := s.RunFlux(context.Background(), `from(bucket: "telegraf") |> range(start: -1h)`)
+	if err != nil {
+		t.Fatalf("unable to run query: %s", err)
+	}
+	want := []any{
+		map[string]any{"result": "_result", "table": int64(0), "_time": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "_value": 1.5, "_field": "usage", "host": "web-1"},
+		map[string]any{"result": "_result", "table": int64(0), "_time": time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC), "_value": 2.5, "_field": "usage", "host": "web-1"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect results: diff %v", diff)
+	}
+}
+
+func TestRunInfluxQL(t *testing.T) {
+	form := map[string]string{}
+	ts := newFakeInfluxDB(t, form)
+	defer ts.Close()
+	s := initSource(t, ts.URL)
+
+	query := "SELECT mean(usage) FROM cpu WHERE host = $host GROUP BY host"
+	got, err := s.RunInfluxQL(context.Background(), query, map[string]any{"host": "web-1"})
+	if err != nil {
+		t.Fatalf("unable to run query: %s", err)
+	}
+	want := []any{
+		map[string]any{"_measurement": "cpu", "host": "web-1", "time": "2025-01-01T00:00:00Z", "mean": json.Number("1.5")},
+		map[string]any{"_measurement": "cpu", "host": "web-1", "time": "2025-01-01T00:01:00Z", "mean": json.Number("2")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect results: diff %v", diff)
+	}
+	wantForm := map[string]string{"q": query, "db": "telegraf", "params": `{"host":"web-1"}`}
+	if diff := cmp.Diff(wantForm, form); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+
+	_, err = s.RunInfluxQL(context.Background(), "SELECT bad", nil)
+	if err == nil || !strings.Contains(err.Error(), "error parsing query") {
+		t.Fatalf("unexpected error: got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package mongodb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "mongodb"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                   string `yaml:"name" validate:"required"`
+	Kind                   string `yaml:"kind" validate:"required"`
+	Uri                    string `yaml:"uri" validate:"required"`
+	Database               string `yaml:"database" validate:"required"`
+	Username               string `yaml:"username"`
+	Password               string `yaml:"password"`
+	AuthSource             string `yaml:"authSource"`
+	TLS                    bool   `yaml:"tls"`
+	CACert                 string `yaml:"caCert"`
+	DisableSslVerification bool   `yaml:"disableSslVerification"`
+	Timeout                string `yaml:"timeout"`
+	ReadOnly               bool   `yaml:"readOnly"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	duration, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+	opts, err := r.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts.SetTimeout(duration)
+
+	client, err := mongo.Connect(opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create MongoDB client: %w", err)
+	}
+	s := &Source{
+		Name:     r.Name,
+		Kind:     SourceKind,
+		Client:   client,
+		Database: client.Database(r.Database),
+		ReadOnly: r.ReadOnly,
+	}
+	if err := s.CheckHealth(ctx); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+// clientOptions returns the options of the client, which are set by the
+// config on top of the connection string.
+func (r Config) clientOptions() (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(r.Uri)
+	if r.Username != "" {
+		opts.SetAuth(options.Credential{
+			AuthSource: r.AuthSource,
+			Username:   r.Username,
+			Password:   r.Password,
+		})
+	}
+	if r.TLS || r.CACert != "" || r.DisableSslVerification {
+		tlsConfig := &tls.Config{InsecureSkipVerify: r.DisableSslVerification}
+		if r.CACert != "" {
+			caCert, err := os.ReadFile(r.CACert)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read caCert: %w", err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("caCert %q contains no PEM certificates", r.CACert)
+			}
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+	return opts, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name     string `yaml:"name"`
+	Kind     string `yaml:"kind"`
+	Client   *mongo.Client
+	Database *mongo.Database
+	// ReadOnly prevents the tools of the source from writing to the
+	// database.
+	ReadOnly bool `yaml:"readOnly"`
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// CheckHealth pings the deployment, with the read preference of the client.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Client.Ping(ctx, nil)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodb_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/mongodb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlMongoDB(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-mongodb-instance:
+					kind: mongodb
+					uri: mongodb://localhost:27017
+					database: my_db
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-mongodb-instance": mongodb.Config{
+					Name:     "my-mongodb-instance",
+					Kind:     mongodb.SourceKind,
+					Uri:      "mongodb://localhost:27017",
+					Database: "my_db",
+					Timeout:  "30s",
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			sources:
+				my-mongodb-instance:
+					kind: mongodb
+					uri: mongodb://node-1:27017,node-2:27017/?replicaSet=rs0
+					database: my_db
+					username: my_user
+					password: my_pass
+					authSource: admin
+					tls: true
+					caCert: /path/to/ca.pem
+					timeout: 10s
+					readOnly: true
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-mongodb-instance": mongodb.Config{
+					Name:       "my-mongodb-instance",
+					Kind:       mongodb.SourceKind,
+					Uri:        "mongodb://node-1:27017,node-2:27017/?replicaSet=rs0",
+					Database:   "my_db",
+					Username:   "my_user",
+					Password:   "my_pass",
+					AuthSource: "admin",
+					TLS:        true,
+					CACert:     "/path/to/ca.pem",
+					Timeout:    "10s",
+					ReadOnly:   true,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	in := `
+	sources:
+		my-mongodb-instance:
+			kind: mongodb
+			uri: mongodb://localhost:27017
+	`
+	got := struct {
+		Sources server.SourceConfigs `yaml:"sources"`
+	}{}
+	err := yaml.Unmarshal(testutils.FormatYaml(in), &got)
+	if err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+	want := "unable to parse source \"my-mongodb-instance\" as \"mongodb\": Key: 'Config.Database' Error:Field validation for 'Database' failed on the 'required' tag"
+	if err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		cfg  mongodb.Config
+		want string
+	}{
+		{
+			desc: "invalid uri",
+			cfg:  mongodb.Config{Name: "my-mongodb-instance", Kind: mongodb.SourceKind, Uri: "localhost:27017", Database: "my_db", Timeout: "30s"},
+			want: `unable to create MongoDB client: error parsing uri: scheme must be "mongodb" or "mongodb+srv"`,
+		},
+		{
+			desc: "missing ca cert",
+			cfg:  mongodb.Config{Name: "my-mongodb-instance", Kind: mongodb.SourceKind, Uri: "mongodb://localhost:27017", Database: "my_db", CACert: "/does/not/exist.pem", Timeout: "30s"},
+			want: "unable to read caCert: open /does/not/exist.pem: no such file or directory",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
@@ -23,11 +23,21 @@ import (
 	"github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	_ "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/azuread"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceKind string = "mssql"
 
+// Methods of authenticating: with a SQL login, or with Microsoft Entra ID
+// (formerly Azure Active Directory).
+const (
+	AuthMethodSQL              = "sql"
+	AuthMethodDefault          = "default"
+	AuthMethodServicePrincipal = "servicePrincipal"
+	AuthMethodManagedIdentity  = "managedIdentity"
+)
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
@@ -38,7 +48,7 @@ func init() {
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
-	actual := Config{Name: name}
+	actual := Config{Name: name, AuthMethod: AuthMethodSQL}
 	if err := decoder.DecodeContext(ctx, &actual); err != nil {
 		return nil, err
 	}
@@ -51,9 +61,16 @@ type Config struct {
 	Kind     string `yaml:"kind" validate:"required"`
 	Host     string `yaml:"host" validate:"required"`
 	Port     string `yaml:"port" validate:"required"`
-	User     string `yaml:"user" validate:"required"`
-	Password string `yaml:"password" validate:"required"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
 	Database string `yaml:"database" validate:"required"`
+	// Microsoft Entra ID configs
+	AuthMethod   string `yaml:"authMethod"`
+	TenantID     string `yaml:"tenantId"`
+	ClientID     string `yaml:"clientId"`
+	ClientSecret string `yaml:"clientSecret"`
+
+	sources.PoolConfig `yaml:",inline"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -63,10 +80,18 @@ func (r Config) SourceConfigKind() string {
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	// Initializes a MSSQL source
-	db, err := initMssqlConnection(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database)
+	driver, dsn, err := r.dsn()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := initMssqlConnection(ctx, tracer, r.Name, driver, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create db connection: %w", err)
 	}
+	if err := r.ConfigureDB(db); err != nil {
+		return nil, err
+	}
 
 	// Verify db connection
 	err = db.PingContext(ctx)
@@ -82,7 +107,64 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	return s, nil
 }
 
+// dsn returns the driver and the connection string of the source. The
+// methods of Microsoft Entra ID use the azuread driver, which gets tokens for
+// each new connection.
+func (r Config) dsn() (string, string, error) {
+	query := url.Values{}
+	query.Add("database", r.Database)
+
+	driver := azuread.DriverName
+	var user *url.Userinfo
+	switch r.AuthMethod {
+	case AuthMethodSQL:
+		if r.User == "" || r.Password == "" {
+			return "", "", fmt.Errorf("user and password must be set with authMethod %q", r.AuthMethod)
+		}
+		driver = "sqlserver"
+		user = url.UserPassword(r.User, r.Password)
+	case AuthMethodDefault:
+		if r.ClientID != "" || r.ClientSecret != "" {
+			return "", "", fmt.Errorf("clientId and clientSecret can't be set with authMethod %q", r.AuthMethod)
+		}
+		query.Add("fedauth", azuread.ActiveDirectoryDefault)
+	case AuthMethodServicePrincipal:
+		if r.ClientID == "" || r.ClientSecret == "" {
+			return "", "", fmt.Errorf("clientId and clientSecret must be set with authMethod %q", r.AuthMethod)
+		}
+		query.Add("fedauth", azuread.ActiveDirectoryServicePrincipal)
+		clientID := r.ClientID
+		if r.TenantID != "" {
+			clientID = fmt.Sprintf("%s@%s", r.ClientID, r.TenantID)
+		}
+		user = url.UserPassword(clientID, r.ClientSecret)
+	case AuthMethodManagedIdentity:
+		if r.ClientSecret != "" {
+			return "", "", fmt.Errorf("clientSecret can't be set with authMethod %q", r.AuthMethod)
+		}
+		query.Add("fedauth", azuread.ActiveDirectoryManagedIdentity)
+		// the client ID selects a user-assigned identity
+		if r.ClientID != "" {
+			user = url.User(r.ClientID)
+		}
+	default:
+		return "", "", fmt.Errorf("invalid authMethod %q", r.AuthMethod)
+	}
+	if r.AuthMethod != AuthMethodSQL && (r.User != "" || r.Password != "") {
+		return "", "", fmt.Errorf("user and password can't be set with authMethod %q", r.AuthMethod)
+	}
+
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		User:     user,
+		Host:     fmt.Sprintf("%s:%s", r.Host, r.Port),
+		RawQuery: query.Encode(),
+	}
+	return driver, u.String(), nil
+}
+
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
 
 type Source struct {
 	// Cloud SQL MSSQL struct with connection pool
@@ -101,23 +183,18 @@ func (s *Source) MSSQLDB() *sql.DB {
 	return s.Db
 }
 
-func initMssqlConnection(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string) (*sql.DB, error) {
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Db.PingContext(ctx)
+}
+
+func initMssqlConnection(ctx context.Context, tracer trace.Tracer, name, driver, dsn string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
 
-	// Create dsn
-	query := url.Values{}
-	query.Add("database", dbname)
-	url := &url.URL{
-		Scheme:   "sqlserver",
-		User:     url.UserPassword(user, pass),
-		Host:     fmt.Sprintf("%s:%s", host, port),
-		RawQuery: query.Encode(),
-	}
-
 	// Open database connection
-	db, err := sql.Open("sqlserver", url.String())
+	db, err := sql.Open(driver, dsn)
 	if err != nil {
 		return nil, fmt.Errorf("sql.Open: %w", err)
 	}
@@ -15,6 +15,7 @@
 package mssql_test
 
 import (
+	"context"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -22,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlMssql(t *testing.T) {
@@ -44,13 +46,38 @@ func TestParseFromYamlMssql(t *testing.T) {
 			`,
 			want: server.SourceConfigs{
 				"my-mssql-instance": mssql.Config{
-					Name:     "my-mssql-instance",
-					Kind:     mssql.SourceKind,
-					Host:     "0.0.0.0",
-					Port:     "my-port",
-					Database: "my_db",
-					User:     "my_user",
-					Password: "my_pass",
+					Name:       "my-mssql-instance",
+					Kind:       mssql.SourceKind,
+					Host:       "0.0.0.0",
+					Port:       "my-port",
+					Database:   "my_db",
+					User:       "my_user",
+					Password:   "my_pass",
+					AuthMethod: mssql.AuthMethodSQL,
+				},
+			},
+		},
+		{
+			desc: "managed identity",
+			in: `
+			sources:
+				my-mssql-instance:
+					kind: mssql
+					host: my-server.database.windows.net
+					port: 1433
+					database: my_db
+					authMethod: managedIdentity
+					clientId: my-client
+			`,
+			want: server.SourceConfigs{
+				"my-mssql-instance": mssql.Config{
+					Name:       "my-mssql-instance",
+					Kind:       mssql.SourceKind,
+					Host:       "my-server.database.windows.net",
+					Port:       "1433",
+					Database:   "my_db",
+					AuthMethod: mssql.AuthMethodManagedIdentity,
+					ClientID:   "my-client",
 				},
 			},
 		},
@@ -101,10 +128,10 @@ func TestFailParseFromYaml(t *testing.T) {
 					kind: mssql
 					host: 0.0.0.0
 					port: my-port
-					database: my_db
 					user: my_user
+					password: my_pass
 			`,
-			err: "unable to parse source \"my-mssql-instance\" as \"mssql\": Key: 'Config.Password' Error:Field validation for 'Password' failed on the 'required' tag",
+			err: "unable to parse source \"my-mssql-instance\" as \"mssql\": Key: 'Config.Database' Error:Field validation for 'Database' failed on the 'required' tag",
 		},
 	}
 	for _, tc := range tcs {
@@ -124,3 +151,54 @@ func TestFailParseFromYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestInitializeInvalidAuthMssql(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  mssql.Config
+		err  string
+	}{
+		{
+			desc: "unknown auth method",
+			cfg:  mssql.Config{AuthMethod: "password"},
+			err:  `invalid authMethod "password"`,
+		},
+		{
+			desc: "sql without password",
+			cfg:  mssql.Config{AuthMethod: mssql.AuthMethodSQL, User: "my_user"},
+			err:  `user and password must be set with authMethod "sql"`,
+		},
+		{
+			desc: "default with user",
+			cfg:  mssql.Config{AuthMethod: mssql.AuthMethodDefault, User: "my_user", Password: "my_pass"},
+			err:  `user and password can't be set with authMethod "default"`,
+		},
+		{
+			desc: "service principal without secret",
+			cfg:  mssql.Config{AuthMethod: mssql.AuthMethodServicePrincipal, ClientID: "my-client"},
+			err:  `clientId and clientSecret must be set with authMethod "servicePrincipal"`,
+		},
+		{
+			desc: "managed identity with client secret",
+			cfg:  mssql.Config{AuthMethod: mssql.AuthMethodManagedIdentity, ClientSecret: "my-secret"},
+			err:  `clientSecret can't be set with authMethod "managedIdentity"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Name = "my-mssql-instance"
+			cfg.Kind = mssql.SourceKind
+			cfg.Host = "0.0.0.0"
+			cfg.Port = "1433"
+			cfg.Database = "my_db"
+			_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+			if err == nil {
+				t.Fatalf("expect error")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
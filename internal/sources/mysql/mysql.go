@@ -54,6 +54,8 @@ type Config struct {
 	Password     string `yaml:"password" validate:"required"`
 	Database     string `yaml:"database" validate:"required"`
 	QueryTimeout string `yaml:"queryTimeout"`
+
+	sources.PoolConfig `yaml:",inline"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -65,6 +67,9 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
+	if err := r.ConfigureDB(pool); err != nil {
+		return nil, err
+	}
 
 	err = pool.PingContext(ctx)
 	if err != nil {
@@ -80,6 +85,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+var _ sources.SchemaDescriber = &Source{}
 
 type Source struct {
 	Name string `yaml:"name"`
@@ -95,6 +102,21 @@ func (s *Source) MySQLPool() *sql.DB {
 	return s.Pool
 }
 
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Pool.PingContext(ctx)
+}
+
+// ListTables returns the tables of the database.
+func (s *Source) ListTables(ctx context.Context) ([]string, error) {
+	return sources.ListMySQLTables(ctx, s.Pool)
+}
+
+// DescribeTable returns the DDL of a table.
+func (s *Source) DescribeTable(ctx context.Context, table string) (string, error) {
+	return sources.DescribeMySQLTable(ctx, s.Pool, table)
+}
+
 func initMySQLConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname, queryTimeout string) (*sql.DB, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
@@ -80,6 +80,7 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
 
 type Source struct {
 	Name     string `yaml:"name"`
@@ -96,6 +97,11 @@ func (s *Source) Neo4jDriver() neo4j.DriverWithContext {
 	return s.Driver
 }
 
+// CheckHealth verifies the driver can connect to the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Driver.VerifyConnectivity(ctx)
+}
+
 func (s *Source) Neo4jDatabase() string {
 	return s.Database
 }
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinecone
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/vectorstore"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "pinecone"
+
+// apiVersion is the version of the data plane API of Pinecone.
+const apiVersion = "2025-01"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name string `yaml:"name" validate:"required"`
+	Kind string `yaml:"kind" validate:"required"`
+	// Host is the URL of the index, e.g.
+	// https://my-index-abc123.svc.us-east1-gcp.pinecone.io.
+	Host    string `yaml:"host" validate:"required"`
+	APIKey  string `yaml:"apiKey" validate:"required"`
+	Timeout string `yaml:"timeout"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+// Initialize initializes a Pinecone Source instance.
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	header := http.Header{}
+	header.Set("Api-Key", r.APIKey)
+	header.Set("X-Pinecone-API-Version", apiVersion)
+	client, err := vectorstore.NewClient(r.Host, r.Timeout, header)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		Name:   r.Name,
+		Kind:   SourceKind,
+		Client: client,
+	}
+	if err := s.CheckHealth(ctx); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	*vectorstore.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+// CheckHealth checks that the index responds to a request of its statistics.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	_, err := s.Do(ctx, http.MethodPost, "/describe_index_stats", map[string]any{})
+	return err
+}
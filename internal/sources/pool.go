@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolConfig tunes the connection pool of a database source. Unset fields
+// keep the defaults of the driver.
+type PoolConfig struct {
+	MaxOpenConns    int    `yaml:"maxOpenConns" validate:"gte=0"`
+	MaxIdleConns    int    `yaml:"maxIdleConns" validate:"gte=0"`
+	ConnMaxLifetime string `yaml:"connMaxLifetime"`
+	ConnMaxIdleTime string `yaml:"connMaxIdleTime"`
+}
+
+// durations parses ConnMaxLifetime and ConnMaxIdleTime, which are 0 if unset.
+func (p PoolConfig) durations() (lifetime, idleTime time.Duration, err error) {
+	if p.MaxOpenConns < 0 || p.MaxIdleConns < 0 {
+		return 0, 0, fmt.Errorf("maxOpenConns and maxIdleConns must not be negative")
+	}
+	if p.ConnMaxLifetime != "" {
+		lifetime, err = time.ParseDuration(p.ConnMaxLifetime)
+		if err != nil || lifetime <= 0 {
+			return 0, 0, fmt.Errorf("invalid connMaxLifetime %q: must be a positive duration, such as \"30m\"", p.ConnMaxLifetime)
+		}
+	}
+	if p.ConnMaxIdleTime != "" {
+		idleTime, err = time.ParseDuration(p.ConnMaxIdleTime)
+		if err != nil || idleTime <= 0 {
+			return 0, 0, fmt.Errorf("invalid connMaxIdleTime %q: must be a positive duration, such as \"5m\"", p.ConnMaxIdleTime)
+		}
+	}
+	return lifetime, idleTime, nil
+}
+
+// ConfigureDB applies the pool config to a database/sql connection pool.
+func (p PoolConfig) ConfigureDB(db *sql.DB) error {
+	lifetime, idleTime, err := p.durations()
+	if err != nil {
+		return err
+	}
+	if p.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(p.MaxOpenConns)
+	}
+	if p.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(p.MaxIdleConns)
+	}
+	if lifetime > 0 {
+		db.SetConnMaxLifetime(lifetime)
+	}
+	if idleTime > 0 {
+		db.SetConnMaxIdleTime(idleTime)
+	}
+	return nil
+}
+
+// ConfigurePgxPool applies the pool config to the config of a pgx connection
+// pool, which closes idle connections after ConnMaxIdleTime rather than
+// keeping up to MaxIdleConns of them, so MaxIdleConns isn't supported.
+func (p PoolConfig) ConfigurePgxPool(cfg *pgxpool.Config) error {
+	lifetime, idleTime, err := p.durations()
+	if err != nil {
+		return err
+	}
+	if p.MaxIdleConns > 0 {
+		return fmt.Errorf("maxIdleConns isn't supported by PostgreSQL sources, use connMaxIdleTime instead")
+	}
+	if p.MaxOpenConns > 0 {
+		cfg.MaxConns = int32(p.MaxOpenConns)
+	}
+	if lifetime > 0 {
+		cfg.MaxConnLifetime = lifetime
+	}
+	if idleTime > 0 {
+		cfg.MaxConnIdleTime = idleTime
+	}
+	return nil
+}
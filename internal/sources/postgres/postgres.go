@@ -52,6 +52,8 @@ type Config struct {
 	User     string `yaml:"user" validate:"required"`
 	Password string `yaml:"password" validate:"required"`
 	Database string `yaml:"database" validate:"required"`
+
+	sources.PoolConfig `yaml:",inline"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -59,7 +61,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	pool, err := initPostgresConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database)
+	pool, err := initPostgresConnectionPool(ctx, tracer, r.Name, r.Host, r.Port, r.User, r.Password, r.Database, r.PoolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create pool: %w", err)
 	}
@@ -78,6 +80,8 @@ func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.So
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+var _ sources.SchemaDescriber = &Source{}
 
 type Source struct {
 	Name string `yaml:"name"`
@@ -93,7 +97,22 @@ func (s *Source) PostgresPool() *pgxpool.Pool {
 	return s.Pool
 }
 
-func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string) (*pgxpool.Pool, error) {
+// CheckHealth pings the database.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Pool.Ping(ctx)
+}
+
+// ListTables returns the tables of the database.
+func (s *Source) ListTables(ctx context.Context) ([]string, error) {
+	return sources.ListPostgresTables(ctx, s.Pool)
+}
+
+// DescribeTable returns the DDL of a table.
+func (s *Source) DescribeTable(ctx context.Context, table string) (string, error) {
+	return sources.DescribePostgresTable(ctx, s.Pool, table)
+}
+
+func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name, host, port, user, pass, dbname string, poolConfig sources.PoolConfig) (*pgxpool.Pool, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
@@ -105,7 +124,14 @@ func initPostgresConnectionPool(ctx context.Context, tracer trace.Tracer, name,
 		Host:   fmt.Sprintf("%s:%s", host, port),
 		Path:   dbname,
 	}
-	pool, err := pgxpool.New(ctx, url.String())
+	config, err := pgxpool.ParseConfig(url.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse connection uri: %w", err)
+	}
+	if err := poolConfig.ConfigurePgxPool(config); err != nil {
+		return nil, err
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
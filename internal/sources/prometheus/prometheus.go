@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package prometheus
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "prometheus"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, Timeout: "30s"} // Default timeout
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                   string            `yaml:"name" validate:"required"`
+	Kind                   string            `yaml:"kind" validate:"required"`
+	URL                    string            `yaml:"url" validate:"required"`
+	Username               string            `yaml:"username"`
+	Password               string            `yaml:"password"`
+	BearerToken            string            `yaml:"bearerToken"`
+	Headers                map[string]string `yaml:"headers"`
+	Timeout                string            `yaml:"timeout"`
+	DisableSslVerification bool              `yaml:"disableSslVerification"`
+	Proxy                  string            `yaml:"proxy"`
+	CACert                 string            `yaml:"caCert"`
+	TLSMinVersion          string            `yaml:"tlsMinVersion"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	duration, err := time.ParseDuration(r.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+
+	client, err := initPrometheusClient(ctx, tracer, r, duration)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	s := &Source{
+		Name: r.Name,
+		Kind: SourceKind,
+		API:  promv1.NewAPI(client),
+	}
+	if err := s.CheckHealth(ctx); err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name string `yaml:"name"`
+	Kind string `yaml:"kind"`
+	API  promv1.API
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) PrometheusAPI() promv1.API {
+	return s.API
+}
+
+// CheckHealth evaluates a constant expression, which is supported by any
+// server that implements the Prometheus query API.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	_, _, err := s.API.Query(ctx, "1", time.Now())
+	return err
+}
+
+// authRoundTripper adds the credentials and the headers of the source to
+// each request.
+type authRoundTripper struct {
+	next        http.RoundTripper
+	username    string
+	password    string
+	bearerToken string
+	headers     map[string]string
+}
+
+func (rt *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range rt.headers {
+		req.Header.Set(k, v)
+	}
+	if rt.username != "" {
+		req.SetBasicAuth(rt.username, rt.password)
+	}
+	if rt.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+rt.bearerToken)
+	}
+	return rt.next.RoundTrip(req)
+}
+
+func initPrometheusClient(ctx context.Context, tracer trace.Tracer, r Config, timeout time.Duration) (api.Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if r.DisableSslVerification {
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	if err := sources.ConfigureTransport(tr, r.Proxy, r.CACert, r.TLSMinVersion); err != nil {
+		return nil, err
+	}
+	return api.NewClient(api.Config{
+		Address: r.URL,
+		Client: &http.Client{
+			Timeout: timeout,
+			Transport: &authRoundTripper{
+				next:        tr,
+				username:    r.Username,
+				password:    r.Password,
+				bearerToken: r.BearerToken,
+				headers:     r.Headers,
+			},
+		},
+	})
+}
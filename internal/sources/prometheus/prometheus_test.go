@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/prometheus"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlPrometheus(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-prometheus:
+					kind: prometheus
+					url: http://localhost:9090
+			`,
+			want: server.SourceConfigs{
+				"my-prometheus": prometheus.Config{
+					Name:    "my-prometheus",
+					Kind:    prometheus.SourceKind,
+					URL:     "http://localhost:9090",
+					Timeout: "30s",
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			sources:
+				my-prometheus:
+					kind: prometheus
+					url: https://prometheus.example.com
+					username: my-user
+					password: my-pass
+					bearerToken: my-token
+					headers:
+						X-Scope-OrgID: tenant-1
+					timeout: 10s
+					disableSslVerification: true
+			`,
+			want: server.SourceConfigs{
+				"my-prometheus": prometheus.Config{
+					Name:                   "my-prometheus",
+					Kind:                   prometheus.SourceKind,
+					URL:                    "https://prometheus.example.com",
+					Username:               "my-user",
+					Password:               "my-pass",
+					BearerToken:            "my-token",
+					Headers:                map[string]string{"X-Scope-OrgID": "tenant-1"},
+					Timeout:                "10s",
+					DisableSslVerification: true,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializePrometheus(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		cfg    prometheus.Config
+		status int
+		body   string
+		err    string
+	}{
+		{
+			desc:   "bearer token",
+			cfg:    prometheus.Config{BearerToken: "my-token", Headers: map[string]string{"X-Scope-OrgID": "tenant-1"}},
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"scalar","result":[1735689600,"1"]}}`,
+		},
+		{
+			desc:   "basic auth",
+			cfg:    prometheus.Config{Username: "my-user", Password: "my-pass"},
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"scalar","result":[1735689600,"1"]}}`,
+		},
+		{
+			desc:   "unauthorized",
+			status: http.StatusUnauthorized,
+			body:   "unauthorized",
+			err:    "unable to connect successfully",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/query" {
+					t.Errorf("unexpected path %q", r.URL.Path)
+				}
+				if tc.cfg.BearerToken != "" {
+					if got := r.Header.Get("Authorization"); got != "Bearer my-token" {
+						t.Errorf("unexpected authorization %q", got)
+					}
+				}
+				if tc.cfg.Username != "" {
+					if user, pass, ok := r.BasicAuth(); !ok || user != "my-user" || pass != "my-pass" {
+						t.Errorf("unexpected basic auth %q %q", user, pass)
+					}
+				}
+				for k, v := range tc.cfg.Headers {
+					if got := r.Header.Get(k); got != v {
+						t.Errorf("unexpected header %s: %q", k, got)
+					}
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer ts.Close()
+
+			tc.cfg.Name = "my-prometheus"
+			tc.cfg.Kind = prometheus.SourceKind
+			tc.cfg.URL = ts.URL
+			tc.cfg.Timeout = "5s"
+			_, err := tc.cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unable to initialize: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
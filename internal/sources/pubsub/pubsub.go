@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pubsubapi "cloud.google.com/go/pubsub/v2"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/option"
+)
+
+const SourceKind string = "pubsub"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name    string `yaml:"name" validate:"required"`
+	Kind    string `yaml:"kind" validate:"required"`
+	Project string `yaml:"project" validate:"required"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initPubsubClient(ctx, tracer, r.Name, r.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		Name:    r.Name,
+		Kind:    SourceKind,
+		Project: r.Project,
+		Client:  client,
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+
+type Source struct {
+	Name    string `yaml:"name"`
+	Kind    string `yaml:"kind"`
+	Project string `yaml:"project"`
+	Client  *pubsubapi.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) PubsubClient() *pubsubapi.Client {
+	return s.Client
+}
+
+// TopicName returns the full name of a topic, which is either the ID of a
+// topic of the project of the source, or already a full name.
+func (s *Source) TopicName(topic string) string {
+	return fullName(s.Project, "topics", topic)
+}
+
+// SubscriptionName returns the full name of a subscription, which is either
+// the ID of a subscription of the project of the source, or already a full
+// name.
+func (s *Source) SubscriptionName(subscription string) string {
+	return fullName(s.Project, "subscriptions", subscription)
+}
+
+func fullName(project, collection, id string) string {
+	if strings.HasPrefix(id, "projects/") {
+		return id
+	}
+	return fmt.Sprintf("projects/%s/%s/%s", project, collection, id)
+}
+
+func initPubsubClient(ctx context.Context, tracer trace.Tracer, name, project string) (*pubsubapi.Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
+	defer span.End()
+
+	userAgent, err := util.UserAgentFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := pubsubapi.NewClient(ctx, project, option.WithUserAgent(userAgent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Pub/Sub client for project %q: %w", project, err)
+	}
+	return client, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/pubsub"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+)
+
+func TestParseFromYamlPubsub(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-instance:
+					kind: pubsub
+					project: my-project
+			`,
+			want: server.SourceConfigs{
+				"my-instance": pubsub.Config{
+					Name:    "my-instance",
+					Kind:    pubsub.SourceKind,
+					Project: "my-project",
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYaml(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "extra field",
+			in: `
+			sources:
+				my-instance:
+					kind: pubsub
+					project: my-project
+					foo: bar
+			`,
+			err: "unable to parse source \"my-instance\" as \"pubsub\": [1:1] unknown field \"foo\"\n>  1 | foo: bar\n       ^\n   2 | kind: pubsub\n   3 | project: my-project",
+		},
+		{
+			desc: "missing required field",
+			in: `
+			sources:
+				my-instance:
+					kind: pubsub
+			`,
+			err: "unable to parse source \"my-instance\" as \"pubsub\": Key: 'Config.Project' Error:Field validation for 'Project' failed on the 'required' tag",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			errStr := err.Error()
+			if errStr != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", errStr, tc.err)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	s := &pubsub.Source{Project: "my-project"}
+	tcs := []struct {
+		desc string
+		got  string
+		want string
+	}{
+		{desc: "topic id", got: s.TopicName("my-topic"), want: "projects/my-project/topics/my-topic"},
+		{desc: "topic name", got: s.TopicName("projects/other-project/topics/my-topic"), want: "projects/other-project/topics/my-topic"},
+		{desc: "subscription id", got: s.SubscriptionName("my-sub"), want: "projects/my-project/subscriptions/my-sub"},
+		{desc: "subscription name", got: s.SubscriptionName("projects/other-project/subscriptions/my-sub"), want: "projects/other-project/subscriptions/my-sub"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Fatalf("incorrect name: got %q, want %q", tc.got, tc.want)
+			}
+		})
+	}
+}
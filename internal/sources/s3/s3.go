@@ -0,0 +1,136 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package s3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	s3api "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const SourceKind string = "s3"
+
+// validate interface
+var _ sources.SourceConfig = Config{}
+
+func init() {
+	if !sources.Register(SourceKind, newConfig) {
+		panic(fmt.Sprintf("source kind %q already registered", SourceKind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name            string `yaml:"name" validate:"required"`
+	Kind            string `yaml:"kind" validate:"required"`
+	Bucket          string `yaml:"bucket" validate:"required"`
+	Region          string `yaml:"region"`
+	RoleArn         string `yaml:"roleArn"`
+	ExternalID      string `yaml:"externalId"`
+	AccessKeyID     string `yaml:"accessKeyId"`
+	SecretAccessKey string `yaml:"secretAccessKey"`
+	SessionToken    string `yaml:"sessionToken"`
+	// Endpoint overrides the endpoint of the S3 API, e.g. for MinIO.
+	Endpoint     string `yaml:"endpoint"`
+	UsePathStyle bool   `yaml:"usePathStyle"`
+}
+
+func (r Config) SourceConfigKind() string {
+	return SourceKind
+}
+
+func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
+	client, err := initS3Client(ctx, tracer, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	s := &Source{
+		Name:   r.Name,
+		Kind:   SourceKind,
+		Bucket: r.Bucket,
+		Client: client,
+	}
+
+	err = s.CheckHealth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect successfully: %w", err)
+	}
+	return s, nil
+}
+
+var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
+
+type Source struct {
+	Name   string `yaml:"name"`
+	Kind   string `yaml:"kind"`
+	Bucket string `yaml:"bucket"`
+	Client *s3api.Client
+}
+
+func (s *Source) SourceKind() string {
+	return SourceKind
+}
+
+func (s *Source) S3Client() *s3api.Client {
+	return s.Client
+}
+
+// CheckHealth gets the bucket, which verifies the credentials of the source.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	_, err := s.Client.HeadBucket(ctx, &s3api.HeadBucketInput{Bucket: aws.String(s.Bucket)})
+	return err
+}
+
+func initS3Client(ctx context.Context, tracer trace.Tracer, r Config) (*s3api.Client, error) {
+	//nolint:all // Reassigned ctx
+	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, r.Name)
+	defer span.End()
+
+	if (r.AccessKeyID == "") != (r.SecretAccessKey == "") {
+		return nil, fmt.Errorf("accessKeyId and secretAccessKey must be set together")
+	}
+	var loadOpts []func(*config.LoadOptions) error
+	if r.AccessKeyID != "" {
+		provider := credentials.NewStaticCredentialsProvider(r.AccessKeyID, r.SecretAccessKey, r.SessionToken)
+		loadOpts = append(loadOpts, config.WithCredentialsProvider(provider))
+	}
+	cfg, err := sources.GetAWSConfig(ctx, r.Region, r.RoleArn, r.ExternalID, loadOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3api.NewFromConfig(cfg, func(o *s3api.Options) {
+		if r.Endpoint != "" {
+			o.BaseEndpoint = aws.String(r.Endpoint)
+		}
+		o.UsePathStyle = r.UsePathStyle
+	})
+	return client, nil
+}
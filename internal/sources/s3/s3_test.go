@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlS3(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want server.SourceConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			sources:
+				my-s3:
+					kind: s3
+					bucket: my-bucket
+					region: us-east-1
+			`,
+			want: server.SourceConfigs{
+				"my-s3": s3.Config{
+					Name:   "my-s3",
+					Kind:   s3.SourceKind,
+					Bucket: "my-bucket",
+					Region: "us-east-1",
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			sources:
+				my-s3:
+					kind: s3
+					bucket: my-bucket
+					region: eu-west-1
+					roleArn: arn:aws:iam::123456789012:role/s3-reader
+					externalId: my-external-id
+					accessKeyId: my-key
+					secretAccessKey: my-secret
+					sessionToken: my-token
+					endpoint: http://localhost:9000
+					usePathStyle: true
+			`,
+			want: server.SourceConfigs{
+				"my-s3": s3.Config{
+					Name:            "my-s3",
+					Kind:            s3.SourceKind,
+					Bucket:          "my-bucket",
+					Region:          "eu-west-1",
+					RoleArn:         "arn:aws:iam::123456789012:role/s3-reader",
+					ExternalID:      "my-external-id",
+					AccessKeyID:     "my-key",
+					SecretAccessKey: "my-secret",
+					SessionToken:    "my-token",
+					Endpoint:        "http://localhost:9000",
+					UsePathStyle:    true,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Sources server.SourceConfigs `yaml:"sources"`
+			}{}
+			// Parse contents
+			err := yaml.Unmarshal(testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Sources); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeS3(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "default-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "default-secret")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	tcs := []struct {
+		desc            string
+		accessKeyID     string
+		secretAccessKey string
+		status          int
+		wantKey         string
+		err             string
+	}{
+		{
+			desc:    "default credentials",
+			status:  http.StatusOK,
+			wantKey: "default-key",
+		},
+		{
+			desc:            "static credentials",
+			accessKeyID:     "my-key",
+			secretAccessKey: "my-secret",
+			status:          http.StatusOK,
+			wantKey:         "my-key",
+		},
+		{
+			desc:        "missing secret access key",
+			accessKeyID: "my-key",
+			err:         "accessKeyId and secretAccessKey must be set together",
+		},
+		{
+			desc:    "missing bucket",
+			status:  http.StatusNotFound,
+			wantKey: "default-key",
+			err:     "NotFound",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodHead || r.URL.Path != "/my-bucket" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); !strings.Contains(got, "Credential="+tc.wantKey+"/") {
+					t.Errorf("unexpected authorization %q", got)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer ts.Close()
+
+			cfg := s3.Config{
+				Name:            "my-s3",
+				Kind:            s3.SourceKind,
+				Bucket:          "my-bucket",
+				Region:          "us-east-1",
+				AccessKeyID:     tc.accessKeyID,
+				SecretAccessKey: tc.secretAccessKey,
+				Endpoint:        ts.URL,
+				UsePathStyle:    true,
+			}
+			_, err := cfg.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unable to initialize: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
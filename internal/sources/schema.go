@@ -0,0 +1,192 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sources
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SchemaDescriber is implemented by sources that can describe the tables of
+// their database, such as to expose them to MCP clients as resources.
+type SchemaDescriber interface {
+	// ListTables returns the names of the tables in the database.
+	ListTables(ctx context.Context) ([]string, error)
+	// DescribeTable returns the DDL of a table.
+	DescribeTable(ctx context.Context, table string) (string, error)
+}
+
+// ErrTableNotFound is returned when describing a table that doesn't exist.
+var ErrTableNotFound = errors.New("table not found")
+
+// ListPostgresTables returns the tables of a PostgreSQL database outside of
+// the system schemas, qualified by their schema.
+func ListPostgresTables(ctx context.Context, pool *pgxpool.Pool) ([]string, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT table_schema || '.' || table_name FROM information_schema.tables
+		WHERE table_type = 'BASE TABLE' AND table_schema NOT IN ('pg_catalog', 'information_schema')
+		ORDER BY 1`)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tables: %w", err)
+	}
+	defer rows.Close()
+	tables := []string{}
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
+// DescribePostgresTable returns a CREATE TABLE statement for a table of a
+// PostgreSQL database, given its schema qualified name. PostgreSQL has no
+// statement that returns the DDL of a table, so it is built from the
+// catalog.
+func DescribePostgresTable(ctx context.Context, pool *pgxpool.Pool, table string) (string, error) {
+	schema, name, ok := strings.Cut(table, ".")
+	if !ok {
+		schema, name = "public", table
+	}
+	rows, err := pool.Query(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull, COALESCE(pg_get_expr(d.adbin, d.adrelid), '')
+		FROM pg_attribute a LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = to_regclass(format('%I.%I', $1::text, $2::text)) AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, schema, name)
+	if err != nil {
+		return "", fmt.Errorf("unable to describe table: %w", err)
+	}
+	defer rows.Close()
+	var defs []string
+	for rows.Next() {
+		var column, typ, def string
+		var notNull bool
+		if err := rows.Scan(&column, &typ, &notNull, &def); err != nil {
+			return "", fmt.Errorf("unable to parse row: %w", err)
+		}
+		d := fmt.Sprintf("%s %s", quotePostgresIdentifier(column), typ)
+		if notNull {
+			d += " NOT NULL"
+		}
+		if def != "" {
+			d += " DEFAULT " + def
+		}
+		defs = append(defs, d)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("unable to describe table: %w", err)
+	}
+	if len(defs) == 0 {
+		return "", fmt.Errorf("%w: %q", ErrTableNotFound, table)
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT conname, pg_get_constraintdef(oid) FROM pg_constraint
+		WHERE conrelid = to_regclass(format('%I.%I', $1::text, $2::text))
+		ORDER BY contype, conname`, schema, name)
+	if err != nil {
+		return "", fmt.Errorf("unable to describe table constraints: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var constraint, def string
+		if err := rows.Scan(&constraint, &def); err != nil {
+			return "", fmt.Errorf("unable to parse row: %w", err)
+		}
+		defs = append(defs, fmt.Sprintf("CONSTRAINT %s %s", quotePostgresIdentifier(constraint), def))
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("unable to describe table constraints: %w", err)
+	}
+	return createTable(quotePostgresIdentifier(schema)+"."+quotePostgresIdentifier(name), defs), nil
+}
+
+// ListMySQLTables returns the tables of the current MySQL database.
+func ListMySQLTables(ctx context.Context, db *sql.DB) ([]string, error) {
+	return querySQLTables(ctx, db, `
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'
+		ORDER BY table_name`)
+}
+
+// DescribeMySQLTable returns the CREATE TABLE statement of a table of the
+// current MySQL database.
+func DescribeMySQLTable(ctx context.Context, db *sql.DB, table string) (string, error) {
+	var name, ddl string
+	err := db.QueryRowContext(ctx, "SHOW CREATE TABLE `"+strings.ReplaceAll(table, "`", "``")+"`").Scan(&name, &ddl)
+	if err != nil {
+		// error 1146 is ER_NO_SUCH_TABLE
+		if strings.Contains(err.Error(), "1146") {
+			return "", fmt.Errorf("%w: %q", ErrTableNotFound, table)
+		}
+		return "", fmt.Errorf("unable to describe table: %w", err)
+	}
+	return ddl, nil
+}
+
+// ListSQLiteTables returns the tables of a SQLite database, excluding its
+// internal tables.
+func ListSQLiteTables(ctx context.Context, db *sql.DB) ([]string, error) {
+	return querySQLTables(ctx, db, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%'
+		ORDER BY name`)
+}
+
+// DescribeSQLiteTable returns the CREATE TABLE statement of a table of a
+// SQLite database.
+func DescribeSQLiteTable(ctx context.Context, db *sql.DB, table string) (string, error) {
+	var ddl string
+	err := db.QueryRowContext(ctx, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&ddl)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: %q", ErrTableNotFound, table)
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to describe table: %w", err)
+	}
+	return ddl, nil
+}
+
+func querySQLTables(ctx context.Context, db *sql.DB, query string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tables: %w", err)
+	}
+	defer rows.Close()
+	tables := []string{}
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
+func quotePostgresIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func createTable(name string, defs []string) string {
+	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n);", name, strings.Join(defs, ",\n  "))
+}
@@ -59,11 +59,28 @@ type SourceConfig interface {
 	Initialize(ctx context.Context, tracer trace.Tracer) (Source, error)
 }
 
+// ClientOAuthConfig is implemented by the configs of sources that can send
+// their requests with the OAuth access token of the caller, see
+// ClientOAuthTransport.
+type ClientOAuthConfig interface {
+	SourceConfig
+	// GetClientOAuthService returns the name of the auth service whose
+	// access token of the caller is sent, or an empty string if the source
+	// uses its own credentials.
+	GetClientOAuthService() string
+}
+
 // Source is the interface for the source itself.
 type Source interface {
 	SourceKind() string
 }
 
+// HealthChecker is implemented by sources that can check whether they are
+// reachable, such as by pinging their database.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
 // InitConnectionSpan adds a span for database pool connection initialization
 func InitConnectionSpan(ctx context.Context, tracer trace.Tracer, sourceKind, sourceName string) (context.Context, trace.Span) {
 	ctx, span := tracer.Start(
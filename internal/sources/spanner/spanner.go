@@ -23,6 +23,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/api/option"
 )
 
 const SourceKind string = "spanner"
@@ -51,6 +52,9 @@ type Config struct {
 	Instance string          `yaml:"instance" validate:"required"`
 	Dialect  sources.Dialect `yaml:"dialect" validate:"required"`
 	Database string          `yaml:"database" validate:"required"`
+	// Credentials configs, which default to the Application Default Credentials
+	CredentialsFile           string `yaml:"credentialsFile"`
+	ImpersonateServiceAccount string `yaml:"impersonateServiceAccount"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -58,7 +62,7 @@ func (r Config) SourceConfigKind() string {
 }
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
-	client, err := initSpannerClient(ctx, tracer, r.Name, r.Project, r.Instance, r.Database)
+	client, err := initSpannerClient(ctx, tracer, r.Name, r.Project, r.Instance, r.Database, r.CredentialsFile, r.ImpersonateServiceAccount)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create client: %w", err)
 	}
@@ -93,7 +97,7 @@ func (s *Source) DatabaseDialect() string {
 	return s.Dialect
 }
 
-func initSpannerClient(ctx context.Context, tracer trace.Tracer, name, project, instance, dbname string) (*spanner.Client, error) {
+func initSpannerClient(ctx context.Context, tracer trace.Tracer, name, project, instance, dbname, credentialsFile, impersonateServiceAccount string) (*spanner.Client, error) {
 	//nolint:all // Reassigned ctx
 	ctx, span := sources.InitConnectionSpan(ctx, tracer, SourceKind, name)
 	defer span.End()
@@ -114,7 +118,15 @@ func initSpannerClient(ctx context.Context, tracer trace.Tracer, name, project,
 	if err != nil {
 		return nil, err
 	}
-	client, err := spanner.NewClientWithConfig(ctx, db, spanner.ClientConfig{SessionPoolConfig: sessionPoolConfig, UserAgent: userAgent})
+	var opts []option.ClientOption
+	if credentialsFile != "" || impersonateServiceAccount != "" {
+		creds, err := sources.GetGoogleCredentials(ctx, credentialsFile, impersonateServiceAccount, sources.CloudPlatformScope)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, option.WithCredentials(creds))
+	}
+	client, err := spanner.NewClientWithConfig(ctx, db, spanner.ClientConfig{SessionPoolConfig: sessionPoolConfig, UserAgent: userAgent}, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create new client: %w", err)
 	}
@@ -52,6 +52,29 @@ func TestParseFromYamlSpannerDb(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "impersonated service account",
+			in: `
+			sources:
+				my-spanner-instance:
+					kind: spanner
+					project: my-project
+					instance: my-instance
+					database: my_db
+					impersonateServiceAccount: toolbox@my-project.iam.gserviceaccount.com
+			`,
+			want: map[string]sources.SourceConfig{
+				"my-spanner-instance": spanner.Config{
+					Name:                      "my-spanner-instance",
+					Kind:                      spanner.SourceKind,
+					Project:                   "my-project",
+					Instance:                  "my-instance",
+					Dialect:                   "googlesql",
+					Database:                  "my_db",
+					ImpersonateServiceAccount: "toolbox@my-project.iam.gserviceaccount.com",
+				},
+			},
+		},
 		{
 			desc: "gsql dialect",
 			in: `
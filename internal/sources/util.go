@@ -16,14 +16,25 @@ package sources
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
 
 	"cloud.google.com/go/cloudsqlconn"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
 )
 
 // GetCloudSQLDialOpts retrieve dial options with the right ip type and user agent for cloud sql
@@ -102,3 +113,154 @@ func GetIAMAccessToken(ctx context.Context) (string, error) {
 	}
 	return token.AccessToken, nil
 }
+
+// CloudPlatformScope is the OAuth2 scope of all Google Cloud APIs.
+const CloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// GetGoogleCredentials loads the Application Default Credentials with the
+// scopes, or the credentials of credentialsFile, which may be a service
+// account key or the credential configuration of Workload Identity
+// Federation, e.g. for AWS or GitHub Actions. If impersonateServiceAccount is
+// set, the service account is impersonated with these credentials, which must
+// be allowed to create its tokens.
+func GetGoogleCredentials(ctx context.Context, credentialsFile, impersonateServiceAccount string, scopes ...string) (*google.Credentials, error) {
+	// the impersonating credentials only call the IAM Credentials API
+	baseScopes := scopes
+	if impersonateServiceAccount != "" {
+		baseScopes = []string{CloudPlatformScope}
+	}
+
+	var creds *google.Credentials
+	if credentialsFile != "" {
+		b, err := os.ReadFile(credentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read credentials file: %w", err)
+		}
+		creds, err = google.CredentialsFromJSON(ctx, b, baseScopes...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse credentials file %q: %w", credentialsFile, err)
+		}
+	} else {
+		var err error
+		creds, err = google.FindDefaultCredentials(ctx, baseScopes...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find default Google Cloud credentials with scopes %q: %w", baseScopes, err)
+		}
+	}
+	if impersonateServiceAccount == "" {
+		return creds, nil
+	}
+
+	ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: impersonateServiceAccount,
+		Scopes:          scopes,
+	}, option.WithCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("unable to impersonate service account %q: %w", impersonateServiceAccount, err)
+	}
+	return &google.Credentials{ProjectID: creds.ProjectID, TokenSource: ts}, nil
+}
+
+// GetAWSConfig loads the AWS config with the default credential chain, or with
+// the credentials of the additional load options. If roleArn is set, the role
+// is assumed with these credentials.
+func GetAWSConfig(ctx context.Context, region, roleArn, externalID string, loadOpts ...func(*config.LoadOptions) error) (aws.Config, error) {
+	opts := loadOpts
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("unable to load AWS config: %w", err)
+	}
+
+	if roleArn != "" {
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleArn, func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = "genai-toolbox"
+			if externalID != "" {
+				o.ExternalID = aws.String(externalID)
+			}
+		})
+		cfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+	return cfg, nil
+}
+
+// ClientOAuthTransport returns a transport that sends the requests with the
+// verified OAuth access token of the caller from authService, so that they're
+// made with the permissions of the caller. Requests without a token fail
+// rather than falling back to the credentials of the server.
+func ClientOAuthTransport(base http.RoundTripper, authService string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return clientOAuthTransport{base: base, authService: authService}
+}
+
+type clientOAuthTransport struct {
+	base        http.RoundTripper
+	authService string
+}
+
+func (t clientOAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, ok := util.AccessTokenFromContext(req.Context(), t.authService)
+	if !ok {
+		return nil, fmt.Errorf("no verified OAuth access token of auth service %q in the request, expected header %q", t.authService, t.authService+"_access_token")
+	}
+	// a RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
+
+// tlsVersions are the allowed values of tlsMinVersion.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ConfigureTransport sets the egress path of the requests of a source: they
+// are sent through the proxy, if set, and the server certificates are also
+// verified with the CA certificates of the caCert file, e.g. of a proxy that
+// inspects TLS. tlsMinVersion is the minimum TLS version, e.g. "1.2", which
+// defaults to the one of Go.
+func ConfigureTransport(tr *http.Transport, proxy, caCert, tlsMinVersion string) error {
+	if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid proxy %q: must be a URL, e.g. http://proxy.example.com:3128", proxy)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+	if caCert == "" && tlsMinVersion == "" {
+		return nil
+	}
+
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{}
+	}
+	if caCert != "" {
+		pem, err := os.ReadFile(caCert)
+		if err != nil {
+			return fmt.Errorf("unable to read caCert: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("caCert %q contains no PEM certificates", caCert)
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+	if tlsMinVersion != "" {
+		v, ok := tlsVersions[tlsMinVersion]
+		if !ok {
+			return fmt.Errorf("invalid tlsMinVersion %q: must be one of \"1.0\", \"1.1\", \"1.2\", or \"1.3\"", tlsMinVersion)
+		}
+		tr.TLSClientConfig.MinVersion = v
+	}
+	return nil
+}
@@ -109,6 +109,7 @@ func initValkeyClient(ctx context.Context, r Config) (valkey.Client, error) {
 }
 
 var _ sources.Source = &Source{}
+var _ sources.HealthChecker = &Source{}
 
 type Source struct {
 	Name   string `yaml:"name"`
@@ -123,3 +124,8 @@ func (s *Source) SourceKind() string {
 func (s *Source) ValkeyClient() valkey.Client {
 	return s.Client
 }
+
+// CheckHealth pings the server.
+func (s *Source) CheckHealth(ctx context.Context) error {
+	return s.Client.Do(ctx, s.Client.B().Ping().Build()).Error()
+}
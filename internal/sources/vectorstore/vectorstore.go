@@ -0,0 +1,97 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vectorstore is the client shared by the sources of vector stores
+// with a JSON REST API, such as Pinecone, Qdrant and Weaviate.
+package vectorstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client sends requests to the REST API of a vector store.
+type Client struct {
+	// URL is the base URL of the API, which paths are appended to.
+	URL        string
+	HTTPClient *http.Client
+	// Header holds the headers sent with every request, such as the API key.
+	Header http.Header
+}
+
+// NewClient returns a client of the API at rawURL, whose requests time out
+// after timeout and are sent with header.
+func NewClient(rawURL, timeout string, header http.Header) (*Client, error) {
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Timeout string as time.Duration: %s", err)
+	}
+	if _, err := url.ParseRequestURI(rawURL); err != nil {
+		return nil, fmt.Errorf("failed to parse url %v", err)
+	}
+	return &Client{
+		URL:        strings.TrimSuffix(rawURL, "/"),
+		HTTPClient: &http.Client{Timeout: duration},
+		Header:     header,
+	}, nil
+}
+
+// Do sends a request to the API and decodes its JSON response.
+func (c *Client) Do(ctx context.Context, method, path string, body any) (any, error) {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal request body: %w", err)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %s", err)
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("unexpected status code: %d, %s", resp.StatusCode, respBody)
+	}
+	var data any
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vectorstore_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/pinecone"
+	"github.com/googleapis/genai-toolbox/internal/sources/qdrant"
+	"github.com/googleapis/genai-toolbox/internal/sources/vectorstore"
+	"github.com/googleapis/genai-toolbox/internal/sources/weaviate"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestInitialize(t *testing.T) {
+	tcs := []struct {
+		kind string
+		// newConfig returns the config of a source of the API at url
+		newConfig  func(url string) sources.SourceConfig
+		wantMethod string
+		wantPath   string
+		wantHeader http.Header
+	}{
+		{
+			kind: pinecone.SourceKind,
+			newConfig: func(url string) sources.SourceConfig {
+				return pinecone.Config{Name: "my-source", Kind: pinecone.SourceKind, Host: url, APIKey: "my-key", Timeout: "5s"}
+			},
+			wantMethod: http.MethodPost,
+			wantPath:   "/describe_index_stats",
+			wantHeader: http.Header{"Api-Key": {"my-key"}, "X-Pinecone-Api-Version": {"2025-01"}},
+		},
+		{
+			kind: qdrant.SourceKind,
+			newConfig: func(url string) sources.SourceConfig {
+				return qdrant.Config{Name: "my-source", Kind: qdrant.SourceKind, URL: url, APIKey: "my-key", Timeout: "5s"}
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/collections",
+			wantHeader: http.Header{"Api-Key": {"my-key"}},
+		},
+		{
+			kind: weaviate.SourceKind,
+			newConfig: func(url string) sources.SourceConfig {
+				return weaviate.Config{Name: "my-source", Kind: weaviate.SourceKind, URL: url, APIKey: "my-key", Timeout: "5s"}
+			},
+			wantMethod: http.MethodGet,
+			wantPath:   "/v1/meta",
+			wantHeader: http.Header{"Authorization": {"Bearer my-key"}},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.kind, func(t *testing.T) {
+			status := http.StatusOK
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tc.wantMethod || r.URL.Path != tc.wantPath {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				for k := range tc.wantHeader {
+					if got, want := r.Header.Get(k), tc.wantHeader.Get(k); got != want {
+						t.Errorf("unexpected %s header: got %q, want %q", k, got, want)
+					}
+				}
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			ctx, err := testutils.ContextWithNewLogger()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			tracer := noop.NewTracerProvider().Tracer("")
+			cfg := tc.newConfig(srv.URL + "/")
+			if _, err := cfg.Initialize(ctx, tracer); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			status = http.StatusUnauthorized
+			if _, err := cfg.Initialize(ctx, tracer); err == nil || !strings.Contains(err.Error(), "unexpected status code: 401") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := tc.newConfig("not a url").Initialize(ctx, tracer); err == nil || !strings.Contains(err.Error(), "failed to parse url") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search":
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("unexpected Content-Type header: %q", r.Header.Get("Content-Type"))
+			}
+			_, _ = w.Write([]byte(`{"matches": [{"id": "a"}]}`))
+		case "/invalid":
+			_, _ = w.Write([]byte(`not json`))
+		}
+	}))
+	defer srv.Close()
+
+	c, err := vectorstore.NewClient(srv.URL, "5s", http.Header{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := c.Do(context.Background(), http.MethodPost, "/search", map[string]any{"topK": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	matches, ok := got.(map[string]any)["matches"].([]any)
+	if !ok || len(matches) != 1 {
+		t.Fatalf("unexpected response: %v", got)
+	}
+	if _, err := c.Do(context.Background(), http.MethodGet, "/invalid", nil); err == nil || !strings.Contains(err.Error(), "unable to decode response") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := vectorstore.NewClient(srv.URL, "soon", nil); err == nil {
+		t.Fatalf("expected an error for an invalid timeout")
+	}
+}
@@ -31,6 +31,10 @@ const (
 	toolInvokeCountName = "toolbox.server.tool.invoke.count"
 	mcpSseCountName     = "toolbox.server.mcp.sse.count"
 	mcpPostCountName    = "toolbox.server.mcp.post.count"
+
+	toolInvocationCountName    = "toolbox.tool.invocation.count"
+	toolInvocationDurationName = "toolbox.tool.invocation.duration"
+	toolInvocationRowsName     = "toolbox.tool.invocation.rows"
 )
 
 // Instrumentation defines the telemetry instrumentation for toolbox
@@ -42,6 +46,11 @@ type Instrumentation struct {
 	ToolInvoke metric.Int64Counter
 	McpSse     metric.Int64Counter
 	McpPost    metric.Int64Counter
+	// ToolInvocation* record every tool invocation, regardless of whether it
+	// was invoked through the API or MCP.
+	ToolInvocationCount    metric.Int64Counter
+	ToolInvocationDuration metric.Float64Histogram
+	ToolInvocationRows     metric.Int64Histogram
 }
 
 func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, error) {
@@ -96,14 +105,44 @@ func CreateTelemetryInstrumentation(versionString string) (*Instrumentation, err
 		return nil, fmt.Errorf("unable to create %s metric: %w", mcpPostCountName, err)
 	}
 
+	toolInvocationCount, err := meter.Int64Counter(
+		toolInvocationCountName,
+		metric.WithDescription("Number of tool invocations."),
+		metric.WithUnit("{invocation}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvocationCountName, err)
+	}
+
+	toolInvocationDuration, err := meter.Float64Histogram(
+		toolInvocationDurationName,
+		metric.WithDescription("Duration of tool invocations."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvocationDurationName, err)
+	}
+
+	toolInvocationRows, err := meter.Int64Histogram(
+		toolInvocationRowsName,
+		metric.WithDescription("Number of rows returned by tool invocations."),
+		metric.WithUnit("{row}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", toolInvocationRowsName, err)
+	}
+
 	instrumentation := &Instrumentation{
-		Tracer:     tracer,
-		meter:      meter,
-		ToolsetGet: toolsetGet,
-		ToolGet:    toolGet,
-		ToolInvoke: toolInvoke,
-		McpSse:     mcpSse,
-		McpPost:    mcpPost,
+		Tracer:                 tracer,
+		meter:                  meter,
+		ToolsetGet:             toolsetGet,
+		ToolGet:                toolGet,
+		ToolInvoke:             toolInvoke,
+		McpSse:                 mcpSse,
+		McpPost:                mcpPost,
+		ToolInvocationCount:    toolInvocationCount,
+		ToolInvocationDuration: toolInvocationDuration,
+		ToolInvocationRows:     toolInvocationRows,
 	}
 	return instrumentation, nil
 }
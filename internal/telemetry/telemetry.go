@@ -18,13 +18,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	mexporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/metric"
 	texporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/propagators/autoprop"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
@@ -33,7 +36,7 @@ import (
 
 // setupOTelSDK bootstraps the OpenTelemetry pipeline.
 // If it does not return an error, make sure to call shutdown for proper cleanup.
-func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemetryGCP bool, telemetryServiceName string) (shutdown func(context.Context) error, err error) {
+func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemetryGCP bool, telemetryPrometheus bool, telemetryServiceName string) (shutdown func(context.Context) error, err error) {
 	var shutdownFuncs []func(context.Context) error
 
 	// shutdown calls cleanup functions registered via shutdownFuncs.
@@ -72,7 +75,7 @@ func SetupOTel(ctx context.Context, versionString, telemetryOTLP string, telemet
 	shutdownFuncs = append(shutdownFuncs, tracerProvider.Shutdown)
 	otel.SetTracerProvider(tracerProvider)
 
-	meterProvider, err := newMeterProvider(ctx, res, telemetryOTLP, telemetryGCP)
+	meterProvider, err := newMeterProvider(ctx, res, telemetryOTLP, telemetryGCP, telemetryPrometheus)
 	if err != nil {
 		errMsg := fmt.Errorf("unable to set up meter provider: %w", err)
 		handleErr(errMsg)
@@ -135,7 +138,7 @@ func newTracerProvider(ctx context.Context, r *resource.Resource, telemetryOTLP
 
 // newMeterProvider creates MeterProvider.
 // MeterProvider is a factory for Meters, and is responsible for creating metrics.
-func newMeterProvider(ctx context.Context, r *resource.Resource, telemetryOTLP string, telemetryGCP bool) (*metric.MeterProvider, error) {
+func newMeterProvider(ctx context.Context, r *resource.Resource, telemetryOTLP string, telemetryGCP bool, telemetryPrometheus bool) (*metric.MeterProvider, error) {
 	metricOpts := []metric.Option{}
 	if telemetryOTLP != "" {
 		// otlpmetrichttp provides an OTLP metrics exporter using HTTP with protobuf payloads.
@@ -153,7 +156,23 @@ func newMeterProvider(ctx context.Context, r *resource.Resource, telemetryOTLP s
 		}
 		metricOpts = append(metricOpts, metric.WithReader(metric.NewPeriodicReader(gcpExporter)))
 	}
+	if telemetryPrometheus {
+		// the prometheus exporter registers with the default prometheus
+		// registry, which is served by PrometheusHandler.
+		promExporter, err := prometheus.New()
+		if err != nil {
+			return nil, err
+		}
+		metricOpts = append(metricOpts, metric.WithReader(promExporter))
+	}
 
 	meterProvider := metric.NewMeterProvider(metricOpts...)
 	return meterProvider, nil
 }
+
+// PrometheusHandler returns the handler that serves metrics in the Prometheus
+// exposition format. Metrics are only exported if telemetryPrometheus was
+// enabled in SetupOTel.
+func PrometheusHandler() http.Handler {
+	return promhttp.Handler()
+}
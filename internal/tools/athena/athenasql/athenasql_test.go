@@ -0,0 +1,246 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package athenasql_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/athena/athenasql"
+)
+
+func TestParseFromYamlAthena(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: athena-sql
+					source: my-athena-instance
+					description: some description
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+					authRequired:
+						- my-google-auth-service
+						- other-auth-service
+					parameters:
+						- name: country
+						  type: string
+						  description: some description
+						  authServices:
+							- name: my-google-auth-service
+							  field: user_id
+							- name: other-auth-service
+							  field: user_id
+			`,
+			want: server.ToolConfigs{
+				"example_tool": athenasql.Config{
+					Name:         "example_tool",
+					Kind:         "athena-sql",
+					Source:       "my-athena-instance",
+					Description:  "some description",
+					Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+					AuthRequired: []string{"my-google-auth-service", "other-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameterWithAuth("country", "some description",
+							[]tools.ParamAuthService{{Name: "my-google-auth-service", Field: "user_id"},
+								{Name: "other-auth-service", Field: "user_id"}}),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseFromYamlWithTemplateParamsAthena(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: athena-sql
+					source: my-athena-instance
+					description: some description
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+					authRequired:
+						- my-google-auth-service
+						- other-auth-service
+					parameters:
+						- name: country
+						  type: string
+						  description: some description
+						  authServices:
+							- name: my-google-auth-service
+							  field: user_id
+							- name: other-auth-service
+							  field: user_id
+					templateParameters:
+						- name: tableName
+						  type: string
+						  description: The table to select hotels from.
+						- name: fieldArray
+						  type: array
+						  description: The columns to return for the query.
+						  items: 
+								name: column
+								type: string
+								description: A column name that will be returned from the query.
+			`,
+			want: server.ToolConfigs{
+				"example_tool": athenasql.Config{
+					Name:         "example_tool",
+					Kind:         "athena-sql",
+					Source:       "my-athena-instance",
+					Description:  "some description",
+					Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+					AuthRequired: []string{"my-google-auth-service", "other-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameterWithAuth("country", "some description",
+							[]tools.ParamAuthService{{Name: "my-google-auth-service", Field: "user_id"},
+								{Name: "other-auth-service", Field: "user_id"}}),
+					},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("tableName", "The table to select hotels from."),
+						tools.NewArrayParameter("fieldArray", "The columns to return for the query.", tools.NewStringParameter("column", "A column name that will be returned from the query.")),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// fakeSource records the query it runs.
+type fakeSource struct {
+	query  string
+	params []string
+}
+
+func (s *fakeSource) SourceKind() string {
+	return "athena"
+}
+
+func (s *fakeSource) RunQuery(ctx context.Context, query string, params []string) ([]any, error) {
+	s.query = query
+	s.params = params
+	return []any{map[string]any{"total": int64(1)}}, nil
+}
+
+func TestInvokeAthena(t *testing.T) {
+	src := &fakeSource{}
+	cfg := athenasql.Config{
+		Name:        "example_tool",
+		Kind:        "athena-sql",
+		Source:      "my-athena-instance",
+		Description: "some description",
+		Statement:   "SELECT count(*) AS total FROM {{.table}} WHERE name = ? AND qty > ? AND price < ? AND active = ? AND id IN (SELECT * FROM UNNEST(?))",
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("name", "some description"),
+			tools.NewIntParameter("qty", "some description"),
+			tools.NewFloatParameter("price", "some description"),
+			tools.NewBooleanParameter("active", "some description"),
+			tools.NewArrayParameter("ids", "some description", tools.NewIntParameter("id", "some description")),
+		},
+		TemplateParameters: tools.Parameters{
+			tools.NewStringParameter("table", "some description"),
+		},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-athena-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{
+		"table":  "orders",
+		"name":   "O'Brien",
+		"qty":    2,
+		"price":  9.5,
+		"active": true,
+		"ids":    []any{1, 2},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	if diff := cmp.Diff([]any{map[string]any{"total": int64(1)}}, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	wantQuery := "SELECT count(*) AS total FROM orders WHERE name = ? AND qty > ? AND price < ? AND active = ? AND id IN (SELECT * FROM UNNEST(?))"
+	if src.query != wantQuery {
+		t.Fatalf("incorrect query: got %q, want %q", src.query, wantQuery)
+	}
+	wantParams := []string{"'O''Brien'", "2", "9.5", "true", "ARRAY[1, 2]"}
+	if diff := cmp.Diff(wantParams, src.params); diff != "" {
+		t.Fatalf("incorrect params: diff %v", diff)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bigquerycommon
+
+import (
+	"context"
+	"fmt"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+)
+
+// DryRun validates the query without running it, and returns the statistics
+// BigQuery estimates for it, such as the bytes it would process.
+func DryRun(ctx context.Context, client *bigqueryapi.Client, sql string) (*bigqueryapi.QueryStatistics, error) {
+	query := client.Query(sql)
+	query.Location = client.Location
+	query.DryRun = true
+
+	job, err := query.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query is invalid: %w", err)
+	}
+	status := job.LastStatus()
+	if status == nil || status.Statistics == nil {
+		return nil, fmt.Errorf("dry run of the query returned no statistics")
+	}
+	stats, ok := status.Statistics.Details.(*bigqueryapi.QueryStatistics)
+	if !ok {
+		return nil, fmt.Errorf("dry run of the query returned no query statistics")
+	}
+	return stats, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bigquerycommon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultTimeout      = 10 * time.Minute
+	// pollJitter is the fraction by which poll intervals are randomized
+	pollJitter = 0.1
+)
+
+var stateNames = map[bigqueryapi.State]string{
+	bigqueryapi.Pending: "PENDING",
+	bigqueryapi.Running: "RUNNING",
+	bigqueryapi.Done:    "DONE",
+}
+
+// Waiter waits for BigQuery jobs to finish, by polling their status.
+type Waiter struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+}
+
+// NewWaiter parses the pollInterval and timeout of a tool config, which
+// default to 5 seconds and 10 minutes.
+func NewWaiter(pollInterval, timeout string) (Waiter, error) {
+	w := Waiter{PollInterval: defaultPollInterval, Timeout: defaultTimeout}
+	var err error
+	if pollInterval != "" {
+		w.PollInterval, err = time.ParseDuration(pollInterval)
+		if err != nil {
+			return Waiter{}, fmt.Errorf("unable to parse pollInterval as time.Duration: %w", err)
+		}
+	}
+	if timeout != "" {
+		w.Timeout, err = time.ParseDuration(timeout)
+		if err != nil {
+			return Waiter{}, fmt.Errorf("unable to parse timeout as time.Duration: %w", err)
+		}
+	}
+	return w, nil
+}
+
+// Wait polls the status of the job until it's done, and returns its final
+// status. It returns an error if the job failed, or if it's still running
+// after the timeout of the waiter; the job isn't cancelled in that case.
+func (w Waiter) Wait(ctx context.Context, job *bigqueryapi.Job) (*bigqueryapi.JobStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	result, err := retry.Poll(ctx, func(ctx context.Context, attempt int) (any, time.Duration, bool, error) {
+		status, err := job.Status(ctx)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("unable to get status of job %q: %w", job.ID(), err)
+		}
+		util.ReportProgress(ctx, float64(attempt), 0, fmt.Sprintf("job %s is %s", job.ID(), stateNames[status.State]))
+		if !status.Done() {
+			return nil, retry.Jitter(w.PollInterval, pollJitter), false, nil
+		}
+		if err := status.Err(); err != nil {
+			return nil, 0, false, fmt.Errorf("job %q failed: %w", job.ID(), err)
+		}
+		return status, 0, true, nil
+	})
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("job %q is still running after %s: %w", job.ID(), w.Timeout, err)
+		}
+		return nil, err
+	}
+	return result.(*bigqueryapi.JobStatus), nil
+}
+
+// JobResult returns the reference of the job, and its state if status is set.
+func JobResult(job *bigqueryapi.Job, status *bigqueryapi.JobStatus) map[string]any {
+	result := map[string]any{
+		"projectId": job.ProjectID(),
+		"jobId":     job.ID(),
+		"location":  job.Location(),
+	}
+	if status != nil {
+		result["state"] = stateNames[status.State]
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerycommon
+
+import (
+	"math/big"
+	"time"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// Value returns a value of a query result as it is returned to clients, see
+// tools.Decimal. NUMERIC and BIGNUMERIC values are read as fractions, which
+// would otherwise be encoded as "1/8".
+func Value(v bigqueryapi.Value) any {
+	switch v := v.(type) {
+	case *big.Rat:
+		if v == nil {
+			return nil
+		}
+		return tools.DecimalFromRat(v)
+	case time.Time:
+		return tools.Timestamp(v)
+	case civil.Date:
+		return v.String()
+	case civil.DateTime:
+		return tools.DateTime(v.In(time.UTC))
+	case civil.Time:
+		return v.String()
+	case []byte:
+		return tools.Bytes(v)
+	case []bigqueryapi.Value:
+		// repeated fields
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = Value(e)
+		}
+		return out
+	case map[string]bigqueryapi.Value:
+		// records
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = Value(e)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerycommon_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycommon"
+)
+
+func TestValue(t *testing.T) {
+	in := map[string]bigqueryapi.Value{
+		"price":    big.NewRat(1, 8),
+		"missing":  nil,
+		"created":  time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		"day":      civil.Date{Year: 2025, Month: 1, Day: 2},
+		"local":    civil.DateTime{Date: civil.Date{Year: 2025, Month: 1, Day: 2}, Time: civil.Time{Hour: 3, Minute: 4, Second: 5}},
+		"data":     []byte("hi"),
+		"readings": []bigqueryapi.Value{big.NewRat(5, 2), int64(3)},
+	}
+	want := map[string]any{
+		"price":    tools.Decimal("0.125"),
+		"missing":  nil,
+		"created":  "2025-01-02T03:04:05Z",
+		"day":      "2025-01-02",
+		"local":    "2025-01-02T03:04:05",
+		"data":     "aGk=",
+		"readings": []any{tools.Decimal("2.5"), int64(3)},
+	}
+	if diff := cmp.Diff(want, bigquerycommon.Value(in)); diff != "" {
+		t.Fatalf("incorrect value: diff %v", diff)
+	}
+}
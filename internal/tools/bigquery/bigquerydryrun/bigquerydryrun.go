@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bigquerydryrun
+
+import (
+	"context"
+	"fmt"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycommon"
+)
+
+const kind string = "bigquery-dry-run"
+
+// defaultPricePerTiB is the on-demand price of the queries, in US dollars per
+// TiB processed.
+const defaultPricePerTiB = 6.25
+
+const bytesPerTiB = 1 << 40
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, PricePerTiB: defaultPricePerTiB}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigQueryClient() *bigqueryapi.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigqueryds.Source{}
+
+var compatibleSources = [...]string{bigqueryds.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	PricePerTiB  float64  `yaml:"pricePerTiB"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	if cfg.PricePerTiB < 0 {
+		return nil, fmt.Errorf("pricePerTiB must not be negative, got %v", cfg.PricePerTiB)
+	}
+
+	sqlParameter := tools.NewStringParameter("sql", "The sql to validate and estimate the cost of.")
+	parameters := tools.Parameters{sqlParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.BigQueryClient(),
+		PricePerTiB:  cfg.PricePerTiB,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Client       *bigqueryapi.Client
+	PricePerTiB  float64
+	manifest     tools.Manifest
+	mcpManifest  tools.McpManifest
+}
+
+// Invoke dry runs the query, which validates it without running it, and
+// returns the bytes it would process and their estimated cost.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	sliceParams := params.AsSlice()
+	sql, ok := sliceParams[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
+	}
+
+	stats, err := bigquerycommon.DryRun(ctx, t.Client, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	referencedTables := make([]string, 0, len(stats.ReferencedTables))
+	for _, table := range stats.ReferencedTables {
+		referencedTables = append(referencedTables, fmt.Sprintf("%s.%s.%s", table.ProjectID, table.DatasetID, table.TableID))
+	}
+	result := map[string]any{
+		"statementType":       stats.StatementType,
+		"totalBytesProcessed": stats.TotalBytesProcessed,
+		"estimatedCost":       float64(stats.TotalBytesProcessed) / bytesPerTiB * t.PricePerTiB,
+		"referencedTables":    referencedTables,
+	}
+	if stats.TotalBytesProcessedAccuracy != "" {
+		result["totalBytesProcessedAccuracy"] = stats.TotalBytesProcessedAccuracy
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerydryrun_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerydryrun"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlBigQueryDryRun(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-dry-run
+					source: my-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigquerydryrun.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-dry-run",
+					Source:       "my-instance",
+					Description:  "some description",
+					PricePerTiB:  6.25,
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "price example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-dry-run
+					source: my-instance
+					description: some description
+					pricePerTiB: 7.5
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigquerydryrun.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-dry-run",
+					Source:       "my-instance",
+					Description:  "some description",
+					PricePerTiB:  7.5,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeBigQueryDryRun(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var job map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+			t.Errorf("unable to decode job: %s", err)
+		}
+		cfg := job["configuration"].(map[string]any)
+		if cfg["dryRun"] != true {
+			t.Errorf("query isn't a dry run: %v", cfg)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		sql := cfg["query"].(map[string]any)["query"].(string)
+		if strings.Contains(sql, "FORM") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":400,"message":"Syntax error: Expected end of input but got identifier \"FORM\" at [1:10]"}}`)
+			return
+		}
+		job["status"] = map[string]any{"state": "DONE"}
+		job["statistics"] = map[string]any{
+			"totalBytesProcessed": "2199023255552",
+			"query": map[string]any{
+				"statementType":               "SELECT",
+				"totalBytesProcessed":         "2199023255552",
+				"totalBytesProcessedAccuracy": "PRECISE",
+				"referencedTables":            []any{map[string]any{"projectId": "my-project", "datasetId": "sales", "tableId": "orders"}},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(job)
+	}))
+	defer ts.Close()
+
+	client, err := bigqueryapi.NewClient(context.Background(), "my-project", option.WithEndpoint(ts.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	src := &bigqueryds.Source{Name: "my-instance", Kind: bigqueryds.SourceKind, Client: client}
+	cfg := bigquerydryrun.Config{
+		Name:        "example_tool",
+		Kind:        "bigquery-dry-run",
+		Source:      "my-instance",
+		Description: "some description",
+		PricePerTiB: 6.25,
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		sql     string
+		want    any
+		wantErr string
+	}{
+		{
+			desc: "valid query",
+			sql:  "SELECT * FROM sales.orders",
+			want: map[string]any{
+				"statementType":               "SELECT",
+				"totalBytesProcessed":         int64(2199023255552),
+				"totalBytesProcessedAccuracy": "PRECISE",
+				"estimatedCost":               12.5,
+				"referencedTables":            []string{"my-project.sales.orders"},
+			},
+		},
+		{
+			desc:    "invalid query",
+			sql:     "SELECT * FORM sales.orders",
+			wantErr: "query is invalid",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(map[string]any{"sql": tc.sql}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
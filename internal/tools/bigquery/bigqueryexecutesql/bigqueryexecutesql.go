@@ -23,6 +23,9 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 	"google.golang.org/api/iterator"
 )
 
@@ -52,11 +55,14 @@ var _ compatibleSource = &bigqueryds.Source{}
 var compatibleSources = [...]string{bigqueryds.SourceKind}
 
 type Config struct {
-	Name         string   `yaml:"name" validate:"required"`
-	Kind         string   `yaml:"kind" validate:"required"`
-	Source       string   `yaml:"source" validate:"required"`
-	Description  string   `yaml:"description" validate:"required"`
-	AuthRequired []string `yaml:"authRequired"`
+	Name               string            `yaml:"name" validate:"required"`
+	Kind               string            `yaml:"kind" validate:"required"`
+	Source             string            `yaml:"source" validate:"required"`
+	Description        string            `yaml:"description" validate:"required"`
+	MaximumBytesBilled int64             `yaml:"maximumBytesBilled" validate:"gte=0"`
+	ReadOnly           bool              `yaml:"readOnly"`
+	Policy             *sqlpolicy.Policy `yaml:"policy"`
+	AuthRequired       []string          `yaml:"authRequired"`
 }
 
 // validate interface
@@ -79,6 +85,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	if cfg.Policy != nil {
+		if err := cfg.Policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	sqlParameter := tools.NewStringParameter("sql", "The sql to execute.")
 	parameters := tools.Parameters{sqlParameter}
 
@@ -90,13 +102,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         kind,
-		Parameters:   parameters,
-		AuthRequired: cfg.AuthRequired,
-		Client:       s.BigQueryClient(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
-		mcpManifest:  mcpManifest,
+		Name:               cfg.Name,
+		Kind:               kind,
+		Parameters:         parameters,
+		AuthRequired:       cfg.AuthRequired,
+		Client:             s.BigQueryClient(),
+		MaximumBytesBilled: cfg.MaximumBytesBilled,
+		ReadOnly:           cfg.ReadOnly,
+		Policy:             cfg.Policy,
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:        mcpManifest,
 	}
 	return t, nil
 }
@@ -105,13 +120,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 var _ tools.Tool = Tool{}
 
 type Tool struct {
-	Name         string           `yaml:"name"`
-	Kind         string           `yaml:"kind"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
-	Client       *bigqueryapi.Client
-	manifest     tools.Manifest
-	mcpManifest  tools.McpManifest
+	Name               string           `yaml:"name"`
+	Kind               string           `yaml:"kind"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	Client             *bigqueryapi.Client
+	MaximumBytesBilled int64
+	ReadOnly           bool
+	Policy             *sqlpolicy.Policy
+	manifest           tools.Manifest
+	mcpManifest        tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
@@ -121,8 +139,20 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
 	}
 
+	if t.ReadOnly {
+		if err := sqlparse.CheckReadOnly(sql, sqlparse.GoogleSQL); err != nil {
+			return nil, err
+		}
+	}
+	if t.Policy != nil {
+		if err := t.Policy.Check(sql, sqlparse.GoogleSQL); err != nil {
+			return nil, err
+		}
+	}
+
 	query := t.Client.Query(sql)
 	query.Location = t.Client.Location
+	query.MaxBytesBilled = t.MaximumBytesBilled
 
 	it, err := query.Read(ctx)
 	if err != nil {
@@ -141,7 +171,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		}
 		vMap := make(map[string]any)
 		for key, value := range row {
-			vMap[key] = value
+			vMap[key] = bigquerycommon.Value(value)
 		}
 		out = append(out, vMap)
 	}
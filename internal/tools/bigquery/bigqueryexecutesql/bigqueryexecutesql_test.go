@@ -53,6 +53,48 @@ func TestParseFromYamlBigQueryExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "maximumBytesBilled example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-execute-sql
+					source: my-instance
+					description: some description
+					maximumBytesBilled: 10000000000
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryexecutesql.Config{
+					Name:               "example_tool",
+					Kind:               "bigquery-execute-sql",
+					Source:             "my-instance",
+					Description:        "some description",
+					MaximumBytesBilled: 10000000000,
+					AuthRequired:       []string{},
+				},
+			},
+		},
+		{
+			desc: "readOnly example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-execute-sql
+					source: my-instance
+					description: some description
+					readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					ReadOnly:     true,
+					AuthRequired: []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -0,0 +1,266 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bigqueryexplain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycommon"
+)
+
+const kind string = "bigquery-explain"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+	tools.RegisterOwnTimeout(kind)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigQueryClient() *bigqueryapi.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigqueryds.Source{}
+
+var compatibleSources = [...]string{bigqueryds.SourceKind}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	// AllowAnalyze adds an analyze parameter to the tool, which runs the
+	// query to return its plan.
+	AllowAnalyze bool     `yaml:"allowAnalyze"`
+	PollInterval string   `yaml:"pollInterval"`
+	Timeout      string   `yaml:"timeout"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	waiter, err := bigquerycommon.NewWaiter(cfg.PollInterval, cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlParameter := tools.NewStringParameter("sql", "The sql query to explain.")
+	parameters := tools.Parameters{sqlParameter}
+	if cfg.AllowAnalyze {
+		analyzeParameter := tools.NewBooleanParameterWithDefault("analyze", false, "Whether to run the query to return its query plan, rather than only validate it. Only SELECT queries can be analyzed.")
+		parameters = append(parameters, analyzeParameter)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.BigQueryClient(),
+		Waiter:       waiter,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+var _ tools.OwnTimeoutTool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Client       *bigqueryapi.Client
+	Waiter       bigquerycommon.Waiter
+	manifest     tools.Manifest
+	mcpManifest  tools.McpManifest
+}
+
+// Invoke dry runs the query, and returns the statistics BigQuery estimates for
+// it. BigQuery only plans a query as it runs it, so an analyzed query is run
+// too, and its query plan is returned along with its actual statistics.
+// OwnTimeout returns how long the tool waits for its job to finish.
+func (t Tool) OwnTimeout() time.Duration {
+	return t.Waiter.Timeout
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	sql, ok := paramsMap["sql"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", paramsMap["sql"])
+	}
+	analyze, _ := paramsMap["analyze"].(bool)
+
+	stats, err := bigquerycommon.DryRun(ctx, t.Client, sql)
+	if err != nil {
+		return nil, err
+	}
+	if !analyze {
+		return map[string]any{
+			"statementType":       stats.StatementType,
+			"totalBytesProcessed": stats.TotalBytesProcessed,
+			"referencedTables":    referencedTables(stats),
+		}, nil
+	}
+	// the statement type of the dry run is checked, so that analyzing a query
+	// never changes data
+	if stats.StatementType != "SELECT" {
+		return nil, fmt.Errorf("%s statements can't be analyzed", stats.StatementType)
+	}
+
+	query := t.Client.Query(sql)
+	query.Location = t.Client.Location
+	// cached results have no query plan
+	query.DisableQueryCache = true
+	job, err := query.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run query: %w", err)
+	}
+	status, err := t.Waiter.Wait(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	if status.Statistics == nil {
+		return nil, fmt.Errorf("job %q returned no statistics", job.ID())
+	}
+	stats, ok = status.Statistics.Details.(*bigqueryapi.QueryStatistics)
+	if !ok {
+		return nil, fmt.Errorf("job %q returned no query statistics", job.ID())
+	}
+
+	plan := make([]any, 0, len(stats.QueryPlan))
+	for _, stage := range stats.QueryPlan {
+		plan = append(plan, stageResult(stage))
+	}
+	return map[string]any{
+		"statementType":       stats.StatementType,
+		"totalBytesProcessed": stats.TotalBytesProcessed,
+		"totalSlotMs":         stats.SlotMillis,
+		"referencedTables":    referencedTables(stats),
+		"queryPlan":           plan,
+	}, nil
+}
+
+func referencedTables(stats *bigqueryapi.QueryStatistics) []string {
+	tables := make([]string, 0, len(stats.ReferencedTables))
+	for _, table := range stats.ReferencedTables {
+		tables = append(tables, fmt.Sprintf("%s.%s.%s", table.ProjectID, table.DatasetID, table.TableID))
+	}
+	return tables
+}
+
+// stageResult returns a stage of a query plan, with the field names of the
+// BigQuery API.
+func stageResult(stage *bigqueryapi.ExplainQueryStage) map[string]any {
+	steps := make([]any, 0, len(stage.Steps))
+	for _, step := range stage.Steps {
+		steps = append(steps, map[string]any{"kind": step.Kind, "substeps": step.Substeps})
+	}
+	inputStages := stage.InputStages
+	if inputStages == nil {
+		inputStages = []int64{}
+	}
+	return map[string]any{
+		"id":                        stage.ID,
+		"name":                      stage.Name,
+		"status":                    stage.Status,
+		"inputStages":               inputStages,
+		"parallelInputs":            stage.ParallelInputs,
+		"completedParallelInputs":   stage.CompletedParallelInputs,
+		"recordsRead":               stage.RecordsRead,
+		"recordsWritten":            stage.RecordsWritten,
+		"shuffleOutputBytes":        stage.ShuffleOutputBytes,
+		"shuffleOutputBytesSpilled": stage.ShuffleOutputBytesSpilled,
+		"waitMsAvg":                 stage.WaitAvg.Milliseconds(),
+		"waitMsMax":                 stage.WaitMax.Milliseconds(),
+		"readMsAvg":                 stage.ReadAvg.Milliseconds(),
+		"readMsMax":                 stage.ReadMax.Milliseconds(),
+		"computeMsAvg":              stage.ComputeAvg.Milliseconds(),
+		"computeMsMax":              stage.ComputeMax.Milliseconds(),
+		"writeMsAvg":                stage.WriteAvg.Milliseconds(),
+		"writeMsMax":                stage.WriteMax.Milliseconds(),
+		"elapsedMs":                 elapsed(stage.StartTime, stage.EndTime).Milliseconds(),
+		"steps":                     steps,
+	}
+}
+
+// elapsed returns the duration of a stage, or 0 if it didn't finish.
+func elapsed(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
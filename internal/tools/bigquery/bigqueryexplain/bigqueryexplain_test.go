@@ -0,0 +1,266 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bigqueryexplain_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexplain"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlBigQueryExplain(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-explain
+					source: my-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryexplain.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-explain",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "analyze example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-explain
+					source: my-instance
+					description: some description
+					allowAnalyze: true
+					timeout: 2m
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryexplain.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-explain",
+					Source:       "my-instance",
+					Description:  "some description",
+					AllowAnalyze: true,
+					Timeout:      "2m",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeBigQueryExplain(t *testing.T) {
+	queryStats := map[string]any{
+		"totalBytesProcessed": "1024",
+		"referencedTables":    []any{map[string]any{"projectId": "my-project", "datasetId": "sales", "tableId": "orders"}},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPost {
+			// the status of the job that runs the query
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"status": map[string]any{"state": "DONE"},
+				"statistics": map[string]any{"query": map[string]any{
+					"statementType":       "SELECT",
+					"totalBytesProcessed": "1024",
+					"totalSlotMs":         "250",
+					"referencedTables":    queryStats["referencedTables"],
+					"queryPlan": []any{map[string]any{
+						"id":           "0",
+						"name":         "S00: Input",
+						"status":       "COMPLETE",
+						"recordsRead":  "100",
+						"computeMsAvg": "12",
+						"steps":        []any{map[string]any{"kind": "READ", "substeps": []any{"$1:id", "FROM sales.orders"}}},
+					}},
+				}},
+			})
+			return
+		}
+		var job map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+			t.Errorf("unable to decode job: %s", err)
+		}
+		cfg := job["configuration"].(map[string]any)
+		sql := cfg["query"].(map[string]any)["query"].(string)
+		if cfg["dryRun"] != true {
+			if cfg["query"].(map[string]any)["useQueryCache"] != false {
+				t.Errorf("query of analyze uses the cache: %v", cfg)
+			}
+			job["status"] = map[string]any{"state": "RUNNING"}
+			_ = json.NewEncoder(w).Encode(job)
+			return
+		}
+		if strings.Contains(sql, "FORM") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":400,"message":"Syntax error: Expected end of input but got identifier \"FORM\" at [1:10]"}}`)
+			return
+		}
+		stats := map[string]any{"statementType": "SELECT"}
+		if strings.HasPrefix(sql, "DELETE") {
+			stats["statementType"] = "DELETE"
+		}
+		for k, v := range queryStats {
+			stats[k] = v
+		}
+		job["status"] = map[string]any{"state": "DONE"}
+		job["statistics"] = map[string]any{"query": stats}
+		_ = json.NewEncoder(w).Encode(job)
+	}))
+	defer ts.Close()
+
+	client, err := bigqueryapi.NewClient(context.Background(), "my-project", option.WithEndpoint(ts.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	src := &bigqueryds.Source{Name: "my-instance", Kind: bigqueryds.SourceKind, Client: client}
+	cfg := bigqueryexplain.Config{
+		Name:         "example_tool",
+		Kind:         "bigquery-explain",
+		Source:       "my-instance",
+		Description:  "some description",
+		AllowAnalyze: true,
+		PollInterval: "10ms",
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		sql     string
+		analyze bool
+		want    any
+		wantErr string
+	}{
+		{
+			desc: "explain",
+			sql:  "SELECT * FROM sales.orders",
+			want: map[string]any{
+				"statementType":       "SELECT",
+				"totalBytesProcessed": int64(1024),
+				"referencedTables":    []string{"my-project.sales.orders"},
+			},
+		},
+		{
+			desc:    "analyze",
+			sql:     "SELECT * FROM sales.orders",
+			analyze: true,
+			want: map[string]any{
+				"statementType":       "SELECT",
+				"totalBytesProcessed": int64(1024),
+				"totalSlotMs":         int64(250),
+				"referencedTables":    []string{"my-project.sales.orders"},
+				"queryPlan": []any{map[string]any{
+					"id":                        int64(0),
+					"name":                      "S00: Input",
+					"status":                    "COMPLETE",
+					"inputStages":               []int64{},
+					"parallelInputs":            int64(0),
+					"completedParallelInputs":   int64(0),
+					"recordsRead":               int64(100),
+					"recordsWritten":            int64(0),
+					"shuffleOutputBytes":        int64(0),
+					"shuffleOutputBytesSpilled": int64(0),
+					"waitMsAvg":                 int64(0),
+					"waitMsMax":                 int64(0),
+					"readMsAvg":                 int64(0),
+					"readMsMax":                 int64(0),
+					"computeMsAvg":              int64(12),
+					"computeMsMax":              int64(0),
+					"writeMsAvg":                int64(0),
+					"writeMsMax":                int64(0),
+					"elapsedMs":                 int64(0),
+					"steps":                     []any{map[string]any{"kind": "READ", "substeps": []string{"$1:id", "FROM sales.orders"}}},
+				}},
+			},
+		},
+		{
+			desc:    "analyze dml",
+			sql:     "DELETE FROM sales.orders WHERE TRUE",
+			analyze: true,
+			wantErr: "DELETE statements can't be analyzed",
+		},
+		{
+			desc:    "invalid query",
+			sql:     "SELECT * FORM sales.orders",
+			wantErr: "query is invalid",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(map[string]any{"sql": tc.sql, "analyze": tc.analyze}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bigqueryexport
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycommon"
+)
+
+const kind string = "bigquery-export"
+const projectKey string = "project"
+const datasetKey string = "dataset"
+const tableKey string = "table"
+const destinationUriKey string = "destinationUri"
+
+var destinationFormats = []bigqueryapi.DataFormat{bigqueryapi.CSV, bigqueryapi.JSON, bigqueryapi.Avro, bigqueryapi.Parquet}
+
+var compressions = []bigqueryapi.Compression{bigqueryapi.None, bigqueryapi.Gzip, bigqueryapi.Deflate, bigqueryapi.Snappy}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+	tools.RegisterOwnTimeout(kind)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, DestinationFormat: string(bigqueryapi.CSV), Compression: string(bigqueryapi.None)}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigQueryClient() *bigqueryapi.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigqueryds.Source{}
+
+var compatibleSources = [...]string{bigqueryds.SourceKind}
+
+type Config struct {
+	Name              string   `yaml:"name" validate:"required"`
+	Kind              string   `yaml:"kind" validate:"required"`
+	Source            string   `yaml:"source" validate:"required"`
+	Description       string   `yaml:"description" validate:"required"`
+	DestinationFormat string   `yaml:"destinationFormat"`
+	Compression       string   `yaml:"compression"`
+	Wait              bool     `yaml:"wait"`
+	PollInterval      string   `yaml:"pollInterval"`
+	Timeout           string   `yaml:"timeout"`
+	AuthRequired      []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	if !slices.Contains(destinationFormats, bigqueryapi.DataFormat(cfg.DestinationFormat)) {
+		return nil, fmt.Errorf("invalid destinationFormat %q: must be one of %q", cfg.DestinationFormat, destinationFormats)
+	}
+	if !slices.Contains(compressions, bigqueryapi.Compression(cfg.Compression)) {
+		return nil, fmt.Errorf("invalid compression %q: must be one of %q", cfg.Compression, compressions)
+	}
+	waiter, err := bigquerycommon.NewWaiter(cfg.PollInterval, cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	projectParameter := tools.NewStringParameterWithDefault(projectKey, s.BigQueryClient().Project(), "The Google Cloud project ID containing the dataset and table.")
+	datasetParameter := tools.NewStringParameter(datasetKey, "The dataset of the table to export.")
+	tableParameter := tools.NewStringParameter(tableKey, "The table to export.")
+	destinationUriParameter := tools.NewStringParameter(destinationUriKey, "The Cloud Storage URI to export the table to, e.g. gs://my-bucket/exports/orders-*.csv. Use a single * wildcard to export tables larger than 1 GB to several files.")
+	parameters := tools.Parameters{projectParameter, datasetParameter, tableParameter, destinationUriParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:              cfg.Name,
+		Kind:              kind,
+		Parameters:        parameters,
+		AuthRequired:      cfg.AuthRequired,
+		Client:            s.BigQueryClient(),
+		DestinationFormat: bigqueryapi.DataFormat(cfg.DestinationFormat),
+		Compression:       bigqueryapi.Compression(cfg.Compression),
+		Wait:              cfg.Wait,
+		Waiter:            waiter,
+		manifest:          tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:       mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+var _ tools.OwnTimeoutTool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client            *bigqueryapi.Client
+	DestinationFormat bigqueryapi.DataFormat
+	Compression       bigqueryapi.Compression
+	Wait              bool
+	Waiter            bigquerycommon.Waiter
+	manifest          tools.Manifest
+	mcpManifest       tools.McpManifest
+}
+
+// Invoke starts an extract job. Unless the tool waits for the job, it returns
+// the reference of the job, which can be looked up to check its progress.
+// OwnTimeout returns how long the tool waits for its job to finish.
+func (t Tool) OwnTimeout() time.Duration {
+	return t.Waiter.Timeout
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	projectId, ok := mapParams[projectKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a string", projectKey)
+	}
+	datasetId, ok := mapParams[datasetKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a string", datasetKey)
+	}
+	tableId, ok := mapParams[tableKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a string", tableKey)
+	}
+	uri, ok := mapParams[destinationUriKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a string", destinationUriKey)
+	}
+	if !strings.HasPrefix(uri, "gs://") {
+		return nil, fmt.Errorf("invalid destination URI %q: must be a Cloud Storage URI starting with gs://", uri)
+	}
+
+	gcsRef := bigqueryapi.NewGCSReference(uri)
+	gcsRef.DestinationFormat = t.DestinationFormat
+	gcsRef.Compression = t.Compression
+	extractor := t.Client.DatasetInProject(projectId, datasetId).Table(tableId).ExtractorTo(gcsRef)
+
+	job, err := extractor.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start extract job of table %s.%s.%s: %w", projectId, datasetId, tableId, err)
+	}
+	if !t.Wait {
+		return bigquerycommon.JobResult(job, nil), nil
+	}
+
+	status, err := t.Waiter.Wait(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	result := bigquerycommon.JobResult(job, status)
+	if status.Statistics == nil {
+		return result, nil
+	}
+	if stats, ok := status.Statistics.Details.(*bigqueryapi.ExtractStatistics); ok && len(stats.DestinationURIFileCounts) > 0 {
+		result["fileCount"] = stats.DestinationURIFileCounts[0]
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryexport_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexport"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlBigQueryExport(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-export
+					source: my-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryexport.Config{
+					Name:              "example_tool",
+					Kind:              "bigquery-export",
+					Source:            "my-instance",
+					Description:       "some description",
+					DestinationFormat: "CSV",
+					Compression:       "NONE",
+					AuthRequired:      []string{},
+				},
+			},
+		},
+		{
+			desc: "wait example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-export
+					source: my-instance
+					description: some description
+					destinationFormat: PARQUET
+					compression: SNAPPY
+					wait: true
+					timeout: 30m
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryexport.Config{
+					Name:              "example_tool",
+					Kind:              "bigquery-export",
+					Source:            "my-instance",
+					Description:       "some description",
+					DestinationFormat: "PARQUET",
+					Compression:       "SNAPPY",
+					Wait:              true,
+					Timeout:           "30m",
+					AuthRequired:      []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeBigQueryExport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			var job map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+				t.Errorf("unable to decode job: %s", err)
+			}
+			extract := job["configuration"].(map[string]any)["extract"].(map[string]any)
+			if extract["destinationFormat"] != "PARQUET" || extract["compression"] != "SNAPPY" {
+				t.Errorf("unexpected extract configuration: %v", extract)
+			}
+			job["jobReference"].(map[string]any)["location"] = "US"
+			job["status"] = map[string]any{"state": "RUNNING"}
+			_ = json.NewEncoder(w).Encode(job)
+			return
+		}
+		fmt.Fprint(w, `{"status":{"state":"DONE"},"statistics":{"extract":{"destinationUriFileCounts":["3"]}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := bigqueryapi.NewClient(context.Background(), "my-project", option.WithEndpoint(ts.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	src := &bigqueryds.Source{Name: "my-instance", Kind: bigqueryds.SourceKind, Client: client}
+	cfg := bigqueryexport.Config{
+		Name:              "example_tool",
+		Kind:              "bigquery-export",
+		Source:            "my-instance",
+		Description:       "some description",
+		DestinationFormat: "PARQUET",
+		Compression:       "SNAPPY",
+		Wait:              true,
+		PollInterval:      "10ms",
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		uri     string
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			desc: "wait for job",
+			uri:  "gs://my-bucket/exports/orders-*.parquet",
+			want: map[string]any{"projectId": "my-project", "location": "US", "state": "DONE", "fileCount": int64(3)},
+		},
+		{
+			desc:    "invalid uri",
+			uri:     "/tmp/orders.parquet",
+			wantErr: `invalid destination URI "/tmp/orders.parquet": must be a Cloud Storage URI starting with gs://`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(map[string]any{"dataset": "sales", "table": "orders", "destinationUri": tc.uri}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			result := got.(map[string]any)
+			delete(result, "jobId")
+			if diff := cmp.Diff(tc.want, result); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bigqueryload
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycommon"
+)
+
+const kind string = "bigquery-load"
+const projectKey string = "project"
+const datasetKey string = "dataset"
+const tableKey string = "table"
+const sourceUrisKey string = "sourceUris"
+
+var sourceFormats = []bigqueryapi.DataFormat{bigqueryapi.CSV, bigqueryapi.JSON, bigqueryapi.Avro, bigqueryapi.Parquet, bigqueryapi.ORC}
+
+var writeDispositions = []bigqueryapi.TableWriteDisposition{bigqueryapi.WriteAppend, bigqueryapi.WriteTruncate, bigqueryapi.WriteEmpty}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+	tools.RegisterOwnTimeout(kind)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, SourceFormat: string(bigqueryapi.CSV), WriteDisposition: string(bigqueryapi.WriteAppend)}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigQueryClient() *bigqueryapi.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigqueryds.Source{}
+
+var compatibleSources = [...]string{bigqueryds.SourceKind}
+
+type Config struct {
+	Name             string   `yaml:"name" validate:"required"`
+	Kind             string   `yaml:"kind" validate:"required"`
+	Source           string   `yaml:"source" validate:"required"`
+	Description      string   `yaml:"description" validate:"required"`
+	SourceFormat     string   `yaml:"sourceFormat"`
+	Autodetect       bool     `yaml:"autodetect"`
+	SkipLeadingRows  int64    `yaml:"skipLeadingRows"`
+	WriteDisposition string   `yaml:"writeDisposition"`
+	Wait             bool     `yaml:"wait"`
+	PollInterval     string   `yaml:"pollInterval"`
+	Timeout          string   `yaml:"timeout"`
+	AuthRequired     []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	if !slices.Contains(sourceFormats, bigqueryapi.DataFormat(cfg.SourceFormat)) {
+		return nil, fmt.Errorf("invalid sourceFormat %q: must be one of %q", cfg.SourceFormat, sourceFormats)
+	}
+	if !slices.Contains(writeDispositions, bigqueryapi.TableWriteDisposition(cfg.WriteDisposition)) {
+		return nil, fmt.Errorf("invalid writeDisposition %q: must be one of %q", cfg.WriteDisposition, writeDispositions)
+	}
+	waiter, err := bigquerycommon.NewWaiter(cfg.PollInterval, cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	projectParameter := tools.NewStringParameterWithDefault(projectKey, s.BigQueryClient().Project(), "The Google Cloud project ID containing the dataset and table.")
+	datasetParameter := tools.NewStringParameter(datasetKey, "The dataset of the table to load the data into.")
+	tableParameter := tools.NewStringParameter(tableKey, "The table to load the data into. It's created if it doesn't exist.")
+	sourceUrisParameter := tools.NewArrayParameter(sourceUrisKey, "The Cloud Storage URIs of the files to load, e.g. gs://my-bucket/data/*.csv.", tools.NewStringParameter("uri", "A Cloud Storage URI, which may contain a single * wildcard."))
+	parameters := tools.Parameters{projectParameter, datasetParameter, tableParameter, sourceUrisParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:             cfg.Name,
+		Kind:             kind,
+		Parameters:       parameters,
+		AuthRequired:     cfg.AuthRequired,
+		Client:           s.BigQueryClient(),
+		SourceFormat:     bigqueryapi.DataFormat(cfg.SourceFormat),
+		Autodetect:       cfg.Autodetect,
+		SkipLeadingRows:  cfg.SkipLeadingRows,
+		WriteDisposition: bigqueryapi.TableWriteDisposition(cfg.WriteDisposition),
+		Wait:             cfg.Wait,
+		Waiter:           waiter,
+		manifest:         tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:      mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+var _ tools.OwnTimeoutTool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client           *bigqueryapi.Client
+	SourceFormat     bigqueryapi.DataFormat
+	Autodetect       bool
+	SkipLeadingRows  int64
+	WriteDisposition bigqueryapi.TableWriteDisposition
+	Wait             bool
+	Waiter           bigquerycommon.Waiter
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
+}
+
+// Invoke starts a load job. Unless the tool waits for the job, it returns the
+// reference of the job, which can be looked up to check its progress.
+// OwnTimeout returns how long the tool waits for its job to finish.
+func (t Tool) OwnTimeout() time.Duration {
+	return t.Waiter.Timeout
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	mapParams := params.AsMap()
+	projectId, ok := mapParams[projectKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a string", projectKey)
+	}
+	datasetId, ok := mapParams[datasetKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a string", datasetKey)
+	}
+	tableId, ok := mapParams[tableKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a string", tableKey)
+	}
+	rawUris, ok := mapParams[sourceUrisKey].([]any)
+	if !ok || len(rawUris) == 0 {
+		return nil, fmt.Errorf("invalid or missing '%s' parameter; expected a non-empty array of strings", sourceUrisKey)
+	}
+	uris := make([]string, 0, len(rawUris))
+	for _, u := range rawUris {
+		uri, ok := u.(string)
+		if !ok || !strings.HasPrefix(uri, "gs://") {
+			return nil, fmt.Errorf("invalid source URI %v: must be a Cloud Storage URI starting with gs://", u)
+		}
+		uris = append(uris, uri)
+	}
+
+	gcsRef := bigqueryapi.NewGCSReference(uris...)
+	gcsRef.SourceFormat = t.SourceFormat
+	gcsRef.AutoDetect = t.Autodetect
+	gcsRef.SkipLeadingRows = t.SkipLeadingRows
+	loader := t.Client.DatasetInProject(projectId, datasetId).Table(tableId).LoaderFrom(gcsRef)
+	loader.WriteDisposition = t.WriteDisposition
+
+	job, err := loader.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start load job into table %s.%s.%s: %w", projectId, datasetId, tableId, err)
+	}
+	if !t.Wait {
+		return bigquerycommon.JobResult(job, nil), nil
+	}
+
+	status, err := t.Waiter.Wait(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	result := bigquerycommon.JobResult(job, status)
+	if status.Statistics == nil {
+		return result, nil
+	}
+	if stats, ok := status.Statistics.Details.(*bigqueryapi.LoadStatistics); ok {
+		result["inputFiles"] = stats.InputFiles
+		result["inputFileBytes"] = stats.InputFileBytes
+		result["outputRows"] = stats.OutputRows
+		result["outputBytes"] = stats.OutputBytes
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryload_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	bigqueryapi "cloud.google.com/go/bigquery"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryload"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlBigQueryLoad(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-load
+					source: my-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryload.Config{
+					Name:             "example_tool",
+					Kind:             "bigquery-load",
+					Source:           "my-instance",
+					Description:      "some description",
+					SourceFormat:     "CSV",
+					WriteDisposition: "WRITE_APPEND",
+					AuthRequired:     []string{},
+				},
+			},
+		},
+		{
+			desc: "wait example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-load
+					source: my-instance
+					description: some description
+					sourceFormat: NEWLINE_DELIMITED_JSON
+					autodetect: true
+					writeDisposition: WRITE_TRUNCATE
+					wait: true
+					pollInterval: 10s
+					timeout: 30m
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryload.Config{
+					Name:             "example_tool",
+					Kind:             "bigquery-load",
+					Source:           "my-instance",
+					Description:      "some description",
+					SourceFormat:     "NEWLINE_DELIMITED_JSON",
+					Autodetect:       true,
+					WriteDisposition: "WRITE_TRUNCATE",
+					Wait:             true,
+					PollInterval:     "10s",
+					Timeout:          "30m",
+					AuthRequired:     []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func newSource(t *testing.T, url string) *bigqueryds.Source {
+	client, err := bigqueryapi.NewClient(context.Background(), "my-project", option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	return &bigqueryds.Source{Name: "my-instance", Kind: bigqueryds.SourceKind, Client: client}
+}
+
+func TestInitializeBigQueryLoadFailure(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  bigqueryload.Config
+		err  string
+	}{
+		{
+			desc: "invalid source format",
+			cfg:  bigqueryload.Config{SourceFormat: "XML", WriteDisposition: "WRITE_APPEND"},
+			err:  `invalid sourceFormat "XML": must be one of ["CSV" "NEWLINE_DELIMITED_JSON" "AVRO" "PARQUET" "ORC"]`,
+		},
+		{
+			desc: "invalid write disposition",
+			cfg:  bigqueryload.Config{SourceFormat: "CSV", WriteDisposition: "WRITE_ALWAYS"},
+			err:  `invalid writeDisposition "WRITE_ALWAYS": must be one of ["WRITE_APPEND" "WRITE_TRUNCATE" "WRITE_EMPTY"]`,
+		},
+		{
+			desc: "invalid timeout",
+			cfg:  bigqueryload.Config{SourceFormat: "CSV", WriteDisposition: "WRITE_APPEND", Wait: true, Timeout: "forever"},
+			err:  `unable to parse timeout as time.Duration: time: invalid duration "forever"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.cfg.Name = "example_tool"
+			tc.cfg.Kind = "bigquery-load"
+			tc.cfg.Source = "my-instance"
+			tc.cfg.Description = "some description"
+			_, err := tc.cfg.Initialize(map[string]sources.Source{"my-instance": newSource(t, "http://localhost")})
+			if err == nil {
+				t.Fatalf("expect initialization to fail")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+// isBroken reports whether the job of the path loads into the "broken" table.
+func isBroken(brokenJobs *sync.Map, path string) bool {
+	_, ok := brokenJobs.Load(path[strings.LastIndex(path, "/")+1:])
+	return ok
+}
+
+func TestInvokeBigQueryLoad(t *testing.T) {
+	// the jobs are running until their status is polled twice, and the jobs
+	// loading into the "broken" table fail
+	var polls atomic.Int32
+	var brokenJobs sync.Map
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			var job map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+				t.Errorf("unable to decode job: %s", err)
+			}
+			ref := job["jobReference"].(map[string]any)
+			ref["location"] = "US"
+			dst := job["configuration"].(map[string]any)["load"].(map[string]any)["destinationTable"].(map[string]any)
+			if dst["tableId"] == "broken" {
+				brokenJobs.Store(ref["jobId"], true)
+			}
+			job["status"] = map[string]any{"state": "RUNNING"}
+			_ = json.NewEncoder(w).Encode(job)
+			return
+		}
+		switch {
+		case polls.Add(1) < 2:
+			fmt.Fprint(w, `{"status":{"state":"RUNNING"}}`)
+		case isBroken(&brokenJobs, r.URL.Path):
+			fmt.Fprint(w, `{"status":{"state":"DONE","errorResult":{"reason":"invalid","message":"CSV table encountered too many errors"}}}`)
+		default:
+			fmt.Fprint(w, `{"status":{"state":"DONE"},"statistics":{"load":{"inputFiles":"2","inputFileBytes":"1024","outputRows":"10","outputBytes":"2048"}}}`)
+		}
+	}))
+	defer ts.Close()
+
+	tcs := []struct {
+		desc    string
+		wait    bool
+		table   string
+		uris    []any
+		want    map[string]any
+		wantErr string
+	}{
+		{
+			desc:  "job reference",
+			table: "orders",
+			uris:  []any{"gs://my-bucket/orders/*.csv"},
+			want:  map[string]any{"projectId": "my-project", "location": "US"},
+		},
+		{
+			desc:  "wait for job",
+			wait:  true,
+			table: "orders",
+			uris:  []any{"gs://my-bucket/orders/*.csv"},
+			want: map[string]any{
+				"projectId":      "my-project",
+				"location":       "US",
+				"state":          "DONE",
+				"inputFiles":     int64(2),
+				"inputFileBytes": int64(1024),
+				"outputRows":     int64(10),
+				"outputBytes":    int64(2048),
+			},
+		},
+		{
+			desc:    "failed job",
+			wait:    true,
+			table:   "broken",
+			uris:    []any{"gs://my-bucket/orders/*.csv"},
+			wantErr: "CSV table encountered too many errors",
+		},
+		{
+			desc:    "invalid uri",
+			table:   "orders",
+			uris:    []any{"https://example.com/orders.csv"},
+			wantErr: "invalid source URI https://example.com/orders.csv: must be a Cloud Storage URI starting with gs://",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			polls.Store(0)
+			cfg := bigqueryload.Config{
+				Name:             "example_tool",
+				Kind:             "bigquery-load",
+				Source:           "my-instance",
+				Description:      "some description",
+				SourceFormat:     "CSV",
+				WriteDisposition: "WRITE_APPEND",
+				Wait:             tc.wait,
+				PollInterval:     "10ms",
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": newSource(t, ts.URL)})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			params, err := tool.ParseParams(map[string]any{"dataset": "sales", "table": tc.table, "sourceUris": tc.uris}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			result := got.(map[string]any)
+			if result["jobId"] == "" {
+				t.Fatalf("missing job ID: %v", result)
+			}
+			delete(result, "jobId")
+			if diff := cmp.Diff(tc.want, result); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
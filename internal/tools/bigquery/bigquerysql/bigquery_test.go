@@ -64,6 +64,30 @@ func TestParseFromYamlBigQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "maximumBytesBilled example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-sql
+					source: my-instance
+					description: some description
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+					maximumBytesBilled: 10000000000
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigquerysql.Config{
+					Name:               "example_tool",
+					Kind:               "bigquery-sql",
+					Source:             "my-instance",
+					Description:        "some description",
+					Statement:          "SELECT * FROM SQL_STATEMENT;\n",
+					MaximumBytesBilled: 10000000000,
+					AuthRequired:       []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -24,6 +24,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerycommon"
 	"google.golang.org/api/iterator"
 )
 
@@ -58,6 +59,7 @@ type Config struct {
 	Source             string           `yaml:"source" validate:"required"`
 	Description        string           `yaml:"description" validate:"required"`
 	Statement          string           `yaml:"statement" validate:"required"`
+	MaximumBytesBilled int64            `yaml:"maximumBytesBilled" validate:"gte=0"`
 	AuthRequired       []string         `yaml:"authRequired"`
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
@@ -84,6 +86,13 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	}
 
 	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	claims, err := tools.BindClaims(cfg.Statement, allParameters, func(name string, _ int) string {
+		return "@" + name
+	})
+	if err != nil {
+		return nil, err
+	}
+	allParameters = append(allParameters, claims.Parameters...)
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -98,7 +107,9 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Parameters:         cfg.Parameters,
 		TemplateParameters: cfg.TemplateParameters,
 		AllParams:          allParameters,
-		Statement:          cfg.Statement,
+		Statement:          claims.Statement,
+		Claims:             claims,
+		MaximumBytesBilled: cfg.MaximumBytesBilled,
 		AuthRequired:       cfg.AuthRequired,
 		Client:             s.BigQueryClient(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
@@ -118,10 +129,12 @@ type Tool struct {
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 	AllParams          tools.Parameters `yaml:"allParams"`
 
-	Client      *bigqueryapi.Client
-	Statement   string
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Client             *bigqueryapi.Client
+	Statement          string
+	Claims             tools.ClaimBinding
+	MaximumBytesBilled int64
+	manifest           tools.Manifest
+	mcpManifest        tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
@@ -151,6 +164,14 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 			if err != nil {
 				return nil, fmt.Errorf("unable to convert parameter `%s` from []any to typed slice: %w", name, err)
 			}
+		default:
+			if value != nil {
+				var err error
+				value, err = tools.ConvertAnyToTyped(value, p.GetType())
+				if err != nil {
+					return nil, fmt.Errorf("unable to convert parameter `%s`: %w", name, err)
+				}
+			}
 		}
 
 		if strings.Contains(t.Statement, "@"+name) {
@@ -164,10 +185,17 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 			})
 		}
 	}
+	for _, c := range t.Claims.Values(paramsMap) {
+		namedArgs = append(namedArgs, bigqueryapi.QueryParameter{
+			Name:  c.Name,
+			Value: c.Value,
+		})
+	}
 
 	query := t.Client.Query(newStatement)
 	query.Parameters = namedArgs
 	query.Location = t.Client.Location
+	query.MaxBytesBilled = t.MaximumBytesBilled
 
 	it, err := query.Read(ctx)
 	if err != nil {
@@ -186,7 +214,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		}
 		vMap := make(map[string]any)
 		for key, value := range row {
-			vMap[key] = value
+			vMap[key] = bigquerycommon.Value(value)
 		}
 		out = append(out, vMap)
 	}
@@ -17,6 +17,7 @@ package bigtable
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/bigtable"
 	yaml "github.com/goccy/go-yaml"
@@ -132,6 +133,12 @@ func getBigtableType(paramType string) (bigtable.SQLType, error) {
 		return bigtable.Int64SQLType{}, nil
 	case "float":
 		return bigtable.Float64SQLType{}, nil
+	case "date":
+		return bigtable.DateSQLType{}, nil
+	case "timestamp":
+		return bigtable.TimestampSQLType{}, nil
+	case "enum":
+		return bigtable.StringSQLType{}, nil
 	case "array":
 		return bigtable.ArraySQLType{}, nil
 	default:
@@ -173,6 +180,28 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
 
+	// Bigtable binds dates as civil.Date
+	for i, p := range t.Parameters {
+		switch v := newParams[i].Value.(type) {
+		case time.Time:
+			newParams[i].Value, err = tools.ConvertAnyToTyped(v, p.GetType())
+		case []any:
+			if p.Manifest().Items.Type != "date" {
+				continue
+			}
+			dates := make([]any, len(v))
+			for j, item := range v {
+				if dates[j], err = tools.ConvertAnyToTyped(item, "date"); err != nil {
+					break
+				}
+			}
+			newParams[i].Value = dates
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert parameter `%s`: %w", p.GetName(), err)
+		}
+	}
+
 	mapParamsType, err := getMapParamsType(t.Parameters, newParams)
 	if err != nil {
 		return nil, fmt.Errorf("fail to get map params: %w", err)
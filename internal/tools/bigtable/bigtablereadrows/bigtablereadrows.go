@@ -0,0 +1,254 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigtablereadrows
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"cloud.google.com/go/bigtable"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigtabledb "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "bigtable-read-rows"
+
+// defaultMaxLimit is the maximum number of rows that the agent may ask for, if
+// maxLimit isn't set.
+const defaultMaxLimit = 100
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	BigtableClient() *bigtable.Client
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &bigtabledb.Source{}
+
+var compatibleSources = [...]string{bigtabledb.SourceKind}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	Table       string `yaml:"table" validate:"required"`
+	// ColumnFamilies are the column families that the agent may read. Defaults
+	// to all of them.
+	ColumnFamilies []string `yaml:"columnFamilies"`
+	MaxLimit       int      `yaml:"maxLimit" validate:"gte=0"`
+	AuthRequired   []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	maxLimit := cfg.MaxLimit
+	if maxLimit == 0 {
+		maxLimit = defaultMaxLimit
+	}
+	limitParameter := tools.NewIntParameterWithDefault("limit", min(10, maxLimit), "Maximum number of rows to return.")
+	minimum, maximum := 1.0, float64(maxLimit)
+	limitParameter.Minimum, limitParameter.Maximum = &minimum, &maximum
+	cellsParameter := tools.NewIntParameterWithDefault("cellsPerColumn", 1, "Number of the latest cells, or versions, of each column to return.")
+	cellsParameter.Minimum = &minimum
+
+	var familyParameter tools.Parameter = tools.NewStringParameter("family", "A column family.")
+	if len(cfg.ColumnFamilies) > 0 {
+		familyParameter = tools.NewEnumParameter("family", "A column family.", cfg.ColumnFamilies)
+	}
+	parameters := tools.Parameters{
+		tools.NewStringParameterWithRequired("prefix", "Prefix of the keys of the rows to return.", false),
+		tools.NewStringParameterWithRequired("start", "Key of the first row of the range of rows to return, inclusive.", false),
+		tools.NewStringParameterWithRequired("end", "Key of the end of the range of rows to return, exclusive.", false),
+		tools.NewArrayParameterWithRequired("families", "Column families to return. Defaults to all of them.", false, familyParameter),
+		cellsParameter,
+		limitParameter,
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:           cfg.Name,
+		Kind:           kind,
+		Parameters:     parameters,
+		AuthRequired:   cfg.AuthRequired,
+		Client:         s.BigtableClient(),
+		Table:          cfg.Table,
+		ColumnFamilies: cfg.ColumnFamilies,
+		manifest:       tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:    mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client         *bigtable.Client
+	Table          string
+	ColumnFamilies []string
+	manifest       tools.Manifest
+	mcpManifest    tools.McpManifest
+}
+
+// Invoke returns the rows of the range of the parameters, in the order of
+// their keys, with the latest cells of their columns.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	rowSet, err := RowSet(paramsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	families := t.ColumnFamilies
+	if values, ok := paramsMap["families"].([]any); ok && len(values) > 0 {
+		families = make([]string, len(values))
+		for i, v := range values {
+			families[i], _ = v.(string)
+		}
+	}
+	cellsPerColumn, _ := paramsMap["cellsPerColumn"].(int)
+	limit, _ := paramsMap["limit"].(int)
+	filter := bigtable.LatestNFilter(cellsPerColumn)
+	if len(families) > 0 {
+		filter = bigtable.ChainFilters(bigtable.FamilyFilter(familyPattern(families)), filter)
+	}
+
+	rows := []any{}
+	err = t.Client.Open(t.Table).ReadRows(ctx, rowSet, func(r bigtable.Row) bool {
+		rows = append(rows, rowResult(r))
+		return true
+	}, bigtable.RowFilter(filter), bigtable.LimitRows(int64(limit)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read rows: %w", err)
+	}
+	return rows, nil
+}
+
+// RowSet returns the rows of the parameters: the rows with the prefix, or the
+// rows from start to end, or else all the rows.
+func RowSet(paramsMap map[string]any) (bigtable.RowSet, error) {
+	prefix, _ := paramsMap["prefix"].(string)
+	start, _ := paramsMap["start"].(string)
+	end, _ := paramsMap["end"].(string)
+	switch {
+	case prefix != "" && (start != "" || end != ""):
+		return nil, fmt.Errorf("prefix can't be set along with start or end")
+	case prefix != "":
+		return bigtable.PrefixRange(prefix), nil
+	case end != "":
+		if start >= end {
+			return nil, fmt.Errorf("start %q must be before end %q", start, end)
+		}
+		return bigtable.NewRange(start, end), nil
+	default:
+		return bigtable.InfiniteRange(start), nil
+	}
+}
+
+// familyPattern returns the regular expression that matches the names of the
+// families exactly.
+func familyPattern(families []string) string {
+	quoted := make([]string, len(families))
+	for i, f := range families {
+		quoted[i] = regexp.QuoteMeta(f)
+	}
+	return "^(?:" + strings.Join(quoted, "|") + ")$"
+}
+
+// rowResult returns the key of the row and its cells by column, as
+// family:qualifier, latest first. Values that aren't UTF-8 text are encoded in
+// base64.
+func rowResult(r bigtable.Row) map[string]any {
+	columns := make(map[string]any)
+	for _, items := range r {
+		for _, item := range items {
+			cell := map[string]any{"timestamp": item.Timestamp.Time().UTC().Format(time.RFC3339Nano)}
+			if utf8.Valid(item.Value) {
+				cell["value"] = string(item.Value)
+			} else {
+				cell["base64Value"] = base64.StdEncoding.EncodeToString(item.Value)
+			}
+			cells, _ := columns[item.Column].([]any)
+			columns[item.Column] = append(cells, cell)
+		}
+	}
+	return map[string]any{"key": r.Key(), "columns": columns}
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
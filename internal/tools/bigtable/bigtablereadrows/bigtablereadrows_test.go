@@ -0,0 +1,242 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigtablereadrows_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigtable"
+	"cloud.google.com/go/bigtable/bttest"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigtabledb "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigtable/bigtablereadrows"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestParseFromYamlBigtableReadRows(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		example_tool:
+			kind: bigtable-read-rows
+			source: my-bigtable-instance
+			description: some description
+			table: events
+			columnFamilies: [info, stats]
+			maxLimit: 50
+	`
+	want := server.ToolConfigs{
+		"example_tool": bigtablereadrows.Config{
+			Name:           "example_tool",
+			Kind:           "bigtable-read-rows",
+			Source:         "my-bigtable-instance",
+			Description:    "some description",
+			Table:          "events",
+			ColumnFamilies: []string{"info", "stats"},
+			MaxLimit:       50,
+			AuthRequired:   []string{},
+		},
+	}
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	// Parse contents
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestRowSet(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		params  map[string]any
+		want    string
+		wantErr string
+	}{
+		{desc: "all", params: map[string]any{}, want: bigtable.InfiniteRange("").String()},
+		{desc: "prefix", params: map[string]any{"prefix": "user#1"}, want: bigtable.PrefixRange("user#1").String()},
+		{desc: "range", params: map[string]any{"start": "a", "end": "c"}, want: bigtable.NewRange("a", "c").String()},
+		{desc: "start", params: map[string]any{"start": "b"}, want: bigtable.InfiniteRange("b").String()},
+		{desc: "prefix and range", params: map[string]any{"prefix": "a", "end": "c"}, wantErr: "prefix can't be set along with start or end"},
+		{desc: "empty range", params: map[string]any{"start": "c", "end": "a"}, wantErr: `start "c" must be before end "a"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := bigtablereadrows.RowSet(tc.params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if r := got.(bigtable.RowRange).String(); r != tc.want {
+				t.Fatalf("incorrect row set: got %s, want %s", r, tc.want)
+			}
+		})
+	}
+}
+
+// newSource returns a source of an in-memory Bigtable instance, with a table
+// events of rows with two cells of info:name and one of stats:count each.
+func newSource(t *testing.T) sources.Source {
+	t.Helper()
+	ctx := context.Background()
+	srv, err := bttest.NewServer("localhost:0")
+	if err != nil {
+		t.Fatalf("unable to start server: %s", err)
+	}
+	t.Cleanup(srv.Close)
+	conn, err := grpc.NewClient(srv.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unable to connect: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	admin, err := bigtable.NewAdminClient(ctx, "proj", "instance", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("unable to create admin client: %s", err)
+	}
+	if err := admin.CreateTable(ctx, "events"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	for _, family := range []string{"info", "stats"} {
+		if err := admin.CreateColumnFamily(ctx, "events", family); err != nil {
+			t.Fatalf("unable to create column family: %s", err)
+		}
+	}
+
+	client, err := bigtable.NewClientWithConfig(ctx, "proj", "instance", bigtable.ClientConfig{MetricsProvider: bigtable.NoopMetricsProvider{}}, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	table := client.Open("events")
+	for _, key := range []string{"user#1#a", "user#1#b", "user#2#a"} {
+		mut := bigtable.NewMutation()
+		mut.Set("info", "name", bigtable.Time(time.Unix(1, 0)), []byte("old "+key))
+		mut.Set("info", "name", bigtable.Time(time.Unix(2, 0)), []byte("new "+key))
+		mut.Set("stats", "count", bigtable.Time(time.Unix(2, 0)), []byte{0xff, 0x01})
+		if err := table.Apply(ctx, key, mut); err != nil {
+			t.Fatalf("unable to write row: %s", err)
+		}
+	}
+	return &bigtabledb.Source{Name: "my-bigtable-instance", Kind: "bigtable", Client: client}
+}
+
+func TestInvoke(t *testing.T) {
+	srcs := map[string]sources.Source{"my-bigtable-instance": newSource(t)}
+	cfg := bigtablereadrows.Config{
+		Name:        "example_tool",
+		Kind:        "bigtable-read-rows",
+		Source:      "my-bigtable-instance",
+		Description: "some description",
+		Table:       "events",
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	name := func(v string, sec int64) map[string]any {
+		return map[string]any{"value": v, "timestamp": time.Unix(sec, 0).UTC().Format(time.RFC3339Nano)}
+	}
+	count := map[string]any{"base64Value": "/wE=", "timestamp": "1970-01-01T00:00:02Z"}
+	tcs := []struct {
+		desc string
+		data map[string]any
+		want []any
+	}{
+		{
+			desc: "prefix",
+			data: map[string]any{"prefix": "user#1"},
+			want: []any{
+				map[string]any{"key": "user#1#a", "columns": map[string]any{"info:name": []any{name("new user#1#a", 2)}, "stats:count": []any{count}}},
+				map[string]any{"key": "user#1#b", "columns": map[string]any{"info:name": []any{name("new user#1#b", 2)}, "stats:count": []any{count}}},
+			},
+		},
+		{
+			desc: "range with families and cells",
+			data: map[string]any{"start": "user#1#b", "end": "user#2#b", "families": []any{"info"}, "cellsPerColumn": 2},
+			want: []any{
+				map[string]any{"key": "user#1#b", "columns": map[string]any{"info:name": []any{name("new user#1#b", 2), name("old user#1#b", 1)}}},
+				map[string]any{"key": "user#2#a", "columns": map[string]any{"info:name": []any{name("new user#2#a", 2), name("old user#2#a", 1)}}},
+			},
+		},
+		{
+			desc: "limit",
+			data: map[string]any{"families": []any{"stats"}, "limit": 1},
+			want: []any{
+				map[string]any{"key": "user#1#a", "columns": map[string]any{"stats:count": []any{count}}},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(tc.data, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect rows: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseParamsColumnFamilies(t *testing.T) {
+	cfg := bigtablereadrows.Config{
+		Name:           "example_tool",
+		Kind:           "bigtable-read-rows",
+		Source:         "my-bigtable-instance",
+		Description:    "some description",
+		Table:          "events",
+		ColumnFamilies: []string{"info"},
+		MaxLimit:       5,
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-bigtable-instance": &bigtabledb.Source{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := tool.ParseParams(map[string]any{"families": []any{"info"}}, nil); err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.ParseParams(map[string]any{"families": []any{"stats"}}, nil); err == nil {
+		t.Fatalf("expected error for a column family that isn't allowed")
+	}
+	if _, err := tool.ParseParams(map[string]any{"limit": 6}, nil); err == nil {
+		t.Fatalf("expected error for a limit above maxLimit")
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandracql_test
+
+import (
+	"strings"
+	"testing"
+
+	gocql "github.com/apache/cassandra-gocql-driver/v2"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/cassandra/cassandracql"
+)
+
+func TestParseFromYamlCassandraCql(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: cassandra-cql
+					source: my-cassandra-instance
+					description: some description
+					statement: |
+						SELECT * FROM users WHERE country = ?;
+					consistency: local_one
+					authRequired:
+						- my-google-auth-service
+						- other-auth-service
+					parameters:
+						- name: country
+						  type: string
+						  description: some description
+						  authServices:
+							- name: my-google-auth-service
+							  field: user_id
+							- name: other-auth-service
+							  field: user_id
+			`,
+			want: server.ToolConfigs{
+				"example_tool": cassandracql.Config{
+					Name:         "example_tool",
+					Kind:         "cassandra-cql",
+					Source:       "my-cassandra-instance",
+					Description:  "some description",
+					Statement:    "SELECT * FROM users WHERE country = ?;\n",
+					Consistency:  "local_one",
+					AuthRequired: []string{"my-google-auth-service", "other-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameterWithAuth("country", "some description",
+							[]tools.ParamAuthService{{Name: "my-google-auth-service", Field: "user_id"},
+								{Name: "other-auth-service", Field: "user_id"}}),
+					},
+				},
+			},
+		},
+		{
+			desc: "with template parameters",
+			in: `
+			tools:
+				example_tool:
+					kind: cassandra-cql
+					source: my-cassandra-instance
+					description: some description
+					statement: |
+						SELECT * FROM {{.tableName}};
+					templateParameters:
+						- name: tableName
+						  type: string
+						  description: The table to select from.
+			`,
+			want: server.ToolConfigs{
+				"example_tool": cassandracql.Config{
+					Name:         "example_tool",
+					Kind:         "cassandra-cql",
+					Source:       "my-cassandra-instance",
+					Description:  "some description",
+					Statement:    "SELECT * FROM {{.tableName}};\n",
+					AuthRequired: []string{},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("tableName", "The table to select from."),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// fakeSource is a cassandra source without a session.
+type fakeSource struct{}
+
+func (s *fakeSource) SourceKind() string {
+	return "cassandra"
+}
+
+func (s *fakeSource) CassandraSession() *gocql.Session {
+	return nil
+}
+
+func TestInitializeCassandraCqlConsistency(t *testing.T) {
+	tcs := []struct {
+		desc        string
+		consistency string
+		want        *gocql.Consistency
+		err         string
+	}{
+		{
+			desc: "source default",
+		},
+		{
+			desc:        "valid consistency",
+			consistency: "local_quorum",
+			want:        func() *gocql.Consistency { c := gocql.LocalQuorum; return &c }(),
+		},
+		{
+			desc:        "invalid consistency",
+			consistency: "most",
+			err:         `invalid consistency "most"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := cassandracql.Config{
+				Name:        "example_tool",
+				Kind:        "cassandra-cql",
+				Source:      "my-cassandra-instance",
+				Description: "some description",
+				Statement:   "SELECT * FROM users;",
+				Consistency: tc.consistency,
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-cassandra-instance": &fakeSource{}})
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, tool.(cassandracql.Tool).Consistency); diff != "" {
+				t.Fatalf("incorrect consistency: diff %v", diff)
+			}
+		})
+	}
+}
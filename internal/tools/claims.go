@@ -0,0 +1,203 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+)
+
+// claimReference matches a reference to a claim of a verified auth service in
+// a statement, e.g. {{ .claims.my-google-auth.email }}.
+var claimReference = regexp.MustCompile(`\{\{-?\s*\.claims\.([\w-]+)\.(\w+)\s*-?\}\}`)
+
+// claimMarker marks the positional placeholder of a claim while the statement
+// is tokenized.
+var claimMarker = regexp.MustCompile(`\?__claim_(\d+)`)
+
+// ClaimBinding is a statement whose references to claims are replaced by
+// query parameters, so the values of the claims are bound by the database
+// instead of being rendered into the SQL.
+type ClaimBinding struct {
+	// Statement is the statement with the references replaced by
+	// placeholders.
+	Statement string
+	// Parameters are the parameters of the claims, in the order of their
+	// first reference. They are authenticated parameters, so their values
+	// always come from the claims of a verified token, and they should only
+	// be added to the parameters parsed by the tool, not to its manifests.
+	Parameters Parameters
+	// positions is the index in Parameters of the claim of each '?'
+	// placeholder of a positional statement, or -1 for the placeholder of a
+	// parameter of the tool.
+	positions []int
+}
+
+// BindClaims replaces the references to claims in statement with the
+// placeholders returned by placeholder, which is called with the bind name of
+// the claim and its index in Parameters. The bind name must not be the name of
+// one of params.
+func BindClaims(statement string, params Parameters, placeholder func(name string, i int) string) (ClaimBinding, error) {
+	b := ClaimBinding{Parameters: Parameters{}}
+	indexes := make(map[string]int)
+	var err error
+	b.Statement = claimReference.ReplaceAllStringFunc(statement, func(ref string) string {
+		m := claimReference.FindStringSubmatch(ref)
+		service, field := m[1], m[2]
+		name := claimBindName(service, field)
+		i, ok := indexes[name]
+		if !ok {
+			for _, p := range params {
+				if p.GetName() == name && err == nil {
+					err = fmt.Errorf("parameter %q conflicts with the claim %q of auth service %q", name, field, service)
+				}
+			}
+			i = len(b.Parameters)
+			indexes[name] = i
+			b.Parameters = append(b.Parameters, newClaimParameter(name, service, field))
+		}
+		return placeholder(name, i)
+	})
+	if err != nil {
+		return ClaimBinding{}, err
+	}
+	return b, nil
+}
+
+// BindPositionalClaims replaces the references to claims in statement with '?'
+// placeholders, and records the position of each one among the '?'
+// placeholders of the parameters of the tool, which are bound in order.
+func BindPositionalClaims(statement string, params Parameters, dialect sqlparse.Dialect) (ClaimBinding, error) {
+	b, err := BindClaims(statement, params, func(_ string, i int) string {
+		return fmt.Sprintf("?__claim_%d", i)
+	})
+	if err != nil || len(b.Parameters) == 0 {
+		return b, err
+	}
+
+	stmts, err := sqlparse.Parse(b.Statement, dialect)
+	if err != nil {
+		return ClaimBinding{}, fmt.Errorf("unable to parse statement: %w", err)
+	}
+	b.positions = []int{}
+	bound := 0
+	for _, stmt := range stmts {
+		for _, tok := range stmt.Tokens {
+			if tok.Kind != sqlparse.Parameter || !strings.HasPrefix(tok.Value, "?") {
+				continue
+			}
+			if tok.Value == "?" {
+				b.positions = append(b.positions, -1)
+				continue
+			}
+			m := claimMarker.FindStringSubmatch(tok.Value)
+			if m == nil || m[0] != tok.Value {
+				return ClaimBinding{}, fmt.Errorf("claims can't be used with the numbered parameter %q", tok.Value)
+			}
+			i, _ := strconv.Atoi(m[1])
+			b.positions = append(b.positions, i)
+			bound++
+		}
+	}
+	// a marker inside a literal or a comment isn't a parameter token
+	if bound != len(claimMarker.FindAllString(b.Statement, -1)) {
+		return ClaimBinding{}, fmt.Errorf("claims must be referenced as values, not inside a string literal, quoted identifier or comment")
+	}
+	b.Statement = claimMarker.ReplaceAllString(b.Statement, "?")
+	return b, nil
+}
+
+// Values returns the values of the claims, in the order of Parameters.
+func (b ClaimBinding) Values(paramsMap map[string]any) ParamValues {
+	values := make(ParamValues, 0, len(b.Parameters))
+	for _, p := range b.Parameters {
+		values = append(values, ParamValue{Name: p.GetName(), Value: paramsMap[p.GetName()]})
+	}
+	return values
+}
+
+// Args returns the arguments of the statement, given the arguments of the
+// parameters of the tool. The values of the claims are appended to them, or
+// for a positional statement, inserted at the positions of their
+// placeholders.
+func (b ClaimBinding) Args(args []any, paramsMap map[string]any) []any {
+	values := b.Values(paramsMap).AsSlice()
+	if b.positions == nil {
+		return append(args, values...)
+	}
+	out := make([]any, 0, len(args)+len(b.positions))
+	next := 0
+	for _, i := range b.positions {
+		if i >= 0 {
+			out = append(out, values[i])
+			continue
+		}
+		if next < len(args) {
+			out = append(out, args[next])
+			next++
+		}
+	}
+	return append(out, args[next:]...)
+}
+
+// claimBindName returns the name of the parameter of a claim, which is a valid
+// name of a query parameter in every dialect.
+func claimBindName(service, field string) string {
+	return "claims_" + strings.ReplaceAll(service, "-", "_") + "_" + field
+}
+
+// claimParameter is the value of a claim referenced by a statement. It is
+// always parsed from the claims of its auth service.
+type claimParameter struct {
+	CommonParameter
+}
+
+func newClaimParameter(name, service, field string) *claimParameter {
+	return &claimParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         "claim",
+			Desc:         fmt.Sprintf("claim %q of auth service %q", field, service),
+			AuthServices: []ParamAuthService{{Name: service, Field: field}},
+		},
+	}
+}
+
+// Parse keeps the value of the claim as it is in the token.
+func (p *claimParameter) Parse(v any) (any, error) {
+	return v, nil
+}
+
+func (p *claimParameter) GetDefault() any {
+	return nil
+}
+
+func (p *claimParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *claimParameter) Manifest() ParameterManifest {
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     true,
+		Description:  p.Desc,
+		AuthServices: []string{p.AuthServices[0].Name},
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+)
+
+func TestBindClaims(t *testing.T) {
+	params := tools.Parameters{tools.NewStringParameter("status", "some description")}
+	b, err := tools.BindClaims(
+		"SELECT * FROM t WHERE status = $1 AND owner = {{ .claims.my-google-auth.email }} OR editor = {{.claims.my-google-auth.email}} AND org = {{- .claims.other.hd -}}",
+		params,
+		func(_ string, i int) string { return fmt.Sprintf("$%d", len(params)+i+1) },
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantStatement := "SELECT * FROM t WHERE status = $1 AND owner = $2 OR editor = $2 AND org = $3"
+	if b.Statement != wantStatement {
+		t.Fatalf("incorrect statement: got %q, want %q", b.Statement, wantStatement)
+	}
+	var names []string
+	for _, p := range b.Parameters {
+		names = append(names, p.GetName())
+	}
+	if diff := cmp.Diff([]string{"claims_my_google_auth_email", "claims_other_hd"}, names); diff != "" {
+		t.Fatalf("incorrect parameters: diff %v", diff)
+	}
+
+	// the values of the claims only come from the verified claims
+	claims := map[string]map[string]any{
+		"my-google-auth": {"email": "alice@example.com"},
+		"other":          {"hd": "example.com"},
+	}
+	data := map[string]any{"status": "open", "claims_other_hd": "evil.com"}
+	values, err := tools.ParseParams(append(params, b.Parameters...), data, claims)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got := b.Args([]any{"open"}, values.AsMap())
+	if diff := cmp.Diff([]any{"open", "alice@example.com", "example.com"}, got); diff != "" {
+		t.Fatalf("incorrect args: diff %v", diff)
+	}
+	_, err = tools.ParseParams(append(params, b.Parameters...), data, nil)
+	if err == nil {
+		t.Fatalf("expect error without verified claims")
+	}
+}
+
+func TestBindClaimsConflict(t *testing.T) {
+	params := tools.Parameters{tools.NewStringParameter("claims_google_email", "some description")}
+	_, err := tools.BindClaims("SELECT {{ .claims.google.email }}", params, func(name string, _ int) string { return "@" + name })
+	want := `parameter "claims_google_email" conflicts with the claim "email" of auth service "google"`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %s", err, want)
+	}
+}
+
+func TestBindPositionalClaims(t *testing.T) {
+	paramsMap := map[string]any{"claims_google_email": "alice@example.com", "claims_google_sub": "123"}
+	tcs := []struct {
+		desc          string
+		statement     string
+		args          []any
+		wantStatement string
+		wantArgs      []any
+	}{
+		{
+			desc:          "no claims",
+			statement:     "SELECT * FROM t WHERE a = ? AND b = ?",
+			args:          []any{1, 2},
+			wantStatement: "SELECT * FROM t WHERE a = ? AND b = ?",
+			wantArgs:      []any{1, 2},
+		},
+		{
+			desc:          "claims between parameters",
+			statement:     "SELECT * FROM t WHERE a = ? AND owner = {{ .claims.google.email }} AND b = ? AND sub = {{ .claims.google.sub }} AND c = ? OR editor = {{ .claims.google.email }}",
+			args:          []any{1, 2, 3},
+			wantStatement: "SELECT * FROM t WHERE a = ? AND owner = ? AND b = ? AND sub = ? AND c = ? OR editor = ?",
+			wantArgs:      []any{1, "alice@example.com", 2, "123", 3, "alice@example.com"},
+		},
+		{
+			desc:          "placeholder in a literal",
+			statement:     "SELECT '?' AS q FROM t WHERE owner = {{ .claims.google.email }} AND a = ?",
+			args:          []any{1},
+			wantStatement: "SELECT '?' AS q FROM t WHERE owner = ? AND a = ?",
+			wantArgs:      []any{"alice@example.com", 1},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := tools.BindPositionalClaims(tc.statement, nil, sqlparse.MySQL)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if b.Statement != tc.wantStatement {
+				t.Fatalf("incorrect statement: got %q, want %q", b.Statement, tc.wantStatement)
+			}
+			if diff := cmp.Diff(tc.wantArgs, b.Args(tc.args, paramsMap)); diff != "" {
+				t.Fatalf("incorrect args: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestBindPositionalClaimsErrors(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		statement string
+		want      string
+	}{
+		{
+			desc:      "claim in a string literal",
+			statement: "SELECT * FROM t WHERE owner = '{{ .claims.google.email }}'",
+			want:      "claims must be referenced as values, not inside a string literal, quoted identifier or comment",
+		},
+		{
+			desc:      "numbered parameter",
+			statement: "SELECT * FROM t WHERE a = ?1 AND owner = {{ .claims.google.email }}",
+			want:      `claims can't be used with the numbered parameter "?1"`,
+		},
+		{
+			desc:      "unterminated literal",
+			statement: "SELECT * FROM t WHERE owner = {{ .claims.google.email }} AND a = 'x",
+			want:      "unable to parse statement: unterminated string literal",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tools.BindPositionalClaims(tc.statement, nil, sqlparse.SQLite)
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: got %v, want %s", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cloudloggingquery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudlogging"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	logging "google.golang.org/api/logging/v2"
+)
+
+const kind string = "cloud-logging-query"
+
+// maxPageSize is the largest page of entries the API returns.
+const maxPageSize = 1000
+
+// errLimitReached stops the pagination once the limit of entries is reached.
+var errLimitReached = errors.New("limit reached")
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, OrderBy: "timestamp desc", Limit: 50}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name          string           `yaml:"name" validate:"required"`
+	Kind          string           `yaml:"kind" validate:"required"`
+	Source        string           `yaml:"source" validate:"required"`
+	Description   string           `yaml:"description" validate:"required"`
+	Filter        string           `yaml:"filter"`
+	ResourceNames []string         `yaml:"resourceNames"`
+	OrderBy       string           `yaml:"orderBy"`
+	Limit         int64            `yaml:"limit"`
+	AuthRequired  []string         `yaml:"authRequired"`
+	Parameters    tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*cloudlogging.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, cloudlogging.SourceKind)
+	}
+
+	if cfg.Limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive, got %d", cfg.Limit)
+	}
+	filterTmpl, err := template.New("filter").Funcs(template.FuncMap{"json": toJSON}).Parse(cfg.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse filter: %w", err)
+	}
+	resourceNames := cfg.ResourceNames
+	if len(resourceNames) == 0 {
+		resourceNames = []string{"projects/" + s.Project}
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:          cfg.Name,
+		Kind:          kind,
+		Parameters:    cfg.Parameters,
+		AuthRequired:  cfg.AuthRequired,
+		Source:        s,
+		Filter:        filterTmpl,
+		ResourceNames: resourceNames,
+		OrderBy:       cfg.OrderBy,
+		Limit:         cfg.Limit,
+		manifest:      tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:   mcpManifest,
+	}
+	return t, nil
+}
+
+// toJSON encodes a parameter as a JSON value, which is also a valid string or
+// number of the Logging query language.
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal param to JSON: %w", err)
+	}
+	return string(b), nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source        *cloudlogging.Source
+	Filter        *template.Template
+	ResourceNames []string
+	OrderBy       string
+	Limit         int64
+	manifest      tools.Manifest
+	mcpManifest   tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	var filter bytes.Buffer
+	if err := t.Filter.Execute(&filter, params.AsMap()); err != nil {
+		return nil, fmt.Errorf("error replacing filter parameters: %s", err)
+	}
+
+	req := &logging.ListLogEntriesRequest{
+		ResourceNames: t.ResourceNames,
+		Filter:        filter.String(),
+		OrderBy:       t.OrderBy,
+		PageSize:      min(t.Limit, maxPageSize),
+	}
+	entries := []*logging.LogEntry{}
+	err := t.Source.LoggingService().Entries.List(req).Pages(ctx, func(resp *logging.ListLogEntriesResponse) error {
+		for _, e := range resp.Entries {
+			entries = append(entries, e)
+			if int64(len(entries)) == t.Limit {
+				return errLimitReached
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return nil, fmt.Errorf("unable to list log entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudloggingquery_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudlogging"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/cloudlogging/cloudloggingquery"
+	logging "google.golang.org/api/logging/v2"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlCloudLoggingQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: cloud-logging-query
+					source: my-logging-source
+					description: some description
+					filter: severity>=ERROR AND resource.labels.service_name={{json .service}}
+					parameters:
+						- name: service
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": cloudloggingquery.Config{
+					Name:         "example_tool",
+					Kind:         "cloud-logging-query",
+					Source:       "my-logging-source",
+					Description:  "some description",
+					Filter:       "severity>=ERROR AND resource.labels.service_name={{json .service}}",
+					OrderBy:      "timestamp desc",
+					Limit:        50,
+					AuthRequired: []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("service", "some description"),
+					},
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			tools:
+				example_tool:
+					kind: cloud-logging-query
+					source: my-logging-source
+					description: some description
+					filter: severity>=WARNING
+					resourceNames:
+						- projects/my-project
+						- folders/1234
+					orderBy: timestamp asc
+					limit: 10
+			`,
+			want: server.ToolConfigs{
+				"example_tool": cloudloggingquery.Config{
+					Name:          "example_tool",
+					Kind:          "cloud-logging-query",
+					Source:        "my-logging-source",
+					Description:   "some description",
+					Filter:        "severity>=WARNING",
+					ResourceNames: []string{"projects/my-project", "folders/1234"},
+					OrderBy:       "timestamp asc",
+					Limit:         10,
+					AuthRequired:  []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidLimit(t *testing.T) {
+	cfg := cloudloggingquery.Config{
+		Name:        "example_tool",
+		Kind:        "cloud-logging-query",
+		Source:      "my-logging-source",
+		Description: "some description",
+		Limit:       0,
+	}
+	srcs := map[string]sources.Source{"my-logging-source": &cloudlogging.Source{Project: "my-project"}}
+	_, err := cfg.Initialize(srcs)
+	if err == nil {
+		t.Fatalf("expect initialization to fail")
+	}
+	if want := "limit must be positive, got 0"; err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err, want)
+	}
+}
+
+func TestInvokeCloudLoggingQuery(t *testing.T) {
+	var reqs []logging.ListLogEntriesRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req logging.ListLogEntriesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		reqs = append(reqs, req)
+		w.Header().Set("Content-Type", "application/json")
+		if req.PageToken == "" {
+			_, _ = w.Write([]byte(`{"entries":[{"insertId":"1","textPayload":"a"},{"insertId":"2","textPayload":"b"}],"nextPageToken":"next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"entries":[{"insertId":"3","textPayload":"c"},{"insertId":"4","textPayload":"d"}],"nextPageToken":"last"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	service, err := logging.NewService(ctx, option.WithEndpoint(ts.URL+"/"), option.WithHTTPClient(ts.Client()))
+	if err != nil {
+		t.Fatalf("unable to create service: %s", err)
+	}
+	src := &cloudlogging.Source{
+		Name:    "my-logging-source",
+		Kind:    cloudlogging.SourceKind,
+		Project: "my-project",
+		Service: service,
+	}
+
+	cfg := cloudloggingquery.Config{
+		Name:        "example_tool",
+		Kind:        "cloud-logging-query",
+		Source:      "my-logging-source",
+		Description: "some description",
+		Filter:      `severity>=ERROR AND textPayload:{{json .text}}`,
+		OrderBy:     "timestamp desc",
+		Limit:       3,
+		Parameters:  tools.Parameters{tools.NewStringParameter("text", "some description")},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-logging-source": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"text": `say "hi"`}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+
+	if len(reqs) != 2 {
+		t.Fatalf("incorrect number of requests: got %d, want 2", len(reqs))
+	}
+	wantReq := logging.ListLogEntriesRequest{
+		ResourceNames: []string{"projects/my-project"},
+		Filter:        `severity>=ERROR AND textPayload:"say \"hi\""`,
+		OrderBy:       "timestamp desc",
+		PageSize:      3,
+	}
+	if diff := cmp.Diff(wantReq, reqs[0]); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("unable to marshal result: %s", err)
+	}
+	want := `[{"insertId":"1","textPayload":"a"},{"insertId":"2","textPayload":"b"},{"insertId":"3","textPayload":"c"}]`
+	if string(b) != want {
+		t.Fatalf("incorrect result: got %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cloudmonitoringquery
+
+import (
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudmonitoring"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/prometheus/prometheuscommon"
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+const kind string = "cloud-monitoring-query"
+
+const (
+	languagePromQL = "promql"
+	languageMQL    = "mql"
+)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, Language: languagePromQL}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string           `yaml:"name" validate:"required"`
+	Kind         string           `yaml:"kind" validate:"required"`
+	Source       string           `yaml:"source" validate:"required"`
+	Description  string           `yaml:"description" validate:"required"`
+	Query        string           `yaml:"query" validate:"required"`
+	Language     string           `yaml:"language"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*cloudmonitoring.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, cloudmonitoring.SourceKind)
+	}
+
+	if cfg.Language != languagePromQL && cfg.Language != languageMQL {
+		return nil, fmt.Errorf("invalid language %q: must be %q or %q", cfg.Language, languagePromQL, languageMQL)
+	}
+	queryTmpl, err := prometheuscommon.ParseTemplate(cfg.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Query:        queryTmpl,
+		Language:     cfg.Language,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *cloudmonitoring.Source
+	Query       *template.Template
+	Language    string
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	query, err := prometheuscommon.RenderQuery(t.Query, params.AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Language == languageMQL {
+		return t.queryMQL(ctx, query)
+	}
+	v, warnings, err := t.Source.PrometheusAPI().Query(ctx, query, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	return prometheuscommon.Result(v, warnings), nil
+}
+
+// queryMQL runs an MQL query, and returns the descriptor of its time series
+// and the data of all the pages of the time series.
+func (t Tool) queryMQL(ctx context.Context, query string) (any, error) {
+	var descriptor *monitoring.TimeSeriesDescriptor
+	data := []*monitoring.TimeSeriesData{}
+	var partialErrors []*monitoring.Status
+
+	req := &monitoring.QueryTimeSeriesRequest{Query: query}
+	call := t.Source.MonitoringService().Projects.TimeSeries.Query("projects/"+t.Source.Project, req)
+	err := call.Pages(ctx, func(resp *monitoring.QueryTimeSeriesResponse) error {
+		if descriptor == nil {
+			descriptor = resp.TimeSeriesDescriptor
+		}
+		data = append(data, resp.TimeSeriesData...)
+		partialErrors = append(partialErrors, resp.PartialErrors...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+
+	result := map[string]any{
+		"timeSeriesDescriptor": descriptor,
+		"timeSeriesData":       data,
+	}
+	if len(partialErrors) > 0 {
+		result["partialErrors"] = partialErrors
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
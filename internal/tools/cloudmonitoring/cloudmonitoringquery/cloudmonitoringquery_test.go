@@ -0,0 +1,241 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudmonitoringquery_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudmonitoring"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/cloudmonitoring/cloudmonitoringquery"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	monitoring "google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlCloudMonitoringQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: cloud-monitoring-query
+					source: my-monitoring-source
+					description: some description
+					query: |
+						sum(rate(run_googleapis_com:request_count{service_name={{json .service}}}[5m]))
+					parameters:
+						- name: service
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": cloudmonitoringquery.Config{
+					Name:         "example_tool",
+					Kind:         "cloud-monitoring-query",
+					Source:       "my-monitoring-source",
+					Description:  "some description",
+					Query:        "sum(rate(run_googleapis_com:request_count{service_name={{json .service}}}[5m]))\n",
+					Language:     "promql",
+					AuthRequired: []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("service", "some description"),
+					},
+				},
+			},
+		},
+		{
+			desc: "mql example",
+			in: `
+			tools:
+				example_tool:
+					kind: cloud-monitoring-query
+					source: my-monitoring-source
+					description: some description
+					language: mql
+					query: fetch gce_instance | metric 'compute.googleapis.com/instance/cpu/utilization' | within 1h
+			`,
+			want: server.ToolConfigs{
+				"example_tool": cloudmonitoringquery.Config{
+					Name:         "example_tool",
+					Kind:         "cloud-monitoring-query",
+					Source:       "my-monitoring-source",
+					Description:  "some description",
+					Query:        "fetch gce_instance | metric 'compute.googleapis.com/instance/cpu/utilization' | within 1h",
+					Language:     "mql",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidLanguage(t *testing.T) {
+	cfg := cloudmonitoringquery.Config{
+		Name:        "example_tool",
+		Kind:        "cloud-monitoring-query",
+		Source:      "my-monitoring-source",
+		Description: "some description",
+		Query:       "up",
+		Language:    "sql",
+	}
+	srcs := map[string]sources.Source{"my-monitoring-source": &cloudmonitoring.Source{Project: "my-project"}}
+	_, err := cfg.Initialize(srcs)
+	if err == nil {
+		t.Fatalf("expect initialization to fail")
+	}
+	if want := `invalid language "sql": must be "promql" or "mql"`; err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err, want)
+	}
+}
+
+func TestInvokeCloudMonitoringQuery(t *testing.T) {
+	var path, query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v3/projects/my-project/timeSeries:query" {
+			var req monitoring.QueryTimeSeriesRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("unable to decode request: %s", err)
+			}
+			query = req.Query
+			_, _ = w.Write([]byte(`{"timeSeriesDescriptor":{"labelDescriptors":[{"key":"resource.zone"}]},"timeSeriesData":[{"labelValues":[{"stringValue":"us-central1-a"}]}]}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse form: %s", err)
+		}
+		query = r.Form.Get("query")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1735689600,"3"]}]}}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	service, err := monitoring.NewService(ctx, option.WithEndpoint(ts.URL+"/"), option.WithHTTPClient(ts.Client()))
+	if err != nil {
+		t.Fatalf("unable to create service: %s", err)
+	}
+	promClient, err := api.NewClient(api.Config{Address: ts.URL + "/prometheus"})
+	if err != nil {
+		t.Fatalf("unable to create prometheus client: %s", err)
+	}
+	src := &cloudmonitoring.Source{
+		Name:    "my-monitoring-source",
+		Kind:    cloudmonitoring.SourceKind,
+		Project: "my-project",
+		Service: service,
+		API:     promv1.NewAPI(promClient),
+	}
+
+	tcs := []struct {
+		desc      string
+		language  string
+		query     string
+		wantPath  string
+		wantQuery string
+		want      string
+	}{
+		{
+			desc:      "promql",
+			language:  "promql",
+			query:     `count(up{job={{json .name}}})`,
+			wantPath:  "/prometheus/api/v1/query",
+			wantQuery: `count(up{job="api"})`,
+			want:      `{"result":[{"metric":{},"value":[1735689600,"3"]}],"resultType":"vector"}`,
+		},
+		{
+			desc:      "mql",
+			language:  "mql",
+			query:     `fetch gce_instance | filter resource.instance_id == {{json .name}}`,
+			wantPath:  "/v3/projects/my-project/timeSeries:query",
+			wantQuery: `fetch gce_instance | filter resource.instance_id == "api"`,
+			want:      `{"timeSeriesData":[{"labelValues":[{"stringValue":"us-central1-a"}]}],"timeSeriesDescriptor":{"labelDescriptors":[{"key":"resource.zone"}]}}`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := cloudmonitoringquery.Config{
+				Name:        "example_tool",
+				Kind:        "cloud-monitoring-query",
+				Source:      "my-monitoring-source",
+				Description: "some description",
+				Query:       tc.query,
+				Language:    tc.language,
+				Parameters:  tools.Parameters{tools.NewStringParameter("name", "some description")},
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-monitoring-source": src})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			params, err := tool.ParseParams(map[string]any{"name": "api"}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(ctx, params)
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+
+			if path != tc.wantPath {
+				t.Fatalf("incorrect path: got %q, want %q", path, tc.wantPath)
+			}
+			if query != tc.wantQuery {
+				t.Fatalf("incorrect query: got %q, want %q", query, tc.wantQuery)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("unable to marshal result: %s", err)
+			}
+			if string(b) != tc.want {
+				t.Fatalf("incorrect result: got %s, want %s", b, tc.want)
+			}
+		})
+	}
+}
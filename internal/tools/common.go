@@ -16,7 +16,11 @@ package tools
 
 import (
 	"fmt"
+	"math/big"
 	"regexp"
+	"time"
+
+	"cloud.google.com/go/civil"
 )
 
 var validName = regexp.MustCompile(`^[a-zA-Z0-9_-]*$`)
@@ -68,6 +72,71 @@ func ConvertAnySliceToTyped(s []any, itemType string) (any, error) {
 			tempSlice[j] = b
 		}
 		typedSlice = tempSlice
+	case typeEnum:
+		tempSlice := make([]string, len(s))
+		for j, item := range s {
+			e, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected item at index %d to be enum, got %T", j, item)
+			}
+			tempSlice[j] = e
+		}
+		typedSlice = tempSlice
+	case typeDate:
+		tempSlice := make([]civil.Date, len(s))
+		for j, item := range s {
+			d, ok := item.(time.Time)
+			if !ok {
+				return nil, fmt.Errorf("expected item at index %d to be date, got %T", j, item)
+			}
+			tempSlice[j] = civil.DateOf(d)
+		}
+		typedSlice = tempSlice
+	case typeTimestamp:
+		tempSlice := make([]time.Time, len(s))
+		for j, item := range s {
+			t, ok := item.(time.Time)
+			if !ok {
+				return nil, fmt.Errorf("expected item at index %d to be timestamp, got %T", j, item)
+			}
+			tempSlice[j] = t
+		}
+		typedSlice = tempSlice
+	case typeDecimal:
+		tempSlice := make([]*big.Rat, len(s))
+		for j, item := range s {
+			r, err := ConvertAnyToTyped(item, typeDecimal)
+			if err != nil {
+				return nil, fmt.Errorf("expected item at index %d to be decimal: %w", j, err)
+			}
+			tempSlice[j] = r.(*big.Rat)
+		}
+		typedSlice = tempSlice
 	}
 	return typedSlice, nil
 }
+
+// ConvertAnyToTyped converts the parsed value of a parameter of the given type
+// to the Go type that client libraries bind natively, i.e. a civil.Date for a
+// date and a *big.Rat for a decimal. Other values are returned unchanged.
+func ConvertAnyToTyped(v any, typ string) (any, error) {
+	switch typ {
+	case typeDate:
+		d, ok := v.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("expected date, got %T", v)
+		}
+		return civil.DateOf(d), nil
+	case typeDecimal:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected decimal, got %T", v)
+		}
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a decimal number", s)
+		}
+		return r, nil
+	}
+	return v, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// expressions caches the parsed templates of computed parameters.
+var expressions sync.Map
+
+// expressionFuncs are the functions available to the expressions of computed
+// parameters, mostly for date arithmetic, e.g. {{ now | truncate "24h" }}.
+var expressionFuncs = template.FuncMap{
+	"now": func() templateTime {
+		return templateTime{time.Now().UTC()}
+	},
+	"truncate": func(d string, v any) (templateTime, error) {
+		t, dur, err := timeAndDuration(v, d)
+		if err != nil {
+			return templateTime{}, err
+		}
+		return templateTime{t.Truncate(dur)}, nil
+	},
+	"add": func(d string, v any) (templateTime, error) {
+		t, dur, err := timeAndDuration(v, d)
+		if err != nil {
+			return templateTime{}, err
+		}
+		return templateTime{t.Add(dur)}, nil
+	},
+	"date": func(v any) (string, error) {
+		t, err := toTime(v)
+		if err != nil {
+			return "", err
+		}
+		return t.Format(time.DateOnly), nil
+	},
+	"format": func(layout string, v any) (string, error) {
+		t, err := toTime(v)
+		if err != nil {
+			return "", err
+		}
+		return t.Format(layout), nil
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// templateTime is a time rendered in RFC 3339 format, which is the format of
+// timestamp parameters.
+type templateTime struct {
+	time.Time
+}
+
+func (t templateTime) String() string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// toTime converts the value of a time function, a timestamp parameter or an
+// RFC 3339 string to a time.
+func toTime(v any) (time.Time, error) {
+	switch v := v.(type) {
+	case templateTime:
+		return v.Time, nil
+	case time.Time:
+		return v, nil
+	case string:
+		return time.Parse(time.RFC3339Nano, v)
+	}
+	return time.Time{}, fmt.Errorf("%v is not a time", v)
+}
+
+func timeAndDuration(v any, d string) (time.Time, time.Duration, error) {
+	t, err := toTime(v)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	dur, err := time.ParseDuration(d)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	return t, dur, nil
+}
+
+// parseExpression parses the expression of a computed parameter.
+func parseExpression(expr string) (*template.Template, error) {
+	if t, ok := expressions.Load(expr); ok {
+		return t.(*template.Template), nil
+	}
+	t, err := template.New("expression").Funcs(expressionFuncs).Option("missingkey=error").Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", expr, err)
+	}
+	expressions.Store(expr, t)
+	return t, nil
+}
+
+// validateExpression returns an error if the expression of a computed
+// parameter is invalid. A computed parameter is always set by the server, so
+// it can't also be an authenticated parameter.
+func validateExpression(p Parameter) error {
+	if p.GetExpression() == "" {
+		return nil
+	}
+	if len(p.GetAuthServices()) != 0 {
+		return fmt.Errorf("computed parameter %q should not have auth services", p.GetName())
+	}
+	_, err := parseExpression(p.GetExpression())
+	return err
+}
+
+// computeParam evaluates the expression of a computed parameter with the
+// values of the other parameters, and parses the result. Results that aren't
+// valid for a parameter that expects a string are parsed as JSON, e.g. for an
+// integer or an array.
+func computeParam(p Parameter, values map[string]any) (any, error) {
+	t, err := parseExpression(p.GetExpression())
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := t.Execute(&out, values); err != nil {
+		return nil, err
+	}
+	v, err := p.Parse(out.String())
+	var typeErr *ParseTypeError
+	if !errors.As(err, &typeErr) {
+		return v, err
+	}
+	dec := json.NewDecoder(&out)
+	dec.UseNumber()
+	var j any
+	if err := dec.Decode(&j); err != nil {
+		return nil, fmt.Errorf("%q is not a valid %s", out.String(), p.GetType())
+	}
+	return p.Parse(j)
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestComputedParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: first_name
+  type: string
+  description: first name
+- name: last_name
+  type: string
+  description: last name
+- name: full_name
+  type: string
+  description: full name
+  expression: '{{ .first_name }} {{ .last_name | upper }}'
+- name: start_of_day
+  type: timestamp
+  description: start of the current day
+  expression: '{{ now | truncate "24h" }}'
+- name: yesterday
+  type: date
+  description: the day before
+  expression: '{{ .start_of_day | add "-24h" | date }}'
+- name: name_length
+  type: integer
+  description: length of the full name
+  expression: '{{ len .full_name }}'
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+
+	// computed parameters aren't part of the manifests
+	var names []string
+	for _, m := range params.Manifest() {
+		names = append(names, m.Name)
+	}
+	if diff := cmp.Diff([]string{"first_name", "last_name"}, names); diff != "" {
+		t.Fatalf("incorrect manifest: diff %v", diff)
+	}
+	if got := len(params.McpManifest().Properties); got != 2 {
+		t.Fatalf("unexpected number of MCP properties: got %d, want 2", got)
+	}
+
+	// values given by the client for computed parameters are ignored
+	data := map[string]any{"first_name": "Ada", "last_name": "Lovelace", "full_name": "someone else"}
+	got, err := tools.ParseParams(params, data, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	startOfDay := time.Now().UTC().Truncate(24 * time.Hour)
+	want := map[string]any{
+		"first_name":   "Ada",
+		"last_name":    "Lovelace",
+		"full_name":    "Ada LOVELACE",
+		"start_of_day": startOfDay,
+		"yesterday":    startOfDay.Add(-24 * time.Hour),
+		"name_length":  12,
+	}
+	if diff := cmp.Diff(want, got.AsMap()); diff != "" {
+		t.Fatalf("incorrect params: diff %v", diff)
+	}
+}
+
+func TestFailComputedParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "invalid expression",
+			in: `
+- name: start
+  type: timestamp
+  description: start
+  expression: '{{ now | truncate "24h" '`,
+			err: `invalid expression "{{ now | truncate \"24h\" ": template: expression:1: unclosed action`,
+		},
+		{
+			name: "computed authenticated parameter",
+			in: `
+- name: email
+  type: string
+  description: email
+  expression: '{{ "a@example.com" }}'
+  authServices:
+    - name: my-google-auth
+      field: email`,
+			err: `computed parameter "email" should not have auth services`,
+		},
+		{
+			name: "computed array items",
+			in: `
+- name: ids
+  type: array
+  description: ids
+  items:
+    name: id
+    type: integer
+    description: id
+    expression: '{{ 1 }}'`,
+			err: `unable to parse as "array": nested items should not have expressions`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var params tools.Parameters
+			err := yaml.UnmarshalContext(ctx, []byte(tc.in), &params)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestFailComputeParameter(t *testing.T) {
+	params := tools.Parameters{
+		&tools.IntParameter{
+			CommonParameter: tools.CommonParameter{Name: "n", Type: "integer", Desc: "n", Expression: "{{ .missing }}"},
+		},
+	}
+	_, err := tools.ParseParams(params, map[string]any{}, nil)
+	want := `unable to compute value for "n": template: expression:1:3: executing "expression" at <.missing>: map has no entry for key "missing"`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+)
+
+// patterns caches the compiled patterns of parameters.
+var patterns sync.Map
+
+// compilePattern compiles a regular expression, reusing the result for
+// patterns that were already compiled.
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(expr, re)
+	return re, nil
+}
+
+// validateRange returns an error if the minimum is greater than the maximum.
+func validateRange(minimum, maximum *float64) error {
+	if minimum != nil && maximum != nil && *minimum > *maximum {
+		return fmt.Errorf("minimum %v is greater than maximum %v", *minimum, *maximum)
+	}
+	return nil
+}
+
+// checkRange returns an error if v is outside of the range.
+func checkRange(v float64, minimum, maximum *float64) error {
+	if minimum != nil && v < *minimum {
+		return fmt.Errorf("%v is less than the minimum of %v", v, *minimum)
+	}
+	if maximum != nil && v > *maximum {
+		return fmt.Errorf("%v is greater than the maximum of %v", v, *maximum)
+	}
+	return nil
+}
+
+// validateLength returns an error if a bound of the length of a string or an
+// array is negative, or if the minimum is greater than the maximum.
+func validateLength(minField string, minimum *int, maxField string, maximum *int) error {
+	if minimum != nil && *minimum < 0 {
+		return fmt.Errorf("%s must not be negative", minField)
+	}
+	if maximum != nil && *maximum < 0 {
+		return fmt.Errorf("%s must not be negative", maxField)
+	}
+	if minimum != nil && maximum != nil && *minimum > *maximum {
+		return fmt.Errorf("%s %d is greater than %s %d", minField, *minimum, maxField, *maximum)
+	}
+	return nil
+}
+
+// validate returns an error if the constraints of the parameter are invalid.
+func (p *StringParameter) validate() error {
+	if err := validateLength("minLength", p.MinLength, "maxLength", p.MaxLength); err != nil {
+		return err
+	}
+	if p.Pattern != "" {
+		if _, err := compilePattern(p.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", p.Pattern, err)
+		}
+	}
+	if p.Identifier != nil {
+		return p.Identifier.validate()
+	}
+	return nil
+}
+
+// checkConstraints returns an error if v doesn't satisfy the constraints of
+// the parameter. The length of a string is its number of characters.
+func (p *StringParameter) checkConstraints(v string) error {
+	n := utf8.RuneCountInString(v)
+	if p.MinLength != nil && n < *p.MinLength {
+		return fmt.Errorf("%q is shorter than the minimum length of %d", v, *p.MinLength)
+	}
+	if p.MaxLength != nil && n > *p.MaxLength {
+		return fmt.Errorf("%q is longer than the maximum length of %d", v, *p.MaxLength)
+	}
+	if p.Pattern != "" {
+		re, err := compilePattern(p.Pattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(v) {
+			return fmt.Errorf("%q does not match the pattern %q", v, p.Pattern)
+		}
+	}
+	return nil
+}
+
+// checkConstraints returns an error if the number of items doesn't satisfy
+// the constraints of the parameter.
+func (p *ArrayParameter) checkConstraints(n int) error {
+	if p.MinItems != nil && n < *p.MinItems {
+		return fmt.Errorf("%d items are fewer than the minimum of %d", n, *p.MinItems)
+	}
+	if p.MaxItems != nil && n > *p.MaxItems {
+		return fmt.Errorf("%d items are more than the maximum of %d", n, *p.MaxItems)
+	}
+	return nil
+}
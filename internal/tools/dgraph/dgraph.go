@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
@@ -31,6 +32,7 @@ func init() {
 	if !tools.Register(kind, newConfig) {
 		panic(fmt.Sprintf("tool kind %q already registered", kind))
 	}
+	tools.RegisterOwnTimeout(kind)
 }
 
 func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
@@ -60,6 +62,8 @@ type Config struct {
 	IsQuery      bool             `yaml:"isQuery"`
 	Timeout      string           `yaml:"timeout"`
 	Parameters   tools.Parameters `yaml:"parameters"`
+	ReadOnly     bool             `yaml:"readOnly"`
+	BestEffort   bool             `yaml:"bestEffort"`
 }
 
 // validate interface
@@ -82,6 +86,14 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	// mutations always run in a read-write transaction
+	if cfg.ReadOnly && !cfg.IsQuery {
+		return nil, fmt.Errorf("readOnly requires isQuery to be set")
+	}
+	if cfg.BestEffort && !cfg.ReadOnly {
+		return nil, fmt.Errorf("bestEffort requires readOnly to be set")
+	}
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
@@ -98,6 +110,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		DgraphClient: s.DgraphClient(),
 		IsQuery:      cfg.IsQuery,
 		Timeout:      cfg.Timeout,
+		QueryOptions: dgraph.QueryOptions{ReadOnly: cfg.ReadOnly, BestEffort: cfg.BestEffort},
 		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
 		mcpManifest:  mcpManifest,
 	}
@@ -106,6 +119,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 // validate interface
 var _ tools.Tool = Tool{}
+var _ tools.OwnTimeoutTool = Tool{}
 
 type Tool struct {
 	Name         string           `yaml:"name"`
@@ -115,15 +129,26 @@ type Tool struct {
 	DgraphClient *dgraph.DgraphClient
 	IsQuery      bool
 	Timeout      string
+	QueryOptions dgraph.QueryOptions
 	Statement    string
 	manifest     tools.Manifest
 	mcpManifest  tools.McpManifest
 }
 
+// OwnTimeout returns the timeout of the queries of the tool, if it is set as
+// a duration.
+func (t Tool) OwnTimeout() time.Duration {
+	d, err := time.ParseDuration(t.Timeout)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMapWithDollarPrefix()
 
-	resp, err := t.DgraphClient.ExecuteQuery(t.Statement, paramsMap, t.IsQuery, t.Timeout)
+	resp, err := t.DgraphClient.ExecuteQuery(t.Statement, paramsMap, t.IsQuery, t.Timeout, t.QueryOptions)
 	if err != nil {
 		return nil, err
 	}
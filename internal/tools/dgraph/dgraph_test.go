@@ -20,6 +20,8 @@ import (
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	dgraphsrc "github.com/googleapis/genai-toolbox/internal/sources/dgraph"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools/dgraph"
 )
@@ -60,6 +62,34 @@ func TestParseFromYamlDgraph(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "best effort query example",
+			in: `
+			tools:
+				example_tool:
+					kind: dgraph-dql
+					source: my-dgraph-instance
+					description: some tool description
+					isQuery: true
+					readOnly: true
+					bestEffort: true
+					statement: |
+						    query {q(func: has(email)) {email}}
+			`,
+			want: server.ToolConfigs{
+				"example_tool": dgraph.Config{
+					Name:         "example_tool",
+					Kind:         "dgraph-dql",
+					Source:       "my-dgraph-instance",
+					AuthRequired: []string{},
+					Description:  "some tool description",
+					IsQuery:      true,
+					ReadOnly:     true,
+					BestEffort:   true,
+					Statement:    "query {q(func: has(email)) {email}}\n",
+				},
+			},
+		},
 		{
 			desc: "basic mutation example",
 			in: `
@@ -100,3 +130,32 @@ func TestParseFromYamlDgraph(t *testing.T) {
 	}
 
 }
+
+func TestFailInitializeDgraph(t *testing.T) {
+	src := &dgraphsrc.Source{Name: "my-dgraph-instance", Kind: dgraphsrc.SourceKind}
+	tcs := []struct {
+		desc string
+		cfg  dgraph.Config
+		err  string
+	}{
+		{
+			desc: "read only mutation",
+			cfg:  dgraph.Config{ReadOnly: true},
+			err:  "readOnly requires isQuery to be set",
+		},
+		{
+			desc: "best effort without read only",
+			cfg:  dgraph.Config{IsQuery: true, BestEffort: true},
+			err:  "bestEffort requires readOnly to be set",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.cfg.Source = "my-dgraph-instance"
+			_, err := tc.cfg.Initialize(map[string]sources.Source{"my-dgraph-instance": src})
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dynamodbcommon contains the helpers shared by the DynamoDB tools.
+//
+// The parameters of the tools are converted to DynamoDB attribute values by
+// their type: strings are `S` values, integers and floats are `N` values,
+// booleans are `BOOL` values and arrays are `L` values.
+package dynamodbcommon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// AttributeValues converts parameters to attribute values, by the name of the
+// parameter.
+func AttributeValues(params tools.ParamValues) (map[string]types.AttributeValue, error) {
+	values := make(map[string]types.AttributeValue, len(params))
+	for _, p := range params {
+		v, err := attributevalue.Marshal(p.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert parameter %q: %w", p.Name, err)
+		}
+		values[p.Name] = v
+	}
+	return values, nil
+}
+
+// ExpressionValues converts parameters to the values of an expression, where
+// a parameter is referenced as `:name`.
+func ExpressionValues(params tools.ParamValues) (map[string]types.AttributeValue, error) {
+	values, err := AttributeValues(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	exprValues := make(map[string]types.AttributeValue, len(values))
+	for name, v := range values {
+		exprValues[":"+name] = v
+	}
+	return exprValues, nil
+}
+
+// Projection returns the projection expression of attributes. The attributes
+// are referenced by placeholders, so that they can be reserved words, which
+// are added to names.
+func Projection(attributes []string, names map[string]string) (*string, map[string]string) {
+	if len(attributes) == 0 {
+		return nil, names
+	}
+	merged := make(map[string]string, len(names)+len(attributes))
+	for k, v := range names {
+		merged[k] = v
+	}
+	expr := ""
+	for i, attr := range attributes {
+		placeholder := fmt.Sprintf("#proj%d", i)
+		merged[placeholder] = attr
+		if i > 0 {
+			expr += ", "
+		}
+		expr += placeholder
+	}
+	return aws.String(expr), merged
+}
+
+// DecodeItems converts items to JSON values.
+func DecodeItems(items []map[string]types.AttributeValue) ([]any, error) {
+	out := make([]any, 0, len(items))
+	for _, item := range items {
+		var v map[string]any
+		if err := attributevalue.UnmarshalMap(item, &v); err != nil {
+			return nil, fmt.Errorf("unable to decode item: %w", err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// Page is a page of items read by a query or scan.
+type Page struct {
+	Items            []map[string]types.AttributeValue
+	LastEvaluatedKey map[string]types.AttributeValue
+	ConsumedCapacity *types.ConsumedCapacity
+}
+
+// ReadFunc reads the page of items that starts at a key, with at most limit
+// items. The key is nil for the first page, and limit is nil if the number of
+// items isn't limited.
+type ReadFunc func(ctx context.Context, startKey map[string]types.AttributeValue, limit *int32) (Page, error)
+
+// Limits are the limits of reading the pages of a query or scan. A limit is
+// disabled if it is zero.
+type Limits struct {
+	// Limit is the maximum number of items.
+	Limit int32
+	// MaxConsumedCapacity is the maximum number of capacity units that can be
+	// consumed. A page is only read if the capacity units consumed by the
+	// pages before it are less than the maximum, so the units consumed can
+	// exceed it by those of the last page.
+	MaxConsumedCapacity float64
+}
+
+// ReadPages reads the pages of a query or scan until there are no more pages
+// or a limit is reached. The result is truncated if there are more pages.
+func ReadPages(ctx context.Context, limits Limits, read ReadFunc) (map[string]any, error) {
+	var items []map[string]types.AttributeValue
+	var startKey map[string]types.AttributeValue
+	consumed := 0.0
+	for {
+		var limit *int32
+		if limits.Limit > 0 {
+			limit = aws.Int32(limits.Limit - int32(len(items)))
+		}
+		page, err := read(ctx, startKey, limit)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if page.ConsumedCapacity != nil {
+			consumed += aws.ToFloat64(page.ConsumedCapacity.CapacityUnits)
+		}
+		startKey = page.LastEvaluatedKey
+		if len(startKey) == 0 {
+			break
+		}
+		if limits.Limit > 0 && int32(len(items)) >= limits.Limit {
+			break
+		}
+		if limits.MaxConsumedCapacity > 0 && consumed >= limits.MaxConsumedCapacity {
+			break
+		}
+	}
+
+	decoded, err := DecodeItems(items)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"items":            decoded,
+		"consumedCapacity": consumed,
+		"truncated":        len(startKey) > 0,
+	}, nil
+}
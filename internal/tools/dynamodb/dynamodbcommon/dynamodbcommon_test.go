@@ -0,0 +1,156 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodbcommon_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/dynamodb/dynamodbcommon"
+)
+
+func TestExpressionValues(t *testing.T) {
+	params := tools.ParamValues{
+		{Name: "id", Value: "abc"},
+		{Name: "count", Value: 3},
+		{Name: "active", Value: true},
+		{Name: "tags", Value: []any{"a", "b"}},
+	}
+	got, err := dynamodbcommon.ExpressionValues(params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]types.AttributeValue{
+		":id":     &types.AttributeValueMemberS{Value: "abc"},
+		":count":  &types.AttributeValueMemberN{Value: "3"},
+		":active": &types.AttributeValueMemberBOOL{Value: true},
+		":tags": &types.AttributeValueMemberL{Value: []types.AttributeValue{
+			&types.AttributeValueMemberS{Value: "a"},
+			&types.AttributeValueMemberS{Value: "b"},
+		}},
+	}
+	opts := cmpopts.IgnoreUnexported(types.AttributeValueMemberS{}, types.AttributeValueMemberN{}, types.AttributeValueMemberBOOL{}, types.AttributeValueMemberL{})
+	if diff := cmp.Diff(want, got, opts); diff != "" {
+		t.Fatalf("incorrect values: diff %v", diff)
+	}
+
+	got, err = dynamodbcommon.ExpressionValues(nil)
+	if err != nil || got != nil {
+		t.Fatalf("unexpected values without parameters: %v, %v", got, err)
+	}
+}
+
+func TestProjection(t *testing.T) {
+	expr, names := dynamodbcommon.Projection([]string{"name", "status"}, map[string]string{"#s": "status"})
+	if got := aws.ToString(expr); got != "#proj0, #proj1" {
+		t.Fatalf("incorrect expression: %q", got)
+	}
+	want := map[string]string{"#s": "status", "#proj0": "name", "#proj1": "status"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Fatalf("incorrect names: diff %v", diff)
+	}
+
+	expr, names = dynamodbcommon.Projection(nil, nil)
+	if expr != nil || names != nil {
+		t.Fatalf("unexpected projection without attributes: %v, %v", expr, names)
+	}
+}
+
+// pages returns a ReadFunc that reads items numbered from 0 to n, in pages of
+// size items that consume 1 capacity unit each.
+func pages(n, size int, limits *[]int32) dynamodbcommon.ReadFunc {
+	return func(ctx context.Context, startKey map[string]types.AttributeValue, limit *int32) (dynamodbcommon.Page, error) {
+		start := 0
+		if startKey != nil {
+			start, _ = strconv.Atoi(startKey["id"].(*types.AttributeValueMemberN).Value)
+		}
+		if limit != nil {
+			*limits = append(*limits, *limit)
+		}
+		end := start + size
+		if limit != nil && start+int(*limit) < end {
+			end = start + int(*limit)
+		}
+		end = min(end, n)
+		var page dynamodbcommon.Page
+		for i := start; i < end; i++ {
+			page.Items = append(page.Items, map[string]types.AttributeValue{"id": &types.AttributeValueMemberN{Value: strconv.Itoa(i)}})
+		}
+		if end < n {
+			page.LastEvaluatedKey = map[string]types.AttributeValue{"id": &types.AttributeValueMemberN{Value: strconv.Itoa(end)}}
+		}
+		page.ConsumedCapacity = &types.ConsumedCapacity{CapacityUnits: aws.Float64(1)}
+		return page, nil
+	}
+}
+
+func TestReadPages(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		limits     dynamodbcommon.Limits
+		wantItems  int
+		wantUnits  float64
+		truncated  bool
+		wantLimits []int32
+	}{
+		{
+			desc:      "all pages",
+			wantItems: 10,
+			wantUnits: 3,
+		},
+		{
+			desc:       "limit",
+			limits:     dynamodbcommon.Limits{Limit: 5},
+			wantItems:  5,
+			wantUnits:  2,
+			truncated:  true,
+			wantLimits: []int32{5, 1},
+		},
+		{
+			desc:      "max consumed capacity",
+			limits:    dynamodbcommon.Limits{MaxConsumedCapacity: 1.5},
+			wantItems: 8,
+			wantUnits: 2,
+			truncated: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var limits []int32
+			got, err := dynamodbcommon.ReadPages(context.Background(), tc.limits, pages(10, 4, &limits))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if n := len(got["items"].([]any)); n != tc.wantItems {
+				t.Fatalf("incorrect number of items: got %d, want %d", n, tc.wantItems)
+			}
+			if got["consumedCapacity"] != tc.wantUnits {
+				t.Fatalf("incorrect consumed capacity: got %v, want %v", got["consumedCapacity"], tc.wantUnits)
+			}
+			if got["truncated"] != tc.truncated {
+				t.Fatalf("incorrect truncated: got %v, want %v", got["truncated"], tc.truncated)
+			}
+			if diff := cmp.Diff(tc.wantLimits, limits); diff != "" {
+				t.Fatalf("incorrect limits of pages: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dynamodbgetitem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbapi "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/dynamodb"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/dynamodb/dynamodbcommon"
+)
+
+const kind string = "dynamodb-get-item"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name           string           `yaml:"name" validate:"required"`
+	Kind           string           `yaml:"kind" validate:"required"`
+	Source         string           `yaml:"source" validate:"required"`
+	Description    string           `yaml:"description" validate:"required"`
+	Table          string           `yaml:"table" validate:"required"`
+	Projection     []string         `yaml:"projection"`
+	ConsistentRead bool             `yaml:"consistentRead"`
+	AuthRequired   []string         `yaml:"authRequired"`
+	Parameters     tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*dynamodb.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, dynamodb.SourceKind)
+	}
+
+	// the parameters are the attributes of the key
+	if len(cfg.Parameters) == 0 {
+		return nil, fmt.Errorf("%q tool requires the attributes of the key as parameters", kind)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:           cfg.Name,
+		Kind:           kind,
+		Parameters:     cfg.Parameters,
+		AuthRequired:   cfg.AuthRequired,
+		Client:         s.DynamoDBClient(),
+		Table:          cfg.Table,
+		Projection:     cfg.Projection,
+		ConsistentRead: cfg.ConsistentRead,
+		manifest:       tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:    mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client         *dynamodbapi.Client
+	Table          string
+	Projection     []string
+	ConsistentRead bool
+	manifest       tools.Manifest
+	mcpManifest    tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	key, err := dynamodbcommon.AttributeValues(params)
+	if err != nil {
+		return nil, err
+	}
+	input := &dynamodbapi.GetItemInput{
+		TableName:      aws.String(t.Table),
+		Key:            key,
+		ConsistentRead: aws.Bool(t.ConsistentRead),
+	}
+	input.ProjectionExpression, input.ExpressionAttributeNames = dynamodbcommon.Projection(t.Projection, nil)
+
+	out, err := t.Client.GetItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get item: %w", err)
+	}
+	// there is no item with the key
+	if out.Item == nil {
+		return nil, nil
+	}
+	items, err := dynamodbcommon.DecodeItems([]map[string]types.AttributeValue{out.Item})
+	if err != nil {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodbgetitem_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/dynamodb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/dynamodb/dynamodbgetitem"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlDynamoDBGetItem(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: dynamodb-get-item
+					source: my-dynamodb-instance
+					description: some description
+					table: orders
+					projection:
+						- status
+						- total
+					consistentRead: true
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: customer_id
+						  type: string
+						  description: some description
+						- name: order_id
+						  type: integer
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": dynamodbgetitem.Config{
+					Name:           "example_tool",
+					Kind:           "dynamodb-get-item",
+					Source:         "my-dynamodb-instance",
+					Description:    "some description",
+					Table:          "orders",
+					Projection:     []string{"status", "total"},
+					ConsistentRead: true,
+					AuthRequired:   []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("customer_id", "some description"),
+						tools.NewIntParameter("order_id", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeDynamoDBGetItem(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "my-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "my-secret")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	var got map[string]any
+	item := `{"Item":{"customer_id":{"S":"c1"},"order_id":{"N":"7"},"status":{"S":"shipped"}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		if r.Header.Get("X-Amz-Target") == "DynamoDB_20120810.ListTables" {
+			_, _ = w.Write([]byte(`{"TableNames":[]}`))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got["Key"].(map[string]any)["order_id"].(map[string]any)["N"] == "8" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(item))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	src, err := dynamodb.Config{Name: "my-dynamodb-instance", Kind: dynamodb.SourceKind, Region: "us-east-1", Endpoint: ts.URL}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	tool, err := dynamodbgetitem.Config{
+		Name:        "example_tool",
+		Kind:        "dynamodb-get-item",
+		Source:      "my-dynamodb-instance",
+		Description: "some description",
+		Table:       "orders",
+		Projection:  []string{"status"},
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("customer_id", "some description"),
+			tools.NewIntParameter("order_id", "some description"),
+		},
+	}.Initialize(map[string]sources.Source{"my-dynamodb-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"customer_id": "c1", "order_id": 7}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	result, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	want := map[string]any{"customer_id": "c1", "order_id": float64(7), "status": "shipped"}
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	wantRequest := map[string]any{
+		"TableName":                "orders",
+		"ConsistentRead":           false,
+		"Key":                      map[string]any{"customer_id": map[string]any{"S": "c1"}, "order_id": map[string]any{"N": "7"}},
+		"ProjectionExpression":     "#proj0",
+		"ExpressionAttributeNames": map[string]any{"#proj0": "status"},
+	}
+	if diff := cmp.Diff(wantRequest, got); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+
+	// there is no item with the key
+	params, err = tool.ParseParams(map[string]any{"customer_id": "c1", "order_id": 8}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	result, err = tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	if result != nil {
+		t.Fatalf("unexpected result for missing item: %v", result)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamodbquery_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/dynamodb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/dynamodb/dynamodbquery"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlDynamoDBQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: dynamodb-query
+					source: my-dynamodb-instance
+					description: some description
+					table: orders
+					index: by-status
+					keyConditionExpression: customer_id = :customer_id
+					filterExpression: "#s = :status"
+					expressionAttributeNames:
+						"#s": status
+					projection:
+						- order_id
+					descending: true
+					limit: 10
+					maxConsumedCapacity: 5
+					parameters:
+						- name: customer_id
+						  type: string
+						  description: some description
+						- name: status
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": dynamodbquery.Config{
+					Name:                     "example_tool",
+					Kind:                     "dynamodb-query",
+					Source:                   "my-dynamodb-instance",
+					Description:              "some description",
+					Table:                    "orders",
+					Index:                    "by-status",
+					KeyConditionExpression:   "customer_id = :customer_id",
+					FilterExpression:         "#s = :status",
+					ExpressionAttributeNames: map[string]string{"#s": "status"},
+					Projection:               []string{"order_id"},
+					Descending:               true,
+					Limit:                    10,
+					MaxConsumedCapacity:      5,
+					AuthRequired:             []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("customer_id", "some description"),
+						tools.NewStringParameter("status", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeDynamoDBQuery(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "my-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "my-secret")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+
+	var requests []map[string]any
+	pages := []string{
+		`{"Items":[{"order_id":{"N":"1"}}],"LastEvaluatedKey":{"order_id":{"N":"1"}},"ConsumedCapacity":{"CapacityUnits":0.5}}`,
+		`{"Items":[{"order_id":{"N":"2"}}],"ConsumedCapacity":{"CapacityUnits":0.5}}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		if r.Header.Get("X-Amz-Target") == "DynamoDB_20120810.ListTables" {
+			_, _ = w.Write([]byte(`{"TableNames":[]}`))
+			return
+		}
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		_, _ = w.Write([]byte(pages[len(requests)-1]))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	src, err := dynamodb.Config{Name: "my-dynamodb-instance", Kind: dynamodb.SourceKind, Region: "us-east-1", Endpoint: ts.URL}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	tool, err := dynamodbquery.Config{
+		Name:                   "example_tool",
+		Kind:                   "dynamodb-query",
+		Source:                 "my-dynamodb-instance",
+		Description:            "some description",
+		Table:                  "orders",
+		KeyConditionExpression: "customer_id = :customer_id",
+		Descending:             true,
+		Limit:                  5,
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("customer_id", "some description"),
+		},
+	}.Initialize(map[string]sources.Source{"my-dynamodb-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"customer_id": "c1"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	result, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	want := map[string]any{
+		"items":            []any{map[string]any{"order_id": float64(1)}, map[string]any{"order_id": float64(2)}},
+		"consumedCapacity": float64(1),
+		"truncated":        false,
+	}
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	wantRequests := []map[string]any{
+		{
+			"TableName":                 "orders",
+			"KeyConditionExpression":    "customer_id = :customer_id",
+			"ExpressionAttributeValues": map[string]any{":customer_id": map[string]any{"S": "c1"}},
+			"ScanIndexForward":          false,
+			"ConsistentRead":            false,
+			"ReturnConsumedCapacity":    "TOTAL",
+			"Limit":                     float64(5),
+		},
+		{
+			"TableName":                 "orders",
+			"KeyConditionExpression":    "customer_id = :customer_id",
+			"ExpressionAttributeValues": map[string]any{":customer_id": map[string]any{"S": "c1"}},
+			"ScanIndexForward":          false,
+			"ConsistentRead":            false,
+			"ReturnConsumedCapacity":    "TOTAL",
+			"Limit":                     float64(4),
+			"ExclusiveStartKey":         map[string]any{"order_id": map[string]any{"N": "1"}},
+		},
+	}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Fatalf("incorrect requests: diff %v", diff)
+	}
+}
+
+func TestFailInitializeDynamoDBQuery(t *testing.T) {
+	src := &dynamodb.Source{Name: "my-dynamodb-instance", Kind: dynamodb.SourceKind}
+	tcs := []struct {
+		desc string
+		cfg  dynamodbquery.Config
+		err  string
+	}{
+		{
+			desc: "negative limit",
+			cfg:  dynamodbquery.Config{Limit: -1},
+			err:  "limit must not be negative, got -1",
+		},
+		{
+			desc: "negative max consumed capacity",
+			cfg:  dynamodbquery.Config{MaxConsumedCapacity: -0.5},
+			err:  "maxConsumedCapacity must not be negative, got -0.5",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc.cfg.Source = "my-dynamodb-instance"
+			_, err := tc.cfg.Initialize(map[string]sources.Source{"my-dynamodb-instance": src})
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dynamodbscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamodbapi "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/dynamodb"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/dynamodb/dynamodbcommon"
+)
+
+const kind string = "dynamodb-scan"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name                     string            `yaml:"name" validate:"required"`
+	Kind                     string            `yaml:"kind" validate:"required"`
+	Source                   string            `yaml:"source" validate:"required"`
+	Description              string            `yaml:"description" validate:"required"`
+	Table                    string            `yaml:"table" validate:"required"`
+	Index                    string            `yaml:"index"`
+	FilterExpression         string            `yaml:"filterExpression"`
+	ExpressionAttributeNames map[string]string `yaml:"expressionAttributeNames"`
+	Projection               []string          `yaml:"projection"`
+	ConsistentRead           bool              `yaml:"consistentRead"`
+	Limit                    int32             `yaml:"limit"`
+	MaxConsumedCapacity      float64           `yaml:"maxConsumedCapacity"`
+	AuthRequired             []string          `yaml:"authRequired"`
+	Parameters               tools.Parameters  `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*dynamodb.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, dynamodb.SourceKind)
+	}
+
+	if cfg.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative, got %d", cfg.Limit)
+	}
+	if cfg.MaxConsumedCapacity < 0 {
+		return nil, fmt.Errorf("maxConsumedCapacity must not be negative, got %v", cfg.MaxConsumedCapacity)
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:                     cfg.Name,
+		Kind:                     kind,
+		Parameters:               cfg.Parameters,
+		AuthRequired:             cfg.AuthRequired,
+		Client:                   s.DynamoDBClient(),
+		Table:                    cfg.Table,
+		Index:                    cfg.Index,
+		FilterExpression:         cfg.FilterExpression,
+		ExpressionAttributeNames: cfg.ExpressionAttributeNames,
+		Projection:               cfg.Projection,
+		ConsistentRead:           cfg.ConsistentRead,
+		Limits:                   dynamodbcommon.Limits{Limit: cfg.Limit, MaxConsumedCapacity: cfg.MaxConsumedCapacity},
+		manifest:                 tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:              mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client                   *dynamodbapi.Client
+	Table                    string
+	Index                    string
+	FilterExpression         string
+	ExpressionAttributeNames map[string]string
+	Projection               []string
+	ConsistentRead           bool
+	Limits                   dynamodbcommon.Limits
+	manifest                 tools.Manifest
+	mcpManifest              tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	values, err := dynamodbcommon.ExpressionValues(params)
+	if err != nil {
+		return nil, err
+	}
+	input := &dynamodbapi.ScanInput{
+		TableName:                 aws.String(t.Table),
+		ExpressionAttributeValues: values,
+		ConsistentRead:            aws.Bool(t.ConsistentRead),
+		ReturnConsumedCapacity:    types.ReturnConsumedCapacityTotal,
+	}
+	if t.Index != "" {
+		input.IndexName = aws.String(t.Index)
+	}
+	if t.FilterExpression != "" {
+		input.FilterExpression = aws.String(t.FilterExpression)
+	}
+	input.ProjectionExpression, input.ExpressionAttributeNames = dynamodbcommon.Projection(t.Projection, t.ExpressionAttributeNames)
+
+	return dynamodbcommon.ReadPages(ctx, t.Limits, func(ctx context.Context, startKey map[string]types.AttributeValue, limit *int32) (dynamodbcommon.Page, error) {
+		input.ExclusiveStartKey = startKey
+		input.Limit = limit
+		out, err := t.Client.Scan(ctx, input)
+		if err != nil {
+			return dynamodbcommon.Page{}, fmt.Errorf("unable to run scan: %w", err)
+		}
+		return dynamodbcommon.Page{Items: out.Items, LastEvaluatedKey: out.LastEvaluatedKey, ConsumedCapacity: out.ConsumedCapacity}, nil
+	})
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
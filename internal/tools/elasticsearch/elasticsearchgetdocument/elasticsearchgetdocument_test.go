@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchgetdocument_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/elasticsearch"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/elasticsearch/elasticsearchgetdocument"
+)
+
+func TestParseFromYamlElasticsearchGetDocument(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: elasticsearch-get-document
+					source: my-es-instance
+					description: some description
+					index: products
+					sourceFields:
+						- name
+			`,
+			want: server.ToolConfigs{
+				"example_tool": elasticsearchgetdocument.Config{
+					Name:         "example_tool",
+					Kind:         "elasticsearch-get-document",
+					Source:       "my-es-instance",
+					Description:  "some description",
+					Index:        "products",
+					SourceFields: []string{"name"},
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURI = r.URL.RequestURI()
+		switch r.URL.Path {
+		case "/products/_doc/a/b":
+			_, _ = w.Write([]byte(`{"_index": "products", "_id": "a/b", "found": true, "_source": {"name": "chair"}}`))
+		case "/missing/_doc/1":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "index_not_found_exception", "reason": "no such index [missing]"}, "status": 404}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"_index": "products", "_id": "2", "found": false}`))
+		}
+	}))
+	defer srv.Close()
+
+	src := &elasticsearch.Source{Name: "my-es-instance", Kind: elasticsearch.SourceKind, Addresses: []string{srv.URL}, Client: srv.Client()}
+	newTool := func(index string) elasticsearchgetdocument.Tool {
+		cfg := elasticsearchgetdocument.Config{
+			Name:         "example_tool",
+			Kind:         "elasticsearch-get-document",
+			Source:       "my-es-instance",
+			Description:  "some description",
+			Index:        index,
+			SourceFields: []string{"name", "price"},
+		}
+		tool, err := cfg.Initialize(map[string]sources.Source{"my-es-instance": src})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return tool.(elasticsearchgetdocument.Tool)
+	}
+
+	tcs := []struct {
+		desc    string
+		index   string
+		id      string
+		want    any
+		wantErr string
+	}{
+		{
+			desc:  "found",
+			index: "products",
+			id:    "a/b",
+			want:  map[string]any{"_id": "a/b", "_index": "products", "name": "chair"},
+		},
+		{
+			desc:    "missing document",
+			index:   "products",
+			id:      "2",
+			wantErr: `document "2" not found in index "products"`,
+		},
+		{
+			desc:    "missing index",
+			index:   "missing",
+			id:      "1",
+			wantErr: "unexpected status code: 404, index_not_found_exception: no such index [missing]",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tool := newTool(tc.index)
+			params, err := tool.ParseParams(map[string]any{"id": tc.id}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			res, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, res); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+			// the id is escaped, and only the source fields are fetched
+			if want := "/products/_doc/a%2Fb?_source_includes=name%2Cprice"; gotURI != want {
+				t.Fatalf("unexpected request URI: got %q, want %q", gotURI, want)
+			}
+		})
+	}
+}
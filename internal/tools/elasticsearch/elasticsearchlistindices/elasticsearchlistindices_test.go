@@ -0,0 +1,130 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchlistindices_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/elasticsearch"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/elasticsearch/elasticsearchlistindices"
+)
+
+func TestParseFromYamlElasticsearchListIndices(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: elasticsearch-list-indices
+					source: my-es-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": elasticsearchlistindices.Config{
+					Name:         "example_tool",
+					Kind:         "elasticsearch-list-indices",
+					Source:       "my-es-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(`[{"index": "logs-1", "health": "green", "status": "open", "docs.count": "5", "store.size": "10kb"}]`))
+	}))
+	defer srv.Close()
+
+	src := &elasticsearch.Source{Name: "my-es-instance", Kind: elasticsearch.SourceKind, Addresses: []string{srv.URL}, Client: srv.Client()}
+	cfg := elasticsearchlistindices.Config{
+		Name:        "example_tool",
+		Kind:        "elasticsearch-list-indices",
+		Source:      "my-es-instance",
+		Description: "some description",
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-es-instance": src})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		desc     string
+		in       map[string]any
+		wantPath string
+	}{
+		{desc: "all indices", in: map[string]any{}, wantPath: "/_cat/indices/*"},
+		{desc: "pattern", in: map[string]any{"pattern": "logs-*"}, wantPath: "/_cat/indices/logs-*"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(tc.in, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			res, err := tool.Invoke(context.Background(), params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			want := []any{map[string]any{"index": "logs-1", "health": "green", "status": "open", "docs.count": "5", "store.size": "10kb"}}
+			if diff := cmp.Diff(want, res); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+			if gotPath != tc.wantPath {
+				t.Fatalf("unexpected path: got %q, want %q", gotPath, tc.wantPath)
+			}
+			if want := "format=json&h=index%2Chealth%2Cstatus%2Cdocs.count%2Cstore.size&s=index"; gotQuery != want {
+				t.Fatalf("unexpected query: got %q, want %q", gotQuery, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package elasticsearchsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"text/template"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/elasticsearch"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "elasticsearch-search"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string           `yaml:"name" validate:"required"`
+	Kind         string           `yaml:"kind" validate:"required"`
+	Source       string           `yaml:"source" validate:"required"`
+	Description  string           `yaml:"description" validate:"required"`
+	Index        string           `yaml:"index" validate:"required"`
+	Query        string           `yaml:"query" validate:"required"`
+	SourceFields []string         `yaml:"sourceFields"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*elasticsearch.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, elasticsearch.SourceKind)
+	}
+
+	query, err := template.New("query").Funcs(template.FuncMap{"json": toJSON}).Parse(cfg.Query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse query of tool %q: %w", cfg.Name, err)
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AuthRequired: cfg.AuthRequired,
+		Index:        cfg.Index,
+		Query:        query,
+		SourceFields: cfg.SourceFields,
+		Source:       s,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// toJSON encodes a parameter so it can be inserted into the query.
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal param to JSON: %w", err)
+	}
+	return string(b), nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source       *elasticsearch.Source
+	Index        string
+	Query        *template.Template
+	SourceFields []string
+	manifest     tools.Manifest
+	mcpManifest  tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	var query bytes.Buffer
+	if err := t.Query.Execute(&query, params.AsMap()); err != nil {
+		return nil, fmt.Errorf("error replacing query parameters: %s", err)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(query.Bytes(), &body); err != nil {
+		return nil, fmt.Errorf("query is not a JSON object: %w", err)
+	}
+	if len(t.SourceFields) > 0 {
+		body["_source"] = t.SourceFields
+	}
+
+	res, err := t.Source.Do(ctx, http.MethodPost, "/"+url.PathEscape(t.Index)+"/_search", nil, body)
+	if err != nil {
+		return nil, err
+	}
+	resMap, _ := res.(map[string]any)
+	rows := hitRows(resMap)
+	// aggregations are returned with the hits, since they are usually what
+	// the query is for
+	if aggs, ok := resMap["aggregations"]; ok {
+		return map[string]any{"hits": rows, "aggregations": aggs}, nil
+	}
+	return rows, nil
+}
+
+// hitRows returns a row for each hit, made of its source fields and its _id
+// and _index.
+func hitRows(res map[string]any) []any {
+	hits, _ := res["hits"].(map[string]any)
+	list, _ := hits["hits"].([]any)
+	rows := make([]any, 0, len(list))
+	for _, h := range list {
+		hit, ok := h.(map[string]any)
+		if !ok {
+			continue
+		}
+		row := map[string]any{"_id": hit["_id"], "_index": hit["_index"]}
+		if src, ok := hit["_source"].(map[string]any); ok {
+			for k, v := range src {
+				row[k] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
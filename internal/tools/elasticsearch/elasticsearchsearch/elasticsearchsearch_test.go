@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchsearch_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/elasticsearch"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/elasticsearch/elasticsearchsearch"
+)
+
+func TestParseFromYamlElasticsearchSearch(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: elasticsearch-search
+					source: my-es-instance
+					description: some description
+					index: products
+					query: |
+						{"query": {"match": {"name": {{json .name}}}}}
+					sourceFields:
+						- name
+						- price
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: name
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": elasticsearchsearch.Config{
+					Name:         "example_tool",
+					Kind:         "elasticsearch-search",
+					Source:       "my-es-instance",
+					Description:  "some description",
+					Index:        "products",
+					Query:        "{\"query\": {\"match\": {\"name\": {{json .name}}}}}\n",
+					SourceFields: []string{"name", "price"},
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("name", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		resp := `{"hits": {"total": {"value": 1}, "hits": [{"_index": "products", "_id": "1", "_score": 1.2, "_source": {"name": "O'Brien \"chair\"", "price": 10}}]}`
+		if _, ok := gotBody["aggs"]; ok {
+			resp += `, "aggregations": {"avg_price": {"value": 10}}`
+		}
+		_, _ = w.Write([]byte(resp + "}"))
+	}))
+	defer srv.Close()
+
+	src := &elasticsearch.Source{Name: "my-es-instance", Kind: elasticsearch.SourceKind, Addresses: []string{srv.URL}, Client: srv.Client()}
+	newTool := func(query string) tools.Tool {
+		cfg := elasticsearchsearch.Config{
+			Name:         "example_tool",
+			Kind:         "elasticsearch-search",
+			Source:       "my-es-instance",
+			Description:  "some description",
+			Index:        "products",
+			Query:        query,
+			SourceFields: []string{"name", "price"},
+			Parameters: tools.Parameters{
+				tools.NewStringParameter("name", "the name"),
+				tools.NewIntParameterWithDefault("size", 10, "the number of hits"),
+			},
+		}
+		tool, err := cfg.Initialize(map[string]sources.Source{"my-es-instance": src})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return tool
+	}
+
+	tool := newTool(`{"query": {"match": {"name": {{json .name}}}}, "size": {{.size}}}`)
+	params, err := tool.ParseParams(map[string]any{"name": `O'Brien "chair"`}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantRows := []any{map[string]any{"_id": "1", "_index": "products", "name": `O'Brien "chair"`, "price": float64(10)}}
+	if diff := cmp.Diff(wantRows, res); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	if gotPath != "/products/_search" {
+		t.Fatalf("unexpected path: %s", gotPath)
+	}
+	// parameters are escaped as JSON strings, and the source fields are filtered
+	wantBody := map[string]any{
+		"query":   map[string]any{"match": map[string]any{"name": `O'Brien "chair"`}},
+		"size":    float64(10),
+		"_source": []any{"name", "price"},
+	}
+	if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+
+	tool = newTool(`{"query": {"match": {"name": {{json .name}}}}, "aggs": {"avg_price": {"avg": {"field": "price"}}}}`)
+	res, err = tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]any{
+		"hits":         wantRows,
+		"aggregations": map[string]any{"avg_price": map[string]any{"value": float64(10)}},
+	}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	tool = newTool(`[{{json .name}}]`)
+	if _, err := tool.Invoke(context.Background(), params); err == nil {
+		t.Fatalf("expected a query that isn't a JSON object to fail")
+	}
+}
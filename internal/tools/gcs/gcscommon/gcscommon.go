@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gcscommon
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxBytes is the default size limit of the objects read or written by
+// the tools.
+const DefaultMaxBytes int64 = 1 << 20
+
+// CheckObjectName verifies that the name of an object is under the prefix that
+// a tool is allowed to access.
+func CheckObjectName(prefix, name string) error {
+	if name == "" || strings.HasSuffix(name, "/") {
+		return fmt.Errorf("invalid object name %q", name)
+	}
+	if !strings.HasPrefix(name, prefix) {
+		return fmt.Errorf("object %q is not under prefix %q", name, prefix)
+	}
+	return nil
+}
+
+// CheckPrefix verifies that a prefix of the object names is under the prefix
+// that a tool is allowed to access.
+func CheckPrefix(prefix, p string) error {
+	if !strings.HasPrefix(p, prefix) {
+		return fmt.Errorf("prefix %q is not under prefix %q", p, prefix)
+	}
+	return nil
+}
+
+// Decode decodes the content of an object: JSON objects are decoded as JSON
+// values, and other text objects as strings.
+func Decode(name, contentType string, data []byte) (any, error) {
+	if isJSON(name, contentType) {
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("unable to decode JSON object %q: %w", name, err)
+		}
+		return v, nil
+	}
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("object %q of content type %q is not text", name, contentType)
+	}
+	return string(data), nil
+}
+
+func isJSON(name, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		return true
+	}
+	return (contentType == "" || mediaType == "application/octet-stream") && strings.HasSuffix(name, ".json")
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcscommon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcscommon"
+)
+
+func TestCheckObjectName(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		prefix  string
+		name    string
+		wantErr string
+	}{
+		{desc: "under prefix", prefix: "reports/", name: "reports/a.md"},
+		{desc: "no prefix", prefix: "", name: "a.md"},
+		{desc: "outside of prefix", prefix: "reports/", name: "reference/a.md", wantErr: `object "reference/a.md" is not under prefix "reports/"`},
+		{desc: "empty name", prefix: "", name: "", wantErr: `invalid object name ""`},
+		{desc: "folder name", prefix: "reports/", name: "reports/2025/", wantErr: `invalid object name "reports/2025/"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := gcscommon.CheckObjectName(tc.prefix, tc.name)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tcs := []struct {
+		desc        string
+		name        string
+		contentType string
+		data        string
+		want        any
+		wantErr     string
+	}{
+		{desc: "text", name: "a.txt", contentType: "text/plain; charset=utf-8", data: "hello", want: "hello"},
+		{desc: "json", name: "a", contentType: "application/json", data: `{"a":1}`, want: map[string]any{"a": float64(1)}},
+		{desc: "json suffix", name: "a", contentType: "application/ld+json", data: `[1]`, want: []any{float64(1)}},
+		{desc: "json extension", name: "a.json", contentType: "application/octet-stream", data: `true`, want: true},
+		{desc: "json extension of text", name: "a.json", contentType: "text/plain", data: `true`, want: "true"},
+		{desc: "invalid json", name: "a.json", contentType: "application/json", data: `{`, wantErr: `unable to decode JSON object "a.json": unexpected end of JSON input`},
+		{desc: "binary", name: "a.bin", contentType: "application/octet-stream", data: "\xff\xfe", wantErr: `object "a.bin" of content type "application/octet-stream" is not text`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := gcscommon.Decode(tc.name, tc.contentType, []byte(tc.data))
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gcslistobjects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/storage"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/gcs"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcscommon"
+	"google.golang.org/api/iterator"
+)
+
+const kind string = "gcs-list-objects"
+const prefixKey string = "prefix"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, MaxResults: 100}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	Prefix       string   `yaml:"prefix"`
+	Delimiter    string   `yaml:"delimiter"`
+	MaxResults   int      `yaml:"maxResults"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*gcs.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, gcs.SourceKind)
+	}
+
+	if cfg.MaxResults <= 0 {
+		return nil, fmt.Errorf("maxResults must be positive, got %d", cfg.MaxResults)
+	}
+
+	prefixParameter := tools.NewStringParameterWithDefault(prefixKey, cfg.Prefix, fmt.Sprintf("Prefix of the names of the listed objects, which must start with %q.", cfg.Prefix))
+	parameters := tools.Parameters{prefixParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Bucket:       s.BucketHandle(),
+		Prefix:       cfg.Prefix,
+		Delimiter:    cfg.Delimiter,
+		MaxResults:   cfg.MaxResults,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Bucket      *storage.BucketHandle
+	Prefix      string
+	Delimiter   string
+	MaxResults  int
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	prefix, ok := params.AsMap()[prefixKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", prefixKey)
+	}
+	if err := gcscommon.CheckPrefix(t.Prefix, prefix); err != nil {
+		return nil, err
+	}
+
+	query := &storage.Query{Prefix: prefix, Delimiter: t.Delimiter}
+	if err := query.SetAttrSelection([]string{"Name", "Size", "ContentType", "Updated"}); err != nil {
+		return nil, err
+	}
+
+	objects := []map[string]any{}
+	prefixes := []string{}
+	truncated := false
+	it := t.Bucket.Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to list objects: %w", err)
+		}
+		if len(objects)+len(prefixes) == t.MaxResults {
+			truncated = true
+			break
+		}
+		if attrs.Prefix != "" {
+			prefixes = append(prefixes, attrs.Prefix)
+			continue
+		}
+		objects = append(objects, map[string]any{
+			"name":        attrs.Name,
+			"size":        attrs.Size,
+			"contentType": attrs.ContentType,
+			"updated":     attrs.Updated.Format(time.RFC3339),
+		})
+	}
+
+	result := map[string]any{"objects": objects, "truncated": truncated}
+	if t.Delimiter != "" {
+		result["prefixes"] = prefixes
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
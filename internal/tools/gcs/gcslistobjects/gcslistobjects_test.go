@@ -0,0 +1,205 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcslistobjects_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/gcs"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcslistobjects"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlGCSListObjects(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: gcs-list-objects
+					source: my-gcs-source
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": gcslistobjects.Config{
+					Name:         "example_tool",
+					Kind:         "gcs-list-objects",
+					Source:       "my-gcs-source",
+					Description:  "some description",
+					MaxResults:   100,
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			tools:
+				example_tool:
+					kind: gcs-list-objects
+					source: my-gcs-source
+					description: some description
+					prefix: reference/
+					delimiter: /
+					maxResults: 20
+			`,
+			want: server.ToolConfigs{
+				"example_tool": gcslistobjects.Config{
+					Name:         "example_tool",
+					Kind:         "gcs-list-objects",
+					Source:       "my-gcs-source",
+					Description:  "some description",
+					Prefix:       "reference/",
+					Delimiter:    "/",
+					MaxResults:   20,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeGCSListObjects(t *testing.T) {
+	var query map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/storage/v1/b/my-bucket/o" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		query = map[string]string{"prefix": r.URL.Query().Get("prefix"), "delimiter": r.URL.Query().Get("delimiter")}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"prefixes": ["reference/2025/"],
+			"items": [
+				{"name": "reference/a.md", "size": "12", "contentType": "text/markdown", "updated": "2025-01-01T00:00:00Z"},
+				{"name": "reference/b.json", "size": "34", "contentType": "application/json", "updated": "2025-01-02T00:00:00Z"}
+			]
+		}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx, option.WithEndpoint(ts.URL+"/storage/v1/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	src := &gcs.Source{Name: "my-gcs-source", Kind: gcs.SourceKind, Bucket: "my-bucket", Client: client}
+
+	tcs := []struct {
+		desc       string
+		maxResults int
+		params     map[string]any
+		wantPrefix string
+		want       string
+		wantErr    string
+	}{
+		{
+			desc:       "default prefix",
+			maxResults: 100,
+			params:     map[string]any{},
+			wantPrefix: "reference/",
+			want:       `{"objects":[{"contentType":"text/markdown","name":"reference/a.md","size":12,"updated":"2025-01-01T00:00:00Z"},{"contentType":"application/json","name":"reference/b.json","size":34,"updated":"2025-01-02T00:00:00Z"}],"prefixes":["reference/2025/"],"truncated":false}`,
+		},
+		{
+			desc:       "truncated",
+			maxResults: 2,
+			params:     map[string]any{"prefix": "reference/2025"},
+			wantPrefix: "reference/2025",
+			want:       `{"objects":[{"contentType":"text/markdown","name":"reference/a.md","size":12,"updated":"2025-01-01T00:00:00Z"},{"contentType":"application/json","name":"reference/b.json","size":34,"updated":"2025-01-02T00:00:00Z"}],"prefixes":[],"truncated":true}`,
+		},
+		{
+			desc:       "prefix outside of the tool prefix",
+			maxResults: 100,
+			params:     map[string]any{"prefix": "secrets/"},
+			wantErr:    `prefix "secrets/" is not under prefix "reference/"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			query = nil
+			cfg := gcslistobjects.Config{
+				Name:        "example_tool",
+				Kind:        "gcs-list-objects",
+				Source:      "my-gcs-source",
+				Description: "some description",
+				Prefix:      "reference/",
+				Delimiter:   "/",
+				MaxResults:  tc.maxResults,
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-gcs-source": src})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			params, err := tool.ParseParams(tc.params, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(ctx, params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+
+			if diff := cmp.Diff(map[string]string{"prefix": tc.wantPrefix, "delimiter": "/"}, query); diff != "" {
+				t.Fatalf("incorrect query: diff %v", diff)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("unable to marshal result: %s", err)
+			}
+			if string(b) != tc.want {
+				t.Fatalf("incorrect result: got %s, want %s", b, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gcsreadobject
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/gcs"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcscommon"
+)
+
+const kind string = "gcs-read-object"
+const objectKey string = "object"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, MaxBytes: gcscommon.DefaultMaxBytes}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	Prefix       string   `yaml:"prefix"`
+	MaxBytes     int64    `yaml:"maxBytes"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*gcs.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, gcs.SourceKind)
+	}
+
+	if cfg.MaxBytes <= 0 {
+		return nil, fmt.Errorf("maxBytes must be positive, got %d", cfg.MaxBytes)
+	}
+
+	objectParameter := tools.NewStringParameter(objectKey, fmt.Sprintf("Name of the object to read, which must start with %q.", cfg.Prefix))
+	parameters := tools.Parameters{objectParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Bucket:       s.BucketHandle(),
+		Prefix:       cfg.Prefix,
+		MaxBytes:     cfg.MaxBytes,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Bucket      *storage.BucketHandle
+	Prefix      string
+	MaxBytes    int64
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	name, ok := params.AsMap()[objectKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", objectKey)
+	}
+	if err := gcscommon.CheckObjectName(t.Prefix, name); err != nil {
+		return nil, err
+	}
+
+	r, err := t.Bucket.Object(name).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read object %q: %w", name, err)
+	}
+	defer r.Close()
+	if r.Attrs.Size > t.MaxBytes {
+		return nil, fmt.Errorf("object %q has %d bytes, more than the limit of %d bytes", name, r.Attrs.Size, t.MaxBytes)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, t.MaxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read object %q: %w", name, err)
+	}
+
+	content, err := gcscommon.Decode(name, r.Attrs.ContentType, data)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"name":        name,
+		"contentType": r.Attrs.ContentType,
+		"size":        r.Attrs.Size,
+		"content":     content,
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
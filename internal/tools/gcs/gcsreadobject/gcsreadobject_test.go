@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsreadobject_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/gcs"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcsreadobject"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlGCSReadObject(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: gcs-read-object
+					source: my-gcs-source
+					description: some description
+					prefix: reference/
+			`,
+			want: server.ToolConfigs{
+				"example_tool": gcsreadobject.Config{
+					Name:         "example_tool",
+					Kind:         "gcs-read-object",
+					Source:       "my-gcs-source",
+					Description:  "some description",
+					Prefix:       "reference/",
+					MaxBytes:     1048576,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeGCSReadObject(t *testing.T) {
+	objects := map[string]struct {
+		contentType string
+		data        string
+	}{
+		"reference/a.md":   {contentType: "text/markdown", data: "# Runbook"},
+		"reference/b.json": {contentType: "application/json", data: `{"owner":"sre","services":["checkout"]}`},
+		"reference/c.png":  {contentType: "image/png", data: "\x89PNG\r\n\x1a\n\xff"},
+		"reference/d.txt":  {contentType: "text/plain", data: strings.Repeat("x", 100)},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/storage/v1/b/my-bucket/o/")
+		o, ok := objects[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", o.contentType)
+		w.Header().Set("X-Goog-Generation", "1")
+		_, _ = w.Write([]byte(o.data))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx, option.WithEndpoint(ts.URL+"/storage/v1/"), option.WithoutAuthentication(), storage.WithJSONReads())
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	src := &gcs.Source{Name: "my-gcs-source", Kind: gcs.SourceKind, Bucket: "my-bucket", Client: client}
+	cfg := gcsreadobject.Config{
+		Name:        "example_tool",
+		Kind:        "gcs-read-object",
+		Source:      "my-gcs-source",
+		Description: "some description",
+		Prefix:      "reference/",
+		MaxBytes:    50,
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-gcs-source": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		object  string
+		want    any
+		wantErr string
+	}{
+		{
+			desc:   "text object",
+			object: "reference/a.md",
+			want:   map[string]any{"name": "reference/a.md", "contentType": "text/markdown", "size": int64(9), "content": "# Runbook"},
+		},
+		{
+			desc:   "json object",
+			object: "reference/b.json",
+			want: map[string]any{"name": "reference/b.json", "contentType": "application/json", "size": int64(39), "content": map[string]any{
+				"owner":    "sre",
+				"services": []any{"checkout"},
+			}},
+		},
+		{
+			desc:    "binary object",
+			object:  "reference/c.png",
+			wantErr: `object "reference/c.png" of content type "image/png" is not text`,
+		},
+		{
+			desc:    "object too large",
+			object:  "reference/d.txt",
+			wantErr: `object "reference/d.txt" has 100 bytes, more than the limit of 50 bytes`,
+		},
+		{
+			desc:    "object outside of the prefix",
+			object:  "secrets/key.json",
+			wantErr: `object "secrets/key.json" is not under prefix "reference/"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(map[string]any{"object": tc.object}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(ctx, params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
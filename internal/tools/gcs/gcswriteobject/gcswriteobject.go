@@ -0,0 +1,187 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gcswriteobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/gcs"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcscommon"
+	"google.golang.org/api/googleapi"
+)
+
+const kind string = "gcs-write-object"
+const objectKey string = "object"
+const contentKey string = "content"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, ContentType: "text/plain; charset=utf-8", MaxBytes: gcscommon.DefaultMaxBytes}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	Prefix       string   `yaml:"prefix" validate:"required"`
+	ContentType  string   `yaml:"contentType"`
+	MaxBytes     int64    `yaml:"maxBytes"`
+	Overwrite    bool     `yaml:"overwrite"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*gcs.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, gcs.SourceKind)
+	}
+
+	if cfg.MaxBytes <= 0 {
+		return nil, fmt.Errorf("maxBytes must be positive, got %d", cfg.MaxBytes)
+	}
+
+	objectParameter := tools.NewStringParameter(objectKey, fmt.Sprintf("Name of the object to write, which must start with %q.", cfg.Prefix))
+	contentParameter := tools.NewStringParameter(contentKey, "Content of the object.")
+	parameters := tools.Parameters{objectParameter, contentParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Bucket:       s.BucketHandle(),
+		Prefix:       cfg.Prefix,
+		ContentType:  cfg.ContentType,
+		MaxBytes:     cfg.MaxBytes,
+		Overwrite:    cfg.Overwrite,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Bucket      *storage.BucketHandle
+	Prefix      string
+	ContentType string
+	MaxBytes    int64
+	Overwrite   bool
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	name, ok := paramsMap[objectKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", objectKey)
+	}
+	content, ok := paramsMap[contentKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", contentKey)
+	}
+	if err := gcscommon.CheckObjectName(t.Prefix, name); err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > t.MaxBytes {
+		return nil, fmt.Errorf("content has %d bytes, more than the limit of %d bytes", len(content), t.MaxBytes)
+	}
+
+	obj := t.Bucket.Object(name)
+	if !t.Overwrite {
+		obj = obj.If(storage.Conditions{DoesNotExist: true})
+	}
+	w := obj.NewWriter(ctx)
+	w.ContentType = t.ContentType
+	if _, err := w.Write([]byte(content)); err != nil {
+		_ = w.Close()
+		return nil, fmt.Errorf("unable to write object %q: %w", name, err)
+	}
+	if err := w.Close(); err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed {
+			return nil, fmt.Errorf("object %q already exists", name)
+		}
+		return nil, fmt.Errorf("unable to write object %q: %w", name, err)
+	}
+
+	attrs := w.Attrs()
+	return map[string]any{
+		"name":       attrs.Name,
+		"size":       attrs.Size,
+		"generation": attrs.Generation,
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcswriteobject_test
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/gcs"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcswriteobject"
+	"google.golang.org/api/option"
+)
+
+func TestParseFromYamlGCSWriteObject(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: gcs-write-object
+					source: my-gcs-source
+					description: some description
+					prefix: reports/
+			`,
+			want: server.ToolConfigs{
+				"example_tool": gcswriteobject.Config{
+					Name:         "example_tool",
+					Kind:         "gcs-write-object",
+					Source:       "my-gcs-source",
+					Description:  "some description",
+					Prefix:       "reports/",
+					ContentType:  "text/plain; charset=utf-8",
+					MaxBytes:     1048576,
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			tools:
+				example_tool:
+					kind: gcs-write-object
+					source: my-gcs-source
+					description: some description
+					prefix: reports/
+					contentType: text/markdown
+					maxBytes: 4096
+					overwrite: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": gcswriteobject.Config{
+					Name:         "example_tool",
+					Kind:         "gcs-write-object",
+					Source:       "my-gcs-source",
+					Description:  "some description",
+					Prefix:       "reports/",
+					ContentType:  "text/markdown",
+					MaxBytes:     4096,
+					Overwrite:    true,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeGCSWriteObject(t *testing.T) {
+	var ifGenerationMatch, content string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/upload/storage/v1/b/my-bucket/o" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		ifGenerationMatch = r.URL.Query().Get("ifGenerationMatch")
+		if ifGenerationMatch == "0" && r.URL.Query().Get("name") == "reports/existing.md" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"error":{"code":412,"message":"conditionNotMet"}}`))
+			return
+		}
+		_, mediaParams, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("unable to parse content type: %s", err)
+		}
+		mr := multipart.NewReader(r.Body, mediaParams["boundary"])
+		if _, err := mr.NextPart(); err != nil {
+			t.Errorf("unable to read metadata part: %s", err)
+		}
+		part, err := mr.NextPart()
+		if err != nil {
+			t.Errorf("unable to read media part: %s", err)
+		}
+		b, _ := io.ReadAll(part)
+		content = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"bucket":"my-bucket","name":"reports/incident.md","size":"10","generation":"42"}`))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx, option.WithEndpoint(ts.URL+"/storage/v1/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	src := &gcs.Source{Name: "my-gcs-source", Kind: gcs.SourceKind, Bucket: "my-bucket", Client: client}
+
+	tcs := []struct {
+		desc                  string
+		overwrite             bool
+		object                string
+		content               string
+		want                  any
+		wantIfGenerationMatch string
+		wantErr               string
+	}{
+		{
+			desc:                  "new object",
+			object:                "reports/incident.md",
+			content:               "# Incident",
+			want:                  map[string]any{"name": "reports/incident.md", "size": int64(10), "generation": int64(42)},
+			wantIfGenerationMatch: "0",
+		},
+		{
+			desc:                  "overwrite",
+			overwrite:             true,
+			object:                "reports/incident.md",
+			content:               "# Incident",
+			want:                  map[string]any{"name": "reports/incident.md", "size": int64(10), "generation": int64(42)},
+			wantIfGenerationMatch: "",
+		},
+		{
+			desc:    "existing object",
+			object:  "reports/existing.md",
+			content: "# Incident",
+			wantErr: `object "reports/existing.md" already exists`,
+		},
+		{
+			desc:    "content too large",
+			object:  "reports/incident.md",
+			content: "# Incident with a long description",
+			wantErr: "content has 34 bytes, more than the limit of 20 bytes",
+		},
+		{
+			desc:    "object outside of the prefix",
+			object:  "reference/runbook.md",
+			content: "# Runbook",
+			wantErr: `object "reference/runbook.md" is not under prefix "reports/"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ifGenerationMatch, content = "", ""
+			cfg := gcswriteobject.Config{
+				Name:        "example_tool",
+				Kind:        "gcs-write-object",
+				Source:      "my-gcs-source",
+				Description: "some description",
+				Prefix:      "reports/",
+				ContentType: "text/markdown",
+				MaxBytes:    20,
+				Overwrite:   tc.overwrite,
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-gcs-source": src})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			params, err := tool.ParseParams(map[string]any{"object": tc.object, "content": tc.content}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(ctx, params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+			if ifGenerationMatch != tc.wantIfGenerationMatch {
+				t.Fatalf("incorrect ifGenerationMatch: got %q, want %q", ifGenerationMatch, tc.wantIfGenerationMatch)
+			}
+			if content != tc.content {
+				t.Fatalf("incorrect content: got %q, want %q", content, tc.content)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphqlexecute_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	graphqlsrc "github.com/googleapis/genai-toolbox/internal/sources/graphql"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/graphql/graphqlexecute"
+)
+
+func TestParseFromYamlGraphQLExecute(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: graphql-execute
+					source: my-graphql-instance
+					description: some description
+					query: |
+						query($owner: String!) { repositoryOwner(login: $owner) { login } }
+					parameters:
+						- name: owner
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": graphqlexecute.Config{
+					Name:         "example_tool",
+					Kind:         "graphql-execute",
+					Source:       "my-graphql-instance",
+					Description:  "some description",
+					Query:        "query($owner: String!) { repositoryOwner(login: $owner) { login } }\n",
+					AuthRequired: []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("owner", "some description"),
+					},
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			tools:
+				example_tool:
+					kind: graphql-execute
+					source: my-graphql-instance
+					description: some description
+					query: "query GetRepo { repository(name: \"{{.name}}\") { id } }"
+					operationName: GetRepo
+					headers:
+						X-Custom: custom
+					authRequired:
+						- my-google-auth-service
+					templateParameters:
+						- name: name
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": graphqlexecute.Config{
+					Name:          "example_tool",
+					Kind:          "graphql-execute",
+					Source:        "my-graphql-instance",
+					Description:   "some description",
+					Query:         "query GetRepo { repository(name: \"{{.name}}\") { id } }",
+					OperationName: "GetRepo",
+					Headers:       map[string]string{"X-Custom": "custom"},
+					AuthRequired:  []string{"my-google-auth-service"},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("name", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var got graphqlsrc.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		if got.Variables["login"] == "missing" {
+			_, _ = w.Write([]byte(`{"data": {"user": null}, "errors": [{"message": "Could not resolve to a User"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"user": {"name": "Alice"}}}`))
+	}))
+	defer srv.Close()
+
+	src := &graphqlsrc.Source{Name: "my-graphql-instance", Kind: graphqlsrc.SourceKind, Endpoint: srv.URL, Client: srv.Client()}
+	cfg := graphqlexecute.Config{
+		Name:        "example_tool",
+		Kind:        "graphql-execute",
+		Source:      "my-graphql-instance",
+		Description: "some description",
+		Query:       "query($login: String!, $first: Int) { user(login: $login) { {{.field}} } }",
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("login", "the user"),
+			tools.NewIntParameterWithRequired("first", "optional", false),
+		},
+		TemplateParameters: tools.Parameters{
+			tools.NewStringParameter("field", "the field"),
+		},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-graphql-instance": src})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"login": "alice", "field": "name"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(map[string]any{"user": map[string]any{"name": "Alice"}}, res); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	// unset optional parameters aren't sent as variables
+	want := graphqlsrc.Request{
+		Query:     "query($login: String!, $first: Int) { user(login: $login) { name } }",
+		Variables: map[string]any{"login": "alice"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+
+	params, err = tool.ParseParams(map[string]any{"login": "missing", "field": "name"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := tool.Invoke(context.Background(), params); err == nil || err.Error() != "GraphQL errors: Could not resolve to a User" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
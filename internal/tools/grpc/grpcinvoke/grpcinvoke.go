@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package grpcinvoke
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	grpcsrc "github.com/googleapis/genai-toolbox/internal/sources/grpc"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const kind string = "grpc-invoke"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string            `yaml:"name" validate:"required"`
+	Kind         string            `yaml:"kind" validate:"required"`
+	Source       string            `yaml:"source" validate:"required"`
+	Description  string            `yaml:"description" validate:"required"`
+	Method       string            `yaml:"method" validate:"required"`
+	Headers      map[string]string `yaml:"headers"`
+	AuthRequired []string          `yaml:"authRequired"`
+	Parameters   tools.Parameters  `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*grpcsrc.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, grpcsrc.SourceKind)
+	}
+
+	// verify the method exists, and that its request has a field for every
+	// parameter
+	method, err := s.FindMethod(context.Background(), cfg.Method)
+	if err != nil {
+		return nil, err
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("method %q is a streaming method, only unary methods are supported", cfg.Method)
+	}
+	fields := method.Input().Fields()
+	for _, p := range cfg.Parameters {
+		if fields.ByName(protoreflect.Name(p.GetName())) == nil && fields.ByJSONName(p.GetName()) == nil {
+			return nil, fmt.Errorf("parameter %q is not a field of %s", p.GetName(), method.Input().FullName())
+		}
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		Headers:      cfg.Headers,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Method:       method,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *grpcsrc.Source
+	Method      protoreflect.MethodDescriptor
+	Headers     map[string]string
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	// the request is the JSON encoding of the parameters, and unset optional
+	// parameters are left out so their fields keep their default values
+	fields := make(map[string]any)
+	for _, p := range params {
+		if p.Value != nil {
+			fields[p.Name] = p.Value
+		}
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal request: %w", err)
+	}
+	req := dynamicpb.NewMessage(t.Method.Input())
+	if err := protojson.Unmarshal(b, req); err != nil {
+		return nil, fmt.Errorf("unable to build %s request: %w", t.Method.Input().FullName(), err)
+	}
+
+	ctx, cancel := t.Source.OutgoingContext(ctx, t.Headers)
+	defer cancel()
+	resp := dynamicpb.NewMessage(t.Method.Output())
+	fullMethod := fmt.Sprintf("/%s/%s", t.Method.Parent().FullName(), t.Method.Name())
+	if err := t.Source.Conn.Invoke(ctx, fullMethod, req, resp); err != nil {
+		return nil, fmt.Errorf("unable to call %s: %w", fullMethod, err)
+	}
+
+	out, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal response: %w", err)
+	}
+	var data any
+	if err := json.Unmarshal(out, &data); err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+	return data, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcinvoke_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	grpcsrc "github.com/googleapis/genai-toolbox/internal/sources/grpc"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/grpc/grpcinvoke"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+func TestParseFromYamlGRPCInvoke(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: grpc-invoke
+					source: my-grpc-instance
+					description: some description
+					method: grpc.health.v1.Health/Check
+					headers:
+						x-custom: custom
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: service
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": grpcinvoke.Config{
+					Name:         "example_tool",
+					Kind:         "grpc-invoke",
+					Source:       "my-grpc-instance",
+					Description:  "some description",
+					Method:       "grpc.health.v1.Health/Check",
+					Headers:      map[string]string{"x-custom": "custom"},
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("service", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	s := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("my-service", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+	reflection.Register(s)
+	go func() { _ = s.Serve(lis) }()
+	defer s.Stop()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	srcCfg := grpcsrc.Config{Name: "my-grpc-instance", Kind: grpcsrc.SourceKind, Target: lis.Addr().String(), Timeout: "5s"}
+	src, err := srcCfg.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	srcs := map[string]sources.Source{"my-grpc-instance": src}
+
+	cfg := grpcinvoke.Config{
+		Name:        "example_tool",
+		Kind:        "grpc-invoke",
+		Source:      "my-grpc-instance",
+		Description: "some description",
+		Method:      "grpc.health.v1.Health/Check",
+		Parameters: tools.Parameters{
+			tools.NewStringParameterWithRequired("service", "the service", false),
+		},
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		in   map[string]any
+		want any
+	}{
+		{desc: "unset parameter", in: map[string]any{}, want: map[string]any{"status": "SERVING"}},
+		{desc: "set parameter", in: map[string]any{"service": "my-service"}, want: map[string]any{"status": "NOT_SERVING"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(tc.in, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+
+	// the schema is validated when the tool is initialized
+	cfg.Parameters = tools.Parameters{tools.NewStringParameter("missing", "not a field")}
+	if _, err := cfg.Initialize(srcs); err == nil {
+		t.Fatalf("expected error for parameter that isn't a field")
+	}
+	cfg.Method, cfg.Parameters = "grpc.health.v1.Health/Watch", nil
+	if _, err := cfg.Initialize(srcs); err == nil {
+		t.Fatalf("expected error for streaming method")
+	}
+}
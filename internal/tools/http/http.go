@@ -31,6 +31,8 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
+	"github.com/jmespath/go-jmespath"
 )
 
 const kind string = "http"
@@ -50,19 +52,21 @@ func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.T
 }
 
 type Config struct {
-	Name         string            `yaml:"name" validate:"required"`
-	Kind         string            `yaml:"kind" validate:"required"`
-	Source       string            `yaml:"source" validate:"required"`
-	Description  string            `yaml:"description" validate:"required"`
-	AuthRequired []string          `yaml:"authRequired"`
-	Path         string            `yaml:"path" validate:"required"`
-	Method       tools.HTTPMethod  `yaml:"method" validate:"required"`
-	Headers      map[string]string `yaml:"headers"`
-	RequestBody  string            `yaml:"requestBody"`
-	PathParams   tools.Parameters  `yaml:"pathParams"`
-	QueryParams  tools.Parameters  `yaml:"queryParams"`
-	BodyParams   tools.Parameters  `yaml:"bodyParams"`
-	HeaderParams tools.Parameters  `yaml:"headerParams"`
+	Name              string            `yaml:"name" validate:"required"`
+	Kind              string            `yaml:"kind" validate:"required"`
+	Source            string            `yaml:"source" validate:"required"`
+	Description       string            `yaml:"description" validate:"required"`
+	AuthRequired      []string          `yaml:"authRequired"`
+	Path              string            `yaml:"path" validate:"required"`
+	Method            tools.HTTPMethod  `yaml:"method" validate:"required"`
+	Headers           map[string]string `yaml:"headers"`
+	RequestBody       string            `yaml:"requestBody"`
+	PathParams        tools.Parameters  `yaml:"pathParams"`
+	QueryParams       tools.Parameters  `yaml:"queryParams"`
+	BodyParams        tools.Parameters  `yaml:"bodyParams"`
+	HeaderParams      tools.Parameters  `yaml:"headerParams"`
+	Retry             *retry.Config     `yaml:"retry"`
+	ResponseTransform string            `yaml:"responseTransform"`
 }
 
 // validate interface
@@ -91,6 +95,25 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	maps.Copy(combinedHeaders, s.DefaultHeaders)
 	maps.Copy(combinedHeaders, cfg.Headers)
 
+	// The tool's retry policy overrides the source's retry policy
+	retryPolicy := s.RetryPolicy
+	if cfg.Retry != nil {
+		var err error
+		retryPolicy, err = cfg.Retry.Policy()
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry policy for tool %q: %w", cfg.Name, err)
+		}
+	}
+
+	var responseTransform *jmespath.JMESPath
+	if cfg.ResponseTransform != "" {
+		var err error
+		responseTransform, err = jmespath.Compile(cfg.ResponseTransform)
+		if err != nil {
+			return nil, fmt.Errorf("invalid responseTransform for tool %q: %w", cfg.Name, err)
+		}
+	}
+
 	// Create a slice for all parameters
 	allParameters := slices.Concat(cfg.PathParams, cfg.BodyParams, cfg.HeaderParams, cfg.QueryParams)
 
@@ -173,6 +196,8 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Headers:            combinedHeaders,
 		DefaultQueryParams: s.QueryParams,
 		Client:             s.Client,
+		RetryPolicy:        retryPolicy,
+		ResponseTransform:  responseTransform,
 		AllParams:          allParameters,
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
@@ -181,6 +206,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 // validate interface
 var _ tools.Tool = Tool{}
+var _ tools.DryRunner = Tool{}
 
 type Tool struct {
 	Name         string   `yaml:"name"`
@@ -201,9 +227,11 @@ type Tool struct {
 	HeaderParams tools.Parameters `yaml:"headerParams"`
 	AllParams    tools.Parameters `yaml:"allParams"`
 
-	Client      *http.Client
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Client            *http.Client
+	RetryPolicy       retry.Policy
+	ResponseTransform *jmespath.JMESPath
+	manifest          tools.Manifest
+	mcpManifest       tools.McpManifest
 }
 
 // helper function to convert a parameter to JSON formatted string.
@@ -303,9 +331,9 @@ func getHeaders(headerParams tools.Parameters, defaultHeaders map[string]string,
 	return allHeaders, nil
 }
 
-func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
-	paramsMap := params.AsMap()
-
+// BuildRequest creates the HTTP request described by the Tool for the given
+// parameter values.
+func (t Tool) BuildRequest(ctx context.Context, paramsMap map[string]any) (*http.Request, error) {
 	// Calculate request body
 	requestBody, err := getRequestBody(t.BodyParams, t.RequestBody, paramsMap)
 	if err != nil {
@@ -318,7 +346,10 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("error populating path parameters: %s", err)
 	}
 
-	req, _ := http.NewRequest(string(t.Method), urlString, strings.NewReader(requestBody))
+	req, err := http.NewRequestWithContext(ctx, string(t.Method), urlString, strings.NewReader(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request: %s", err)
+	}
 
 	// Calculate request headers
 	allHeaders, err := getHeaders(t.HeaderParams, t.Headers, paramsMap)
@@ -329,9 +360,16 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	for k, v := range allHeaders {
 		req.Header.Set(k, v)
 	}
+	return req, nil
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
 
 	// Make request and fetch response
-	resp, err := t.Client.Do(req)
+	resp, err := t.RetryPolicy.Do(ctx, t.Client, func() (*http.Request, error) {
+		return t.BuildRequest(ctx, paramsMap)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error making HTTP request: %s", err)
 	}
@@ -351,7 +389,71 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		// if unable to unmarshal data, return result as string.
 		return string(body), nil
 	}
-	return data, nil
+	return t.TransformResponse(data)
+}
+
+// credentialHeaders are the canonical names of the headers whose values are
+// credentials, which are redacted from dry runs.
+var credentialHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+	"X-Goog-Api-Key":      true,
+}
+
+// DryRunRequest is the result of a dry run, which is the request that would
+// be sent.
+type DryRunRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+// DryRun returns the request that the tool would send, without sending it.
+// The values of credential headers and sensitive header params are redacted.
+func (t Tool) DryRun(ctx context.Context, params tools.ParamValues) (any, error) {
+	req, err := t.BuildRequest(ctx, params.AsMap())
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	sensitive := make(map[string]bool)
+	for _, p := range params {
+		sensitive[p.Name] = p.Sensitive
+	}
+	redact := maps.Clone(credentialHeaders)
+	for _, p := range t.HeaderParams {
+		if sensitive[p.GetName()] {
+			redact[http.CanonicalHeaderKey(p.GetName())] = true
+		}
+	}
+	headers := make(map[string]string, len(req.Header))
+	for k := range req.Header {
+		if redact[k] {
+			headers[k] = tools.RedactedValue
+			continue
+		}
+		headers[k] = req.Header.Get(k)
+	}
+	return DryRunRequest{Method: req.Method, URL: req.URL.String(), Headers: headers, Body: string(body)}, nil
+}
+
+// TransformResponse applies the Tool's responseTransform, if any, to a decoded
+// JSON response.
+func (t Tool) TransformResponse(data any) (any, error) {
+	if t.ResponseTransform == nil {
+		return data, nil
+	}
+	res, err := t.ResponseTransform.Search(data)
+	if err != nil {
+		return nil, fmt.Errorf("error applying responseTransform: %s", err)
+	}
+	return res, nil
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
@@ -15,6 +15,7 @@
 package http_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	http "github.com/googleapis/genai-toolbox/internal/tools/http"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
 )
 
 func TestParseFromYamlHTTP(t *testing.T) {
@@ -105,6 +107,10 @@ func TestParseFromYamlHTTP(t *testing.T) {
 						- name: Language
 						  type: string
 						  description: language string
+					retry:
+						maxRetries: 3
+						retryOn: [429, connection-error]
+					responseTransform: "items[].{id: id, name: name}"
 			`,
 			want: server.ToolConfigs{
 				"example_tool": http.Config{
@@ -131,9 +137,11 @@ func TestParseFromYamlHTTP(t *testing.T) {
   "food": {{.food}}
 }
 `,
-					BodyParams:   []tools.Parameter{tools.NewIntParameter("age", "age num"), tools.NewStringParameter("city", "city string")},
-					Headers:      map[string]string{"Authorization": "API_KEY", "Content-Type": "application/json"},
-					HeaderParams: []tools.Parameter{tools.NewStringParameter("Language", "language string")},
+					BodyParams:        []tools.Parameter{tools.NewIntParameter("age", "age num"), tools.NewStringParameter("city", "city string")},
+					Headers:           map[string]string{"Authorization": "API_KEY", "Content-Type": "application/json"},
+					HeaderParams:      []tools.Parameter{tools.NewStringParameter("Language", "language string")},
+					Retry:             &retry.Config{MaxRetries: 3, RetryOn: []retry.Condition{"429", retry.ConnectionError}},
+					ResponseTransform: "items[].{id: id, name: name}",
 				},
 			},
 		},
@@ -229,3 +237,42 @@ func TestFailParseFromYamlHTTP(t *testing.T) {
 	}
 
 }
+
+func TestDryRun(t *testing.T) {
+	session := tools.NewStringParameter("X-Session", "the session of the user")
+	session.Sensitive = true
+	tool := http.Tool{
+		BaseURL:      "https://example.com",
+		Path:         "/flights/{{.id}}",
+		Method:       "POST",
+		Headers:      map[string]string{"Authorization": "Bearer secret", "Content-Type": "application/json"},
+		RequestBody:  `{"seat": "{{.seat}}"}`,
+		PathParams:   tools.Parameters{tools.NewStringParameter("id", "the flight")},
+		QueryParams:  tools.Parameters{tools.NewStringParameter("airline", "the airline")},
+		BodyParams:   tools.Parameters{tools.NewStringParameter("seat", "the seat")},
+		HeaderParams: tools.Parameters{session},
+	}
+	tool.AllParams = append(append(append(append(tools.Parameters{}, tool.PathParams...), tool.QueryParams...), tool.BodyParams...), tool.HeaderParams...)
+
+	params, err := tool.ParseParams(map[string]any{"id": "CY123", "airline": "CY", "seat": "12A", "X-Session": "abc"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.DryRun(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := http.DryRunRequest{
+		Method: "POST",
+		URL:    "https://example.com/flights/CY123?airline=CY",
+		Headers: map[string]string{
+			"Authorization": tools.RedactedValue,
+			"Content-Type":  "application/json",
+			"X-Session":     tools.RedactedValue,
+		},
+		Body: `{"seat": "12A"}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected dry run (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package httpbatch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	httptool "github.com/googleapis/genai-toolbox/internal/tools/http"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
+)
+
+const kind string = "http-batch"
+
+const (
+	defaultMaxConcurrency = 5
+	defaultMaxBatchSize   = 100
+)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name              string            `yaml:"name" validate:"required"`
+	Kind              string            `yaml:"kind" validate:"required"`
+	Source            string            `yaml:"source" validate:"required"`
+	Description       string            `yaml:"description" validate:"required"`
+	AuthRequired      []string          `yaml:"authRequired"`
+	Path              string            `yaml:"path" validate:"required"`
+	Method            tools.HTTPMethod  `yaml:"method" validate:"required"`
+	Headers           map[string]string `yaml:"headers"`
+	RequestBody       string            `yaml:"requestBody"`
+	PathParams        tools.Parameters  `yaml:"pathParams"`
+	QueryParams       tools.Parameters  `yaml:"queryParams"`
+	BodyParams        tools.Parameters  `yaml:"bodyParams"`
+	HeaderParams      tools.Parameters  `yaml:"headerParams"`
+	Retry             *retry.Config     `yaml:"retry"`
+	ResponseTransform string            `yaml:"responseTransform"`
+	BatchParam        string            `yaml:"batchParam" validate:"required"`
+	MaxConcurrency    int               `yaml:"maxConcurrency" validate:"gte=0"`
+	MaxBatchSize      int               `yaml:"maxBatchSize" validate:"gte=0"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// each request in the batch is built exactly like an `http` tool request
+	requestCfg := httptool.Config{
+		Name:              cfg.Name,
+		Kind:              kind,
+		Source:            cfg.Source,
+		Description:       cfg.Description,
+		AuthRequired:      cfg.AuthRequired,
+		Path:              cfg.Path,
+		Method:            cfg.Method,
+		Headers:           cfg.Headers,
+		RequestBody:       cfg.RequestBody,
+		PathParams:        cfg.PathParams,
+		QueryParams:       cfg.QueryParams,
+		BodyParams:        cfg.BodyParams,
+		HeaderParams:      cfg.HeaderParams,
+		Retry:             cfg.Retry,
+		ResponseTransform: cfg.ResponseTransform,
+	}
+	rawT, err := requestCfg.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	requestTool := rawT.(httptool.Tool)
+
+	// the batch param is exposed to callers as an array of the declared param
+	idx := slices.IndexFunc(requestTool.AllParams, func(p tools.Parameter) bool {
+		return p.GetName() == cfg.BatchParam
+	})
+	if idx < 0 {
+		return nil, fmt.Errorf("batchParam %q for tool %q must be one of the tool's parameters", cfg.BatchParam, cfg.Name)
+	}
+	item := requestTool.AllParams[idx]
+	if item.GetType() == "array" {
+		return nil, fmt.Errorf("batchParam %q for tool %q must not be an array", cfg.BatchParam, cfg.Name)
+	}
+	if len(item.GetAuthServices()) > 0 {
+		return nil, fmt.Errorf("batchParam %q for tool %q cannot be populated from authServices", cfg.BatchParam, cfg.Name)
+	}
+	batchDesc := strings.TrimSuffix(item.Manifest().Description, ".") + ". One request is sent for each value."
+	allParams := slices.Clone(requestTool.AllParams)
+	allParams[idx] = tools.NewArrayParameter(cfg.BatchParam, batchDesc, item)
+
+	maxConcurrency := cfg.MaxConcurrency
+	if maxConcurrency == 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+	maxBatchSize := cfg.MaxBatchSize
+	if maxBatchSize == 0 {
+		maxBatchSize = defaultMaxBatchSize
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: allParams.McpManifest(),
+	}
+
+	return Tool{
+		Name:           cfg.Name,
+		Kind:           kind,
+		AuthRequired:   cfg.AuthRequired,
+		BatchParam:     cfg.BatchParam,
+		MaxConcurrency: maxConcurrency,
+		MaxBatchSize:   maxBatchSize,
+		AllParams:      allParams,
+		request:        requestTool,
+		manifest:       tools.Manifest{Description: cfg.Description, Parameters: allParams.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:    mcpManifest,
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name           string           `yaml:"name"`
+	Kind           string           `yaml:"kind"`
+	AuthRequired   []string         `yaml:"authRequired"`
+	BatchParam     string           `yaml:"batchParam"`
+	MaxConcurrency int              `yaml:"maxConcurrency"`
+	MaxBatchSize   int              `yaml:"maxBatchSize"`
+	AllParams      tools.Parameters `yaml:"allParams"`
+
+	request     httptool.Tool
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// batchResult is the outcome of a single request in the batch. Exactly one of
+// Result or Error is set.
+type batchResult struct {
+	Item   any    `json:"item"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	var items []any
+	for _, p := range params {
+		if p.Name == t.BatchParam {
+			items, _ = p.Value.([]any)
+		}
+	}
+	if len(items) > t.MaxBatchSize {
+		return nil, fmt.Errorf("batch of %d values for %q exceeds the maximum batch size of %d", len(items), t.BatchParam, t.MaxBatchSize)
+	}
+
+	results := make([]batchResult, len(items))
+	var mu sync.Mutex
+	completed := 0
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, t.MaxConcurrency)
+	for i, item := range items {
+		select {
+		case <-ctx.Done():
+			results[i] = batchResult{Item: item, Error: ctx.Err().Error()}
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res, err := t.request.Invoke(ctx, itemParams(params, t.BatchParam, item))
+			if err != nil {
+				results[i] = batchResult{Item: item, Error: err.Error()}
+			} else {
+				results[i] = batchResult{Item: item, Result: res}
+			}
+
+			mu.Lock()
+			completed++
+			util.ReportProgress(ctx, float64(completed), float64(len(items)), fmt.Sprintf("%d of %d requests completed", completed, len(items)))
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// only fail the whole invocation if no request succeeded
+	failed := 0
+	rtn := make([]any, 0, len(results))
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+		rtn = append(rtn, r)
+	}
+	if failed > 0 && failed == len(results) {
+		return nil, fmt.Errorf("all %d requests failed, first error: %s", failed, results[0].Error)
+	}
+	return rtn, nil
+}
+
+// itemParams returns the params for a single request, with the batch param
+// replaced by one of its values.
+func itemParams(params tools.ParamValues, batchParam string, item any) tools.ParamValues {
+	rtn := make(tools.ParamValues, 0, len(params))
+	for _, p := range params {
+		if p.Name == batchParam {
+			p.Value = item
+		}
+		rtn = append(rtn, p)
+	}
+	return rtn
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.AllParams, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
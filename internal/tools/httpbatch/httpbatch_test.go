@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpbatch_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/httpbatch"
+)
+
+func TestParseFromYamlHTTPBatch(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: http-batch
+					source: my-instance
+					method: GET
+					description: some description
+					path: /users/{{.id}}
+					pathParams:
+						- name: id
+						  type: string
+						  description: user id
+					batchParam: id
+				`,
+			want: server.ToolConfigs{
+				"example_tool": httpbatch.Config{
+					Name:         "example_tool",
+					Kind:         "http-batch",
+					Source:       "my-instance",
+					Method:       "GET",
+					Path:         "/users/{{.id}}",
+					Description:  "some description",
+					AuthRequired: []string{},
+					PathParams:   tools.Parameters{tools.NewStringParameter("id", "user id")},
+					BatchParam:   "id",
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			tools:
+				example_tool:
+					kind: http-batch
+					source: my-instance
+					method: POST
+					description: some description
+					path: /lookup
+					requestBody: |
+						{"sku": "{{.sku}}"}
+					bodyParams:
+						- name: sku
+						  type: string
+						  description: product sku
+					queryParams:
+						- name: region
+						  type: string
+						  description: region to search
+					batchParam: sku
+					maxConcurrency: 10
+					maxBatchSize: 50
+				`,
+			want: server.ToolConfigs{
+				"example_tool": httpbatch.Config{
+					Name:           "example_tool",
+					Kind:           "http-batch",
+					Source:         "my-instance",
+					Method:         "POST",
+					Path:           "/lookup",
+					Description:    "some description",
+					AuthRequired:   []string{},
+					RequestBody:    "{\"sku\": \"{{.sku}}\"}\n",
+					BodyParams:     tools.Parameters{tools.NewStringParameter("sku", "product sku")},
+					QueryParams:    tools.Parameters{tools.NewStringParameter("region", "region to search")},
+					BatchParam:     "sku",
+					MaxConcurrency: 10,
+					MaxBatchSize:   50,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
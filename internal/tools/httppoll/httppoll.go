@@ -0,0 +1,373 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package httppoll
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/telemetry"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	httptool "github.com/googleapis/genai-toolbox/internal/tools/http"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const kind string = "http-poll"
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultTimeout      = 10 * time.Minute
+	// pollJitter is the fraction by which the poll interval is randomized
+	pollJitter = 0.1
+)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+	tools.RegisterOwnTimeout(kind)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Predicate describes a condition on a polled HTTP response. Every condition
+// that is set must hold for the predicate to match.
+type Predicate struct {
+	// StatusCodes matches if the response status code is one of the values.
+	StatusCodes []int `yaml:"statusCodes"`
+	// Header is the name of a response header to inspect.
+	Header string `yaml:"header"`
+	// Field is a dot-separated path into the JSON response body (e.g. "status.phase").
+	Field string `yaml:"field"`
+	// Values matches if the header or field value is one of the values. If
+	// empty, the header or field only has to be present.
+	Values []string `yaml:"values"`
+}
+
+func (p Predicate) validate() error {
+	if len(p.StatusCodes) == 0 && p.Header == "" && p.Field == "" {
+		return fmt.Errorf("predicate must set at least one of `statusCodes`, `header`, or `field`")
+	}
+	if p.Header != "" && p.Field != "" {
+		return fmt.Errorf("predicate cannot set both `header` and `field`")
+	}
+	if len(p.Values) > 0 && p.Header == "" && p.Field == "" {
+		return fmt.Errorf("predicate `values` requires `header` or `field` to be set")
+	}
+	return nil
+}
+
+// matches reports whether the response satisfies the predicate.
+func (p Predicate) matches(statusCode int, header http.Header, body any) bool {
+	if len(p.StatusCodes) > 0 && !slices.Contains(p.StatusCodes, statusCode) {
+		return false
+	}
+	var value string
+	switch {
+	case p.Header != "":
+		vals := header.Values(p.Header)
+		if len(vals) == 0 {
+			return false
+		}
+		value = vals[0]
+	case p.Field != "":
+		v, ok := lookupField(body, p.Field)
+		if !ok || v == nil {
+			return false
+		}
+		value = fmt.Sprintf("%v", v)
+	default:
+		return true
+	}
+	return len(p.Values) == 0 || slices.Contains(p.Values, value)
+}
+
+// lookupField walks a decoded JSON value using a dot-separated path. Numeric
+// path elements index into arrays.
+func lookupField(data any, path string) (any, bool) {
+	cur := data
+	for _, key := range strings.Split(path, ".") {
+		switch v := cur.(type) {
+		case map[string]any:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			cur = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func matchAny(predicates []Predicate, statusCode int, header http.Header, body any) bool {
+	for _, p := range predicates {
+		if p.matches(statusCode, header, body) {
+			return true
+		}
+	}
+	return false
+}
+
+type Config struct {
+	Name              string            `yaml:"name" validate:"required"`
+	Kind              string            `yaml:"kind" validate:"required"`
+	Source            string            `yaml:"source" validate:"required"`
+	Description       string            `yaml:"description" validate:"required"`
+	AuthRequired      []string          `yaml:"authRequired"`
+	Path              string            `yaml:"path" validate:"required"`
+	Method            tools.HTTPMethod  `yaml:"method" validate:"required"`
+	Headers           map[string]string `yaml:"headers"`
+	RequestBody       string            `yaml:"requestBody"`
+	PathParams        tools.Parameters  `yaml:"pathParams"`
+	QueryParams       tools.Parameters  `yaml:"queryParams"`
+	BodyParams        tools.Parameters  `yaml:"bodyParams"`
+	HeaderParams      tools.Parameters  `yaml:"headerParams"`
+	Retry             *retry.Config     `yaml:"retry"`
+	ResponseTransform string            `yaml:"responseTransform"`
+	PollInterval      string            `yaml:"pollInterval"`
+	Timeout           string            `yaml:"timeout"`
+	SuccessWhen       []Predicate       `yaml:"successWhen" validate:"required"`
+	FailureWhen       []Predicate       `yaml:"failureWhen"`
+	RetryWhen         []Predicate       `yaml:"retryWhen"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	for _, predicates := range [][]Predicate{cfg.SuccessWhen, cfg.FailureWhen, cfg.RetryWhen} {
+		for _, p := range predicates {
+			if err := p.validate(); err != nil {
+				return nil, fmt.Errorf("invalid predicate for tool %q: %w", cfg.Name, err)
+			}
+		}
+	}
+
+	pollInterval := defaultPollInterval
+	if cfg.PollInterval != "" {
+		var err error
+		pollInterval, err = time.ParseDuration(cfg.PollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse pollInterval as time.Duration: %w", err)
+		}
+	}
+	timeout := defaultTimeout
+	if cfg.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse timeout as time.Duration: %w", err)
+		}
+	}
+
+	// the poll request is built exactly like an `http` tool request
+	requestCfg := httptool.Config{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Source:       cfg.Source,
+		Description:  cfg.Description,
+		AuthRequired: cfg.AuthRequired,
+		Path:         cfg.Path,
+		Method:       cfg.Method,
+		Headers:      cfg.Headers,
+		RequestBody:  cfg.RequestBody,
+		PathParams:   cfg.PathParams,
+		QueryParams:  cfg.QueryParams,
+		BodyParams:   cfg.BodyParams,
+		HeaderParams: cfg.HeaderParams,
+		Retry:        cfg.Retry,
+		// the transform is only applied to the final result, since
+		// predicates need to see the full response
+		ResponseTransform: cfg.ResponseTransform,
+	}
+	rawT, err := requestCfg.Initialize(srcs)
+	if err != nil {
+		return nil, err
+	}
+	requestTool := rawT.(httptool.Tool)
+
+	return Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		AuthRequired: cfg.AuthRequired,
+		PollInterval: pollInterval,
+		Timeout:      timeout,
+		SuccessWhen:  cfg.SuccessWhen,
+		FailureWhen:  cfg.FailureWhen,
+		RetryWhen:    cfg.RetryWhen,
+		request:      requestTool,
+	}, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+var _ tools.OwnTimeoutTool = Tool{}
+
+type Tool struct {
+	Name         string   `yaml:"name"`
+	Kind         string   `yaml:"kind"`
+	AuthRequired []string `yaml:"authRequired"`
+	PollInterval time.Duration
+	Timeout      time.Duration
+	SuccessWhen  []Predicate `yaml:"successWhen"`
+	FailureWhen  []Predicate `yaml:"failureWhen"`
+	RetryWhen    []Predicate `yaml:"retryWhen"`
+
+	request httptool.Tool
+}
+
+// OwnTimeout returns how long the tool polls before it gives up.
+func (t Tool) OwnTimeout() time.Duration {
+	return t.Timeout
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	logger, err := util.LoggerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	paramsMap := params.AsMap()
+
+	ctx, cancel := context.WithTimeout(ctx, t.Timeout)
+	defer cancel()
+
+	return retry.Poll(ctx, func(ctx context.Context, attempt int) (any, time.Duration, bool, error) {
+		result, delay, done, err := t.poll(ctx, attempt, paramsMap)
+		if !done && err == nil {
+			logger.DebugContext(ctx, fmt.Sprintf("operation not finished after poll attempt %d, polling again in %s", attempt, delay))
+		}
+		return result, delay, done, err
+	})
+}
+
+// poll sends a single poll request. It returns the result and done if the
+// operation reached a terminal state, and otherwise how long to wait before
+// polling again.
+func (t Tool) poll(ctx context.Context, attempt int, paramsMap map[string]any) (any, time.Duration, bool, error) {
+	ctx, span := otel.Tracer(telemetry.TracerName).Start(ctx, "toolbox/tool/http-poll/attempt")
+	span.SetAttributes(
+		attribute.String("tool_name", t.Name),
+		attribute.Int("attempt", attempt),
+	)
+	var err error
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	resp, err := t.request.RetryPolicy.Do(ctx, t.request.Client, func() (*http.Request, error) {
+		return t.request.BuildRequest(ctx, paramsMap)
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("polling timed out after %d attempt(s): %w", attempt, ctx.Err())
+			return nil, 0, false, err
+		}
+		err = fmt.Errorf("error making HTTP request: %s", err)
+		return nil, 0, false, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, 0, false, err
+	}
+	span.SetAttributes(attribute.Int("status_code", resp.StatusCode))
+
+	util.ReportProgress(ctx, float64(attempt), 0, fmt.Sprintf("poll attempt %d returned status code %d", attempt, resp.StatusCode))
+
+	var result any = string(body)
+	var data any
+	if jsonErr := json.Unmarshal(body, &data); jsonErr == nil {
+		result = data
+	}
+
+	switch {
+	case matchAny(t.FailureWhen, resp.StatusCode, resp.Header, data):
+		span.SetAttributes(attribute.String("outcome", "failure"))
+		err = fmt.Errorf("operation failed with status code: %d, response body: %s", resp.StatusCode, string(body))
+		return nil, 0, false, err
+	case matchAny(t.SuccessWhen, resp.StatusCode, resp.Header, data):
+		span.SetAttributes(attribute.String("outcome", "success"))
+		if data != nil {
+			result, err = t.request.TransformResponse(data)
+		}
+		return result, 0, true, err
+	case len(t.RetryWhen) == 0 || matchAny(t.RetryWhen, resp.StatusCode, resp.Header, data):
+		// not in a terminal state yet, poll again
+	default:
+		span.SetAttributes(attribute.String("outcome", "unexpected"))
+		err = fmt.Errorf("unexpected response with status code: %d, response body: %s", resp.StatusCode, string(body))
+		return nil, 0, false, err
+	}
+
+	// wait longer if the server asked for it, e.g. when rate limited
+	delay := retry.Jitter(t.PollInterval, pollJitter)
+	if retryAfter, ok := retry.RetryAfter(resp.Header, time.Now()); ok {
+		delay = max(delay, retryAfter)
+	}
+	span.SetAttributes(
+		attribute.String("outcome", "retry"),
+		attribute.String("delay", delay.String()),
+	)
+	return nil, delay, false, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return t.request.ParseParams(data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.request.Manifest()
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.request.McpManifest()
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
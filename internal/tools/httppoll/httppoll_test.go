@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httppoll_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/httppoll"
+)
+
+func TestParseFromYamlHTTPPoll(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: http-poll
+					source: my-instance
+					method: GET
+					description: some description
+					path: /jobs/{{.id}}
+					pathParams:
+						- name: id
+						  type: string
+						  description: job id
+					successWhen:
+						- field: status.phase
+						  values: ["Succeeded"]
+				`,
+			want: server.ToolConfigs{
+				"example_tool": httppoll.Config{
+					Name:         "example_tool",
+					Kind:         "http-poll",
+					Source:       "my-instance",
+					Method:       "GET",
+					Path:         "/jobs/{{.id}}",
+					Description:  "some description",
+					AuthRequired: []string{},
+					PathParams:   tools.Parameters{tools.NewStringParameter("id", "job id")},
+					SuccessWhen:  []httppoll.Predicate{{Field: "status.phase", Values: []string{"Succeeded"}}},
+				},
+			},
+		},
+		{
+			desc: "predicate matrix",
+			in: `
+			tools:
+				example_tool:
+					kind: http-poll
+					source: my-instance
+					method: POST
+					description: some description
+					path: /graphql
+					pollInterval: 2s
+					timeout: 5m
+					requestBody: |
+						{"query": "{ pipeline(id: \"{{.id}}\") { state } }"}
+					bodyParams:
+						- name: id
+						  type: string
+						  description: pipeline id
+					successWhen:
+						- statusCodes: [200]
+						  field: data.pipeline.state
+						  values: ["PASSED"]
+					failureWhen:
+						- field: data.pipeline.state
+						  values: ["FAILED", "CANCELED"]
+						- statusCodes: [404]
+					retryWhen:
+						- statusCodes: [200, 429, 503]
+						- header: Retry-After
+				`,
+			want: server.ToolConfigs{
+				"example_tool": httppoll.Config{
+					Name:         "example_tool",
+					Kind:         "http-poll",
+					Source:       "my-instance",
+					Method:       "POST",
+					Path:         "/graphql",
+					Description:  "some description",
+					AuthRequired: []string{},
+					PollInterval: "2s",
+					Timeout:      "5m",
+					RequestBody:  "{\"query\": \"{ pipeline(id: \\\"{{.id}}\\\") { state } }\"}\n",
+					BodyParams:   tools.Parameters{tools.NewStringParameter("id", "pipeline id")},
+					SuccessWhen: []httppoll.Predicate{
+						{StatusCodes: []int{200}, Field: "data.pipeline.state", Values: []string{"PASSED"}},
+					},
+					FailureWhen: []httppoll.Predicate{
+						{Field: "data.pipeline.state", Values: []string{"FAILED", "CANCELED"}},
+						{StatusCodes: []int{404}},
+					},
+					RetryWhen: []httppoll.Predicate{
+						{StatusCodes: []int{200, 429, 503}},
+						{Header: "Retry-After"},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
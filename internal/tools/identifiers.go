@@ -0,0 +1,173 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultIdentifierPattern matches unquoted SQL identifiers, which may be
+// qualified, e.g. orders or public.orders.
+const DefaultIdentifierPattern = `[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*`
+
+const (
+	// CatalogTable requires an identifier to be the name of a table or view,
+	// which may be qualified by its schema.
+	CatalogTable = "table"
+	// CatalogColumn requires an identifier to be the name of a column.
+	CatalogColumn = "column"
+)
+
+// Identifier restricts the values of a string parameter to SQL identifiers,
+// so that template parameters can safely substitute them into the text of a
+// statement.
+type Identifier struct {
+	// Pattern is the regular expression that values must fully match.
+	// Defaults to DefaultIdentifierPattern.
+	Pattern string `yaml:"pattern"`
+	// Catalog is the kind of object that values must name in the catalog of
+	// the database, if set.
+	Catalog string `yaml:"catalog"`
+}
+
+// validate returns an error if the pattern or the catalog isn't valid.
+func (i *Identifier) validate() error {
+	if i.Catalog != "" && i.Catalog != CatalogTable && i.Catalog != CatalogColumn {
+		return fmt.Errorf("invalid identifier catalog %q: must be %q or %q", i.Catalog, CatalogTable, CatalogColumn)
+	}
+	_, err := i.pattern()
+	return err
+}
+
+func (i *Identifier) pattern() (*regexp.Regexp, error) {
+	pattern := i.Pattern
+	if pattern == "" {
+		pattern = DefaultIdentifierPattern
+	}
+	re, err := compilePattern(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identifier pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// check returns an error if v isn't an identifier.
+func (i *Identifier) check(v string) error {
+	re, err := i.pattern()
+	if err != nil {
+		return err
+	}
+	if !re.MatchString(v) {
+		return fmt.Errorf("%q is not a valid identifier", v)
+	}
+	return nil
+}
+
+// CatalogIdentifier is the value of a parameter that must name an object of
+// the catalog of the database.
+type CatalogIdentifier struct {
+	// Catalog is the kind of object, CatalogTable or CatalogColumn.
+	Catalog string
+	// Name is the name of the object, e.g. public.orders.
+	Name string
+}
+
+// Parts splits the name into its qualifier, the schema of a table or the
+// table of a column, which is empty for an unqualified name, and the name of
+// the object.
+func (c CatalogIdentifier) Parts() (qualifier, name string) {
+	if i := strings.LastIndex(c.Name, "."); i >= 0 {
+		return c.Name[:i], c.Name[i+1:]
+	}
+	return "", c.Name
+}
+
+// CatalogIdentifiers returns the values of the parameters, or of the items of
+// array parameters, that must be found in the catalog of the database.
+func CatalogIdentifiers(params Parameters, paramsMap map[string]any) []CatalogIdentifier {
+	var ids []CatalogIdentifier
+	for _, p := range params {
+		switch p := p.(type) {
+		case *StringParameter:
+			if v, ok := paramsMap[p.Name].(string); ok && p.Identifier != nil && p.Identifier.Catalog != "" {
+				ids = append(ids, CatalogIdentifier{Catalog: p.Identifier.Catalog, Name: v})
+			}
+		case *ArrayParameter:
+			items, ok := p.Items.(*StringParameter)
+			if !ok || items.Identifier == nil || items.Identifier.Catalog == "" {
+				continue
+			}
+			values, _ := paramsMap[p.Name].([]any)
+			for _, v := range values {
+				if v, ok := v.(string); ok {
+					ids = append(ids, CatalogIdentifier{Catalog: items.Identifier.Catalog, Name: v})
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// CatalogChecker is implemented by the configs of the tools that look up the
+// values of some of their parameters in the catalog of their database, see
+// CatalogIdentifiers. The catalog of an identifier is rejected for the other
+// parameters and tools, which couldn't check it.
+type CatalogChecker interface {
+	ToolConfig
+	// CatalogParameters returns the parameters whose identifiers are looked
+	// up in the catalog.
+	CatalogParameters() Parameters
+}
+
+// UncheckedCatalogParameters returns the names of the parameters whose
+// values, or the values of their items or properties, must name an object of
+// the catalog, except for the parameters of checked whose values
+// CatalogIdentifiers returns.
+func UncheckedCatalogParameters(params, checked Parameters) []string {
+	ok := make(map[Parameter]bool)
+	for _, p := range checked {
+		switch p := p.(type) {
+		case *StringParameter:
+			ok[p] = true
+		case *ArrayParameter:
+			_, ok[p] = p.Items.(*StringParameter)
+		}
+	}
+	var names []string
+	for _, p := range params {
+		if hasCatalog(p) && !ok[p] {
+			names = append(names, p.GetName())
+		}
+	}
+	return names
+}
+
+func hasCatalog(p Parameter) bool {
+	switch p := p.(type) {
+	case *StringParameter:
+		return p.Identifier != nil && p.Identifier.Catalog != ""
+	case *ArrayParameter:
+		return p.Items != nil && hasCatalog(p.Items)
+	case *ObjectParameter:
+		for _, prop := range p.Properties {
+			if hasCatalog(prop) {
+				return true
+			}
+		}
+	}
+	return false
+}
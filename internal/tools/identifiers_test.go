@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestCatalogIdentifiers(t *testing.T) {
+	params := tools.Parameters{
+		tools.NewStringParameterWithIdentifier("table", "a table", &tools.Identifier{Catalog: tools.CatalogTable}),
+		tools.NewStringParameterWithIdentifier("alias", "an alias", &tools.Identifier{}),
+		tools.NewStringParameter("filter", "a filter"),
+		tools.NewArrayParameter("columns", "some columns",
+			tools.NewStringParameterWithIdentifier("column", "a column", &tools.Identifier{Catalog: tools.CatalogColumn})),
+	}
+	paramsMap := map[string]any{
+		"table":   "public.orders",
+		"alias":   "o",
+		"filter":  "status",
+		"columns": []any{"id", "status"},
+	}
+	want := []tools.CatalogIdentifier{
+		{Catalog: tools.CatalogTable, Name: "public.orders"},
+		{Catalog: tools.CatalogColumn, Name: "id"},
+		{Catalog: tools.CatalogColumn, Name: "status"},
+	}
+	got := tools.CatalogIdentifiers(params, paramsMap)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect identifiers: diff %v", diff)
+	}
+}
+
+func TestUncheckedCatalogParameters(t *testing.T) {
+	table := tools.NewStringParameterWithIdentifier("table", "a table", &tools.Identifier{Catalog: tools.CatalogTable})
+	columns := tools.NewArrayParameter("columns", "some columns",
+		tools.NewStringParameterWithIdentifier("column", "a column", &tools.Identifier{Catalog: tools.CatalogColumn}))
+	sort := tools.NewObjectParameter("sort", "a sort order", tools.Parameters{
+		tools.NewStringParameterWithIdentifier("column", "a column", &tools.Identifier{Catalog: tools.CatalogColumn}),
+	})
+	params := tools.Parameters{
+		table,
+		tools.NewStringParameterWithIdentifier("alias", "an alias", &tools.Identifier{}),
+		tools.NewStringParameter("filter", "a filter"),
+		columns,
+		sort,
+	}
+	tcs := []struct {
+		desc    string
+		checked tools.Parameters
+		want    []string
+	}{
+		{desc: "nothing checked", want: []string{"table", "columns", "sort"}},
+		{desc: "all checked", checked: params, want: []string{"sort"}},
+		{desc: "some checked", checked: tools.Parameters{columns}, want: []string{"table", "sort"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tools.UncheckedCatalogParameters(params, tc.checked)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parameters: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestCatalogIdentifierParts(t *testing.T) {
+	tcs := []struct {
+		name          string
+		wantQualifier string
+		wantName      string
+	}{
+		{name: "orders", wantQualifier: "", wantName: "orders"},
+		{name: "public.orders", wantQualifier: "public", wantName: "orders"},
+		{name: "db.public.orders", wantQualifier: "db.public", wantName: "orders"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			qualifier, name := tools.CatalogIdentifier{Catalog: tools.CatalogTable, Name: tc.name}.Parts()
+			if qualifier != tc.wantQualifier || name != tc.wantName {
+				t.Fatalf("incorrect parts: got (%q, %q), want (%q, %q)", qualifier, name, tc.wantQualifier, tc.wantName)
+			}
+		})
+	}
+}
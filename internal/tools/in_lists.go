@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+)
+
+// RewriteArrayInLists rewrites the IN lists of the array parameters of a
+// statement to compare with each element of the array, e.g. IN ($1) to
+// = ANY($1) for PostgreSQL, or IN (@ids) to IN UNNEST(@ids) for GoogleSQL.
+// Parameters are referenced by position in PostgreSQL, and by name in
+// GoogleSQL.
+func RewriteArrayInLists(statement string, params Parameters, dialect sqlparse.Dialect) (string, error) {
+	if !slices.ContainsFunc(params, isArrayParameter) {
+		return statement, nil
+	}
+	switch dialect {
+	case sqlparse.Postgres:
+		return sqlparse.RewritePlaceholders(statement, dialect, func(p sqlparse.Placeholder) (string, error) {
+			i, err := strconv.Atoi(strings.TrimPrefix(p.Value, "$"))
+			if !p.InList || err != nil || i < 1 || i > len(params) || !isArrayParameter(params[i-1]) {
+				return p.Text, nil
+			}
+			if p.Not {
+				return "<> ALL(" + p.Value + ")", nil
+			}
+			return "= ANY(" + p.Value + ")", nil
+		})
+	case sqlparse.GoogleSQL:
+		return sqlparse.RewritePlaceholders(statement, dialect, func(p sqlparse.Placeholder) (string, error) {
+			name := strings.TrimPrefix(p.Value, "@")
+			if !p.InList || !slices.ContainsFunc(params, func(param Parameter) bool { return param.GetName() == name && isArrayParameter(param) }) {
+				return p.Text, nil
+			}
+			if p.Not {
+				return "NOT IN UNNEST(" + p.Value + ")", nil
+			}
+			return "IN UNNEST(" + p.Value + ")", nil
+		})
+	default:
+		return "", fmt.Errorf("array parameters in IN lists aren't supported for this dialect")
+	}
+}
+
+func isArrayParameter(p Parameter) bool {
+	_, ok := p.(*ArrayParameter)
+	return ok
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+)
+
+func TestRewriteArrayInLists(t *testing.T) {
+	params := tools.Parameters{
+		tools.NewArrayParameter("ids", "some description", tools.NewIntParameter("id", "some description")),
+		tools.NewStringParameter("name", "some description"),
+		tools.NewArrayParameter("names", "some description", tools.NewStringParameter("name", "some description")),
+	}
+	tcs := []struct {
+		desc      string
+		statement string
+		params    tools.Parameters
+		dialect   sqlparse.Dialect
+		want      string
+	}{
+		{
+			desc:      "postgres",
+			statement: "SELECT * FROM t WHERE id IN ($1) AND name = $2 AND name NOT IN ($3)",
+			params:    params,
+			dialect:   sqlparse.Postgres,
+			want:      "SELECT * FROM t WHERE id = ANY($1) AND name = $2 AND name <> ALL($3)",
+		},
+		{
+			desc:      "postgres any",
+			statement: "SELECT * FROM t WHERE id = ANY($1) AND name IN ($2)",
+			params:    params,
+			dialect:   sqlparse.Postgres,
+			want:      "SELECT * FROM t WHERE id = ANY($1) AND name IN ($2)",
+		},
+		{
+			desc:      "googlesql",
+			statement: "SELECT * FROM t WHERE id IN (@ids) AND name IN (@name) AND name NOT IN (@names)",
+			params:    params,
+			dialect:   sqlparse.GoogleSQL,
+			want:      "SELECT * FROM t WHERE id IN UNNEST(@ids) AND name IN (@name) AND name NOT IN UNNEST(@names)",
+		},
+		{
+			desc:      "googlesql unnest",
+			statement: "SELECT * FROM t WHERE id IN UNNEST(@ids)",
+			params:    params,
+			dialect:   sqlparse.GoogleSQL,
+			want:      "SELECT * FROM t WHERE id IN UNNEST(@ids)",
+		},
+		{
+			desc:      "no array parameters",
+			statement: "SELECT * FROM t WHERE name IN (?)",
+			params:    tools.Parameters{tools.NewStringParameter("name", "some description")},
+			dialect:   sqlparse.MySQL,
+			want:      "SELECT * FROM t WHERE name IN (?)",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tools.RewriteArrayInLists(tc.statement, tc.params, tc.dialect)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected statement: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluxquery_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/influxdb/fluxquery"
+)
+
+func TestParseFromYamlFluxQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: flux-query
+					source: my-influxdb-instance
+					description: some description
+					statement: |
+						from(bucket: "telegraf") |> range(start: params.start)
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: start
+						  type: string
+						  description: some description
+					templateParameters:
+						- name: measurement
+						  type: string
+						  description: The measurement to query.
+			`,
+			want: server.ToolConfigs{
+				"example_tool": fluxquery.Config{
+					Name:         "example_tool",
+					Kind:         "flux-query",
+					Source:       "my-influxdb-instance",
+					Description:  "some description",
+					Statement:    "from(bucket: \"telegraf\") |> range(start: params.start)\n",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("start", "some description"),
+					},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameter("measurement", "The measurement to query."),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// fakeSource records the query it runs.
+type fakeSource struct {
+	query string
+}
+
+func (s *fakeSource) SourceKind() string {
+	return "influxdb"
+}
+
+func (s *fakeSource) RunFlux(ctx context.Context, query string) ([]any, error) {
+	s.query = query
+	return []any{map[string]any{"_value": 1.5}}, nil
+}
+
+func TestInvokeFluxQuery(t *testing.T) {
+	tcs := []struct {
+		desc      string
+		statement string
+		params    tools.ParamValues
+		want      string
+	}{
+		{
+			desc:      "no parameters",
+			statement: "from(bucket: \"telegraf\") |> range(start: -1h)",
+			want:      "from(bucket: \"telegraf\") |> range(start: -1h)",
+		},
+		{
+			desc:      "time range parameters",
+			statement: "from(bucket: \"telegraf\")\n  |> range(start: params.start, stop: params.stop)\n",
+			params: tools.ParamValues{
+				{Name: "start", Value: "2025-01-01T00:00:00Z"},
+				{Name: "stop", Value: "-30m"},
+			},
+			want: "params = {start: 2025-01-01T00:00:00Z, stop: -30m}\nfrom(bucket: \"telegraf\")\n  |> range(start: params.start, stop: params.stop)\n",
+		},
+		{
+			desc:      "after imports",
+			statement: "// usage of the hosts\nimport \"strings\"\nimport \"math\"\n\nfrom(bucket: \"telegraf\")",
+			params: tools.ParamValues{
+				{Name: "host", Value: `web "1" ${x}`},
+				{Name: "threshold", Value: float64(2)},
+				{Name: "limit", Value: 10},
+				{Name: "hosts", Value: []any{"a", "b"}},
+				{Name: "exact", Value: true},
+			},
+			want: "// usage of the hosts\nimport \"strings\"\nimport \"math\"\nparams = {host: \"web \\\"1\\\" \\${x}\", threshold: 2.0, limit: 10, hosts: [\"a\", \"b\"], exact: true}\n\nfrom(bucket: \"telegraf\")",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var parameters tools.Parameters
+			for _, p := range tc.params {
+				parameters = append(parameters, tools.NewStringParameter(p.Name, "some description"))
+			}
+			src := &fakeSource{}
+			cfg := fluxquery.Config{
+				Name:        "example_tool",
+				Kind:        "flux-query",
+				Source:      "my-influxdb-instance",
+				Description: "some description",
+				Statement:   tc.statement,
+				Parameters:  parameters,
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-influxdb-instance": src})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			if _, err := tool.Invoke(context.Background(), tc.params); err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, src.query); diff != "" {
+				t.Fatalf("incorrect query: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxqlquery_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/influxdb/influxqlquery"
+)
+
+func TestParseFromYamlInfluxQLQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: influxql-query
+					source: my-influxdb-instance
+					description: some description
+					statement: |
+						SELECT mean(usage) FROM cpu WHERE time > $start GROUP BY host
+					parameters:
+						- name: start
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": influxqlquery.Config{
+					Name:         "example_tool",
+					Kind:         "influxql-query",
+					Source:       "my-influxdb-instance",
+					Description:  "some description",
+					Statement:    "SELECT mean(usage) FROM cpu WHERE time > $start GROUP BY host\n",
+					AuthRequired: []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("start", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// fakeSource records the query it runs.
+type fakeSource struct {
+	query  string
+	params map[string]any
+}
+
+func (s *fakeSource) SourceKind() string {
+	return "influxdb"
+}
+
+func (s *fakeSource) RunInfluxQL(ctx context.Context, query string, params map[string]any) ([]any, error) {
+	s.query = query
+	s.params = params
+	return []any{map[string]any{"mean": 1.5}}, nil
+}
+
+func TestInvokeInfluxQLQuery(t *testing.T) {
+	src := &fakeSource{}
+	cfg := influxqlquery.Config{
+		Name:        "example_tool",
+		Kind:        "influxql-query",
+		Source:      "my-influxdb-instance",
+		Description: "some description",
+		Statement:   "SELECT mean(usage) FROM {{.measurement}} WHERE time > $start AND host = $host",
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("start", "some description"),
+			tools.NewStringParameter("host", "some description"),
+		},
+		TemplateParameters: tools.Parameters{
+			tools.NewStringParameter("measurement", "some description"),
+		},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-influxdb-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"start": "2025-01-01T00:00:00Z", "host": "web-1", "measurement": "cpu"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	if diff := cmp.Diff([]any{map[string]any{"mean": 1.5}}, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	if want := "SELECT mean(usage) FROM cpu WHERE time > $start AND host = $host"; src.query != want {
+		t.Fatalf("incorrect query: got %q, want %q", src.query, want)
+	}
+	if diff := cmp.Diff(map[string]any{"start": "2025-01-01T00:00:00Z", "host": "web-1"}, src.params); diff != "" {
+		t.Fatalf("incorrect params: diff %v", diff)
+	}
+}
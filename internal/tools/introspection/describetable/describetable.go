@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describetable
+
+import (
+	"context"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/introspection/introspectioncommon"
+)
+
+const kind string = "describe-table"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// queries describe the columns of a table or view. A table without a schema
+// is looked up in the current schema.
+var queries = introspectioncommon.Queries{
+	introspectioncommon.Postgres: `SELECT c.column_name, c.data_type, c.is_nullable, c.column_default, c.ordinal_position,
+		EXISTS (SELECT 1 FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage k ON k.constraint_schema = tc.constraint_schema AND k.constraint_name = tc.constraint_name
+			WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = c.table_schema AND tc.table_name = c.table_name
+			AND k.table_name = c.table_name AND k.column_name = c.column_name) AS is_primary_key
+		FROM information_schema.columns c
+		WHERE c.table_schema = COALESCE(NULLIF({schema}::text, ''), current_schema()) AND c.table_name = {table}::text
+		ORDER BY c.ordinal_position`,
+	introspectioncommon.DuckDB: `SELECT c.column_name, c.data_type, c.is_nullable, c.column_default, c.ordinal_position,
+		EXISTS (SELECT 1 FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage k ON k.constraint_schema = tc.constraint_schema AND k.constraint_name = tc.constraint_name
+			WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = c.table_schema AND tc.table_name = c.table_name
+			AND k.table_name = c.table_name AND k.column_name = c.column_name) AS is_primary_key
+		FROM information_schema.columns c
+		WHERE c.table_schema = COALESCE(NULLIF({schema}::text, ''), current_schema()) AND c.table_name = {table}::text
+		ORDER BY c.ordinal_position`,
+	introspectioncommon.MySQL: `SELECT column_name AS column_name, column_type AS data_type, is_nullable AS is_nullable, column_default AS column_default, ordinal_position AS ordinal_position,
+		column_key = 'PRI' AS is_primary_key
+		FROM information_schema.columns
+		WHERE table_schema = COALESCE(NULLIF({schema}, ''), DATABASE()) AND table_name = {table}
+		ORDER BY ordinal_position`,
+	introspectioncommon.MSSQL: `SELECT c.COLUMN_NAME AS column_name, c.DATA_TYPE AS data_type, c.IS_NULLABLE AS is_nullable, c.COLUMN_DEFAULT AS column_default, c.ORDINAL_POSITION AS ordinal_position,
+		CAST(CASE WHEN EXISTS (SELECT 1 FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc
+			JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE k ON k.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND k.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+			WHERE tc.CONSTRAINT_TYPE = 'PRIMARY KEY' AND tc.TABLE_SCHEMA = c.TABLE_SCHEMA AND tc.TABLE_NAME = c.TABLE_NAME
+			AND k.TABLE_NAME = c.TABLE_NAME AND k.COLUMN_NAME = c.COLUMN_NAME) THEN 1 ELSE 0 END AS bit) AS is_primary_key
+		FROM INFORMATION_SCHEMA.COLUMNS c
+		WHERE c.TABLE_SCHEMA = COALESCE(NULLIF({schema}, ''), SCHEMA_NAME()) AND c.TABLE_NAME = {table}
+		ORDER BY c.ORDINAL_POSITION`,
+	introspectioncommon.SQLite: `SELECT name AS column_name, type AS data_type, CASE "notnull" WHEN 1 THEN 'NO' ELSE 'YES' END AS is_nullable, dflt_value AS column_default, cid + 1 AS ordinal_position,
+		pk > 0 AS is_primary_key
+		FROM pragma_table_info({table})
+		WHERE {schema} = '' OR {schema} = 'main'
+		ORDER BY cid`,
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	db, err := introspectioncommon.NewDB(kind, rawS)
+	if err != nil {
+		return nil, err
+	}
+
+	tableParameter := tools.NewStringParameter("table", "Name of the table or view to describe.")
+	schemaParameter := tools.NewStringParameterWithDefault("schema", "", "Schema of the table. The table is looked up in the current schema if it's empty.")
+	parameters := tools.Parameters{tableParameter, schemaParameter}
+
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		AuthRequired: cfg.AuthRequired,
+		Parameters:   parameters,
+		DB:           db,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: parameters.McpManifest(),
+		},
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	DB          introspectioncommon.DB
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke returns the columns of the table, in order.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	columns, err := t.DB.Query(ctx, queries[t.DB.Dialect], paramsMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %q not found", paramsMap["table"])
+	}
+	for _, c := range columns {
+		c := c.(map[string]any)
+		// MySQL and SQLite return booleans as integers
+		if n, ok := c["is_primary_key"].(int64); ok {
+			c["is_primary_key"] = n != 0
+		}
+	}
+	return columns, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package introspectioncommon holds the parts of the schema introspection
+// tools that are shared by all of them: the dialect of their source, and the
+// queries of its catalog.
+package introspectioncommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresynapse"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/duckdb"
+	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Dialect is the SQL dialect of a source, which decides how its catalog is
+// queried.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	MySQL    Dialect = "mysql"
+	MSSQL    Dialect = "mssql"
+	SQLite   Dialect = "sqlite"
+	DuckDB   Dialect = "duckdb"
+)
+
+type postgresSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+type mysqlSource interface {
+	MySQLPool() *sql.DB
+}
+
+type mssqlSource interface {
+	MSSQLDB() *sql.DB
+}
+
+type sqliteSource interface {
+	SQLiteDB() *sql.DB
+}
+
+type duckdbSource interface {
+	DuckDB() *sql.DB
+}
+
+// validate compatible sources are still compatible
+var _ postgresSource = &alloydbpg.Source{}
+var _ postgresSource = &cloudsqlpg.Source{}
+var _ postgresSource = &postgres.Source{}
+var _ mysqlSource = &cloudsqlmysql.Source{}
+var _ mysqlSource = &mysql.Source{}
+var _ mssqlSource = &cloudsqlmssql.Source{}
+var _ mssqlSource = &mssql.Source{}
+var _ mssqlSource = &azuresynapse.Source{}
+var _ sqliteSource = &sqlite.Source{}
+var _ duckdbSource = &duckdb.Source{}
+
+var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind, cloudsqlmysql.SourceKind, mysql.SourceKind, cloudsqlmssql.SourceKind, mssql.SourceKind, azuresynapse.SourceKind, sqlite.SourceKind, duckdb.SourceKind}
+
+// DB is the database of a source, along with its dialect.
+type DB struct {
+	Dialect Dialect
+	// Pool is the pool of PostgreSQL sources, and DB the database of the
+	// others.
+	Pool *pgxpool.Pool
+	DB   *sql.DB
+}
+
+// NewDB returns the database of a source, or an error if the source of a tool
+// of the kind isn't a SQL source.
+func NewDB(kind string, rawS sources.Source) (DB, error) {
+	switch s := rawS.(type) {
+	case postgresSource:
+		return DB{Dialect: Postgres, Pool: s.PostgresPool()}, nil
+	case mysqlSource:
+		return DB{Dialect: MySQL, DB: s.MySQLPool()}, nil
+	case mssqlSource:
+		return DB{Dialect: MSSQL, DB: s.MSSQLDB()}, nil
+	case sqliteSource:
+		return DB{Dialect: SQLite, DB: s.SQLiteDB()}, nil
+	case duckdbSource:
+		return DB{Dialect: DuckDB, DB: s.DuckDB()}, nil
+	}
+	return DB{}, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+}
+
+// argument matches the arguments of queries, e.g. {schema}.
+var argument = regexp.MustCompile(`\{(\w+)\}`)
+
+// bind replaces the arguments of a query with the placeholders of the
+// dialect, and returns the values to bind to them, in order. An argument may
+// appear several times.
+func bind(query string, dialect Dialect, args map[string]any) (string, []any, error) {
+	var values []any
+	var err error
+	query = argument.ReplaceAllStringFunc(query, func(m string) string {
+		name := m[1 : len(m)-1]
+		v, ok := args[name]
+		if !ok {
+			err = fmt.Errorf("missing value of query argument %q", name)
+		}
+		values = append(values, v)
+		switch dialect {
+		case Postgres:
+			return fmt.Sprintf("$%d", len(values))
+		case MSSQL:
+			return fmt.Sprintf("@p%d", len(values))
+		}
+		return "?"
+	})
+	return query, values, err
+}
+
+// Query runs a query of the catalog, whose arguments, e.g. {schema}, are bound
+// to the values of args, and returns its rows.
+func (db DB) Query(ctx context.Context, query string, args map[string]any) ([]any, error) {
+	query, values, err := bind(query, db.Dialect, args)
+	if err != nil {
+		return nil, err
+	}
+	if db.Pool != nil {
+		return queryPostgres(ctx, db.Pool, query, values)
+	}
+	return querySQL(ctx, db.DB, query, values)
+}
+
+func queryPostgres(ctx context.Context, pool *pgxpool.Pool, query string, args []any) ([]any, error) {
+	results, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer results.Close()
+
+	fields := results.FieldDescriptions()
+	out := []any{}
+	for results.Next() {
+		v, err := results.Values()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		vMap := make(map[string]any)
+		for i, f := range fields {
+			vMap[f.Name] = v[i]
+		}
+		out = append(out, vMap)
+	}
+	if err := results.Err(); err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	return out, nil
+}
+
+func querySQL(ctx context.Context, db *sql.DB, query string, args []any) ([]any, error) {
+	results, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	defer results.Close()
+
+	cols, err := results.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+	}
+	colTypes, err := results.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get column types: %w", err)
+	}
+
+	// create an array of values for each column, which can be re-used to scan each row
+	rawValues := make([]any, len(cols))
+	values := make([]any, len(cols))
+	for i := range rawValues {
+		values[i] = &rawValues[i]
+	}
+	out := []any{}
+	for results.Next() {
+		if err := results.Scan(values...); err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		vMap := make(map[string]any)
+		for i, name := range cols {
+			val := rawValues[i]
+			// some drivers, e.g. the mysql driver, return the values of text
+			// columns as []byte, which must be cast back to strings
+			if b, ok := val.([]byte); ok && isText(colTypes[i].DatabaseTypeName()) {
+				val = string(b)
+			}
+			vMap[name] = val
+		}
+		out = append(out, vMap)
+	}
+	if err := results.Err(); err != nil {
+		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
+	}
+	return out, nil
+}
+
+func isText(typeName string) bool {
+	typeName = strings.ToUpper(typeName)
+	return strings.Contains(typeName, "CHAR") || strings.Contains(typeName, "TEXT") || typeName == "ENUM" || typeName == "SET"
+}
+
+// QuoteIdentifier quotes an identifier in the dialect, so that it can be part
+// of the text of a query whatever its characters.
+func (db DB) QuoteIdentifier(name string) string {
+	switch db.Dialect {
+	case MySQL:
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	case MSSQL:
+		return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Queries are the queries of a tool, by dialect. Their arguments are written
+// as {name}.
+type Queries map[Dialect]string
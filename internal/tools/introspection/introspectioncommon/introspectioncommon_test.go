@@ -0,0 +1,53 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspectioncommon_test
+
+import (
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/tools/introspection/introspectioncommon"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	tcs := []struct {
+		dialect introspectioncommon.Dialect
+		want    string
+	}{
+		{dialect: introspectioncommon.Postgres, want: `"my ""odd"" ` + "`table`" + ` [1]"`},
+		{dialect: introspectioncommon.SQLite, want: `"my ""odd"" ` + "`table`" + ` [1]"`},
+		{dialect: introspectioncommon.MySQL, want: "`my \"odd\" ``table`` [1]`"},
+		{dialect: introspectioncommon.MSSQL, want: "[my \"odd\" `table` [1]]]"},
+	}
+	for _, tc := range tcs {
+		t.Run(string(tc.dialect), func(t *testing.T) {
+			db := introspectioncommon.DB{Dialect: tc.dialect}
+			if got := db.QuoteIdentifier("my \"odd\" `table` [1]"); got != tc.want {
+				t.Fatalf("incorrect identifier: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+type fakeSource struct{}
+
+func (fakeSource) SourceKind() string {
+	return "http"
+}
+
+func TestNewDBIncompatibleSource(t *testing.T) {
+	if _, err := introspectioncommon.NewDB("list-tables", fakeSource{}); err == nil {
+		t.Fatalf("expected an error for a source that isn't a SQL source")
+	}
+}
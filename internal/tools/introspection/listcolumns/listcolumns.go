@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listcolumns
+
+import (
+	"context"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/introspection/introspectioncommon"
+)
+
+const kind string = "list-columns"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// queries list the columns of the tables and views of the schemas that aren't
+// part of the catalog, optionally of one schema or table.
+var queries = introspectioncommon.Queries{
+	introspectioncommon.Postgres: `SELECT table_schema, table_name, column_name, data_type, is_nullable, ordinal_position FROM information_schema.columns
+		WHERE table_schema NOT IN ('pg_catalog', 'information_schema') AND table_schema NOT LIKE 'pg\_%'
+		AND ({schema}::text = '' OR table_schema = {schema}::text) AND ({table}::text = '' OR table_name = {table}::text)
+		ORDER BY table_schema, table_name, ordinal_position`,
+	introspectioncommon.DuckDB: `SELECT table_schema, table_name, column_name, data_type, is_nullable, ordinal_position FROM information_schema.columns
+		WHERE table_schema NOT IN ('pg_catalog', 'information_schema')
+		AND ({schema}::text = '' OR table_schema = {schema}::text) AND ({table}::text = '' OR table_name = {table}::text)
+		ORDER BY table_schema, table_name, ordinal_position`,
+	introspectioncommon.MySQL: `SELECT table_schema AS table_schema, table_name AS table_name, column_name AS column_name, data_type AS data_type, is_nullable AS is_nullable, ordinal_position AS ordinal_position FROM information_schema.columns
+		WHERE table_schema NOT IN ('mysql', 'information_schema', 'performance_schema', 'sys')
+		AND ({schema} = '' OR table_schema = {schema}) AND ({table} = '' OR table_name = {table})
+		ORDER BY table_schema, table_name, ordinal_position`,
+	introspectioncommon.MSSQL: `SELECT TABLE_SCHEMA AS table_schema, TABLE_NAME AS table_name, COLUMN_NAME AS column_name, DATA_TYPE AS data_type, IS_NULLABLE AS is_nullable, ORDINAL_POSITION AS ordinal_position FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE ({schema} = '' OR TABLE_SCHEMA = {schema}) AND ({table} = '' OR TABLE_NAME = {table})
+		ORDER BY TABLE_SCHEMA, TABLE_NAME, ORDINAL_POSITION`,
+	introspectioncommon.SQLite: `SELECT 'main' AS table_schema, m.name AS table_name, p.name AS column_name, p.type AS data_type, CASE p."notnull" WHEN 1 THEN 'NO' ELSE 'YES' END AS is_nullable, p.cid + 1 AS ordinal_position
+		FROM sqlite_master m JOIN pragma_table_info(m.name) p
+		WHERE m.type IN ('table', 'view') AND m.name NOT LIKE 'sqlite\_%' ESCAPE '\'
+		AND ({schema} = '' OR {schema} = 'main') AND ({table} = '' OR m.name = {table})
+		ORDER BY m.name, p.cid`,
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	db, err := introspectioncommon.NewDB(kind, rawS)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaParameter := tools.NewStringParameterWithDefault("schema", "", "Schema of the tables whose columns to list. Lists the columns of all the schemas if it's empty.")
+	tableParameter := tools.NewStringParameterWithDefault("table", "", "Table whose columns to list. Lists the columns of all the tables if it's empty.")
+	parameters := tools.Parameters{schemaParameter, tableParameter}
+
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		AuthRequired: cfg.AuthRequired,
+		Parameters:   parameters,
+		DB:           db,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: parameters.McpManifest(),
+		},
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	DB          introspectioncommon.DB
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.DB.Query(ctx, queries[t.DB.Dialect], params.AsMap())
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
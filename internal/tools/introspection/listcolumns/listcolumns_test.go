@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listcolumns_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/introspection/listcolumns"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYaml(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		example_tool:
+			kind: list-columns
+			source: my-instance
+			description: some description
+	`
+	want := server.ToolConfigs{
+		"example_tool": listcolumns.Config{
+			Name:         "example_tool",
+			Kind:         "list-columns",
+			Source:       "my-instance",
+			Description:  "some description",
+			AuthRequired: []string{},
+		},
+	}
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	// Parse contents
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+// newSource returns an in-memory SQLite source with a table and a view.
+func newSource(t *testing.T) sources.Source {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = src.(*sqlite.Source).SQLiteDB().ExecContext(ctx, `
+		CREATE TABLE orders (id INTEGER PRIMARY KEY, item TEXT NOT NULL, quantity INTEGER DEFAULT 1);
+		CREATE VIEW big_orders AS SELECT id, item FROM orders WHERE quantity > 10;
+		INSERT INTO orders (item, quantity) VALUES ('apple', 3), ('pear', 12), ('plum', 1);`)
+	if err != nil {
+		t.Fatalf("unable to create tables: %s", err)
+	}
+	return src
+}
+
+// invoke initializes a tool of the config, and invokes it with the params.
+func invoke(t *testing.T, cfg tools.ToolConfig, params map[string]any) (any, error) {
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": newSource(t)})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	parsed, err := tool.ParseParams(params, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tool.Invoke(context.Background(), parsed)
+}
+
+func TestInvoke(t *testing.T) {
+	cfg := listcolumns.Config{Name: "example_tool", Kind: "list-columns", Source: "my-instance", Description: "some description"}
+	got, err := invoke(t, cfg, map[string]any{"table": "big_orders"})
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	want := []any{
+		map[string]any{"table_schema": "main", "table_name": "big_orders", "column_name": "id", "data_type": "INTEGER", "is_nullable": "YES", "ordinal_position": int64(1)},
+		map[string]any{"table_schema": "main", "table_name": "big_orders", "column_name": "item", "data_type": "TEXT", "is_nullable": "YES", "ordinal_position": int64(2)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	got, err = invoke(t, cfg, map[string]any{})
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	if n := len(got.([]any)); n != 5 {
+		t.Fatalf("expected the 5 columns of all the tables, got %d", n)
+	}
+}
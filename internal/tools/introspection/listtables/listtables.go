@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package listtables
+
+import (
+	"context"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/introspection/introspectioncommon"
+)
+
+const kind string = "list-tables"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// queries list the tables and views of the schemas that aren't part of the
+// catalog, or of one schema.
+var queries = introspectioncommon.Queries{
+	introspectioncommon.Postgres: `SELECT table_schema, table_name, table_type FROM information_schema.tables
+		WHERE table_schema NOT IN ('pg_catalog', 'information_schema') AND table_schema NOT LIKE 'pg\_%'
+		AND ({schema}::text = '' OR table_schema = {schema}::text)
+		ORDER BY table_schema, table_name`,
+	introspectioncommon.DuckDB: `SELECT table_schema, table_name, table_type FROM information_schema.tables
+		WHERE table_schema NOT IN ('pg_catalog', 'information_schema')
+		AND ({schema}::text = '' OR table_schema = {schema}::text)
+		ORDER BY table_schema, table_name`,
+	introspectioncommon.MySQL: `SELECT table_schema AS table_schema, table_name AS table_name, table_type AS table_type FROM information_schema.tables
+		WHERE table_schema NOT IN ('mysql', 'information_schema', 'performance_schema', 'sys')
+		AND ({schema} = '' OR table_schema = {schema})
+		ORDER BY table_schema, table_name`,
+	introspectioncommon.MSSQL: `SELECT TABLE_SCHEMA AS table_schema, TABLE_NAME AS table_name, TABLE_TYPE AS table_type FROM INFORMATION_SCHEMA.TABLES
+		WHERE ({schema} = '' OR TABLE_SCHEMA = {schema})
+		ORDER BY TABLE_SCHEMA, TABLE_NAME`,
+	introspectioncommon.SQLite: `SELECT 'main' AS table_schema, name AS table_name, CASE type WHEN 'view' THEN 'VIEW' ELSE 'BASE TABLE' END AS table_type FROM sqlite_master
+		WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite\_%' ESCAPE '\'
+		AND ({schema} = '' OR {schema} = 'main')
+		ORDER BY name`,
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	db, err := introspectioncommon.NewDB(kind, rawS)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaParameter := tools.NewStringParameterWithDefault("schema", "", "Schema of the tables to list. Lists the tables of all the schemas if it's empty.")
+	parameters := tools.Parameters{schemaParameter}
+
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		AuthRequired: cfg.AuthRequired,
+		Parameters:   parameters,
+		DB:           db,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: parameters.McpManifest(),
+		},
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	DB          introspectioncommon.DB
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.DB.Query(ctx, queries[t.DB.Dialect], params.AsMap())
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package samplerows
+
+import (
+	"context"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/introspection/introspectioncommon"
+)
+
+const kind string = "sample-rows"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// DefaultMaxLimit is the maximum number of rows that a tool returns, by
+// default.
+const DefaultMaxLimit = 100
+
+// tableQueries look up the schema of a table or view, so that only the names
+// of existing tables are quoted into the query of the rows. A table without a
+// schema is looked up in the current schema.
+var tableQueries = introspectioncommon.Queries{
+	introspectioncommon.Postgres: `SELECT table_schema FROM information_schema.tables
+		WHERE table_schema = COALESCE(NULLIF({schema}::text, ''), current_schema()) AND table_name = {table}::text`,
+	introspectioncommon.DuckDB: `SELECT table_schema FROM information_schema.tables
+		WHERE table_schema = COALESCE(NULLIF({schema}::text, ''), current_schema()) AND table_name = {table}::text`,
+	introspectioncommon.MySQL: `SELECT table_schema AS table_schema FROM information_schema.tables
+		WHERE table_schema = COALESCE(NULLIF({schema}, ''), DATABASE()) AND table_name = {table}`,
+	introspectioncommon.MSSQL: `SELECT TABLE_SCHEMA AS table_schema FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_SCHEMA = COALESCE(NULLIF({schema}, ''), SCHEMA_NAME()) AND TABLE_NAME = {table}`,
+	introspectioncommon.SQLite: `SELECT 'main' AS table_schema FROM sqlite_master
+		WHERE type IN ('table', 'view') AND name = {table} AND ({schema} = '' OR {schema} = 'main')`,
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	MaxLimit     int      `yaml:"maxLimit" validate:"gte=0"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	db, err := introspectioncommon.NewDB(kind, rawS)
+	if err != nil {
+		return nil, err
+	}
+
+	maxLimit := cfg.MaxLimit
+	if maxLimit == 0 {
+		maxLimit = DefaultMaxLimit
+	}
+
+	tableParameter := tools.NewStringParameter("table", "Name of the table or view to sample.")
+	schemaParameter := tools.NewStringParameterWithDefault("schema", "", "Schema of the table. The table is looked up in the current schema if it's empty.")
+	limitParameter := tools.NewIntParameterWithDefault("limit", min(10, maxLimit), "Number of rows to return.")
+	minimum, maximum := 1.0, float64(maxLimit)
+	limitParameter.Minimum, limitParameter.Maximum = &minimum, &maximum
+	parameters := tools.Parameters{tableParameter, schemaParameter, limitParameter}
+
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		AuthRequired: cfg.AuthRequired,
+		Parameters:   parameters,
+		DB:           db,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: parameters.McpManifest(),
+		},
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	DB          introspectioncommon.DB
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke returns up to limit rows of the table, in no particular order.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	table, _ := paramsMap["table"].(string)
+	limit, _ := paramsMap["limit"].(int)
+
+	found, err := t.DB.Query(ctx, tableQueries[t.DB.Dialect], paramsMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("table %q not found", table)
+	}
+	schema, _ := found[0].(map[string]any)["table_schema"].(string)
+
+	name := t.DB.QuoteIdentifier(schema) + "." + t.DB.QuoteIdentifier(table)
+	query := fmt.Sprintf("SELECT * FROM %s LIMIT {limit}", name)
+	if t.DB.Dialect == introspectioncommon.MSSQL {
+		query = fmt.Sprintf("SELECT TOP ({limit}) * FROM %s", name)
+	}
+	return t.DB.Query(ctx, query, map[string]any{"limit": limit})
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbaggregate_test
+
+import (
+	"context"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/mongodb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mongodb/mongodbaggregate"
+)
+
+func TestParseFromYamlMongoDBAggregate(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: mongodb-aggregate
+					source: my-mongodb-instance
+					description: some description
+					collection: orders
+					pipeline: |
+						[{"$match": {"status": {{json .status}}}}, {"$count": "total"}]
+					parameters:
+						- name: status
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mongodbaggregate.Config{
+					Name:         "example_tool",
+					Kind:         "mongodb-aggregate",
+					Source:       "my-mongodb-instance",
+					Description:  "some description",
+					Collection:   "orders",
+					Pipeline:     "[{\"$match\": {\"status\": {{json .status}}}}, {\"$count\": \"total\"}]\n",
+					AuthRequired: []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("status", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeReadOnly(t *testing.T) {
+	src := &mongodb.Source{Name: "my-mongodb-instance", Kind: mongodb.SourceKind, ReadOnly: true}
+	cfg := mongodbaggregate.Config{
+		Name:        "example_tool",
+		Kind:        "mongodb-aggregate",
+		Source:      "my-mongodb-instance",
+		Description: "some description",
+		Collection:  "orders",
+		Pipeline:    `[{"$match": {}}, {{.stage}}]`,
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("stage", "some description"),
+		},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-mongodb-instance": src})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc  string
+		stage string
+		want  string
+	}{
+		{desc: "out", stage: `{"$out": "copy"}`, want: "the $out stage is not allowed, since source is read-only"},
+		{desc: "merge", stage: `{"$merge": {"into": "copy"}}`, want: "the $merge stage is not allowed, since source is read-only"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(map[string]any{"stage": tc.stage}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_, err = tool.Invoke(context.Background(), params)
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
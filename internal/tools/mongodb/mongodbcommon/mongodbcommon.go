@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mongodbcommon contains the helpers shared by the MongoDB tools.
+//
+// The documents of the tools, such as filters and pipelines, are written as
+// MongoDB Extended JSON. They are Go templates, which are rendered with the
+// parameters of the tool before they are parsed.
+package mongodbcommon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ParseTemplate parses the text of a document. The `json` function of the
+// template encodes a parameter as a JSON value, so that strings are quoted and
+// escaped.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(template.FuncMap{"json": toJSON}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", name, err)
+	}
+	return t, nil
+}
+
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal param to JSON: %w", err)
+	}
+	return string(b), nil
+}
+
+// RenderDocument renders a template into a document.
+func RenderDocument(t *template.Template, params map[string]any) (bson.D, error) {
+	v, err := render(t, params)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := v.(bson.D)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a JSON object", t.Name())
+	}
+	return d, nil
+}
+
+// RenderArray renders a template into an array of documents.
+func RenderArray(t *template.Template, params map[string]any) ([]bson.D, error) {
+	v, err := render(t, params)
+	if err != nil {
+		return nil, err
+	}
+	a, ok := v.(bson.A)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a JSON array", t.Name())
+	}
+	return toDocuments(t.Name(), a)
+}
+
+// RenderDocuments renders a template into either a document or an array of
+// documents.
+func RenderDocuments(t *template.Template, params map[string]any) ([]bson.D, error) {
+	v, err := render(t, params)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case bson.D:
+		return []bson.D{v}, nil
+	case bson.A:
+		return toDocuments(t.Name(), v)
+	default:
+		return nil, fmt.Errorf("%s must be a JSON object or array", t.Name())
+	}
+}
+
+func toDocuments(name string, a bson.A) ([]bson.D, error) {
+	docs := make([]bson.D, 0, len(a))
+	for i, e := range a {
+		d, ok := e.(bson.D)
+		if !ok {
+			return nil, fmt.Errorf("element %d of %s must be a JSON object", i, name)
+		}
+		docs = append(docs, d)
+	}
+	return docs, nil
+}
+
+// render renders a template into a document or an array.
+func render(t *template.Template, params map[string]any) (any, error) {
+	var b bytes.Buffer
+	// Extended JSON must be a document at the top level, so the result is
+	// wrapped into one
+	b.WriteString(`{"v": `)
+	if err := t.Execute(&b, params); err != nil {
+		return nil, fmt.Errorf("error replacing %s parameters: %s", t.Name(), err)
+	}
+	b.WriteString("\n}")
+	var wrapper struct {
+		V any `bson:"v"`
+	}
+	if err := bson.UnmarshalExtJSON(b.Bytes(), false, &wrapper); err != nil {
+		return nil, fmt.Errorf("%s is not valid Extended JSON: %w", t.Name(), err)
+	}
+	return wrapper.V, nil
+}
+
+// DecodeCursor returns the documents of a cursor. They are converted to
+// relaxed Extended JSON, so that types without a JSON equivalent, such as
+// ObjectIds, keep their type.
+func DecodeCursor(ctx context.Context, cursor *mongo.Cursor) ([]any, error) {
+	defer cursor.Close(ctx)
+	out := []any{}
+	for cursor.Next(ctx) {
+		b, err := bson.MarshalExtJSON(cursor.Current, false, false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert document to JSON: %w", err)
+		}
+		var row any
+		if err := json.Unmarshal(b, &row); err != nil {
+			return nil, fmt.Errorf("unable to decode document: %w", err)
+		}
+		out = append(out, row)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbcommon_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools/mongodb/mongodbcommon"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestRenderDocument(t *testing.T) {
+	oid, err := bson.ObjectIDFromHex("5f1d7a9e8b2c4a3d9e0f1a2b")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc    string
+		in      string
+		params  map[string]any
+		want    bson.D
+		wantErr string
+	}{
+		{
+			desc:   "parameters are escaped",
+			in:     `{"name": {{json .name}}, "tags": {"$in": {{json .tags}}}}`,
+			params: map[string]any{"name": `O'Brien", "$ne": "`, "tags": []any{"a", "b"}},
+			want: bson.D{
+				{Key: "name", Value: `O'Brien", "$ne": "`},
+				{Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"a", "b"}}}},
+			},
+		},
+		{
+			desc:   "extended json",
+			in:     `{"_id": {"$oid": {{json .id}}}, "count": {"$gt": {{.count}}}}`,
+			params: map[string]any{"id": "5f1d7a9e8b2c4a3d9e0f1a2b", "count": 3},
+			want: bson.D{
+				{Key: "_id", Value: oid},
+				{Key: "count", Value: bson.D{{Key: "$gt", Value: int32(3)}}},
+			},
+		},
+		{
+			desc:    "not an object",
+			in:      `[{"a": 1}]`,
+			wantErr: "filter must be a JSON object",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tmpl, err := mongodbcommon.ParseTemplate("filter", tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := mongodbcommon.RenderDocument(tmpl, tc.params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect document: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestRenderDocuments(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		in      string
+		want    []bson.D
+		wantErr string
+	}{
+		{
+			desc: "document",
+			in:   `{"a": 1}`,
+			want: []bson.D{{{Key: "a", Value: int32(1)}}},
+		},
+		{
+			desc: "array",
+			in:   `[{"a": 1}, {"b": 2}]`,
+			want: []bson.D{{{Key: "a", Value: int32(1)}}, {{Key: "b", Value: int32(2)}}},
+		},
+		{
+			desc:    "array of values",
+			in:      `[{"a": 1}, 2]`,
+			wantErr: "element 1 of document must be a JSON object",
+		},
+		{
+			desc:    "invalid",
+			in:      `{"a": }`,
+			wantErr: "document is not valid Extended JSON",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tmpl, err := mongodbcommon.ParseTemplate("document", tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got, err := mongodbcommon.RenderDocuments(tmpl, nil)
+			if tc.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect documents: diff %v", diff)
+			}
+		})
+	}
+}
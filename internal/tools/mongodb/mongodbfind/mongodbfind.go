@@ -0,0 +1,196 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package mongodbfind
+
+import (
+	"context"
+	"fmt"
+	"text/template"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/mongodb"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mongodb/mongodbcommon"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+const kind string = "mongodb-find"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string           `yaml:"name" validate:"required"`
+	Kind         string           `yaml:"kind" validate:"required"`
+	Source       string           `yaml:"source" validate:"required"`
+	Description  string           `yaml:"description" validate:"required"`
+	Collection   string           `yaml:"collection" validate:"required"`
+	Filter       string           `yaml:"filter"`
+	Projection   string           `yaml:"projection"`
+	Sort         string           `yaml:"sort"`
+	Limit        int64            `yaml:"limit"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*mongodb.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, mongodb.SourceKind)
+	}
+
+	if cfg.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative, got %d", cfg.Limit)
+	}
+	filter := cfg.Filter
+	if filter == "" {
+		filter = "{}"
+	}
+	filterTmpl, err := mongodbcommon.ParseTemplate("filter", filter)
+	if err != nil {
+		return nil, err
+	}
+	var projectionTmpl, sortTmpl *template.Template
+	if cfg.Projection != "" {
+		if projectionTmpl, err = mongodbcommon.ParseTemplate("projection", cfg.Projection); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Sort != "" {
+		if sortTmpl, err = mongodbcommon.ParseTemplate("sort", cfg.Sort); err != nil {
+			return nil, err
+		}
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Collection:   cfg.Collection,
+		Filter:       filterTmpl,
+		Projection:   projectionTmpl,
+		Sort:         sortTmpl,
+		Limit:        cfg.Limit,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *mongodb.Source
+	Collection  string
+	Filter      *template.Template
+	Projection  *template.Template
+	Sort        *template.Template
+	Limit       int64
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	filter, err := mongodbcommon.RenderDocument(t.Filter, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find()
+	if t.Projection != nil {
+		projection, err := mongodbcommon.RenderDocument(t.Projection, paramsMap)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetProjection(projection)
+	}
+	if t.Sort != nil {
+		sort, err := mongodbcommon.RenderDocument(t.Sort, paramsMap)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetSort(sort)
+	}
+	if t.Limit > 0 {
+		opts.SetLimit(t.Limit)
+	}
+
+	cursor, err := t.Source.Database.Collection(t.Collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run find: %w", err)
+	}
+	return mongodbcommon.DecodeCursor(ctx, cursor)
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
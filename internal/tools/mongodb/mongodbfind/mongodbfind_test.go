@@ -0,0 +1,144 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbfind_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/mongodb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mongodb/mongodbfind"
+)
+
+func TestParseFromYamlMongoDBFind(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: mongodb-find
+					source: my-mongodb-instance
+					description: some description
+					collection: users
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mongodbfind.Config{
+					Name:         "example_tool",
+					Kind:         "mongodb-find",
+					Source:       "my-mongodb-instance",
+					Description:  "some description",
+					Collection:   "users",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "advanced example",
+			in: `
+			tools:
+				example_tool:
+					kind: mongodb-find
+					source: my-mongodb-instance
+					description: some description
+					collection: users
+					filter: |
+						{"name": {{json .name}}}
+					projection: '{"name": 1, "email": 1}'
+					sort: '{"name": 1}'
+					limit: 10
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: name
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mongodbfind.Config{
+					Name:         "example_tool",
+					Kind:         "mongodb-find",
+					Source:       "my-mongodb-instance",
+					Description:  "some description",
+					Collection:   "users",
+					Filter:       "{\"name\": {{json .name}}}\n",
+					Projection:   `{"name": 1, "email": 1}`,
+					Sort:         `{"name": 1}`,
+					Limit:        10,
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("name", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailInitialize(t *testing.T) {
+	srcs := map[string]sources.Source{"my-mongodb-instance": &mongodb.Source{Name: "my-mongodb-instance", Kind: mongodb.SourceKind}}
+	tcs := []struct {
+		desc string
+		cfg  mongodbfind.Config
+		want string
+	}{
+		{
+			desc: "negative limit",
+			cfg:  mongodbfind.Config{Name: "example_tool", Kind: "mongodb-find", Source: "my-mongodb-instance", Description: "some description", Collection: "users", Limit: -1},
+			want: "limit must not be negative, got -1",
+		},
+		{
+			desc: "invalid filter template",
+			cfg:  mongodbfind.Config{Name: "example_tool", Kind: "mongodb-find", Source: "my-mongodb-instance", Description: "some description", Collection: "users", Filter: `{"name": {{json .name}`},
+			want: "unable to parse filter: template: filter:1: bad character U+007D '}'",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(srcs)
+			if err == nil || err.Error() != tc.want {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodbinsert_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/mongodb"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mongodb/mongodbinsert"
+)
+
+func TestParseFromYamlMongoDBInsert(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: mongodb-insert
+					source: my-mongodb-instance
+					description: some description
+					collection: users
+					document: |
+						{"name": {{json .name}}}
+					parameters:
+						- name: name
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mongodbinsert.Config{
+					Name:         "example_tool",
+					Kind:         "mongodb-insert",
+					Source:       "my-mongodb-instance",
+					Description:  "some description",
+					Collection:   "users",
+					Document:     "{\"name\": {{json .name}}}\n",
+					AuthRequired: []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("name", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailInitializeReadOnly(t *testing.T) {
+	src := &mongodb.Source{Name: "my-mongodb-instance", Kind: mongodb.SourceKind, ReadOnly: true}
+	cfg := mongodbinsert.Config{
+		Name:        "example_tool",
+		Kind:        "mongodb-insert",
+		Source:      "my-mongodb-instance",
+		Description: "some description",
+		Collection:  "users",
+		Document:    `{"name": "Alice"}`,
+	}
+	_, err := cfg.Initialize(map[string]sources.Source{"my-mongodb-instance": src})
+	want := `"mongodb-insert" tool can't use source "my-mongodb-instance", since it is read-only`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mssqlcallprocedure
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresynapse"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
+	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlprocedure"
+	mssqldb "github.com/microsoft/go-mssqldb"
+)
+
+const kind string = "mssql-call-procedure"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	MSSQLDB() *sql.DB
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &azuresynapse.Source{}
+var _ compatibleSource = &cloudsqlmssql.Source{}
+var _ compatibleSource = &mssql.Source{}
+
+var compatibleSources = [...]string{azuresynapse.SourceKind, cloudsqlmssql.SourceKind, mssql.SourceKind}
+
+type Config struct {
+	Name          string           `yaml:"name" validate:"required"`
+	Kind          string           `yaml:"kind" validate:"required"`
+	Source        string           `yaml:"source" validate:"required"`
+	Description   string           `yaml:"description" validate:"required"`
+	Procedure     string           `yaml:"procedure" validate:"required"`
+	AuthRequired  []string         `yaml:"authRequired"`
+	Parameters    tools.Parameters `yaml:"parameters"`
+	OutParameters tools.Parameters `yaml:"outParameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	if err := sqlprocedure.CheckName(cfg.Procedure); err != nil {
+		return nil, err
+	}
+	// the arguments are passed by name, so their order doesn't matter
+	args, err := sqlprocedure.Arguments(nil, cfg.Parameters, cfg.OutParameters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments of tool %q: %w", cfg.Name, err)
+	}
+	for _, p := range cfg.OutParameters {
+		if _, err := outDest(p, false, nil); err != nil {
+			return nil, fmt.Errorf("invalid arguments of tool %q: %w", cfg.Name, err)
+		}
+	}
+
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AuthRequired: cfg.AuthRequired,
+		Db:           s.MSSQLDB(),
+		Procedure:    cfg.Procedure,
+		Arguments:    args,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: cfg.Parameters.McpManifest(),
+		},
+	}
+	return t, nil
+}
+
+// outDest returns the destination of an OUT or INOUT argument, whose type
+// declares the type of the parameter to the server. The sql.Null types let
+// the procedure return NULL.
+func outDest(p tools.Parameter, in bool, v any) (any, error) {
+	switch p.GetType() {
+	case "string":
+		// a string is declared with the length of its value, which would
+		// truncate the value returned, so INOUT strings are nvarchar(max)
+		if s, ok := v.(string); ok && in {
+			d := mssqldb.NVarCharMax(s)
+			return &d, nil
+		}
+		s, ok := v.(string)
+		return &sql.NullString{String: s, Valid: ok}, nil
+	case "integer":
+		n, ok := v.(int)
+		return &sql.NullInt64{Int64: int64(n), Valid: ok}, nil
+	case "float":
+		f, ok := v.(float64)
+		return &sql.NullFloat64{Float64: f, Valid: ok}, nil
+	case "boolean":
+		b, ok := v.(bool)
+		return &sql.NullBool{Bool: b, Valid: ok}, nil
+	}
+	return nil, fmt.Errorf("out parameter %q: type %q is not supported", p.GetName(), p.GetType())
+}
+
+// outValue returns the value of an OUT or INOUT argument after the call.
+func outValue(dest any) (any, error) {
+	switch d := dest.(type) {
+	case *mssqldb.NVarCharMax:
+		return string(*d), nil
+	case driver.Valuer:
+		return d.Value()
+	}
+	return nil, fmt.Errorf("unexpected out destination %T", dest)
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Db          *sql.DB
+	Procedure   string
+	Arguments   []sqlprocedure.Argument
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke calls the procedure, and returns the values of its OUT and INOUT
+// parameters, along with the result sets it returned.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	args := make([]any, 0, len(t.Arguments))
+	dests := make(map[string]any)
+	for _, a := range t.Arguments {
+		if a.Out == nil {
+			args = append(args, sql.Named(a.Name, paramsMap[a.Name]))
+			continue
+		}
+		dest, err := outDest(a.Out, a.In, paramsMap[a.Name])
+		if err != nil {
+			return nil, err
+		}
+		dests[a.Name] = dest
+		args = append(args, sql.Named(a.Name, sql.Out{Dest: dest}))
+	}
+
+	// a query that is only the name of a procedure is sent as an RPC call
+	// of the procedure, with the arguments as its named parameters
+	resultSets, err := call(ctx, t.Db, t.Procedure, args)
+	if err != nil {
+		return nil, err
+	}
+	result := sqlprocedure.Result{Out: map[string]any{}, ResultSets: resultSets}
+	for name, dest := range dests {
+		v, err := outValue(dest)
+		if err != nil {
+			return nil, err
+		}
+		result.Out[name] = v
+	}
+	return result, nil
+}
+
+// call calls the procedure, and returns the rows of the result sets it
+// returned. The values of the OUT arguments are set once all of them are
+// read.
+func call(ctx context.Context, db *sql.DB, procedure string, args []any) ([][]any, error) {
+	results, err := db.QueryContext(ctx, procedure, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to call procedure: %w", err)
+	}
+	defer results.Close()
+
+	var resultSets [][]any
+	for {
+		cols, err := results.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+		}
+		rawValues := make([]any, len(cols))
+		values := make([]any, len(cols))
+		for i := range rawValues {
+			values[i] = &rawValues[i]
+		}
+
+		rows := []any{}
+		for results.Next() {
+			if err := results.Scan(values...); err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, name := range cols {
+				vMap[name] = rawValues[i]
+			}
+			rows = append(rows, vMap)
+		}
+		if len(cols) != 0 {
+			resultSets = append(resultSets, rows)
+		}
+		if !results.NextResultSet() {
+			break
+		}
+	}
+	if err := results.Err(); err != nil {
+		return nil, fmt.Errorf("unable to call procedure: %w", err)
+	}
+	if err := results.Close(); err != nil {
+		return nil, fmt.Errorf("unable to call procedure: %w", err)
+	}
+	return resultSets, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mssqlcallprocedure_test
+
+import (
+	"database/sql"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mssql/mssqlcallprocedure"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlprocedure"
+)
+
+func TestParseFromYamlCallProcedure(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: mssql-call-procedure
+					source: my-mssql-instance
+					description: some description
+					procedure: dbo.restock
+					parameters:
+						- name: item
+						  type: string
+						  description: Item to restock.
+					outParameters:
+						- name: stock
+						  type: integer
+						  description: Stock after restocking.
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mssqlcallprocedure.Config{
+					Name:          "example_tool",
+					Kind:          "mssql-call-procedure",
+					Source:        "my-mssql-instance",
+					Description:   "some description",
+					Procedure:     "dbo.restock",
+					AuthRequired:  []string{},
+					Parameters:    tools.Parameters{tools.NewStringParameter("item", "Item to restock.")},
+					OutParameters: tools.Parameters{tools.NewIntParameter("stock", "Stock after restocking.")},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type fakeSource struct{}
+
+func (fakeSource) SourceKind() string {
+	return "mssql"
+}
+
+func (fakeSource) MSSQLDB() *sql.DB {
+	return nil
+}
+
+func TestInitialize(t *testing.T) {
+	srcs := map[string]sources.Source{"my-mssql-instance": fakeSource{}}
+	item := tools.NewStringParameter("item", "Item to restock.")
+	quantity := tools.NewIntParameter("quantity", "Quantity to add.")
+	stock := tools.NewIntParameter("stock", "Stock after restocking.")
+
+	tcs := []struct {
+		desc    string
+		in      tools.Parameters
+		out     tools.Parameters
+		want    []sqlprocedure.Argument
+		wantErr string
+	}{
+		{
+			desc: "in and out",
+			in:   tools.Parameters{item, quantity},
+			out:  tools.Parameters{stock},
+			want: []sqlprocedure.Argument{
+				{Name: "item", In: true},
+				{Name: "quantity", In: true},
+				{Name: "stock", Out: stock},
+			},
+		},
+		{
+			desc: "inout",
+			in:   tools.Parameters{item, quantity},
+			out:  tools.Parameters{quantity},
+			want: []sqlprocedure.Argument{
+				{Name: "item", In: true},
+				{Name: "quantity", In: true, Out: quantity},
+			},
+		},
+		{
+			desc:    "unsupported out type",
+			out:     tools.Parameters{tools.NewArrayParameter("items", "Items restocked.", item)},
+			wantErr: `invalid arguments of tool "example_tool": out parameter "items": type "array" is not supported`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := mssqlcallprocedure.Config{
+				Name:          "example_tool",
+				Kind:          "mssql-call-procedure",
+				Source:        "my-mssql-instance",
+				Description:   "some description",
+				Procedure:     "dbo.restock",
+				Parameters:    tc.in,
+				OutParameters: tc.out,
+			}
+			tool, err := cfg.Initialize(srcs)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := tool.(mssqlcallprocedure.Tool)
+			if diff := cmp.Diff(tc.want, got.Arguments); diff != "" {
+				t.Fatalf("incorrect arguments: diff %v", diff)
+			}
+		})
+	}
+}
@@ -21,9 +21,12 @@ import (
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresynapse"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 )
 
 const kind string = "mssql-execute-sql"
@@ -47,17 +50,20 @@ type compatibleSource interface {
 }
 
 // validate compatible sources are still compatible
+var _ compatibleSource = &azuresynapse.Source{}
 var _ compatibleSource = &cloudsqlmssql.Source{}
 var _ compatibleSource = &mssql.Source{}
 
-var compatibleSources = [...]string{cloudsqlmssql.SourceKind, mssql.SourceKind}
+var compatibleSources = [...]string{azuresynapse.SourceKind, cloudsqlmssql.SourceKind, mssql.SourceKind}
 
 type Config struct {
-	Name         string   `yaml:"name" validate:"required"`
-	Kind         string   `yaml:"kind" validate:"required"`
-	Source       string   `yaml:"source" validate:"required"`
-	Description  string   `yaml:"description" validate:"required"`
-	AuthRequired []string `yaml:"authRequired"`
+	Name         string            `yaml:"name" validate:"required"`
+	Kind         string            `yaml:"kind" validate:"required"`
+	Source       string            `yaml:"source" validate:"required"`
+	Description  string            `yaml:"description" validate:"required"`
+	ReadOnly     bool              `yaml:"readOnly"`
+	Policy       *sqlpolicy.Policy `yaml:"policy"`
+	AuthRequired []string          `yaml:"authRequired"`
 }
 
 // validate interface
@@ -80,6 +86,12 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	if cfg.Policy != nil {
+		if err := cfg.Policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	sqlParameter := tools.NewStringParameter("sql", "The sql to execute.")
 	parameters := tools.Parameters{sqlParameter}
 
@@ -96,6 +108,8 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Parameters:   parameters,
 		AuthRequired: cfg.AuthRequired,
 		Pool:         s.MSSQLDB(),
+		ReadOnly:     cfg.ReadOnly,
+		Policy:       cfg.Policy,
 		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
 		mcpManifest:  mcpManifest,
 	}
@@ -112,6 +126,8 @@ type Tool struct {
 	Parameters   tools.Parameters `yaml:"parameters"`
 
 	Pool        *sql.DB
+	ReadOnly    bool
+	Policy      *sqlpolicy.Policy
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
@@ -122,6 +138,20 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if !ok {
 		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
 	}
+
+	// SQL Server doesn't have read-only transactions, so a read-only tool
+	// relies on the classification of the statements only
+	if t.ReadOnly {
+		if err := sqlparse.CheckReadOnly(sql, sqlparse.SQLServer); err != nil {
+			return nil, err
+		}
+	}
+	if t.Policy != nil {
+		if err := t.Policy.Check(sql, sqlparse.SQLServer); err != nil {
+			return nil, err
+		}
+	}
+
 	results, err := t.Pool.QueryContext(ctx, sql)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
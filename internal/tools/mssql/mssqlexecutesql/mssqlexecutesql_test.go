@@ -56,6 +56,27 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "readOnly example",
+			in: `
+			tools:
+				example_tool:
+					kind: mssql-execute-sql
+					source: my-instance
+					description: some description
+					readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mssqlexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "mssql-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					ReadOnly:     true,
+					AuthRequired: []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
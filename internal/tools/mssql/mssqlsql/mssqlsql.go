@@ -17,14 +17,18 @@ package mssqlsql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/azuresynapse"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlstmtcache"
+	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 const kind string = "mssql-sql"
@@ -48,10 +52,11 @@ type compatibleSource interface {
 }
 
 // validate compatible sources are still compatible
+var _ compatibleSource = &azuresynapse.Source{}
 var _ compatibleSource = &cloudsqlmssql.Source{}
 var _ compatibleSource = &mssql.Source{}
 
-var compatibleSources = [...]string{cloudsqlmssql.SourceKind, mssql.SourceKind}
+var compatibleSources = [...]string{azuresynapse.SourceKind, cloudsqlmssql.SourceKind, mssql.SourceKind}
 
 type Config struct {
 	Name               string           `yaml:"name" validate:"required"`
@@ -59,6 +64,7 @@ type Config struct {
 	Source             string           `yaml:"source" validate:"required"`
 	Description        string           `yaml:"description" validate:"required"`
 	Statement          string           `yaml:"statement" validate:"required"`
+	PrepareStatement   bool             `yaml:"prepareStatement"`
 	AuthRequired       []string         `yaml:"authRequired"`
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
@@ -66,11 +72,18 @@ type Config struct {
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.CatalogChecker = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// CatalogParameters returns the template parameters, whose identifiers are
+// looked up in the catalog before they are substituted into the statement.
+func (cfg Config) CatalogParameters() tools.Parameters {
+	return cfg.TemplateParameters
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -85,6 +98,13 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	}
 
 	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	claims, err := tools.BindClaims(cfg.Statement, allParameters, func(name string, _ int) string {
+		return "@" + name
+	})
+	if err != nil {
+		return nil, err
+	}
+	allParameters = append(allParameters, claims.Parameters...)
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -99,12 +119,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Parameters:         cfg.Parameters,
 		TemplateParameters: cfg.TemplateParameters,
 		AllParams:          allParameters,
-		Statement:          cfg.Statement,
+		Statement:          claims.Statement,
+		Claims:             claims,
 		AuthRequired:       cfg.AuthRequired,
 		Db:                 s.MSSQLDB(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
 	}
+	if cfg.PrepareStatement {
+		t.Stmts = sqlstmtcache.New(t.Db)
+	}
 	return t, nil
 }
 
@@ -120,13 +144,18 @@ type Tool struct {
 	AllParams          tools.Parameters `yaml:"allParams"`
 
 	Db          *sql.DB
+	Stmts       *sqlstmtcache.Cache
 	Statement   string
+	Claims      tools.ClaimBinding
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMap()
+	if err := checkCatalog(ctx, t.Db, tools.CatalogIdentifiers(t.TemplateParameters, paramsMap)); err != nil {
+		return nil, err
+	}
 	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
@@ -149,8 +178,15 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 			namedArgs = append(namedArgs, value)
 		}
 	}
+	for _, c := range t.Claims.Values(paramsMap) {
+		namedArgs = append(namedArgs, sql.Named(c.Name, c.Value))
+	}
 
-	rows, err := t.Db.QueryContext(ctx, newStatement, namedArgs...)
+	var q sqlstmtcache.Querier = t.Db
+	if t.Stmts != nil {
+		q = t.Stmts
+	}
+	rows, err := q.QueryContext(ctx, newStatement, namedArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -159,6 +195,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to fetch column types: %w", err)
 	}
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch column types: %w", err)
+	}
+	util.ReportColumns(ctx, util.SQLColumns(colTypes))
 
 	// create an array of values for each column, which can be re-used to scan each row
 	rawValues := make([]any, len(cols))
@@ -192,6 +233,33 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	return out, nil
 }
 
+// catalogQueries look up the objects of the catalog by their qualifier, the
+// schema of a table or the table of a column, and their name. Unqualified
+// names are looked up in the default schema of the user.
+var catalogQueries = map[string]string{
+	tools.CatalogTable: `SELECT TOP 1 1 FROM INFORMATION_SCHEMA.TABLES
+		WHERE TABLE_SCHEMA = COALESCE(NULLIF(@p1, ''), SCHEMA_NAME()) AND TABLE_NAME = @p2`,
+	tools.CatalogColumn: `SELECT TOP 1 1 FROM INFORMATION_SCHEMA.COLUMNS
+		WHERE TABLE_SCHEMA = SCHEMA_NAME() AND TABLE_NAME = COALESCE(NULLIF(@p1, ''), TABLE_NAME) AND COLUMN_NAME = @p2`,
+}
+
+// checkCatalog returns an error if one of the identifiers isn't found in the
+// INFORMATION_SCHEMA of the database.
+func checkCatalog(ctx context.Context, db *sql.DB, ids []tools.CatalogIdentifier) error {
+	for _, id := range ids {
+		qualifier, name := id.Parts()
+		var found int
+		err := db.QueryRowContext(ctx, catalogQueries[id.Catalog], qualifier, name).Scan(&found)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s %q not found", id.Catalog, id.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to look up %s %q: %w", id.Catalog, id.Name, err)
+		}
+	}
+	return nil
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
 	return tools.ParseParams(t.AllParams, data, claims)
 }
@@ -0,0 +1,290 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlcallprocedure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlprocedure"
+)
+
+const kind string = "mysql-call-procedure"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	MySQLPool() *sql.DB
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &cloudsqlmysql.Source{}
+var _ compatibleSource = &mysql.Source{}
+
+var compatibleSources = [...]string{cloudsqlmysql.SourceKind, mysql.SourceKind}
+
+type Config struct {
+	Name          string           `yaml:"name" validate:"required"`
+	Kind          string           `yaml:"kind" validate:"required"`
+	Source        string           `yaml:"source" validate:"required"`
+	Description   string           `yaml:"description" validate:"required"`
+	Procedure     string           `yaml:"procedure" validate:"required"`
+	AuthRequired  []string         `yaml:"authRequired"`
+	Parameters    tools.Parameters `yaml:"parameters"`
+	OutParameters tools.Parameters `yaml:"outParameters"`
+	// Arguments are the names of the parameters and out parameters in the
+	// order of the arguments of the procedure.
+	Arguments []string `yaml:"arguments"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	if err := sqlprocedure.CheckName(cfg.Procedure); err != nil {
+		return nil, err
+	}
+	args, err := sqlprocedure.Arguments(cfg.Arguments, cfg.Parameters, cfg.OutParameters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments of tool %q: %w", cfg.Name, err)
+	}
+
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AuthRequired: cfg.AuthRequired,
+		Pool:         s.MySQLPool(),
+		Arguments:    args,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: cfg.Parameters.McpManifest(),
+		},
+	}
+	t.SetStatement, t.CallStatement, t.SelectStatement = statements(cfg.Procedure, args)
+	return t, nil
+}
+
+// statements returns the statements of a procedure call. MySQL only returns
+// OUT parameters in user variables, so the OUT and INOUT arguments are user
+// variables, which are set before the call, and selected after it.
+func statements(procedure string, args []sqlprocedure.Argument) (set, call, selectOut string) {
+	var assignments, placeholders, variables []string
+	for i, a := range args {
+		if a.Out == nil {
+			placeholders = append(placeholders, "?")
+			continue
+		}
+		v := fmt.Sprintf("@toolbox_out_%d", i+1)
+		placeholders = append(placeholders, v)
+		variables = append(variables, v)
+		// OUT variables are reset, so that a value left on the connection by
+		// a previous call isn't returned
+		if a.In {
+			assignments = append(assignments, v+" = ?")
+		} else {
+			assignments = append(assignments, v+" = NULL")
+		}
+	}
+	if len(variables) != 0 {
+		set = "SET " + strings.Join(assignments, ", ")
+		selectOut = "SELECT " + strings.Join(variables, ", ")
+	}
+	call = fmt.Sprintf("CALL %s(%s)", procedure, strings.Join(placeholders, ", "))
+	return set, call, selectOut
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Pool *sql.DB
+	// SetStatement and SelectStatement set and select the user variables of
+	// the OUT and INOUT arguments, and are empty if there are none.
+	SetStatement    string
+	CallStatement   string
+	SelectStatement string
+	Arguments       []sqlprocedure.Argument
+	manifest        tools.Manifest
+	mcpManifest     tools.McpManifest
+}
+
+// Invoke calls the procedure, and returns the values of its OUT and INOUT
+// parameters, along with the result sets it returned.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	var setArgs, callArgs []any
+	for _, a := range t.Arguments {
+		switch {
+		case a.Out == nil:
+			callArgs = append(callArgs, paramsMap[a.Name])
+		case a.In:
+			setArgs = append(setArgs, paramsMap[a.Name])
+		}
+	}
+
+	// user variables belong to a connection, so the statements share one
+	conn, err := t.Pool.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if t.SetStatement != "" {
+		if _, err := conn.ExecContext(ctx, t.SetStatement, setArgs...); err != nil {
+			return nil, fmt.Errorf("unable to set out parameters: %w", err)
+		}
+	}
+	resultSets, err := call(ctx, conn, t.CallStatement, callArgs)
+	if err != nil {
+		return nil, err
+	}
+	result := sqlprocedure.Result{Out: map[string]any{}, ResultSets: resultSets}
+	if t.SelectStatement == "" {
+		return result, nil
+	}
+
+	var outArgs []sqlprocedure.Argument
+	for _, a := range t.Arguments {
+		if a.Out != nil {
+			outArgs = append(outArgs, a)
+		}
+	}
+	values := make([]any, len(outArgs))
+	ptrs := make([]any, len(outArgs))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	if err := conn.QueryRowContext(ctx, t.SelectStatement).Scan(ptrs...); err != nil {
+		return nil, fmt.Errorf("unable to get out parameters: %w", err)
+	}
+	for i, a := range outArgs {
+		v, err := sqlprocedure.OutValue(a.Out, values[i])
+		if err != nil {
+			return nil, err
+		}
+		result.Out[a.Name] = v
+	}
+	return result, nil
+}
+
+// call calls the procedure, and returns the rows of the result sets it
+// returned.
+func call(ctx context.Context, conn *sql.Conn, statement string, args []any) ([][]any, error) {
+	results, err := conn.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to call procedure: %w", err)
+	}
+	defer results.Close()
+
+	var resultSets [][]any
+	for {
+		cols, err := results.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+		}
+		colTypes, err := results.ColumnTypes()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get column types: %w", err)
+		}
+		rawValues := make([]any, len(cols))
+		values := make([]any, len(cols))
+		for i := range rawValues {
+			values[i] = &rawValues[i]
+		}
+
+		// the status of the call is a result set without columns
+		rows := []any{}
+		for results.Next() {
+			if err := results.Scan(values...); err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, name := range cols {
+				vMap[name] = mysqlcommon.Value(colTypes[i].DatabaseTypeName(), rawValues[i])
+			}
+			rows = append(rows, vMap)
+		}
+		if len(cols) != 0 {
+			resultSets = append(resultSets, rows)
+		}
+		if !results.NextResultSet() {
+			break
+		}
+	}
+	if err := results.Err(); err != nil {
+		return nil, fmt.Errorf("unable to call procedure: %w", err)
+	}
+	return resultSets, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
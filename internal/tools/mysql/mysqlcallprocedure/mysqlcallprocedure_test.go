@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlcallprocedure_test
+
+import (
+	"database/sql"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcallprocedure"
+)
+
+func TestParseFromYamlCallProcedure(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: mysql-call-procedure
+					source: my-mysql-instance
+					description: some description
+					procedure: restock
+					parameters:
+						- name: item
+						  type: string
+						  description: Item to restock.
+					outParameters:
+						- name: stock
+						  type: integer
+						  description: Stock after restocking.
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mysqlcallprocedure.Config{
+					Name:          "example_tool",
+					Kind:          "mysql-call-procedure",
+					Source:        "my-mysql-instance",
+					Description:   "some description",
+					Procedure:     "restock",
+					AuthRequired:  []string{},
+					Parameters:    tools.Parameters{tools.NewStringParameter("item", "Item to restock.")},
+					OutParameters: tools.Parameters{tools.NewIntParameter("stock", "Stock after restocking.")},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type fakeSource struct{}
+
+func (fakeSource) SourceKind() string {
+	return "mysql"
+}
+
+func (fakeSource) MySQLPool() *sql.DB {
+	return nil
+}
+
+func TestInitialize(t *testing.T) {
+	srcs := map[string]sources.Source{"my-mysql-instance": fakeSource{}}
+	item := tools.NewStringParameter("item", "Item to restock.")
+	quantity := tools.NewIntParameter("quantity", "Quantity to add.")
+	stock := tools.NewIntParameter("stock", "Stock after restocking.")
+
+	tcs := []struct {
+		desc       string
+		arguments  []string
+		in         tools.Parameters
+		out        tools.Parameters
+		wantSet    string
+		wantCall   string
+		wantSelect string
+	}{
+		{
+			desc:     "in only",
+			in:       tools.Parameters{item, quantity},
+			wantCall: "CALL shop.restock(?, ?)",
+		},
+		{
+			desc:       "in and out",
+			arguments:  []string{"stock", "item", "quantity"},
+			in:         tools.Parameters{item, quantity},
+			out:        tools.Parameters{stock},
+			wantSet:    "SET @toolbox_out_1 = NULL",
+			wantCall:   "CALL shop.restock(@toolbox_out_1, ?, ?)",
+			wantSelect: "SELECT @toolbox_out_1",
+		},
+		{
+			desc:       "inout",
+			in:         tools.Parameters{item, quantity},
+			out:        tools.Parameters{tools.NewIntParameter("quantity", "Quantity added."), stock},
+			wantSet:    "SET @toolbox_out_2 = ?, @toolbox_out_3 = NULL",
+			wantCall:   "CALL shop.restock(?, @toolbox_out_2, @toolbox_out_3)",
+			wantSelect: "SELECT @toolbox_out_2, @toolbox_out_3",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := mysqlcallprocedure.Config{
+				Name:          "example_tool",
+				Kind:          "mysql-call-procedure",
+				Source:        "my-mysql-instance",
+				Description:   "some description",
+				Procedure:     "shop.restock",
+				Parameters:    tc.in,
+				OutParameters: tc.out,
+				Arguments:     tc.arguments,
+			}
+			tool, err := cfg.Initialize(srcs)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := tool.(mysqlcallprocedure.Tool)
+			want := []string{tc.wantSet, tc.wantCall, tc.wantSelect}
+			if diff := cmp.Diff(want, []string{got.SetStatement, got.CallStatement, got.SelectStatement}); diff != "" {
+				t.Fatalf("incorrect statements: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package mysqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+)
+
+// Querier runs queries on a pool, on one of its connections, or in a
+// transaction.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Value returns a value of a column of a query result, of the given database
+// type, as it is returned to clients, see tools.Decimal.
+func Value(databaseType string, v any) any {
+	switch v := v.(type) {
+	case []byte:
+		// the driver reads the values of most types as bytes
+		switch databaseType {
+		case "TEXT", "VARCHAR", "NVARCHAR":
+			return string(v)
+		case "DECIMAL":
+			return tools.Decimal(v)
+		case "DATE":
+			return string(v)
+		case "DATETIME", "TIMESTAMP":
+			// "2006-01-02 15:04:05" unless the source parses times
+			return strings.Replace(string(v), " ", "T", 1)
+		case "BINARY", "VARBINARY", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB":
+			return tools.Bytes(v)
+		}
+	case time.Time:
+		switch databaseType {
+		case "DATE":
+			return tools.Date(v)
+		case "DATETIME":
+			return tools.DateTime(v)
+		}
+		return tools.Timestamp(v)
+	}
+	return v
+}
+
+// ParseMaxExecutionTime parses the maxExecutionTime of a tool config. An empty
+// maxExecutionTime doesn't limit the statements.
+func ParseMaxExecutionTime(maxExecutionTime string) (time.Duration, error) {
+	if maxExecutionTime == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(maxExecutionTime)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse maxExecutionTime as time.Duration: %w", err)
+	}
+	// a max_execution_time of 0 disables the timeout
+	if d < time.Millisecond {
+		return 0, fmt.Errorf("maxExecutionTime must be at least 1ms, got %s", d)
+	}
+	return d, nil
+}
+
+// SessionOptions are the settings of the session that a tool runs its
+// statements in.
+type SessionOptions struct {
+	// MaxExecutionTime is the max_execution_time of the session, if set, so
+	// that the server interrupts the SELECT statements that run longer.
+	MaxExecutionTime time.Duration
+	// ReadOnly runs the statements in a read-only transaction, so that the
+	// server rejects the statements that write data.
+	ReadOnly bool
+	// Session is the token of a session, see sqlsession, whose transaction
+	// run is called with instead. The other options apply to the statements
+	// of run only; since the transaction can't be made read-only, ReadOnly
+	// rolls back the statements instead.
+	Session string
+}
+
+// RunInSession calls run with the pool. If any of the options is set, run is
+// called instead with a connection with the options. The settings are reset
+// before the connection returns to the pool.
+func RunInSession(ctx context.Context, pool *sql.DB, opts SessionOptions, run func(Querier) (any, error)) (any, error) {
+	if opts == (SessionOptions{}) {
+		return run(pool)
+	}
+	if opts.Session != "" {
+		return sqlsession.Run(ctx, opts.Session, pool, func(tx *sql.Tx) (any, error) {
+			return runInSessionTx(ctx, tx, opts, run)
+		})
+	}
+
+	conn, err := pool.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if opts.MaxExecutionTime != 0 {
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("SET SESSION max_execution_time = %d", opts.MaxExecutionTime.Milliseconds())); err != nil {
+			return nil, fmt.Errorf("unable to set max_execution_time: %w", err)
+		}
+		defer func() {
+			// the reset must run even if ctx is done; a connection that can't
+			// be reset is discarded instead of returned to the pool
+			if _, err := conn.ExecContext(context.Background(), "SET SESSION max_execution_time = DEFAULT"); err != nil {
+				_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+			}
+		}()
+	}
+	if !opts.ReadOnly {
+		return run(conn)
+	}
+
+	// START TRANSACTION READ ONLY
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	result, err := run(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// runInSessionTx calls run with the transaction of a session, with the
+// options.
+func runInSessionTx(ctx context.Context, tx *sql.Tx, opts SessionOptions, run func(Querier) (any, error)) (result any, err error) {
+	if opts.MaxExecutionTime != 0 {
+		// the transaction keeps its connection until it ends, so that the
+		// previous max_execution_time is restored for its next statements
+		var previous int64
+		if err := tx.QueryRowContext(ctx, "SELECT @@SESSION.max_execution_time").Scan(&previous); err != nil {
+			return nil, fmt.Errorf("unable to read max_execution_time: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET SESSION max_execution_time = %d", opts.MaxExecutionTime.Milliseconds())); err != nil {
+			return nil, fmt.Errorf("unable to set max_execution_time: %w", err)
+		}
+		defer func() {
+			if _, resetErr := tx.ExecContext(context.Background(), fmt.Sprintf("SET SESSION max_execution_time = %d", previous)); resetErr != nil && err == nil {
+				result, err = nil, fmt.Errorf("unable to reset max_execution_time: %w", resetErr)
+			}
+		}()
+	}
+	if !opts.ReadOnly {
+		return run(tx)
+	}
+
+	// rolling back to a savepoint undoes the changes of the statements of run
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT toolbox_read_only"); err != nil {
+		return nil, fmt.Errorf("unable to create savepoint: %w", err)
+	}
+	defer func() {
+		if _, rollbackErr := tx.ExecContext(context.Background(), "ROLLBACK TO SAVEPOINT toolbox_read_only"); rollbackErr != nil && err == nil {
+			result, err = nil, fmt.Errorf("unable to roll back to savepoint: %w", rollbackErr)
+		}
+	}()
+	return run(tx)
+}
+
+// explainableTypes are the types of the statements that EXPLAIN describes
+// without running them.
+var explainableTypes = map[string]bool{
+	"SELECT":  true,
+	"TABLE":   true,
+	"INSERT":  true,
+	"REPLACE": true,
+	"UPDATE":  true,
+	"DELETE":  true,
+}
+
+// Explainable reports whether EXPLAIN describes statements of the type, as
+// returned by sqlparse, without running them.
+func Explainable(typ string) bool {
+	return explainableTypes[typ]
+}
+
+// ExplainStatement returns the EXPLAIN statement of a statement, for dry runs.
+// The server describes how it would run the statement without running it.
+// Statements can't be dry run by rolling back a transaction, since DDL
+// statements commit the transaction implicitly.
+func ExplainStatement(sql string) (string, error) {
+	statements, err := sqlparse.Parse(sql, sqlparse.MySQL)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse SQL: %w", err)
+	}
+	if len(statements) != 1 {
+		return "", fmt.Errorf("a dry run must have exactly 1 statement, got %d", len(statements))
+	}
+	// EXPLAIN ANALYZE runs the statement, so an EXPLAIN statement is never
+	// explained again
+	if typ := statements[0].Type(); !Explainable(typ) {
+		return "", fmt.Errorf("%s statements can't be dry run", typ)
+	}
+	return "EXPLAIN " + statements[0].Text, nil
+}
+
+// catalogQueries look up the objects of the catalog by their qualifier, the
+// schema of a table or the table of a column, and their name. Unqualified
+// names are looked up in the current database.
+var catalogQueries = map[string]string{
+	tools.CatalogTable: `SELECT 1 FROM information_schema.tables
+		WHERE LOWER(table_schema) = LOWER(COALESCE(NULLIF(?, ''), DATABASE()))
+		AND LOWER(table_name) = LOWER(?) LIMIT 1`,
+	tools.CatalogColumn: `SELECT 1 FROM information_schema.columns
+		WHERE table_schema = DATABASE() AND LOWER(table_name) = LOWER(COALESCE(NULLIF(?, ''), table_name))
+		AND LOWER(column_name) = LOWER(?) LIMIT 1`,
+}
+
+// CheckCatalog returns an error if one of the identifiers isn't found in the
+// information_schema of the database.
+func CheckCatalog(ctx context.Context, q Querier, ids []tools.CatalogIdentifier) error {
+	for _, id := range ids {
+		qualifier, name := id.Parts()
+		rows, err := q.QueryContext(ctx, catalogQueries[id.Catalog], qualifier, name)
+		if err != nil {
+			return fmt.Errorf("unable to look up %s %q: %w", id.Catalog, id.Name, err)
+		}
+		found := rows.Next()
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("unable to look up %s %q: %w", id.Catalog, id.Name, err)
+		}
+		if !found {
+			return fmt.Errorf("%s %q not found", id.Catalog, id.Name)
+		}
+	}
+	return nil
+}
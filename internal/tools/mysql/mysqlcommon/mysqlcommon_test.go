@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlcommon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
+)
+
+func TestParseMaxExecutionTime(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		in      string
+		want    time.Duration
+		wantErr string
+	}{
+		{
+			desc: "unset",
+			in:   "",
+			want: 0,
+		},
+		{
+			desc: "duration",
+			in:   "1m30s",
+			want: 90 * time.Second,
+		},
+		{
+			desc:    "invalid duration",
+			in:      "90",
+			wantErr: `unable to parse maxExecutionTime as time.Duration: time: missing unit in duration "90"`,
+		},
+		{
+			desc:    "too short",
+			in:      "500us",
+			wantErr: "maxExecutionTime must be at least 1ms, got 500µs",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := mysqlcommon.ParseMaxExecutionTime(tc.in)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected duration: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExplainStatement(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		in      string
+		want    string
+		wantErr string
+	}{
+		{
+			desc: "select",
+			in:   "SELECT * FROM flights WHERE id = ?;",
+			want: "EXPLAIN SELECT * FROM flights WHERE id = ?",
+		},
+		{
+			desc: "common table expression",
+			in:   "WITH f AS (SELECT id FROM flights) DELETE FROM bookings WHERE flight_id IN (SELECT id FROM f)",
+			want: "EXPLAIN WITH f AS (SELECT id FROM flights) DELETE FROM bookings WHERE flight_id IN (SELECT id FROM f)",
+		},
+		{
+			desc:    "ddl",
+			in:      "DROP TABLE flights",
+			wantErr: "DROP statements can't be dry run",
+		},
+		{
+			desc:    "explain analyze",
+			in:      "EXPLAIN ANALYZE DELETE FROM flights",
+			wantErr: "EXPLAIN statements can't be dry run",
+		},
+		{
+			desc:    "multiple statements",
+			in:      "SELECT 1; DELETE FROM flights",
+			wantErr: "a dry run must have exactly 1 statement, got 2",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := mysqlcommon.ExplainStatement(tc.in)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected statement: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tcs := []struct {
+		databaseType string
+		in           any
+		want         any
+	}{
+		{databaseType: "VARCHAR", in: []byte("widget"), want: "widget"},
+		{databaseType: "DECIMAL", in: []byte("12.50"), want: tools.Decimal("12.50")},
+		{databaseType: "DATETIME", in: []byte("2025-01-02 03:04:05"), want: "2025-01-02T03:04:05"},
+		{databaseType: "DATETIME", in: ts, want: "2025-01-02T03:04:05"},
+		{databaseType: "TIMESTAMP", in: ts, want: "2025-01-02T03:04:05Z"},
+		{databaseType: "DATE", in: ts, want: "2025-01-02"},
+		{databaseType: "BLOB", in: []byte("hi"), want: "aGk="},
+		{databaseType: "BIGINT", in: int64(7), want: int64(7)},
+		{databaseType: "DECIMAL", in: nil, want: nil},
+	}
+	for _, tc := range tcs {
+		if diff := cmp.Diff(tc.want, mysqlcommon.Value(tc.databaseType, tc.in)); diff != "" {
+			t.Fatalf("incorrect value of %s %v: diff %v", tc.databaseType, tc.in, diff)
+		}
+	}
+}
@@ -18,12 +18,18 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 )
 
 const kind string = "mysql-execute-sql"
@@ -53,11 +59,15 @@ var _ compatibleSource = &mysql.Source{}
 var compatibleSources = [...]string{cloudsqlmysql.SourceKind, mysql.SourceKind}
 
 type Config struct {
-	Name         string   `yaml:"name" validate:"required"`
-	Kind         string   `yaml:"kind" validate:"required"`
-	Source       string   `yaml:"source" validate:"required"`
-	Description  string   `yaml:"description" validate:"required"`
-	AuthRequired []string `yaml:"authRequired"`
+	Name             string            `yaml:"name" validate:"required"`
+	Kind             string            `yaml:"kind" validate:"required"`
+	Source           string            `yaml:"source" validate:"required"`
+	Description      string            `yaml:"description" validate:"required"`
+	MaxExecutionTime string            `yaml:"maxExecutionTime"`
+	ReadOnly         bool              `yaml:"readOnly"`
+	AllowSessions    bool              `yaml:"allowSessions"`
+	Policy           *sqlpolicy.Policy `yaml:"policy"`
+	AuthRequired     []string          `yaml:"authRequired"`
 }
 
 // validate interface
@@ -80,8 +90,22 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	maxExecutionTime, err := mysqlcommon.ParseMaxExecutionTime(cfg.MaxExecutionTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Policy != nil {
+		if err := cfg.Policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	sqlParameter := tools.NewStringParameter("sql", "The sql to execute.")
 	parameters := tools.Parameters{sqlParameter}
+	if cfg.AllowSessions {
+		parameters = append(parameters, sqlsession.Parameter())
+	}
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -91,19 +115,23 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         kind,
-		Parameters:   parameters,
-		AuthRequired: cfg.AuthRequired,
-		Pool:         s.MySQLPool(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
-		mcpManifest:  mcpManifest,
+		Name:             cfg.Name,
+		Kind:             kind,
+		Parameters:       parameters,
+		AuthRequired:     cfg.AuthRequired,
+		Pool:             s.MySQLPool(),
+		MaxExecutionTime: maxExecutionTime,
+		ReadOnly:         cfg.ReadOnly,
+		Policy:           cfg.Policy,
+		manifest:         tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:      mcpManifest,
 	}
 	return t, nil
 }
 
 // validate interface
 var _ tools.Tool = Tool{}
+var _ tools.DryRunner = Tool{}
 
 type Tool struct {
 	Name         string           `yaml:"name"`
@@ -111,72 +139,96 @@ type Tool struct {
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
 
-	Pool        *sql.DB
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Pool             *sql.DB
+	MaxExecutionTime time.Duration
+	ReadOnly         bool
+	Policy           *sqlpolicy.Policy
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, false)
+}
+
+// DryRun returns the EXPLAIN output of the statement, which describes how the
+// server would run it without running it.
+func (t Tool) DryRun(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, true)
+}
+
+func (t Tool) run(ctx context.Context, params tools.ParamValues, dryRun bool) (any, error) {
 	sliceParams := params.AsSlice()
 	sql, ok := sliceParams[0].(string)
 	if !ok {
 		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
 	}
 
-	results, err := t.Pool.QueryContext(ctx, sql)
-	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+	if t.ReadOnly {
+		if err := sqlparse.CheckReadOnly(sql, sqlparse.MySQL); err != nil {
+			return nil, err
+		}
 	}
-	defer results.Close()
-
-	cols, err := results.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+	if t.Policy != nil {
+		if err := t.Policy.Check(sql, sqlparse.MySQL); err != nil {
+			return nil, err
+		}
 	}
-
-	// create an array of values for each column, which can be re-used to scan each row
-	rawValues := make([]any, len(cols))
-	values := make([]any, len(cols))
-	for i := range rawValues {
-		values[i] = &rawValues[i]
+	if dryRun {
+		var err error
+		sql, err = mysqlcommon.ExplainStatement(sql)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	colTypes, err := results.ColumnTypes()
-	if err != nil {
-		return nil, fmt.Errorf("unable to get column types: %w", err)
-	}
+	opts := mysqlcommon.SessionOptions{MaxExecutionTime: t.MaxExecutionTime, ReadOnly: t.ReadOnly, Session: sqlsession.Token(params)}
+	return mysqlcommon.RunInSession(ctx, t.Pool, opts, func(q mysqlcommon.Querier) (any, error) {
+		results, err := q.QueryContext(ctx, sql)
+		if err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+		defer results.Close()
 
-	var out []any
-	for results.Next() {
-		err := results.Scan(values...)
+		cols, err := results.Columns()
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+		}
+
+		// create an array of values for each column, which can be re-used to scan each row
+		rawValues := make([]any, len(cols))
+		values := make([]any, len(cols))
+		for i := range rawValues {
+			values[i] = &rawValues[i]
 		}
-		vMap := make(map[string]any)
-		for i, name := range cols {
-			val := rawValues[i]
-			if val == nil {
-				vMap[name] = nil
-				continue
-			}
 
-			// mysql driver return []uint8 type for "TEXT", "VARCHAR", and "NVARCHAR"
-			// we'll need to cast it back to string
-			switch colTypes[i].DatabaseTypeName() {
-			case "TEXT", "VARCHAR", "NVARCHAR":
-				vMap[name] = string(val.([]byte))
-			default:
-				vMap[name] = val
+		colTypes, err := results.ColumnTypes()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get column types: %w", err)
+		}
+		if !dryRun {
+			util.ReportColumns(ctx, util.SQLColumns(colTypes))
+		}
+
+		var out []any
+		for results.Next() {
+			err := results.Scan(values...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
 			}
+			vMap := make(map[string]any)
+			for i, name := range cols {
+				vMap[name] = mysqlcommon.Value(colTypes[i].DatabaseTypeName(), rawValues[i])
+			}
+			out = append(out, vMap)
 		}
-		out = append(out, vMap)
-	}
 
-	if err := results.Err(); err != nil {
-		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
-	}
+		if err := results.Err(); err != nil {
+			return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
+		}
 
-	return out, nil
+		return out, nil
+	})
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
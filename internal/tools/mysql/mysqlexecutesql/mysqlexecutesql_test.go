@@ -56,6 +56,48 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "maxExecutionTime example",
+			in: `
+			tools:
+				example_tool:
+					kind: mysql-execute-sql
+					source: my-instance
+					description: some description
+					maxExecutionTime: 30s
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mysqlexecutesql.Config{
+					Name:             "example_tool",
+					Kind:             "mysql-execute-sql",
+					Source:           "my-instance",
+					Description:      "some description",
+					MaxExecutionTime: "30s",
+					AuthRequired:     []string{},
+				},
+			},
+		},
+		{
+			desc: "readOnly example",
+			in: `
+			tools:
+				example_tool:
+					kind: mysql-execute-sql
+					source: my-instance
+					description: some description
+					readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mysqlexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "mysql-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					ReadOnly:     true,
+					AuthRequired: []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysqlexplain_test
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlexplain"
+)
+
+func TestParseFromYamlMySQLExplain(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: mysql-explain
+					source: my-mysql-instance
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mysqlexplain.Config{
+					Name:         "example_tool",
+					Kind:         "mysql-explain",
+					Source:       "my-mysql-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "analyze example",
+			in: `
+			tools:
+				example_tool:
+					kind: mysql-explain
+					source: my-mysql-instance
+					description: some description
+					allowAnalyze: true
+					maxExecutionTime: 30s
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mysqlexplain.Config{
+					Name:             "example_tool",
+					Kind:             "mysql-explain",
+					Source:           "my-mysql-instance",
+					Description:      "some description",
+					AllowAnalyze:     true,
+					MaxExecutionTime: "30s",
+					AuthRequired:     []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestStatement(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		sql     string
+		analyze bool
+		want    string
+		wantErr string
+	}{
+		{
+			desc: "explain",
+			sql:  "SELECT * FROM orders WHERE id = 1;",
+			want: "EXPLAIN FORMAT=JSON SELECT * FROM orders WHERE id = 1",
+		},
+		{
+			desc: "explain update",
+			sql:  "UPDATE orders SET total = 0",
+			want: "EXPLAIN FORMAT=JSON UPDATE orders SET total = 0",
+		},
+		{
+			desc:    "analyze",
+			sql:     "SELECT * FROM orders",
+			analyze: true,
+			want:    "EXPLAIN ANALYZE FORMAT=JSON SELECT * FROM orders",
+		},
+		{
+			desc:    "analyze update",
+			sql:     "UPDATE orders SET total = 0",
+			analyze: true,
+			wantErr: "UPDATE statements can't be analyzed",
+		},
+		{
+			desc:    "ddl",
+			sql:     "DROP TABLE orders",
+			wantErr: "DROP statements can't be explained",
+		},
+		{
+			desc:    "several statements",
+			sql:     "SELECT 1; DROP TABLE orders",
+			wantErr: "exactly 1 statement",
+		},
+		{
+			desc:    "no statement",
+			sql:     "-- nothing",
+			wantErr: "exactly 1 statement",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := mysqlexplain.Statement(tc.sql, tc.analyze)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect statement: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+type fakeSource struct{}
+
+func (fakeSource) SourceKind() string {
+	return "mysql"
+}
+
+func (fakeSource) MySQLPool() *sql.DB {
+	return nil
+}
+
+func TestInitializeAnalyzeParameter(t *testing.T) {
+	srcs := map[string]sources.Source{"my-mysql-instance": fakeSource{}}
+	for _, allowAnalyze := range []bool{false, true} {
+		cfg := mysqlexplain.Config{
+			Name:         "example_tool",
+			Kind:         "mysql-explain",
+			Source:       "my-mysql-instance",
+			Description:  "some description",
+			AllowAnalyze: allowAnalyze,
+		}
+		tool, err := cfg.Initialize(srcs)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var got []string
+		for _, p := range tool.Manifest().Parameters {
+			got = append(got, p.Name)
+		}
+		want := []string{"sql"}
+		if allowAnalyze {
+			want = append(want, "analyze")
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("incorrect parameters with allowAnalyze %t: diff %v", allowAnalyze, diff)
+		}
+	}
+}
@@ -18,12 +18,20 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/mysql/mysqlcommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlstmtcache"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 )
 
 const kind string = "mysql-sql"
@@ -58,6 +66,9 @@ type Config struct {
 	Source             string           `yaml:"source" validate:"required"`
 	Description        string           `yaml:"description" validate:"required"`
 	Statement          string           `yaml:"statement" validate:"required"`
+	MaxExecutionTime   string           `yaml:"maxExecutionTime"`
+	AllowSessions      bool             `yaml:"allowSessions"`
+	PrepareStatement   bool             `yaml:"prepareStatement"`
 	AuthRequired       []string         `yaml:"authRequired"`
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
@@ -65,11 +76,18 @@ type Config struct {
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.CatalogChecker = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// CatalogParameters returns the template parameters, whose identifiers are
+// looked up in the catalog before they are substituted into the statement.
+func (cfg Config) CatalogParameters() tools.Parameters {
+	return cfg.TemplateParameters
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -83,7 +101,21 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
-	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	maxExecutionTime, err := mysqlcommon.ParseMaxExecutionTime(cfg.MaxExecutionTime)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := cfg.Parameters
+	if cfg.AllowSessions {
+		parameters = append(slices.Clone(cfg.Parameters), sqlsession.Parameter())
+	}
+	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, parameters)
+	claims, err := tools.BindPositionalClaims(cfg.Statement, allParameters, sqlparse.MySQL)
+	if err != nil {
+		return nil, err
+	}
+	allParameters = append(allParameters, claims.Parameters...)
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -98,17 +130,23 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Parameters:         cfg.Parameters,
 		TemplateParameters: cfg.TemplateParameters,
 		AllParams:          allParameters,
-		Statement:          cfg.Statement,
+		Statement:          claims.Statement,
+		Claims:             claims,
+		MaxExecutionTime:   maxExecutionTime,
 		AuthRequired:       cfg.AuthRequired,
 		Pool:               s.MySQLPool(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
 	}
+	if cfg.PrepareStatement {
+		t.Stmts = sqlstmtcache.New(t.Pool)
+	}
 	return t, nil
 }
 
 // validate interface
 var _ tools.Tool = Tool{}
+var _ tools.DryRunner = Tool{}
 
 type Tool struct {
 	Name               string           `yaml:"name"`
@@ -118,14 +156,30 @@ type Tool struct {
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 	AllParams          tools.Parameters `yaml:"allParams"`
 
-	Pool        *sql.DB
-	Statement   string
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Pool             *sql.DB
+	Stmts            *sqlstmtcache.Cache
+	Statement        string
+	Claims           tools.ClaimBinding
+	MaxExecutionTime time.Duration
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, false)
+}
+
+// DryRun returns the EXPLAIN output of the statement, which describes how the
+// server would run it without running it.
+func (t Tool) DryRun(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, true)
+}
+
+func (t Tool) run(ctx context.Context, params tools.ParamValues, dryRun bool) (any, error) {
 	paramsMap := params.AsMap()
+	if err := mysqlcommon.CheckCatalog(ctx, t.Pool, tools.CatalogIdentifiers(t.TemplateParameters, paramsMap)); err != nil {
+		return nil, err
+	}
 	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
@@ -136,61 +190,118 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
 
-	sliceParams := newParams.AsSlice()
-	results, err := t.Pool.QueryContext(ctx, newStatement, sliceParams...)
+	newStatement, sliceParams, err := expandArrayInLists(newStatement, t.Claims.Args(newParams.AsSlice(), paramsMap))
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return nil, fmt.Errorf("unable to expand array params: %w", err)
 	}
-
-	cols, err := results.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+	if dryRun {
+		newStatement, err = mysqlcommon.ExplainStatement(newStatement)
+		if err != nil {
+			return nil, err
+		}
 	}
+	return mysqlcommon.RunInSession(ctx, t.Pool, mysqlcommon.SessionOptions{MaxExecutionTime: t.MaxExecutionTime, Session: sqlsession.Token(params)}, func(q mysqlcommon.Querier) (any, error) {
+		// statements are only prepared once on the pool, not in sessions
+		if _, onPool := q.(*sql.DB); onPool && t.Stmts != nil && !dryRun {
+			q = t.Stmts
+		}
+		results, err := q.QueryContext(ctx, newStatement, sliceParams...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
 
-	// create an array of values for each column, which can be re-used to scan each row
-	rawValues := make([]any, len(cols))
-	values := make([]any, len(cols))
-	for i := range rawValues {
-		values[i] = &rawValues[i]
-	}
-	defer results.Close()
+		cols, err := results.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve rows column name: %w", err)
+		}
 
-	colTypes, err := results.ColumnTypes()
-	if err != nil {
-		return nil, fmt.Errorf("unable to get column types: %w", err)
-	}
+		// create an array of values for each column, which can be re-used to scan each row
+		rawValues := make([]any, len(cols))
+		values := make([]any, len(cols))
+		for i := range rawValues {
+			values[i] = &rawValues[i]
+		}
+		defer results.Close()
 
-	var out []any
-	for results.Next() {
-		err := results.Scan(values...)
+		colTypes, err := results.ColumnTypes()
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return nil, fmt.Errorf("unable to get column types: %w", err)
 		}
-		vMap := make(map[string]any)
-		for i, name := range cols {
-			val := rawValues[i]
-			if val == nil {
-				vMap[name] = nil
+		if !dryRun {
+			util.ReportColumns(ctx, util.SQLColumns(colTypes))
+		}
+
+		// the rows are written as they're read if the client streams them
+		write, stream := util.RowWriterFromContext(ctx)
+		var out []any
+		for results.Next() {
+			err := results.Scan(values...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, name := range cols {
+				vMap[name] = mysqlcommon.Value(colTypes[i].DatabaseTypeName(), rawValues[i])
+			}
+			if stream {
+				if err := write(vMap); err != nil {
+					return nil, err
+				}
 				continue
 			}
+			out = append(out, vMap)
+		}
 
-			// mysql driver return []uint8 type for "TEXT", "VARCHAR", and "NVARCHAR"
-			// we'll need to cast it back to string
-			switch colTypes[i].DatabaseTypeName() {
-			case "TEXT", "VARCHAR", "NVARCHAR":
-				vMap[name] = string(val.([]byte))
-			default:
-				vMap[name] = val
-			}
+		if err := results.Err(); err != nil {
+			return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
 		}
-		out = append(out, vMap)
-	}
+		if stream {
+			return nil, nil
+		}
+
+		return out, nil
+	})
+}
 
-	if err := results.Err(); err != nil {
-		return nil, fmt.Errorf("errors encountered during row iteration: %w", err)
+// expandArrayInLists expands the IN lists of array arguments, e.g. IN (?) to
+// IN (?, ?, ?) for an array of 3 elements, since the driver can't bind arrays.
+func expandArrayInLists(statement string, args []any) (string, []any, error) {
+	if !slices.ContainsFunc(args, isArray) {
+		return statement, args, nil
 	}
+	expanded := make([]any, 0, len(args))
+	next := 0
+	statement, err := sqlparse.RewritePlaceholders(statement, sqlparse.MySQL, func(p sqlparse.Placeholder) (string, error) {
+		if p.Value != "?" || next == len(args) {
+			return p.Text, nil
+		}
+		arg := args[next]
+		next++
+		values, ok := arg.([]any)
+		if !p.InList || !ok {
+			expanded = append(expanded, arg)
+			return p.Text, nil
+		}
+		in := "IN"
+		if p.Not {
+			in = "NOT IN"
+		}
+		if len(values) == 0 {
+			// IN () isn't valid, but an empty subquery has the same result
+			return in + " (SELECT NULL FROM DUAL WHERE FALSE)", nil
+		}
+		expanded = append(expanded, values...)
+		return in + " (?" + strings.Repeat(", ?", len(values)-1) + ")", nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return statement, append(expanded, args[next:]...), nil
+}
 
-	return out, nil
+func isArray(v any) bool {
+	_, ok := v.([]any)
+	return ok
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
@@ -74,6 +74,30 @@ func TestParseFromYamlMySQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "maxExecutionTime example",
+			in: `
+			tools:
+				example_tool:
+					kind: mysql-sql
+					source: my-instance
+					description: some description
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+					maxExecutionTime: 30s
+			`,
+			want: server.ToolConfigs{
+				"example_tool": mysqlsql.Config{
+					Name:             "example_tool",
+					Kind:             "mysql-sql",
+					Source:           "my-instance",
+					Description:      "some description",
+					Statement:        "SELECT * FROM SQL_STATEMENT;\n",
+					MaxExecutionTime: "30s",
+					AuthRequired:     []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
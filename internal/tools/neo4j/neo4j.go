@@ -60,6 +60,7 @@ type Config struct {
 	Statement    string           `yaml:"statement" validate:"required"`
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
+	ReadOnly     bool             `yaml:"readOnly"`
 }
 
 // validate interface
@@ -94,6 +95,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Kind:         kind,
 		Parameters:   cfg.Parameters,
 		Statement:    cfg.Statement,
+		ReadOnly:     cfg.ReadOnly,
 		AuthRequired: cfg.AuthRequired,
 		Driver:       s.Neo4jDriver(),
 		Database:     s.Neo4jDatabase(),
@@ -115,6 +117,7 @@ type Tool struct {
 	Driver      neo4j.DriverWithContext
 	Database    string
 	Statement   string
+	ReadOnly    bool
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
@@ -122,9 +125,14 @@ type Tool struct {
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMap()
 
-	config := neo4j.ExecuteQueryWithDatabase(t.Database)
+	config := []neo4j.ExecuteQueryConfigurationOption{neo4j.ExecuteQueryWithDatabase(t.Database)}
+	if t.ReadOnly {
+		// Run the statement in a read transaction, in which the server
+		// rejects writes, on the readers of the cluster.
+		config = append(config, neo4j.ExecuteQueryWithReadersRouting())
+	}
 	results, err := neo4j.ExecuteQuery[*neo4j.EagerResult](ctx, t.Driver, t.Statement, paramsMap,
-		neo4j.EagerResultTransformer, config)
+		neo4j.EagerResultTransformer, config...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -67,6 +67,30 @@ func TestParseFromYamlNeo4j(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "read only",
+			in: `
+			tools:
+				example_tool:
+					kind: neo4j-cypher
+					source: my-neo4j-instance
+					description: some tool description
+					readOnly: true
+					statement: |
+						MATCH (c:Country) RETURN c.name as name;
+			`,
+			want: server.ToolConfigs{
+				"example_tool": neo4j.Config{
+					Name:         "example_tool",
+					Kind:         "neo4j-cypher",
+					Source:       "my-neo4j-instance",
+					Description:  "some tool description",
+					AuthRequired: []string{},
+					Statement:    "MATCH (c:Country) RETURN c.name as name;\n",
+					ReadOnly:     true,
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
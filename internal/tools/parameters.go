@@ -19,28 +19,99 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
 const (
-	typeString = "string"
-	typeInt    = "integer"
-	typeFloat  = "float"
-	typeBool   = "boolean"
-	typeArray  = "array"
+	typeString    = "string"
+	typeInt       = "integer"
+	typeFloat     = "float"
+	typeBool      = "boolean"
+	typeArray     = "array"
+	typeDate      = "date"
+	typeTimestamp = "timestamp"
+	typeDecimal   = "decimal"
+	typeEnum      = "enum"
+	typeObject    = "object"
 )
 
 // ParamValues is an ordered list of ParamValue
 type ParamValues []ParamValue
 
+// RedactedValue replaces the values of sensitive parameters wherever they
+// would be shown, such as logs and error messages.
+const RedactedValue = "[REDACTED]"
+
 // ParamValue represents the parameter's name and value.
 type ParamValue struct {
 	Name  string
 	Value any
+	// Sensitive is true if the value must not be shown. It is still passed
+	// to the tool.
+	Sensitive bool
+}
+
+// String formats the parameter for logs, with its value redacted if it is
+// sensitive.
+func (p ParamValue) String() string {
+	if p.Sensitive {
+		return fmt.Sprintf("{%s %s}", p.Name, RedactedValue)
+	}
+	return fmt.Sprintf("{%s %v}", p.Name, p.Value)
+}
+
+// RedactError returns err with the values of the sensitive parameters
+// removed from its message. The returned error still wraps err.
+func (p ParamValues) RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var values []any
+	for _, param := range p {
+		if param.Sensitive {
+			values = append(values, param.Value)
+		}
+	}
+	return redactError(err, values...)
+}
+
+// redactedError is an error whose message has sensitive values removed.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e redactedError) Error() string {
+	return e.msg
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactError returns err with the given values removed from its message.
+func redactError(err error, values ...any) error {
+	msg := err.Error()
+	redacted := msg
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if s := fmt.Sprint(v); s != "" {
+			redacted = strings.ReplaceAll(redacted, s, RedactedValue)
+		}
+	}
+	if redacted == msg {
+		return err
+	}
+	return redactedError{msg: redacted, err: err}
 }
 
 // AsSlice returns a slice of the Param's values (in order).
@@ -122,6 +193,11 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 		var err error
 		paramAuthServices := p.GetAuthServices()
 		name := p.GetName()
+		if p.GetExpression() != "" {
+			// computed parameters are evaluated once the others are parsed
+			params = append(params, ParamValue{Name: name, Sensitive: p.GetSensitive()})
+			continue
+		}
 		if len(paramAuthServices) == 0 {
 			// parse non auth-required parameter
 			var ok bool
@@ -143,10 +219,24 @@ func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[st
 		if v != nil {
 			newV, err = p.Parse(v)
 			if err != nil {
-				return nil, fmt.Errorf("unable to parse value for %q: %w", name, err)
+				err = fmt.Errorf("unable to parse value for %q: %w", name, err)
+				if p.GetSensitive() {
+					err = redactError(err, v)
+				}
+				return nil, err
 			}
 		}
-		params = append(params, ParamValue{Name: name, Value: newV})
+		params = append(params, ParamValue{Name: name, Value: newV, Sensitive: p.GetSensitive()})
+	}
+	for i, p := range ps {
+		if p.GetExpression() == "" {
+			continue
+		}
+		v, err := computeParam(p, ParamValues(params).AsMap())
+		if err != nil {
+			return nil, ParamValues(params).RedactError(fmt.Errorf("unable to compute value for %q: %w", p.GetName(), err))
+		}
+		params[i].Value = v
 	}
 	return params, nil
 }
@@ -258,6 +348,12 @@ type Parameter interface {
 	GetDefault() any
 	GetRequired() bool
 	GetAuthServices() []ParamAuthService
+	// GetExpression returns the template that computes the value of the
+	// parameter on the server, or "" if the value is given by the client.
+	GetExpression() string
+	// GetSensitive returns whether the value of the parameter must be
+	// redacted from logs, traces, and error messages.
+	GetSensitive() bool
 	Parse(any) (any, error)
 	Manifest() ParameterManifest
 	McpManifest() ParameterMcpManifest
@@ -284,6 +380,9 @@ func (c *Parameters) UnmarshalYAML(ctx context.Context, unmarshal func(interface
 		if err != nil {
 			return err
 		}
+		if err := validateExpression(p); err != nil {
+			return err
+		}
 		(*c) = append((*c), p)
 	}
 	return nil
@@ -317,6 +416,14 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
 		}
+		if err := a.validate(); err != nil {
+			return nil, err
+		}
+		if a.Default != nil {
+			if err := a.checkConstraints(*a.Default); err != nil {
+				return nil, fmt.Errorf("invalid default value for %q: %w", a.Name, err)
+			}
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -328,6 +435,14 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
 		}
+		if err := validateRange(a.Minimum, a.Maximum); err != nil {
+			return nil, fmt.Errorf("invalid range for %q: %w", a.Name, err)
+		}
+		if a.Default != nil {
+			if err := checkRange(float64(*a.Default), a.Minimum, a.Maximum); err != nil {
+				return nil, fmt.Errorf("invalid default value for %q: %w", a.Name, err)
+			}
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -339,6 +454,14 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 		if err := dec.DecodeContext(ctx, a); err != nil {
 			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
 		}
+		if err := validateRange(a.Minimum, a.Maximum); err != nil {
+			return nil, fmt.Errorf("invalid range for %q: %w", a.Name, err)
+		}
+		if a.Default != nil {
+			if err := checkRange(float64(*a.Default), a.Minimum, a.Maximum); err != nil {
+				return nil, fmt.Errorf("invalid default value for %q: %w", a.Name, err)
+			}
+		}
 		if a.AuthSources != nil {
 			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
 			a.AuthServices = append(a.AuthServices, a.AuthSources...)
@@ -367,6 +490,86 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 			a.AuthSources = nil
 		}
 		return a, nil
+	case typeDate:
+		a := &DateParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		if a.Default != nil {
+			if _, err := a.Parse(*a.Default); err != nil {
+				return nil, fmt.Errorf("invalid default value for %q: %w", a.Name, err)
+			}
+		}
+		return a, nil
+	case typeTimestamp:
+		a := &TimestampParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		if a.Default != nil {
+			if _, err := a.Parse(*a.Default); err != nil {
+				return nil, fmt.Errorf("invalid default value for %q: %w", a.Name, err)
+			}
+		}
+		return a, nil
+	case typeDecimal:
+		a := &DecimalParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		if a.Default != nil {
+			if _, err := a.Parse(*a.Default); err != nil {
+				return nil, fmt.Errorf("invalid default value for %q: %w", a.Name, err)
+			}
+		}
+		return a, nil
+	case typeObject:
+		a := &ObjectParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		if a.Default != nil {
+			if _, err := a.Parse(*a.Default); err != nil {
+				return nil, fmt.Errorf("invalid default value for %q: %w", a.Name, err)
+			}
+		}
+		return a, nil
+	case typeEnum:
+		a := &EnumParameter{}
+		if err := dec.DecodeContext(ctx, a); err != nil {
+			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+		}
+		if a.AuthSources != nil {
+			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+			a.AuthServices = append(a.AuthServices, a.AuthSources...)
+			a.AuthSources = nil
+		}
+		if a.Default != nil {
+			if _, err := a.Parse(*a.Default); err != nil {
+				return nil, fmt.Errorf("invalid default value for %q: %w", a.Name, err)
+			}
+		}
+		return a, nil
 	}
 	return nil, fmt.Errorf("%q is not valid type for a parameter", t)
 }
@@ -374,7 +577,13 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 func (ps Parameters) Manifest() []ParameterManifest {
 	rtn := make([]ParameterManifest, 0, len(ps))
 	for _, p := range ps {
-		rtn = append(rtn, p.Manifest())
+		// computed parameters are set by the server, not by the client
+		if p.GetExpression() != "" {
+			continue
+		}
+		m := p.Manifest()
+		m.Default = p.GetDefault()
+		rtn = append(rtn, m)
 	}
 	return rtn
 }
@@ -384,8 +593,14 @@ func (ps Parameters) McpManifest() McpToolsSchema {
 	required := make([]string, 0)
 
 	for _, p := range ps {
+		if p.GetExpression() != "" {
+			continue
+		}
 		name := p.GetName()
-		properties[name] = p.McpManifest()
+		m := p.McpManifest()
+		// the default value is visible to the model, which may omit the parameter
+		m.Default = p.GetDefault()
+		properties[name] = m
 		// parameters that doesn't have a default value are added to the required field
 		if CheckParamRequired(p.GetRequired(), p.GetDefault()) {
 			required = append(required, name)
@@ -401,19 +616,36 @@ func (ps Parameters) McpManifest() McpToolsSchema {
 
 // ParameterManifest represents parameters when served as part of a ToolManifest.
 type ParameterManifest struct {
-	Name         string             `json:"name"`
-	Type         string             `json:"type"`
-	Required     bool               `json:"required"`
-	Description  string             `json:"description"`
-	AuthServices []string           `json:"authSources"`
-	Items        *ParameterManifest `json:"items,omitempty"`
+	Name         string              `json:"name"`
+	Type         string              `json:"type"`
+	Required     bool                `json:"required"`
+	Description  string              `json:"description"`
+	AuthServices []string            `json:"authSources"`
+	Default      any                 `json:"default,omitempty"`
+	Items        *ParameterManifest  `json:"items,omitempty"`
+	Properties   []ParameterManifest `json:"properties,omitempty"`
 }
 
 // ParameterMcpManifest represents properties when served as part of a ToolMcpManifest.
 type ParameterMcpManifest struct {
 	Type        string                `json:"type"`
 	Description string                `json:"description"`
+	Default     any                   `json:"default,omitempty"`
+	Format      string                `json:"format,omitempty"`
+	Pattern     string                `json:"pattern,omitempty"`
+	Enum        []string              `json:"enum,omitempty"`
+	Minimum     *float64              `json:"minimum,omitempty"`
+	Maximum     *float64              `json:"maximum,omitempty"`
+	MinLength   *int                  `json:"minLength,omitempty"`
+	MaxLength   *int                  `json:"maxLength,omitempty"`
 	Items       *ParameterMcpManifest `json:"items,omitempty"`
+	MinItems    *int                  `json:"minItems,omitempty"`
+	MaxItems    *int                  `json:"maxItems,omitempty"`
+	// Properties, Required and AdditionalProperties describe the properties
+	// of an object.
+	Properties           map[string]ParameterMcpManifest `json:"properties,omitempty"`
+	Required             []string                        `json:"required,omitempty"`
+	AdditionalProperties *bool                           `json:"additionalProperties,omitempty"`
 }
 
 // CommonParameter are default fields that are emebdding in most Parameter implementations. Embedding this stuct will give the object Name() and Type() functions.
@@ -424,6 +656,8 @@ type CommonParameter struct {
 	Required     *bool              `yaml:"required"`
 	AuthServices []ParamAuthService `yaml:"authServices"`
 	AuthSources  []ParamAuthService `yaml:"authSources"` // Deprecated: Kept for compatibility.
+	Expression   string             `yaml:"expression"`
+	Sensitive    bool               `yaml:"sensitive"`
 }
 
 // GetName returns the name specified for the Parameter.
@@ -431,6 +665,16 @@ func (p *CommonParameter) GetName() string {
 	return p.Name
 }
 
+// GetExpression returns the expression of a computed Parameter.
+func (p *CommonParameter) GetExpression() string {
+	return p.Expression
+}
+
+// GetSensitive returns whether the value of the Parameter must be redacted.
+func (p *CommonParameter) GetSensitive() bool {
+	return p.Sensitive
+}
+
 // GetType returns the type specified for the Parameter.
 func (p *CommonParameter) GetType() string {
 	return p.Type
@@ -519,12 +763,29 @@ func NewStringParameterWithAuth(name string, desc string, authServices []ParamAu
 	}
 }
 
+// NewStringParameterWithIdentifier is a convenience function for initializing a StringParameter whose values must be identifiers.
+func NewStringParameterWithIdentifier(name string, desc string, identifier *Identifier) *StringParameter {
+	return &StringParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeString,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Identifier: identifier,
+	}
+}
+
 var _ Parameter = &StringParameter{}
 
 // StringParameter is a parameter representing the "string" type.
 type StringParameter struct {
 	CommonParameter `yaml:",inline"`
-	Default         *string `yaml:"default"`
+	Default         *string     `yaml:"default"`
+	Identifier      *Identifier `yaml:"identifier"`
+	Pattern         string      `yaml:"pattern"`
+	MinLength       *int        `yaml:"minLength"`
+	MaxLength       *int        `yaml:"maxLength"`
 }
 
 // Parse casts the value "v" as a "string".
@@ -533,6 +794,14 @@ func (p *StringParameter) Parse(v any) (any, error) {
 	if !ok {
 		return nil, &ParseTypeError{p.Name, p.Type, v}
 	}
+	if p.Identifier != nil {
+		if err := p.Identifier.check(newV); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.checkConstraints(newV); err != nil {
+		return nil, err
+	}
 	return newV, nil
 }
 
@@ -564,6 +833,17 @@ func (p *StringParameter) Manifest() ParameterManifest {
 	}
 }
 
+// McpManifest returns the MCP manifest for the StringParameter.
+func (p *StringParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        p.Type,
+		Description: p.Desc,
+		Pattern:     p.Pattern,
+		MinLength:   p.MinLength,
+		MaxLength:   p.MaxLength,
+	}
+}
+
 // NewIntParameter is a convenience function for initializing a IntParameter.
 func NewIntParameter(name string, desc string) *IntParameter {
 	return &IntParameter{
@@ -619,7 +899,9 @@ var _ Parameter = &IntParameter{}
 // IntParameter is a parameter representing the "int" type.
 type IntParameter struct {
 	CommonParameter `yaml:",inline"`
-	Default         *int `yaml:"default"`
+	Default         *int     `yaml:"default"`
+	Minimum         *float64 `yaml:"minimum"`
+	Maximum         *float64 `yaml:"maximum"`
 }
 
 func (p *IntParameter) Parse(v any) (any, error) {
@@ -640,6 +922,9 @@ func (p *IntParameter) Parse(v any) (any, error) {
 		}
 		out = int(newI)
 	}
+	if err := checkRange(float64(out), p.Minimum, p.Maximum); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
@@ -671,6 +956,16 @@ func (p *IntParameter) Manifest() ParameterManifest {
 	}
 }
 
+// McpManifest returns the MCP manifest for the IntParameter.
+func (p *IntParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        p.Type,
+		Description: p.Desc,
+		Minimum:     p.Minimum,
+		Maximum:     p.Maximum,
+	}
+}
+
 // NewFloatParameter is a convenience function for initializing a FloatParameter.
 func NewFloatParameter(name string, desc string) *FloatParameter {
 	return &FloatParameter{
@@ -727,6 +1022,8 @@ var _ Parameter = &FloatParameter{}
 type FloatParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *float64 `yaml:"default"`
+	Minimum         *float64 `yaml:"minimum"`
+	Maximum         *float64 `yaml:"maximum"`
 }
 
 func (p *FloatParameter) Parse(v any) (any, error) {
@@ -745,6 +1042,9 @@ func (p *FloatParameter) Parse(v any) (any, error) {
 		}
 		out = float64(newI)
 	}
+	if err := checkRange(out, p.Minimum, p.Maximum); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
@@ -776,6 +1076,16 @@ func (p *FloatParameter) Manifest() ParameterManifest {
 	}
 }
 
+// McpManifest returns the MCP manifest for the FloatParameter.
+func (p *FloatParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        p.Type,
+		Description: p.Desc,
+		Minimum:     p.Minimum,
+		Maximum:     p.Maximum,
+	}
+}
+
 // NewBooleanParameter is a convenience function for initializing a BooleanParameter.
 func NewBooleanParameter(name string, desc string) *BooleanParameter {
 	return &BooleanParameter{
@@ -870,6 +1180,521 @@ func (p *BooleanParameter) Manifest() ParameterManifest {
 	}
 }
 
+// decimalPattern matches the decimal numbers accepted by a DecimalParameter.
+const decimalPattern = `^[+-]?(\d+(\.\d*)?|\.\d+)$`
+
+var decimalRegexp = regexp.MustCompile(decimalPattern)
+
+// manifest returns the manifest of a parameter with the given default value.
+func (p *CommonParameter) manifest(defaultV any) ParameterManifest {
+	// only list ParamAuthService names (without fields) in manifest
+	authNames := make([]string, len(p.AuthServices))
+	for i, a := range p.AuthServices {
+		authNames[i] = a.Name
+	}
+	return ParameterManifest{
+		Name:         p.Name,
+		Type:         p.Type,
+		Required:     CheckParamRequired(p.GetRequired(), defaultV),
+		Description:  p.Desc,
+		AuthServices: authNames,
+	}
+}
+
+// NewDateParameter is a convenience function for initializing a DateParameter.
+func NewDateParameter(name string, desc string) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDate,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDateParameterWithDefault is a convenience function for initializing a DateParameter with default value.
+func NewDateParameterWithDefault(name string, defaultV string, desc string) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDate,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewDateParameterWithRequired is a convenience function for initializing a DateParameter.
+func NewDateParameterWithRequired(name string, desc string, required bool) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDate,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+var _ Parameter = &DateParameter{}
+
+// DateParameter is a parameter representing the "date" type. Its values are
+// dates in the format YYYY-MM-DD, parsed to a time.Time at midnight UTC.
+type DateParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *DateParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a date in the format YYYY-MM-DD", s)
+	}
+	return d, nil
+}
+
+func (p *DateParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *DateParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the DateParameter.
+func (p *DateParameter) Manifest() ParameterManifest {
+	return p.manifest(p.GetDefault())
+}
+
+// McpManifest returns the MCP manifest for the DateParameter.
+func (p *DateParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        typeString,
+		Description: p.Desc,
+		Format:      "date",
+	}
+}
+
+// NewTimestampParameter is a convenience function for initializing a TimestampParameter.
+func NewTimestampParameter(name string, desc string) *TimestampParameter {
+	return &TimestampParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeTimestamp,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewTimestampParameterWithDefault is a convenience function for initializing a TimestampParameter with default value.
+func NewTimestampParameterWithDefault(name string, defaultV string, desc string) *TimestampParameter {
+	return &TimestampParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeTimestamp,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewTimestampParameterWithRequired is a convenience function for initializing a TimestampParameter.
+func NewTimestampParameterWithRequired(name string, desc string, required bool) *TimestampParameter {
+	return &TimestampParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeTimestamp,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+var _ Parameter = &TimestampParameter{}
+
+// TimestampParameter is a parameter representing the "timestamp" type. Its
+// values are RFC 3339 timestamps, e.g. 2025-01-02T15:04:05Z, parsed to a
+// time.Time.
+type TimestampParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *TimestampParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a timestamp in RFC 3339 format, e.g. 2025-01-02T15:04:05Z", s)
+	}
+	return t, nil
+}
+
+func (p *TimestampParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *TimestampParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the TimestampParameter.
+func (p *TimestampParameter) Manifest() ParameterManifest {
+	return p.manifest(p.GetDefault())
+}
+
+// McpManifest returns the MCP manifest for the TimestampParameter.
+func (p *TimestampParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        typeString,
+		Description: p.Desc,
+		Format:      "date-time",
+	}
+}
+
+// NewDecimalParameter is a convenience function for initializing a DecimalParameter.
+func NewDecimalParameter(name string, desc string) *DecimalParameter {
+	return &DecimalParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDecimal,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+	}
+}
+
+// NewDecimalParameterWithDefault is a convenience function for initializing a DecimalParameter with default value.
+func NewDecimalParameterWithDefault(name string, defaultV string, desc string) *DecimalParameter {
+	return &DecimalParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDecimal,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+	}
+}
+
+// NewDecimalParameterWithRequired is a convenience function for initializing a DecimalParameter.
+func NewDecimalParameterWithRequired(name string, desc string, required bool) *DecimalParameter {
+	return &DecimalParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeDecimal,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+	}
+}
+
+var _ Parameter = &DecimalParameter{}
+
+// DecimalParameter is a parameter representing the "decimal" type. Its
+// values are exact decimal numbers, e.g. 12.50, kept as strings so that no
+// precision is lost.
+type DecimalParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string `yaml:"default"`
+}
+
+func (p *DecimalParameter) Parse(v any) (any, error) {
+	var s string
+	switch newV := v.(type) {
+	default:
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	case string:
+		s = newV
+	case json.Number:
+		s = newV.String()
+		if strings.ContainsAny(s, "eE") {
+			f, err := newV.Float64()
+			if err != nil {
+				return nil, &ParseTypeError{p.Name, p.Type, v}
+			}
+			s = strconv.FormatFloat(f, 'f', -1, 64)
+		}
+	case int:
+		s = strconv.Itoa(newV)
+	case float64:
+		s = strconv.FormatFloat(newV, 'f', -1, 64)
+	}
+	if !decimalRegexp.MatchString(s) {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	return s, nil
+}
+
+func (p *DecimalParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *DecimalParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the DecimalParameter.
+func (p *DecimalParameter) Manifest() ParameterManifest {
+	return p.manifest(p.GetDefault())
+}
+
+// McpManifest returns the MCP manifest for the DecimalParameter.
+func (p *DecimalParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        typeString,
+		Description: p.Desc,
+		Pattern:     decimalPattern,
+	}
+}
+
+// NewEnumParameter is a convenience function for initializing a EnumParameter.
+func NewEnumParameter(name string, desc string, values []string) *EnumParameter {
+	return &EnumParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeEnum,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Values: values,
+	}
+}
+
+// NewEnumParameterWithDefault is a convenience function for initializing a EnumParameter with default value.
+func NewEnumParameterWithDefault(name string, defaultV string, desc string, values []string) *EnumParameter {
+	return &EnumParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeEnum,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default: &defaultV,
+		Values:  values,
+	}
+}
+
+var _ Parameter = &EnumParameter{}
+
+// EnumParameter is a parameter representing the "enum" type. Its values are
+// one of a list of allowed strings.
+type EnumParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *string  `yaml:"default"`
+	Values          []string `yaml:"values" validate:"required,min=1"`
+}
+
+func (p *EnumParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if !slices.Contains(p.Values, s) {
+		return nil, fmt.Errorf("%q is not one of %q", s, p.Values)
+	}
+	return s, nil
+}
+
+func (p *EnumParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *EnumParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+// Manifest returns the manifest for the EnumParameter.
+func (p *EnumParameter) Manifest() ParameterManifest {
+	return p.manifest(p.GetDefault())
+}
+
+// McpManifest returns the MCP manifest for the EnumParameter.
+func (p *EnumParameter) McpManifest() ParameterMcpManifest {
+	return ParameterMcpManifest{
+		Type:        typeString,
+		Description: p.Desc,
+		Enum:        p.Values,
+	}
+}
+
+// NewObjectParameter is a convenience function for initializing a ObjectParameter.
+func NewObjectParameter(name string, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeObject,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Properties: properties,
+	}
+}
+
+// NewObjectParameterWithDefault is a convenience function for initializing a ObjectParameter with default value.
+func NewObjectParameterWithDefault(name string, defaultV map[string]any, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeObject,
+			Desc:         desc,
+			AuthServices: nil,
+		},
+		Default:    &defaultV,
+		Properties: properties,
+	}
+}
+
+// NewObjectParameterWithRequired is a convenience function for initializing a ObjectParameter.
+func NewObjectParameterWithRequired(name string, desc string, required bool, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name:         name,
+			Type:         typeObject,
+			Desc:         desc,
+			Required:     &required,
+			AuthServices: nil,
+		},
+		Properties: properties,
+	}
+}
+
+var _ Parameter = &ObjectParameter{}
+
+// ObjectParameter is a parameter representing the "object" type. Its values
+// are JSON objects with the declared properties, which are themselves
+// parameters of any type.
+type ObjectParameter struct {
+	CommonParameter `yaml:",inline"`
+	Default         *map[string]any `yaml:"default"`
+	Properties      Parameters      `yaml:"properties"`
+}
+
+func (p *ObjectParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var rawItem struct {
+		CommonParameter `yaml:",inline"`
+		Default         *map[string]any `yaml:"default"`
+		Properties      Parameters      `yaml:"properties"`
+	}
+	if err := unmarshal(&rawItem); err != nil {
+		return err
+	}
+	if len(rawItem.Properties) == 0 {
+		return fmt.Errorf("'properties' field must have at least one property")
+	}
+	names := make(map[string]bool, len(rawItem.Properties))
+	for _, prop := range rawItem.Properties {
+		if len(prop.GetAuthServices()) != 0 {
+			return fmt.Errorf("nested properties should not have auth services")
+		}
+		if prop.GetExpression() != "" {
+			return fmt.Errorf("nested properties should not have expressions")
+		}
+		if names[prop.GetName()] {
+			return fmt.Errorf("duplicate property %q", prop.GetName())
+		}
+		names[prop.GetName()] = true
+	}
+	p.CommonParameter = rawItem.CommonParameter
+	p.Default = rawItem.Default
+	p.Properties = rawItem.Properties
+	return nil
+}
+
+// Parse validates an object against the declared properties, filling in the
+// defaults of missing properties. Unknown properties are rejected.
+func (p *ObjectParameter) Parse(v any) (any, error) {
+	objVal, ok := v.(map[string]any)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	for key := range objVal {
+		if !slices.ContainsFunc(p.Properties, func(prop Parameter) bool { return prop.GetName() == key }) {
+			return nil, fmt.Errorf("unknown property %q", key)
+		}
+	}
+	rtn := make(map[string]any, len(p.Properties))
+	for _, prop := range p.Properties {
+		name := prop.GetName()
+		val, ok := objVal[name]
+		if !ok {
+			val = prop.GetDefault()
+			if CheckParamRequired(prop.GetRequired(), val) {
+				return nil, fmt.Errorf("property %q is required", name)
+			}
+			if val == nil {
+				continue
+			}
+		}
+		val, err := prop.Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse property %q: %w", name, err)
+		}
+		rtn[name] = val
+	}
+	return rtn, nil
+}
+
+func (p *ObjectParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+func (p *ObjectParameter) GetDefault() any {
+	if p.Default == nil {
+		return nil
+	}
+	return *p.Default
+}
+
+func (p *ObjectParameter) GetProperties() Parameters {
+	return p.Properties
+}
+
+// Manifest returns the manifest for the ObjectParameter.
+func (p *ObjectParameter) Manifest() ParameterManifest {
+	m := p.manifest(p.GetDefault())
+	m.Properties = p.Properties.Manifest()
+	return m
+}
+
+// McpManifest returns the MCP manifest for the ObjectParameter.
+func (p *ObjectParameter) McpManifest() ParameterMcpManifest {
+	schema := p.Properties.McpManifest()
+	additionalProperties := false
+	return ParameterMcpManifest{
+		Type:                 typeObject,
+		Description:          p.Desc,
+		Properties:           schema.Properties,
+		Required:             schema.Required,
+		AdditionalProperties: &additionalProperties,
+	}
+}
+
 // NewArrayParameter is a convenience function for initializing a ArrayParameter.
 func NewArrayParameter(name string, desc string, items Parameter) *ArrayParameter {
 	return &ArrayParameter{
@@ -931,6 +1756,8 @@ type ArrayParameter struct {
 	CommonParameter `yaml:",inline"`
 	Default         *[]any    `yaml:"default"`
 	Items           Parameter `yaml:"items"`
+	MinItems        *int      `yaml:"minItems"`
+	MaxItems        *int      `yaml:"maxItems"`
 }
 
 func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
@@ -938,12 +1765,24 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 		CommonParameter `yaml:",inline"`
 		Default         *[]any                  `yaml:"default"`
 		Items           util.DelayedUnmarshaler `yaml:"items"`
+		MinItems        *int                    `yaml:"minItems"`
+		MaxItems        *int                    `yaml:"maxItems"`
 	}
 	if err := unmarshal(&rawItem); err != nil {
 		return err
 	}
 	p.CommonParameter = rawItem.CommonParameter
 	p.Default = rawItem.Default
+	p.MinItems = rawItem.MinItems
+	p.MaxItems = rawItem.MaxItems
+	if err := validateLength("minItems", p.MinItems, "maxItems", p.MaxItems); err != nil {
+		return err
+	}
+	if p.Default != nil {
+		if err := p.checkConstraints(len(*p.Default)); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+	}
 	i, err := parseParamFromDelayedUnmarshaler(ctx, &rawItem.Items)
 	if err != nil {
 		return fmt.Errorf("unable to parse 'items' field: %w", err)
@@ -951,6 +1790,9 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 	if i.GetAuthServices() != nil && len(i.GetAuthServices()) != 0 {
 		return fmt.Errorf("nested items should not have auth services")
 	}
+	if i.GetExpression() != "" {
+		return fmt.Errorf("nested items should not have expressions")
+	}
 	p.Items = i
 
 	return nil
@@ -961,6 +1803,9 @@ func (p *ArrayParameter) Parse(v any) (any, error) {
 	if !ok {
 		return nil, &ParseTypeError{p.Name, p.Type, arrVal}
 	}
+	if err := p.checkConstraints(len(arrVal)); err != nil {
+		return nil, err
+	}
 	rtn := make([]any, 0, len(arrVal))
 	for idx, val := range arrVal {
 		val, err := p.Items.Parse(val)
@@ -1020,5 +1865,7 @@ func (p *ArrayParameter) McpManifest() ParameterMcpManifest {
 		Type:        p.Type,
 		Description: p.Desc,
 		Items:       &items,
+		MinItems:    p.MinItems,
+		MaxItems:    p.MaxItems,
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"math"
 	"reflect"
 	"testing"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
@@ -64,6 +65,161 @@ func TestParametersMarshal(t *testing.T) {
 				tools.NewStringParameterWithRequired("my_string", "this param is a string", false),
 			},
 		},
+		{
+			name: "string identifier",
+			in: []map[string]any{
+				{
+					"name":        "my_table",
+					"type":        "string",
+					"description": "this param is a table",
+					"identifier": map[string]any{
+						"pattern": "[a-z_]+",
+						"catalog": "table",
+					},
+				},
+			},
+			want: tools.Parameters{
+				tools.NewStringParameterWithIdentifier("my_table", "this param is a table", &tools.Identifier{Pattern: "[a-z_]+", Catalog: tools.CatalogTable}),
+			},
+		},
+		{
+			name: "date",
+			in: []map[string]any{
+				{
+					"name":        "my_date",
+					"type":        "date",
+					"description": "this param is a date",
+					"default":     "2025-01-02",
+				},
+			},
+			want: tools.Parameters{
+				tools.NewDateParameterWithDefault("my_date", "2025-01-02", "this param is a date"),
+			},
+		},
+		{
+			name: "timestamp",
+			in: []map[string]any{
+				{
+					"name":        "my_timestamp",
+					"type":        "timestamp",
+					"description": "this param is a timestamp",
+				},
+			},
+			want: tools.Parameters{
+				tools.NewTimestampParameter("my_timestamp", "this param is a timestamp"),
+			},
+		},
+		{
+			name: "decimal not required",
+			in: []map[string]any{
+				{
+					"name":        "my_decimal",
+					"type":        "decimal",
+					"description": "this param is a decimal",
+					"required":    false,
+				},
+			},
+			want: tools.Parameters{
+				tools.NewDecimalParameterWithRequired("my_decimal", "this param is a decimal", false),
+			},
+		},
+		{
+			name: "enum",
+			in: []map[string]any{
+				{
+					"name":        "my_enum",
+					"type":        "enum",
+					"description": "this param is an enum",
+					"values":      []string{"open", "closed"},
+					"default":     "open",
+				},
+			},
+			want: tools.Parameters{
+				tools.NewEnumParameterWithDefault("my_enum", "open", "this param is an enum", []string{"open", "closed"}),
+			},
+		},
+		{
+			name: "object",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{
+							"name":        "my_string",
+							"type":        "string",
+							"description": "string property",
+						},
+						{
+							"name":        "my_int",
+							"type":        "integer",
+							"description": "int property",
+							"default":     1,
+						},
+					},
+				},
+			},
+			want: tools.Parameters{
+				tools.NewObjectParameter("my_object", "this param is an object", tools.Parameters{
+					tools.NewStringParameter("my_string", "string property"),
+					tools.NewIntParameterWithDefault("my_int", 1, "int property"),
+				}),
+			},
+		},
+		{
+			name: "constraints",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"pattern":     "^[A-Z]{2}$",
+					"minLength":   2,
+					"maxLength":   2,
+				},
+				{
+					"name":        "my_integer",
+					"type":        "integer",
+					"description": "this param is an int",
+					"minimum":     1,
+					"maximum":     100,
+				},
+				{
+					"name":        "my_array",
+					"type":        "array",
+					"description": "this param is an array of floats",
+					"minItems":    1,
+					"items": map[string]any{
+						"name":        "my_float",
+						"type":        "float",
+						"description": "float item",
+						"minimum":     0.5,
+					},
+				},
+			},
+			want: tools.Parameters{
+				&tools.StringParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+					Pattern:         "^[A-Z]{2}$",
+					MinLength:       intPtr(2),
+					MaxLength:       intPtr(2),
+				},
+				&tools.IntParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_integer", Type: "integer", Desc: "this param is an int"},
+					Minimum:         floatPtr(1),
+					Maximum:         floatPtr(100),
+				},
+				&tools.ArrayParameter{
+					CommonParameter: tools.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array of floats"},
+					Items: &tools.FloatParameter{
+						CommonParameter: tools.CommonParameter{Name: "my_float", Type: "float", Desc: "float item"},
+						Minimum:         floatPtr(0.5),
+					},
+					MinItems: intPtr(1),
+				},
+			},
+		},
 		{
 			name: "int",
 			in: []map[string]any{
@@ -641,6 +797,130 @@ func TestParametersParse(t *testing.T) {
 				"my_string": 4,
 			},
 		},
+		{
+			name: "identifier",
+			params: tools.Parameters{
+				tools.NewStringParameterWithIdentifier("my_table", "this param is a table", &tools.Identifier{}),
+			},
+			in: map[string]any{
+				"my_table": "public.orders",
+			},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_table", Value: "public.orders"}},
+		},
+		{
+			name: "not identifier",
+			params: tools.Parameters{
+				tools.NewStringParameterWithIdentifier("my_table", "this param is a table", &tools.Identifier{}),
+			},
+			in: map[string]any{
+				"my_table": "orders; DROP TABLE orders",
+			},
+		},
+		{
+			name: "not identifier of pattern",
+			params: tools.Parameters{
+				tools.NewStringParameterWithIdentifier("my_table", "this param is a table", &tools.Identifier{Pattern: "orders|customers"}),
+			},
+			in: map[string]any{
+				"my_table": "orders_archive",
+			},
+		},
+		{
+			name: "array of not identifiers",
+			params: tools.Parameters{
+				tools.NewArrayParameter("my_columns", "this param is a list of columns",
+					tools.NewStringParameterWithIdentifier("my_column", "a column", &tools.Identifier{})),
+			},
+			in: map[string]any{
+				"my_columns": []any{"id", "name FROM secrets --"},
+			},
+		},
+		{
+			name: "date",
+			params: tools.Parameters{
+				tools.NewDateParameter("my_date", "this param is a date"),
+			},
+			in: map[string]any{
+				"my_date": "2025-01-02",
+			},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_date", Value: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		},
+		{
+			name: "not date",
+			params: tools.Parameters{
+				tools.NewDateParameter("my_date", "this param is a date"),
+			},
+			in: map[string]any{
+				"my_date": "2025-01-02T15:04:05Z",
+			},
+		},
+		{
+			name: "timestamp",
+			params: tools.Parameters{
+				tools.NewTimestampParameter("my_timestamp", "this param is a timestamp"),
+			},
+			in: map[string]any{
+				"my_timestamp": "2025-01-02T15:04:05.5Z",
+			},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_timestamp", Value: time.Date(2025, 1, 2, 15, 4, 5, 500000000, time.UTC)}},
+		},
+		{
+			name: "not timestamp",
+			params: tools.Parameters{
+				tools.NewTimestampParameter("my_timestamp", "this param is a timestamp"),
+			},
+			in: map[string]any{
+				"my_timestamp": "2025-01-02 15:04",
+			},
+		},
+		{
+			name: "decimal",
+			params: tools.Parameters{
+				tools.NewDecimalParameter("my_decimal", "this param is a decimal"),
+			},
+			in: map[string]any{
+				"my_decimal": "-12.50",
+			},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_decimal", Value: "-12.50"}},
+		},
+		{
+			name: "decimal from number",
+			params: tools.Parameters{
+				tools.NewDecimalParameter("my_decimal", "this param is a decimal"),
+			},
+			in: map[string]any{
+				"my_decimal": json.Number("1e-3"),
+			},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_decimal", Value: "0.001"}},
+		},
+		{
+			name: "not decimal",
+			params: tools.Parameters{
+				tools.NewDecimalParameter("my_decimal", "this param is a decimal"),
+			},
+			in: map[string]any{
+				"my_decimal": "12,5",
+			},
+		},
+		{
+			name: "enum",
+			params: tools.Parameters{
+				tools.NewEnumParameter("my_enum", "this param is an enum", []string{"open", "closed"}),
+			},
+			in: map[string]any{
+				"my_enum": "closed",
+			},
+			want: tools.ParamValues{tools.ParamValue{Name: "my_enum", Value: "closed"}},
+		},
+		{
+			name: "not enum",
+			params: tools.Parameters{
+				tools.NewEnumParameter("my_enum", "this param is an enum", []string{"open", "closed"}),
+			},
+			in: map[string]any{
+				"my_enum": "pending",
+			},
+		},
 		{
 			name: "int",
 			params: tools.Parameters{
@@ -984,6 +1264,185 @@ func TestAuthParametersParse(t *testing.T) {
 	}
 }
 
+func TestObjectParameterParse(t *testing.T) {
+	param := tools.NewObjectParameter("my_object", "this param is an object", tools.Parameters{
+		tools.NewStringParameter("my_string", "string property"),
+		tools.NewIntParameterWithDefault("my_int", 1, "int property"),
+		tools.NewBooleanParameterWithRequired("my_bool", "bool property", false),
+		tools.NewObjectParameterWithRequired("my_nested", "nested property", false, tools.Parameters{
+			tools.NewDateParameter("my_date", "date property"),
+		}),
+	})
+	tcs := []struct {
+		name string
+		in   any
+		want any
+		err  string
+	}{
+		{
+			name: "defaults",
+			in:   map[string]any{"my_string": "hello"},
+			want: map[string]any{"my_string": "hello", "my_int": 1},
+		},
+		{
+			name: "nested",
+			in: map[string]any{
+				"my_string": "hello",
+				"my_int":    json.Number("2"),
+				"my_bool":   true,
+				"my_nested": map[string]any{"my_date": "2025-01-02"},
+			},
+			want: map[string]any{
+				"my_string": "hello",
+				"my_int":    2,
+				"my_bool":   true,
+				"my_nested": map[string]any{"my_date": time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name: "not object",
+			in:   []any{"hello"},
+			err:  `["hello"] not type "object"`,
+		},
+		{
+			name: "missing property",
+			in:   map[string]any{"my_int": 2},
+			err:  `property "my_string" is required`,
+		},
+		{
+			name: "unknown property",
+			in:   map[string]any{"my_string": "hello", "my_float": 1.5},
+			err:  `unknown property "my_float"`,
+		},
+		{
+			name: "invalid nested property",
+			in:   map[string]any{"my_string": "hello", "my_nested": map[string]any{"my_date": "tomorrow"}},
+			err:  `unable to parse property "my_nested": unable to parse property "my_date": "tomorrow" is not a date in the format YYYY-MM-DD`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := param.Parse(tc.in)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect value: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParametersParseConstraints(t *testing.T) {
+	tcs := []struct {
+		name  string
+		param tools.Parameter
+		in    any
+		err   string
+	}{
+		{
+			name: "pattern",
+			param: &tools.StringParameter{
+				CommonParameter: tools.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+				Pattern:         "^[A-Z]{2}$",
+			},
+			in: "US",
+		},
+		{
+			name: "not pattern",
+			param: &tools.StringParameter{
+				CommonParameter: tools.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+				Pattern:         "^[A-Z]{2}$",
+			},
+			in:  "USA",
+			err: `"USA" does not match the pattern "^[A-Z]{2}$"`,
+		},
+		{
+			name: "min length in characters",
+			param: &tools.StringParameter{
+				CommonParameter: tools.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+				MinLength:       intPtr(3),
+			},
+			in:  "日本",
+			err: `"日本" is shorter than the minimum length of 3`,
+		},
+		{
+			name: "max length",
+			param: &tools.StringParameter{
+				CommonParameter: tools.CommonParameter{Name: "my_string", Type: "string", Desc: "this param is a string"},
+				MaxLength:       intPtr(3),
+			},
+			in:  "hello",
+			err: `"hello" is longer than the maximum length of 3`,
+		},
+		{
+			name: "minimum",
+			param: &tools.IntParameter{
+				CommonParameter: tools.CommonParameter{Name: "my_int", Type: "integer", Desc: "this param is an int"},
+				Minimum:         floatPtr(1),
+			},
+			in:  json.Number("0"),
+			err: "0 is less than the minimum of 1",
+		},
+		{
+			name: "maximum",
+			param: &tools.FloatParameter{
+				CommonParameter: tools.CommonParameter{Name: "my_float", Type: "float", Desc: "this param is a float"},
+				Maximum:         floatPtr(1),
+			},
+			in: 1.0,
+		},
+		{
+			name: "min items",
+			param: &tools.ArrayParameter{
+				CommonParameter: tools.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array"},
+				Items:           tools.NewStringParameter("my_string", "string item"),
+				MinItems:        intPtr(1),
+			},
+			in:  []any{},
+			err: "0 items are fewer than the minimum of 1",
+		},
+		{
+			name: "max items",
+			param: &tools.ArrayParameter{
+				CommonParameter: tools.CommonParameter{Name: "my_array", Type: "array", Desc: "this param is an array"},
+				Items:           tools.NewStringParameter("my_string", "string item"),
+				MaxItems:        intPtr(1),
+			},
+			in:  []any{"a", "b"},
+			err: "2 items are more than the maximum of 1",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.param.Parse(tc.in)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
 func TestParamValues(t *testing.T) {
 	tcs := []struct {
 		name              string
@@ -1179,6 +1638,76 @@ func TestParamMcpManifest(t *testing.T) {
 			in:   tools.NewBooleanParameter("foo-bool", "bar"),
 			want: tools.ParameterMcpManifest{Type: "boolean", Description: "bar"},
 		},
+		{
+			name: "date",
+			in:   tools.NewDateParameter("foo-date", "bar"),
+			want: tools.ParameterMcpManifest{Type: "string", Description: "bar", Format: "date"},
+		},
+		{
+			name: "timestamp",
+			in:   tools.NewTimestampParameter("foo-timestamp", "bar"),
+			want: tools.ParameterMcpManifest{Type: "string", Description: "bar", Format: "date-time"},
+		},
+		{
+			name: "decimal",
+			in:   tools.NewDecimalParameter("foo-decimal", "bar"),
+			want: tools.ParameterMcpManifest{Type: "string", Description: "bar", Pattern: `^[+-]?(\d+(\.\d*)?|\.\d+)$`},
+		},
+		{
+			name: "enum",
+			in:   tools.NewEnumParameter("foo-enum", "bar", []string{"a", "b"}),
+			want: tools.ParameterMcpManifest{Type: "string", Description: "bar", Enum: []string{"a", "b"}},
+		},
+		{
+			name: "object",
+			in: tools.NewObjectParameter("foo-object", "bar", tools.Parameters{
+				tools.NewStringParameter("foo-string", "bar"),
+				tools.NewIntParameterWithRequired("foo-int", "bar", false),
+			}),
+			want: tools.ParameterMcpManifest{
+				Type:        "object",
+				Description: "bar",
+				Properties: map[string]tools.ParameterMcpManifest{
+					"foo-string": {Type: "string", Description: "bar"},
+					"foo-int":    {Type: "integer", Description: "bar"},
+				},
+				Required:             []string{"foo-string"},
+				AdditionalProperties: new(bool),
+			},
+		},
+		{
+			name: "string constraints",
+			in: &tools.StringParameter{
+				CommonParameter: tools.CommonParameter{Name: "foo-string", Type: "string", Desc: "bar"},
+				Pattern:         "^[a-z]+$",
+				MaxLength:       intPtr(8),
+			},
+			want: tools.ParameterMcpManifest{Type: "string", Description: "bar", Pattern: "^[a-z]+$", MaxLength: intPtr(8)},
+		},
+		{
+			name: "int constraints",
+			in: &tools.IntParameter{
+				CommonParameter: tools.CommonParameter{Name: "foo-int", Type: "integer", Desc: "bar"},
+				Minimum:         floatPtr(0),
+			},
+			want: tools.ParameterMcpManifest{Type: "integer", Description: "bar", Minimum: floatPtr(0)},
+		},
+		{
+			name: "array constraints",
+			in: &tools.ArrayParameter{
+				CommonParameter: tools.CommonParameter{Name: "foo-array", Type: "array", Desc: "bar"},
+				Items:           tools.NewStringParameter("foo-string", "bar"),
+				MinItems:        intPtr(1),
+				MaxItems:        intPtr(3),
+			},
+			want: tools.ParameterMcpManifest{
+				Type:        "array",
+				Description: "bar",
+				Items:       &tools.ParameterMcpManifest{Type: "string", Description: "bar"},
+				MinItems:    intPtr(1),
+				MaxItems:    intPtr(3),
+			},
+		},
 		{
 			name: "array",
 			in:   tools.NewArrayParameter("foo-array", "bar", tools.NewStringParameter("foo-string", "bar")),
@@ -1220,15 +1749,16 @@ func TestMcpManifest(t *testing.T) {
 			want: tools.McpToolsSchema{
 				Type: "object",
 				Properties: map[string]tools.ParameterMcpManifest{
-					"foo-string":         tools.ParameterMcpManifest{Type: "string", Description: "bar"},
+					"foo-string":         tools.ParameterMcpManifest{Type: "string", Description: "bar", Default: "foo"},
 					"foo-string2":        tools.ParameterMcpManifest{Type: "string", Description: "bar"},
 					"foo-string-req":     tools.ParameterMcpManifest{Type: "string", Description: "bar"},
 					"foo-string-not-req": tools.ParameterMcpManifest{Type: "string", Description: "bar"},
-					"foo-int":            tools.ParameterMcpManifest{Type: "integer", Description: "bar"},
+					"foo-int":            tools.ParameterMcpManifest{Type: "integer", Description: "bar", Default: 1},
 					"foo-int2":           tools.ParameterMcpManifest{Type: "integer", Description: "bar"},
 					"foo-array": tools.ParameterMcpManifest{
 						Type:        "array",
 						Description: "bar",
+						Default:     []any{"hello", "world"},
 						Items:       &tools.ParameterMcpManifest{Type: "string", Description: "bar"},
 					},
 					"foo-array2": tools.ParameterMcpManifest{
@@ -1251,6 +1781,30 @@ func TestMcpManifest(t *testing.T) {
 	}
 }
 
+func TestParametersManifestDefaults(t *testing.T) {
+	in := tools.Parameters{
+		tools.NewBooleanParameterWithDefault("foo-bool", false, "bar"),
+		tools.NewEnumParameterWithDefault("foo-enum", "a", "bar", []string{"a", "b"}),
+		tools.NewStringParameter("foo-string", "bar"),
+	}
+	want := []tools.ParameterManifest{
+		{Name: "foo-bool", Type: "boolean", Required: false, Description: "bar", AuthServices: []string{}, Default: false},
+		{Name: "foo-enum", Type: "enum", Required: false, Description: "bar", AuthServices: []string{}, Default: "a"},
+		{Name: "foo-string", Type: "string", Required: true, Description: "bar", AuthServices: []string{}},
+	}
+	if diff := cmp.Diff(want, in.Manifest()); diff != "" {
+		t.Fatalf("incorrect manifest: diff %v", diff)
+	}
+	// a default of false is still visible to the model
+	got, err := json.Marshal(in.McpManifest().Properties["foo-bool"])
+	if err != nil {
+		t.Fatalf("unable to marshal manifest: %s", err)
+	}
+	if wantJSON := `{"type":"boolean","description":"bar","default":false}`; string(got) != wantJSON {
+		t.Fatalf("unexpected manifest: got %s, want %s", got, wantJSON)
+	}
+}
+
 func TestFailParametersUnmarshal(t *testing.T) {
 	ctx, err := testutils.ContextWithNewLogger()
 	if err != nil {
@@ -1281,6 +1835,30 @@ func TestFailParametersUnmarshal(t *testing.T) {
 			},
 			err: "parameter is missing 'type' field: %!w(<nil>)",
 		},
+		{
+			name: "invalid identifier pattern",
+			in: []map[string]any{
+				{
+					"name":        "my_table",
+					"type":        "string",
+					"description": "this is a param for a table",
+					"identifier":  map[string]any{"pattern": "[a-z"},
+				},
+			},
+			err: "invalid identifier pattern \"[a-z\": error parsing regexp: missing closing ]: `[a-z)$`",
+		},
+		{
+			name: "invalid identifier catalog",
+			in: []map[string]any{
+				{
+					"name":        "my_table",
+					"type":        "string",
+					"description": "this is a param for a table",
+					"identifier":  map[string]any{"catalog": "schema"},
+				},
+			},
+			err: "invalid identifier catalog \"schema\": must be \"table\" or \"column\"",
+		},
 		{
 			name: "common parameter missing description",
 			in: []map[string]any{
@@ -1291,6 +1869,135 @@ func TestFailParametersUnmarshal(t *testing.T) {
 			},
 			err: "unable to parse as \"string\": Key: 'CommonParameter.Desc' Error:Field validation for 'Desc' failed on the 'required' tag",
 		},
+		{
+			name: "enum parameter missing values",
+			in: []map[string]any{
+				{
+					"name":        "my_enum",
+					"type":        "enum",
+					"description": "this param is an enum",
+				},
+			},
+			err: "unable to parse as \"enum\": Key: 'EnumParameter.Values' Error:Field validation for 'Values' failed on the 'required' tag",
+		},
+		{
+			name: "invalid default",
+			in: []map[string]any{
+				{
+					"name":        "my_date",
+					"type":        "date",
+					"description": "this param is a date",
+					"default":     "01/02/2025",
+				},
+			},
+			err: "invalid default value for \"my_date\": \"01/02/2025\" is not a date in the format YYYY-MM-DD",
+		},
+		{
+			name: "object parameter missing properties",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+				},
+			},
+			err: "unable to parse as \"object\": 'properties' field must have at least one property",
+		},
+		{
+			name: "object parameter with duplicate properties",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]any{
+						{"name": "my_string", "type": "string", "description": "string property"},
+						{"name": "my_string", "type": "integer", "description": "int property"},
+					},
+				},
+			},
+			err: "unable to parse as \"object\": duplicate property \"my_string\"",
+		},
+		{
+			name: "invalid pattern",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"pattern":     "[a-z",
+				},
+			},
+			err: "invalid pattern \"[a-z\": error parsing regexp: missing closing ]: `[a-z`",
+		},
+		{
+			name: "invalid length",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"minLength":   5,
+					"maxLength":   2,
+				},
+			},
+			err: "minLength 5 is greater than maxLength 2",
+		},
+		{
+			name: "invalid range",
+			in: []map[string]any{
+				{
+					"name":        "my_integer",
+					"type":        "integer",
+					"description": "this param is an int",
+					"minimum":     10,
+					"maximum":     1,
+				},
+			},
+			err: "invalid range for \"my_integer\": minimum 10 is greater than maximum 1",
+		},
+		{
+			name: "default out of range",
+			in: []map[string]any{
+				{
+					"name":        "my_float",
+					"type":        "float",
+					"description": "this param is a float",
+					"maximum":     1,
+					"default":     1.5,
+				},
+			},
+			err: "invalid default value for \"my_float\": 1.5 is greater than the maximum of 1",
+		},
+		{
+			name: "minimum on a string",
+			in: []map[string]any{
+				{
+					"name":        "my_string",
+					"type":        "string",
+					"description": "this param is a string",
+					"minimum":     1,
+				},
+			},
+			err: "unable to parse as \"string\": [2:1] unknown field \"minimum\"\n   1 | description: this param is a string\n>  2 | minimum: 1\n       ^\n   3 | name: my_string\n   4 | type: string",
+		},
+		{
+			name: "negative minItems",
+			in: []map[string]any{
+				{
+					"name":        "my_array",
+					"type":        "array",
+					"description": "this param is an array of strings",
+					"minItems":    -1,
+					"items": map[string]string{
+						"name":        "my_string",
+						"type":        "string",
+						"description": "string item",
+					},
+				},
+			},
+			err: "unable to parse as \"array\": minItems must not be negative",
+		},
 		{
 			name: "array parameter missing items",
 			in: []map[string]any{
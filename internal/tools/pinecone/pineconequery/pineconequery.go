@@ -0,0 +1,197 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pineconequery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/pinecone"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/vectorcommon"
+)
+
+const kind string = "pinecone-query"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name            string   `yaml:"name" validate:"required"`
+	Kind            string   `yaml:"kind" validate:"required"`
+	Source          string   `yaml:"source" validate:"required"`
+	Description     string   `yaml:"description" validate:"required"`
+	Namespace       string   `yaml:"namespace"`
+	Dimensions      int      `yaml:"dimensions" validate:"gte=0"`
+	EmbeddingSource string   `yaml:"embeddingSource"`
+	MaxLimit        int      `yaml:"maxLimit" validate:"gte=0"`
+	AuthRequired    []string `yaml:"authRequired"`
+	// Parameters are metadata fields that the vectors must be equal to.
+	Parameters tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*pinecone.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, pinecone.SourceKind)
+	}
+
+	if err := vectorcommon.CheckReserved(kind, cfg.Parameters, vectorcommon.EmbeddingParameterName, vectorcommon.TextParameterName, vectorcommon.LimitParameterName); err != nil {
+		return nil, err
+	}
+	embedder, err := vectorcommon.NewEmbedder(kind, srcs, cfg.EmbeddingSource)
+	if err != nil {
+		return nil, err
+	}
+	embeddingParameter := vectorcommon.NewEmbeddingParameter(embedder, cfg.Dimensions, "The embedding to find the nearest vectors to.", "The text to find the nearest vectors to, by the similarity of their meaning.")
+	limitParameter := vectorcommon.NewLimitParameter(cfg.MaxLimit, "Number of nearest vectors to return.")
+	parameters := append(slices.Clone(cfg.Parameters), embeddingParameter, limitParameter)
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Namespace:    cfg.Namespace,
+		Dimensions:   cfg.Dimensions,
+		Embedder:     embedder,
+		Filters:      cfg.Parameters,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *pinecone.Source
+	Namespace   string
+	Dimensions  int
+	Embedder    vectorcommon.Embedder
+	Filters     tools.Parameters
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke returns the vectors nearest to the embedding whose metadata match the
+// filters, nearest first, along with their metadata and score.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	embedding, err := vectorcommon.Embedding(ctx, t.Embedder, t.Dimensions, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{
+		"vector":          embedding,
+		"topK":            paramsMap[vectorcommon.LimitParameterName],
+		"includeMetadata": true,
+	}
+	if t.Namespace != "" {
+		body["namespace"] = t.Namespace
+	}
+	filter := make(map[string]any)
+	for _, p := range t.Filters {
+		// optional filters without a value match any vector
+		if v := paramsMap[p.GetName()]; v != nil {
+			filter[p.GetName()] = map[string]any{"$eq": v}
+		}
+	}
+	if len(filter) > 0 {
+		body["filter"] = filter
+	}
+
+	res, err := t.Source.Do(ctx, http.MethodPost, "/query", body)
+	if err != nil {
+		return nil, err
+	}
+	resMap, _ := res.(map[string]any)
+	matches, _ := resMap["matches"].([]any)
+	rows := make([]any, 0, len(matches))
+	for _, m := range matches {
+		match, ok := m.(map[string]any)
+		if !ok {
+			continue
+		}
+		row := map[string]any{"id": match["id"], "score": match["score"]}
+		if metadata, ok := match["metadata"].(map[string]any); ok {
+			for k, v := range metadata {
+				row[k] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
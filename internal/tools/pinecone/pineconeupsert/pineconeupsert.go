@@ -0,0 +1,192 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pineconeupsert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/pinecone"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/vectorcommon"
+)
+
+const kind string = "pinecone-upsert"
+
+const idParameterName = "id"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name            string `yaml:"name" validate:"required"`
+	Kind            string `yaml:"kind" validate:"required"`
+	Source          string `yaml:"source" validate:"required"`
+	Description     string `yaml:"description" validate:"required"`
+	Namespace       string `yaml:"namespace"`
+	Dimensions      int    `yaml:"dimensions" validate:"gte=0"`
+	EmbeddingSource string `yaml:"embeddingSource"`
+	// TextField is the metadata field that the text is stored in, if the
+	// embedding is generated by the embedding source.
+	TextField    string   `yaml:"textField"`
+	AuthRequired []string `yaml:"authRequired"`
+	// Parameters are the metadata fields of the vector.
+	Parameters tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*pinecone.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, pinecone.SourceKind)
+	}
+
+	if err := vectorcommon.CheckReserved(kind, cfg.Parameters, idParameterName, vectorcommon.EmbeddingParameterName, vectorcommon.TextParameterName, cfg.TextField); err != nil {
+		return nil, err
+	}
+	embedder, err := vectorcommon.NewEmbedder(kind, srcs, cfg.EmbeddingSource)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TextField != "" && embedder == nil {
+		return nil, fmt.Errorf("textField requires an embeddingSource")
+	}
+	idParameter := tools.NewStringParameter(idParameterName, "The id of the vector, which replaces the vector with the same id.")
+	embeddingParameter := vectorcommon.NewEmbeddingParameter(embedder, cfg.Dimensions, "The embedding of the vector.", "The text whose embedding is stored.")
+	parameters := append(tools.Parameters{idParameter, embeddingParameter}, cfg.Parameters...)
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Namespace:    cfg.Namespace,
+		Dimensions:   cfg.Dimensions,
+		Embedder:     embedder,
+		TextField:    cfg.TextField,
+		Metadata:     cfg.Parameters,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *pinecone.Source
+	Namespace   string
+	Dimensions  int
+	Embedder    vectorcommon.Embedder
+	TextField   string
+	Metadata    tools.Parameters
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke inserts the vector, or replaces the vector with the same id, along
+// with its metadata.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	id, ok := paramsMap[idParameterName].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", paramsMap[idParameterName])
+	}
+	embedding, err := vectorcommon.Embedding(ctx, t.Embedder, t.Dimensions, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]any)
+	for _, p := range t.Metadata {
+		// Pinecone doesn't store null metadata
+		if v := paramsMap[p.GetName()]; v != nil {
+			metadata[p.GetName()] = v
+		}
+	}
+	if t.TextField != "" {
+		metadata[t.TextField] = paramsMap[vectorcommon.TextParameterName]
+	}
+	vector := map[string]any{"id": id, "values": embedding}
+	if len(metadata) > 0 {
+		vector["metadata"] = metadata
+	}
+	body := map[string]any{"vectors": []any{vector}}
+	if t.Namespace != "" {
+		body["namespace"] = t.Namespace
+	}
+
+	if _, err := t.Source.Do(ctx, http.MethodPost, "/vectors/upsert", body); err != nil {
+		return nil, err
+	}
+	return map[string]any{"id": id}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pineconeupsert_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/pinecone"
+	"github.com/googleapis/genai-toolbox/internal/sources/vectorstore"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/pinecone/pineconeupsert"
+)
+
+func TestParseFromYamlPineconeUpsert(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: pinecone-upsert
+					source: my-pinecone-instance
+					description: some description
+					embeddingSource: my-embeddings
+					textField: content
+			`,
+			want: server.ToolConfigs{
+				"example_tool": pineconeupsert.Config{
+					Name:            "example_tool",
+					Kind:            "pinecone-upsert",
+					Source:          "my-pinecone-instance",
+					Description:     "some description",
+					EmbeddingSource: "my-embeddings",
+					TextField:       "content",
+					AuthRequired:    []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) SourceKind() string {
+	return "embeddings"
+}
+
+func (fakeEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	return []float64{0.5, 0.25}, nil
+}
+
+func TestInvoke(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vectors/upsert" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		_, _ = w.Write([]byte(`{"upsertedCount": 1}`))
+	}))
+	defer srv.Close()
+
+	s := &pinecone.Source{Name: "my-pinecone-instance", Kind: pinecone.SourceKind, Client: &vectorstore.Client{URL: srv.URL, HTTPClient: srv.Client()}}
+	srcs := map[string]sources.Source{"my-pinecone-instance": s, "my-embeddings": fakeEmbedder{}}
+	cfg := pineconeupsert.Config{
+		Name:            "example_tool",
+		Kind:            "pinecone-upsert",
+		Source:          "my-pinecone-instance",
+		Description:     "some description",
+		Namespace:       "docs",
+		EmbeddingSource: "my-embeddings",
+		TextField:       "content",
+		Parameters:      tools.Parameters{tools.NewStringParameter("title", "Title of the document.")},
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"id": "doc-1", "text": "Toolbox is an MCP server.", "title": "Intro"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	res, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantReq := map[string]any{
+		"vectors": []any{map[string]any{
+			"id":       "doc-1",
+			"values":   []any{0.5, 0.25},
+			"metadata": map[string]any{"title": "Intro", "content": "Toolbox is an MCP server."},
+		}},
+		"namespace": "docs",
+	}
+	if diff := cmp.Diff(wantReq, got); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"id": "doc-1"}, res); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	// the text can only be stored if the embedding is generated from it
+	cfg.EmbeddingSource = ""
+	if _, err := cfg.Initialize(srcs); err == nil || !strings.Contains(err.Error(), "textField requires an embeddingSource") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescallprocedure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlprocedure"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const kind string = "postgres-call-procedure"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &alloydbpg.Source{}
+var _ compatibleSource = &cloudsqlpg.Source{}
+var _ compatibleSource = &postgres.Source{}
+
+var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind}
+
+type Config struct {
+	Name             string           `yaml:"name" validate:"required"`
+	Kind             string           `yaml:"kind" validate:"required"`
+	Source           string           `yaml:"source" validate:"required"`
+	Description      string           `yaml:"description" validate:"required"`
+	Procedure        string           `yaml:"procedure" validate:"required"`
+	StatementTimeout string           `yaml:"statementTimeout"`
+	AuthRequired     []string         `yaml:"authRequired"`
+	Parameters       tools.Parameters `yaml:"parameters"`
+	OutParameters    tools.Parameters `yaml:"outParameters"`
+	// Arguments are the names of the parameters and out parameters in the
+	// order of the arguments of the procedure.
+	Arguments []string `yaml:"arguments"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	if err := sqlprocedure.CheckName(cfg.Procedure); err != nil {
+		return nil, err
+	}
+	args, err := sqlprocedure.Arguments(cfg.Arguments, cfg.Parameters, cfg.OutParameters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments of tool %q: %w", cfg.Name, err)
+	}
+	statementTimeout, err := postgrescommon.ParseStatementTimeout(cfg.StatementTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	t := Tool{
+		Name:             cfg.Name,
+		Kind:             kind,
+		Parameters:       cfg.Parameters,
+		AuthRequired:     cfg.AuthRequired,
+		Pool:             s.PostgresPool(),
+		Statement:        callStatement(cfg.Procedure, args),
+		Arguments:        args,
+		StatementTimeout: statementTimeout,
+		manifest:         tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: cfg.Parameters.McpManifest(),
+		},
+	}
+	return t, nil
+}
+
+// callStatement returns the CALL statement of a procedure. The values of IN
+// and INOUT arguments are bound to placeholders, and OUT arguments are NULL,
+// as PostgreSQL requires.
+func callStatement(procedure string, args []sqlprocedure.Argument) string {
+	placeholders := make([]string, len(args))
+	n := 0
+	for i, a := range args {
+		if !a.In {
+			placeholders[i] = "NULL"
+			continue
+		}
+		n++
+		placeholders[i] = fmt.Sprintf("$%d", n)
+	}
+	return fmt.Sprintf("CALL %s(%s)", procedure, strings.Join(placeholders, ", "))
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Pool             *pgxpool.Pool
+	Statement        string
+	Arguments        []sqlprocedure.Argument
+	StatementTimeout time.Duration
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
+}
+
+// Invoke calls the procedure, and returns the values of its OUT and INOUT
+// parameters.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	var args []any
+	for _, a := range t.Arguments {
+		if a.In {
+			args = append(args, paramsMap[a.Name])
+		}
+	}
+
+	opts := postgrescommon.TxOptions{StatementTimeout: t.StatementTimeout}
+	return postgrescommon.RunInTx(ctx, t.Pool, opts, func(q postgrescommon.Querier) (any, error) {
+		results, err := q.Query(ctx, t.Statement, args...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to call procedure: %w", err)
+		}
+		defer results.Close()
+
+		// CALL returns a row with the OUT and INOUT parameters, in order
+		var values []any
+		if results.Next() {
+			values, err = results.Values()
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
+			}
+		}
+		results.Close()
+		if err := results.Err(); err != nil {
+			return nil, fmt.Errorf("unable to call procedure: %w", err)
+		}
+
+		out := make(map[string]any)
+		i := 0
+		for _, a := range t.Arguments {
+			if a.Out == nil {
+				continue
+			}
+			if i >= len(values) {
+				return nil, fmt.Errorf("procedure returned %d values, but the tool has more out parameters", len(values))
+			}
+			v, err := sqlprocedure.OutValue(a.Out, values[i])
+			if err != nil {
+				return nil, err
+			}
+			out[a.Name] = v
+			i++
+		}
+		return sqlprocedure.Result{Out: out}, nil
+	})
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescallprocedure_test
+
+import (
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescallprocedure"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestParseFromYamlCallProcedure(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-call-procedure
+					source: my-pg-instance
+					description: some description
+					procedure: inventory.restock
+					statementTimeout: 30s
+					parameters:
+						- name: item
+						  type: string
+						  description: Item to restock.
+					outParameters:
+						- name: stock
+						  type: integer
+						  description: Stock after restocking.
+					arguments: [stock, item]
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgrescallprocedure.Config{
+					Name:             "example_tool",
+					Kind:             "postgres-call-procedure",
+					Source:           "my-pg-instance",
+					Description:      "some description",
+					Procedure:        "inventory.restock",
+					StatementTimeout: "30s",
+					AuthRequired:     []string{},
+					Parameters:       tools.Parameters{tools.NewStringParameter("item", "Item to restock.")},
+					OutParameters:    tools.Parameters{tools.NewIntParameter("stock", "Stock after restocking.")},
+					Arguments:        []string{"stock", "item"},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type fakeSource struct{}
+
+func (fakeSource) SourceKind() string {
+	return "postgres"
+}
+
+func (fakeSource) PostgresPool() *pgxpool.Pool {
+	return nil
+}
+
+func TestInitialize(t *testing.T) {
+	srcs := map[string]sources.Source{"my-pg-instance": fakeSource{}}
+	item := tools.NewStringParameter("item", "Item to restock.")
+	quantity := tools.NewIntParameter("quantity", "Quantity to add.")
+	stock := tools.NewIntParameter("stock", "Stock after restocking.")
+
+	tcs := []struct {
+		desc      string
+		procedure string
+		arguments []string
+		in        tools.Parameters
+		out       tools.Parameters
+		want      string
+	}{
+		{
+			desc:      "no arguments",
+			procedure: "refresh_stock",
+			want:      "CALL refresh_stock()",
+		},
+		{
+			desc:      "in and out",
+			procedure: "inventory.restock",
+			in:        tools.Parameters{item, quantity},
+			out:       tools.Parameters{stock},
+			want:      "CALL inventory.restock($1, $2, NULL)",
+		},
+		{
+			desc:      "arguments order",
+			procedure: "inventory.restock",
+			arguments: []string{"item", "stock", "quantity"},
+			in:        tools.Parameters{item, quantity},
+			out:       tools.Parameters{stock},
+			want:      "CALL inventory.restock($1, NULL, $2)",
+		},
+		{
+			desc:      "inout",
+			procedure: "inventory.restock",
+			in:        tools.Parameters{item, quantity},
+			out:       tools.Parameters{tools.NewIntParameter("quantity", "Quantity added.")},
+			want:      "CALL inventory.restock($1, $2)",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := postgrescallprocedure.Config{
+				Name:          "example_tool",
+				Kind:          "postgres-call-procedure",
+				Source:        "my-pg-instance",
+				Description:   "some description",
+				Procedure:     tc.procedure,
+				Parameters:    tc.in,
+				OutParameters: tc.out,
+				Arguments:     tc.arguments,
+			}
+			tool, err := cfg.Initialize(srcs)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := tool.(postgrescallprocedure.Tool).Statement; got != tc.want {
+				t.Fatalf("incorrect statement: got %q, want %q", got, tc.want)
+			}
+			// out parameters aren't inputs of the tool
+			if got := len(tool.Manifest().Parameters); got != len(tc.in) {
+				t.Fatalf("incorrect number of parameters in manifest: got %d, want %d", got, len(tc.in))
+			}
+		})
+	}
+
+	cfg := postgrescallprocedure.Config{
+		Name:        "example_tool",
+		Kind:        "postgres-call-procedure",
+		Source:      "my-pg-instance",
+		Description: "some description",
+		Procedure:   "restock(); DROP TABLE orders; --",
+	}
+	if _, err := cfg.Initialize(srcs); err == nil {
+		t.Fatalf("expected error for a procedure that isn't an identifier")
+	}
+}
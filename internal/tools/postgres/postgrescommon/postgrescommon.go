@@ -0,0 +1,288 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package postgrescommon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Querier runs queries on a pool, or in a transaction.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Columns returns the columns of the fields of a query result, with the names
+// of their types in the type map of the connection. PostgreSQL doesn't report
+// whether the columns of a result can be NULL.
+func Columns(fields []pgconn.FieldDescription, typeMap *pgtype.Map) []util.Column {
+	columns := make([]util.Column, len(fields))
+	for i, f := range fields {
+		columns[i] = util.Column{Name: f.Name, Type: "unknown"}
+		if t, ok := typeMap.TypeForOID(f.DataTypeOID); ok {
+			columns[i].Type = t.Name
+		}
+	}
+	return columns
+}
+
+// Value returns a value of a field of a query result as it is returned to
+// clients, see tools.Decimal.
+func Value(f pgconn.FieldDescription, v any) any {
+	switch v := v.(type) {
+	case pgtype.Numeric:
+		s, err := v.Value()
+		if err != nil || s == nil {
+			return nil
+		}
+		return tools.Decimal(s.(string))
+	case time.Time:
+		switch f.DataTypeOID {
+		case pgtype.TimestampOID:
+			return tools.DateTime(v)
+		case pgtype.DateOID:
+			return tools.Date(v)
+		}
+		return tools.Timestamp(v)
+	case []byte:
+		return tools.Bytes(v)
+	}
+	return v
+}
+
+// ParseStatementTimeout parses the statementTimeout of a tool config. An empty
+// statementTimeout doesn't limit the statements.
+func ParseStatementTimeout(statementTimeout string) (time.Duration, error) {
+	if statementTimeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(statementTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse statementTimeout as time.Duration: %w", err)
+	}
+	// a statement_timeout of 0 disables the timeout
+	if timeout < time.Millisecond {
+		return 0, fmt.Errorf("statementTimeout must be at least 1ms, got %s", timeout)
+	}
+	return timeout, nil
+}
+
+// TxOptions are the settings of the transaction that a tool runs its
+// statements in.
+type TxOptions struct {
+	// StatementTimeout is the statement_timeout of the transaction, if set,
+	// so that the server cancels the statements that run longer.
+	StatementTimeout time.Duration
+	// ReadOnly makes the transaction read-only, so that the server rejects
+	// the statements that write data.
+	ReadOnly bool
+	// Rollback rolls the transaction back even if run succeeds, so that the
+	// statements make no changes, for dry runs.
+	Rollback bool
+	// Session is the token of a session, see sqlsession, whose transaction
+	// run is called with instead. The transaction isn't committed, and the
+	// other options apply to a savepoint of it, for the statements of run
+	// only.
+	Session string
+	// Settings are run-time parameters of the transaction, e.g.
+	// hnsw.ef_search, which apply to its statements only.
+	Settings map[string]string
+}
+
+// isZero reports whether none of the options is set.
+func (opts TxOptions) isZero() bool {
+	return opts.StatementTimeout == 0 && !opts.ReadOnly && !opts.Rollback && opts.Session == "" && len(opts.Settings) == 0
+}
+
+// DryRunResult is the result of a dry run of a statement, whose changes were
+// rolled back.
+type DryRunResult struct {
+	// RowsAffected is the number of rows that the statement would have
+	// inserted, updated, deleted, or returned.
+	RowsAffected int64 `json:"rowsAffected"`
+	// Rows are the rows returned by the statement, e.g. by a RETURNING
+	// clause.
+	Rows []any `json:"rows"`
+}
+
+// setting is a run-time parameter set by the options.
+type setting struct {
+	name, value string
+}
+
+// settings returns the run-time parameters set by the options, with the
+// statement_timeout first and the settings in the order of their names, so
+// that the first invalid setting is always the one reported.
+func (opts TxOptions) settings() []setting {
+	var settings []setting
+	if opts.StatementTimeout != 0 {
+		settings = append(settings, setting{"statement_timeout", fmt.Sprint(opts.StatementTimeout.Milliseconds())})
+	}
+	names := make([]string, 0, len(opts.Settings))
+	for name := range opts.Settings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		settings = append(settings, setting{name, opts.Settings[name]})
+	}
+	return settings
+}
+
+// set sets run-time parameters for the rest of a transaction.
+func set(ctx context.Context, tx pgx.Tx, settings []setting) error {
+	for _, s := range settings {
+		// set_config with is_local applies the setting to the transaction only
+		if _, err := tx.Exec(ctx, "SELECT set_config($1, $2, true)", s.name, s.value); err != nil {
+			return fmt.Errorf("unable to set %s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// RunInTx calls run with the pool. If any of the options is set, run is called
+// instead with a transaction with the options. The transaction is committed if
+// run succeeds, unless it is rolled back, or belongs to a session.
+func RunInTx(ctx context.Context, pool *pgxpool.Pool, opts TxOptions, run func(Querier) (any, error)) (any, error) {
+	if opts.isZero() {
+		return run(pool)
+	}
+	if opts.Session != "" {
+		return sqlsession.Run(ctx, opts.Session, pool, func(tx pgx.Tx) (any, error) {
+			return runInSavepoint(ctx, tx, opts, run)
+		})
+	}
+
+	txOptions := pgx.TxOptions{}
+	if opts.ReadOnly {
+		txOptions.AccessMode = pgx.ReadOnly
+	}
+	tx, err := pool.BeginTx(ctx, txOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := set(ctx, tx, opts.settings()); err != nil {
+		return nil, err
+	}
+	result, err := run(tx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Rollback {
+		return result, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// runInSavepoint calls run with a savepoint of the transaction of a session,
+// with the options, which apply to the statements of run only.
+func runInSavepoint(ctx context.Context, tx pgx.Tx, opts TxOptions, run func(Querier) (any, error)) (any, error) {
+	settings := opts.settings()
+	if !opts.ReadOnly && !opts.Rollback && len(settings) == 0 {
+		return run(tx)
+	}
+	// rolling back a savepoint undoes the statements of run, and the
+	// settings set in it
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create savepoint: %w", err)
+	}
+	defer func() { _ = sp.Rollback(ctx) }()
+
+	if opts.ReadOnly {
+		if _, err := sp.Exec(ctx, "SET LOCAL transaction_read_only = on"); err != nil {
+			return nil, fmt.Errorf("unable to make transaction read-only: %w", err)
+		}
+	}
+	// the settings outlive a savepoint that is released, so that their
+	// previous values are restored before releasing it
+	previous := make([]setting, len(settings))
+	for i, s := range settings {
+		var value *string
+		if err := sp.QueryRow(ctx, "SELECT current_setting($1, true)", s.name).Scan(&value); err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", s.name, err)
+		}
+		previous[i] = setting{name: s.name}
+		if value != nil {
+			previous[i].value = *value
+		}
+	}
+	if err := set(ctx, sp, settings); err != nil {
+		return nil, err
+	}
+	result, err := run(sp)
+	if err != nil {
+		return nil, err
+	}
+	// a read-only savepoint has no changes to keep, and the transaction can't
+	// be made read-write again otherwise
+	if opts.Rollback || opts.ReadOnly {
+		return result, nil
+	}
+	if err := set(ctx, sp, previous); err != nil {
+		return nil, err
+	}
+	if err := sp.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("unable to release savepoint: %w", err)
+	}
+	return result, nil
+}
+
+// catalogQueries look up the objects of the catalog by their qualifier, the
+// schema of a table or the table of a column, and their name. Unqualified
+// names are looked up in the schemas of the search path.
+var catalogQueries = map[string]string{
+	tools.CatalogTable: `SELECT 1 FROM information_schema.tables
+		WHERE (($1 = '' AND table_schema = ANY(current_schemas(false))) OR lower(table_schema) = lower($1))
+		AND lower(table_name) = lower($2) LIMIT 1`,
+	tools.CatalogColumn: `SELECT 1 FROM information_schema.columns
+		WHERE table_schema = ANY(current_schemas(false)) AND ($1 = '' OR lower(table_name) = lower($1))
+		AND lower(column_name) = lower($2) LIMIT 1`,
+}
+
+// CheckCatalog returns an error if one of the identifiers isn't found in the
+// information_schema of the database.
+func CheckCatalog(ctx context.Context, q Querier, ids []tools.CatalogIdentifier) error {
+	for _, id := range ids {
+		qualifier, name := id.Parts()
+		rows, err := q.Query(ctx, catalogQueries[id.Catalog], qualifier, name)
+		if err != nil {
+			return fmt.Errorf("unable to look up %s %q: %w", id.Catalog, id.Name, err)
+		}
+		found := rows.Next()
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("unable to look up %s %q: %w", id.Catalog, id.Name, err)
+		}
+		if !found {
+			return fmt.Errorf("%s %q not found", id.Catalog, id.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescommon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestParseStatementTimeout(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		in      string
+		want    time.Duration
+		wantErr string
+	}{
+		{
+			desc: "unset",
+			in:   "",
+			want: 0,
+		},
+		{
+			desc: "duration",
+			in:   "1m30s",
+			want: 90 * time.Second,
+		},
+		{
+			desc:    "invalid duration",
+			in:      "90",
+			wantErr: `unable to parse statementTimeout as time.Duration: time: missing unit in duration "90"`,
+		},
+		{
+			desc:    "too short",
+			in:      "500us",
+			wantErr: "statementTimeout must be at least 1ms, got 500µs",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := postgrescommon.ParseStatementTimeout(tc.in)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected duration: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	fields := []pgconn.FieldDescription{
+		{Name: "id", DataTypeOID: pgtype.Int8OID},
+		{Name: "price", DataTypeOID: pgtype.NumericOID},
+		{Name: "mood", DataTypeOID: 99999},
+	}
+	got := postgrescommon.Columns(fields, pgtype.NewMap())
+	want := []util.Column{
+		{Name: "id", Type: "int8"},
+		{Name: "price", Type: "numeric"},
+		{Name: "mood", Type: "unknown"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect columns: diff %v", diff)
+	}
+}
+
+func TestValue(t *testing.T) {
+	var price pgtype.Numeric
+	if err := price.Scan("12345.678901234567890"); err != nil {
+		t.Fatalf("unable to scan numeric: %s", err)
+	}
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("", 2*60*60))
+	tcs := []struct {
+		oid  uint32
+		in   any
+		want any
+	}{
+		{oid: pgtype.NumericOID, in: price, want: tools.Decimal("12345.678901234567890")},
+		{oid: pgtype.NumericOID, in: pgtype.Numeric{}, want: nil},
+		{oid: pgtype.TimestamptzOID, in: ts, want: "2025-01-02T03:04:05+02:00"},
+		{oid: pgtype.TimestampOID, in: ts, want: "2025-01-02T03:04:05"},
+		{oid: pgtype.DateOID, in: ts, want: "2025-01-02"},
+		{oid: pgtype.ByteaOID, in: []byte("hi"), want: "aGk="},
+		{oid: pgtype.TextOID, in: "widget", want: "widget"},
+	}
+	for _, tc := range tcs {
+		got := postgrescommon.Value(pgconn.FieldDescription{Name: "col", DataTypeOID: tc.oid}, tc.in)
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Fatalf("incorrect value of %v: diff %v", tc.in, diff)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescreatevectorindex
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const kind string = "postgres-create-vector-index"
+
+const (
+	methodHNSW    = "hnsw"
+	methodIVFFlat = "ivfflat"
+	// methodScaNN is the ScaNN index of the alloydb_scann extension, which
+	// is only available on AlloyDB.
+	methodScaNN = "scann"
+)
+
+// opClasses are the operator classes of the distances, by index method.
+var opClasses = map[string]map[string]string{
+	methodHNSW:    {"cosine": "vector_cosine_ops", "l2": "vector_l2_ops", "inner_product": "vector_ip_ops"},
+	methodIVFFlat: {"cosine": "vector_cosine_ops", "l2": "vector_l2_ops", "inner_product": "vector_ip_ops"},
+	methodScaNN:   {"cosine": "cosine", "l2": "l2", "inner_product": "dot_product"},
+}
+
+// partitionsOptions are the storage parameters of the number of partitions of
+// the index, by index method. HNSW indexes aren't partitioned.
+var partitionsOptions = map[string]string{
+	methodIVFFlat: "lists",
+	methodScaNN:   "num_leaves",
+}
+
+// names match unqualified identifiers, e.g. of columns and indexes.
+const namePattern = `[A-Za-z_][A-Za-z0-9_]*`
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &alloydbpg.Source{}
+var _ compatibleSource = &cloudsqlpg.Source{}
+var _ compatibleSource = &postgres.Source{}
+
+var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	// ScaNN indexes are the default on AlloyDB, the only database with them
+	methods, method := []string{methodHNSW, methodIVFFlat}, methodHNSW
+	if rawS.SourceKind() == alloydbpg.SourceKind {
+		methods, method = append(methods, methodScaNN), methodScaNN
+	}
+	nameParameter := tools.NewStringParameterWithRequired("name", "The name of the index. Defaults to <table>_<column>_<method>_idx.", false)
+	nameParameter.Identifier = &tools.Identifier{Pattern: namePattern}
+	parameters := tools.Parameters{
+		tools.NewStringParameterWithIdentifier("table", "The table to index, which may be qualified by its schema, e.g. public.documents.", &tools.Identifier{Catalog: tools.CatalogTable}),
+		tools.NewStringParameterWithIdentifier("column", "The vector column of the table to index.", &tools.Identifier{Pattern: namePattern}),
+		tools.NewEnumParameterWithDefault("distance", "cosine", "The distance that the index speeds up searches by.", []string{"cosine", "l2", "inner_product"}),
+		tools.NewEnumParameterWithDefault("method", method, "The method of the index.", methods),
+		tools.NewIntParameterWithRequired("partitions", fmt.Sprintf("The number of partitions of the index, for the %s methods only. Defaults to the default of the method.", strings.Join(methods[1:], " and ")), false),
+		nameParameter,
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Pool:         s.PostgresPool(),
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Pool        *pgxpool.Pool
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke creates the index, unless an index with its name exists, and returns
+// its name and the statement that created it.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	table, _ := paramsMap["table"].(string)
+	column, _ := paramsMap["column"].(string)
+	_, tableName := tools.CatalogIdentifier{Name: table}.Parts()
+	ids := append(tools.CatalogIdentifiers(t.Parameters, paramsMap), tools.CatalogIdentifier{Catalog: tools.CatalogColumn, Name: tableName + "." + column})
+	if err := postgrescommon.CheckCatalog(ctx, t.Pool, ids); err != nil {
+		return nil, err
+	}
+
+	name, statement, err := Statement(paramsMap)
+	if err != nil {
+		return nil, err
+	}
+	// CREATE INDEX CONCURRENTLY can't run in a transaction
+	if _, err := t.Pool.Exec(ctx, statement); err != nil {
+		return nil, fmt.Errorf("unable to create index: %w", err)
+	}
+	return map[string]any{"index": name, "statement": statement}, nil
+}
+
+// Statement returns the name of the index of the parameters, and the
+// statement that creates it. The index is built concurrently, so that the
+// table can still be written to.
+func Statement(paramsMap map[string]any) (string, string, error) {
+	table, _ := paramsMap["table"].(string)
+	column, _ := paramsMap["column"].(string)
+	distance, _ := paramsMap["distance"].(string)
+	method, _ := paramsMap["method"].(string)
+	opClass, ok := opClasses[method][distance]
+	if !ok {
+		return "", "", fmt.Errorf("invalid distance %q for method %q", distance, method)
+	}
+
+	name, _ := paramsMap["name"].(string)
+	if name == "" {
+		_, tableName := tools.CatalogIdentifier{Name: table}.Parts()
+		name = fmt.Sprintf("%s_%s_%s_idx", tableName, column, method)
+	}
+	statement := fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s USING %s (%s %s)", name, table, method, column, opClass)
+
+	if partitions, ok := paramsMap["partitions"].(int); ok {
+		option, ok := partitionsOptions[method]
+		if !ok {
+			return "", "", fmt.Errorf("partitions can't be set for method %q", method)
+		}
+		if partitions < 1 {
+			return "", "", fmt.Errorf("partitions must be at least 1, got %d", partitions)
+		}
+		statement += fmt.Sprintf(" WITH (%s = %d)", option, partitions)
+	}
+	return name, statement, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgrescreatevectorindex_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescreatevectorindex"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestParseFromYamlCreateVectorIndex(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		example_tool:
+			kind: postgres-create-vector-index
+			source: my-alloydb-instance
+			description: some description
+	`
+	want := server.ToolConfigs{
+		"example_tool": postgrescreatevectorindex.Config{
+			Name:         "example_tool",
+			Kind:         "postgres-create-vector-index",
+			Source:       "my-alloydb-instance",
+			Description:  "some description",
+			AuthRequired: []string{},
+		},
+	}
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	// Parse contents
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+type fakeSource struct {
+	kind string
+}
+
+func (s fakeSource) SourceKind() string {
+	return s.kind
+}
+
+func (fakeSource) PostgresPool() *pgxpool.Pool {
+	return nil
+}
+
+func TestParseParamsMethod(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		sourceKind string
+		want       string
+		wantErr    bool
+	}{
+		{desc: "alloydb", sourceKind: "alloydb-postgres", want: "scann"},
+		{desc: "cloud sql", sourceKind: "cloud-sql-postgres", want: "hnsw"},
+		{desc: "cloud sql scann", sourceKind: "cloud-sql-postgres", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := postgrescreatevectorindex.Config{Name: "example_tool", Kind: "postgres-create-vector-index", Source: "my-pg-instance", Description: "some description"}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-pg-instance": fakeSource{kind: tc.sourceKind}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			data := map[string]any{"table": "docs.chunks", "column": "embedding"}
+			if tc.wantErr {
+				data["method"] = "scann"
+			}
+			params, err := tool.ParseParams(data, nil)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for method scann")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			if got := params.AsMap()["method"]; got != tc.want {
+				t.Fatalf("incorrect method: got %v, want %q", got, tc.want)
+			}
+		})
+	}
+
+	cfg := postgrescreatevectorindex.Config{Name: "example_tool", Kind: "postgres-create-vector-index", Source: "my-pg-instance", Description: "some description"}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-pg-instance": fakeSource{kind: "postgres"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, data := range []map[string]any{
+		{"table": "chunks; DROP TABLE chunks", "column": "embedding"},
+		{"table": "chunks", "column": "chunks.embedding"},
+		{"table": "chunks", "column": "embedding", "name": "public.chunks_idx"},
+	} {
+		if _, err := tool.ParseParams(data, nil); err == nil {
+			t.Fatalf("expected error for invalid identifier in %v", data)
+		}
+	}
+}
+
+func TestStatement(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		params   map[string]any
+		wantName string
+		want     string
+		wantErr  string
+	}{
+		{
+			desc:     "hnsw",
+			params:   map[string]any{"table": "docs.chunks", "column": "embedding", "distance": "cosine", "method": "hnsw"},
+			wantName: "chunks_embedding_hnsw_idx",
+			want:     "CREATE INDEX CONCURRENTLY IF NOT EXISTS chunks_embedding_hnsw_idx ON docs.chunks USING hnsw (embedding vector_cosine_ops)",
+		},
+		{
+			desc:     "ivfflat",
+			params:   map[string]any{"table": "chunks", "column": "embedding", "distance": "l2", "method": "ivfflat", "partitions": 100, "name": "chunks_idx"},
+			wantName: "chunks_idx",
+			want:     "CREATE INDEX CONCURRENTLY IF NOT EXISTS chunks_idx ON chunks USING ivfflat (embedding vector_l2_ops) WITH (lists = 100)",
+		},
+		{
+			desc:     "scann",
+			params:   map[string]any{"table": "chunks", "column": "embedding", "distance": "inner_product", "method": "scann", "partitions": 1000},
+			wantName: "chunks_embedding_scann_idx",
+			want:     "CREATE INDEX CONCURRENTLY IF NOT EXISTS chunks_embedding_scann_idx ON chunks USING scann (embedding dot_product) WITH (num_leaves = 1000)",
+		},
+		{
+			desc:    "hnsw partitions",
+			params:  map[string]any{"table": "chunks", "column": "embedding", "distance": "cosine", "method": "hnsw", "partitions": 100},
+			wantErr: `partitions can't be set for method "hnsw"`,
+		},
+		{
+			desc:    "zero partitions",
+			params:  map[string]any{"table": "chunks", "column": "embedding", "distance": "cosine", "method": "ivfflat", "partitions": 0},
+			wantErr: "partitions must be at least 1",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			name, got, err := postgrescreatevectorindex.Statement(tc.params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if name != tc.wantName || got != tc.want {
+				t.Fatalf("incorrect statement: got %q, %q, want %q, %q", name, got, tc.wantName, tc.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package postgresexecutesql
 import (
 	"context"
 	"fmt"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
@@ -24,6 +25,11 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -55,11 +61,15 @@ var _ compatibleSource = &postgres.Source{}
 var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind}
 
 type Config struct {
-	Name         string   `yaml:"name" validate:"required"`
-	Kind         string   `yaml:"kind" validate:"required"`
-	Source       string   `yaml:"source" validate:"required"`
-	Description  string   `yaml:"description" validate:"required"`
-	AuthRequired []string `yaml:"authRequired"`
+	Name             string            `yaml:"name" validate:"required"`
+	Kind             string            `yaml:"kind" validate:"required"`
+	Source           string            `yaml:"source" validate:"required"`
+	Description      string            `yaml:"description" validate:"required"`
+	StatementTimeout string            `yaml:"statementTimeout"`
+	ReadOnly         bool              `yaml:"readOnly"`
+	AllowSessions    bool              `yaml:"allowSessions"`
+	Policy           *sqlpolicy.Policy `yaml:"policy"`
+	AuthRequired     []string          `yaml:"authRequired"`
 }
 
 // validate interface
@@ -82,8 +92,22 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	statementTimeout, err := postgrescommon.ParseStatementTimeout(cfg.StatementTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Policy != nil {
+		if err := cfg.Policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	sqlParameter := tools.NewStringParameter("sql", "The sql to execute.")
 	parameters := tools.Parameters{sqlParameter}
+	if cfg.AllowSessions {
+		parameters = append(parameters, sqlsession.Parameter())
+	}
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -93,19 +117,23 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         kind,
-		Parameters:   parameters,
-		AuthRequired: cfg.AuthRequired,
-		Pool:         s.PostgresPool(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
-		mcpManifest:  mcpManifest,
+		Name:             cfg.Name,
+		Kind:             kind,
+		Parameters:       parameters,
+		AuthRequired:     cfg.AuthRequired,
+		Pool:             s.PostgresPool(),
+		StatementTimeout: statementTimeout,
+		ReadOnly:         cfg.ReadOnly,
+		Policy:           cfg.Policy,
+		manifest:         tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:      mcpManifest,
 	}
 	return t, nil
 }
 
 // validate interface
 var _ tools.Tool = Tool{}
+var _ tools.DryRunner = Tool{}
 
 type Tool struct {
 	Name         string           `yaml:"name"`
@@ -113,39 +141,76 @@ type Tool struct {
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
 
-	Pool        *pgxpool.Pool
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Pool             *pgxpool.Pool
+	StatementTimeout time.Duration
+	ReadOnly         bool
+	Policy           *sqlpolicy.Policy
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, false)
+}
+
+// DryRun runs the statement in a transaction that is rolled back, and returns
+// the number of rows it affected along with the rows it returned.
+func (t Tool) DryRun(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, true)
+}
+
+func (t Tool) run(ctx context.Context, params tools.ParamValues, dryRun bool) (any, error) {
 	sliceParams := params.AsSlice()
 	sql, ok := sliceParams[0].(string)
 	if !ok {
 		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
 	}
 
-	results, err := t.Pool.Query(ctx, sql)
-	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+	if t.ReadOnly {
+		if err := sqlparse.CheckReadOnly(sql, sqlparse.Postgres); err != nil {
+			return nil, err
+		}
+	}
+	if t.Policy != nil {
+		if err := t.Policy.Check(sql, sqlparse.Postgres); err != nil {
+			return nil, err
+		}
 	}
 
-	fields := results.FieldDescriptions()
-
-	var out []any
-	for results.Next() {
-		v, err := results.Values()
+	opts := postgrescommon.TxOptions{StatementTimeout: t.StatementTimeout, ReadOnly: t.ReadOnly, Rollback: dryRun, Session: sqlsession.Token(params)}
+	return postgrescommon.RunInTx(ctx, t.Pool, opts, func(q postgrescommon.Querier) (any, error) {
+		results, err := q.Query(ctx, sql)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+			return nil, fmt.Errorf("unable to execute query: %w", err)
 		}
-		vMap := make(map[string]any)
-		for i, f := range fields {
-			vMap[f.Name] = v[i]
+
+		fields := results.FieldDescriptions()
+		if !dryRun {
+			util.ReportColumns(ctx, postgrescommon.Columns(fields, results.Conn().TypeMap()))
+		}
+
+		var out []any
+		for results.Next() {
+			v, err := results.Values()
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, f := range fields {
+				vMap[f.Name] = postgrescommon.Value(f, v[i])
+			}
+			out = append(out, vMap)
+		}
+		// a statement canceled by the timeout fails while its rows are read
+		if err := results.Err(); err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+		if dryRun {
+			return postgrescommon.DryRunResult{RowsAffected: results.CommandTag().RowsAffected(), Rows: append([]any{}, out...)}, nil
 		}
-		out = append(out, vMap)
-	}
 
-	return out, nil
+		return out, nil
+	})
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
@@ -22,6 +22,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresexecutesql"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
 )
 
 func TestParseFromYamlExecuteSql(t *testing.T) {
@@ -56,6 +57,99 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "statementTimeout example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-execute-sql
+					source: my-instance
+					description: some description
+					statementTimeout: 30s
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresexecutesql.Config{
+					Name:             "example_tool",
+					Kind:             "postgres-execute-sql",
+					Source:           "my-instance",
+					Description:      "some description",
+					StatementTimeout: "30s",
+					AuthRequired:     []string{},
+				},
+			},
+		},
+		{
+			desc: "readOnly example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-execute-sql
+					source: my-instance
+					description: some description
+					readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "postgres-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					ReadOnly:     true,
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "allowSessions example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-execute-sql
+					source: my-instance
+					description: some description
+					allowSessions: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresexecutesql.Config{
+					Name:          "example_tool",
+					Kind:          "postgres-execute-sql",
+					Source:        "my-instance",
+					Description:   "some description",
+					AllowSessions: true,
+					AuthRequired:  []string{},
+				},
+			},
+		},
+		{
+			desc: "policy example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-execute-sql
+					source: my-instance
+					description: some description
+					policy:
+						allowedStatements: [SELECT, INSERT]
+						allowedTables: ["public.*"]
+						deniedKeywords: [pg_sleep]
+						denyCrossDatabase: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresexecutesql.Config{
+					Name:        "example_tool",
+					Kind:        "postgres-execute-sql",
+					Source:      "my-instance",
+					Description: "some description",
+					Policy: &sqlpolicy.Policy{
+						AllowedStatements: []string{"SELECT", "INSERT"},
+						AllowedTables:     []string{"public.*"},
+						DeniedKeywords:    []string{"pg_sleep"},
+						DenyCrossDatabase: true,
+					},
+					AuthRequired: []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
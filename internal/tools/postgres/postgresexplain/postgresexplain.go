@@ -0,0 +1,209 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresexplain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const kind string = "postgres-explain"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &alloydbpg.Source{}
+var _ compatibleSource = &cloudsqlpg.Source{}
+var _ compatibleSource = &postgres.Source{}
+
+var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	// AllowAnalyze adds an analyze parameter to the tool, which runs the
+	// statement to measure its actual plan.
+	AllowAnalyze     bool     `yaml:"allowAnalyze"`
+	StatementTimeout string   `yaml:"statementTimeout"`
+	AuthRequired     []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	statementTimeout, err := postgrescommon.ParseStatementTimeout(cfg.StatementTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlParameter := tools.NewStringParameter("sql", "The sql statement to explain.")
+	parameters := tools.Parameters{sqlParameter}
+	if cfg.AllowAnalyze {
+		analyzeParameter := tools.NewBooleanParameterWithDefault("analyze", false, "Whether to run the statement to measure its actual plan, rather than only estimate it. Its changes are rolled back.")
+		parameters = append(parameters, analyzeParameter)
+	}
+
+	t := Tool{
+		Name:             cfg.Name,
+		Kind:             kind,
+		Parameters:       parameters,
+		AuthRequired:     cfg.AuthRequired,
+		Pool:             s.PostgresPool(),
+		StatementTimeout: statementTimeout,
+		manifest:         tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: parameters.McpManifest(),
+		},
+	}
+	return t, nil
+}
+
+// Statement returns the EXPLAIN statement of a statement, which returns its
+// plan as JSON. With ANALYZE, the statement is run, and the plan holds the
+// actual rows and timings of each node.
+func Statement(sql string, analyze bool) (string, error) {
+	statements, err := sqlparse.Parse(sql, sqlparse.Postgres)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse SQL: %w", err)
+	}
+	if len(statements) != 1 {
+		return "", fmt.Errorf("sql must have exactly 1 statement, got %d", len(statements))
+	}
+	if analyze {
+		return "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) " + statements[0].Text, nil
+	}
+	return "EXPLAIN (FORMAT JSON) " + statements[0].Text, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Pool             *pgxpool.Pool
+	StatementTimeout time.Duration
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
+}
+
+// Invoke explains the statement, and returns its plan. A statement that is
+// analyzed runs in a transaction that is rolled back, so that it makes no
+// changes.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	sql, ok := paramsMap["sql"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", paramsMap["sql"])
+	}
+	analyze, _ := paramsMap["analyze"].(bool)
+
+	statement, err := Statement(sql, analyze)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := postgrescommon.TxOptions{StatementTimeout: t.StatementTimeout, Rollback: analyze}
+	return postgrescommon.RunInTx(ctx, t.Pool, opts, func(q postgrescommon.Querier) (any, error) {
+		results, err := q.Query(ctx, statement)
+		if err != nil {
+			return nil, fmt.Errorf("unable to explain statement: %w", err)
+		}
+		defer results.Close()
+
+		// EXPLAIN (FORMAT JSON) returns a single row with a json column,
+		// which pgx decodes
+		var plan any
+		if results.Next() {
+			values, err := results.Values()
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
+			}
+			plan = values[0]
+		}
+		results.Close()
+		if err := results.Err(); err != nil {
+			return nil, fmt.Errorf("unable to explain statement: %w", err)
+		}
+		return plan, nil
+	})
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -17,6 +17,8 @@ package postgressql
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
@@ -24,6 +26,10 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -60,6 +66,8 @@ type Config struct {
 	Source             string           `yaml:"source" validate:"required"`
 	Description        string           `yaml:"description" validate:"required"`
 	Statement          string           `yaml:"statement" validate:"required"`
+	StatementTimeout   string           `yaml:"statementTimeout"`
+	AllowSessions      bool             `yaml:"allowSessions"`
 	AuthRequired       []string         `yaml:"authRequired"`
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
@@ -67,11 +75,18 @@ type Config struct {
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.CatalogChecker = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return kind
 }
 
+// CatalogParameters returns the template parameters, whose identifiers are
+// looked up in the catalog before they are substituted into the statement.
+func (cfg Config) CatalogParameters() tools.Parameters {
+	return cfg.TemplateParameters
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -85,7 +100,23 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
-	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	statementTimeout, err := postgrescommon.ParseStatementTimeout(cfg.StatementTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := cfg.Parameters
+	if cfg.AllowSessions {
+		parameters = append(slices.Clone(cfg.Parameters), sqlsession.Parameter())
+	}
+	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, parameters)
+	claims, err := tools.BindClaims(cfg.Statement, allParameters, func(_ string, i int) string {
+		return fmt.Sprintf("$%d", len(cfg.Parameters)+i+1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	allParameters = append(allParameters, claims.Parameters...)
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -100,7 +131,9 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Parameters:         cfg.Parameters,
 		TemplateParameters: cfg.TemplateParameters,
 		AllParams:          allParameters,
-		Statement:          cfg.Statement,
+		Statement:          claims.Statement,
+		Claims:             claims,
+		StatementTimeout:   statementTimeout,
 		AuthRequired:       cfg.AuthRequired,
 		Pool:               s.PostgresPool(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
@@ -111,6 +144,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 // validate interface
 var _ tools.Tool = Tool{}
+var _ tools.DryRunner = Tool{}
 
 type Tool struct {
 	Name               string           `yaml:"name"`
@@ -120,14 +154,29 @@ type Tool struct {
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 	AllParams          tools.Parameters `yaml:"allParams"`
 
-	Pool        *pgxpool.Pool
-	Statement   string
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	Pool             *pgxpool.Pool
+	Statement        string
+	Claims           tools.ClaimBinding
+	StatementTimeout time.Duration
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, false)
+}
+
+// DryRun runs the statement in a transaction that is rolled back, and returns
+// the number of rows it affected along with the rows it returned.
+func (t Tool) DryRun(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, true)
+}
+
+func (t Tool) run(ctx context.Context, params tools.ParamValues, dryRun bool) (any, error) {
 	paramsMap := params.AsMap()
+	if err := postgrescommon.CheckCatalog(ctx, t.Pool, tools.CatalogIdentifiers(t.TemplateParameters, paramsMap)); err != nil {
+		return nil, err
+	}
 	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract template params %w", err)
@@ -137,28 +186,59 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
-	sliceParams := newParams.AsSlice()
-	results, err := t.Pool.Query(ctx, newStatement, sliceParams...)
+	newStatement, err = tools.RewriteArrayInLists(newStatement, t.Parameters, sqlparse.Postgres)
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", err)
+		return nil, fmt.Errorf("unable to rewrite array params: %w", err)
 	}
+	sliceParams := t.Claims.Args(newParams.AsSlice(), paramsMap)
+	opts := postgrescommon.TxOptions{StatementTimeout: t.StatementTimeout, Rollback: dryRun, Session: sqlsession.Token(params)}
+	return postgrescommon.RunInTx(ctx, t.Pool, opts, func(q postgrescommon.Querier) (any, error) {
+		results, err := q.Query(ctx, newStatement, sliceParams...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+		defer results.Close()
 
-	fields := results.FieldDescriptions()
+		fields := results.FieldDescriptions()
+		if !dryRun {
+			util.ReportColumns(ctx, postgrescommon.Columns(fields, results.Conn().TypeMap()))
+		}
 
-	var out []any
-	for results.Next() {
-		v, err := results.Values()
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
+		// the rows are written as they're read if the client streams them,
+		// except by a dry run, whose result also has the rows affected
+		write, stream := util.RowWriterFromContext(ctx)
+		stream = stream && !dryRun
+		var out []any
+		for results.Next() {
+			v, err := results.Values()
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, f := range fields {
+				vMap[f.Name] = postgrescommon.Value(f, v[i])
+			}
+			if stream {
+				if err := write(vMap); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			out = append(out, vMap)
 		}
-		vMap := make(map[string]any)
-		for i, f := range fields {
-			vMap[f.Name] = v[i]
+		// a statement canceled by the timeout fails while its rows are read
+		if err := results.Err(); err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+		if stream {
+			return nil, nil
+		}
+		if dryRun {
+			return postgrescommon.DryRunResult{RowsAffected: results.CommandTag().RowsAffected(), Rows: append([]any{}, out...)}, nil
 		}
-		out = append(out, vMap)
-	}
 
-	return out, nil
+		return out, nil
+	})
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
@@ -74,6 +74,30 @@ func TestParseFromYamlPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "statementTimeout example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-instance
+					description: some description
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+					statementTimeout: 30s
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgressql.Config{
+					Name:             "example_tool",
+					Kind:             "postgres-sql",
+					Source:           "my-instance",
+					Description:      "some description",
+					Statement:        "SELECT * FROM SQL_STATEMENT;\n",
+					StatementTimeout: "30s",
+					AuthRequired:     []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -147,6 +171,49 @@ func TestParseFromYamlWithTemplateParamsPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "identifier example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-pg-instance
+					description: some description
+					statement: |
+						SELECT {{array .fieldArray}} FROM {{.tableName}};
+					templateParameters:
+						- name: tableName
+						  type: string
+						  description: The table to select hotels from.
+						  identifier:
+								catalog: table
+						- name: fieldArray
+						  type: array
+						  description: The columns to return for the query.
+						  items:
+								name: column
+								type: string
+								description: A column name that will be returned from the query.
+								identifier:
+									pattern: "[a-z_]+"
+									catalog: column
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgressql.Config{
+					Name:         "example_tool",
+					Kind:         "postgres-sql",
+					Source:       "my-pg-instance",
+					Description:  "some description",
+					Statement:    "SELECT {{array .fieldArray}} FROM {{.tableName}};\n",
+					AuthRequired: []string{},
+					TemplateParameters: []tools.Parameter{
+						tools.NewStringParameterWithIdentifier("tableName", "The table to select hotels from.", &tools.Identifier{Catalog: tools.CatalogTable}),
+						tools.NewArrayParameter("fieldArray", "The columns to return for the query.",
+							tools.NewStringParameterWithIdentifier("column", "A column name that will be returned from the query.", &tools.Identifier{Pattern: "[a-z_]+", Catalog: tools.CatalogColumn})),
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
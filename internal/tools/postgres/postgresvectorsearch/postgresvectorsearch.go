@@ -0,0 +1,337 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresvectorsearch
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgrescommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/vectorcommon"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const kind string = "postgres-vector-search"
+
+// distanceColumn is the name of the column of the distance of each row to the
+// embedding.
+const distanceColumn = "distance"
+
+// embeddingStatement generates the embedding of a text with a model of the
+// google_ml_integration extension, as the text of a vector.
+const embeddingStatement = "SELECT embedding($1, $2)::vector::text"
+
+// searchOptionPrefixes are the prefixes of the run-time parameters of the
+// vector indexes, of pgvector and of the ScaNN indexes of AlloyDB.
+var searchOptionPrefixes = []string{"hnsw.", "ivfflat.", "scann."}
+
+// distanceOperators are the pgvector operators of the distance metrics.
+var distanceOperators = map[string]string{
+	"cosine":        "<=>",
+	"l2":            "<->",
+	"inner_product": "<#>",
+	"l1":            "<+>",
+}
+
+var identifier = regexp.MustCompile(`^` + tools.DefaultIdentifierPattern + `$`)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, Distance: "cosine"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &alloydbpg.Source{}
+var _ compatibleSource = &cloudsqlpg.Source{}
+var _ compatibleSource = &postgres.Source{}
+
+var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind}
+
+type Config struct {
+	Name            string   `yaml:"name" validate:"required"`
+	Kind            string   `yaml:"kind" validate:"required"`
+	Source          string   `yaml:"source" validate:"required"`
+	Description     string   `yaml:"description" validate:"required"`
+	Table           string   `yaml:"table" validate:"required"`
+	EmbeddingColumn string   `yaml:"embeddingColumn" validate:"required"`
+	Columns         []string `yaml:"columns" validate:"required"`
+	Distance        string   `yaml:"distance"`
+	Dimensions      int      `yaml:"dimensions" validate:"gte=0"`
+	// EmbeddingSource is the name of an embeddings source, which generates
+	// the embedding of a text given by the agent instead of an embedding.
+	EmbeddingSource string `yaml:"embeddingSource"`
+	// EmbeddingModel is the id of a model of the google_ml_integration
+	// extension of AlloyDB or Cloud SQL, whose embedding function generates
+	// the embedding of a text given by the agent in the database instead.
+	EmbeddingModel string `yaml:"embeddingModel"`
+	// SearchOptions are the run-time parameters of the vector index of the
+	// query, e.g. hnsw.ef_search or scann.num_leaves_to_search.
+	SearchOptions map[string]string `yaml:"searchOptions"`
+	// Filter is a condition that the rows must match, whose placeholders, $1
+	// to $n, are bound to the values of the parameters, in order.
+	Filter           string           `yaml:"filter"`
+	MaxLimit         int              `yaml:"maxLimit" validate:"gte=0"`
+	StatementTimeout string           `yaml:"statementTimeout"`
+	AuthRequired     []string         `yaml:"authRequired"`
+	Parameters       tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	// the names are part of the text of the statement
+	for _, name := range append([]string{cfg.Table, cfg.EmbeddingColumn}, cfg.Columns...) {
+		if !identifier.MatchString(name) {
+			return nil, fmt.Errorf("%q must be an unquoted identifier, e.g. public.documents", name)
+		}
+	}
+	if slices.Contains(cfg.Columns, distanceColumn) {
+		return nil, fmt.Errorf("column %q is reserved for the distance of the rows", distanceColumn)
+	}
+	operator, ok := distanceOperators[cfg.Distance]
+	if !ok {
+		return nil, fmt.Errorf("invalid distance %q: must be one of %q", cfg.Distance, []string{"cosine", "l2", "inner_product", "l1"})
+	}
+	if err := vectorcommon.CheckReserved(kind, cfg.Parameters, vectorcommon.EmbeddingParameterName, vectorcommon.TextParameterName, vectorcommon.LimitParameterName); err != nil {
+		return nil, err
+	}
+	if cfg.EmbeddingSource != "" && cfg.EmbeddingModel != "" {
+		return nil, fmt.Errorf("only one of embeddingSource or embeddingModel can be set")
+	}
+	for name := range cfg.SearchOptions {
+		if !slices.ContainsFunc(searchOptionPrefixes, func(prefix string) bool { return strings.HasPrefix(name, prefix) }) {
+			return nil, fmt.Errorf("invalid search option %q: must start with one of %q", name, searchOptionPrefixes)
+		}
+	}
+	statementTimeout, err := postgrescommon.ParseStatementTimeout(cfg.StatementTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	embedder, err := vectorcommon.NewEmbedder(kind, srcs, cfg.EmbeddingSource)
+	if err != nil {
+		return nil, err
+	}
+	textDesc := "The text to find the nearest rows to, by the similarity of their meaning."
+	embeddingParameter := vectorcommon.NewEmbeddingParameter(embedder, cfg.Dimensions, "The embedding to find the nearest rows to.", textDesc)
+	if cfg.EmbeddingModel != "" {
+		embeddingParameter = tools.NewStringParameter(vectorcommon.TextParameterName, textDesc)
+	}
+	limitParameter := vectorcommon.NewLimitParameter(cfg.MaxLimit, "Number of nearest rows to return.")
+	parameters := append(slices.Clone(cfg.Parameters), embeddingParameter, limitParameter)
+
+	t := Tool{
+		Name:             cfg.Name,
+		Kind:             kind,
+		Parameters:       parameters,
+		AuthRequired:     cfg.AuthRequired,
+		Pool:             s.PostgresPool(),
+		Embedder:         embedder,
+		EmbeddingModel:   cfg.EmbeddingModel,
+		Dimensions:       cfg.Dimensions,
+		SearchOptions:    cfg.SearchOptions,
+		Statement:        statement(cfg, operator),
+		StatementTimeout: statementTimeout,
+		manifest:         tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest: tools.McpManifest{
+			Name:        cfg.Name,
+			Description: cfg.Description,
+			InputSchema: parameters.McpManifest(),
+		},
+	}
+	return t, nil
+}
+
+// statement returns the query of the nearest rows to an embedding. The
+// embedding and the limit are bound to the placeholders that follow the ones
+// of the parameters of the filter.
+func statement(cfg Config, operator string) string {
+	embedding := fmt.Sprintf("$%d::vector", len(cfg.Parameters)+1)
+	limit := fmt.Sprintf("$%d", len(cfg.Parameters)+2)
+	distance := fmt.Sprintf("%s %s %s", cfg.EmbeddingColumn, operator, embedding)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "SELECT %s, %s AS %s FROM %s", strings.Join(cfg.Columns, ", "), distance, distanceColumn, cfg.Table)
+	if cfg.Filter != "" {
+		fmt.Fprintf(&sb, " WHERE (%s)", cfg.Filter)
+	}
+	// ordering by the distance expression, rather than its alias, lets
+	// Postgres use a vector index of the column
+	fmt.Fprintf(&sb, " ORDER BY %s LIMIT %s", distance, limit)
+	return sb.String()
+}
+
+// vectorLiteral returns the text of an embedding as a pgvector vector, e.g.
+// [0.1,0.2], since pgx can't encode the vector type.
+func vectorLiteral(embedding []float64) string {
+	values := make([]string, len(embedding))
+	for i, v := range embedding {
+		values[i] = strconv.FormatFloat(v, 'g', -1, 32)
+	}
+	return "[" + strings.Join(values, ",") + "]"
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Pool *pgxpool.Pool
+	// Embedder generates the embedding of the text parameter, if set.
+	Embedder vectorcommon.Embedder
+	// EmbeddingModel generates the embedding of the text parameter in the
+	// database, if set.
+	EmbeddingModel   string
+	Dimensions       int
+	SearchOptions    map[string]string
+	Statement        string
+	StatementTimeout time.Duration
+	manifest         tools.Manifest
+	mcpManifest      tools.McpManifest
+}
+
+// Invoke returns the rows nearest to the embedding that match the filter,
+// nearest first, along with their distance to it.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	var vector string
+	if t.EmbeddingModel == "" {
+		embedding, err := vectorcommon.Embedding(ctx, t.Embedder, t.Dimensions, paramsMap)
+		if err != nil {
+			return nil, err
+		}
+		vector = vectorLiteral(embedding)
+	}
+
+	opts := postgrescommon.TxOptions{StatementTimeout: t.StatementTimeout, Settings: t.SearchOptions}
+	return postgrescommon.RunInTx(ctx, t.Pool, opts, func(q postgrescommon.Querier) (any, error) {
+		if t.EmbeddingModel != "" {
+			var err error
+			if vector, err = generateEmbedding(ctx, q, t.EmbeddingModel, paramsMap[vectorcommon.TextParameterName]); err != nil {
+				return nil, err
+			}
+		}
+		// the embedding, or its text, and the limit are the last parameters
+		sliceParams := params.AsSlice()
+		n := len(sliceParams)
+		args := append(sliceParams[:n-2:n-2], vector, sliceParams[n-1])
+
+		results, err := q.Query(ctx, t.Statement, args...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+
+		fields := results.FieldDescriptions()
+
+		out := []any{}
+		for results.Next() {
+			v, err := results.Values()
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse row: %w", err)
+			}
+			vMap := make(map[string]any)
+			for i, f := range fields {
+				vMap[f.Name] = v[i]
+			}
+			out = append(out, vMap)
+		}
+		if err := results.Err(); err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+		return out, nil
+	})
+}
+
+// generateEmbedding returns the embedding of a text, generated by a model in
+// the database, as the text of a vector.
+func generateEmbedding(ctx context.Context, q postgrescommon.Querier, model string, text any) (string, error) {
+	results, err := q.Query(ctx, embeddingStatement, model, text)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate embedding: %w", err)
+	}
+	defer results.Close()
+	var vector string
+	if results.Next() {
+		if err := results.Scan(&vector); err != nil {
+			return "", fmt.Errorf("unable to parse embedding: %w", err)
+		}
+	}
+	results.Close()
+	if err := results.Err(); err != nil {
+		return "", fmt.Errorf("unable to generate embedding: %w", err)
+	}
+	return vector, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
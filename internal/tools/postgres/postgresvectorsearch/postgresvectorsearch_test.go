@@ -0,0 +1,391 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgresvectorsearch_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgres/postgresvectorsearch"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestParseFromYamlVectorSearch(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-vector-search
+					source: my-pg-instance
+					description: some description
+					table: docs.chunks
+					embeddingColumn: embedding
+					columns: [id, content]
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresvectorsearch.Config{
+					Name:            "example_tool",
+					Kind:            "postgres-vector-search",
+					Source:          "my-pg-instance",
+					Description:     "some description",
+					Table:           "docs.chunks",
+					EmbeddingColumn: "embedding",
+					Columns:         []string{"id", "content"},
+					Distance:        "cosine",
+					AuthRequired:    []string{},
+				},
+			},
+		},
+		{
+			desc: "filter example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-vector-search
+					source: my-pg-instance
+					description: some description
+					table: docs.chunks
+					embeddingColumn: embedding
+					columns: [id, content]
+					distance: l2
+					dimensions: 768
+					maxLimit: 20
+					filter: language = $1
+					parameters:
+						- name: language
+						  type: string
+						  description: Language of the chunks.
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresvectorsearch.Config{
+					Name:            "example_tool",
+					Kind:            "postgres-vector-search",
+					Source:          "my-pg-instance",
+					Description:     "some description",
+					Table:           "docs.chunks",
+					EmbeddingColumn: "embedding",
+					Columns:         []string{"id", "content"},
+					Distance:        "l2",
+					Dimensions:      768,
+					MaxLimit:        20,
+					Filter:          "language = $1",
+					AuthRequired:    []string{},
+					Parameters:      tools.Parameters{tools.NewStringParameter("language", "Language of the chunks.")},
+				},
+			},
+		},
+		{
+			desc: "embedding source example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-vector-search
+					source: my-pg-instance
+					description: some description
+					table: docs.chunks
+					embeddingColumn: embedding
+					columns: [id, content]
+					embeddingSource: my-embeddings
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresvectorsearch.Config{
+					Name:            "example_tool",
+					Kind:            "postgres-vector-search",
+					Source:          "my-pg-instance",
+					Description:     "some description",
+					Table:           "docs.chunks",
+					EmbeddingColumn: "embedding",
+					Columns:         []string{"id", "content"},
+					Distance:        "cosine",
+					EmbeddingSource: "my-embeddings",
+					AuthRequired:    []string{},
+				},
+			},
+		},
+		{
+			desc: "embedding model example",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-vector-search
+					source: my-alloydb-instance
+					description: some description
+					table: docs.chunks
+					embeddingColumn: embedding
+					columns: [id, content]
+					embeddingModel: text-embedding-005
+					searchOptions:
+						scann.num_leaves_to_search: "20"
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgresvectorsearch.Config{
+					Name:            "example_tool",
+					Kind:            "postgres-vector-search",
+					Source:          "my-alloydb-instance",
+					Description:     "some description",
+					Table:           "docs.chunks",
+					EmbeddingColumn: "embedding",
+					Columns:         []string{"id", "content"},
+					Distance:        "cosine",
+					EmbeddingModel:  "text-embedding-005",
+					SearchOptions:   map[string]string{"scann.num_leaves_to_search": "20"},
+					AuthRequired:    []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type fakeSource struct{}
+
+func (fakeSource) SourceKind() string {
+	return "postgres"
+}
+
+func (fakeSource) PostgresPool() *pgxpool.Pool {
+	return nil
+}
+
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) SourceKind() string {
+	return "embeddings"
+}
+
+func (fakeEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	return []float64{0.1, 0.2, 0.3}, nil
+}
+
+func TestInitialize(t *testing.T) {
+	srcs := map[string]sources.Source{"my-pg-instance": fakeSource{}, "my-embeddings": fakeEmbedder{}}
+	language := tools.NewStringParameter("language", "Language of the chunks.")
+
+	tcs := []struct {
+		desc    string
+		cfg     postgresvectorsearch.Config
+		want    string
+		wantErr string
+	}{
+		{
+			desc: "cosine",
+			cfg:  postgresvectorsearch.Config{Distance: "cosine"},
+			want: "SELECT id, content, embedding <=> $1::vector AS distance FROM docs.chunks ORDER BY embedding <=> $1::vector LIMIT $2",
+		},
+		{
+			desc: "filter",
+			cfg:  postgresvectorsearch.Config{Distance: "inner_product", Filter: "language = $1", Parameters: tools.Parameters{language}},
+			want: "SELECT id, content, embedding <#> $2::vector AS distance FROM docs.chunks WHERE (language = $1) ORDER BY embedding <#> $2::vector LIMIT $3",
+		},
+		{
+			desc:    "invalid distance",
+			cfg:     postgresvectorsearch.Config{Distance: "hamming"},
+			wantErr: `invalid distance "hamming"`,
+		},
+		{
+			desc:    "invalid column",
+			cfg:     postgresvectorsearch.Config{Distance: "cosine", Columns: []string{"id; DROP TABLE chunks"}},
+			wantErr: "must be an unquoted identifier",
+		},
+		{
+			desc:    "reserved column",
+			cfg:     postgresvectorsearch.Config{Distance: "cosine", Columns: []string{"id", "distance"}},
+			wantErr: `column "distance" is reserved`,
+		},
+		{
+			desc:    "reserved parameter",
+			cfg:     postgresvectorsearch.Config{Distance: "cosine", Filter: "id < $1", Parameters: tools.Parameters{tools.NewIntParameter("limit", "Maximum id.")}},
+			wantErr: `parameter name "limit" is reserved`,
+		},
+		{
+			desc: "embedding source",
+			cfg:  postgresvectorsearch.Config{Distance: "cosine", EmbeddingSource: "my-embeddings"},
+			want: "SELECT id, content, embedding <=> $1::vector AS distance FROM docs.chunks ORDER BY embedding <=> $1::vector LIMIT $2",
+		},
+		{
+			desc:    "missing embedding source",
+			cfg:     postgresvectorsearch.Config{Distance: "cosine", EmbeddingSource: "other-embeddings"},
+			wantErr: `no source named "other-embeddings" configured`,
+		},
+		{
+			desc:    "invalid embedding source",
+			cfg:     postgresvectorsearch.Config{Distance: "cosine", EmbeddingSource: "my-pg-instance"},
+			wantErr: "invalid embedding source",
+		},
+		{
+			desc: "embedding model",
+			cfg:  postgresvectorsearch.Config{Distance: "cosine", EmbeddingModel: "text-embedding-005", SearchOptions: map[string]string{"hnsw.ef_search": "100"}},
+			want: "SELECT id, content, embedding <=> $1::vector AS distance FROM docs.chunks ORDER BY embedding <=> $1::vector LIMIT $2",
+		},
+		{
+			desc:    "embedding source and model",
+			cfg:     postgresvectorsearch.Config{Distance: "cosine", EmbeddingSource: "my-embeddings", EmbeddingModel: "text-embedding-005"},
+			wantErr: "only one of embeddingSource or embeddingModel can be set",
+		},
+		{
+			desc:    "invalid search option",
+			cfg:     postgresvectorsearch.Config{Distance: "cosine", SearchOptions: map[string]string{"work_mem": "1GB"}},
+			wantErr: `invalid search option "work_mem"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := tc.cfg
+			cfg.Name, cfg.Kind, cfg.Source, cfg.Description = "example_tool", "postgres-vector-search", "my-pg-instance", "some description"
+			cfg.Table, cfg.EmbeddingColumn = "docs.chunks", "embedding"
+			if cfg.Columns == nil {
+				cfg.Columns = []string{"id", "content"}
+			}
+			tool, err := cfg.Initialize(srcs)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := tool.(postgresvectorsearch.Tool).Statement; got != tc.want {
+				t.Fatalf("incorrect statement: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseParamsDimensions(t *testing.T) {
+	cfg := postgresvectorsearch.Config{
+		Name:            "example_tool",
+		Kind:            "postgres-vector-search",
+		Source:          "my-pg-instance",
+		Description:     "some description",
+		Table:           "docs.chunks",
+		EmbeddingColumn: "embedding",
+		Columns:         []string{"id"},
+		Distance:        "cosine",
+		Dimensions:      3,
+		MaxLimit:        5,
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-pg-instance": fakeSource{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"embedding": []any{0.1, 0.2, 0.3}}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	want := tools.ParamValues{{Name: "embedding", Value: []any{0.1, 0.2, 0.3}}, {Name: "limit", Value: 5}}
+	if diff := cmp.Diff(want, params); diff != "" {
+		t.Fatalf("incorrect params: diff %v", diff)
+	}
+
+	if _, err := tool.ParseParams(map[string]any{"embedding": []any{0.1, 0.2}}, nil); err == nil {
+		t.Fatalf("expected error for an embedding with the wrong number of dimensions")
+	}
+	if _, err := tool.ParseParams(map[string]any{"embedding": []any{0.1, 0.2, 0.3}, "limit": 6}, nil); err == nil {
+		t.Fatalf("expected error for a limit above maxLimit")
+	}
+}
+
+func TestParseParamsText(t *testing.T) {
+	cfg := postgresvectorsearch.Config{
+		Name:            "example_tool",
+		Kind:            "postgres-vector-search",
+		Source:          "my-pg-instance",
+		Description:     "some description",
+		Table:           "docs.chunks",
+		EmbeddingColumn: "embedding",
+		Columns:         []string{"id"},
+		Distance:        "cosine",
+		EmbeddingSource: "my-embeddings",
+	}
+	srcs := map[string]sources.Source{"my-pg-instance": fakeSource{}, "my-embeddings": fakeEmbedder{}}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"text": "how do I reset my password?"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	want := tools.ParamValues{{Name: "text", Value: "how do I reset my password?"}, {Name: "limit", Value: 10}}
+	if diff := cmp.Diff(want, params); diff != "" {
+		t.Fatalf("incorrect params: diff %v", diff)
+	}
+
+	if _, err := tool.ParseParams(map[string]any{"embedding": []any{0.1, 0.2, 0.3}}, nil); err == nil {
+		t.Fatalf("expected error for an embedding instead of a text")
+	}
+}
+
+func TestParseParamsEmbeddingModel(t *testing.T) {
+	cfg := postgresvectorsearch.Config{
+		Name:            "example_tool",
+		Kind:            "postgres-vector-search",
+		Source:          "my-pg-instance",
+		Description:     "some description",
+		Table:           "docs.chunks",
+		EmbeddingColumn: "embedding",
+		Columns:         []string{"id"},
+		Distance:        "cosine",
+		EmbeddingModel:  "text-embedding-005",
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-pg-instance": fakeSource{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"text": "how do I reset my password?"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	want := tools.ParamValues{{Name: "text", Value: "how do I reset my password?"}, {Name: "limit", Value: 10}}
+	if diff := cmp.Diff(want, params); diff != "" {
+		t.Fatalf("incorrect params: diff %v", diff)
+	}
+}
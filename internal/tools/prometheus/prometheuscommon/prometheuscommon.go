@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package prometheuscommon contains the helpers shared by the Prometheus tools.
+//
+// The queries of the tools are Go templates, which are rendered with the
+// parameters of the tool before they are evaluated.
+package prometheuscommon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// ParseTemplate parses the text of a query. The `json` function of the
+// template encodes a parameter as a JSON value, which is also a valid PromQL
+// literal, so that strings are quoted and escaped.
+func ParseTemplate(text string) (*template.Template, error) {
+	t, err := template.New("query").Funcs(template.FuncMap{"json": toJSON}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse query: %w", err)
+	}
+	return t, nil
+}
+
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal param to JSON: %w", err)
+	}
+	return string(b), nil
+}
+
+// RenderQuery renders a template into a query.
+func RenderQuery(t *template.Template, params map[string]any) (string, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, params); err != nil {
+		return "", fmt.Errorf("error replacing query parameters: %s", err)
+	}
+	return b.String(), nil
+}
+
+// ParseTime parses a time given as `now`, an RFC3339 timestamp, a Unix
+// timestamp, or a negative duration relative to now such as `-1h`.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" || s == "now" {
+		return now, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(), nil
+	}
+	if strings.HasPrefix(s, "-") {
+		if d, err := model.ParseDuration(strings.TrimPrefix(s, "-")); err == nil {
+			return now.Add(-time.Duration(d)), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: must be now, an RFC3339 or Unix timestamp, or a negative duration such as -1h", s)
+}
+
+// Result returns the result of a query in the format of the Prometheus HTTP
+// API, with the warnings of the query if any.
+func Result(v model.Value, warnings promv1.Warnings) map[string]any {
+	result := map[string]any{
+		"resultType": v.Type().String(),
+		"result":     v,
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheuscommon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools/prometheus/prometheuscommon"
+)
+
+func TestRenderQuery(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		in     string
+		params map[string]any
+		want   string
+	}{
+		{
+			desc:   "quoted label value",
+			in:     `rate(http_requests_total{job={{json .job}}}[5m])`,
+			params: map[string]any{"job": `api"} or vector(1) #`},
+			want:   `rate(http_requests_total{job="api\"} or vector(1) #"}[5m])`,
+		},
+		{
+			desc:   "number",
+			in:     `up > {{.threshold}}`,
+			params: map[string]any{"threshold": 0.5},
+			want:   `up > 0.5`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			tmpl, err := prometheuscommon.ParseTemplate(tc.in)
+			if err != nil {
+				t.Fatalf("unable to parse: %s", err)
+			}
+			got, err := prometheuscommon.RenderQuery(tmpl, tc.params)
+			if err != nil {
+				t.Fatalf("unable to render: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect query: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	tcs := []struct {
+		in   string
+		want time.Time
+		err  bool
+	}{
+		{in: "", want: now},
+		{in: "now", want: now},
+		{in: "2025-01-30T00:00:00Z", want: time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC)},
+		{in: "1738324800", want: time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)},
+		{in: "1738324800.5", want: time.Date(2025, 1, 31, 12, 0, 0, 500000000, time.UTC)},
+		{in: "-1h30m", want: time.Date(2025, 1, 31, 10, 30, 0, 0, time.UTC)},
+		{in: "-2d", want: time.Date(2025, 1, 29, 12, 0, 0, 0, time.UTC)},
+		{in: "1h", err: true},
+		{in: "yesterday", err: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := prometheuscommon.ParseTime(tc.in, now)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Fatalf("incorrect time: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
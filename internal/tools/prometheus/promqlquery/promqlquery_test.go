@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promqlquery_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/prometheus"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/prometheus/promqlquery"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlPromQLQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: promql-query
+					source: my-prometheus-instance
+					description: some description
+					query: |
+						sum by (code) (rate(http_requests_total{job={{json .job}}}[5m]))
+					authRequired:
+						- my-google-auth-service
+					parameters:
+						- name: job
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": promqlquery.Config{
+					Name:         "example_tool",
+					Kind:         "promql-query",
+					Source:       "my-prometheus-instance",
+					Description:  "some description",
+					Query:        "sum by (code) (rate(http_requests_total{job={{json .job}}}[5m]))\n",
+					AuthRequired: []string{"my-google-auth-service"},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("job", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokePromQLQuery(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse form: %s", err)
+		}
+		query = r.Form.Get("query")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"code":"200"},"value":[1735689600,"12.5"]}]}}`))
+	}))
+	defer ts.Close()
+
+	src, err := prometheus.Config{Name: "my-prometheus-instance", Kind: prometheus.SourceKind, URL: ts.URL, Timeout: "5s"}.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	cfg := promqlquery.Config{
+		Name:        "example_tool",
+		Kind:        "promql-query",
+		Source:      "my-prometheus-instance",
+		Description: "some description",
+		Query:       `sum by (code) (rate(http_requests_total{job={{json .job}}}[5m]))`,
+		Parameters:  tools.Parameters{tools.NewStringParameter("job", "some description")},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-prometheus-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"job": "api"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+
+	if want := `sum by (code) (rate(http_requests_total{job="api"}[5m]))`; query != want {
+		t.Fatalf("incorrect query: got %q, want %q", query, want)
+	}
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("unable to marshal result: %s", err)
+	}
+	want := `{"result":[{"metric":{"code":"200"},"value":[1735689600,"12.5"]}],"resultType":"vector"}`
+	if string(b) != want {
+		t.Fatalf("incorrect result: got %s, want %s", b, want)
+	}
+}
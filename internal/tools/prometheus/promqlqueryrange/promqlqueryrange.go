@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package promqlqueryrange
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"text/template"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudmonitoring"
+	"github.com/googleapis/genai-toolbox/internal/sources/prometheus"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/prometheus/prometheuscommon"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+const kind string = "promql-query-range"
+
+// maxPoints is the number of points per series that the default step of a
+// query returns.
+const maxPoints = 250
+
+// rangeParameters are the parameters of the time range, which are added to
+// the parameters of each tool.
+var rangeParameters = tools.Parameters{
+	tools.NewStringParameter("start", "Start of the time range: an RFC3339 or Unix timestamp, or a negative duration relative to now such as -1h."),
+	tools.NewStringParameterWithDefault("end", "now", "End of the time range: now, an RFC3339 or Unix timestamp, or a negative duration relative to now such as -5m."),
+	tools.NewStringParameterWithRequired("step", "Resolution of the query, as a duration such as 1m or a number of seconds. Defaults to a step that returns 250 points.", false),
+}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type compatibleSource interface {
+	PrometheusAPI() promv1.API
+}
+
+// validate compatible sources are still compatible
+var _ compatibleSource = &prometheus.Source{}
+var _ compatibleSource = &cloudmonitoring.Source{}
+
+var compatibleSources = [...]string{prometheus.SourceKind, cloudmonitoring.SourceKind}
+
+type Config struct {
+	Name         string           `yaml:"name" validate:"required"`
+	Kind         string           `yaml:"kind" validate:"required"`
+	Source       string           `yaml:"source" validate:"required"`
+	Description  string           `yaml:"description" validate:"required"`
+	Query        string           `yaml:"query" validate:"required"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	queryTmpl, err := prometheuscommon.ParseTemplate(cfg.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range cfg.Parameters {
+		if slices.ContainsFunc(rangeParameters, func(r tools.Parameter) bool { return r.GetName() == p.GetName() }) {
+			return nil, fmt.Errorf("parameter %q is reserved for the time range of the query", p.GetName())
+		}
+	}
+	allParameters := slices.Concat(cfg.Parameters, rangeParameters)
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: allParameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AllParams:    allParameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Query:        queryTmpl,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	AllParams    tools.Parameters `yaml:"allParams"`
+
+	Source      compatibleSource
+	Query       *template.Template
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	r, err := timeRange(paramsMap, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	query, err := prometheuscommon.RenderQuery(t.Query, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	v, warnings, err := t.Source.PrometheusAPI().QueryRange(ctx, query, r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+	return prometheuscommon.Result(v, warnings), nil
+}
+
+// timeRange returns the time range of the range parameters.
+func timeRange(paramsMap map[string]any, now time.Time) (promv1.Range, error) {
+	start, _ := paramsMap["start"].(string)
+	end, _ := paramsMap["end"].(string)
+	step, _ := paramsMap["step"].(string)
+
+	r := promv1.Range{}
+	var err error
+	if r.Start, err = prometheuscommon.ParseTime(start, now); err != nil {
+		return r, fmt.Errorf("invalid start: %w", err)
+	}
+	if r.End, err = prometheuscommon.ParseTime(end, now); err != nil {
+		return r, fmt.Errorf("invalid end: %w", err)
+	}
+	if !r.End.After(r.Start) {
+		return r, fmt.Errorf("end %s must be after start %s", r.End.Format(time.RFC3339), r.Start.Format(time.RFC3339))
+	}
+
+	if step == "" {
+		r.Step = max(r.End.Sub(r.Start)/maxPoints, time.Second).Truncate(time.Second)
+		return r, nil
+	}
+	if seconds, err := strconv.ParseFloat(step, 64); err == nil {
+		r.Step = time.Duration(seconds * float64(time.Second))
+	} else if d, err := model.ParseDuration(step); err == nil {
+		r.Step = time.Duration(d)
+	} else {
+		return r, fmt.Errorf("invalid step %q: must be a duration such as 1m or a number of seconds", step)
+	}
+	if r.Step <= 0 {
+		return r, fmt.Errorf("step must be positive, got %q", step)
+	}
+	return r, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.AllParams, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promqlqueryrange_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/prometheus"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/prometheus/promqlqueryrange"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlPromQLQueryRange(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: promql-query-range
+					source: my-prometheus-instance
+					description: some description
+					query: |
+						rate(http_requests_total{job={{json .job}}}[5m])
+					parameters:
+						- name: job
+						  type: string
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": promqlqueryrange.Config{
+					Name:         "example_tool",
+					Kind:         "promql-query-range",
+					Source:       "my-prometheus-instance",
+					Description:  "some description",
+					Query:        "rate(http_requests_total{job={{json .job}}}[5m])\n",
+					AuthRequired: []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("job", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+// newSource returns a source whose server records the form of the last range
+// query.
+func newSource(t *testing.T, form *url.Values) sources.Source {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse form: %s", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/query" {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1735689600,"1"]}}`))
+			return
+		}
+		*form = r.Form
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"job":"api"},"values":[[1735689600,"1"],[1735689660,"2"]]}]}}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	src, err := prometheus.Config{Name: "my-prometheus-instance", Kind: prometheus.SourceKind, URL: ts.URL, Timeout: "5s"}.Initialize(context.Background(), noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	return src
+}
+
+func TestInvokePromQLQueryRange(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   map[string]any
+		want url.Values
+		err  string
+	}{
+		{
+			desc: "explicit range",
+			in:   map[string]any{"job": "api", "start": "2025-01-01T00:00:00Z", "end": "2025-01-01T01:00:00Z", "step": "1m"},
+			want: url.Values{
+				"query": {`rate(http_requests_total{job="api"}[5m])`},
+				"start": {"1735689600"},
+				"end":   {"1735693200"},
+				"step":  {"60"},
+			},
+		},
+		{
+			desc: "default step",
+			in:   map[string]any{"job": "api", "start": "1735689600", "end": "1735776000"},
+			want: url.Values{
+				"query": {`rate(http_requests_total{job="api"}[5m])`},
+				"start": {"1735689600"},
+				"end":   {"1735776000"},
+				"step":  {"345"},
+			},
+		},
+		{
+			desc: "end before start",
+			in:   map[string]any{"job": "api", "start": "2025-01-01T01:00:00Z", "end": "2025-01-01T00:00:00Z"},
+			err:  "must be after start",
+		},
+		{
+			desc: "invalid step",
+			in:   map[string]any{"job": "api", "start": "-1h", "step": "often"},
+			err:  `invalid step "often"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var form url.Values
+			src := newSource(t, &form)
+			cfg := promqlqueryrange.Config{
+				Name:        "example_tool",
+				Kind:        "promql-query-range",
+				Source:      "my-prometheus-instance",
+				Description: "some description",
+				Query:       `rate(http_requests_total{job={{json .job}}}[5m])`,
+				Parameters:  tools.Parameters{tools.NewStringParameter("job", "some description")},
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-prometheus-instance": src})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			params, err := tool.ParseParams(tc.in, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			_, err = tool.Invoke(context.Background(), params)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, form); diff != "" {
+				t.Fatalf("incorrect request: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializePromQLQueryRangeReservedParameter(t *testing.T) {
+	cfg := promqlqueryrange.Config{
+		Name:        "example_tool",
+		Kind:        "promql-query-range",
+		Source:      "my-prometheus-instance",
+		Description: "some description",
+		Query:       `up`,
+		Parameters:  tools.Parameters{tools.NewStringParameter("step", "some description")},
+	}
+	var form url.Values
+	_, err := cfg.Initialize(map[string]sources.Source{"my-prometheus-instance": newSource(t, &form)})
+	if err == nil || !strings.Contains(err.Error(), `parameter "step" is reserved`) {
+		t.Fatalf("unexpected error: got %v", err)
+	}
+}
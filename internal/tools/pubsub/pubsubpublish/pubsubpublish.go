@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pubsubpublish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/pubsub"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "pubsub-publish"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string            `yaml:"name" validate:"required"`
+	Kind         string            `yaml:"kind" validate:"required"`
+	Source       string            `yaml:"source" validate:"required"`
+	Description  string            `yaml:"description" validate:"required"`
+	Topic        string            `yaml:"topic" validate:"required"`
+	Attributes   map[string]string `yaml:"attributes"`
+	OrderingKey  string            `yaml:"orderingKey"`
+	AuthRequired []string          `yaml:"authRequired"`
+	Parameters   tools.Parameters  `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*pubsub.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, pubsub.SourceKind)
+	}
+
+	paramManifest := cfg.Parameters.Manifest()
+	if paramManifest == nil {
+		paramManifest = make([]tools.ParameterManifest, 0)
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Topic:        s.TopicName(cfg.Topic),
+		Attributes:   cfg.Attributes,
+		OrderingKey:  cfg.OrderingKey,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *pubsub.Source
+	Topic       string
+	Attributes  map[string]string
+	OrderingKey string
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke publishes a message whose data is the JSON object of the parameters,
+// keyed by their names. Since the parameters are typed, the message matches
+// the JSON encoding of the schema of the topic, if any.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	data, err := json.Marshal(params.AsMap())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal message data: %w", err)
+	}
+
+	req := &pubsubpb.PublishRequest{
+		Topic: t.Topic,
+		Messages: []*pubsubpb.PubsubMessage{{
+			Data:        data,
+			Attributes:  t.Attributes,
+			OrderingKey: t.OrderingKey,
+		}},
+	}
+	resp, err := t.Source.PubsubClient().TopicAdminClient.Publish(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to publish message: %w", err)
+	}
+	return map[string]any{"messageId": resp.MessageIds[0]}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,158 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsubpublish_test
+
+import (
+	"context"
+	"testing"
+
+	pubsubapi "cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/v2/pstest"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/pubsub"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/pubsub/pubsubpublish"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestParseFromYamlPubsubPublish(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: pubsub-publish
+					source: my-pubsub-source
+					description: some description
+					topic: incidents
+					attributes:
+						origin: toolbox
+					orderingKey: agent
+					parameters:
+						- name: severity
+						  type: string
+						  description: some description
+						- name: count
+						  type: integer
+						  description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": pubsubpublish.Config{
+					Name:         "example_tool",
+					Kind:         "pubsub-publish",
+					Source:       "my-pubsub-source",
+					Description:  "some description",
+					Topic:        "incidents",
+					Attributes:   map[string]string{"origin": "toolbox"},
+					OrderingKey:  "agent",
+					AuthRequired: []string{},
+					Parameters: []tools.Parameter{
+						tools.NewStringParameter("severity", "some description"),
+						tools.NewIntParameter("count", "some description"),
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokePubsubPublish(t *testing.T) {
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	defer srv.Close()
+	client, err := pubsubapi.NewClient(ctx, "my-project",
+		option.WithEndpoint(srv.Addr),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+	)
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	defer client.Close()
+	if _, err := client.TopicAdminClient.CreateTopic(ctx, &pubsubpb.Topic{Name: "projects/my-project/topics/incidents"}); err != nil {
+		t.Fatalf("unable to create topic: %s", err)
+	}
+	src := &pubsub.Source{Name: "my-pubsub-source", Kind: pubsub.SourceKind, Project: "my-project", Client: client}
+
+	cfg := pubsubpublish.Config{
+		Name:        "example_tool",
+		Kind:        "pubsub-publish",
+		Source:      "my-pubsub-source",
+		Description: "some description",
+		Topic:       "incidents",
+		Attributes:  map[string]string{"origin": "toolbox"},
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("severity", "some description"),
+			tools.NewIntParameter("count", "some description"),
+		},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-pubsub-source": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"severity": "high", "count": 3}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+
+	msgs := srv.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("incorrect number of messages: got %d, want 1", len(msgs))
+	}
+	if diff := cmp.Diff(map[string]any{"messageId": msgs[0].ID}, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	if want := `{"count":3,"severity":"high"}`; string(msgs[0].Data) != want {
+		t.Fatalf("incorrect data: got %s, want %s", msgs[0].Data, want)
+	}
+	if diff := cmp.Diff(map[string]string{"origin": "toolbox"}, msgs[0].Attributes); diff != "" {
+		t.Fatalf("incorrect attributes: diff %v", diff)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pubsubpull
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/pubsub"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+const kind string = "pubsub-pull"
+
+// maxMessagesLimit is the largest number of messages pulled at once.
+const maxMessagesLimit = 1000
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, MaxMessages: 10, Acknowledge: true}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	Subscription string   `yaml:"subscription" validate:"required"`
+	MaxMessages  int32    `yaml:"maxMessages"`
+	Acknowledge  bool     `yaml:"acknowledge"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*pubsub.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, pubsub.SourceKind)
+	}
+
+	if cfg.MaxMessages <= 0 || cfg.MaxMessages > maxMessagesLimit {
+		return nil, fmt.Errorf("maxMessages must be between 1 and %d, got %d", maxMessagesLimit, cfg.MaxMessages)
+	}
+
+	// No parameters needed for this tool
+	parameters := tools.Parameters{}
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Subscription: s.SubscriptionName(cfg.Subscription),
+		MaxMessages:  cfg.MaxMessages,
+		Acknowledge:  cfg.Acknowledge,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source       *pubsub.Source
+	Subscription string
+	MaxMessages  int32
+	Acknowledge  bool
+	manifest     tools.Manifest
+	mcpManifest  tools.McpManifest
+}
+
+// Invoke pulls at most MaxMessages messages of the subscription. The messages
+// are either acknowledged, or made available for redelivery right away.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	client := t.Source.PubsubClient().SubscriptionAdminClient
+	resp, err := client.Pull(ctx, &pubsubpb.PullRequest{Subscription: t.Subscription, MaxMessages: t.MaxMessages})
+	if err != nil {
+		return nil, fmt.Errorf("unable to pull messages: %w", err)
+	}
+
+	messages := make([]map[string]any, 0, len(resp.ReceivedMessages))
+	ackIDs := make([]string, 0, len(resp.ReceivedMessages))
+	for _, m := range resp.ReceivedMessages {
+		messages = append(messages, message(m))
+		ackIDs = append(ackIDs, m.AckId)
+	}
+	if len(ackIDs) == 0 {
+		return messages, nil
+	}
+
+	if t.Acknowledge {
+		err = client.Acknowledge(ctx, &pubsubpb.AcknowledgeRequest{Subscription: t.Subscription, AckIds: ackIDs})
+	} else {
+		err = client.ModifyAckDeadline(ctx, &pubsubpb.ModifyAckDeadlineRequest{Subscription: t.Subscription, AckIds: ackIDs, AckDeadlineSeconds: 0})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to release pulled messages: %w", err)
+	}
+	return messages, nil
+}
+
+// message converts a received message, decoding its data as JSON when it's a
+// valid JSON value.
+func message(m *pubsubpb.ReceivedMessage) map[string]any {
+	var data any
+	if err := json.Unmarshal(m.Message.Data, &data); err != nil {
+		data = string(m.Message.Data)
+	}
+	out := map[string]any{
+		"messageId":   m.Message.MessageId,
+		"publishTime": m.Message.PublishTime.AsTime().Format(time.RFC3339Nano),
+		"data":        data,
+	}
+	if len(m.Message.Attributes) > 0 {
+		out["attributes"] = m.Message.Attributes
+	}
+	if m.Message.OrderingKey != "" {
+		out["orderingKey"] = m.Message.OrderingKey
+	}
+	if m.DeliveryAttempt > 0 {
+		out["deliveryAttempt"] = m.DeliveryAttempt
+	}
+	return out
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsubpull_test
+
+import (
+	"context"
+	"testing"
+
+	pubsubapi "cloud.google.com/go/pubsub/v2"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"cloud.google.com/go/pubsub/v2/pstest"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/pubsub"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/pubsub/pubsubpull"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestParseFromYamlPubsubPull(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: pubsub-pull
+					source: my-pubsub-source
+					description: some description
+					subscription: incidents-agent
+			`,
+			want: server.ToolConfigs{
+				"example_tool": pubsubpull.Config{
+					Name:         "example_tool",
+					Kind:         "pubsub-pull",
+					Source:       "my-pubsub-source",
+					Description:  "some description",
+					Subscription: "incidents-agent",
+					MaxMessages:  10,
+					Acknowledge:  true,
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			tools:
+				example_tool:
+					kind: pubsub-pull
+					source: my-pubsub-source
+					description: some description
+					subscription: incidents-agent
+					maxMessages: 5
+					acknowledge: false
+			`,
+			want: server.ToolConfigs{
+				"example_tool": pubsubpull.Config{
+					Name:         "example_tool",
+					Kind:         "pubsub-pull",
+					Source:       "my-pubsub-source",
+					Description:  "some description",
+					Subscription: "incidents-agent",
+					MaxMessages:  5,
+					Acknowledge:  false,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidMaxMessages(t *testing.T) {
+	cfg := pubsubpull.Config{
+		Name:         "example_tool",
+		Kind:         "pubsub-pull",
+		Source:       "my-pubsub-source",
+		Description:  "some description",
+		Subscription: "incidents-agent",
+		MaxMessages:  1001,
+	}
+	srcs := map[string]sources.Source{"my-pubsub-source": &pubsub.Source{Project: "my-project"}}
+	_, err := cfg.Initialize(srcs)
+	if err == nil {
+		t.Fatalf("expect initialization to fail")
+	}
+	if want := "maxMessages must be between 1 and 1000, got 1001"; err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err, want)
+	}
+}
+
+func TestInvokePubsubPull(t *testing.T) {
+	tcs := []struct {
+		desc        string
+		acknowledge bool
+		wantAcks    int
+		wantNacks   int
+	}{
+		{desc: "acknowledge", acknowledge: true, wantAcks: 1, wantNacks: 0},
+		{desc: "no acknowledge", acknowledge: false, wantAcks: 0, wantNacks: 1},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.Background()
+			srv := pstest.NewServer()
+			defer srv.Close()
+			client, err := pubsubapi.NewClient(ctx, "my-project",
+				option.WithEndpoint(srv.Addr),
+				option.WithoutAuthentication(),
+				option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+			)
+			if err != nil {
+				t.Fatalf("unable to create client: %s", err)
+			}
+			defer client.Close()
+			topic := "projects/my-project/topics/incidents"
+			if _, err := client.TopicAdminClient.CreateTopic(ctx, &pubsubpb.Topic{Name: topic}); err != nil {
+				t.Fatalf("unable to create topic: %s", err)
+			}
+			sub := &pubsubpb.Subscription{Name: "projects/my-project/subscriptions/incidents-agent", Topic: topic, AckDeadlineSeconds: 60}
+			if _, err := client.SubscriptionAdminClient.CreateSubscription(ctx, sub); err != nil {
+				t.Fatalf("unable to create subscription: %s", err)
+			}
+			srv.Publish(topic, []byte(`{"severity":"high"}`), map[string]string{"origin": "toolbox"})
+			srv.Publish(topic, []byte("plain text"), nil)
+			srv.Publish(topic, []byte("plain text"), nil)
+
+			src := &pubsub.Source{Name: "my-pubsub-source", Kind: pubsub.SourceKind, Project: "my-project", Client: client}
+			cfg := pubsubpull.Config{
+				Name:         "example_tool",
+				Kind:         "pubsub-pull",
+				Source:       "my-pubsub-source",
+				Description:  "some description",
+				Subscription: "incidents-agent",
+				MaxMessages:  2,
+				Acknowledge:  tc.acknowledge,
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-pubsub-source": src})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			got, err := tool.Invoke(ctx, nil)
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+
+			messages, ok := got.([]map[string]any)
+			if !ok {
+				t.Fatalf("unexpected result type %T", got)
+			}
+			if len(messages) == 0 || len(messages) > 2 {
+				t.Fatalf("incorrect number of messages: got %d, want 1 or 2", len(messages))
+			}
+			datas := map[string]any{}
+			for _, m := range messages {
+				datas[m["messageId"].(string)] = m["data"]
+			}
+			for _, m := range srv.Messages() {
+				data, ok := datas[m.ID]
+				if !ok {
+					continue
+				}
+				if m.ID == "m0" {
+					if diff := cmp.Diff(map[string]any{"severity": "high"}, data); diff != "" {
+						t.Fatalf("incorrect data: diff %v", diff)
+					}
+				} else if data != "plain text" {
+					t.Fatalf("incorrect data: got %v, want %q", data, "plain text")
+				}
+				if m.Acks != tc.wantAcks {
+					t.Fatalf("incorrect acks of message %s: got %d, want %d", m.ID, m.Acks, tc.wantAcks)
+				}
+				nacks := 0
+				for _, modack := range m.Modacks {
+					if modack.AckDeadline == 0 {
+						nacks++
+					}
+				}
+				if nacks != tc.wantNacks {
+					t.Fatalf("incorrect nacks of message %s: got %d, want %d", m.ID, nacks, tc.wantNacks)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qdrantquery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/qdrant"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/vectorcommon"
+)
+
+const kind string = "qdrant-query"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	Collection  string `yaml:"collection" validate:"required"`
+	// VectorName is the name of the vector to search, for collections with
+	// named vectors.
+	VectorName      string   `yaml:"vectorName"`
+	Dimensions      int      `yaml:"dimensions" validate:"gte=0"`
+	EmbeddingSource string   `yaml:"embeddingSource"`
+	MaxLimit        int      `yaml:"maxLimit" validate:"gte=0"`
+	AuthRequired    []string `yaml:"authRequired"`
+	// Parameters are payload fields that the points must be equal to.
+	Parameters tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*qdrant.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, qdrant.SourceKind)
+	}
+
+	if err := vectorcommon.CheckReserved(kind, cfg.Parameters, vectorcommon.EmbeddingParameterName, vectorcommon.TextParameterName, vectorcommon.LimitParameterName); err != nil {
+		return nil, err
+	}
+	embedder, err := vectorcommon.NewEmbedder(kind, srcs, cfg.EmbeddingSource)
+	if err != nil {
+		return nil, err
+	}
+	embeddingParameter := vectorcommon.NewEmbeddingParameter(embedder, cfg.Dimensions, "The embedding to find the nearest points to.", "The text to find the nearest points to, by the similarity of their meaning.")
+	limitParameter := vectorcommon.NewLimitParameter(cfg.MaxLimit, "Number of nearest points to return.")
+	parameters := append(slices.Clone(cfg.Parameters), embeddingParameter, limitParameter)
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Collection:   cfg.Collection,
+		VectorName:   cfg.VectorName,
+		Dimensions:   cfg.Dimensions,
+		Embedder:     embedder,
+		Filters:      cfg.Parameters,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *qdrant.Source
+	Collection  string
+	VectorName  string
+	Dimensions  int
+	Embedder    vectorcommon.Embedder
+	Filters     tools.Parameters
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke returns the points nearest to the embedding whose payload match the
+// filters, nearest first, along with their payload and score.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	embedding, err := vectorcommon.Embedding(ctx, t.Embedder, t.Dimensions, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{
+		"query":        embedding,
+		"limit":        paramsMap[vectorcommon.LimitParameterName],
+		"with_payload": true,
+	}
+	if t.VectorName != "" {
+		body["using"] = t.VectorName
+	}
+	var must []any
+	for _, p := range t.Filters {
+		// optional filters without a value match any point
+		if v := paramsMap[p.GetName()]; v != nil {
+			must = append(must, map[string]any{"key": p.GetName(), "match": map[string]any{"value": v}})
+		}
+	}
+	if len(must) > 0 {
+		body["filter"] = map[string]any{"must": must}
+	}
+
+	res, err := t.Source.Do(ctx, http.MethodPost, "/collections/"+url.PathEscape(t.Collection)+"/points/query", body)
+	if err != nil {
+		return nil, err
+	}
+	resMap, _ := res.(map[string]any)
+	result, _ := resMap["result"].(map[string]any)
+	points, _ := result["points"].([]any)
+	rows := make([]any, 0, len(points))
+	for _, p := range points {
+		point, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		row := map[string]any{"id": point["id"], "score": point["score"]}
+		if payload, ok := point["payload"].(map[string]any); ok {
+			for k, v := range payload {
+				row[k] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qdrantupsert
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/qdrant"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/vectorcommon"
+)
+
+const kind string = "qdrant-upsert"
+
+const idParameterName = "id"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	Collection  string `yaml:"collection" validate:"required"`
+	// VectorName is the name of the vector to store, for collections with
+	// named vectors.
+	VectorName      string `yaml:"vectorName"`
+	Dimensions      int    `yaml:"dimensions" validate:"gte=0"`
+	EmbeddingSource string `yaml:"embeddingSource"`
+	// TextField is the payload field that the text is stored in, if the
+	// embedding is generated by the embedding source.
+	TextField    string   `yaml:"textField"`
+	AuthRequired []string `yaml:"authRequired"`
+	// Parameters are the payload fields of the point.
+	Parameters tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*qdrant.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, qdrant.SourceKind)
+	}
+
+	if err := vectorcommon.CheckReserved(kind, cfg.Parameters, idParameterName, vectorcommon.EmbeddingParameterName, vectorcommon.TextParameterName, cfg.TextField); err != nil {
+		return nil, err
+	}
+	embedder, err := vectorcommon.NewEmbedder(kind, srcs, cfg.EmbeddingSource)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TextField != "" && embedder == nil {
+		return nil, fmt.Errorf("textField requires an embeddingSource")
+	}
+	idParameter := tools.NewStringParameter(idParameterName, "The id of the point, an unsigned integer or a UUID, which replaces the point with the same id.")
+	embeddingParameter := vectorcommon.NewEmbeddingParameter(embedder, cfg.Dimensions, "The embedding of the point.", "The text whose embedding is stored.")
+	parameters := append(tools.Parameters{idParameter, embeddingParameter}, cfg.Parameters...)
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Collection:   cfg.Collection,
+		VectorName:   cfg.VectorName,
+		Dimensions:   cfg.Dimensions,
+		Embedder:     embedder,
+		TextField:    cfg.TextField,
+		Payload:      cfg.Parameters,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *qdrant.Source
+	Collection  string
+	VectorName  string
+	Dimensions  int
+	Embedder    vectorcommon.Embedder
+	TextField   string
+	Payload     tools.Parameters
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke inserts the point, or replaces the point with the same id, along
+// with its payload.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	id, ok := paramsMap[idParameterName].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", paramsMap[idParameterName])
+	}
+	embedding, err := vectorcommon.Embedding(ctx, t.Embedder, t.Dimensions, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := make(map[string]any)
+	for _, p := range t.Payload {
+		payload[p.GetName()] = paramsMap[p.GetName()]
+	}
+	if t.TextField != "" {
+		payload[t.TextField] = paramsMap[vectorcommon.TextParameterName]
+	}
+	var vector any = embedding
+	if t.VectorName != "" {
+		vector = map[string]any{t.VectorName: embedding}
+	}
+	point := map[string]any{"id": pointID(id), "vector": vector, "payload": payload}
+
+	// wait makes the point searchable before the tool returns
+	path := "/collections/" + url.PathEscape(t.Collection) + "/points?wait=true"
+	if _, err := t.Source.Do(ctx, http.MethodPut, path, map[string]any{"points": []any{point}}); err != nil {
+		return nil, err
+	}
+	return map[string]any{"id": id}, nil
+}
+
+// pointID returns an id that is an unsigned integer as a number, since Qdrant
+// only takes the ids that are UUIDs as strings.
+func pointID(id string) any {
+	if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+		return n
+	}
+	return id
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
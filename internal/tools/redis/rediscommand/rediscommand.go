@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rediscommand
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/redis/rediscommon"
+)
+
+const kind string = "redis-command"
+
+// DefaultAllowedCommands are the commands that the agent may run if
+// allowedCommands isn't set, which only read keys.
+var DefaultAllowedCommands = []string{
+	"EXISTS", "GET", "HGET", "HGETALL", "HKEYS", "HLEN", "HMGET", "HSCAN",
+	"LLEN", "LRANGE", "MGET", "PTTL", "SCAN", "SCARD", "SISMEMBER", "SMEMBERS",
+	"SSCAN", "STRLEN", "TTL", "TYPE", "ZCARD", "ZRANGE", "ZSCAN", "ZSCORE",
+}
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	// AllowedCommands are the names of the commands that the agent may run.
+	// Defaults to DefaultAllowedCommands.
+	AllowedCommands []string `yaml:"allowedCommands"`
+	AuthRequired    []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	do, err := rediscommon.NewDoer(kind, rawS)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := DefaultAllowedCommands
+	if len(cfg.AllowedCommands) > 0 {
+		allowed = make([]string, len(cfg.AllowedCommands))
+		for i, c := range cfg.AllowedCommands {
+			allowed[i] = strings.ToUpper(c)
+		}
+	}
+	commandParameter := tools.NewArrayParameter("command", fmt.Sprintf("The command to run, as its name followed by its arguments, e.g. [\"GET\", \"my-key\"]. The name must be one of %s.", strings.Join(allowed, ", ")), tools.NewStringParameter("part", "The name or an argument of the command."))
+	minItems := 1
+	commandParameter.MinItems = &minItems
+	parameters := tools.Parameters{commandParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:            cfg.Name,
+		Kind:            kind,
+		Parameters:      parameters,
+		AuthRequired:    cfg.AuthRequired,
+		Do:              do,
+		AllowedCommands: allowed,
+		manifest:        tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:     mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Do              rediscommon.Doer
+	AllowedCommands []string
+	manifest        tools.Manifest
+	mcpManifest     tools.McpManifest
+}
+
+// Invoke runs the command, if it's allowed, and returns its reply.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	values, ok := params.AsMap()["command"].([]any)
+	if !ok || len(values) == 0 {
+		return nil, fmt.Errorf("unable to get cast %s", params.AsMap()["command"])
+	}
+	args := make([]string, len(values))
+	for i, v := range values {
+		args[i] = fmt.Sprint(v)
+	}
+	if name := strings.ToUpper(args[0]); !slices.Contains(t.AllowedCommands, name) {
+		return nil, fmt.Errorf("command %q is not allowed: must be one of %q", args[0], t.AllowedCommands)
+	}
+
+	res, err := t.Do(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run command: %w", err)
+	}
+	return res, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rediscommand_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	redissrc "github.com/googleapis/genai-toolbox/internal/sources/redis"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/redis/rediscommand"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestParseFromYamlRedisCommand(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		example_tool:
+			kind: redis-command
+			source: my-redis-instance
+			description: some description
+			allowedCommands: [GET, TTL]
+	`
+	want := server.ToolConfigs{
+		"example_tool": rediscommand.Config{
+			Name:            "example_tool",
+			Kind:            "redis-command",
+			Source:          "my-redis-instance",
+			Description:     "some description",
+			AllowedCommands: []string{"GET", "TTL"},
+			AuthRequired:    []string{},
+		},
+	}
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	// Parse contents
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+// fakeClient records the commands, and replies to them with the reply of their
+// name, or a nil reply.
+type fakeClient struct {
+	replies map[string]any
+	cmds    [][]any
+}
+
+func (c *fakeClient) Do(ctx context.Context, args ...any) *redis.Cmd {
+	c.cmds = append(c.cmds, args)
+	cmd := redis.NewCmd(ctx, args...)
+	if reply, ok := c.replies[fmt.Sprint(args[0])]; ok {
+		cmd.SetVal(reply)
+	} else {
+		cmd.SetErr(redis.Nil)
+	}
+	return cmd
+}
+
+func TestInvoke(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		replies  map[string]any
+		data     map[string]any
+		wantCmds [][]any
+		want     any
+		allowed  []string
+		wantErr  string
+	}{
+		{
+			desc:     "default allow-list",
+			replies:  map[string]any{"ttl": int64(30)},
+			data:     map[string]any{"command": []any{"ttl", "session:1"}},
+			wantCmds: [][]any{{"ttl", "session:1"}},
+			want:     int64(30),
+		},
+		{
+			desc:    "command not in default allow-list",
+			data:    map[string]any{"command": []any{"FLUSHALL"}},
+			wantErr: `command "FLUSHALL" is not allowed`,
+		},
+		{
+			desc:     "configured allow-list",
+			allowed:  []string{"get", "set"},
+			replies:  map[string]any{"SET": "OK"},
+			data:     map[string]any{"command": []any{"SET", "greeting", "hello"}},
+			wantCmds: [][]any{{"SET", "greeting", "hello"}},
+			want:     "OK",
+		},
+		{
+			desc:    "command not in configured allow-list",
+			allowed: []string{"GET"},
+			data:    map[string]any{"command": []any{"HGETALL", "user:1"}},
+			wantErr: `command "HGETALL" is not allowed`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := &fakeClient{replies: tc.replies}
+			cfg := rediscommand.Config{
+				Name:            "example_tool",
+				Kind:            "redis-command",
+				Source:          "my-redis-instance",
+				Description:     "some description",
+				AllowedCommands: tc.allowed,
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-redis-instance": &redissrc.Source{Client: client}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			params, err := tool.ParseParams(tc.data, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantCmds, client.cmds); diff != "" {
+				t.Fatalf("incorrect commands: diff %v", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseParamsEmptyCommand(t *testing.T) {
+	cfg := rediscommand.Config{Name: "example_tool", Kind: "redis-command", Source: "my-redis-instance", Description: "some description"}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-redis-instance": &redissrc.Source{Client: &fakeClient{}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := tool.ParseParams(map[string]any{"command": []any{}}, nil); err == nil {
+		t.Fatalf("expected error for an empty command")
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rediscommon runs the commands of the tools of Redis and Valkey
+// sources.
+package rediscommon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	redissrc "github.com/googleapis/genai-toolbox/internal/sources/redis"
+	valkeysrc "github.com/googleapis/genai-toolbox/internal/sources/valkey"
+	"github.com/redis/go-redis/v9"
+	"github.com/valkey-io/valkey-go"
+)
+
+// CompatibleSources are the kinds of the sources that the tools run commands
+// on.
+var CompatibleSources = [...]string{redissrc.SourceKind, valkeysrc.SourceKind}
+
+// Doer runs a command and returns its reply, which is nil for a nil reply,
+// e.g. of the GET of a missing key. Maps are returned as map[string]any.
+type Doer func(ctx context.Context, args ...string) (any, error)
+
+type redisSource interface {
+	RedisClient() redissrc.RedisClient
+}
+
+type valkeySource interface {
+	ValkeyClient() valkey.Client
+}
+
+// validate compatible sources are still compatible
+var _ redisSource = &redissrc.Source{}
+var _ valkeySource = &valkeysrc.Source{}
+
+// NewDoer returns the Doer of a Redis or Valkey source.
+func NewDoer(kind string, src sources.Source) (Doer, error) {
+	switch s := src.(type) {
+	case redisSource:
+		client := s.RedisClient()
+		return func(ctx context.Context, args ...string) (any, error) {
+			cmdArgs := make([]any, len(args))
+			for i, a := range args {
+				cmdArgs[i] = a
+			}
+			v, err := client.Do(ctx, cmdArgs...).Result()
+			if errors.Is(err, redis.Nil) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return normalize(v), nil
+		}, nil
+	case valkeySource:
+		client := s.ValkeyClient()
+		return func(ctx context.Context, args ...string) (any, error) {
+			v, err := client.Do(ctx, client.B().Arbitrary(args...).Build()).ToAny()
+			if valkey.IsValkeyNil(err) {
+				return nil, nil
+			}
+			return v, err
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, CompatibleSources)
+	}
+}
+
+// normalize converts the map[any]any of RESP3 maps to map[string]any, which
+// can be encoded as JSON.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = normalize(e)
+		}
+		return m
+	case []any:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// Pairs returns the map of a RESP3 map reply, or of a RESP2 array of
+// alternating fields and values, e.g. of HGETALL.
+func Pairs(v any) (map[string]any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		return v, nil
+	case []any:
+		if len(v)%2 != 0 {
+			return nil, fmt.Errorf("unexpected reply of %d elements", len(v))
+		}
+		m := make(map[string]any, len(v)/2)
+		for i := 0; i < len(v); i += 2 {
+			m[fmt.Sprint(v[i])] = v[i+1]
+		}
+		return m, nil
+	case nil:
+		return map[string]any{}, nil
+	default:
+		return nil, fmt.Errorf("unexpected reply of type %T", v)
+	}
+}
+
+// EscapePattern escapes the special characters of the glob-style patterns of
+// SCAN and KEYS in s, so that it only matches itself.
+func EscapePattern(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rediscommon_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools/redis/rediscommon"
+)
+
+func TestPairs(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		in      any
+		want    map[string]any
+		wantErr bool
+	}{
+		{desc: "map", in: map[string]any{"a": "1"}, want: map[string]any{"a": "1"}},
+		{desc: "array", in: []any{"a", "1", "b", "2"}, want: map[string]any{"a": "1", "b": "2"}},
+		{desc: "nil", in: nil, want: map[string]any{}},
+		{desc: "odd array", in: []any{"a"}, wantErr: true},
+		{desc: "string", in: "a", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := rediscommon.Pairs(tc.in)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %v", tc.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect pairs: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestEscapePattern(t *testing.T) {
+	for in, want := range map[string]string{
+		"flags:":     "flags:",
+		"a*b?[c]":    `a\*b\?\[c\]`,
+		`back\slash`: `back\\slash`,
+	} {
+		if got := rediscommon.EscapePattern(in); got != want {
+			t.Fatalf("incorrect pattern for %q: got %q, want %q", in, got, want)
+		}
+	}
+}
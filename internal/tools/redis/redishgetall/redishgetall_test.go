@@ -0,0 +1,145 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redishgetall_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	redissrc "github.com/googleapis/genai-toolbox/internal/sources/redis"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/redis/redishgetall"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestParseFromYamlRedisHGetAll(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		example_tool:
+			kind: redis-hgetall
+			source: my-redis-instance
+			description: some description
+			keyPrefix: "user:"
+	`
+	want := server.ToolConfigs{
+		"example_tool": redishgetall.Config{
+			Name:         "example_tool",
+			Kind:         "redis-hgetall",
+			Source:       "my-redis-instance",
+			Description:  "some description",
+			KeyPrefix:    "user:",
+			AuthRequired: []string{},
+		},
+	}
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	// Parse contents
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+// fakeClient records the commands, and replies to them with the reply of their
+// name, or a nil reply.
+type fakeClient struct {
+	replies map[string]any
+	cmds    [][]any
+}
+
+func (c *fakeClient) Do(ctx context.Context, args ...any) *redis.Cmd {
+	c.cmds = append(c.cmds, args)
+	cmd := redis.NewCmd(ctx, args...)
+	if reply, ok := c.replies[fmt.Sprint(args[0])]; ok {
+		cmd.SetVal(reply)
+	} else {
+		cmd.SetErr(redis.Nil)
+	}
+	return cmd
+}
+
+func TestInvoke(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		replies  map[string]any
+		data     map[string]any
+		wantCmds [][]any
+		want     any
+	}{
+		{
+			desc:     "resp3",
+			replies:  map[string]any{"HGETALL": map[any]any{"name": "Alice", "plan": "pro"}},
+			data:     map[string]any{"key": "1"},
+			wantCmds: [][]any{{"HGETALL", "user:1"}},
+			want:     map[string]any{"name": "Alice", "plan": "pro"},
+		},
+		{
+			desc:     "resp2",
+			replies:  map[string]any{"HGETALL": []any{"name", "Alice", "plan", "pro"}},
+			data:     map[string]any{"key": "1"},
+			wantCmds: [][]any{{"HGETALL", "user:1"}},
+			want:     map[string]any{"name": "Alice", "plan": "pro"},
+		},
+		{
+			desc:     "missing key",
+			replies:  map[string]any{"HGETALL": map[any]any{}},
+			data:     map[string]any{"key": "2"},
+			wantCmds: [][]any{{"HGETALL", "user:2"}},
+			want:     map[string]any{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := &fakeClient{replies: tc.replies}
+			cfg := redishgetall.Config{
+				Name:        "example_tool",
+				Kind:        "redis-hgetall",
+				Source:      "my-redis-instance",
+				Description: "some description",
+				KeyPrefix:   "user:",
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-redis-instance": &redissrc.Source{Client: client}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			params, err := tool.ParseParams(tc.data, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.wantCmds, client.cmds); diff != "" {
+				t.Fatalf("incorrect commands: diff %v", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
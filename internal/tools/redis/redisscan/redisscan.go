@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisscan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/redis/rediscommon"
+)
+
+const kind string = "redis-scan"
+
+// defaultMaxCount is the maximum count that the agent may ask for, if
+// maxCount isn't set.
+const defaultMaxCount = 1000
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	// KeyPrefix is prepended to the keys of the agent, so that it can only
+	// list the keys with the prefix, e.g. flags:.
+	KeyPrefix    string   `yaml:"keyPrefix"`
+	MaxCount     int      `yaml:"maxCount" validate:"gte=0"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	do, err := rediscommon.NewDoer(kind, rawS)
+	if err != nil {
+		return nil, err
+	}
+	maxCount := cfg.MaxCount
+	if maxCount == 0 {
+		maxCount = defaultMaxCount
+	}
+	countParameter := tools.NewIntParameterWithDefault("count", min(100, maxCount), "The number of keys to look at, which is a hint of the number of keys to return.")
+	minimum, maximum := 1.0, float64(maxCount)
+	countParameter.Minimum, countParameter.Maximum = &minimum, &maximum
+
+	parameters := tools.Parameters{
+		tools.NewStringParameterWithDefault("pattern", "*", "The glob-style pattern of the keys to return, e.g. user:*."),
+		tools.NewStringParameterWithDefault("cursor", "0", "The cursor returned by the previous call, to continue the scan, or 0 to start it."),
+		countParameter,
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Do:           do,
+		KeyPrefix:    cfg.KeyPrefix,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Do          rediscommon.Doer
+	KeyPrefix   string
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke returns the keys of the next iteration of the scan that match the
+// pattern, without the key prefix, along with the cursor of the iteration after
+// it, which is 0 once the scan is complete.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	pattern, _ := paramsMap["pattern"].(string)
+	cursor, _ := paramsMap["cursor"].(string)
+	count, _ := paramsMap["count"].(int)
+	res, err := t.Do(ctx, "SCAN", cursor, "MATCH", rediscommon.EscapePattern(t.KeyPrefix)+pattern, "COUNT", strconv.Itoa(count))
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan keys: %w", err)
+	}
+
+	// the reply is the next cursor and the keys
+	reply, ok := res.([]any)
+	if !ok || len(reply) != 2 {
+		return nil, fmt.Errorf("unexpected reply of SCAN: %v", res)
+	}
+	values, _ := reply[1].([]any)
+	keys := make([]string, len(values))
+	for i, v := range values {
+		keys[i] = strings.TrimPrefix(fmt.Sprint(v), t.KeyPrefix)
+	}
+	return map[string]any{"cursor": fmt.Sprint(reply[0]), "keys": keys}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisset
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/redis/rediscommon"
+)
+
+const kind string = "redis-set"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	// KeyPrefix is prepended to the keys of the agent, so that it can only
+	// write the keys with the prefix, e.g. flags:.
+	KeyPrefix string `yaml:"keyPrefix"`
+	// TTL is the time to live of the keys, e.g. 1h. Defaults to no expiry.
+	TTL          string   `yaml:"ttl"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	do, err := rediscommon.NewDoer(kind, rawS)
+	if err != nil {
+		return nil, err
+	}
+	var ttl time.Duration
+	if cfg.TTL != "" {
+		if ttl, err = time.ParseDuration(cfg.TTL); err != nil {
+			return nil, fmt.Errorf("unable to parse ttl %q as a duration: %w", cfg.TTL, err)
+		}
+		if ttl < time.Millisecond {
+			return nil, fmt.Errorf("ttl must be at least 1ms, got %s", cfg.TTL)
+		}
+	}
+
+	parameters := tools.Parameters{
+		tools.NewStringParameter("key", "The key to set."),
+		tools.NewStringParameter("value", "The value to set the key to."),
+	}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Do:           do,
+		KeyPrefix:    cfg.KeyPrefix,
+		TTL:          ttl,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Do          rediscommon.Doer
+	KeyPrefix   string
+	TTL         time.Duration
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke sets the key to the value, replacing its value and time to live.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	key, ok := paramsMap["key"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", paramsMap["key"])
+	}
+	value, ok := paramsMap["value"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", paramsMap["value"])
+	}
+	args := []string{"SET", t.KeyPrefix + key, value}
+	if t.TTL > 0 {
+		args = append(args, "PX", strconv.FormatInt(t.TTL.Milliseconds(), 10))
+	}
+	res, err := t.Do(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set key: %w", err)
+	}
+	return res, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// TruncateResponse drops the rows or bytes over the limit and appends a
+	// marker explaining what was removed.
+	TruncateResponse TruncationMode = "truncate"
+	// ErrorResponse fails the invocation if the result is over the limit.
+	ErrorResponse TruncationMode = "error"
+	// PaginateResponse returns the rows over the limit in later pages,
+	// fetched with a continuation token.
+	PaginateResponse TruncationMode = "paginate"
+)
+
+// TruncationMode is how a result that exceeds its ResponseLimits is handled.
+type TruncationMode string
+
+// String is used by both fmt.Print and by Cobra in help text
+func (m *TruncationMode) String() string {
+	if string(*m) != "" {
+		return strings.ToLower(string(*m))
+	}
+	return string(TruncateResponse)
+}
+
+// validate truncation mode flag
+func (m *TruncationMode) Set(v string) error {
+	switch TruncationMode(strings.ToLower(v)) {
+	case TruncateResponse, ErrorResponse, PaginateResponse:
+		*m = TruncationMode(strings.ToLower(v))
+		return nil
+	default:
+		return fmt.Errorf(`truncation must be one of %q, %q, or %q`, TruncateResponse, ErrorResponse, PaginateResponse)
+	}
+}
+
+// Type is used in Cobra help text
+func (m *TruncationMode) Type() string {
+	return "truncationMode"
+}
+
+func (m *TruncationMode) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var v string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return m.Set(v)
+}
+
+// ResponseLimits bounds the size of a tool's result before it is returned to
+// the client. A zero limit is unbounded.
+type ResponseLimits struct {
+	MaxResponseBytes int            `yaml:"maxResponseBytes" validate:"gte=0"`
+	MaxRows          int            `yaml:"maxRows" validate:"gte=0"`
+	Truncation       TruncationMode `yaml:"truncation"`
+}
+
+// Enabled returns true if any limit is set.
+func (l ResponseLimits) Enabled() bool {
+	return l.MaxResponseBytes > 0 || l.MaxRows > 0
+}
+
+// Override returns the limits with every unset field taken from defaults.
+func (l ResponseLimits) Override(defaults ResponseLimits) ResponseLimits {
+	if l.MaxResponseBytes == 0 {
+		l.MaxResponseBytes = defaults.MaxResponseBytes
+	}
+	if l.MaxRows == 0 {
+		l.MaxRows = defaults.MaxRows
+	}
+	if l.Truncation == "" {
+		l.Truncation = defaults.Truncation
+	}
+	return l
+}
+
+// Apply enforces the limits on a tool result. Results that are a list are
+// limited by whole rows, any other result is limited by its JSON encoding.
+// Paginated results are split into pages by the server, so Apply truncates
+// them.
+func (l ResponseLimits) Apply(result any) (any, error) {
+	if rows, ok := result.([]any); ok {
+		return l.applyRows(rows)
+	}
+	if l.MaxResponseBytes == 0 {
+		return result, nil
+	}
+	b, err := json.Marshal(result)
+	if err != nil || len(b) <= l.MaxResponseBytes {
+		// results that can't be encoded are left for the caller to report
+		return result, nil
+	}
+	if l.Truncation == ErrorResponse {
+		return nil, fmt.Errorf("tool result of %d bytes exceeds the maximum response size of %d bytes", len(b), l.MaxResponseBytes)
+	}
+	return fmt.Sprintf("%s... [truncated %d of %d bytes]", truncateUTF8(b, l.MaxResponseBytes), len(b)-l.MaxResponseBytes, len(b)), nil
+}
+
+func (l ResponseLimits) applyRows(rows []any) (any, error) {
+	total := len(rows)
+	if l.MaxRows > 0 && total > l.MaxRows {
+		if l.Truncation == ErrorResponse {
+			return nil, fmt.Errorf("tool result of %d rows exceeds the maximum of %d rows", total, l.MaxRows)
+		}
+		rows = rows[:l.MaxRows]
+	}
+	if n := l.FitRows(rows); n < len(rows) {
+		if l.Truncation == ErrorResponse {
+			return nil, fmt.Errorf("tool result exceeds the maximum response size of %d bytes", l.MaxResponseBytes)
+		}
+		rows = rows[:n]
+	}
+	if len(rows) == total {
+		return rows, nil
+	}
+	marker := fmt.Sprintf("[truncated: returned %d of %d rows]", len(rows), total)
+	return append(rows[:len(rows):len(rows)], marker), nil
+}
+
+// FitRows returns how many leading rows fit within MaxResponseBytes.
+func (l ResponseLimits) FitRows(rows []any) int {
+	if l.MaxResponseBytes == 0 {
+		return len(rows)
+	}
+	size := 2 // enclosing brackets
+	for i, row := range rows {
+		b, err := json.Marshal(row)
+		if err != nil {
+			// rows that can't be encoded are left for the caller to report
+			return len(rows)
+		}
+		size += len(b) + 1 // row and separator
+		if size > l.MaxResponseBytes {
+			return i
+		}
+	}
+	return len(rows)
+}
+
+// truncateUTF8 returns at most n bytes of b without splitting a UTF-8 character.
+func truncateUTF8(b []byte, n int) string {
+	s := string(b[:n])
+	return strings.ToValidUTF8(s, "")
+}
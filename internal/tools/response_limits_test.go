@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestResponseLimitsApply(t *testing.T) {
+	rows := []any{
+		map[string]any{"id": 1},
+		map[string]any{"id": 2},
+		map[string]any{"id": 3},
+	}
+	tcs := []struct {
+		desc    string
+		limits  tools.ResponseLimits
+		in      any
+		want    any
+		wantErr string
+	}{
+		{
+			desc:   "within limits",
+			limits: tools.ResponseLimits{MaxRows: 3, MaxResponseBytes: 100},
+			in:     rows,
+			want:   rows,
+		},
+		{
+			desc:   "truncate rows",
+			limits: tools.ResponseLimits{MaxRows: 2},
+			in:     rows,
+			want:   []any{rows[0], rows[1], "[truncated: returned 2 of 3 rows]"},
+		},
+		{
+			desc:   "truncate rows by bytes",
+			limits: tools.ResponseLimits{MaxResponseBytes: 20},
+			in:     rows,
+			want:   []any{rows[0], rows[1], "[truncated: returned 2 of 3 rows]"},
+		},
+		{
+			desc:    "error on rows",
+			limits:  tools.ResponseLimits{MaxRows: 2, Truncation: tools.ErrorResponse},
+			in:      rows,
+			wantErr: "exceeds the maximum of 2 rows",
+		},
+		{
+			desc:   "truncate bytes",
+			limits: tools.ResponseLimits{MaxResponseBytes: 5},
+			in:     "hello world",
+			want:   `"hell... [truncated 8 of 13 bytes]`,
+		},
+		{
+			desc:    "error on bytes",
+			limits:  tools.ResponseLimits{MaxResponseBytes: 5, Truncation: tools.ErrorResponse},
+			in:      map[string]any{"message": "hello world"},
+			wantErr: "exceeds the maximum response size of 5 bytes",
+		},
+		{
+			desc:   "paginate bytes",
+			limits: tools.ResponseLimits{MaxResponseBytes: 5, Truncation: tools.PaginateResponse},
+			in:     "hello world",
+			want:   `"hell... [truncated 8 of 13 bytes]`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.limits.Apply(tc.in)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestResponseLimitsOverride(t *testing.T) {
+	defaults := tools.ResponseLimits{MaxResponseBytes: 1000, MaxRows: 10, Truncation: tools.ErrorResponse}
+	got := tools.ResponseLimits{MaxRows: 5}.Override(defaults)
+	want := tools.ResponseLimits{MaxResponseBytes: 1000, MaxRows: 5, Truncation: tools.ErrorResponse}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect override: diff %v", diff)
+	}
+}
+
+func TestResponseLimitsFitRows(t *testing.T) {
+	rows := []any{"aaaa", "bbbb", "cccc"}
+	if got := (tools.ResponseLimits{}).FitRows(rows); got != 3 {
+		t.Fatalf("expected every row to fit, got %d", got)
+	}
+	// ["aaaa","bbbb"] is 15 bytes
+	if got := (tools.ResponseLimits{MaxResponseBytes: 16}).FitRows(rows); got != 2 {
+		t.Fatalf("expected 2 rows to fit, got %d", got)
+	}
+}
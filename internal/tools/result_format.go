@@ -0,0 +1,214 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// JSONFormat returns results as they are, encoded as JSON.
+	JSONFormat ResultFormat = "json"
+	// CSVFormat returns rows as CSV, with a header line of column names.
+	CSVFormat ResultFormat = "csv"
+	// MarkdownFormat returns rows as a Markdown table.
+	MarkdownFormat ResultFormat = "markdown"
+)
+
+// TextResult is a tool result that is already serialized, and is returned to
+// clients as text rather than encoded as JSON.
+type TextResult string
+
+// ResultFormat is how the rows of a tool result are serialized.
+type ResultFormat string
+
+// String is used by both fmt.Print and by Cobra in help text
+func (f *ResultFormat) String() string {
+	if string(*f) != "" {
+		return strings.ToLower(string(*f))
+	}
+	return string(JSONFormat)
+}
+
+// validate result format flag
+func (f *ResultFormat) Set(v string) error {
+	switch ResultFormat(strings.ToLower(v)) {
+	case JSONFormat, CSVFormat, MarkdownFormat:
+		*f = ResultFormat(strings.ToLower(v))
+		return nil
+	default:
+		return fmt.Errorf(`result format must be one of %q, %q, or %q`, JSONFormat, CSVFormat, MarkdownFormat)
+	}
+}
+
+// Type is used in Cobra help text
+func (f *ResultFormat) Type() string {
+	return "resultFormat"
+}
+
+func (f *ResultFormat) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var v string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	return f.Set(v)
+}
+
+// Apply serializes the rows of a tool result in the format, as a TextResult.
+// Only results that are a list of rows with columns are serialized; any other
+// result, and every result in the JSON format, is returned as it is. Columns are ordered
+// by name, as in JSON results, and rows that aren't a set of columns, such as
+// truncation markers, follow the table on lines of their own.
+func (f ResultFormat) Apply(result any) (any, error) {
+	if f == "" || f == JSONFormat {
+		return result, nil
+	}
+	rows, ok := result.([]any)
+	if !ok {
+		return result, nil
+	}
+	var table []map[string]any
+	var notes []string
+	for _, row := range rows {
+		switch row := row.(type) {
+		case map[string]any:
+			table = append(table, row)
+		case string:
+			notes = append(notes, row)
+		default:
+			// rows of other shapes have no columns to serialize
+			return result, nil
+		}
+	}
+	if len(table) == 0 {
+		return result, nil
+	}
+	columns := tableColumns(table)
+
+	var out string
+	var err error
+	switch f {
+	case CSVFormat:
+		out, err = formatCSV(columns, table)
+	case MarkdownFormat:
+		out, err = formatMarkdown(columns, table)
+	default:
+		return nil, fmt.Errorf("unknown result format %q", f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		out += note + "\n"
+	}
+	return TextResult(out), nil
+}
+
+// tableColumns returns the names of the columns of every row, sorted.
+func tableColumns(table []map[string]any) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range table {
+		for k := range row {
+			if !seen[k] {
+				seen[k] = true
+				columns = append(columns, k)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func formatCSV(columns []string, table []map[string]any) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(columns); err != nil {
+		return "", err
+	}
+	record := make([]string, len(columns))
+	for _, row := range table {
+		for i, c := range columns {
+			s, err := formatCell(row[c])
+			if err != nil {
+				return "", err
+			}
+			record[i] = s
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func formatMarkdown(columns []string, table []map[string]any) (string, error) {
+	var b strings.Builder
+	writeLine := func(cells []string) {
+		b.WriteString("|")
+		for _, c := range cells {
+			b.WriteString(" ")
+			b.WriteString(markdownEscaper.Replace(c))
+			b.WriteString(" |")
+		}
+		b.WriteString("\n")
+	}
+	writeLine(columns)
+	b.WriteString(strings.Repeat("| --- ", len(columns)) + "|\n")
+	cells := make([]string, len(columns))
+	for _, row := range table {
+		for i, c := range columns {
+			s, err := formatCell(row[c])
+			if err != nil {
+				return "", err
+			}
+			cells[i] = s
+		}
+		writeLine(cells)
+	}
+	return b.String(), nil
+}
+
+// formatCell returns the text of a value in a table. Strings are written as
+// they are, NULLs as an empty cell, and other values as their JSON encoding.
+func formatCell(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case Decimal:
+		return string(v), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("unable to serialize value of type %T: %w", v, err)
+	}
+	// values encoded as JSON strings, such as timestamps, are unquoted
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		return s, nil
+	}
+	return string(b), nil
+}
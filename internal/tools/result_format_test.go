@@ -0,0 +1,96 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestResultFormatApply(t *testing.T) {
+	rows := []any{
+		map[string]any{"id": 1, "name": "Alice, Jr.", "joined": time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		map[string]any{"id": 2, "name": "a|b\nc", "tags": []any{"x"}},
+		"[truncated: returned 2 of 3 rows]",
+	}
+	tcs := []struct {
+		desc   string
+		format tools.ResultFormat
+		in     any
+		want   any
+	}{
+		{
+			desc:   "json",
+			format: tools.JSONFormat,
+			in:     rows,
+			want:   rows,
+		},
+		{
+			desc:   "csv",
+			format: tools.CSVFormat,
+			in:     rows,
+			want: tools.TextResult("id,joined,name,tags\n" +
+				"1,2025-01-02T03:04:05Z,\"Alice, Jr.\",\n" +
+				"2,,\"a|b\nc\",\"[\"\"x\"\"]\"\n" +
+				"[truncated: returned 2 of 3 rows]\n"),
+		},
+		{
+			desc:   "markdown",
+			format: tools.MarkdownFormat,
+			in:     rows,
+			want: tools.TextResult("| id | joined | name | tags |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| 1 | 2025-01-02T03:04:05Z | Alice, Jr. |  |\n" +
+				"| 2 |  | a\\|b<br>c | [\"x\"] |\n" +
+				"[truncated: returned 2 of 3 rows]\n"),
+		},
+		{
+			desc:   "not rows",
+			format: tools.CSVFormat,
+			in:     map[string]any{"id": 1},
+			want:   map[string]any{"id": 1},
+		},
+		{
+			desc:   "rows without columns",
+			format: tools.MarkdownFormat,
+			in:     []any{"a", "b"},
+			want:   []any{"a", "b"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.format.Apply(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestResultFormatSet(t *testing.T) {
+	var f tools.ResultFormat
+	if err := f.Set("Markdown"); err != nil || f != tools.MarkdownFormat {
+		t.Fatalf("expected markdown format, got %q, %v", f, err)
+	}
+	if err := f.Set("xml"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3getobject_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	s3api "github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/s3/s3getobject"
+)
+
+func TestParseFromYamlS3GetObject(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: s3-get-object
+					source: my-s3-source
+					description: some description
+					prefix: reference/
+			`,
+			want: server.ToolConfigs{
+				"example_tool": s3getobject.Config{
+					Name:         "example_tool",
+					Kind:         "s3-get-object",
+					Source:       "my-s3-source",
+					Description:  "some description",
+					Prefix:       "reference/",
+					MaxBytes:     1048576,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeS3GetObject(t *testing.T) {
+	objects := map[string]struct {
+		contentType string
+		data        string
+	}{
+		"reference/a.md":   {contentType: "text/markdown", data: "# Runbook"},
+		"reference/b.json": {contentType: "application/json", data: `{"owner":"sre"}`},
+		"reference/c.txt":  {contentType: "text/plain", data: strings.Repeat("x", 100)},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		o, ok := objects[strings.TrimPrefix(r.URL.Path, "/my-bucket/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+		w.Header().Set("Content-Type", o.contentType)
+		_, _ = w.Write([]byte(o.data))
+	}))
+	defer ts.Close()
+
+	client := s3api.New(s3api.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(ts.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("my-key", "my-secret", ""),
+	})
+	src := &s3.Source{Name: "my-s3-source", Kind: s3.SourceKind, Bucket: "my-bucket", Client: client}
+	cfg := s3getobject.Config{
+		Name:        "example_tool",
+		Kind:        "s3-get-object",
+		Source:      "my-s3-source",
+		Description: "some description",
+		Prefix:      "reference/",
+		MaxBytes:    50,
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-s3-source": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	tcs := []struct {
+		desc    string
+		object  string
+		want    any
+		wantErr string
+	}{
+		{
+			desc:   "text object",
+			object: "reference/a.md",
+			want:   map[string]any{"name": "reference/a.md", "contentType": "text/markdown", "size": int64(9), "content": "# Runbook"},
+		},
+		{
+			desc:   "json object",
+			object: "reference/b.json",
+			want:   map[string]any{"name": "reference/b.json", "contentType": "application/json", "size": int64(15), "content": map[string]any{"owner": "sre"}},
+		},
+		{
+			desc:    "object too large",
+			object:  "reference/c.txt",
+			wantErr: `object "reference/c.txt" has 100 bytes, more than the limit of 50 bytes`,
+		},
+		{
+			desc:    "missing object",
+			object:  "reference/d.txt",
+			wantErr: "NoSuchKey",
+		},
+		{
+			desc:    "object outside of the prefix",
+			object:  "secrets/key.json",
+			wantErr: `object "secrets/key.json" is not under prefix "reference/"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(map[string]any{"object": tc.object}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+}
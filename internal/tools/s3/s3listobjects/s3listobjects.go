@@ -0,0 +1,186 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package s3listobjects
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3api "github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcscommon"
+)
+
+const kind string = "s3-list-objects"
+const prefixKey string = "prefix"
+
+// maxKeysLimit is the largest number of keys that a list request returns.
+const maxKeysLimit = 1000
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, MaxResults: 100}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	Prefix       string   `yaml:"prefix"`
+	Delimiter    string   `yaml:"delimiter"`
+	MaxResults   int32    `yaml:"maxResults"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*s3.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, s3.SourceKind)
+	}
+
+	if cfg.MaxResults <= 0 || cfg.MaxResults > maxKeysLimit {
+		return nil, fmt.Errorf("maxResults must be between 1 and %d, got %d", maxKeysLimit, cfg.MaxResults)
+	}
+
+	prefixParameter := tools.NewStringParameterWithDefault(prefixKey, cfg.Prefix, fmt.Sprintf("Prefix of the keys of the listed objects, which must start with %q.", cfg.Prefix))
+	parameters := tools.Parameters{prefixParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.S3Client(),
+		Bucket:       s.Bucket,
+		Prefix:       cfg.Prefix,
+		Delimiter:    cfg.Delimiter,
+		MaxResults:   cfg.MaxResults,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *s3api.Client
+	Bucket      string
+	Prefix      string
+	Delimiter   string
+	MaxResults  int32
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	prefix, ok := params.AsMap()[prefixKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", prefixKey)
+	}
+	if err := gcscommon.CheckPrefix(t.Prefix, prefix); err != nil {
+		return nil, err
+	}
+
+	input := &s3api.ListObjectsV2Input{
+		Bucket:  aws.String(t.Bucket),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int32(t.MaxResults),
+	}
+	if t.Delimiter != "" {
+		input.Delimiter = aws.String(t.Delimiter)
+	}
+
+	// MaxKeys bounds the number of both objects and prefixes of a page
+	out, err := t.Client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list objects: %w", err)
+	}
+	objects := make([]map[string]any, 0, len(out.Contents))
+	for _, o := range out.Contents {
+		objects = append(objects, map[string]any{
+			"name":    aws.ToString(o.Key),
+			"size":    aws.ToInt64(o.Size),
+			"updated": aws.ToTime(o.LastModified).Format(time.RFC3339),
+		})
+	}
+	prefixes := make([]string, 0, len(out.CommonPrefixes))
+	for _, p := range out.CommonPrefixes {
+		prefixes = append(prefixes, aws.ToString(p.Prefix))
+	}
+
+	result := map[string]any{"objects": objects, "truncated": aws.ToBool(out.IsTruncated)}
+	if t.Delimiter != "" {
+		result["prefixes"] = prefixes
+	}
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
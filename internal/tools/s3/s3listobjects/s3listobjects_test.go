@@ -0,0 +1,218 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3listobjects_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	s3api "github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/s3/s3listobjects"
+)
+
+func TestParseFromYamlS3ListObjects(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: s3-list-objects
+					source: my-s3-source
+					description: some description
+			`,
+			want: server.ToolConfigs{
+				"example_tool": s3listobjects.Config{
+					Name:         "example_tool",
+					Kind:         "s3-list-objects",
+					Source:       "my-s3-source",
+					Description:  "some description",
+					MaxResults:   100,
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+			tools:
+				example_tool:
+					kind: s3-list-objects
+					source: my-s3-source
+					description: some description
+					prefix: reference/
+					delimiter: /
+					maxResults: 20
+			`,
+			want: server.ToolConfigs{
+				"example_tool": s3listobjects.Config{
+					Name:         "example_tool",
+					Kind:         "s3-list-objects",
+					Source:       "my-s3-source",
+					Description:  "some description",
+					Prefix:       "reference/",
+					Delimiter:    "/",
+					MaxResults:   20,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeInvalidMaxResults(t *testing.T) {
+	cfg := s3listobjects.Config{
+		Name:        "example_tool",
+		Kind:        "s3-list-objects",
+		Source:      "my-s3-source",
+		Description: "some description",
+		MaxResults:  1001,
+	}
+	srcs := map[string]sources.Source{"my-s3-source": &s3.Source{Bucket: "my-bucket", Client: s3api.New(s3api.Options{})}}
+	_, err := cfg.Initialize(srcs)
+	if err == nil {
+		t.Fatalf("expect initialization to fail")
+	}
+	if want := "maxResults must be between 1 and 1000, got 1001"; err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err, want)
+	}
+}
+
+func TestInvokeS3ListObjects(t *testing.T) {
+	var query map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/my-bucket" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		q := r.URL.Query()
+		query = map[string]string{"prefix": q.Get("prefix"), "delimiter": q.Get("delimiter"), "max-keys": q.Get("max-keys")}
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+			<Name>my-bucket</Name>
+			<IsTruncated>true</IsTruncated>
+			<Contents><Key>reference/a.md</Key><LastModified>2025-01-01T00:00:00.000Z</LastModified><Size>12</Size></Contents>
+			<CommonPrefixes><Prefix>reference/2025/</Prefix></CommonPrefixes>
+		</ListBucketResult>`))
+	}))
+	defer ts.Close()
+
+	client := s3api.New(s3api.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(ts.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("my-key", "my-secret", ""),
+	})
+	src := &s3.Source{Name: "my-s3-source", Kind: s3.SourceKind, Bucket: "my-bucket", Client: client}
+	cfg := s3listobjects.Config{
+		Name:        "example_tool",
+		Kind:        "s3-list-objects",
+		Source:      "my-s3-source",
+		Description: "some description",
+		Prefix:      "reference/",
+		Delimiter:   "/",
+		MaxResults:  2,
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-s3-source": src})
+	if err != nil {
+		t.Fatalf("unable to initialize: %s", err)
+	}
+
+	tcs := []struct {
+		desc       string
+		params     map[string]any
+		wantPrefix string
+		wantErr    string
+	}{
+		{
+			desc:       "default prefix",
+			params:     map[string]any{},
+			wantPrefix: "reference/",
+		},
+		{
+			desc:       "sub prefix",
+			params:     map[string]any{"prefix": "reference/a"},
+			wantPrefix: "reference/a",
+		},
+		{
+			desc:    "prefix outside of the tool prefix",
+			params:  map[string]any{"prefix": "secrets/"},
+			wantErr: `prefix "secrets/" is not under prefix "reference/"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(tc.params, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+
+			if diff := cmp.Diff(map[string]string{"prefix": tc.wantPrefix, "delimiter": "/", "max-keys": "2"}, query); diff != "" {
+				t.Fatalf("incorrect query: diff %v", diff)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("unable to marshal result: %s", err)
+			}
+			want := `{"objects":[{"name":"reference/a.md","size":12,"updated":"2025-01-01T00:00:00Z"}],"prefixes":["reference/2025/"],"truncated":true}`
+			if string(b) != want {
+				t.Fatalf("incorrect result: got %s, want %s", b, want)
+			}
+		})
+	}
+}
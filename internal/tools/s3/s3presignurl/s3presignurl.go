@@ -0,0 +1,182 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package s3presignurl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	s3api "github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcscommon"
+)
+
+const kind string = "s3-presign-url"
+const objectKey string = "object"
+
+// maxExpiration is the longest expiration of a URL presigned with SigV4.
+const maxExpiration = 7 * 24 * time.Hour
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, Method: http.MethodGet, Expiration: "15m"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	Prefix       string   `yaml:"prefix"`
+	Method       string   `yaml:"method"`
+	Expiration   string   `yaml:"expiration"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*s3.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, s3.SourceKind)
+	}
+
+	if cfg.Method != http.MethodGet && cfg.Method != http.MethodPut {
+		return nil, fmt.Errorf("invalid method %q: must be %q or %q", cfg.Method, http.MethodGet, http.MethodPut)
+	}
+	expiration, err := time.ParseDuration(cfg.Expiration)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse expiration %q: %w", cfg.Expiration, err)
+	}
+	if expiration <= 0 || expiration > maxExpiration {
+		return nil, fmt.Errorf("expiration must be positive and at most %s, got %s", maxExpiration, expiration)
+	}
+
+	objectParameter := tools.NewStringParameter(objectKey, fmt.Sprintf("Key of the object of the URL, which must start with %q.", cfg.Prefix))
+	parameters := tools.Parameters{objectParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s3api.NewPresignClient(s.S3Client()),
+		Bucket:       s.Bucket,
+		Prefix:       cfg.Prefix,
+		Method:       cfg.Method,
+		Expiration:   expiration,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *s3api.PresignClient
+	Bucket      string
+	Prefix      string
+	Method      string
+	Expiration  time.Duration
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke presigns a URL of an object. The URL is signed locally, so the object
+// isn't required to exist.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	key, ok := params.AsMap()[objectKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", objectKey)
+	}
+	if err := gcscommon.CheckObjectName(t.Prefix, key); err != nil {
+		return nil, err
+	}
+
+	expires := s3api.WithPresignExpires(t.Expiration)
+	var req *v4.PresignedHTTPRequest
+	var err error
+	if t.Method == http.MethodPut {
+		req, err = t.Client.PresignPutObject(ctx, &s3api.PutObjectInput{Bucket: aws.String(t.Bucket), Key: aws.String(key)}, expires)
+	} else {
+		req, err = t.Client.PresignGetObject(ctx, &s3api.GetObjectInput{Bucket: aws.String(t.Bucket), Key: aws.String(key)}, expires)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to presign URL of object %q: %w", key, err)
+	}
+
+	return map[string]any{
+		"url":       req.URL,
+		"method":    req.Method,
+		"expiresAt": time.Now().Add(t.Expiration).UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3presignurl_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	s3api "github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/s3/s3presignurl"
+)
+
+func TestParseFromYamlS3PresignUrl(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: s3-presign-url
+					source: my-s3-source
+					description: some description
+					prefix: reports/
+			`,
+			want: server.ToolConfigs{
+				"example_tool": s3presignurl.Config{
+					Name:         "example_tool",
+					Kind:         "s3-presign-url",
+					Source:       "my-s3-source",
+					Description:  "some description",
+					Prefix:       "reports/",
+					Method:       "GET",
+					Expiration:   "15m",
+					AuthRequired: []string{},
+				},
+			},
+		},
+		{
+			desc: "upload example",
+			in: `
+			tools:
+				example_tool:
+					kind: s3-presign-url
+					source: my-s3-source
+					description: some description
+					prefix: uploads/
+					method: PUT
+					expiration: 1h
+			`,
+			want: server.ToolConfigs{
+				"example_tool": s3presignurl.Config{
+					Name:         "example_tool",
+					Kind:         "s3-presign-url",
+					Source:       "my-s3-source",
+					Description:  "some description",
+					Prefix:       "uploads/",
+					Method:       "PUT",
+					Expiration:   "1h",
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func newSource() *s3.Source {
+	client := s3api.New(s3api.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String("http://localhost:9000"),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("my-key", "my-secret", ""),
+	})
+	return &s3.Source{Name: "my-s3-source", Kind: s3.SourceKind, Bucket: "my-bucket", Client: client}
+}
+
+func TestInitializeS3PresignUrlFailure(t *testing.T) {
+	tcs := []struct {
+		desc       string
+		method     string
+		expiration string
+		err        string
+	}{
+		{
+			desc:       "invalid method",
+			method:     "DELETE",
+			expiration: "15m",
+			err:        `invalid method "DELETE": must be "GET" or "PUT"`,
+		},
+		{
+			desc:       "invalid expiration",
+			method:     "GET",
+			expiration: "tomorrow",
+			err:        `unable to parse expiration "tomorrow": time: invalid duration "tomorrow"`,
+		},
+		{
+			desc:       "expiration too long",
+			method:     "GET",
+			expiration: "200h",
+			err:        "expiration must be positive and at most 168h0m0s, got 200h0m0s",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := s3presignurl.Config{
+				Name:        "example_tool",
+				Kind:        "s3-presign-url",
+				Source:      "my-s3-source",
+				Description: "some description",
+				Method:      tc.method,
+				Expiration:  tc.expiration,
+			}
+			_, err := cfg.Initialize(map[string]sources.Source{"my-s3-source": newSource()})
+			if err == nil {
+				t.Fatalf("expect initialization to fail")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestInvokeS3PresignUrl(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		method   string
+		object   string
+		wantPath string
+		wantErr  string
+	}{
+		{
+			desc:     "download url",
+			method:   "GET",
+			object:   "reports/a.md",
+			wantPath: "http://localhost:9000/my-bucket/reports/a.md?",
+		},
+		{
+			desc:     "upload url",
+			method:   "PUT",
+			object:   "reports/b.md",
+			wantPath: "http://localhost:9000/my-bucket/reports/b.md?",
+		},
+		{
+			desc:    "object outside of the prefix",
+			method:  "GET",
+			object:  "secrets/key.json",
+			wantErr: `object "secrets/key.json" is not under prefix "reports/"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := s3presignurl.Config{
+				Name:        "example_tool",
+				Kind:        "s3-presign-url",
+				Source:      "my-s3-source",
+				Description: "some description",
+				Prefix:      "reports/",
+				Method:      tc.method,
+				Expiration:  "15m",
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-s3-source": newSource()})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			params, err := tool.ParseParams(map[string]any{"object": tc.object}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			res := got.(map[string]any)
+			url := res["url"].(string)
+			if !strings.HasPrefix(url, tc.wantPath) {
+				t.Fatalf("unexpected url: got %q, want prefix %q", url, tc.wantPath)
+			}
+			if !strings.Contains(url, "X-Amz-Expires=900") || !strings.Contains(url, "X-Amz-Signature=") {
+				t.Fatalf("url is not presigned for 15 minutes: %q", url)
+			}
+			if res["method"] != tc.method {
+				t.Fatalf("unexpected method: got %v, want %q", res["method"], tc.method)
+			}
+		})
+	}
+}
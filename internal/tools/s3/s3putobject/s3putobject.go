@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package s3putobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	s3api "github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/gcs/gcscommon"
+)
+
+const kind string = "s3-put-object"
+const objectKey string = "object"
+const contentKey string = "content"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name, ContentType: "text/plain; charset=utf-8", MaxBytes: gcscommon.DefaultMaxBytes}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	Prefix       string   `yaml:"prefix" validate:"required"`
+	ContentType  string   `yaml:"contentType"`
+	MaxBytes     int64    `yaml:"maxBytes"`
+	Overwrite    bool     `yaml:"overwrite"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*s3.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, s3.SourceKind)
+	}
+
+	if cfg.MaxBytes <= 0 {
+		return nil, fmt.Errorf("maxBytes must be positive, got %d", cfg.MaxBytes)
+	}
+
+	objectParameter := tools.NewStringParameter(objectKey, fmt.Sprintf("Key of the object to put, which must start with %q.", cfg.Prefix))
+	contentParameter := tools.NewStringParameter(contentKey, "Content of the object.")
+	parameters := tools.Parameters{objectParameter, contentParameter}
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Client:       s.S3Client(),
+		Bucket:       s.Bucket,
+		Prefix:       cfg.Prefix,
+		ContentType:  cfg.ContentType,
+		MaxBytes:     cfg.MaxBytes,
+		Overwrite:    cfg.Overwrite,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Client      *s3api.Client
+	Bucket      string
+	Prefix      string
+	ContentType string
+	MaxBytes    int64
+	Overwrite   bool
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	key, ok := paramsMap[objectKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", objectKey)
+	}
+	content, ok := paramsMap[contentKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", contentKey)
+	}
+	if err := gcscommon.CheckObjectName(t.Prefix, key); err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > t.MaxBytes {
+		return nil, fmt.Errorf("content has %d bytes, more than the limit of %d bytes", len(content), t.MaxBytes)
+	}
+
+	input := &s3api.PutObjectInput{
+		Bucket:      aws.String(t.Bucket),
+		Key:         aws.String(key),
+		Body:        strings.NewReader(content),
+		ContentType: aws.String(t.ContentType),
+	}
+	if !t.Overwrite {
+		input.IfNoneMatch = aws.String("*")
+	}
+	out, err := t.Client.PutObject(ctx, input)
+	if err != nil {
+		var respErr *awshttp.ResponseError
+		if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusPreconditionFailed {
+			return nil, fmt.Errorf("object %q already exists", key)
+		}
+		return nil, fmt.Errorf("unable to put object %q: %w", key, err)
+	}
+
+	return map[string]any{
+		"name": key,
+		"size": len(content),
+		"etag": aws.ToString(out.ETag),
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
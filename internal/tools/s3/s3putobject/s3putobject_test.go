@@ -0,0 +1,202 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3putobject_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	s3api "github.com/aws/aws-sdk-go-v2/service/s3"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/s3"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/s3/s3putobject"
+)
+
+func TestParseFromYamlS3PutObject(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: s3-put-object
+					source: my-s3-source
+					description: some description
+					prefix: reports/
+			`,
+			want: server.ToolConfigs{
+				"example_tool": s3putobject.Config{
+					Name:         "example_tool",
+					Kind:         "s3-put-object",
+					Source:       "my-s3-source",
+					Description:  "some description",
+					Prefix:       "reports/",
+					ContentType:  "text/plain; charset=utf-8",
+					MaxBytes:     1048576,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInvokeS3PutObject(t *testing.T) {
+	var ifNoneMatch, contentType, content string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected method %q", r.Method)
+		}
+		ifNoneMatch = r.Header.Get("If-None-Match")
+		contentType = r.Header.Get("Content-Type")
+		if ifNoneMatch == "*" && r.URL.Path == "/my-bucket/reports/existing.md" {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`<Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`))
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		content = string(b)
+		w.Header().Set("ETag", `"abc"`)
+	}))
+	defer ts.Close()
+
+	client := s3api.New(s3api.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(ts.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("my-key", "my-secret", ""),
+	})
+	src := &s3.Source{Name: "my-s3-source", Kind: s3.SourceKind, Bucket: "my-bucket", Client: client}
+
+	tcs := []struct {
+		desc            string
+		overwrite       bool
+		object          string
+		content         string
+		want            any
+		wantIfNoneMatch string
+		wantErr         string
+	}{
+		{
+			desc:            "new object",
+			object:          "reports/incident.md",
+			content:         "# Incident",
+			want:            map[string]any{"name": "reports/incident.md", "size": 10, "etag": `"abc"`},
+			wantIfNoneMatch: "*",
+		},
+		{
+			desc:            "overwrite",
+			overwrite:       true,
+			object:          "reports/incident.md",
+			content:         "# Incident",
+			want:            map[string]any{"name": "reports/incident.md", "size": 10, "etag": `"abc"`},
+			wantIfNoneMatch: "",
+		},
+		{
+			desc:    "existing object",
+			object:  "reports/existing.md",
+			content: "# Incident",
+			wantErr: `object "reports/existing.md" already exists`,
+		},
+		{
+			desc:    "content too large",
+			object:  "reports/incident.md",
+			content: "# Incident with a long description",
+			wantErr: "content has 34 bytes, more than the limit of 20 bytes",
+		},
+		{
+			desc:    "object outside of the prefix",
+			object:  "reference/runbook.md",
+			content: "# Runbook",
+			wantErr: `object "reference/runbook.md" is not under prefix "reports/"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ifNoneMatch, contentType, content = "", "", ""
+			cfg := s3putobject.Config{
+				Name:        "example_tool",
+				Kind:        "s3-put-object",
+				Source:      "my-s3-source",
+				Description: "some description",
+				Prefix:      "reports/",
+				ContentType: "text/markdown",
+				MaxBytes:    20,
+				Overwrite:   tc.overwrite,
+			}
+			tool, err := cfg.Initialize(map[string]sources.Source{"my-s3-source": src})
+			if err != nil {
+				t.Fatalf("unable to initialize: %s", err)
+			}
+			params, err := tool.ParseParams(map[string]any{"object": tc.object, "content": tc.content}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(context.Background(), params)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+			if ifNoneMatch != tc.wantIfNoneMatch {
+				t.Fatalf("incorrect If-None-Match: got %q, want %q", ifNoneMatch, tc.wantIfNoneMatch)
+			}
+			if contentType != "text/markdown" {
+				t.Fatalf("incorrect content type: got %q, want %q", contentType, "text/markdown")
+			}
+			if content != tc.content {
+				t.Fatalf("incorrect content: got %q, want %q", content, tc.content)
+			}
+		})
+	}
+}
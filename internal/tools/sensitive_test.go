@@ -0,0 +1,87 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestSensitiveParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: username
+  type: string
+  description: name of the user
+- name: password
+  type: string
+  description: password of the user
+  sensitive: true
+- name: pin
+  type: integer
+  description: pin of the user
+  sensitive: true
+  required: false
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+
+	got, err := tools.ParseParams(params, map[string]any{"username": "ada", "password": "hunter2"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	// sensitive values are still passed to the tool
+	want := tools.ParamValues{
+		{Name: "username", Value: "ada"},
+		{Name: "password", Value: "hunter2", Sensitive: true},
+		{Name: "pin", Sensitive: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected params (-want +got):\n%s", diff)
+	}
+	if s := fmt.Sprintf("%s", got); s != "[{username ada} {password [REDACTED]} {pin [REDACTED]}]" {
+		t.Fatalf("unexpected formatted params: %s", s)
+	}
+
+	// errors don't include the values of sensitive parameters
+	cause := errors.New(`authentication failed for user "ada" with password "hunter2"`)
+	err = got.RedactError(cause)
+	if msg := err.Error(); msg != `authentication failed for user "ada" with password "[REDACTED]"` {
+		t.Fatalf("unexpected error message: %s", msg)
+	}
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected the redacted error to wrap its cause")
+	}
+
+	_, err = tools.ParseParams(params, map[string]any{"username": "ada", "password": "hunter2", "pin": "secret-pin"}, nil)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid pin")
+	}
+	if strings.Contains(err.Error(), "secret-pin") {
+		t.Fatalf("error contains the value of a sensitive parameter: %s", err)
+	}
+}
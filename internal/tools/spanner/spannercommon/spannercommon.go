@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package spannercommon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Staleness is the staleness of the reads of read-only tools, which read a
+// recent snapshot of the database instead of its latest data, so that they
+// don't wait for, or slow down, the transactions that write to it. At most one
+// of its fields may be set.
+type Staleness struct {
+	// ExactStaleness reads at a timestamp exactly this old, e.g. 15s.
+	ExactStaleness string `yaml:"exactStaleness"`
+	// MaxStaleness reads at a timestamp at most this old, chosen by Spanner
+	// to avoid waiting for writes, e.g. 10s.
+	MaxStaleness string `yaml:"maxStaleness"`
+	// MinReadTimestamp reads at a timestamp no older than this one, in RFC
+	// 3339 format, e.g. 2025-01-01T00:00:00Z.
+	MinReadTimestamp string `yaml:"minReadTimestamp"`
+}
+
+// TimestampBound returns the timestamp bound of the staleness, or a strong
+// bound, which reads the latest data, if s is nil.
+func (s *Staleness) TimestampBound() (spanner.TimestampBound, error) {
+	if s == nil {
+		return spanner.StrongRead(), nil
+	}
+	n := 0
+	for _, v := range []string{s.ExactStaleness, s.MaxStaleness, s.MinReadTimestamp} {
+		if v != "" {
+			n++
+		}
+	}
+	if n > 1 {
+		return spanner.TimestampBound{}, fmt.Errorf("only one of exactStaleness, maxStaleness or minReadTimestamp can be set")
+	}
+
+	switch {
+	case s.ExactStaleness != "":
+		d, err := parseStaleness("exactStaleness", s.ExactStaleness)
+		if err != nil {
+			return spanner.TimestampBound{}, err
+		}
+		return spanner.ExactStaleness(d), nil
+	case s.MaxStaleness != "":
+		d, err := parseStaleness("maxStaleness", s.MaxStaleness)
+		if err != nil {
+			return spanner.TimestampBound{}, err
+		}
+		return spanner.MaxStaleness(d), nil
+	case s.MinReadTimestamp != "":
+		t, err := time.Parse(time.RFC3339, s.MinReadTimestamp)
+		if err != nil {
+			return spanner.TimestampBound{}, fmt.Errorf("unable to parse minReadTimestamp %q as an RFC 3339 timestamp: %w", s.MinReadTimestamp, err)
+		}
+		return spanner.MinReadTimestamp(t), nil
+	}
+	return spanner.StrongRead(), nil
+}
+
+func parseStaleness(field, v string) (time.Duration, error) {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s %q as a duration: %w", field, v, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %s", field, v)
+	}
+	return d, nil
+}
+
+// CheckStaleness returns an error if the staleness of a tool is set but the
+// tool isn't read-only, since only read-only transactions read at a timestamp.
+func CheckStaleness(s *Staleness, readOnly bool) error {
+	if s != nil && !readOnly {
+		return fmt.Errorf("staleness requires readOnly to be set")
+	}
+	return nil
+}
+
+// Query runs the statement and returns its rows. If readOnly, it runs in a
+// single-use read-only transaction, which takes no locks, and reads at the
+// timestamp bound. Otherwise, it runs in a read-write transaction.
+func Query(ctx context.Context, client *spanner.Client, stmt spanner.Statement, readOnly bool, bound spanner.TimestampBound) ([]any, error) {
+	if readOnly {
+		return processRows(client.Single().WithTimestampBound(bound).Query(ctx, stmt))
+	}
+	var results []any
+	_, err := client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		var err error
+		results, err = processRows(txn.Query(ctx, stmt))
+		return err
+	})
+	return results, err
+}
+
+// processRows iterates over the spanner.RowIterator and converts each row to a map[string]any.
+func processRows(iter *spanner.RowIterator) ([]any, error) {
+	var out []any
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+
+		out = append(out, RowMap(row))
+	}
+	return out, nil
+}
+
+// RowMap converts a row to a map of its columns.
+func RowMap(row *spanner.Row) map[string]any {
+	vMap := make(map[string]any)
+	for i, c := range row.ColumnNames() {
+		vMap[c] = value(row.ColumnType(i), row.ColumnValue(i))
+	}
+	return vMap
+}
+
+// value returns a value of a column as it is returned to clients, see
+// tools.Decimal. Spanner encodes timestamps, dates and bytes as strings in the
+// same way as other databases' results, so only exact numbers are converted.
+func value(t *sppb.Type, v *structpb.Value) any {
+	if _, ok := v.GetKind().(*structpb.Value_StringValue); ok && t.GetCode() == sppb.TypeCode_NUMERIC {
+		return tools.Decimal(v.GetStringValue())
+	}
+	return v
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spannercommon_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannercommon"
+)
+
+func TestTimestampBound(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		in      *spannercommon.Staleness
+		want    spanner.TimestampBound
+		wantErr string
+	}{
+		{
+			desc: "unset",
+			in:   nil,
+			want: spanner.StrongRead(),
+		},
+		{
+			desc: "exact staleness",
+			in:   &spannercommon.Staleness{ExactStaleness: "15s"},
+			want: spanner.ExactStaleness(15 * time.Second),
+		},
+		{
+			desc: "max staleness",
+			in:   &spannercommon.Staleness{MaxStaleness: "1m"},
+			want: spanner.MaxStaleness(time.Minute),
+		},
+		{
+			desc: "min read timestamp",
+			in:   &spannercommon.Staleness{MinReadTimestamp: "2025-01-01T00:00:00Z"},
+			want: spanner.MinReadTimestamp(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			desc:    "invalid duration",
+			in:      &spannercommon.Staleness{ExactStaleness: "15"},
+			wantErr: `unable to parse exactStaleness "15"`,
+		},
+		{
+			desc:    "negative duration",
+			in:      &spannercommon.Staleness{MaxStaleness: "-1s"},
+			wantErr: "maxStaleness must not be negative",
+		},
+		{
+			desc:    "invalid timestamp",
+			in:      &spannercommon.Staleness{MinReadTimestamp: "2025-01-01"},
+			wantErr: `unable to parse minReadTimestamp "2025-01-01"`,
+		},
+		{
+			desc:    "several",
+			in:      &spannercommon.Staleness{ExactStaleness: "15s", MaxStaleness: "1m"},
+			wantErr: "only one of exactStaleness, maxStaleness or minReadTimestamp can be set",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.in.TimestampBound()
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got.String() != tc.want.String() {
+				t.Fatalf("incorrect timestamp bound: got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckStaleness(t *testing.T) {
+	staleness := &spannercommon.Staleness{ExactStaleness: "15s"}
+	if err := spannercommon.CheckStaleness(staleness, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := spannercommon.CheckStaleness(nil, false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := spannercommon.CheckStaleness(staleness, false); err == nil {
+		t.Fatalf("expected error for staleness without readOnly")
+	}
+}
+
+func TestRowMap(t *testing.T) {
+	price, _ := new(big.Rat).SetString("12345.678901234")
+	row, err := spanner.NewRow(
+		[]string{"price", "missing", "name", "created", "data"},
+		[]any{price, spanner.NullNumeric{}, "widget", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), []byte("hi")},
+	)
+	if err != nil {
+		t.Fatalf("unable to create row: %s", err)
+	}
+	b, err := json.Marshal(spannercommon.RowMap(row))
+	if err != nil {
+		t.Fatalf("unable to marshal row: %s", err)
+	}
+	want := `{"created":"2025-01-02T03:04:05Z","data":"aGk=","missing":null,"name":"widget","price":{"type":"decimal","value":"12345.678901234"}}`
+	if string(b) != want {
+		t.Fatalf("incorrect row: got %s, want %s", b, want)
+	}
+}
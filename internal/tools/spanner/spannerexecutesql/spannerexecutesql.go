@@ -23,7 +23,9 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	spannerdb "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	"github.com/googleapis/genai-toolbox/internal/tools"
-	"google.golang.org/api/iterator"
+	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannercommon"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 )
 
 const kind string = "spanner-execute-sql"
@@ -53,12 +55,15 @@ var _ compatibleSource = &spannerdb.Source{}
 var compatibleSources = [...]string{spannerdb.SourceKind}
 
 type Config struct {
-	Name         string   `yaml:"name" validate:"required"`
-	Kind         string   `yaml:"kind" validate:"required"`
-	Source       string   `yaml:"source" validate:"required"`
-	Description  string   `yaml:"description" validate:"required"`
-	AuthRequired []string `yaml:"authRequired"`
-	ReadOnly     bool     `yaml:"readOnly"`
+	Name         string            `yaml:"name" validate:"required"`
+	Kind         string            `yaml:"kind" validate:"required"`
+	Source       string            `yaml:"source" validate:"required"`
+	Description  string            `yaml:"description" validate:"required"`
+	AuthRequired []string          `yaml:"authRequired"`
+	ReadOnly     bool              `yaml:"readOnly"`
+	Policy       *sqlpolicy.Policy `yaml:"policy"`
+	// Staleness is the staleness of the reads of the statements, if ReadOnly.
+	Staleness *spannercommon.Staleness `yaml:"staleness"`
 }
 
 // validate interface
@@ -81,6 +86,20 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	if cfg.Policy != nil {
+		if err := cfg.Policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := spannercommon.CheckStaleness(cfg.Staleness, cfg.ReadOnly); err != nil {
+		return nil, err
+	}
+	bound, err := cfg.Staleness.TimestampBound()
+	if err != nil {
+		return nil, err
+	}
+
 	sqlParameter := tools.NewStringParameter("sql", "The sql to execute.")
 	parameters := tools.Parameters{sqlParameter}
 
@@ -92,15 +111,17 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         kind,
-		Parameters:   parameters,
-		AuthRequired: cfg.AuthRequired,
-		ReadOnly:     cfg.ReadOnly,
-		Client:       s.SpannerClient(),
-		dialect:      s.DatabaseDialect(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
-		mcpManifest:  mcpManifest,
+		Name:           cfg.Name,
+		Kind:           kind,
+		Parameters:     parameters,
+		AuthRequired:   cfg.AuthRequired,
+		ReadOnly:       cfg.ReadOnly,
+		TimestampBound: bound,
+		Policy:         cfg.Policy,
+		Client:         s.SpannerClient(),
+		dialect:        s.DatabaseDialect(),
+		manifest:       tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:    mcpManifest,
 	}
 	return t, nil
 }
@@ -109,39 +130,17 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 var _ tools.Tool = Tool{}
 
 type Tool struct {
-	Name         string           `yaml:"name"`
-	Kind         string           `yaml:"kind"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
-	ReadOnly     bool             `yaml:"readOnly"`
-	Client       *spanner.Client
-	dialect      string
-	manifest     tools.Manifest
-	mcpManifest  tools.McpManifest
-}
-
-// processRows iterates over the spanner.RowIterator and converts each row to a map[string]any.
-func processRows(iter *spanner.RowIterator) ([]any, error) {
-	var out []any
-	defer iter.Stop()
-
-	for {
-		row, err := iter.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
-		}
-
-		vMap := make(map[string]any)
-		cols := row.ColumnNames()
-		for i, c := range cols {
-			vMap[c] = row.ColumnValue(i)
-		}
-		out = append(out, vMap)
-	}
-	return out, nil
+	Name           string           `yaml:"name"`
+	Kind           string           `yaml:"kind"`
+	AuthRequired   []string         `yaml:"authRequired"`
+	Parameters     tools.Parameters `yaml:"parameters"`
+	ReadOnly       bool             `yaml:"readOnly"`
+	TimestampBound spanner.TimestampBound
+	Policy         *sqlpolicy.Policy
+	Client         *spanner.Client
+	dialect        string
+	manifest       tools.Manifest
+	mcpManifest    tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
@@ -151,29 +150,26 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 		return nil, fmt.Errorf("unable to get cast %s", sliceParams[0])
 	}
 
-	var results []any
-	var opErr error
-	stmt := spanner.Statement{SQL: sql}
-
+	dialect := sqlparse.GoogleSQL
+	if t.dialect == "postgresql" {
+		dialect = sqlparse.Postgres
+	}
 	if t.ReadOnly {
-		iter := t.Client.Single().Query(ctx, stmt)
-		results, opErr = processRows(iter)
-	} else {
-		_, opErr = t.Client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
-			var err error
-			iter := txn.Query(ctx, stmt)
-			results, err = processRows(iter)
-			if err != nil {
-				return err
-			}
-			return nil
-		})
+		if err := sqlparse.CheckReadOnly(sql, dialect); err != nil {
+			return nil, err
+		}
 	}
-
-	if opErr != nil {
-		return nil, fmt.Errorf("unable to execute query: %w", opErr)
+	if t.Policy != nil {
+		if err := t.Policy.Check(sql, dialect); err != nil {
+			return nil, err
+		}
 	}
 
+	stmt := spanner.Statement{SQL: sql}
+	results, err := spannercommon.Query(ctx, t.Client, stmt, t.ReadOnly, t.TimestampBound)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
 	return results, nil
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannercommon"
 	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannerexecutesql"
 )
 
@@ -75,6 +76,30 @@ func TestParseFromYamlExecuteSql(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "staleness",
+			in: `
+			tools:
+				example_tool:
+					kind: spanner-execute-sql
+					source: my-spanner-instance
+					description: some description
+					readOnly: true
+					staleness:
+						exactStaleness: 15s
+			`,
+			want: server.ToolConfigs{
+				"example_tool": spannerexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "spanner-execute-sql",
+					Source:       "my-spanner-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					ReadOnly:     true,
+					Staleness:    &spannercommon.Staleness{ExactStaleness: "15s"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -22,6 +22,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannercommon"
 	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannersql"
 )
 
@@ -95,6 +96,33 @@ func TestParseFromYamlSpanner(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "staleness",
+			in: `
+			tools:
+				example_tool:
+					kind: spanner-sql
+					source: my-pg-instance
+					description: some description
+					readOnly: true
+					staleness:
+						minReadTimestamp: 2025-01-01T00:00:00Z
+					statement: |
+						SELECT * FROM SQL_STATEMENT;
+			`,
+			want: server.ToolConfigs{
+				"example_tool": spannersql.Config{
+					Name:         "example_tool",
+					Kind:         "spanner-sql",
+					Source:       "my-pg-instance",
+					Description:  "some description",
+					Statement:    "SELECT * FROM SQL_STATEMENT;\n",
+					ReadOnly:     true,
+					Staleness:    &spannercommon.Staleness{MinReadTimestamp: "2025-01-01T00:00:00Z"},
+					AuthRequired: []string{},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -24,7 +24,8 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	spannerdb "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	"github.com/googleapis/genai-toolbox/internal/tools"
-	"google.golang.org/api/iterator"
+	"github.com/googleapis/genai-toolbox/internal/tools/spanner/spannercommon"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 )
 
 const kind string = "spanner-sql"
@@ -63,6 +64,8 @@ type Config struct {
 	AuthRequired       []string         `yaml:"authRequired"`
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	// Staleness is the staleness of the reads of the statement, if ReadOnly.
+	Staleness *spannercommon.Staleness `yaml:"staleness"`
 }
 
 // validate interface
@@ -85,7 +88,26 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
 	}
 
+	if err := spannercommon.CheckStaleness(cfg.Staleness, cfg.ReadOnly); err != nil {
+		return nil, err
+	}
+	bound, err := cfg.Staleness.TimestampBound()
+	if err != nil {
+		return nil, err
+	}
+
 	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	// Spanner binds the parameters of the PostgreSQL dialect by position
+	claims, err := tools.BindClaims(cfg.Statement, allParameters, func(name string, i int) string {
+		if strings.ToLower(s.DatabaseDialect()) == "postgresql" {
+			return fmt.Sprintf("$%d", len(cfg.Parameters)+i+1)
+		}
+		return "@" + name
+	})
+	if err != nil {
+		return nil, err
+	}
+	allParameters = append(allParameters, claims.Parameters...)
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -100,9 +122,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Parameters:         cfg.Parameters,
 		TemplateParameters: cfg.TemplateParameters,
 		AllParams:          allParameters,
-		Statement:          cfg.Statement,
+		Statement:          claims.Statement,
+		Claims:             claims,
 		AuthRequired:       cfg.AuthRequired,
 		ReadOnly:           cfg.ReadOnly,
+		TimestampBound:     bound,
 		Client:             s.SpannerClient(),
 		dialect:            s.DatabaseDialect(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
@@ -122,9 +146,11 @@ type Tool struct {
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 	AllParams          tools.Parameters `yaml:"allParams"`
 	ReadOnly           bool             `yaml:"readOnly"`
+	TimestampBound     spanner.TimestampBound
 	Client             *spanner.Client
 	dialect            string
 	Statement          string
+	Claims             tools.ClaimBinding
 	manifest           tools.Manifest
 	mcpManifest        tools.McpManifest
 }
@@ -140,30 +166,6 @@ func getMapParams(params tools.ParamValues, dialect string) (map[string]interfac
 	}
 }
 
-// processRows iterates over the spanner.RowIterator and converts each row to a map[string]any.
-func processRows(iter *spanner.RowIterator) ([]any, error) {
-	var out []any
-	defer iter.Stop()
-
-	for {
-		row, err := iter.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse row: %w", err)
-		}
-
-		vMap := make(map[string]any)
-		cols := row.ColumnNames()
-		for i, c := range cols {
-			vMap[c] = row.ColumnValue(i)
-		}
-		out = append(out, vMap)
-	}
-	return out, nil
-}
-
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
 	paramsMap := params.AsMap()
 	newStatement, err := tools.ResolveTemplateParams(t.TemplateParameters, t.Statement, paramsMap)
@@ -195,40 +197,55 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 			if err != nil {
 				return nil, fmt.Errorf("unable to convert parameter `%s` from []any to typed slice: %w", name, err)
 			}
+		case *tools.DecimalParameter:
+			// the PostgreSQL dialect has its own NUMERIC type
+			if s, ok := value.(string); ok && strings.ToLower(t.dialect) == "postgresql" {
+				value = spanner.PGNumeric{Numeric: s, Valid: true}
+				break
+			}
+			if value != nil {
+				var err error
+				value, err = tools.ConvertAnyToTyped(value, p.GetType())
+				if err != nil {
+					return nil, fmt.Errorf("unable to convert parameter `%s`: %w", name, err)
+				}
+			}
+		default:
+			if value != nil {
+				var err error
+				value, err = tools.ConvertAnyToTyped(value, p.GetType())
+				if err != nil {
+					return nil, fmt.Errorf("unable to convert parameter `%s`: %w", name, err)
+				}
+			}
 		}
 		newParams[i] = tools.ParamValue{Name: name, Value: value}
 	}
 
+	newParams = append(newParams, t.Claims.Values(paramsMap)...)
+
+	dialect := sqlparse.GoogleSQL
+	if strings.ToLower(t.dialect) == "postgresql" {
+		dialect = sqlparse.Postgres
+	}
+	newStatement, err = tools.RewriteArrayInLists(newStatement, t.Parameters, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("unable to rewrite array params: %w", err)
+	}
+
 	mapParams, err := getMapParams(newParams, t.dialect)
 	if err != nil {
 		return nil, fmt.Errorf("fail to get map params: %w", err)
 	}
 
-	var results []any
-	var opErr error
 	stmt := spanner.Statement{
 		SQL:    newStatement,
 		Params: mapParams,
 	}
-
-	if t.ReadOnly {
-		iter := t.Client.Single().Query(ctx, stmt)
-		results, opErr = processRows(iter)
-	} else {
-		_, opErr = t.Client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
-			iter := txn.Query(ctx, stmt)
-			results, err = processRows(iter)
-			if err != nil {
-				return err
-			}
-			return nil
-		})
-	}
-
-	if opErr != nil {
-		return nil, fmt.Errorf("unable to execute client: %w", opErr)
+	results, err := spannercommon.Query(ctx, t.Client, stmt, t.ReadOnly, t.TimestampBound)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute client: %w", err)
 	}
-
 	return results, nil
 }
 
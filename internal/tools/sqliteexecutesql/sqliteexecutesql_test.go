@@ -0,0 +1,212 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqliteexecutesql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqliteexecutesql"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlExecuteSql(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: sqlite-execute-sql
+					source: my-instance
+					description: some description
+					authRequired:
+						- my-google-auth-service
+						- other-auth-service
+			`,
+			want: server.ToolConfigs{
+				"example_tool": sqliteexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "sqlite-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{"my-google-auth-service", "other-auth-service"},
+				},
+			},
+		},
+		{
+			desc: "read only",
+			in: `
+			tools:
+				example_tool:
+					kind: sqlite-execute-sql
+					source: my-instance
+					description: some description
+					readOnly: true
+			`,
+			want: server.ToolConfigs{
+				"example_tool": sqliteexecutesql.Config{
+					Name:         "example_tool",
+					Kind:         "sqlite-execute-sql",
+					Source:       "my-instance",
+					Description:  "some description",
+					ReadOnly:     true,
+					AuthRequired: []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+
+}
+
+func TestInvokeExecuteSql(t *testing.T) {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	tool, err := sqliteexecutesql.Config{
+		Name:        "example_tool",
+		Kind:        "sqlite-execute-sql",
+		Source:      "my-instance",
+		Description: "some description",
+	}.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		sql  string
+		want any
+	}{
+		{
+			desc: "create table",
+			sql:  "CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT)",
+		},
+		{
+			desc: "insert",
+			sql:  "INSERT INTO t (name) VALUES ('Alice'), (NULL)",
+		},
+		{
+			desc: "select",
+			sql:  "SELECT * FROM t ORDER BY id",
+			want: []any{
+				map[string]any{"id": int64(1), "name": "Alice"},
+				map[string]any{"id": int64(2), "name": nil},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params, err := tool.ParseParams(map[string]any{"sql": tc.sql}, nil)
+			if err != nil {
+				t.Fatalf("unable to parse params: %s", err)
+			}
+			got, err := tool.Invoke(ctx, params)
+			if err != nil {
+				t.Fatalf("unable to invoke: %s", err)
+			}
+			if tc.want == nil {
+				if got.([]any) != nil {
+					t.Fatalf("unexpected result: %v", got)
+				}
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect result: diff %v", diff)
+			}
+		})
+	}
+
+	_, err = tool.Invoke(ctx, tools.ParamValues{{Name: "sql", Value: "SELEC 1"}})
+	if err == nil {
+		t.Fatalf("expect error for invalid statement")
+	}
+
+	readOnlyTool, err := sqliteexecutesql.Config{
+		Name:        "read_only_tool",
+		Kind:        "sqlite-execute-sql",
+		Source:      "my-instance",
+		Description: "some description",
+		ReadOnly:    true,
+	}.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	got, err := readOnlyTool.Invoke(ctx, tools.ParamValues{{Name: "sql", Value: "SELECT count(*) AS n FROM t"}})
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	if diff := cmp.Diff([]any{map[string]any{"n": int64(2)}}, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+	_, err = readOnlyTool.Invoke(ctx, tools.ParamValues{{Name: "sql", Value: "DELETE FROM t"}})
+	if err == nil || err.Error() != "DELETE statements are not allowed by a read-only tool" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	policyTool, err := sqliteexecutesql.Config{
+		Name:        "policy_tool",
+		Kind:        "sqlite-execute-sql",
+		Source:      "my-instance",
+		Description: "some description",
+		Policy:      &sqlpolicy.Policy{AllowedTables: []string{"t"}},
+	}.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	_, err = policyTool.Invoke(ctx, tools.ParamValues{{Name: "sql", Value: "SELECT * FROM t JOIN sqlite_master"}})
+	var violation *sqlpolicy.ViolationError
+	if !errors.As(err, &violation) || violation.Rule != sqlpolicy.RuleAllowedTables || violation.Value != "sqlite_master" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the pragma is reset after the read-only statement
+	if _, err := tool.Invoke(ctx, tools.ParamValues{{Name: "sql", Value: "DELETE FROM t WHERE name IS NULL"}}); err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+}
@@ -18,11 +18,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlstmtcache"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
 )
 
 const kind string = "sqlite-sql"
@@ -56,6 +60,7 @@ type Config struct {
 	Source             string           `yaml:"source" validate:"required"`
 	Description        string           `yaml:"description" validate:"required"`
 	Statement          string           `yaml:"statement" validate:"required"`
+	PrepareStatement   bool             `yaml:"prepareStatement"`
 	AuthRequired       []string         `yaml:"authRequired"`
 	Parameters         tools.Parameters `yaml:"parameters"`
 	TemplateParameters tools.Parameters `yaml:"templateParameters"`
@@ -82,6 +87,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	}
 
 	allParameters, paramManifest, paramMcpManifest := tools.ProcessParameters(cfg.TemplateParameters, cfg.Parameters)
+	claims, err := tools.BindPositionalClaims(cfg.Statement, allParameters, sqlparse.SQLite)
+	if err != nil {
+		return nil, err
+	}
+	allParameters = append(allParameters, claims.Parameters...)
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -96,12 +106,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Parameters:         cfg.Parameters,
 		TemplateParameters: cfg.TemplateParameters,
 		AllParams:          allParameters,
-		Statement:          cfg.Statement,
+		Statement:          claims.Statement,
+		Claims:             claims,
 		AuthRequired:       cfg.AuthRequired,
 		Db:                 s.SQLiteDB(),
 		manifest:           tools.Manifest{Description: cfg.Description, Parameters: paramManifest, AuthRequired: cfg.AuthRequired},
 		mcpManifest:        mcpManifest,
 	}
+	if cfg.PrepareStatement {
+		t.Stmts = sqlstmtcache.New(t.Db)
+	}
 	return t, nil
 }
 
@@ -117,7 +131,9 @@ type Tool struct {
 	AllParams          tools.Parameters `yaml:"allParams"`
 
 	Db          *sql.DB
+	Stmts       *sqlstmtcache.Cache
 	Statement   string `yaml:"statement"`
+	Claims      tools.ClaimBinding
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
@@ -133,9 +149,19 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to extract standard params %w", err)
 	}
+	// SQLite has no date type, dates are stored as text in the format YYYY-MM-DD
+	for i, p := range t.Parameters {
+		if d, ok := newParams[i].Value.(time.Time); ok && p.GetType() == "date" {
+			newParams[i].Value = d.Format(time.DateOnly)
+		}
+	}
 
 	// Execute the SQL query with parameters
-	rows, err := t.Db.QueryContext(ctx, newStatement, newParams.AsSlice()...)
+	var q sqlstmtcache.Querier = t.Db
+	if t.Stmts != nil {
+		q = t.Stmts
+	}
+	rows, err := q.QueryContext(ctx, newStatement, t.Claims.Args(newParams.AsSlice(), paramsMap)...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -146,6 +172,11 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get column names: %w", err)
 	}
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get column types: %w", err)
+	}
+	util.ReportColumns(ctx, util.SQLColumns(colTypes))
 
 	values := make([]any, len(cols))
 	valuePtrs := make([]any, len(cols))
@@ -15,14 +15,19 @@
 package sqlitesql_test
 
 import (
+	"context"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlSQLite(t *testing.T) {
@@ -174,3 +179,178 @@ func TestParseFromYamlWithTemplateSqlite(t *testing.T) {
 		})
 	}
 }
+
+func TestInvokeWithClaims(t *testing.T) {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = src.(*sqlite.Source).SQLiteDB().ExecContext(ctx, `
+		CREATE TABLE notes (owner TEXT, status TEXT, body TEXT);
+		INSERT INTO notes VALUES
+			('alice@example.com', 'open', 'a1'),
+			('alice@example.com', 'done', 'a2'),
+			('bob@example.com', 'open', 'b1');`)
+	if err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	tool, err := sqlitesql.Config{
+		Name:        "example_tool",
+		Kind:        "sqlite-sql",
+		Source:      "my-instance",
+		Description: "some description",
+		Statement:   "SELECT body FROM notes WHERE status = ? AND owner = {{ .claims.my-google-auth.email }} ORDER BY body",
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("status", "some description"),
+		},
+	}.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	// the claim isn't part of the manifest, and can't be set by the client
+	if diff := cmp.Diff([]tools.ParameterManifest{{Name: "status", Type: "string", Required: true, Description: "some description", AuthServices: []string{}}}, tool.Manifest().Parameters); diff != "" {
+		t.Fatalf("incorrect manifest: diff %v", diff)
+	}
+	claims := map[string]map[string]any{"my-google-auth": {"email": "alice@example.com"}}
+	data := map[string]any{"status": "open", "claims_my_google_auth_email": "bob@example.com"}
+	params, err := tool.ParseParams(data, claims)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	if diff := cmp.Diff([]any{map[string]any{"body": "a1"}}, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	_, err = tool.ParseParams(data, nil)
+	if err == nil {
+		t.Fatalf("expect error without a verified token")
+	}
+}
+
+func TestInvokeWithDate(t *testing.T) {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = src.(*sqlite.Source).SQLiteDB().ExecContext(ctx, `
+		CREATE TABLE orders (id INTEGER, placed TEXT);
+		INSERT INTO orders VALUES (1, '2025-01-01'), (2, '2025-01-02');`)
+	if err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	tool, err := sqlitesql.Config{
+		Name:        "example_tool",
+		Kind:        "sqlite-sql",
+		Source:      "my-instance",
+		Description: "some description",
+		Statement:   "SELECT id FROM orders WHERE placed = ?",
+		Parameters: tools.Parameters{
+			tools.NewDateParameter("placed", "some description"),
+		},
+	}.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"placed": "2025-01-02"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	if diff := cmp.Diff([]any{map[string]any{"id": int64(2)}}, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+}
+
+func TestInvokePrepareStatement(t *testing.T) {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = src.(*sqlite.Source).SQLiteDB().ExecContext(ctx, `
+		CREATE TABLE orders (id INTEGER, status TEXT);
+		INSERT INTO orders VALUES (1, 'open'), (2, 'done'), (3, 'open');`)
+	if err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	tool, err := sqlitesql.Config{
+		Name:             "example_tool",
+		Kind:             "sqlite-sql",
+		Source:           "my-instance",
+		Description:      "some description",
+		Statement:        "SELECT id FROM orders WHERE status = ? ORDER BY id",
+		PrepareStatement: true,
+		Parameters: tools.Parameters{
+			tools.NewStringParameter("status", "some description"),
+		},
+	}.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	// the prepared statement is reused with other arguments
+	for status, want := range map[string][]any{
+		"open": {map[string]any{"id": int64(1)}, map[string]any{"id": int64(3)}},
+		"done": {map[string]any{"id": int64(2)}},
+	} {
+		params, err := tool.ParseParams(map[string]any{"status": status}, nil)
+		if err != nil {
+			t.Fatalf("unable to parse params: %s", err)
+		}
+		got, err := tool.Invoke(ctx, params)
+		if err != nil {
+			t.Fatalf("unable to invoke: %s", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("incorrect result for %q: diff %v", status, diff)
+		}
+	}
+	if n := tool.(sqlitesql.Tool).Stmts.Len(); n != 1 {
+		t.Fatalf("expected 1 prepared statement, got %d", n)
+	}
+}
+
+func TestInvokeReportsColumns(t *testing.T) {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	_, err = src.(*sqlite.Source).SQLiteDB().ExecContext(ctx, `
+		CREATE TABLE orders (id INTEGER, status TEXT);
+		INSERT INTO orders VALUES (1, 'open');`)
+	if err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	tool, err := sqlitesql.Config{
+		Name:        "example_tool",
+		Kind:        "sqlite-sql",
+		Source:      "my-instance",
+		Description: "some description",
+		Statement:   "SELECT id, status FROM orders",
+	}.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	schema := &util.Schema{}
+	if _, err := tool.Invoke(util.WithSchema(ctx, schema), nil); err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	nullable := true
+	want := []util.Column{{Name: "id", Type: "INTEGER", Nullable: &nullable}, {Name: "status", Type: "TEXT", Nullable: &nullable}}
+	if diff := cmp.Diff(want, schema.Columns()); diff != "" {
+		t.Fatalf("incorrect columns: diff %v", diff)
+	}
+}
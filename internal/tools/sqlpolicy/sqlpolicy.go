@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package sqlpolicy restricts the SQL that tools run on behalf of clients.
+// Operators declare the policy of a tool in its config, and the tool checks
+// the statements of each invocation against it before running them.
+package sqlpolicy
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+)
+
+// Rules of a policy, as reported in a ViolationError.
+const (
+	RuleSyntax            = "syntax"
+	RuleAllowedStatements = "allowedStatements"
+	RuleAllowedTables     = "allowedTables"
+	RuleDeniedKeywords    = "deniedKeywords"
+	RuleCrossDatabase     = "denyCrossDatabase"
+)
+
+// Policy is the SQL policy of a tool. Its zero value allows any SQL.
+type Policy struct {
+	// AllowedStatements are the types of the statements that are allowed,
+	// e.g. SELECT. Any type is allowed if it's empty.
+	AllowedStatements []string `yaml:"allowedStatements"`
+	// AllowedTables are patterns of the names of the tables that statements
+	// can reference, e.g. "public.*". Each part of a pattern matches a part of
+	// a name, so "*" only matches unqualified names. Any table is allowed if
+	// it's empty.
+	AllowedTables []string `yaml:"allowedTables"`
+	// DeniedKeywords are keywords that statements can't contain, e.g. DROP.
+	DeniedKeywords []string `yaml:"deniedKeywords"`
+	// DenyCrossDatabase rejects statements that reference a table by a name
+	// that is qualified with a database, e.g. other_db.public.t.
+	DenyCrossDatabase bool `yaml:"denyCrossDatabase"`
+}
+
+// Validate verifies the patterns of the allowed tables.
+func (p Policy) Validate() error {
+	for _, pattern := range p.AllowedTables {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid allowedTables pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// ViolationError is returned when SQL violates a policy. It's serialized in
+// the error responses of the server, so that clients can tell why the SQL was
+// rejected.
+type ViolationError struct {
+	// Rule is the rule of the policy that was violated, e.g. allowedTables.
+	Rule string `json:"rule"`
+	// Statement is the position of the statement that violated the rule,
+	// starting at 1.
+	Statement int `json:"statement"`
+	// Value is the statement type, table or keyword that violated the rule.
+	Value string `json:"value,omitempty"`
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("SQL policy violation in statement %d (%s): %s", e.Statement, e.Rule, e.Message)
+}
+
+// Check parses the SQL, and returns a ViolationError for the first statement
+// that violates the policy.
+func (p Policy) Check(sql string, dialect sqlparse.Dialect) error {
+	statements, err := sqlparse.Parse(sql, dialect)
+	if err != nil {
+		return &ViolationError{Rule: RuleSyntax, Statement: 1, Message: fmt.Sprintf("unable to parse SQL: %s", err)}
+	}
+	for i, s := range statements {
+		if err := p.checkStatement(s, dialect); err != nil {
+			err.Statement = i + 1
+			return err
+		}
+	}
+	return nil
+}
+
+func (p Policy) checkStatement(s sqlparse.Statement, dialect sqlparse.Dialect) *ViolationError {
+	typ := s.Type()
+	if len(p.AllowedStatements) > 0 && !slices.ContainsFunc(p.AllowedStatements, func(allowed string) bool { return strings.EqualFold(allowed, typ) }) {
+		return &ViolationError{Rule: RuleAllowedStatements, Value: typ, Message: fmt.Sprintf("%s statements are not allowed", typ)}
+	}
+	for _, tok := range s.Tokens {
+		if tok.Kind != sqlparse.Word {
+			continue
+		}
+		for _, keyword := range p.DeniedKeywords {
+			if strings.EqualFold(keyword, tok.Value) {
+				keyword = strings.ToUpper(keyword)
+				return &ViolationError{Rule: RuleDeniedKeywords, Value: keyword, Message: fmt.Sprintf("keyword %s is not allowed", keyword)}
+			}
+		}
+	}
+	for _, table := range s.Tables() {
+		if p.DenyCrossDatabase && len(table) > maxParts(dialect) {
+			return &ViolationError{Rule: RuleCrossDatabase, Value: table.String(), Message: fmt.Sprintf("table %q is in another database", table)}
+		}
+		if len(p.AllowedTables) > 0 && !slices.ContainsFunc(p.AllowedTables, func(pattern string) bool { return match(pattern, table) }) {
+			return &ViolationError{Rule: RuleAllowedTables, Value: table.String(), Message: fmt.Sprintf("table %q is not allowed", table)}
+		}
+	}
+	return nil
+}
+
+// maxParts returns the number of parts of the longest table name that doesn't
+// name a database: schema.table, or dataset.table in GoogleSQL. A qualified
+// name in MySQL names a database, and one in SQLite an attached database.
+func maxParts(dialect sqlparse.Dialect) int {
+	switch dialect {
+	case sqlparse.MySQL, sqlparse.SQLite:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// match reports whether the table name matches the pattern, part by part and
+// ignoring case.
+func match(pattern string, table sqlparse.TableName) bool {
+	parts := strings.Split(pattern, ".")
+	if len(parts) != len(table) {
+		return false
+	}
+	for i, part := range parts {
+		ok, _ := path.Match(strings.ToLower(part), strings.ToLower(table[i]))
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlpolicy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlpolicy"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+)
+
+func TestCheck(t *testing.T) {
+	policy := sqlpolicy.Policy{
+		AllowedStatements: []string{"select", "INSERT"},
+		AllowedTables:     []string{"public.*", "orders", "sales.order_*"},
+		DeniedKeywords:    []string{"pg_sleep"},
+		DenyCrossDatabase: true,
+	}
+	tcs := []struct {
+		desc    string
+		dialect sqlparse.Dialect
+		policy  sqlpolicy.Policy
+		sql     string
+		want    *sqlpolicy.ViolationError
+	}{
+		{
+			desc:    "allowed",
+			dialect: sqlparse.Postgres,
+			policy:  policy,
+			sql:     `SELECT * FROM orders o JOIN public.customers c ON o.cid = c.id; INSERT INTO sales.order_items SELECT * FROM "ORDERS"`,
+		},
+		{
+			desc:    "zero policy",
+			dialect: sqlparse.Postgres,
+			sql:     "DROP TABLE other.secret",
+		},
+		{
+			desc:    "statement type",
+			dialect: sqlparse.Postgres,
+			policy:  policy,
+			sql:     "SELECT 1; DELETE FROM orders",
+			want:    &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleAllowedStatements, Statement: 2, Value: "DELETE", Message: "DELETE statements are not allowed"},
+		},
+		{
+			desc:    "table",
+			dialect: sqlparse.Postgres,
+			policy:  policy,
+			sql:     "SELECT * FROM orders WHERE id IN (SELECT id FROM secret.users)",
+			want:    &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleAllowedTables, Statement: 1, Value: "secret.users", Message: `table "secret.users" is not allowed`},
+		},
+		{
+			desc:    "unqualified name doesn't match a qualified pattern",
+			dialect: sqlparse.Postgres,
+			policy:  policy,
+			sql:     "SELECT * FROM customers",
+			want:    &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleAllowedTables, Statement: 1, Value: "customers", Message: `table "customers" is not allowed`},
+		},
+		{
+			desc:    "keyword",
+			dialect: sqlparse.Postgres,
+			policy:  policy,
+			sql:     "SELECT pg_sleep(10)",
+			want:    &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleDeniedKeywords, Statement: 1, Value: "PG_SLEEP", Message: "keyword PG_SLEEP is not allowed"},
+		},
+		{
+			desc:    "keyword in string",
+			dialect: sqlparse.Postgres,
+			policy:  policy,
+			sql:     "SELECT 'pg_sleep' FROM orders",
+		},
+		{
+			desc:    "cross database",
+			dialect: sqlparse.SQLServer,
+			policy:  sqlpolicy.Policy{DenyCrossDatabase: true},
+			sql:     "SELECT * FROM dbo.a JOIN other.dbo.b ON a.id = b.id",
+			want:    &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleCrossDatabase, Statement: 1, Value: "other.dbo.b", Message: `table "other.dbo.b" is in another database`},
+		},
+		{
+			desc:    "cross database in mysql",
+			dialect: sqlparse.MySQL,
+			policy:  sqlpolicy.Policy{DenyCrossDatabase: true},
+			sql:     "SELECT * FROM other.b",
+			want:    &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleCrossDatabase, Statement: 1, Value: "other.b", Message: `table "other.b" is in another database`},
+		},
+		{
+			desc:    "syntax",
+			dialect: sqlparse.Postgres,
+			policy:  policy,
+			sql:     "SELECT 'a",
+			want:    &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleSyntax, Statement: 1, Message: "unable to parse SQL: unterminated string literal"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.policy.Check(tc.sql, tc.dialect)
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			var got *sqlpolicy.ViolationError
+			if !errors.As(err, &got) {
+				t.Fatalf("expect a violation, got %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect violation: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestViolationError(t *testing.T) {
+	err := &sqlpolicy.ViolationError{Rule: sqlpolicy.RuleAllowedStatements, Statement: 2, Value: "DROP", Message: "DROP statements are not allowed"}
+	want := "SQL policy violation in statement 2 (allowedStatements): DROP statements are not allowed"
+	if err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (sqlpolicy.Policy{AllowedTables: []string{"public.*", "t_[0-9]"}}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err := sqlpolicy.Policy{AllowedTables: []string{"public.[a"}}.Validate()
+	if err == nil || err.Error() != `invalid allowedTables pattern "public.[a": syntax error in pattern` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlprocedure holds the parts of the tools that call stored
+// procedures which don't depend on the database: the arguments of the call,
+// and the conversion of the values of OUT parameters to their declared types.
+package sqlprocedure
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+var procedureName = regexp.MustCompile(`^` + tools.DefaultIdentifierPattern + `$`)
+
+// CheckName returns an error if the name of a procedure isn't an unquoted
+// identifier, which may be qualified, since it's part of the text of the CALL
+// statement.
+func CheckName(name string) error {
+	if !procedureName.MatchString(name) {
+		return fmt.Errorf("procedure %q must be an unquoted identifier, e.g. inventory.restock", name)
+	}
+	return nil
+}
+
+// Result is the result of a procedure call.
+type Result struct {
+	// Out are the values of the OUT and INOUT parameters, by name.
+	Out map[string]any `json:"out"`
+	// ResultSets are the rows of the result sets that the procedure returned,
+	// in order, if any.
+	ResultSets [][]any `json:"resultSets,omitempty"`
+}
+
+// Argument is an argument of a procedure call.
+type Argument struct {
+	Name string
+	// In is true if the value of the parameter is passed to the procedure,
+	// for IN and INOUT parameters.
+	In bool
+	// Out is the declared OUT parameter if the procedure returns a value in
+	// the argument, for OUT and INOUT parameters.
+	Out tools.Parameter
+}
+
+// Arguments returns the arguments of a procedure call, in the order of the
+// names of arguments. A parameter that is both in parameters and in
+// outParameters is an INOUT parameter. If arguments is empty, the arguments
+// are the parameters followed by the OUT parameters that aren't INOUT.
+func Arguments(arguments []string, parameters, outParameters tools.Parameters) ([]Argument, error) {
+	in := make(map[string]bool, len(parameters))
+	for _, p := range parameters {
+		in[p.GetName()] = true
+	}
+	out := make(map[string]tools.Parameter, len(outParameters))
+	for _, p := range outParameters {
+		if _, ok := out[p.GetName()]; ok {
+			return nil, fmt.Errorf("duplicate out parameter %q", p.GetName())
+		}
+		if p.GetExpression() != "" || len(p.GetAuthServices()) != 0 {
+			return nil, fmt.Errorf("out parameter %q can't be computed or authenticated", p.GetName())
+		}
+		out[p.GetName()] = p
+	}
+
+	if len(arguments) == 0 {
+		for _, p := range parameters {
+			arguments = append(arguments, p.GetName())
+		}
+		for _, p := range outParameters {
+			if !in[p.GetName()] {
+				arguments = append(arguments, p.GetName())
+			}
+		}
+	}
+
+	args := make([]Argument, 0, len(arguments))
+	seen := make(map[string]bool, len(arguments))
+	for _, name := range arguments {
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate argument %q", name)
+		}
+		seen[name] = true
+		if !in[name] && out[name] == nil {
+			return nil, fmt.Errorf("argument %q isn't a parameter or an out parameter", name)
+		}
+		args = append(args, Argument{Name: name, In: in[name], Out: out[name]})
+	}
+	for name := range out {
+		if !seen[name] {
+			return nil, fmt.Errorf("out parameter %q isn't an argument", name)
+		}
+	}
+	return args, nil
+}
+
+// OutValue converts the value returned in an OUT parameter to the type of
+// the parameter. Drivers return some values as text, e.g. the user variables
+// of MySQL that hold the OUT parameters, which must be parsed.
+func OutValue(p tools.Parameter, v any) (any, error) {
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	switch p.GetType() {
+	case "integer":
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("out parameter %q: %q is not an integer", p.GetName(), s)
+		}
+		return n, nil
+	case "float":
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("out parameter %q: %q is not a float", p.GetName(), s)
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("out parameter %q: %q is not a boolean", p.GetName(), s)
+		}
+		return b, nil
+	}
+	return s, nil
+}
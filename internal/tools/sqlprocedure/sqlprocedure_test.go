@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlprocedure_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlprocedure"
+)
+
+func TestCheckName(t *testing.T) {
+	for _, name := range []string{"restock", "inventory.restock", "_p1"} {
+		if err := sqlprocedure.CheckName(name); err != nil {
+			t.Errorf("CheckName(%q) returned error: %s", name, err)
+		}
+	}
+	for _, name := range []string{"", "1p", "restock()", "restock; DROP TABLE orders", `"restock"`} {
+		if err := sqlprocedure.CheckName(name); err == nil {
+			t.Errorf("CheckName(%q) returned no error", name)
+		}
+	}
+}
+
+func TestArguments(t *testing.T) {
+	item := tools.NewStringParameter("item", "Item to restock.")
+	quantity := tools.NewIntParameter("quantity", "Quantity to add.")
+	stock := tools.NewIntParameter("stock", "Stock after restocking.")
+	qOut := tools.NewIntParameter("quantity", "Quantity added.")
+
+	names := func(args []sqlprocedure.Argument) []string {
+		var got []string
+		for _, a := range args {
+			mode := "IN"
+			switch {
+			case a.In && a.Out != nil:
+				mode = "INOUT"
+			case a.Out != nil:
+				mode = "OUT"
+			}
+			got = append(got, mode+" "+a.Name)
+		}
+		return got
+	}
+
+	tcs := []struct {
+		desc      string
+		arguments []string
+		in        tools.Parameters
+		out       tools.Parameters
+		want      []string
+	}{
+		{
+			desc: "default order",
+			in:   tools.Parameters{item, quantity},
+			out:  tools.Parameters{stock},
+			want: []string{"IN item", "IN quantity", "OUT stock"},
+		},
+		{
+			desc:      "arguments order",
+			arguments: []string{"stock", "item", "quantity"},
+			in:        tools.Parameters{item, quantity},
+			out:       tools.Parameters{stock},
+			want:      []string{"OUT stock", "IN item", "IN quantity"},
+		},
+		{
+			desc: "inout",
+			in:   tools.Parameters{item, quantity},
+			out:  tools.Parameters{qOut, stock},
+			want: []string{"IN item", "INOUT quantity", "OUT stock"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			args, err := sqlprocedure.Arguments(tc.arguments, tc.in, tc.out)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, names(args)); diff != "" {
+				t.Fatalf("incorrect arguments: diff %v", diff)
+			}
+		})
+	}
+
+	errs := []struct {
+		desc      string
+		arguments []string
+		out       tools.Parameters
+	}{
+		{desc: "unknown argument", arguments: []string{"item", "price"}},
+		{desc: "duplicate argument", arguments: []string{"item", "item"}},
+		{desc: "missing out parameter", arguments: []string{"item"}, out: tools.Parameters{stock}},
+		{desc: "duplicate out parameter", out: tools.Parameters{stock, stock}},
+	}
+	for _, tc := range errs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := sqlprocedure.Arguments(tc.arguments, tools.Parameters{item}, tc.out); err == nil {
+				t.Fatalf("expected error")
+			}
+		})
+	}
+}
+
+func TestOutValue(t *testing.T) {
+	tcs := []struct {
+		desc string
+		p    tools.Parameter
+		in   any
+		want any
+	}{
+		{desc: "integer text", p: tools.NewIntParameter("n", ""), in: []byte("42"), want: int64(42)},
+		{desc: "integer value", p: tools.NewIntParameter("n", ""), in: int32(42), want: int32(42)},
+		{desc: "float text", p: tools.NewFloatParameter("f", ""), in: []byte("1.5"), want: 1.5},
+		{desc: "boolean text", p: tools.NewBooleanParameter("b", ""), in: []byte("1"), want: true},
+		{desc: "string text", p: tools.NewStringParameter("s", ""), in: []byte("done"), want: "done"},
+		{desc: "null", p: tools.NewIntParameter("n", ""), in: nil, want: nil},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := sqlprocedure.OutValue(tc.p, tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect value: diff %v", diff)
+			}
+		})
+	}
+
+	if _, err := sqlprocedure.OutValue(tools.NewIntParameter("n", ""), []byte("many")); err == nil {
+		t.Fatalf("expected error for a value that isn't an integer")
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlsession keeps transactions open across tool invocations. A
+// begin-transaction tool opens a transaction and returns the token of its
+// session, which SQL tools accept in their session parameter to run their
+// statements in the transaction, until a commit-transaction or
+// rollback-transaction tool ends it.
+package sqlsession
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ParameterName is the name of the session parameter of SQL tools.
+const ParameterName = "session"
+
+// DefaultTimeout is how long a session stays open without being used, by
+// default. An idle session is rolled back, so that it doesn't hold its locks
+// and connection forever.
+const DefaultTimeout = 5 * time.Minute
+
+// MaxSessions is the maximum number of sessions open at once on a pool or
+// database. Each session holds one of its connections until it ends, so that
+// without a limit, sessions could take all the connections of the pool.
+const MaxSessions = 10
+
+// ErrNotFound is returned for a token that isn't the token of an open session
+// of the caller, e.g. of a session that was committed or expired.
+var ErrNotFound = errors.New("session not found, it may have been committed, rolled back, or expired")
+
+// ErrTooManySessions is returned when beginning a session on a pool or
+// database that already has MaxSessions open sessions.
+var ErrTooManySessions = fmt.Errorf("too many open sessions, at most %d sessions can be open at once on a source", MaxSessions)
+
+// Parameter returns the optional session parameter of SQL tools. Its value is
+// sensitive, since anyone who knows it can use the transaction.
+func Parameter() tools.Parameter {
+	p := tools.NewStringParameterWithRequired(ParameterName, "Token of a session returned by a begin-transaction tool, to run the statement in its transaction. The statement runs on its own if it isn't set.", false)
+	p.Sensitive = true
+	return p
+}
+
+// session is an open transaction.
+type session struct {
+	// mu serializes the statements of the transaction, which can't run
+	// concurrently on its connection
+	mu sync.Mutex
+	// db is the pool or database the transaction was begun on, which the
+	// tools that use the session must use as well
+	db any
+	// caller identifies the caller who began the session, who is the only
+	// one who can use it, see util.CallerIdentity
+	caller   string
+	tx       any
+	commit   func(context.Context) error
+	rollback func(context.Context) error
+	timeout  time.Duration
+	timer    *time.Timer
+}
+
+var (
+	mu       sync.Mutex
+	sessions = make(map[string]*session)
+	// open is the number of open sessions of each pool or database,
+	// including the sessions that are being begun
+	open = make(map[any]int)
+)
+
+// BeginPostgres begins a transaction on a PostgreSQL pool, and returns the
+// token of its session, which only the caller in ctx can use.
+func BeginPostgres(ctx context.Context, pool *pgxpool.Pool, timeout time.Duration) (string, error) {
+	if err := reserve(pool); err != nil {
+		return "", err
+	}
+	// the transaction outlives the invocation that begins it
+	tx, err := pool.BeginTx(context.WithoutCancel(ctx), pgx.TxOptions{})
+	if err != nil {
+		release(pool)
+		return "", fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	return begin(ctx, pool, tx, tx.Commit, tx.Rollback, timeout), nil
+}
+
+// BeginSQL begins a transaction on a database, and returns the token of its
+// session, which only the caller in ctx can use.
+func BeginSQL(ctx context.Context, db *sql.DB, timeout time.Duration) (string, error) {
+	if err := reserve(db); err != nil {
+		return "", err
+	}
+	// a transaction of database/sql is rolled back when its context is done
+	tx, err := db.BeginTx(context.WithoutCancel(ctx), nil)
+	if err != nil {
+		release(db)
+		return "", fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	commit := func(context.Context) error { return tx.Commit() }
+	rollback := func(context.Context) error { return tx.Rollback() }
+	return begin(ctx, db, tx, commit, rollback, timeout), nil
+}
+
+// reserve counts a session that is being begun on db, unless db already has
+// too many open sessions.
+func reserve(db any) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if open[db] >= MaxSessions {
+		return ErrTooManySessions
+	}
+	open[db]++
+	return nil
+}
+
+// release stops counting a session of db.
+func release(db any) {
+	mu.Lock()
+	defer mu.Unlock()
+	releaseLocked(db)
+}
+
+func releaseLocked(db any) {
+	open[db]--
+	if open[db] <= 0 {
+		delete(open, db)
+	}
+}
+
+func begin(ctx context.Context, db any, tx any, commit, rollback func(context.Context) error, timeout time.Duration) string {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	token := uuid.New().String()
+	caller := util.CallerIdentity(ctx)
+	s := &session{db: db, caller: caller, tx: tx, commit: commit, rollback: rollback, timeout: timeout}
+	s.timer = time.AfterFunc(timeout, func() {
+		_ = end(context.Background(), token, caller, false)
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	sessions[token] = s
+	return token
+}
+
+// get returns the open session of the caller in ctx.
+func get(ctx context.Context, token string) (*session, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	s, ok := sessions[token]
+	if !ok || s.caller != util.CallerIdentity(ctx) {
+		return nil, false
+	}
+	return s, true
+}
+
+// Run calls run with the transaction of a session of the caller in ctx, which
+// is a pgx.Tx for PostgreSQL pools, and a *sql.Tx for databases. db is the pool
+// or database of the tool, which must be the one the transaction was begun on.
+func Run[T any](ctx context.Context, token string, db any, run func(tx T) (any, error)) (any, error) {
+	s, ok := get(ctx, token)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	tx, ok := s.tx.(T)
+	if !ok || s.db != db {
+		return nil, fmt.Errorf("session belongs to another source")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// the session may have ended while waiting for the previous statement
+	mu.Lock()
+	_, ok = sessions[token]
+	mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	s.timer.Reset(s.timeout)
+	return run(tx)
+}
+
+// End commits or rolls back the transaction of a session of the caller in ctx,
+// which can't be used afterwards.
+func End(ctx context.Context, token string, commit bool) error {
+	return end(ctx, token, util.CallerIdentity(ctx), commit)
+}
+
+func end(ctx context.Context, token, caller string, commit bool) error {
+	mu.Lock()
+	s, ok := sessions[token]
+	if !ok || s.caller != caller {
+		mu.Unlock()
+		return ErrNotFound
+	}
+	delete(sessions, token)
+	releaseLocked(s.db)
+	mu.Unlock()
+
+	s.timer.Stop()
+	// wait for the statement that is running, if any
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !commit {
+		if err := s.rollback(ctx); err != nil {
+			return fmt.Errorf("unable to roll back transaction: %w", err)
+		}
+		return nil
+	}
+	if err := s.commit(ctx); err != nil {
+		return fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Token returns the session token of the parameters of an invocation, or an
+// empty string if the invocation doesn't run in a session.
+func Token(params tools.ParamValues) string {
+	for _, p := range params {
+		if p.Name == ParameterName {
+			token, _ := p.Value.(string)
+			return token
+		}
+	}
+	return ""
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlsession_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func newDB(t *testing.T) *sql.DB {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	if _, err := db.ExecContext(ctx, "CREATE TABLE orders (id INTEGER)"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	return db
+}
+
+func TestSession(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	insert := func(token string) error {
+		_, err := sqlsession.Run(ctx, token, db, func(tx *sql.Tx) (any, error) {
+			return tx.ExecContext(ctx, "INSERT INTO orders VALUES (1)")
+		})
+		return err
+	}
+	count := func() int {
+		var n int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders").Scan(&n); err != nil {
+			t.Fatalf("unable to count orders: %s", err)
+		}
+		return n
+	}
+
+	for _, commit := range []bool{false, true} {
+		token, err := sqlsession.BeginSQL(ctx, db, time.Minute)
+		if err != nil {
+			t.Fatalf("unable to begin session: %s", err)
+		}
+		for range 2 {
+			if err := insert(token); err != nil {
+				t.Fatalf("unable to run in session: %s", err)
+			}
+		}
+		if err := sqlsession.End(ctx, token, commit); err != nil {
+			t.Fatalf("unable to end session: %s", err)
+		}
+		if err := insert(token); !errors.Is(err, sqlsession.ErrNotFound) {
+			t.Fatalf("expected an ended session to be gone, got %v", err)
+		}
+	}
+	if n := count(); n != 2 {
+		t.Fatalf("expected only the committed session to insert orders, got %d orders", n)
+	}
+
+	token, err := sqlsession.BeginSQL(ctx, db, time.Minute)
+	if err != nil {
+		t.Fatalf("unable to begin session: %s", err)
+	}
+	if err := sqlsession.End(ctx, "unknown", true); !errors.Is(err, sqlsession.ErrNotFound) {
+		t.Fatalf("expected an unknown session to be not found, got %v", err)
+	}
+	_, err = sqlsession.Run(ctx, token, newDB(t), func(tx *sql.Tx) (any, error) { return nil, nil })
+	if err == nil || err.Error() != "session belongs to another source" {
+		t.Fatalf("expected an error for another source, got %v", err)
+	}
+	if err := sqlsession.End(ctx, token, false); err != nil {
+		t.Fatalf("unable to end session: %s", err)
+	}
+}
+
+func TestSessionCaller(t *testing.T) {
+	alice := util.WithClaims(context.Background(), map[string]map[string]any{"my-google-auth": {"sub": "alice"}})
+	bob := util.WithClaims(context.Background(), map[string]map[string]any{"my-google-auth": {"sub": "bob"}})
+	db := newDB(t)
+	token, err := sqlsession.BeginSQL(alice, db, time.Minute)
+	if err != nil {
+		t.Fatalf("unable to begin session: %s", err)
+	}
+	run := func(ctx context.Context) error {
+		_, err := sqlsession.Run(ctx, token, db, func(tx *sql.Tx) (any, error) { return nil, nil })
+		return err
+	}
+	for name, ctx := range map[string]context.Context{"other caller": bob, "unverified caller": context.Background()} {
+		if err := run(ctx); !errors.Is(err, sqlsession.ErrNotFound) {
+			t.Fatalf("expected the session to be not found for an %s, got %v", name, err)
+		}
+		if err := sqlsession.End(ctx, token, false); !errors.Is(err, sqlsession.ErrNotFound) {
+			t.Fatalf("expected an %s to be unable to end the session, got %v", name, err)
+		}
+	}
+	if err := run(alice); err != nil {
+		t.Fatalf("unable to run in session: %s", err)
+	}
+	if err := sqlsession.End(alice, token, false); err != nil {
+		t.Fatalf("unable to end session: %s", err)
+	}
+}
+
+func TestMaxSessions(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	// each session holds a connection
+	db.SetMaxOpenConns(sqlsession.MaxSessions + 1)
+	tokens := make([]string, sqlsession.MaxSessions)
+	for i := range tokens {
+		token, err := sqlsession.BeginSQL(ctx, db, time.Minute)
+		if err != nil {
+			t.Fatalf("unable to begin session: %s", err)
+		}
+		tokens[i] = token
+	}
+	if _, err := sqlsession.BeginSQL(ctx, db, time.Minute); !errors.Is(err, sqlsession.ErrTooManySessions) {
+		t.Fatalf("expected too many sessions, got %v", err)
+	}
+	// the limit applies to each database
+	token, err := sqlsession.BeginSQL(ctx, newDB(t), time.Minute)
+	if err != nil {
+		t.Fatalf("unable to begin session on another database: %s", err)
+	}
+	if err := sqlsession.End(ctx, token, false); err != nil {
+		t.Fatalf("unable to end session: %s", err)
+	}
+
+	if err := sqlsession.End(ctx, tokens[0], false); err != nil {
+		t.Fatalf("unable to end session: %s", err)
+	}
+	token, err = sqlsession.BeginSQL(ctx, db, time.Minute)
+	if err != nil {
+		t.Fatalf("unable to begin session after ending one: %s", err)
+	}
+	for _, token := range append(tokens[1:], token) {
+		if err := sqlsession.End(ctx, token, false); err != nil {
+			t.Fatalf("unable to end session: %s", err)
+		}
+	}
+}
+
+func TestSessionTimeout(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+	token, err := sqlsession.BeginSQL(ctx, db, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unable to begin session: %s", err)
+	}
+	_, err = sqlsession.Run(ctx, token, db, func(tx *sql.Tx) (any, error) {
+		return tx.ExecContext(ctx, "INSERT INTO orders VALUES (1)")
+	})
+	if err != nil {
+		t.Fatalf("unable to run in session: %s", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := sqlsession.End(ctx, token, true); !errors.Is(err, sqlsession.ErrNotFound) {
+		t.Fatalf("expected an idle session to expire, got %v", err)
+	}
+	var n int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders").Scan(&n); err != nil {
+		t.Fatalf("unable to count orders: %s", err)
+	}
+	if n != 0 {
+		t.Fatalf("expected an expired session to be rolled back, got %d orders", n)
+	}
+}
+
+func TestToken(t *testing.T) {
+	params := tools.ParamValues{{Name: "sql", Value: "SELECT 1"}, {Name: sqlsession.ParameterName, Value: "1234", Sensitive: true}}
+	if got := sqlsession.Token(params); got != "1234" {
+		t.Fatalf("unexpected token: got %q, want %q", got, "1234")
+	}
+	if got := sqlsession.Token(params[:1]); got != "" {
+		t.Fatalf("unexpected token without a session: %q", got)
+	}
+	if !sqlsession.Parameter().GetSensitive() || sqlsession.Parameter().GetRequired() {
+		t.Fatalf("expected the session parameter to be sensitive and optional")
+	}
+}
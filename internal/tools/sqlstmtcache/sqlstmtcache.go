@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlstmtcache prepares the statements of SQL tools once and reuses
+// them across invocations, instead of the driver preparing, or parsing, each
+// statement again on every invocation. database/sql prepares a cached
+// statement on each connection of the pool the first time it runs there, and
+// again on a new connection if its connection is closed.
+package sqlstmtcache
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// MaxStatements is the number of statements a cache keeps. The statement of a
+// tool varies with its template parameters, so statements over the limit run
+// without being cached rather than preparing an unbounded number of them.
+const MaxStatements = 64
+
+// Querier runs queries on a pool, or with the statements of a cache.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+var _ Querier = &Cache{}
+
+// Cache is a cache of the prepared statements of a tool on a pool.
+type Cache struct {
+	db    *sql.DB
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+// New returns an empty cache for db.
+func New(db *sql.DB) *Cache {
+	return &Cache{db: db, stmts: make(map[string]*sql.Stmt)}
+}
+
+// QueryContext runs a query with the cached statement of query, which is
+// prepared if it isn't cached yet.
+func (c *Cache) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	stmt, err := c.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return c.db.QueryContext(ctx, query, args...)
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil && ctx.Err() == nil {
+		// the statement may be invalid, e.g. since a table it uses changed,
+		// so it is prepared again by the next invocation
+		c.evict(query, stmt)
+	}
+	return rows, err
+}
+
+// evict removes stmt from the cache and closes it.
+func (c *Cache) evict(query string, stmt *sql.Stmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stmts[query] == stmt {
+		delete(c.stmts, query)
+		stmt.Close()
+	}
+}
+
+// stmt returns the cached statement of query, or nil if the cache is full.
+func (c *Cache) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.mu.Lock()
+	stmt, ok := c.stmts[query]
+	full := len(c.stmts) >= MaxStatements
+	c.mu.Unlock()
+	if ok {
+		return stmt, nil
+	}
+	if full {
+		return nil, nil
+	}
+
+	// the statement is prepared without holding the lock, so invocations of
+	// other statements aren't blocked by a slow round trip
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.stmts[query]; ok {
+		// another invocation prepared it first
+		stmt.Close()
+		return cached, nil
+	}
+	if len(c.stmts) >= MaxStatements {
+		stmt.Close()
+		return nil, nil
+	}
+	c.stmts[query] = stmt
+	return stmt, nil
+}
+
+// Len returns the number of cached statements.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.stmts)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlstmtcache_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlstmtcache"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func newDB(t *testing.T) *sql.DB {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	// an in-memory database only lives as long as its connection
+	db.SetMaxOpenConns(1)
+	if _, err := db.ExecContext(ctx, "CREATE TABLE orders (id INTEGER); INSERT INTO orders VALUES (1), (2), (3)"); err != nil {
+		t.Fatalf("unable to create table: %s", err)
+	}
+	return db
+}
+
+func count(t *testing.T, q sqlstmtcache.Querier, query string, args ...any) int {
+	rows, err := q.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		t.Fatalf("unable to query: %s", err)
+	}
+	defer rows.Close()
+	n := 0
+	for rows.Next() {
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("unable to read rows: %s", err)
+	}
+	return n
+}
+
+func TestCache(t *testing.T) {
+	c := sqlstmtcache.New(newDB(t))
+
+	query := "SELECT id FROM orders WHERE id > ?"
+	if got := count(t, c, query, 1); got != 2 {
+		t.Fatalf("incorrect number of rows: got %d, want 2", got)
+	}
+	// the statement is reused with other arguments
+	if got := count(t, c, query, 2); got != 1 {
+		t.Fatalf("incorrect number of rows: got %d, want 1", got)
+	}
+	if c.Len() != 1 {
+		t.Fatalf("expected 1 cached statement, got %d", c.Len())
+	}
+
+	if _, err := c.QueryContext(context.Background(), "SELECT nope FROM"); err == nil {
+		t.Fatalf("expected error for an invalid statement")
+	}
+	if c.Len() != 1 {
+		t.Fatalf("an invalid statement must not be cached, got %d cached statements", c.Len())
+	}
+
+	// statements over the limit still run, without being cached
+	for i := 0; i < sqlstmtcache.MaxStatements+1; i++ {
+		if got := count(t, c, fmt.Sprintf("SELECT id FROM orders WHERE id > %d", i), nil...); got != max(3-i, 0) {
+			t.Fatalf("incorrect number of rows for statement %d: got %d", i, got)
+		}
+	}
+	if c.Len() != sqlstmtcache.MaxStatements {
+		t.Fatalf("expected %d cached statements, got %d", sqlstmtcache.MaxStatements, c.Len())
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltransaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const kind string = "sql-transaction"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type postgresSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+type mysqlSource interface {
+	MySQLPool() *sql.DB
+}
+
+type mssqlSource interface {
+	MSSQLDB() *sql.DB
+}
+
+type sqliteSource interface {
+	SQLiteDB() *sql.DB
+}
+
+// validate compatible sources are still compatible
+var _ postgresSource = &alloydbpg.Source{}
+var _ postgresSource = &cloudsqlpg.Source{}
+var _ postgresSource = &postgres.Source{}
+var _ mysqlSource = &cloudsqlmysql.Source{}
+var _ mysqlSource = &mysql.Source{}
+var _ mssqlSource = &cloudsqlmssql.Source{}
+var _ mssqlSource = &mssql.Source{}
+var _ sqliteSource = &sqlite.Source{}
+
+var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind, cloudsqlmysql.SourceKind, mysql.SourceKind, cloudsqlmssql.SourceKind, mssql.SourceKind, sqlite.SourceKind}
+
+// Statement is a statement of the transaction.
+type Statement struct {
+	Statement string `yaml:"statement" validate:"required"`
+	// Parameters are the names of the tool parameters whose values are bound,
+	// in order, to the placeholders of the statement.
+	Parameters []string `yaml:"parameters"`
+	// RequireRowsAffected rolls the transaction back if the statement
+	// affects no rows, e.g. if an UPDATE doesn't match any row.
+	RequireRowsAffected bool `yaml:"requireRowsAffected"`
+}
+
+type Config struct {
+	Name         string           `yaml:"name" validate:"required"`
+	Kind         string           `yaml:"kind" validate:"required"`
+	Source       string           `yaml:"source" validate:"required"`
+	Description  string           `yaml:"description" validate:"required"`
+	Statements   []Statement      `yaml:"statements" validate:"required,min=1,dive"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   cfg.Parameters,
+		Statements:   cfg.Statements,
+		AuthRequired: cfg.AuthRequired,
+	}
+	// verify the source is compatible
+	switch s := rawS.(type) {
+	case postgresSource:
+		t.Pool = s.PostgresPool()
+	case mysqlSource:
+		t.Db = s.MySQLPool()
+		t.implicitCommits = true
+	case mssqlSource:
+		t.Db = s.MSSQLDB()
+	case sqliteSource:
+		t.Db = s.SQLiteDB()
+	default:
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+
+	names := make(map[string]bool, len(cfg.Parameters))
+	for _, p := range cfg.Parameters {
+		names[p.GetName()] = true
+	}
+	for i, s := range cfg.Statements {
+		for _, name := range s.Parameters {
+			if !names[name] {
+				return nil, fmt.Errorf("statement %d of tool %q refers to unknown parameter %q", i+1, cfg.Name, name)
+			}
+		}
+	}
+
+	t.manifest = tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), AuthRequired: cfg.AuthRequired}
+	t.mcpManifest = tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: cfg.Parameters.McpManifest(),
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+var _ tools.DryRunner = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+	Statements   []Statement      `yaml:"statements"`
+
+	// Pool is the pool of PostgreSQL sources, and Db the database of the
+	// others.
+	Pool *pgxpool.Pool
+	Db   *sql.DB
+	// implicitCommits is true if DDL statements commit the transaction, as
+	// in MySQL, so that it can't be rolled back for dry runs
+	implicitCommits bool
+	manifest        tools.Manifest
+	mcpManifest     tools.McpManifest
+}
+
+// exec runs a statement in a transaction, and returns the number of rows it
+// affected.
+type exec func(ctx context.Context, statement string, args ...any) (int64, error)
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	return t.run(ctx, params, false)
+}
+
+// DryRun runs the statements in a transaction that is always rolled back.
+func (t Tool) DryRun(ctx context.Context, params tools.ParamValues) (any, error) {
+	if t.implicitCommits {
+		return nil, fmt.Errorf("transactions of MySQL sources can't be dry run, since DDL statements commit them implicitly")
+	}
+	return t.run(ctx, params, true)
+}
+
+// run runs the statements in a transaction, which is rolled back if any of
+// them fails. It returns the number of rows affected by each statement.
+func (t Tool) run(ctx context.Context, params tools.ParamValues, rollback bool) (any, error) {
+	paramsMap := params.AsMap()
+	statements := func(ctx context.Context, exec exec) ([]any, error) {
+		out := make([]any, 0, len(t.Statements))
+		for i, s := range t.Statements {
+			args := make([]any, len(s.Parameters))
+			for j, name := range s.Parameters {
+				args[j] = paramsMap[name]
+			}
+			n, err := exec(ctx, s.Statement, args...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to execute statement %d: %w", i+1, err)
+			}
+			if s.RequireRowsAffected && n == 0 {
+				return nil, fmt.Errorf("statement %d affected no rows, the transaction was rolled back", i+1)
+			}
+			out = append(out, map[string]any{"statement": i + 1, "rowsAffected": n})
+		}
+		return out, nil
+	}
+	if t.Pool != nil {
+		return runPostgres(ctx, t.Pool, rollback, statements)
+	}
+	return runSQL(ctx, t.Db, rollback, statements)
+}
+
+func runPostgres(ctx context.Context, pool *pgxpool.Pool, rollback bool, statements func(context.Context, exec) ([]any, error)) (any, error) {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	out, err := statements(ctx, func(ctx context.Context, statement string, args ...any) (int64, error) {
+		tag, err := tx.Exec(ctx, statement, args...)
+		return tag.RowsAffected(), err
+	})
+	if err != nil || rollback {
+		return out, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return out, nil
+}
+
+func runSQL(ctx context.Context, db *sql.DB, rollback bool, statements func(context.Context, exec) ([]any, error)) (any, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	out, err := statements(ctx, func(ctx context.Context, statement string, args ...any) (int64, error) {
+		res, err := tx.ExecContext(ctx, statement, args...)
+		if err != nil {
+			return 0, err
+		}
+		return res.RowsAffected()
+	})
+	if err != nil || rollback {
+		return out, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return out, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
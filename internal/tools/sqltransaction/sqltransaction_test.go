@@ -0,0 +1,206 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltransaction_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqltransaction"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestParseFromYamlSQLTransaction(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		place_order:
+			kind: sql-transaction
+			source: my-pg-instance
+			description: some description
+			parameters:
+				- name: item_id
+				  type: integer
+				  description: the item
+				- name: quantity
+				  type: integer
+				  description: how many to order
+			statements:
+				- statement: INSERT INTO orders (item_id, quantity) VALUES ($1, $2)
+				  parameters: [item_id, quantity]
+				- statement: UPDATE stock SET count = count - $2 WHERE item_id = $1 AND count >= $2
+				  parameters: [item_id, quantity]
+				  requireRowsAffected: true
+	`
+	want := server.ToolConfigs{
+		"place_order": sqltransaction.Config{
+			Name:         "place_order",
+			Kind:         "sql-transaction",
+			Source:       "my-pg-instance",
+			Description:  "some description",
+			AuthRequired: []string{},
+			Parameters: tools.Parameters{
+				tools.NewIntParameter("item_id", "the item"),
+				tools.NewIntParameter("quantity", "how many to order"),
+			},
+			Statements: []sqltransaction.Statement{
+				{
+					Statement:  "INSERT INTO orders (item_id, quantity) VALUES ($1, $2)",
+					Parameters: []string{"item_id", "quantity"},
+				},
+				{
+					Statement:           "UPDATE stock SET count = count - $2 WHERE item_id = $1 AND count >= $2",
+					Parameters:          []string{"item_id", "quantity"},
+					RequireRowsAffected: true,
+				},
+			},
+		},
+	}
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestFailParseFromYamlSQLTransaction(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		place_order:
+			kind: sql-transaction
+			source: my-pg-instance
+			description: some description
+			statements: []
+	`
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	err = yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got)
+	if err == nil || !strings.Contains(err.Error(), "Statements") {
+		t.Fatalf("expected an error for a transaction without statements, got %v", err)
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	ctx := context.Background()
+	src, err := sqlite.Config{Name: "my-instance", Kind: sqlite.SourceKind, Database: ":memory:"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE orders (item_id INTEGER, quantity INTEGER);
+		CREATE TABLE stock (item_id INTEGER, count INTEGER);
+		INSERT INTO stock VALUES (1, 5);`)
+	if err != nil {
+		t.Fatalf("unable to create tables: %s", err)
+	}
+	cfg := sqltransaction.Config{
+		Name:        "place_order",
+		Kind:        "sql-transaction",
+		Source:      "my-instance",
+		Description: "some description",
+		Parameters: tools.Parameters{
+			tools.NewIntParameter("item_id", "the item"),
+			tools.NewIntParameter("quantity", "how many to order"),
+		},
+		Statements: []sqltransaction.Statement{
+			{
+				Statement:  "INSERT INTO orders (item_id, quantity) VALUES (?, ?)",
+				Parameters: []string{"item_id", "quantity"},
+			},
+			{
+				Statement:           "UPDATE stock SET count = count - ? WHERE item_id = ? AND count >= ?",
+				Parameters:          []string{"quantity", "item_id", "quantity"},
+				RequireRowsAffected: true,
+			},
+		},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	invoke := func(quantity int) (any, error) {
+		params, err := tool.ParseParams(map[string]any{"item_id": 1, "quantity": quantity}, nil)
+		if err != nil {
+			t.Fatalf("unable to parse params: %s", err)
+		}
+		return tool.Invoke(ctx, params)
+	}
+	count := func(query string) int {
+		var n int
+		if err := db.QueryRowContext(ctx, query).Scan(&n); err != nil {
+			t.Fatalf("unable to query: %s", err)
+		}
+		return n
+	}
+
+	got, err := invoke(3)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	want := []any{
+		map[string]any{"statement": 1, "rowsAffected": int64(1)},
+		map[string]any{"statement": 2, "rowsAffected": int64(1)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	// there isn't enough stock left, so the order is rolled back
+	_, err = invoke(3)
+	if err == nil || err.Error() != "statement 2 affected no rows, the transaction was rolled back" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := count("SELECT COUNT(*) FROM orders"); n != 1 {
+		t.Fatalf("expected the failed order to be rolled back, got %d orders", n)
+	}
+
+	// a dry run is always rolled back
+	params, err := tool.ParseParams(map[string]any{"item_id": 1, "quantity": 1}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.(tools.DryRunner).DryRun(ctx, params); err != nil {
+		t.Fatalf("unable to dry run: %s", err)
+	}
+	if n := count("SELECT count FROM stock WHERE item_id = 1"); n != 2 {
+		t.Fatalf("expected the dry run to be rolled back, got a stock of %d", n)
+	}
+
+	cfg.Statements[0].Parameters = []string{"item_id", "missing"}
+	if _, err := cfg.Initialize(map[string]sources.Source{"my-instance": src}); err == nil {
+		t.Fatalf("expected an error for an unknown parameter")
+	}
+}
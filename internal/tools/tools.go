@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
@@ -44,6 +45,24 @@ func Register(kind string, factory ToolConfigFactory) bool {
 	return true
 }
 
+// ownTimeoutKinds are the kinds of tools whose configs have a timeout field of
+// their own.
+var ownTimeoutKinds = make(map[string]bool)
+
+// RegisterOwnTimeout declares that the configs of a kind of tool have a
+// timeout field of their own, such as how long a session stays open, which
+// replaces the timeout field shared by every kind of tool. It is called from
+// the init() function of the tool's package, with Register.
+func RegisterOwnTimeout(kind string) {
+	ownTimeoutKinds[kind] = true
+}
+
+// HasOwnTimeout reports whether the configs of a kind of tool have a timeout
+// field of their own, see RegisterOwnTimeout.
+func HasOwnTimeout(kind string) bool {
+	return ownTimeoutKinds[kind]
+}
+
 // DecodeConfig looks up the registered factory for the given kind and uses it
 // to decode the tool configuration.
 func DecodeConfig(ctx context.Context, kind string, name string, decoder *yaml.Decoder) (ToolConfig, error) {
@@ -71,6 +90,20 @@ type Tool interface {
 	Authorized([]string) bool
 }
 
+// DryRunner is implemented by tools that can preview an invocation without
+// making any changes, e.g. by rolling back a transaction, or by describing the
+// request that would be sent.
+type DryRunner interface {
+	DryRun(context.Context, ParamValues) (any, error)
+}
+
+// OwnTimeoutTool is implemented by tools that bound their invocations with a
+// timeout of their own, such as how long a job is waited for. Their
+// invocations are bounded by the smaller of it and the timeout of the server.
+type OwnTimeoutTool interface {
+	OwnTimeout() time.Duration
+}
+
 // Manifest is the representation of tools sent to Client SDKs.
 type Manifest struct {
 	Description  string              `json:"description"`
@@ -86,6 +119,27 @@ type McpManifest struct {
 	Description string `json:"description,omitempty"`
 	// A JSON Schema object defining the expected parameters for the tool.
 	InputSchema McpToolsSchema `json:"inputSchema,omitempty"`
+	// Optional hints describing the behavior of the tool.
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+}
+
+// ToolAnnotations are hints describing the behavior of a tool, which MCP
+// clients can use to decide whether a call needs to be confirmed. Unset hints
+// are omitted, so that clients apply the defaults of the MCP specification.
+type ToolAnnotations struct {
+	// A human-readable title for the tool.
+	Title string `yaml:"title" json:"title,omitempty"`
+	// If true, the tool does not modify its environment.
+	ReadOnlyHint *bool `yaml:"readOnlyHint" json:"readOnlyHint,omitempty"`
+	// If true, the tool may perform destructive updates to its environment.
+	// If false, the tool performs only additive updates.
+	DestructiveHint *bool `yaml:"destructiveHint" json:"destructiveHint,omitempty"`
+	// If true, calling the tool repeatedly with the same arguments has no
+	// additional effect on its environment.
+	IdempotentHint *bool `yaml:"idempotentHint" json:"idempotentHint,omitempty"`
+	// If true, the tool may interact with an "open world" of external
+	// entities.
+	OpenWorldHint *bool `yaml:"openWorldHint" json:"openWorldHint,omitempty"`
 }
 
 // Helper function that returns if a tool invocation request is authorized
@@ -16,18 +16,35 @@ package tools
 
 import (
 	"fmt"
+	"slices"
 )
 
 type ToolsetConfig struct {
 	Name      string   `yaml:"name"`
 	ToolNames []string `yaml:",inline"`
+	// AllowedAudiences restricts the toolset to callers whose verified token
+	// has one of the listed `aud` claims.
+	AllowedAudiences []string `yaml:"allowedAudiences"`
+	// AllowedSubjects restricts the toolset to callers whose verified token
+	// has one of the listed `sub` claims.
+	AllowedSubjects []string `yaml:"allowedSubjects"`
+	// AllowedClaims restricts the toolset to callers whose verified token
+	// has, for each listed claim, one of the listed values.
+	AllowedClaims map[string][]string `yaml:"allowedClaims"`
+	// SourceResources exposes the tables of the sources of the tools of the
+	// toolset as MCP resources.
+	SourceResources bool `yaml:"sourceResources"`
 }
 
 type Toolset struct {
-	Name        string          `yaml:"name"`
-	Tools       []*Tool         `yaml:",inline"`
-	Manifest    ToolsetManifest `yaml:",inline"`
-	McpManifest []McpManifest   `yaml:",inline"`
+	Name             string              `yaml:"name"`
+	Tools            []*Tool             `yaml:",inline"`
+	Manifest         ToolsetManifest     `yaml:",inline"`
+	McpManifest      []McpManifest       `yaml:",inline"`
+	AllowedAudiences []string            `yaml:"allowedAudiences"`
+	AllowedSubjects  []string            `yaml:"allowedSubjects"`
+	AllowedClaims    map[string][]string `yaml:"allowedClaims"`
+	SourceResources  bool                `yaml:"sourceResources"`
 }
 
 type ToolsetManifest struct {
@@ -41,8 +58,12 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	var toolset Toolset
 	toolset.Name = t.Name
 	if !IsValidName(toolset.Name) {
-		return toolset, fmt.Errorf("invalid toolset name: %s", t)
+		return toolset, fmt.Errorf("invalid toolset name: %s", t.Name)
 	}
+	toolset.AllowedAudiences = t.AllowedAudiences
+	toolset.AllowedSubjects = t.AllowedSubjects
+	toolset.AllowedClaims = t.AllowedClaims
+	toolset.SourceResources = t.SourceResources
 	toolset.Tools = make([]*Tool, len(t.ToolNames))
 	toolset.Manifest = ToolsetManifest{
 		ServerVersion: serverVersion,
@@ -51,7 +72,7 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	for _, toolName := range t.ToolNames {
 		tool, ok := toolsMap[toolName]
 		if !ok {
-			return toolset, fmt.Errorf("tool does not exist: %s", t)
+			return toolset, fmt.Errorf("tool %q of toolset %q does not exist", toolName, t.Name)
 		}
 		toolset.Tools = append(toolset.Tools, &tool)
 		toolset.Manifest.ToolsManifest[toolName] = tool.Manifest()
@@ -60,3 +81,67 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 
 	return toolset, nil
 }
+
+// Restricted returns true if the toolset declares an authorization policy.
+func (t Toolset) Restricted() bool {
+	return len(t.AllowedAudiences) > 0 || len(t.AllowedSubjects) > 0 || len(t.AllowedClaims) > 0
+}
+
+// Authorized checks if a caller may access the toolset, given the claims
+// from each auth service the caller was verified with. A toolset without a
+// policy is available to every caller. Otherwise, the claims from a single
+// auth service must satisfy every list the toolset declares.
+func (t Toolset) Authorized(claimsFromAuth map[string]map[string]any) bool {
+	if !t.Restricted() {
+		return true
+	}
+	for _, claims := range claimsFromAuth {
+		if len(t.AllowedAudiences) > 0 && !claimMatches(claims, "aud", t.AllowedAudiences) {
+			continue
+		}
+		if len(t.AllowedSubjects) > 0 && !claimMatches(claims, "sub", t.AllowedSubjects) {
+			continue
+		}
+		authorized := true
+		for name, allowed := range t.AllowedClaims {
+			if !claimMatches(claims, name, allowed) {
+				authorized = false
+				break
+			}
+		}
+		if authorized {
+			return true
+		}
+	}
+	return false
+}
+
+// claimMatches checks if a claim has one of the allowed values.
+func claimMatches(claims map[string]any, name string, allowed []string) bool {
+	return slices.ContainsFunc(claimValues(claims, name), func(v string) bool {
+		return slices.Contains(allowed, v)
+	})
+}
+
+// claimValues returns the values of a claim, which may either be a single
+// value, such as `sub`, or a list of values, such as `aud` or `groups`.
+// Values that aren't strings are formatted, so that boolean and numeric
+// claims can be matched too.
+func claimValues(claims map[string]any, name string) []string {
+	switch v := claims[name].(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
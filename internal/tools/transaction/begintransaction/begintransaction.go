@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package begintransaction
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const kind string = "begin-transaction"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+	tools.RegisterOwnTimeout(kind)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type postgresSource interface {
+	PostgresPool() *pgxpool.Pool
+}
+
+type mysqlSource interface {
+	MySQLPool() *sql.DB
+}
+
+// validate compatible sources are still compatible
+var _ postgresSource = &alloydbpg.Source{}
+var _ postgresSource = &cloudsqlpg.Source{}
+var _ postgresSource = &postgres.Source{}
+var _ mysqlSource = &cloudsqlmysql.Source{}
+var _ mysqlSource = &mysql.Source{}
+
+var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind, cloudsqlmysql.SourceKind, mysql.SourceKind}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	// Timeout is how long the session stays open without being used before
+	// it is rolled back. Defaults to 5m.
+	Timeout      string   `yaml:"timeout"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		AuthRequired: cfg.AuthRequired,
+		Timeout:      sqlsession.DefaultTimeout,
+	}
+	// verify the source is compatible
+	switch s := rawS.(type) {
+	case postgresSource:
+		t.Pool = s.PostgresPool()
+	case mysqlSource:
+		t.Db = s.MySQLPool()
+	default:
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", kind, compatibleSources)
+	}
+	if cfg.Timeout != "" {
+		timeout, err := time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse timeout as time.Duration: %w", err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout must be greater than 0, got %s", timeout)
+		}
+		t.Timeout = timeout
+	}
+
+	parameters := tools.Parameters{}
+	t.manifest = tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired}
+	t.mcpManifest = tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string   `yaml:"name"`
+	Kind         string   `yaml:"kind"`
+	AuthRequired []string `yaml:"authRequired"`
+
+	// Pool is the pool of PostgreSQL sources, and Db the database of MySQL
+	// sources.
+	Pool        *pgxpool.Pool
+	Db          *sql.DB
+	Timeout     time.Duration
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	var token string
+	var err error
+	if t.Pool != nil {
+		token, err = sqlsession.BeginPostgres(ctx, t.Pool, t.Timeout)
+	} else {
+		token, err = sqlsession.BeginSQL(ctx, t.Db, t.Timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"session": token,
+		"message": fmt.Sprintf("Pass the session to the tools that run statements in the transaction, then commit or roll it back. It is rolled back if it isn't used for %s.", t.Timeout),
+	}, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(tools.Parameters{}, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package begintransaction_test
+
+import (
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/transaction/begintransaction"
+)
+
+func TestParseFromYamlBeginTransaction(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		begin_order:
+			kind: begin-transaction
+			source: my-pg-instance
+			description: some description
+			timeout: 1m
+	`
+	want := server.ToolConfigs{
+		"begin_order": begintransaction.Config{
+			Name:         "begin_order",
+			Kind:         "begin-transaction",
+			Source:       "my-pg-instance",
+			Description:  "some description",
+			Timeout:      "1m",
+			AuthRequired: []string{},
+		},
+	}
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestInitializeErrors(t *testing.T) {
+	tcs := []struct {
+		desc string
+		cfg  begintransaction.Config
+		want string
+	}{
+		{
+			desc: "missing source",
+			cfg:  begintransaction.Config{Name: "begin", Source: "missing"},
+			want: `no source named "missing" configured`,
+		},
+		{
+			desc: "incompatible source",
+			cfg:  begintransaction.Config{Name: "begin", Source: "my-source"},
+			want: "invalid source",
+		},
+	}
+	srcs := map[string]sources.Source{"my-source": nil}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.cfg.Initialize(srcs)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package committransaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlsession"
+	"github.com/googleapis/genai-toolbox/internal/tools/transaction/committransaction"
+)
+
+func TestParseFromYamlCommitTransaction(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	tools:
+		commit_order:
+			kind: commit-transaction
+			description: some description
+	`
+	want := server.ToolConfigs{
+		"commit_order": committransaction.Config{
+			Name:         "commit_order",
+			Kind:         "commit-transaction",
+			Description:  "some description",
+			AuthRequired: []string{},
+		},
+	}
+	got := struct {
+		Tools server.ToolConfigs `yaml:"tools"`
+	}{}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &got); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if diff := cmp.Diff(want, got.Tools); diff != "" {
+		t.Fatalf("incorrect parse: diff %v", diff)
+	}
+}
+
+func TestInvokeUnknownSession(t *testing.T) {
+	tool, err := committransaction.Config{Name: "commit_order", Kind: "commit-transaction", Description: "some description"}.Initialize(nil)
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"session": "unknown"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(context.Background(), params); !errors.Is(err, sqlsession.ErrNotFound) {
+		t.Fatalf("expected an unknown session to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"time"
+)
+
+// The values of database results are returned the same way by every kind of
+// tool, whatever their driver reads them as:
+//   - exact numbers, such as NUMERIC, DECIMAL and BIGNUMERIC values, are
+//     Decimals
+//   - timestamps with a time zone are RFC 3339 strings with their offset, see
+//     Timestamp
+//   - dates, and timestamps without a time zone, are ISO 8601 strings without
+//     an offset, see Date and DateTime
+//   - bytes are base64 strings, see Bytes
+
+const (
+	// DateLayout is the layout of dates in results.
+	DateLayout = "2006-01-02"
+	// DateTimeLayout is the layout of timestamps without a time zone in
+	// results.
+	DateTimeLayout = "2006-01-02T15:04:05.999999999"
+)
+
+// Decimal is an exact number, as its decimal string. It is encoded with a type
+// tag, rather than as a JSON number that clients would parse as a float and
+// lose precision:
+//
+//	{"type": "decimal", "value": "12345.678901234567890"}
+type Decimal string
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}{Type: "decimal", Value: string(d)})
+}
+
+// DecimalFromRat returns the exact decimal of a number read as a fraction.
+// Fractions without an exact decimal, which databases don't return, are
+// rounded to 38 digits after the decimal point.
+func DecimalFromRat(r *big.Rat) Decimal {
+	// a fraction has an exact decimal if its denominator is 2^a * 5^b, which
+	// takes max(a, b) digits after the decimal point
+	denom := new(big.Int).Set(r.Denom())
+	var twos, fives int
+	two, five, rem := big.NewInt(2), big.NewInt(5), new(big.Int)
+	for denom.Cmp(big.NewInt(1)) > 0 {
+		if new(big.Int).QuoRem(denom, two, rem); rem.Sign() == 0 {
+			denom.Quo(denom, two)
+			twos++
+		} else if new(big.Int).QuoRem(denom, five, rem); rem.Sign() == 0 {
+			denom.Quo(denom, five)
+			fives++
+		} else {
+			return Decimal(r.FloatString(38))
+		}
+	}
+	return Decimal(r.FloatString(max(twos, fives)))
+}
+
+// Timestamp returns a timestamp with a time zone as it is returned in results.
+func Timestamp(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// DateTime returns a timestamp without a time zone as it is returned in
+// results. The location of t is ignored.
+func DateTime(t time.Time) string {
+	return t.Format(DateTimeLayout)
+}
+
+// Date returns a date as it is returned in results.
+func Date(t time.Time) string {
+	return t.Format(DateLayout)
+}
+
+// Bytes returns bytes as they are returned in results.
+func Bytes(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
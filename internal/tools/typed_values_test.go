@@ -0,0 +1,73 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestDecimalMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(map[string]any{"price": tools.Decimal("12345.678901234567890")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `{"price":{"type":"decimal","value":"12345.678901234567890"}}`; string(b) != want {
+		t.Fatalf("incorrect JSON: got %s, want %s", b, want)
+	}
+}
+
+func TestDecimalFromRat(t *testing.T) {
+	for in, want := range map[string]tools.Decimal{
+		"1/8":       "0.125",
+		"12345/100": "123.45",
+		"5":         "5",
+		"-1/4":      "-0.25",
+		"123456789012345678901234567890.123456789": "123456789012345678901234567890.123456789",
+	} {
+		r, ok := new(big.Rat).SetString(in)
+		if !ok {
+			t.Fatalf("invalid fraction %q", in)
+		}
+		if got := tools.DecimalFromRat(r); got != want {
+			t.Fatalf("incorrect decimal of %s: got %s, want %s", in, got, want)
+		}
+	}
+	// fractions without an exact decimal are rounded
+	if got := tools.DecimalFromRat(big.NewRat(1, 3)); got != tools.Decimal("0."+strings.Repeat("3", 38)) {
+		t.Fatalf("incorrect decimal of 1/3: got %s", got)
+	}
+}
+
+func TestTemporalValues(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 600000000, time.FixedZone("", -8*60*60))
+	if got, want := tools.Timestamp(ts), "2025-01-02T03:04:05.6-08:00"; got != want {
+		t.Fatalf("incorrect timestamp: got %s, want %s", got, want)
+	}
+	if got, want := tools.DateTime(ts), "2025-01-02T03:04:05.6"; got != want {
+		t.Fatalf("incorrect date time: got %s, want %s", got, want)
+	}
+	if got, want := tools.Date(ts), "2025-01-02"; got != want {
+		t.Fatalf("incorrect date: got %s, want %s", got, want)
+	}
+	if got, want := tools.Bytes([]byte("hi")), "aGk="; got != want {
+		t.Fatalf("incorrect bytes: got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vectorcommon holds the parameters and the embedding generation
+// shared by the tools that search or store embeddings.
+package vectorcommon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/embeddings"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// DefaultMaxLimit is the maximum number of results that the agent may ask
+// for, if maxLimit isn't set.
+const DefaultMaxLimit = 100
+
+const (
+	EmbeddingParameterName = "embedding"
+	TextParameterName      = "text"
+	LimitParameterName     = "limit"
+)
+
+// Embedder generates the embeddings of texts.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float64, error)
+}
+
+// validate embedding sources are still compatible
+var _ Embedder = &embeddings.Source{}
+
+// NewEmbedder returns the embedding source named name, or nil if name is
+// empty.
+func NewEmbedder(kind string, srcs map[string]sources.Source, name string) (Embedder, error) {
+	if name == "" {
+		return nil, nil
+	}
+	rawE, ok := srcs[name]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", name)
+	}
+	e, ok := rawE.(Embedder)
+	if !ok {
+		return nil, fmt.Errorf("invalid embedding source for %q tool: source kind must be %q", kind, embeddings.SourceKind)
+	}
+	return e, nil
+}
+
+// NewEmbeddingParameter returns the parameter of the embedding of a tool: a
+// text, whose embedding is generated by the embedder, or else an array of
+// numbers with the given number of dimensions, or any if it is 0.
+func NewEmbeddingParameter(e Embedder, dimensions int, embeddingDesc, textDesc string) tools.Parameter {
+	if e != nil {
+		return tools.NewStringParameter(TextParameterName, textDesc)
+	}
+	p := tools.NewArrayParameter(EmbeddingParameterName, embeddingDesc, tools.NewFloatParameter("value", "A dimension of the embedding."))
+	minItems := 1
+	p.MinItems = &minItems
+	if dimensions > 0 {
+		p.MinItems, p.MaxItems = &dimensions, &dimensions
+	}
+	return p
+}
+
+// NewLimitParameter returns the parameter of the number of results of a
+// search, which defaults to 10 and is at most maxLimit, or DefaultMaxLimit if
+// it is 0.
+func NewLimitParameter(maxLimit int, desc string) *tools.IntParameter {
+	if maxLimit == 0 {
+		maxLimit = DefaultMaxLimit
+	}
+	p := tools.NewIntParameterWithDefault(LimitParameterName, min(10, maxLimit), desc)
+	minimum, maximum := 1.0, float64(maxLimit)
+	p.Minimum, p.Maximum = &minimum, &maximum
+	return p
+}
+
+// CheckReserved returns an error if any of the parameters has one of the
+// names that the tool adds to its parameters.
+func CheckReserved(kind string, params tools.Parameters, names ...string) error {
+	for _, p := range params {
+		for _, name := range names {
+			if p.GetName() == name {
+				return fmt.Errorf("parameter name %q is reserved by tool %q", name, kind)
+			}
+		}
+	}
+	return nil
+}
+
+// Embedding returns the value of the embedding parameter, or generates the
+// embedding of the value of the text parameter if e is set.
+func Embedding(ctx context.Context, e Embedder, dimensions int, paramsMap map[string]any) ([]float64, error) {
+	if e == nil {
+		values, ok := paramsMap[EmbeddingParameterName].([]any)
+		if !ok {
+			return nil, fmt.Errorf("unable to get cast %s", paramsMap[EmbeddingParameterName])
+		}
+		embedding := make([]float64, len(values))
+		for i, value := range values {
+			if embedding[i], ok = value.(float64); !ok {
+				return nil, fmt.Errorf("unable to cast element #%d of embedding: %v", i, value)
+			}
+		}
+		return embedding, nil
+	}
+
+	text, ok := paramsMap[TextParameterName].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to get cast %s", paramsMap[TextParameterName])
+	}
+	embedding, err := e.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	if dimensions > 0 && len(embedding) != dimensions {
+		return nil, fmt.Errorf("embedding source returned %d dimensions, want %d", len(embedding), dimensions)
+	}
+	return embedding, nil
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviatequery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/weaviate"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/vectorcommon"
+)
+
+const kind string = "weaviate-query"
+
+// name matches the GraphQL names of collections and properties.
+var name = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+type Config struct {
+	Name        string `yaml:"name" validate:"required"`
+	Kind        string `yaml:"kind" validate:"required"`
+	Source      string `yaml:"source" validate:"required"`
+	Description string `yaml:"description" validate:"required"`
+	Collection  string `yaml:"collection" validate:"required"`
+	// Properties are the properties of the objects to return.
+	Properties []string `yaml:"properties" validate:"required"`
+	// VectorName is the name of the vector to search, for collections with
+	// named vectors.
+	VectorName      string   `yaml:"vectorName"`
+	Dimensions      int      `yaml:"dimensions" validate:"gte=0"`
+	EmbeddingSource string   `yaml:"embeddingSource"`
+	MaxLimit        int      `yaml:"maxLimit" validate:"gte=0"`
+	AuthRequired    []string `yaml:"authRequired"`
+	// Parameters are properties that the objects must be equal to.
+	Parameters tools.Parameters `yaml:"parameters"`
+}
+
+// validate interface
+var _ tools.ToolConfig = Config{}
+
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	// verify source exists
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	// verify the source is compatible
+	s, ok := rawS.(*weaviate.Source)
+	if !ok {
+		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `%s`", kind, weaviate.SourceKind)
+	}
+
+	// the names are part of the text of the GraphQL query
+	names := append([]string{cfg.Collection}, cfg.Properties...)
+	if cfg.VectorName != "" {
+		names = append(names, cfg.VectorName)
+	}
+	for _, p := range cfg.Parameters {
+		names = append(names, p.GetName())
+	}
+	for _, n := range names {
+		if !name.MatchString(n) {
+			return nil, fmt.Errorf("%q must be a GraphQL name, e.g. Article", n)
+		}
+	}
+	if err := vectorcommon.CheckReserved(kind, cfg.Parameters, vectorcommon.EmbeddingParameterName, vectorcommon.TextParameterName, vectorcommon.LimitParameterName); err != nil {
+		return nil, err
+	}
+	embedder, err := vectorcommon.NewEmbedder(kind, srcs, cfg.EmbeddingSource)
+	if err != nil {
+		return nil, err
+	}
+	embeddingParameter := vectorcommon.NewEmbeddingParameter(embedder, cfg.Dimensions, "The embedding to find the nearest objects to.", "The text to find the nearest objects to, by the similarity of their meaning.")
+	limitParameter := vectorcommon.NewLimitParameter(cfg.MaxLimit, "Number of nearest objects to return.")
+	parameters := append(slices.Clone(cfg.Parameters), embeddingParameter, limitParameter)
+
+	mcpManifest := tools.McpManifest{
+		Name:        cfg.Name,
+		Description: cfg.Description,
+		InputSchema: parameters.McpManifest(),
+	}
+
+	// finish tool setup
+	t := Tool{
+		Name:         cfg.Name,
+		Kind:         kind,
+		Parameters:   parameters,
+		AuthRequired: cfg.AuthRequired,
+		Source:       s,
+		Collection:   cfg.Collection,
+		Properties:   cfg.Properties,
+		VectorName:   cfg.VectorName,
+		Dimensions:   cfg.Dimensions,
+		Embedder:     embedder,
+		Filters:      cfg.Parameters,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: parameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:  mcpManifest,
+	}
+	return t, nil
+}
+
+// validate interface
+var _ tools.Tool = Tool{}
+
+type Tool struct {
+	Name         string           `yaml:"name"`
+	Kind         string           `yaml:"kind"`
+	AuthRequired []string         `yaml:"authRequired"`
+	Parameters   tools.Parameters `yaml:"parameters"`
+
+	Source      *weaviate.Source
+	Collection  string
+	Properties  []string
+	VectorName  string
+	Dimensions  int
+	Embedder    vectorcommon.Embedder
+	Filters     tools.Parameters
+	manifest    tools.Manifest
+	mcpManifest tools.McpManifest
+}
+
+// Invoke returns the objects nearest to the embedding whose properties match
+// the filters, nearest first, along with their id and distance.
+func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) (any, error) {
+	paramsMap := params.AsMap()
+	embedding, err := vectorcommon.Embedding(ctx, t.Embedder, t.Dimensions, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+	query, err := t.query(embedding, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.Source.Do(ctx, http.MethodPost, "/v1/graphql", map[string]any{"query": query})
+	if err != nil {
+		return nil, err
+	}
+	resMap, _ := res.(map[string]any)
+	// GraphQL errors are returned with a 200 status code
+	if errs, ok := resMap["errors"].([]any); ok && len(errs) > 0 {
+		return nil, fmt.Errorf("unable to execute query: %v", errs[0])
+	}
+	data, _ := resMap["data"].(map[string]any)
+	get, _ := data["Get"].(map[string]any)
+	objects, _ := get[t.Collection].([]any)
+	rows := make([]any, 0, len(objects))
+	for _, o := range objects {
+		object, ok := o.(map[string]any)
+		if !ok {
+			continue
+		}
+		row := make(map[string]any, len(object)+1)
+		for k, v := range object {
+			row[k] = v
+		}
+		delete(row, "_additional")
+		if additional, ok := object["_additional"].(map[string]any); ok {
+			row["id"], row["distance"] = additional["id"], additional["distance"]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// query returns the GraphQL query of the objects nearest to the embedding.
+// The values are encoded as JSON, which is valid GraphQL for numbers, strings
+// and booleans.
+func (t Tool) query(embedding []float64, paramsMap map[string]any) (string, error) {
+	vector, err := json.Marshal(embedding)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal embedding: %w", err)
+	}
+	nearVector := fmt.Sprintf("vector: %s", vector)
+	if t.VectorName != "" {
+		nearVector += fmt.Sprintf(", targetVectors: [%q]", t.VectorName)
+	}
+	args := fmt.Sprintf("nearVector: {%s}, limit: %d", nearVector, paramsMap[vectorcommon.LimitParameterName])
+
+	var operands []string
+	for _, p := range t.Filters {
+		// optional filters without a value match any object
+		v := paramsMap[p.GetName()]
+		if v == nil {
+			continue
+		}
+		var field string
+		switch v.(type) {
+		case string:
+			field = "valueText"
+		case int:
+			field = "valueInt"
+		case float64:
+			field = "valueNumber"
+		case bool:
+			field = "valueBoolean"
+		default:
+			return "", fmt.Errorf("parameter %q of type %T can't be a filter", p.GetName(), v)
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("unable to marshal parameter %q: %w", p.GetName(), err)
+		}
+		operands = append(operands, fmt.Sprintf("{path: [%q], operator: Equal, %s: %s}", p.GetName(), field, value))
+	}
+	if len(operands) > 0 {
+		args += fmt.Sprintf(", where: {operator: And, operands: [%s]}", strings.Join(operands, ", "))
+	}
+	return fmt.Sprintf("{Get {%s(%s) {%s _additional {id distance}}}}", t.Collection, args, strings.Join(t.Properties, " ")), nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
+	return tools.ParseParams(t.Parameters, data, claims)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviatequery_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/vectorstore"
+	"github.com/googleapis/genai-toolbox/internal/sources/weaviate"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/weaviate/weaviatequery"
+)
+
+func TestParseFromYamlWeaviateQuery(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: weaviate-query
+					source: my-weaviate-instance
+					description: some description
+					collection: Document
+					properties: [title, content]
+					embeddingSource: my-embeddings
+					maxLimit: 20
+					parameters:
+						- name: language
+						  type: string
+						  description: Language of the documents.
+			`,
+			want: server.ToolConfigs{
+				"example_tool": weaviatequery.Config{
+					Name:            "example_tool",
+					Kind:            "weaviate-query",
+					Source:          "my-weaviate-instance",
+					Description:     "some description",
+					Collection:      "Document",
+					Properties:      []string{"title", "content"},
+					EmbeddingSource: "my-embeddings",
+					MaxLimit:        20,
+					AuthRequired:    []string{},
+					Parameters:      tools.Parameters{tools.NewStringParameter("language", "Language of the documents.")},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestInitializeNames(t *testing.T) {
+	cfg := weaviatequery.Config{
+		Name:        "example_tool",
+		Kind:        "weaviate-query",
+		Source:      "my-weaviate-instance",
+		Description: "some description",
+		Collection:  "Document",
+		Properties:  []string{"title) { id }"},
+	}
+	srcs := map[string]sources.Source{"my-weaviate-instance": &weaviate.Source{}}
+	if _, err := cfg.Initialize(srcs); err == nil || !strings.Contains(err.Error(), "must be a GraphQL name") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/graphql" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		_, _ = w.Write([]byte(`{"data": {"Get": {"Document": [{"title": "Intro", "_additional": {"id": "7f2c2d3e-9d56-4a43-8b3c-1c1c8f1f0a11", "distance": 0.1}}]}}}`))
+	}))
+	defer srv.Close()
+
+	cfg := weaviatequery.Config{
+		Name:        "example_tool",
+		Kind:        "weaviate-query",
+		Source:      "my-weaviate-instance",
+		Description: "some description",
+		Collection:  "Document",
+		Properties:  []string{"title"},
+		Dimensions:  2,
+		Parameters:  tools.Parameters{tools.NewStringParameterWithRequired("language", "Language of the documents.", false)},
+	}
+	s := &weaviate.Source{Name: "my-weaviate-instance", Kind: weaviate.SourceKind, Client: &vectorstore.Client{URL: srv.URL, HTTPClient: srv.Client()}}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-weaviate-instance": s})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"embedding": []any{0.1, 0.2}, "language": "en", "limit": 3}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	res, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantReq := map[string]any{
+		"query": `{Get {Document(nearVector: {vector: [0.1,0.2]}, limit: 3, where: {operator: And, operands: [{path: ["language"], operator: Equal, valueText: "en"}]}) {title _additional {id distance}}}}`,
+	}
+	if diff := cmp.Diff(wantReq, got); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+	want := []any{map[string]any{"id": "7f2c2d3e-9d56-4a43-8b3c-1c1c8f1f0a11", "distance": 0.1, "title": "Intro"}}
+	if diff := cmp.Diff(want, res); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	// an optional filter without a value isn't sent
+	params, err = tool.ParseParams(map[string]any{"embedding": []any{0.1, 0.2}}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(ctx, params); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `{Get {Document(nearVector: {vector: [0.1,0.2]}, limit: 10) {title _additional {id distance}}}}`; got["query"] != want {
+		t.Fatalf("incorrect query: got %q, want %q", got["query"], want)
+	}
+}
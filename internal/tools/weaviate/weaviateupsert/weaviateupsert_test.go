@@ -0,0 +1,169 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaviateupsert_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/vectorstore"
+	"github.com/googleapis/genai-toolbox/internal/sources/weaviate"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/weaviate/weaviateupsert"
+)
+
+func TestParseFromYamlWeaviateUpsert(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: weaviate-upsert
+					source: my-weaviate-instance
+					description: some description
+					collection: Document
+					embeddingSource: my-embeddings
+					textField: content
+			`,
+			want: server.ToolConfigs{
+				"example_tool": weaviateupsert.Config{
+					Name:            "example_tool",
+					Kind:            "weaviate-upsert",
+					Source:          "my-weaviate-instance",
+					Description:     "some description",
+					Collection:      "Document",
+					EmbeddingSource: "my-embeddings",
+					TextField:       "content",
+					AuthRequired:    []string{},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			// Parse contents
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) SourceKind() string {
+	return "embeddings"
+}
+
+func (fakeEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	return []float64{0.5, 0.25}, nil
+}
+
+func TestInvoke(t *testing.T) {
+	var got map[string]any
+	resp := `[{"class": "Document", "id": "7f2c2d3e-9d56-4a43-8b3c-1c1c8f1f0a11", "result": {}}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/batch/objects" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("unable to decode request: %s", err)
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+
+	s := &weaviate.Source{Name: "my-weaviate-instance", Kind: weaviate.SourceKind, Client: &vectorstore.Client{URL: srv.URL, HTTPClient: srv.Client()}}
+	srcs := map[string]sources.Source{"my-weaviate-instance": s, "my-embeddings": fakeEmbedder{}}
+	cfg := weaviateupsert.Config{
+		Name:            "example_tool",
+		Kind:            "weaviate-upsert",
+		Source:          "my-weaviate-instance",
+		Description:     "some description",
+		Collection:      "Document",
+		EmbeddingSource: "my-embeddings",
+		TextField:       "content",
+		Parameters:      tools.Parameters{tools.NewStringParameter("title", "Title of the document.")},
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"id": "7f2c2d3e-9d56-4a43-8b3c-1c1c8f1f0a11", "text": "Toolbox is an MCP server.", "title": "Intro"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	res, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantReq := map[string]any{
+		"objects": []any{map[string]any{
+			"class":      "Document",
+			"id":         "7f2c2d3e-9d56-4a43-8b3c-1c1c8f1f0a11",
+			"vector":     []any{0.5, 0.25},
+			"properties": map[string]any{"title": "Intro", "content": "Toolbox is an MCP server."},
+		}},
+	}
+	if diff := cmp.Diff(wantReq, got); diff != "" {
+		t.Fatalf("incorrect request: diff %v", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"id": "7f2c2d3e-9d56-4a43-8b3c-1c1c8f1f0a11"}, res); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	// the errors of the objects of a batch are returned with a 200 status code
+	resp = `[{"class": "Document", "result": {"errors": {"error": [{"message": "id must be a valid uuid"}]}}}]`
+	if _, err := tool.Invoke(ctx, params); err == nil || err.Error() != "unable to upsert object: id must be a valid uuid" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the text can only be stored if the embedding is generated from it
+	cfg.EmbeddingSource = ""
+	if _, err := cfg.Initialize(srcs); err == nil || !strings.Contains(err.Error(), "textField requires an embeddingSource") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry implements a configurable retry policy for HTTP requests, and
+// the polling of long-running operations.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConnectionError is the retry condition for requests that fail before a
+// response is received (e.g. connection refused or reset).
+const ConnectionError Condition = "connection-error"
+
+const (
+	defaultInitialBackoff = 1 * time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	defaultMultiplier     = 2.0
+	// backoffJitter is the fraction by which backoff delays are randomized
+	backoffJitter = 0.5
+)
+
+// DefaultRetryOn are the conditions retried if `retryOn` is not set.
+var DefaultRetryOn = []Condition{"429", "502", "503", "504", ConnectionError}
+
+// Condition is a response status code (e.g. "503"), or "connection-error".
+type Condition string
+
+func (c *Condition) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var condition string
+	if err := unmarshal(&condition); err != nil {
+		return fmt.Errorf(`error unmarshalling retry condition: %s`, err)
+	}
+	if Condition(condition) != ConnectionError {
+		code, err := strconv.Atoi(condition)
+		if err != nil || code < 100 || code > 599 {
+			return fmt.Errorf(`%q is not a valid retry condition: must be a status code or %q`, condition, ConnectionError)
+		}
+	}
+	*c = Condition(condition)
+	return nil
+}
+
+// Config is the YAML configuration of a retry policy.
+type Config struct {
+	MaxRetries     int         `yaml:"maxRetries" validate:"gte=0"`
+	RetryOn        []Condition `yaml:"retryOn"`
+	InitialBackoff string      `yaml:"initialBackoff"`
+	MaxBackoff     string      `yaml:"maxBackoff"`
+	Multiplier     float64     `yaml:"multiplier"`
+}
+
+// Policy creates the retry policy described by the config.
+func (cfg Config) Policy() (Policy, error) {
+	p := Policy{
+		MaxRetries:     cfg.MaxRetries,
+		RetryOn:        cfg.RetryOn,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		Multiplier:     cfg.Multiplier,
+	}
+	if len(p.RetryOn) == 0 {
+		p.RetryOn = DefaultRetryOn
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = defaultMultiplier
+	}
+	if p.Multiplier < 1 {
+		return Policy{}, fmt.Errorf("retry multiplier must be at least 1, got %v", p.Multiplier)
+	}
+	var err error
+	if cfg.InitialBackoff != "" {
+		p.InitialBackoff, err = time.ParseDuration(cfg.InitialBackoff)
+		if err != nil {
+			return Policy{}, fmt.Errorf("unable to parse initialBackoff as time.Duration: %w", err)
+		}
+	}
+	if cfg.MaxBackoff != "" {
+		p.MaxBackoff, err = time.ParseDuration(cfg.MaxBackoff)
+		if err != nil {
+			return Policy{}, fmt.Errorf("unable to parse maxBackoff as time.Duration: %w", err)
+		}
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		return Policy{}, fmt.Errorf("retry maxBackoff (%s) must not be less than initialBackoff (%s)", p.MaxBackoff, p.InitialBackoff)
+	}
+	return p, nil
+}
+
+// Policy decides whether, and after how long, a failed request is retried.
+// The zero value never retries.
+type Policy struct {
+	MaxRetries     int
+	RetryOn        []Condition
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// retryable reports whether a request that failed with the status code (or
+// with a connection error, if statusCode is 0) should be retried.
+func (p Policy) retryable(statusCode int) bool {
+	if statusCode == 0 {
+		return slices.Contains(p.RetryOn, ConnectionError)
+	}
+	return slices.Contains(p.RetryOn, Condition(strconv.Itoa(statusCode)))
+}
+
+// Backoff returns the delay before the given retry attempt, starting at 1,
+// with jitter applied.
+func (p Policy) Backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt && d < float64(p.MaxBackoff); i++ {
+		d *= p.Multiplier
+	}
+	return Jitter(min(time.Duration(d), p.MaxBackoff), backoffJitter)
+}
+
+// Do sends the request created by newRequest, and retries it according to the
+// policy. A new request is created for every attempt so that the request body
+// can be sent again. The response of the last attempt is returned.
+func (p Policy) Do(ctx context.Context, client *http.Client, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		var delay time.Duration
+		resp, err := client.Do(req)
+		switch {
+		case err != nil:
+			if attempt > p.MaxRetries || ctx.Err() != nil || !p.retryable(0) {
+				return nil, err
+			}
+			delay = p.Backoff(attempt)
+		case attempt <= p.MaxRetries && p.retryable(resp.StatusCode):
+			var ok bool
+			delay, ok = RetryAfter(resp.Header, time.Now())
+			if !ok {
+				delay = p.Backoff(attempt)
+			}
+			// a server asking to wait for longer doesn't stall the request
+			// beyond the maximum backoff
+			delay = min(delay, p.MaxBackoff)
+			// drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("error waiting to retry request: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// PollFunc checks the state of a long-running operation once. It returns the
+// result and done if the operation reached a terminal state, and otherwise how
+// long to wait before checking it again.
+type PollFunc func(ctx context.Context, attempt int) (result any, delay time.Duration, done bool, err error)
+
+// Poll calls poll until the operation reaches a terminal state, poll returns an
+// error, or ctx is done. Callers bound the polling with a deadline on ctx.
+func Poll(ctx context.Context, poll PollFunc) (any, error) {
+	for attempt := 1; ; attempt++ {
+		result, delay, done, err := poll(ctx, attempt)
+		if done || err != nil {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("polling timed out after %d attempt(s): %w", attempt, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Jitter randomizes d by up to the given fraction of d in either direction, so
+// that clients retrying at the same time spread out their requests.
+func Jitter(d time.Duration, fraction float64) time.Duration {
+	if d <= 0 || fraction <= 0 {
+		return d
+	}
+	delta := float64(d) * fraction
+	return time.Duration(float64(d) - delta + rand.Float64()*2*delta)
+}
+
+// RetryAfter returns how long the server asked the client to wait before
+// sending another request, based on the `Retry-After` header or, if it is not
+// set, the `X-RateLimit-Reset` header.
+func RetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	if v := strings.TrimSpace(header.Get("Retry-After")); v != "" {
+		// Retry-After is either a number of seconds or an HTTP date
+		if secs, err := strconv.Atoi(v); err == nil {
+			return seconds(float64(secs)), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
+	}
+	if v := strings.TrimSpace(header.Get("X-RateLimit-Reset")); v != "" {
+		secs, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		// X-RateLimit-Reset is either a unix timestamp, or a number of
+		// seconds for APIs that report the time until the limit resets
+		if secs > 1e9 {
+			reset := time.Unix(0, int64(secs*float64(time.Second)))
+			return max(reset.Sub(now), 0), true
+		}
+		return seconds(secs), true
+	}
+	return 0, false
+}
+
+// seconds returns a number of seconds as a duration, which is 0 for negative
+// numbers, and the longest duration for numbers too large to be one.
+func seconds(secs float64) time.Duration {
+	d := secs * float64(time.Second)
+	switch {
+	case d <= 0:
+		return 0
+	case d >= math.MaxInt64:
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,380 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/util/retry"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tcs := []struct {
+		desc   string
+		header map[string]string
+		want   time.Duration
+		wantOk bool
+	}{
+		{
+			desc:   "no headers",
+			header: map[string]string{},
+		},
+		{
+			desc:   "retry-after seconds",
+			header: map[string]string{"Retry-After": "120"},
+			want:   2 * time.Minute,
+			wantOk: true,
+		},
+		{
+			desc:   "retry-after http date",
+			header: map[string]string{"Retry-After": now.Add(30 * time.Second).Format(http.TimeFormat)},
+			want:   30 * time.Second,
+			wantOk: true,
+		},
+		{
+			desc:   "retry-after in the past",
+			header: map[string]string{"Retry-After": now.Add(-30 * time.Second).Format(http.TimeFormat)},
+			want:   0,
+			wantOk: true,
+		},
+		{
+			desc:   "rate limit reset timestamp",
+			header: map[string]string{"X-RateLimit-Reset": strconv.FormatInt(now.Add(10*time.Second).Unix(), 10)},
+			want:   10 * time.Second,
+			wantOk: true,
+		},
+		{
+			desc:   "rate limit reset seconds",
+			header: map[string]string{"X-RateLimit-Reset": "5"},
+			want:   5 * time.Second,
+			wantOk: true,
+		},
+		{
+			desc:   "retry-after takes precedence",
+			header: map[string]string{"Retry-After": "1", "X-RateLimit-Reset": "5"},
+			want:   1 * time.Second,
+			wantOk: true,
+		},
+		{
+			desc:   "invalid value",
+			header: map[string]string{"X-RateLimit-Reset": "soon"},
+		},
+		{
+			desc:   "retry-after too large for a duration",
+			header: map[string]string{"Retry-After": "99999999999"},
+			want:   math.MaxInt64,
+			wantOk: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tc.header {
+				header.Set(k, v)
+			}
+			got, ok := retry.RetryAfter(header, now)
+			if ok != tc.wantOk || got != tc.want {
+				t.Fatalf("unexpected result: got (%s, %t), want (%s, %t)", got, ok, tc.want, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := retry.Policy{InitialBackoff: time.Second, MaxBackoff: 30 * time.Second, Multiplier: 2}
+	tcs := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 1 * time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 10, want: 30 * time.Second},
+	}
+	for _, tc := range tcs {
+		t.Run(strconv.Itoa(tc.attempt), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := p.Backoff(tc.attempt)
+				if got < tc.want/2 || got > tc.want*3/2 {
+					t.Fatalf("backoff out of range: got %s, want within 50%% of %s", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFromYamlPolicy(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		want retry.Policy
+	}{
+		{
+			desc: "defaults",
+			in:   `maxRetries: 3`,
+			want: retry.Policy{
+				MaxRetries:     3,
+				RetryOn:        retry.DefaultRetryOn,
+				InitialBackoff: time.Second,
+				MaxBackoff:     30 * time.Second,
+				Multiplier:     2,
+			},
+		},
+		{
+			desc: "all fields",
+			in: `
+maxRetries: 5
+retryOn: [429, 503, connection-error]
+initialBackoff: 500ms
+maxBackoff: 1m
+multiplier: 1.5
+`,
+			want: retry.Policy{
+				MaxRetries:     5,
+				RetryOn:        []retry.Condition{"429", "503", retry.ConnectionError},
+				InitialBackoff: 500 * time.Millisecond,
+				MaxBackoff:     time.Minute,
+				Multiplier:     1.5,
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var cfg retry.Config
+			if err := yaml.UnmarshalContext(context.Background(), []byte(tc.in), &cfg); err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			got, err := cfg.Policy()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailParseFromYamlPolicy(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+	}{
+		{
+			desc: "invalid condition",
+			in:   `retryOn: [timeout]`,
+		},
+		{
+			desc: "invalid status code",
+			in:   `retryOn: [42]`,
+		},
+		{
+			desc: "invalid backoff",
+			in:   `initialBackoff: soon`,
+		},
+		{
+			desc: "max backoff less than initial backoff",
+			in: `
+initialBackoff: 10s
+maxBackoff: 1s
+`,
+		},
+		{
+			desc: "invalid multiplier",
+			in:   `multiplier: 0.5`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var cfg retry.Config
+			if err := yaml.UnmarshalContext(context.Background(), []byte(tc.in), &cfg); err != nil {
+				return
+			}
+			if _, err := cfg.Policy(); err == nil {
+				t.Fatalf("expected error but got none")
+			}
+		})
+	}
+}
+
+func TestPolicyDo(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	tcs := []struct {
+		desc       string
+		policy     retry.Policy
+		wantStatus int
+		wantCalls  int32
+	}{
+		{
+			desc:       "no retries",
+			policy:     retry.Policy{},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		{
+			desc:       "retries exhausted",
+			policy:     retry.Policy{MaxRetries: 1, RetryOn: []retry.Condition{"503"}},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  2,
+		},
+		{
+			desc:       "status code not retried",
+			policy:     retry.Policy{MaxRetries: 5, RetryOn: []retry.Condition{"429"}},
+			wantStatus: http.StatusServiceUnavailable,
+			wantCalls:  1,
+		},
+		{
+			desc:       "success after retries",
+			policy:     retry.Policy{MaxRetries: 5, RetryOn: []retry.Condition{"503"}},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			calls.Store(0)
+			resp, err := tc.policy.Do(context.Background(), ts.Client(), func() (*http.Request, error) {
+				return http.NewRequest(http.MethodGet, ts.URL, nil)
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Fatalf("unexpected number of requests: got %d, want %d", got, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPolicyDoMaxBackoff(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// a misbehaving server asks to wait for about a day
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	policy := retry.Policy{MaxRetries: 1, RetryOn: []retry.Condition{"429"}, MaxBackoff: 10 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := policy.Do(ctx, ts.Client(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, ts.URL, nil)
+	})
+	if err != nil {
+		t.Fatalf("expected the retry to wait for at most the maximum backoff, got %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status code: got %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	errFailed := errors.New("operation failed")
+	tcs := []struct {
+		desc         string
+		doneAt       int
+		err          error
+		timeout      time.Duration
+		want         any
+		wantAttempts int
+		wantErr      string
+	}{
+		{
+			desc:         "done after polls",
+			doneAt:       3,
+			timeout:      time.Minute,
+			want:         "result",
+			wantAttempts: 3,
+		},
+		{
+			desc:         "error",
+			doneAt:       3,
+			err:          errFailed,
+			timeout:      time.Minute,
+			wantAttempts: 1,
+			wantErr:      "operation failed",
+		},
+		{
+			desc:         "timeout",
+			doneAt:       1000,
+			timeout:      50 * time.Millisecond,
+			wantAttempts: -1,
+			wantErr:      "polling timed out after",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			attempts := 0
+			got, err := retry.Poll(ctx, func(ctx context.Context, attempt int) (any, time.Duration, bool, error) {
+				attempts = attempt
+				if tc.err != nil {
+					return nil, 0, false, tc.err
+				}
+				if attempt == tc.doneAt {
+					return "result", 0, true, nil
+				}
+				return nil, 10 * time.Millisecond, false, nil
+			})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("unexpected error: got %v, want %q", err, tc.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected result: got %v, want %v", got, tc.want)
+			}
+			if tc.wantAttempts >= 0 && attempts != tc.wantAttempts {
+				t.Fatalf("unexpected number of attempts: got %d, want %d", attempts, tc.wantAttempts)
+			}
+		})
+	}
+}
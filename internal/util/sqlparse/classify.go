@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// readOnlyTypes are the types of the statements that only read data.
+var readOnlyTypes = map[string]bool{
+	"SELECT":   true,
+	"VALUES":   true,
+	"TABLE":    true,
+	"SHOW":     true,
+	"DESCRIBE": true,
+	"DESC":     true,
+	"EXPLAIN":  true,
+}
+
+// modifyingTypes are the types of the statements that modify data, and that
+// can be nested in other statements, e.g. in a common table expression.
+var modifyingTypes = map[string]bool{
+	"INSERT": true,
+	"UPDATE": true,
+	"DELETE": true,
+	"MERGE":  true,
+}
+
+// Type returns the type of the statement, which is the upper case keyword that
+// starts it, e.g. SELECT or INSERT. The type of a statement with common table
+// expressions is the type of its main statement, e.g. the type of
+// "WITH t AS (...) DELETE ..." is DELETE.
+func (s Statement) Type() string {
+	tokens := s.Tokens
+	// a query in parentheses, e.g. (SELECT 1) UNION (SELECT 2)
+	for len(tokens) > 0 && tokens[0].Kind == Punctuation && tokens[0].Value == "(" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 || tokens[0].Kind != Word {
+		return "UNKNOWN"
+	}
+	typ := strings.ToUpper(tokens[0].Value)
+	if typ != "WITH" {
+		return typ
+	}
+	if main := mainStatement(tokens); main >= 0 {
+		return strings.ToUpper(tokens[main].Value)
+	}
+	return typ
+}
+
+// mainStatement returns the index of the keyword that starts the main
+// statement of a statement with common table expressions, or -1.
+func mainStatement(tokens []Token) int {
+	depth := 0
+	for i, tok := range tokens {
+		if tok.Kind != Punctuation {
+			continue
+		}
+		switch tok.Value {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			// the body of a common table expression is followed by a
+			// comma, or by the main statement
+			if depth == 0 && i+1 < len(tokens) && tokens[i+1].Kind == Word {
+				next := strings.ToUpper(tokens[i+1].Value)
+				if next != "SEARCH" && next != "CYCLE" && next != "AS" {
+					return i + 1
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// ReadOnly reports whether the statement only reads data. It is conservative:
+// a statement it can't classify isn't read-only.
+//
+// Functions with side effects, e.g. SELECT nextval('seq'), can't be detected
+// from the syntax of a statement, so tools should also run read-only
+// statements in a read-only transaction where the database supports it.
+func (s Statement) ReadOnly() bool {
+	typ := s.Type()
+	if !readOnlyTypes[typ] {
+		return false
+	}
+	if typ == "EXPLAIN" {
+		return explainReadOnly(s.Tokens)
+	}
+	// selects records whether a SELECT was seen at each depth of
+	// parentheses, so that the INTO of a nested query, e.g.
+	// (SELECT ... INTO t FROM ...), is detected as well
+	selects := []bool{false}
+	for i, tok := range s.Tokens {
+		switch {
+		case tok.Kind == Punctuation && tok.Value == "(":
+			selects = append(selects, false)
+			// a data-modifying statement in a common table expression or
+			// a subquery
+			if i+1 < len(s.Tokens) && s.Tokens[i+1].Kind == Word && modifyingTypes[strings.ToUpper(s.Tokens[i+1].Value)] {
+				return false
+			}
+		case tok.Kind == Punctuation && tok.Value == ")":
+			if len(selects) > 1 {
+				selects = selects[:len(selects)-1]
+			}
+		case tok.is("SELECT"):
+			selects[len(selects)-1] = true
+		case selects[len(selects)-1] && tok.is("INTO"):
+			// SELECT ... INTO creates a table, or writes to a file or to
+			// variables
+			return false
+		case lockingClause(s.Tokens[i:]):
+			// SELECT ... FOR UPDATE locks the rows it reads, which blocks
+			// writers until the transaction ends
+			return false
+		}
+	}
+	return true
+}
+
+// lockingClause reports whether tokens start with a row locking clause, e.g.
+// FOR UPDATE, FOR NO KEY UPDATE, FOR SHARE or FOR KEY SHARE, or MySQL's
+// LOCK IN SHARE MODE.
+func lockingClause(tokens []Token) bool {
+	switch {
+	case len(tokens) >= 2 && tokens[0].is("FOR"):
+		// skip the NO KEY of FOR NO KEY UPDATE, or the KEY of FOR KEY SHARE
+		next := tokens[1:]
+		for len(next) > 1 && (next[0].is("NO") || next[0].is("KEY")) {
+			next = next[1:]
+		}
+		return next[0].is("UPDATE") || next[0].is("SHARE")
+	case len(tokens) >= 3 && tokens[0].is("LOCK"):
+		return tokens[1].is("IN") && tokens[2].is("SHARE")
+	}
+	return false
+}
+
+// explainReadOnly reports whether an EXPLAIN statement only reads data. EXPLAIN
+// ANALYZE runs the statement that it explains.
+func explainReadOnly(tokens []Token) bool {
+	analyze := false
+	depth := 0
+	for i, tok := range tokens[1:] {
+		switch {
+		case tok.Kind == Punctuation && tok.Value == "(":
+			depth++
+		case tok.Kind == Punctuation && tok.Value == ")":
+			depth--
+		case tok.is("ANALYZE") || tok.is("ANALYSE"):
+			analyze = true
+		case depth == 0 && tok.Kind == Word && (readOnlyTypes[strings.ToUpper(tok.Value)] || modifyingTypes[strings.ToUpper(tok.Value)] || tok.is("WITH")):
+			if !analyze {
+				return true
+			}
+			return Statement{Tokens: tokens[i+1:]}.ReadOnly()
+		}
+	}
+	return !analyze
+}
+
+// CheckReadOnly parses the SQL, and returns an error if any of its statements
+// doesn't only read data.
+func CheckReadOnly(sql string, dialect Dialect) error {
+	statements, err := Parse(sql, dialect)
+	if err != nil {
+		return fmt.Errorf("unable to parse SQL: %w", err)
+	}
+	for _, s := range statements {
+		if !s.ReadOnly() {
+			return fmt.Errorf("%s statements are not allowed by a read-only tool", s.Type())
+		}
+	}
+	return nil
+}
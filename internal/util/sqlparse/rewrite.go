@@ -0,0 +1,84 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sqlparse
+
+import (
+	"strings"
+)
+
+// Placeholder is a parameter of a SQL statement, e.g. ?, $1 or @name.
+type Placeholder struct {
+	// Value is the text of the parameter.
+	Value string
+	// InList reports whether the parameter is the only element of an IN
+	// list, e.g. x IN (?).
+	InList bool
+	// Not reports whether the IN list is negated, e.g. x NOT IN (?).
+	Not bool
+	// Text is the text that is replaced: the parameter, or its whole IN list
+	// from IN, or NOT, to the closing parenthesis.
+	Text string
+}
+
+// span is a token and its position in the SQL.
+type span struct {
+	tok        Token
+	start, end int
+}
+
+// RewritePlaceholders calls rewrite with each parameter of the SQL in order,
+// and replaces the text of the placeholder with the text it returns. It
+// returns the error of rewrite, or an error if the SQL can't be parsed.
+func RewritePlaceholders(sql string, dialect Dialect, rewrite func(p Placeholder) (string, error)) (string, error) {
+	l := lexer{src: sql, dialect: dialect}
+	var spans []span
+	for {
+		tok, end, err := l.next()
+		if err != nil {
+			return "", err
+		}
+		if end {
+			break
+		}
+		spans = append(spans, span{tok: tok, start: l.tokenStart, end: l.pos})
+	}
+
+	var b strings.Builder
+	last := 0
+	for i, s := range spans {
+		if s.tok.Kind != Parameter {
+			continue
+		}
+		p := Placeholder{Value: s.tok.Value}
+		start, end := s.start, s.end
+		if i >= 2 && i+1 < len(spans) && spans[i-2].tok.is("IN") && isPunctuation(spans[i-1].tok, "(") && isPunctuation(spans[i+1].tok, ")") {
+			p.InList = true
+			start, end = spans[i-2].start, spans[i+1].end
+			if i >= 3 && spans[i-3].tok.is("NOT") {
+				p.Not = true
+				start = spans[i-3].start
+			}
+		}
+		p.Text = sql[start:end]
+		text, err := rewrite(p)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(sql[last:start])
+		b.WriteString(text)
+		last = end
+	}
+	b.WriteString(sql[last:])
+	return b.String(), nil
+}
@@ -0,0 +1,358 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlparse splits SQL into statements and classifies them. The SQL is
+// tokenized according to its dialect, so that comments, string literals and
+// quoted identifiers are never mistaken for keywords.
+package sqlparse
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Dialect is the lexical syntax of the SQL of a database.
+type Dialect int
+
+const (
+	// Postgres is the dialect of PostgreSQL, AlloyDB and Cloud SQL for
+	// PostgreSQL.
+	Postgres Dialect = iota
+	// MySQL is the dialect of MySQL and Cloud SQL for MySQL.
+	MySQL
+	// GoogleSQL is the dialect of BigQuery and Spanner.
+	GoogleSQL
+	// SQLServer is the dialect of SQL Server and Cloud SQL for SQL Server.
+	SQLServer
+	// SQLite is the dialect of SQLite.
+	SQLite
+)
+
+// TokenKind is the kind of a token.
+type TokenKind int
+
+const (
+	// Word is a keyword, or an unquoted identifier.
+	Word TokenKind = iota
+	// QuotedIdentifier is an identifier in quotes, backticks or brackets.
+	QuotedIdentifier
+	// String is a string literal, including its quotes.
+	String
+	// Number is a numeric literal.
+	Number
+	// Punctuation is an operator, or any other symbol.
+	Punctuation
+	// Parameter is a query parameter, e.g. $1, ? or @name.
+	Parameter
+)
+
+// Token is a token of a statement.
+type Token struct {
+	Kind  TokenKind
+	Value string
+}
+
+// is reports whether the token is the given keyword, which must be upper case.
+func (t Token) is(keyword string) bool {
+	return t.Kind == Word && strings.EqualFold(t.Value, keyword)
+}
+
+// Statement is a single SQL statement.
+type Statement struct {
+	// Text is the text of the statement, from its first token to its last.
+	Text   string
+	Tokens []Token
+
+	dialect Dialect
+}
+
+// Parse splits the SQL into its statements. Statements that only contain
+// comments are dropped. It returns an error if a string, quoted identifier or
+// comment isn't terminated.
+func Parse(sql string, dialect Dialect) ([]Statement, error) {
+	l := lexer{src: sql, dialect: dialect}
+	var statements []Statement
+	start := 0
+	var tokens []Token
+	for {
+		tok, end, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		if end || (tok.Kind == Punctuation && tok.Value == ";") {
+			if len(tokens) > 0 {
+				statements = append(statements, Statement{Text: strings.TrimSpace(sql[start:l.tokenStart]), Tokens: tokens, dialect: dialect})
+			}
+			if end {
+				return statements, nil
+			}
+			start = l.pos
+			tokens = nil
+			continue
+		}
+		if len(tokens) == 0 {
+			start = l.tokenStart
+		}
+		tokens = append(tokens, tok)
+	}
+}
+
+type lexer struct {
+	src        string
+	dialect    Dialect
+	pos        int
+	tokenStart int
+}
+
+// next returns the next token, or end at the end of the SQL.
+func (l *lexer) next() (tok Token, end bool, err error) {
+	if err := l.skipSpaceAndComments(); err != nil {
+		return Token{}, false, err
+	}
+	l.tokenStart = l.pos
+	if l.pos >= len(l.src) {
+		return Token{}, true, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case c == '\'':
+		if l.dialect == GoogleSQL && strings.HasPrefix(l.src[l.pos:], "'''") {
+			return l.delimited(String, "'''", "'''", true)
+		}
+		return l.delimited(String, "'", "'", l.backslashEscapes())
+	case c == '"':
+		switch l.dialect {
+		case MySQL:
+			return l.delimited(String, `"`, `"`, true)
+		case GoogleSQL:
+			if strings.HasPrefix(l.src[l.pos:], `"""`) {
+				return l.delimited(String, `"""`, `"""`, true)
+			}
+			return l.delimited(String, `"`, `"`, true)
+		default:
+			return l.delimited(QuotedIdentifier, `"`, `"`, false)
+		}
+	case c == '`' && (l.dialect == MySQL || l.dialect == GoogleSQL || l.dialect == SQLite):
+		return l.delimited(QuotedIdentifier, "`", "`", false)
+	case c == '[' && (l.dialect == SQLServer || l.dialect == SQLite):
+		return l.delimited(QuotedIdentifier, "[", "]", false)
+	case c == '$' && l.dialect == Postgres:
+		if tag, ok := l.dollarTag(); ok {
+			return l.delimited(String, tag, tag, false)
+		}
+		return l.parameter()
+	case c == '?' || ((c == '@' || c == ':') && l.dialect != Postgres):
+		return l.parameter()
+	case isDigit(c) || (c == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1])):
+		return l.number()
+	}
+
+	r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	if isWordStart(r) {
+		// string literals with a prefix, e.g. E'...' or r'...'
+		if prefixed, ok, err := l.prefixedString(); ok || err != nil {
+			return prefixed, false, err
+		}
+		for l.pos < len(l.src) {
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			if !isWordPart(r) {
+				break
+			}
+			l.pos += size
+		}
+		return Token{Kind: Word, Value: l.src[l.tokenStart:l.pos]}, false, nil
+	}
+	l.pos += size
+	return Token{Kind: Punctuation, Value: l.src[l.tokenStart:l.pos]}, false, nil
+}
+
+func (l *lexer) backslashEscapes() bool {
+	return l.dialect == MySQL || l.dialect == GoogleSQL
+}
+
+func (l *lexer) skipSpaceAndComments() error {
+	for l.pos < len(l.src) {
+		rest := l.src[l.pos:]
+		r, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case unicode.IsSpace(r):
+			l.pos += size
+		case strings.HasPrefix(rest, "--"), rest[0] == '#' && (l.dialect == MySQL || l.dialect == GoogleSQL):
+			if i := strings.IndexByte(rest, '\n'); i >= 0 {
+				l.pos += i + 1
+			} else {
+				l.pos = len(l.src)
+			}
+		case strings.HasPrefix(rest, "/*"):
+			if err := l.blockComment(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// blockComment skips a block comment, which can be nested in Postgres.
+func (l *lexer) blockComment() error {
+	depth := 0
+	for l.pos < len(l.src) {
+		rest := l.src[l.pos:]
+		switch {
+		case strings.HasPrefix(rest, "/*") && (depth == 0 || l.dialect == Postgres):
+			depth++
+			l.pos += 2
+		case strings.HasPrefix(rest, "*/"):
+			depth--
+			l.pos += 2
+			if depth == 0 {
+				return nil
+			}
+		default:
+			l.pos++
+		}
+	}
+	return fmt.Errorf("unterminated comment")
+}
+
+// delimited reads a token from its opening to its closing delimiter. A
+// doubled closing delimiter is an escaped delimiter, and, if backslash is set,
+// so is a delimiter after a backslash.
+func (l *lexer) delimited(kind TokenKind, open, close string, backslash bool) (Token, bool, error) {
+	l.pos += len(open)
+	for l.pos < len(l.src) {
+		rest := l.src[l.pos:]
+		switch {
+		case backslash && rest[0] == '\\':
+			l.pos += 2
+		case strings.HasPrefix(rest, close):
+			l.pos += len(close)
+			// a doubled single-character delimiter is escaped, e.g. 'it''s'
+			if len(close) == 1 && strings.HasPrefix(l.src[l.pos:], close) {
+				l.pos++
+				continue
+			}
+			return Token{Kind: kind, Value: l.src[l.tokenStart:l.pos]}, false, nil
+		default:
+			l.pos++
+		}
+	}
+	if kind == String {
+		return Token{}, false, fmt.Errorf("unterminated string literal")
+	}
+	return Token{}, false, fmt.Errorf("unterminated quoted identifier")
+}
+
+// dollarTag returns the tag that opens a dollar-quoted string, e.g. $$ or
+// $body$.
+func (l *lexer) dollarTag() (string, bool) {
+	i := l.pos + 1
+	for i < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[i:])
+		if r == '$' {
+			tag := l.src[l.pos : i+1]
+			// $1 is a parameter, not a tag
+			if len(tag) > 2 && isDigit(tag[1]) {
+				return "", false
+			}
+			return tag, true
+		}
+		if !isWordPart(r) {
+			return "", false
+		}
+		i += size
+	}
+	return "", false
+}
+
+func (l *lexer) parameter() (Token, bool, error) {
+	l.pos++
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if !isWordPart(r) {
+			break
+		}
+		l.pos += size
+	}
+	// the @@ prefix of system variables, and the :: cast operator
+	if l.pos == l.tokenStart+1 && l.pos < len(l.src) && l.src[l.pos] == l.src[l.tokenStart] {
+		l.pos++
+	}
+	return Token{Kind: Parameter, Value: l.src[l.tokenStart:l.pos]}, false, nil
+}
+
+func (l *lexer) number() (Token, bool, error) {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if !isDigit(c) && c != '.' && c != 'e' && c != 'E' && c != 'x' && c != 'X' && !isHexLetter(c) {
+			break
+		}
+		l.pos++
+	}
+	return Token{Kind: Number, Value: l.src[l.tokenStart:l.pos]}, false, nil
+}
+
+// prefixedString reads a string literal with a prefix, such as E'...' in
+// Postgres, N'...' in SQL Server, or r'...' and b'...' in GoogleSQL.
+func (l *lexer) prefixedString() (Token, bool, error) {
+	i := l.pos
+	for i < len(l.src) && i-l.pos < 2 && isLetter(l.src[i]) {
+		i++
+	}
+	if i == l.pos || i >= len(l.src) || (l.src[i] != '\'' && l.src[i] != '"') {
+		return Token{}, false, nil
+	}
+	prefix := strings.ToUpper(l.src[l.pos:i])
+	switch prefix {
+	case "E", "N", "X", "B", "U", "R", "BR", "RB":
+	default:
+		return Token{}, false, nil
+	}
+	if l.src[i] == '"' && l.dialect != GoogleSQL {
+		return Token{}, false, nil
+	}
+	l.pos = i
+	quote := string(l.src[i])
+	if l.dialect == GoogleSQL && strings.HasPrefix(l.src[i:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	// a quote after a backslash doesn't end the string, even in a raw string
+	backslash := l.backslashEscapes() || prefix == "E"
+	tok, _, err := l.delimited(String, quote, quote, backslash)
+	return tok, true, err
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHexLetter(c byte) bool {
+	return (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isWordStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isWordPart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
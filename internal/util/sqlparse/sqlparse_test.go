@@ -0,0 +1,318 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlparse_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/util/sqlparse"
+)
+
+func TestParse(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		dialect sqlparse.Dialect
+		sql     string
+		want    []string
+	}{
+		{
+			desc:    "single statement",
+			dialect: sqlparse.Postgres,
+			sql:     "SELECT 1",
+			want:    []string{"SELECT 1"},
+		},
+		{
+			desc:    "semicolons in literals and comments",
+			dialect: sqlparse.Postgres,
+			sql:     "SELECT ';', \"a;b\" FROM t; -- ; DROP\n/* ; /* nested; */ */ SELECT $$;$$, $tag$ ; $tag$, $1;",
+			want:    []string{`SELECT ';', "a;b" FROM t`, "SELECT $$;$$, $tag$ ; $tag$, $1"},
+		},
+		{
+			desc:    "comment only statements",
+			dialect: sqlparse.Postgres,
+			sql:     "; -- nothing\n;",
+			want:    nil,
+		},
+		{
+			desc:    "mysql escapes and hash comments",
+			dialect: sqlparse.MySQL,
+			sql:     "SELECT 'it\\'s;', `a;b` # ;\nFROM t; SELECT 2",
+			want:    []string{"SELECT 'it\\'s;', `a;b` # ;\nFROM t", "SELECT 2"},
+		},
+		{
+			desc:    "googlesql triple quoted and raw strings",
+			dialect: sqlparse.GoogleSQL,
+			sql:     "SELECT '''a;'b''', r\"\\\";\" FROM `p.d.t`",
+			want:    []string{"SELECT '''a;'b''', r\"\\\";\" FROM `p.d.t`"},
+		},
+		{
+			desc:    "sql server brackets",
+			dialect: sqlparse.SQLServer,
+			sql:     "SELECT [a;]]b], N'x;' FROM t;SELECT 2",
+			want:    []string{"SELECT [a;]]b], N'x;' FROM t", "SELECT 2"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			statements, err := sqlparse.Parse(tc.sql, tc.dialect)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var got []string
+			for _, s := range statements {
+				got = append(got, s.Text)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect statements: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseTokens(t *testing.T) {
+	statements, err := sqlparse.Parse(`SELECT "Name", 'x' FROM t WHERE id = $1 AND n > 1.5`, sqlparse.Postgres)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []sqlparse.Token{
+		{Kind: sqlparse.Word, Value: "SELECT"},
+		{Kind: sqlparse.QuotedIdentifier, Value: `"Name"`},
+		{Kind: sqlparse.Punctuation, Value: ","},
+		{Kind: sqlparse.String, Value: "'x'"},
+		{Kind: sqlparse.Word, Value: "FROM"},
+		{Kind: sqlparse.Word, Value: "t"},
+		{Kind: sqlparse.Word, Value: "WHERE"},
+		{Kind: sqlparse.Word, Value: "id"},
+		{Kind: sqlparse.Punctuation, Value: "="},
+		{Kind: sqlparse.Parameter, Value: "$1"},
+		{Kind: sqlparse.Word, Value: "AND"},
+		{Kind: sqlparse.Word, Value: "n"},
+		{Kind: sqlparse.Punctuation, Value: ">"},
+		{Kind: sqlparse.Number, Value: "1.5"},
+	}
+	if diff := cmp.Diff(want, statements[0].Tokens); diff != "" {
+		t.Fatalf("incorrect tokens: diff %v", diff)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		dialect sqlparse.Dialect
+		sql     string
+		err     string
+	}{
+		{
+			desc:    "unterminated string",
+			dialect: sqlparse.Postgres,
+			sql:     "SELECT 'a",
+			err:     "unterminated string literal",
+		},
+		{
+			desc:    "unterminated prefixed string",
+			dialect: sqlparse.Postgres,
+			sql:     "SELECT E'a\\'",
+			err:     "unterminated string literal",
+		},
+		{
+			desc:    "unterminated quoted identifier",
+			dialect: sqlparse.MySQL,
+			sql:     "SELECT `a",
+			err:     "unterminated quoted identifier",
+		},
+		{
+			desc:    "unterminated comment",
+			dialect: sqlparse.Postgres,
+			sql:     "SELECT 1 /* /* */",
+			err:     "unterminated comment",
+		},
+		{
+			desc:    "unterminated dollar quoted string",
+			dialect: sqlparse.Postgres,
+			sql:     "SELECT $a$ x $b$",
+			err:     "unterminated string literal",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := sqlparse.Parse(tc.sql, tc.dialect)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %q, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	tcs := []struct {
+		desc     string
+		dialect  sqlparse.Dialect
+		sql      string
+		wantType string
+		readOnly bool
+	}{
+		{desc: "select", dialect: sqlparse.Postgres, sql: "select * from t", wantType: "SELECT", readOnly: true},
+		{desc: "select in parentheses", dialect: sqlparse.Postgres, sql: "(SELECT 1) UNION (SELECT 2)", wantType: "SELECT", readOnly: true},
+		{desc: "keyword in string", dialect: sqlparse.Postgres, sql: "SELECT 'DELETE FROM t'", wantType: "SELECT", readOnly: true},
+		{desc: "keyword in comment", dialect: sqlparse.MySQL, sql: "/* DELETE */ SELECT 1", wantType: "SELECT", readOnly: true},
+		{desc: "function named like a statement", dialect: sqlparse.MySQL, sql: "SELECT REPLACE(name, 'a', 'b') FROM t", wantType: "SELECT", readOnly: true},
+		{desc: "show", dialect: sqlparse.MySQL, sql: "SHOW TABLES", wantType: "SHOW", readOnly: true},
+		{desc: "insert", dialect: sqlparse.Postgres, sql: "INSERT INTO t VALUES (1)", wantType: "INSERT", readOnly: false},
+		{desc: "drop", dialect: sqlparse.SQLServer, sql: "DROP TABLE [t]", wantType: "DROP", readOnly: false},
+		{desc: "pragma", dialect: sqlparse.SQLite, sql: "PRAGMA journal_mode = WAL", wantType: "PRAGMA", readOnly: false},
+		{desc: "select into", dialect: sqlparse.Postgres, sql: "SELECT * INTO t2 FROM t", wantType: "SELECT", readOnly: false},
+		{desc: "select into outfile", dialect: sqlparse.MySQL, sql: "SELECT * FROM t INTO OUTFILE '/tmp/t'", wantType: "SELECT", readOnly: false},
+		{desc: "parenthesized select into", dialect: sqlparse.Postgres, sql: "(SELECT * INTO t2 FROM t)", wantType: "SELECT", readOnly: false},
+		{desc: "select into in union", dialect: sqlparse.Postgres, sql: "(SELECT 1) UNION (SELECT 2 INTO t2)", wantType: "SELECT", readOnly: false},
+		{desc: "select into in cte", dialect: sqlparse.Postgres, sql: "WITH a AS (SELECT 1 INTO t2) SELECT 1", wantType: "SELECT", readOnly: false},
+		{desc: "subquery", dialect: sqlparse.Postgres, sql: "SELECT * FROM (SELECT 1) a WHERE x IN (SELECT y FROM b)", wantType: "SELECT", readOnly: true},
+		{desc: "select for update", dialect: sqlparse.Postgres, sql: "SELECT * FROM t WHERE id = 1 FOR UPDATE", wantType: "SELECT", readOnly: false},
+		{desc: "select for no key update", dialect: sqlparse.Postgres, sql: "SELECT * FROM t FOR NO KEY UPDATE OF t SKIP LOCKED", wantType: "SELECT", readOnly: false},
+		{desc: "select for share", dialect: sqlparse.MySQL, sql: "SELECT * FROM t FOR SHARE NOWAIT", wantType: "SELECT", readOnly: false},
+		{desc: "select for key share", dialect: sqlparse.Postgres, sql: "SELECT * FROM t FOR KEY SHARE", wantType: "SELECT", readOnly: false},
+		{desc: "subquery for update", dialect: sqlparse.Postgres, sql: "SELECT * FROM (SELECT * FROM t FOR UPDATE) s", wantType: "SELECT", readOnly: false},
+		{desc: "lock in share mode", dialect: sqlparse.MySQL, sql: "SELECT * FROM t LOCK IN SHARE MODE", wantType: "SELECT", readOnly: false},
+		{desc: "for system time", dialect: sqlparse.GoogleSQL, sql: "SELECT * FROM t FOR SYSTEM_TIME AS OF TIMESTAMP '2025-01-01'", wantType: "SELECT", readOnly: true},
+		{desc: "for json", dialect: sqlparse.SQLServer, sql: "SELECT * FROM t FOR JSON AUTO", wantType: "SELECT", readOnly: true},
+		{desc: "read-only cte", dialect: sqlparse.Postgres, sql: "WITH a(x) AS (SELECT 1), b AS MATERIALIZED (SELECT 2) SELECT * FROM a, b", wantType: "SELECT", readOnly: true},
+		{desc: "cte with delete", dialect: sqlparse.Postgres, sql: "WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d", wantType: "SELECT", readOnly: false},
+		{desc: "cte before insert", dialect: sqlparse.GoogleSQL, sql: "WITH a AS (SELECT 1 AS x) INSERT INTO t SELECT x FROM a", wantType: "INSERT", readOnly: false},
+		{desc: "explain", dialect: sqlparse.Postgres, sql: "EXPLAIN DELETE FROM t", wantType: "EXPLAIN", readOnly: true},
+		{desc: "explain analyze select", dialect: sqlparse.Postgres, sql: "EXPLAIN ANALYZE SELECT 1", wantType: "EXPLAIN", readOnly: true},
+		{desc: "explain analyze delete", dialect: sqlparse.Postgres, sql: "EXPLAIN (ANALYZE, FORMAT JSON) DELETE FROM t", wantType: "EXPLAIN", readOnly: false},
+		{desc: "unknown", dialect: sqlparse.Postgres, sql: "'a'", wantType: "UNKNOWN", readOnly: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			statements, err := sqlparse.Parse(tc.sql, tc.dialect)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(statements) != 1 {
+				t.Fatalf("expect 1 statement, got %d", len(statements))
+			}
+			if got := statements[0].Type(); got != tc.wantType {
+				t.Errorf("incorrect type: got %q, want %q", got, tc.wantType)
+			}
+			if got := statements[0].ReadOnly(); got != tc.readOnly {
+				t.Errorf("incorrect read-only: got %t, want %t", got, tc.readOnly)
+			}
+		})
+	}
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	if err := sqlparse.CheckReadOnly("SELECT 1; SELECT 2;", sqlparse.Postgres); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	err := sqlparse.CheckReadOnly("SELECT 1; update t set a = 1", sqlparse.Postgres)
+	if err == nil || !strings.Contains(err.Error(), "UPDATE statements are not allowed by a read-only tool") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = sqlparse.CheckReadOnly("SELECT 'a", sqlparse.Postgres)
+	if err == nil || !strings.Contains(err.Error(), "unable to parse SQL: unterminated string literal") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTables(t *testing.T) {
+	tcs := []struct {
+		desc    string
+		dialect sqlparse.Dialect
+		sql     string
+		want    []string
+	}{
+		{desc: "select", dialect: sqlparse.Postgres, sql: `SELECT * FROM public."Orders" o JOIN customers c ON o.cid = c.id`, want: []string{"public.Orders", "customers"}},
+		{desc: "list of tables", dialect: sqlparse.Postgres, sql: "SELECT * FROM a AS x, b y, c WHERE x.id = y.id", want: []string{"a", "b", "c"}},
+		{desc: "subquery", dialect: sqlparse.MySQL, sql: "SELECT * FROM (SELECT id FROM db.a) x WHERE id IN (SELECT id FROM b)", want: []string{"db.a", "b"}},
+		{desc: "functions", dialect: sqlparse.Postgres, sql: "SELECT EXTRACT(YEAR FROM ts), a IS DISTINCT FROM b FROM generate_series(1, 3), t", want: []string{"t"}},
+		{desc: "common table expressions", dialect: sqlparse.Postgres, sql: "WITH x(id) AS (SELECT id FROM a), y AS MATERIALIZED (SELECT 1) SELECT * FROM x, y, b", want: []string{"a", "b"}},
+		{desc: "insert", dialect: sqlparse.Postgres, sql: "INSERT INTO a (id) SELECT id FROM b ON CONFLICT DO NOTHING", want: []string{"a", "b"}},
+		{desc: "update", dialect: sqlparse.MySQL, sql: "UPDATE a SET x = 1 WHERE id = 2", want: []string{"a"}},
+		{desc: "upsert", dialect: sqlparse.MySQL, sql: "INSERT INTO a VALUES (1) ON DUPLICATE KEY UPDATE x = 1", want: []string{"a"}},
+		{desc: "select for update", dialect: sqlparse.Postgres, sql: "SELECT * FROM a FOR UPDATE OF a SKIP LOCKED", want: []string{"a"}},
+		{desc: "into outfile", dialect: sqlparse.MySQL, sql: "SELECT * FROM a INTO OUTFILE '/tmp/a'", want: []string{"a"}},
+		{desc: "merge", dialect: sqlparse.SQLServer, sql: "MERGE INTO [dbo].[a] USING b ON a.id = b.id WHEN MATCHED THEN UPDATE SET x = 1", want: []string{"dbo.a", "b"}},
+		{desc: "drop", dialect: sqlparse.Postgres, sql: "DROP TABLE IF EXISTS a", want: []string{"a"}},
+		{desc: "truncate", dialect: sqlparse.MySQL, sql: "TRUNCATE TABLE a", want: []string{"a"}},
+		{desc: "googlesql path in backticks", dialect: sqlparse.GoogleSQL, sql: "SELECT * FROM `p.d.t` JOIN d.u USING (id), UNNEST(arr)", want: []string{"p.d.t", "d.u"}},
+		{desc: "sql server empty schema", dialect: sqlparse.SQLServer, sql: "SELECT * FROM db..t WITH (NOLOCK)", want: []string{"db..t"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			statements, err := sqlparse.Parse(tc.sql, tc.dialect)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var got []string
+			for _, name := range statements[0].Tables() {
+				got = append(got, name.String())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect tables: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestRewritePlaceholders(t *testing.T) {
+	// marks each placeholder, and whether it's the only element of an IN list
+	rewrite := func(p sqlparse.Placeholder) (string, error) {
+		switch {
+		case p.Not:
+			return "<not-in " + p.Value + ">", nil
+		case p.InList:
+			return "<in " + p.Value + ">", nil
+		default:
+			return "<" + p.Value + ">", nil
+		}
+	}
+	tcs := []struct {
+		desc    string
+		dialect sqlparse.Dialect
+		sql     string
+		want    string
+	}{
+		{desc: "parameters", dialect: sqlparse.Postgres, sql: "SELECT * FROM t WHERE a = $1 AND b = $2", want: "SELECT * FROM t WHERE a = <$1> AND b = <$2>"},
+		{desc: "in list", dialect: sqlparse.MySQL, sql: "SELECT * FROM t WHERE a IN ( ? ) AND b = ?", want: "SELECT * FROM t WHERE a <in ?> AND b = <?>"},
+		{desc: "not in list", dialect: sqlparse.GoogleSQL, sql: "SELECT * FROM t WHERE a not in (@ids)", want: "SELECT * FROM t WHERE a <not-in @ids>"},
+		{desc: "list of parameters", dialect: sqlparse.MySQL, sql: "SELECT * FROM t WHERE a IN (?, ?)", want: "SELECT * FROM t WHERE a IN (<?>, <?>)"},
+		{desc: "literals and comments", dialect: sqlparse.MySQL, sql: "SELECT '?' /* IN (?) */ FROM t WHERE a IN (?)", want: "SELECT '?' /* IN (?) */ FROM t WHERE a <in ?>"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := sqlparse.RewritePlaceholders(tc.sql, tc.dialect, rewrite)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect SQL: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	_, err := sqlparse.RewritePlaceholders("SELECT ?", sqlparse.MySQL, func(sqlparse.Placeholder) (string, error) {
+		return "", errors.New("some error")
+	})
+	if err == nil || err.Error() != "some error" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
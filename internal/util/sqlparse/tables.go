@@ -0,0 +1,240 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sqlparse
+
+import (
+	"strings"
+)
+
+// TableName is the name of a table, split into its parts and unquoted, e.g.
+// [public orders] for public."orders".
+type TableName []string
+
+func (n TableName) String() string {
+	return strings.Join(n, ".")
+}
+
+// tableKeywords are the keywords that are followed by the name of a table.
+var tableKeywords = map[string]bool{
+	"FROM":     true,
+	"JOIN":     true,
+	"INTO":     true,
+	"UPDATE":   true,
+	"TABLE":    true,
+	"USING":    true,
+	"TRUNCATE": true,
+	"DESCRIBE": true,
+}
+
+// tableModifiers are the keywords that can be between a table keyword and the
+// name of the table, e.g. DROP TABLE IF EXISTS t.
+var tableModifiers = map[string]bool{
+	"ONLY":    true,
+	"LATERAL": true,
+	"IF":      true,
+	"NOT":     true,
+	"EXISTS":  true,
+	"TABLE":   true,
+}
+
+// clauseKeywords are the keywords that can follow the name of a table, and so
+// aren't its alias.
+var clauseKeywords = map[string]bool{
+	"WHERE": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true,
+	"FULL": true, "CROSS": true, "OUTER": true, "NATURAL": true, "ON": true,
+	"USING": true, "GROUP": true, "ORDER": true, "LIMIT": true, "HAVING": true,
+	"UNION": true, "EXCEPT": true, "INTERSECT": true, "WINDOW": true, "SET": true,
+	"VALUES": true, "SELECT": true, "RETURNING": true, "OFFSET": true,
+	"FETCH": true, "FOR": true, "TABLESAMPLE": true, "WITH": true, "QUALIFY": true,
+	"DEFAULT": true, "OUTPUT": true, "WHEN": true, "PARTITION": true,
+	"OUTFILE": true, "DUMPFILE": true, "OF": true, "SKIP": true, "NOWAIT": true,
+}
+
+// queryKeywords are the keywords that start a query in parentheses.
+var queryKeywords = map[string]bool{"SELECT": true, "WITH": true, "VALUES": true, "TABLE": true}
+
+// Tables returns the names of the tables that the statement references, in
+// the order they first appear. The names of common table expressions and of
+// table functions aren't included.
+func (s Statement) Tables() []TableName {
+	ctes := s.commonTableExpressions()
+	var names []TableName
+	seen := map[string]bool{}
+	add := func(name TableName) {
+		if len(name) == 1 && ctes[strings.ToLower(name[0])] {
+			return
+		}
+		key := strings.ToLower(name.String())
+		if !seen[key] {
+			seen[key] = true
+			names = append(names, name)
+		}
+	}
+
+	// whether table names can appear at each depth of parentheses, which
+	// excludes e.g. EXTRACT(YEAR FROM ts)
+	inQuery := []bool{true}
+	for i := 0; i < len(s.Tokens); i++ {
+		tok := s.Tokens[i]
+		switch {
+		case isPunctuation(tok, "("):
+			inQuery = append(inQuery, i+1 < len(s.Tokens) && s.Tokens[i+1].Kind == Word && queryKeywords[strings.ToUpper(s.Tokens[i+1].Value)])
+			continue
+		case isPunctuation(tok, ")"):
+			if len(inQuery) > 1 {
+				inQuery = inQuery[:len(inQuery)-1]
+			}
+			continue
+		}
+		if !inQuery[len(inQuery)-1] || tok.Kind != Word || !tableKeywords[strings.ToUpper(tok.Value)] {
+			continue
+		}
+		keyword := strings.ToUpper(tok.Value)
+		// IS DISTINCT FROM, FOR UPDATE, and ON DUPLICATE KEY UPDATE
+		if i > 0 && ((keyword == "FROM" && s.Tokens[i-1].is("DISTINCT")) || (keyword == "UPDATE" && (s.Tokens[i-1].is("FOR") || s.Tokens[i-1].is("KEY")))) {
+			continue
+		}
+
+		j := i + 1
+		for {
+			for j < len(s.Tokens) && s.Tokens[j].Kind == Word && tableModifiers[strings.ToUpper(s.Tokens[j].Value)] {
+				j++
+			}
+			name, next := s.tableName(j)
+			if name == nil {
+				break
+			}
+			j = next
+			// a table function, e.g. FROM generate_series(1, 10); INTO and
+			// TABLE are followed by a list of columns instead
+			if (keyword == "FROM" || keyword == "JOIN" || keyword == "USING") && j < len(s.Tokens) && isPunctuation(s.Tokens[j], "(") {
+				j = s.skipParentheses(j)
+			} else {
+				add(name)
+			}
+			if keyword != "FROM" && keyword != "UPDATE" {
+				break
+			}
+			// a list of tables, e.g. FROM a AS x, b y
+			if j < len(s.Tokens) && s.Tokens[j].is("AS") {
+				j++
+			}
+			if j < len(s.Tokens) && isAlias(s.Tokens[j]) {
+				j++
+			}
+			if j >= len(s.Tokens) || !isPunctuation(s.Tokens[j], ",") {
+				break
+			}
+			j++
+		}
+		i = j - 1
+	}
+	return names
+}
+
+// skipParentheses returns the index of the token after the parentheses that
+// open at the token i.
+func (s Statement) skipParentheses(i int) int {
+	depth := 0
+	for ; i < len(s.Tokens); i++ {
+		if isPunctuation(s.Tokens[i], "(") {
+			depth++
+		} else if isPunctuation(s.Tokens[i], ")") {
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+// tableName reads a possibly qualified table name at the token i, and returns
+// it with the index of the token after it, or nil.
+func (s Statement) tableName(i int) (TableName, int) {
+	var name TableName
+	for i < len(s.Tokens) {
+		tok := s.Tokens[i]
+		switch {
+		case tok.Kind == Word && (len(name) > 0 || !clauseKeywords[strings.ToUpper(tok.Value)]):
+			name = append(name, tok.Value)
+		case tok.Kind == QuotedIdentifier:
+			name = append(name, s.unquote(tok.Value)...)
+		default:
+			// an empty part, e.g. db..t in SQL Server
+			if len(name) > 0 && isPunctuation(tok, ".") {
+				name = append(name, "")
+				i++
+				continue
+			}
+			return nil, i
+		}
+		i++
+		if i >= len(s.Tokens) || !isPunctuation(s.Tokens[i], ".") {
+			return name, i
+		}
+		i++
+	}
+	return nil, i
+}
+
+// unquote unquotes an identifier. A GoogleSQL identifier in backticks can
+// contain a whole path, e.g. `project.dataset.table`.
+func (s Statement) unquote(quoted string) []string {
+	open, close := quoted[:1], quoted[len(quoted)-1:]
+	unquoted := strings.ReplaceAll(quoted[1:len(quoted)-1], close+close, close)
+	if s.dialect == GoogleSQL && open == "`" {
+		return strings.Split(unquoted, ".")
+	}
+	return []string{unquoted}
+}
+
+// commonTableExpressions returns the lower case names of the common table
+// expressions of the statement.
+func (s Statement) commonTableExpressions() map[string]bool {
+	names := map[string]bool{}
+	for i := 1; i+1 < len(s.Tokens); i++ {
+		prev, tok := s.Tokens[i-1], s.Tokens[i]
+		if !prev.is("WITH") && !prev.is("RECURSIVE") && !isPunctuation(prev, ",") {
+			continue
+		}
+		if tok.Kind != Word && tok.Kind != QuotedIdentifier {
+			continue
+		}
+		j := i + 1
+		// a list of columns, e.g. WITH t(a, b) AS (...)
+		if isPunctuation(s.Tokens[j], "(") {
+			j = s.skipParentheses(j)
+		}
+		if j+1 >= len(s.Tokens) || !s.Tokens[j].is("AS") {
+			continue
+		}
+		if next := s.Tokens[j+1]; isPunctuation(next, "(") || next.is("MATERIALIZED") || next.is("NOT") {
+			name := tok.Value
+			if tok.Kind == QuotedIdentifier {
+				name = strings.Join(s.unquote(tok.Value), ".")
+			}
+			names[strings.ToLower(name)] = true
+		}
+	}
+	return names
+}
+
+func isPunctuation(tok Token, value string) bool {
+	return tok.Kind == Punctuation && tok.Value == value
+}
+
+func isAlias(tok Token) bool {
+	return tok.Kind == QuotedIdentifier || (tok.Kind == Word && !clauseKeywords[strings.ToUpper(tok.Value)])
+}
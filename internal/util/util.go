@@ -16,9 +16,15 @@ package util
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/go-playground/validator/v10"
 	yaml "github.com/goccy/go-yaml"
@@ -121,3 +127,175 @@ func InstrumentationFromContext(ctx context.Context) (*telemetry.Instrumentation
 	}
 	return nil, fmt.Errorf("unable to retrieve instrumentation")
 }
+
+// ProgressReporter receives intermediate progress updates from a long-running
+// tool invocation. Total is 0 if the total amount of work is unknown.
+type ProgressReporter func(progress float64, total float64, message string)
+
+const progressReporterKey contextKey = "progressReporter"
+
+// WithProgressReporter adds a progress reporter into the context as a value
+func WithProgressReporter(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey, reporter)
+}
+
+// ReportProgress sends a progress update to the reporter in the context. It is
+// a no-op if the caller did not ask for progress updates.
+func ReportProgress(ctx context.Context, progress float64, total float64, message string) {
+	if reporter, ok := ctx.Value(progressReporterKey).(ProgressReporter); ok {
+		reporter(progress, total, message)
+	}
+}
+
+// RowWriter receives the rows of a tool result one at a time, so that a large
+// result is written to the client as it is read instead of being buffered. A
+// tool that writes its rows returns a nil result.
+type RowWriter func(row any) error
+
+const rowWriterKey contextKey = "rowWriter"
+
+// WithRowWriter adds a row writer into the context as a value. A nil writer
+// makes the tools of the invocation return their rows as their result.
+func WithRowWriter(ctx context.Context, w RowWriter) context.Context {
+	return context.WithValue(ctx, rowWriterKey, w)
+}
+
+// RowWriterFromContext retrieves the row writer, if the caller asked for the
+// rows of the result to be streamed.
+func RowWriterFromContext(ctx context.Context) (RowWriter, bool) {
+	w, ok := ctx.Value(rowWriterKey).(RowWriter)
+	return w, ok && w != nil
+}
+
+// Column describes a column of the rows of a tool result.
+type Column struct {
+	Name string `json:"name"`
+	// Type is the name of the type of the column in the database, such as
+	// "int4" or "VARCHAR".
+	Type string `json:"type"`
+	// Nullable is nil if the database doesn't report whether the column can
+	// be NULL.
+	Nullable *bool `json:"nullable,omitempty"`
+}
+
+// SQLColumns returns the columns described by the column types of a
+// database/sql result.
+func SQLColumns(colTypes []*sql.ColumnType) []Column {
+	columns := make([]Column, len(colTypes))
+	for i, ct := range colTypes {
+		columns[i] = Column{Name: ct.Name(), Type: ct.DatabaseTypeName()}
+		if nullable, ok := ct.Nullable(); ok {
+			columns[i].Nullable = &nullable
+		}
+	}
+	return columns
+}
+
+// Schema collects the columns of the rows of a tool result, as reported by
+// the tool that reads them.
+type Schema struct {
+	mu      sync.Mutex
+	columns []Column
+}
+
+// Columns returns the reported columns, or nil if the tool didn't report any.
+func (s *Schema) Columns() []Column {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.columns
+}
+
+const schemaKey contextKey = "schema"
+
+// WithSchema adds a schema into the context as a value, which collects the
+// columns reported by the tools of the invocation.
+func WithSchema(ctx context.Context, s *Schema) context.Context {
+	return context.WithValue(ctx, schemaKey, s)
+}
+
+// SchemaFromContext retrieves the schema, if the caller asked for the columns
+// of the result.
+func SchemaFromContext(ctx context.Context) (*Schema, bool) {
+	s, ok := ctx.Value(schemaKey).(*Schema)
+	return s, ok && s != nil
+}
+
+// ReportColumns sets the columns of the schema in the context. It is a no-op
+// if the caller did not ask for the columns of the result.
+func ReportColumns(ctx context.Context, columns []Column) {
+	if s, ok := SchemaFromContext(ctx); ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.columns = columns
+	}
+}
+
+const claimsKey contextKey = "claims"
+
+// WithClaims adds the claims of the auth services the caller was verified
+// with into the context as a value
+func WithClaims(ctx context.Context, claimsFromAuth map[string]map[string]any) context.Context {
+	return context.WithValue(ctx, claimsKey, claimsFromAuth)
+}
+
+// ClaimsFromContext retrieves the claims of the auth services the caller was
+// verified with, keyed by the name of the auth service. It returns nil if the
+// caller wasn't verified.
+func ClaimsFromContext(ctx context.Context) map[string]map[string]any {
+	claims, _ := ctx.Value(claimsKey).(map[string]map[string]any)
+	return claims
+}
+
+const accessTokensKey contextKey = "accessTokens"
+
+// WithAccessTokens adds the verified OAuth access tokens of the caller, keyed
+// by the name of the auth service, into the context as a value. They are kept
+// apart from the claims, which may be logged.
+func WithAccessTokens(ctx context.Context, tokensFromAuth map[string]string) context.Context {
+	return context.WithValue(ctx, accessTokensKey, tokensFromAuth)
+}
+
+// AccessTokensFromContext retrieves the verified OAuth access tokens of the
+// caller, keyed by the name of the auth service.
+func AccessTokensFromContext(ctx context.Context) map[string]string {
+	tokens, _ := ctx.Value(accessTokensKey).(map[string]string)
+	return tokens
+}
+
+// AccessTokenFromContext retrieves the verified OAuth access token of the
+// caller from the auth service.
+func AccessTokenFromContext(ctx context.Context, authService string) (string, bool) {
+	token, ok := AccessTokensFromContext(ctx)[authService]
+	return token, ok && token != ""
+}
+
+// CallerIdentity identifies the caller of an invocation by the identities they
+// were verified with and the OAuth access tokens they forward to sources, so
+// that state kept across invocations, such as paginated results or open
+// transactions, can only be used by the caller it was created for. Callers
+// that weren't verified share the same identity.
+func CallerIdentity(ctx context.Context) string {
+	type identity struct {
+		AuthService string `json:"authService"`
+		Subject     string `json:"subject,omitempty"`
+		Email       string `json:"email,omitempty"`
+	}
+	claimsFromAuth := ClaimsFromContext(ctx)
+	identities := make([]identity, 0, len(claimsFromAuth))
+	for name, claims := range claimsFromAuth {
+		id := identity{AuthService: name}
+		id.Subject, _ = claims["sub"].(string)
+		id.Email, _ = claims["email"].(string)
+		identities = append(identities, id)
+	}
+	slices.SortFunc(identities, func(a, b identity) int { return strings.Compare(a.AuthService, b.AuthService) })
+	b, _ := json.Marshal(struct {
+		Identities   []identity        `json:"identities"`
+		AccessTokens map[string]string `json:"accessTokens,omitempty"`
+	}{
+		Identities:   identities,
+		AccessTokens: AccessTokensFromContext(ctx),
+	})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
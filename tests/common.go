@@ -21,6 +21,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"maps"
 	"testing"
 
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -170,6 +171,25 @@ func AddPgExecuteSqlConfig(t *testing.T, config map[string]any) map[string]any {
 	return config
 }
 
+// AddArrayInListConfig adds a tool like `my-array-tool` whose statement uses
+// its array parameters in IN lists, e.g. `id IN ($1)`.
+func AddArrayInListConfig(t *testing.T, config map[string]any, statement string) map[string]any {
+	tools, ok := config["tools"].(map[string]any)
+	if !ok {
+		t.Fatalf("unable to get tools from config")
+	}
+	arrayTool, ok := tools["my-array-tool"].(map[string]any)
+	if !ok {
+		t.Fatalf("unable to get my-array-tool from config")
+	}
+	inListTool := maps.Clone(arrayTool)
+	inListTool["description"] = "Tool to test invocation with array params in IN lists."
+	inListTool["statement"] = statement
+	tools["my-array-in-list-tool"] = inListTool
+	config["tools"] = tools
+	return config
+}
+
 func AddTemplateParamConfig(t *testing.T, config map[string]any, toolKind, tmplSelectCombined, tmplSelectFilterCombined string, tmplSelectAll string) map[string]any {
 	toolsMap, ok := config["tools"].(map[string]any)
 	if !ok {
@@ -278,6 +298,29 @@ func AddMySqlExecuteSqlConfig(t *testing.T, config map[string]any) map[string]an
 	return config
 }
 
+// AddSQLiteExecuteSqlConfig gets the tools config for `sqlite-execute-sql`
+func AddSQLiteExecuteSqlConfig(t *testing.T, config map[string]any) map[string]any {
+	tools, ok := config["tools"].(map[string]any)
+	if !ok {
+		t.Fatalf("unable to get tools from config")
+	}
+	tools["my-exec-sql-tool"] = map[string]any{
+		"kind":        "sqlite-execute-sql",
+		"source":      "my-instance",
+		"description": "Tool to execute sql",
+	}
+	tools["my-auth-exec-sql-tool"] = map[string]any{
+		"kind":        "sqlite-execute-sql",
+		"source":      "my-instance",
+		"description": "Tool to execute sql",
+		"authRequired": []string{
+			"my-google-auth",
+		},
+	}
+	config["tools"] = tools
+	return config
+}
+
 // AddMSSQLExecuteSqlConfig gets the tools config for `mssql-execute-sql`
 func AddMSSQLExecuteSqlConfig(t *testing.T, config map[string]any) map[string]any {
 	tools, ok := config["tools"].(map[string]any)
@@ -312,6 +355,12 @@ func GetPostgresSQLParamToolInfo(tableName string) (string, string, string, stri
 	return createStatement, insertStatement, toolStatement, toolStatement2, arrayToolStatement, params
 }
 
+// GetPostgresSQLArrayInListToolStatement returns the statement of
+// my-array-in-list-tool for postgres-sql kind
+func GetPostgresSQLArrayInListToolStatement(tableName string) string {
+	return fmt.Sprintf("SELECT * FROM %s WHERE id IN ($1) AND name IN ($2);", tableName)
+}
+
 // GetPostgresSQLAuthToolInfo returns statements and param of my-auth-tool for postgres-sql kind
 func GetPostgresSQLAuthToolInfo(tableName string) (string, string, string, []any) {
 	createStatement := fmt.Sprintf("CREATE TABLE %s (id SERIAL PRIMARY KEY, name TEXT, email TEXT);", tableName)
@@ -361,7 +410,7 @@ func GetMySQLParamToolInfo(tableName string) (string, string, string, string, st
 	insertStatement := fmt.Sprintf("INSERT INTO %s (name) VALUES (?), (?), (?), (?);", tableName)
 	toolStatement := fmt.Sprintf("SELECT * FROM %s WHERE id = ? OR name = ?;", tableName)
 	toolStatement2 := fmt.Sprintf("SELECT * FROM %s WHERE id = ?;", tableName)
-	arrayToolStatement := fmt.Sprintf("SELECT * FROM %s WHERE id = ANY(?) AND name = ANY(?);", tableName)
+	arrayToolStatement := fmt.Sprintf("SELECT * FROM %s WHERE id IN (?) AND name IN (?);", tableName)
 	params := []any{"Alice", "Jane", "Sid", nil}
 	return createStatement, insertStatement, toolStatement, toolStatement2, arrayToolStatement, params
 }
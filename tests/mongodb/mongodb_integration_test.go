@@ -0,0 +1,251 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/tests"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var (
+	MongoDBSourceKind = "mongodb"
+	MongoDBUri        = os.Getenv("MONGODB_URI")
+	MongoDBDatabase   = os.Getenv("MONGODB_DATABASE")
+)
+
+func getMongoDBVars(t *testing.T) map[string]any {
+	switch "" {
+	case MongoDBUri:
+		t.Fatal("'MONGODB_URI' not set")
+	case MongoDBDatabase:
+		t.Fatal("'MONGODB_DATABASE' not set")
+	}
+	return map[string]any{
+		"kind":     MongoDBSourceKind,
+		"uri":      MongoDBUri,
+		"database": MongoDBDatabase,
+	}
+}
+
+func TestMongoDBToolEndpoints(t *testing.T) {
+	sourceConfig := getMongoDBVars(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var args []string
+
+	client, err := mongo.Connect(options.Client().ApplyURI(MongoDBUri))
+	if err != nil {
+		t.Fatalf("unable to create MongoDB connection: %s", err)
+	}
+	defer client.Disconnect(ctx)
+
+	// set up data for the tools
+	collectionName := "users_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	coll := client.Database(MongoDBDatabase).Collection(collectionName)
+	_, err = coll.InsertMany(ctx, []any{
+		bson.D{{Key: "_id", Value: 1}, {Key: "name", Value: "Alice"}, {Key: "age", Value: 30}},
+		bson.D{{Key: "_id", Value: 2}, {Key: "name", Value: "Jane"}, {Key: "age", Value: 25}},
+		bson.D{{Key: "_id", Value: 3}, {Key: "name", Value: "Sid"}, {Key: "age", Value: 35}},
+	})
+	if err != nil {
+		t.Fatalf("unable to insert test data: %s", err)
+	}
+	defer func() {
+		if err := coll.Drop(ctx); err != nil {
+			t.Errorf("Teardown failed: %s", err)
+		}
+	}()
+
+	// Write config into a file and pass it to command
+	toolsFile := getMongoDBToolsConfig(sourceConfig, collectionName)
+
+	cmd, cleanup, err := tests.StartCmd(ctx, toolsFile, args...)
+	if err != nil {
+		t.Fatalf("command initialization returned an error: %s", err)
+	}
+	defer cleanup()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	out, err := testutils.WaitForString(waitCtx, regexp.MustCompile(`Server ready to serve`), cmd.Out)
+	if err != nil {
+		t.Logf("toolbox command logs: \n%s", out)
+		t.Fatalf("toolbox didn't start successfully: %s", err)
+	}
+
+	tests.RunToolGetTest(t)
+
+	// Test tool invoke endpoint
+	invokeTcs := []struct {
+		name        string
+		api         string
+		requestBody io.Reader
+		want        string
+		isErr       bool
+	}{
+		{
+			name:        "invoke my-simple-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-simple-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{}`)),
+			want:        `[{"_id":1,"name":"Alice"},{"_id":2,"name":"Jane"},{"_id":3,"name":"Sid"}]`,
+		},
+		{
+			name:        "invoke my-find-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-find-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"min_age": 28}`)),
+			want:        `[{"_id":3,"age":35,"name":"Sid"},{"_id":1,"age":30,"name":"Alice"}]`,
+		},
+		{
+			name:        "invoke my-find-tool with an injection attempt",
+			api:         "http://127.0.0.1:5000/api/tool/my-find-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"min_age": "28}, \"name\": {\"$exists\": true"}`)),
+			isErr:       true,
+		},
+		{
+			name:        "invoke my-aggregate-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-aggregate-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"name": "Jane"}`)),
+			want:        `[{"_id":null,"total":25}]`,
+		},
+		{
+			name:        "invoke my-insert-tool",
+			api:         "http://127.0.0.1:5000/api/tool/my-insert-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{"id": 4, "name": "Bob \"the builder\""}`)),
+			want:        `{"insertedIds":[4]}`,
+		},
+		{
+			name:        "invoke my-simple-tool after insert",
+			api:         "http://127.0.0.1:5000/api/tool/my-simple-tool/invoke",
+			requestBody: bytes.NewBuffer([]byte(`{}`)),
+			want:        `[{"_id":1,"name":"Alice"},{"_id":2,"name":"Jane"},{"_id":3,"name":"Sid"},{"_id":4,"name":"Bob \"the builder\""}]`,
+		},
+	}
+	for _, tc := range invokeTcs {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Post(tc.api, "application/json", tc.requestBody)
+			if err != nil {
+				t.Fatalf("error when sending a request: %s", err)
+			}
+			defer resp.Body.Close()
+			if tc.isErr {
+				if resp.StatusCode == http.StatusOK {
+					t.Fatalf("expected the invocation to fail")
+				}
+				return
+			}
+			if resp.StatusCode != http.StatusOK {
+				bodyBytes, _ := io.ReadAll(resp.Body)
+				t.Fatalf("response status code is not 200, got %d: %s", resp.StatusCode, string(bodyBytes))
+			}
+
+			var body map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&body)
+			if err != nil {
+				t.Fatalf("error parsing response body")
+			}
+			got, ok := body["result"].(string)
+			if !ok {
+				t.Fatalf("unable to find result in response body")
+			}
+
+			if got != tc.want {
+				t.Fatalf("unexpected value: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func getMongoDBToolsConfig(sourceConfig map[string]any, collectionName string) map[string]any {
+	return map[string]any{
+		"sources": map[string]any{
+			"my-instance": sourceConfig,
+		},
+		"tools": map[string]any{
+			"my-simple-tool": map[string]any{
+				"kind":        "mongodb-find",
+				"source":      "my-instance",
+				"description": "Simple tool to test end to end functionality.",
+				"collection":  collectionName,
+				"projection":  `{"name": 1}`,
+				"sort":        `{"_id": 1}`,
+			},
+			"my-find-tool": map[string]any{
+				"kind":        "mongodb-find",
+				"source":      "my-instance",
+				"description": "Tool to test find with params.",
+				"collection":  collectionName,
+				"filter":      `{"age": {"$gte": {{json .min_age}}}}`,
+				"sort":        `{"age": -1}`,
+				"limit":       2,
+				"parameters": []any{
+					map[string]any{
+						"name":        "min_age",
+						"type":        "integer",
+						"description": "the minimum age",
+					},
+				},
+			},
+			"my-aggregate-tool": map[string]any{
+				"kind":        "mongodb-aggregate",
+				"source":      "my-instance",
+				"description": "Tool to test aggregate with params.",
+				"collection":  collectionName,
+				"pipeline":    `[{"$match": {"name": {{json .name}}}}, {"$group": {"_id": null, "total": {"$sum": "$age"}}}]`,
+				"parameters": []any{
+					map[string]any{
+						"name":        "name",
+						"type":        "string",
+						"description": "user name",
+					},
+				},
+			},
+			"my-insert-tool": map[string]any{
+				"kind":        "mongodb-insert",
+				"source":      "my-instance",
+				"description": "Tool to test insert with params.",
+				"collection":  collectionName,
+				"document":    `{"_id": {{json .id}}, "name": {{json .name}}}`,
+				"parameters": []any{
+					map[string]any{
+						"name":        "id",
+						"type":        "integer",
+						"description": "user ID",
+					},
+					map[string]any{
+						"name":        "name",
+						"type":        "string",
+						"description": "user name",
+					},
+				},
+			},
+		},
+	}
+}
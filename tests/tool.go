@@ -227,6 +227,27 @@ func RunToolInvokeTest(t *testing.T, select1Want, invokeParamWant, invokeParamWa
 	}
 }
 
+// RunArrayInListToolInvokeTest invokes my-array-in-list-tool, which must
+// return the same rows as my-array-tool.
+func RunArrayInListToolInvokeTest(t *testing.T, invokeParamWant string) {
+	t.Run("invoke my-array-in-list-tool", func(t *testing.T) {
+		resp, body := runRequest(t, http.MethodPost, "http://127.0.0.1:5000/api/tool/my-array-in-list-tool/invoke", bytes.NewBuffer([]byte(`{"idArray": [1,2,3], "nameArray": ["Alice", "Sid", "RandomName"]}`)), nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("response status code is not 200, got %d: %s", resp.StatusCode, string(body))
+		}
+
+		var result struct {
+			Result string `json:"result"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			t.Fatalf("error parsing response body: %s", err)
+		}
+		if result.Result != invokeParamWant {
+			t.Fatalf("unexpected value: got %q, want %q", result.Result, invokeParamWant)
+		}
+	})
+}
+
 // TemplateParameterTestConfig represents the various configuration options for template parameter tests.
 type TemplateParameterTestConfig struct {
 	ignoreDdl      bool